@@ -62,3 +62,11 @@ resource "azurerm_postgresql_flexible_server_database" "message_db" {
   collation = "en_US.utf8"
   charset   = "utf8"
 }
+
+# Base de données médias (registre : propriétaire, conversation, objets)
+resource "azurerm_postgresql_flexible_server_database" "media_db" {
+  name      = "storm_media_db"
+  server_id = azurerm_postgresql_flexible_server.main.id
+  collation = "en_US.utf8"
+  charset   = "utf8"
+}
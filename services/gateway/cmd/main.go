@@ -82,6 +82,9 @@ func SetupServer(nc common.NatsConn) *chi.Mux {
 	if err := hub.StartNatsSubscription(nc); err != nil {
 		log.Printf("Avertissement: Impossible de démarrer l'abonnement NATS : %v", err)
 	}
+	if err := hub.StartMediaSubscription(nc); err != nil {
+		log.Printf("Avertissement: Impossible de démarrer l'abonnement NATS media : %v", err)
+	}
 
 	handler := ws.NewHandler(hub, nc)
 	upgrader := gws.NewUpgrader(handler, nil)
@@ -111,6 +114,22 @@ func SetupServer(nc common.NatsConn) *chi.Mux {
 
 	// Media upload (gateway -> NATS -> media-service)
 	r.Post("/media/upload", mediaHandler.Upload)
+	r.Post("/media/upload/presign", mediaHandler.PresignUpload)
+	r.Post("/media/upload/complete", mediaHandler.CompleteUpload)
+	// Upload reprenable (avant /media/*)
+	r.Post("/media/uploads", mediaHandler.CreateUploadSession)
+	r.Get("/media/uploads/{uploadId}", mediaHandler.GetUploadSession)
+	r.Post("/media/uploads/{uploadId}/chunks", mediaHandler.PresignChunk)
+	r.Post("/media/uploads/{uploadId}/complete", mediaHandler.CompleteUploadSession)
+	r.Delete("/media/uploads/{uploadId}", mediaHandler.AbortUploadSession)
+	r.Get("/media/info/*", mediaHandler.GetInfo)
+	r.Get("/media/*", mediaHandler.GetURL)
+	r.Delete("/media/*", mediaHandler.Delete)
+
+	// Messages programmés (avant /api/messages/{id})
+	r.Post("/api/messages/scheduled", messageHandler.ScheduleMessage)
+	r.Get("/api/messages/scheduled", messageHandler.ListScheduledMessages)
+	r.Delete("/api/messages/scheduled/{id}", messageHandler.CancelScheduledMessage)
 
 	r.Get("/api/messages/{id}", messageHandler.GetById)
 	r.Get("/api/messages", messageHandler.GetByGroupId)
@@ -123,15 +142,57 @@ func SetupServer(nc common.NatsConn) *chi.Mux {
 
 	// Groups/Conversations (proxy vers message-service)
 	r.Post("/api/groups", messageHandler.CreateGroup)
+	r.Post("/api/groups/direct", messageHandler.GetOrCreateDirect)
 	r.Get("/api/groups", messageHandler.ListGroups)
 	r.Get("/api/groups/{id}", messageHandler.GetGroup)
+	r.Patch("/api/groups/{id}", messageHandler.UpdateGroup)
+	r.Patch("/api/groups/{id}/permissions", messageHandler.UpdateGroupPermissions)
 	r.Delete("/api/groups/{id}", messageHandler.DeleteGroup)
 	r.Post("/api/groups/{id}/leave", messageHandler.LeaveGroup)
+	r.Post("/api/groups/{id}/archive", messageHandler.ArchiveGroup)
+	r.Post("/api/groups/{id}/restore", messageHandler.RestoreGroup)
 
 	r.Post("/api/groups/{id}/members", messageHandler.AddGroupMember)
 	r.Get("/api/groups/{id}/members", messageHandler.ListGroupMembers)
 	r.Patch("/api/groups/{id}/members/{user_id}/role", messageHandler.UpdateGroupMemberRole)
 	r.Delete("/api/groups/{id}/members/{user_id}", messageHandler.RemoveGroupMember)
+	r.Post("/api/groups/{id}/members/bulk", messageHandler.AddGroupMembersBulk)
+	r.Post("/api/groups/{id}/members/bulk-remove", messageHandler.RemoveGroupMembersBulk)
+	r.Post("/api/groups/{id}/transfer-ownership", messageHandler.TransferOwnership)
+
+	r.Post("/api/groups/{id}/pins", messageHandler.PinMessage)
+	r.Get("/api/groups/{id}/pins", messageHandler.ListPins)
+	r.Delete("/api/groups/{id}/pins/{message_id}", messageHandler.UnpinMessage)
+
+	r.Post("/api/groups/{id}/invites", messageHandler.CreateInvite)
+	r.Get("/api/groups/{id}/invites", messageHandler.ListInvites)
+	r.Delete("/api/groups/{id}/invites/{invite_id}", messageHandler.RevokeInvite)
+	r.Post("/api/invites/{token}/join", messageHandler.JoinInvite)
+
+	r.Post("/api/groups/{id}/join-requests", messageHandler.RequestToJoinGroup)
+	r.Get("/api/groups/{id}/join-requests", messageHandler.ListJoinRequests)
+	r.Post("/api/groups/{id}/join-requests/{request_id}", messageHandler.DecideJoinRequest)
+
+	r.Get("/api/groups/{id}/reports", messageHandler.ListReports)
+	r.Post("/api/groups/{id}/reports/{report_id}", messageHandler.ResolveReport)
+	r.Post("/api/messages/{id}/report", messageHandler.ReportMessage)
+
+	r.Post("/api/groups/{id}/bans", messageHandler.BanGroupMember)
+	r.Get("/api/groups/{id}/bans", messageHandler.ListGroupBans)
+	r.Delete("/api/groups/{id}/bans/{user_id}", messageHandler.UnbanGroupMember)
+	r.Post("/api/groups/{id}/mutes", messageHandler.MuteGroupMember)
+	r.Delete("/api/groups/{id}/mutes/{user_id}", messageHandler.UnmuteGroupMember)
+	r.Post("/api/groups/{id}/members/{user_id}/kick", messageHandler.KickGroupMember)
+	r.Get("/api/groups/{id}/audit-log", messageHandler.GetGroupAuditLog)
+
+	r.Post("/api/blocks", messageHandler.BlockUser)
+	r.Get("/api/blocks", messageHandler.ListBlocks)
+	r.Delete("/api/blocks/{user_id}", messageHandler.UnblockUser)
+
+	r.Post("/api/polls", messageHandler.CreatePoll)
+	r.Get("/api/polls/{id}", messageHandler.GetPoll)
+	r.Post("/api/polls/{id}/vote", messageHandler.VotePoll)
+	r.Post("/api/polls/{id}/close", messageHandler.ClosePoll)
 
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -26,6 +26,36 @@ type ReplyToData struct {
 	Content    string `json:"content"`
 }
 
+// LinkPreview : aperçu du premier lien d'un message (ajouté en asynchrone, événement WS "message_preview").
+type LinkPreview struct {
+	URL         string `json:"url"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+	SiteName    string `json:"site_name,omitempty"`
+}
+
+// SystemEvent : événement décrit par un message système (kind = "system").
+type SystemEvent struct {
+	Type     string `json:"type"`
+	ActorID  string `json:"actor_id"`
+	TargetID string `json:"target_id,omitempty"`
+	Role     int    `json:"role"`
+	Name     string `json:"name,omitempty"`
+}
+
+// AttachmentInfo : pièce jointe décrite par le media-service (Attachment = media ID).
+// Type : image | video | audio | voice | document ; Waveform (notes vocales) : amplitudes 0-100.
+type AttachmentInfo struct {
+	MediaID     string `json:"media_id"`
+	Type        string `json:"type"`
+	ContentType string `json:"content_type,omitempty"`
+	Filename    string `json:"filename,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+	DurationMs  int64  `json:"duration_ms,omitempty"`
+	Waveform    []int  `json:"waveform,omitempty"`
+}
+
 // SeenByEntry : utilisateur ayant vu le message.
 type SeenByEntry struct {
 	UserID      string `json:"user_id"`
@@ -34,20 +64,26 @@ type SeenByEntry struct {
 
 // SendMessageData returns conversation_id and keeps group_id for temporary compatibility.
 type SendMessageData struct {
-	ID             int           `json:"id"`
-	SenderID       string        `json:"sender_id"` // UUID
-	SenderName     string        `json:"sender_name,omitempty"`
-	SenderUsername string        `json:"sender_username,omitempty"`
-	ConversationID int           `json:"conversation_id"`
-	GroupID        int           `json:"group_id,omitempty"` // legacy alias
-	Content        string        `json:"content"`
-	Attachment     string        `json:"attachment,omitempty"`
-	ReceivedAt     int64         `json:"received_at,omitempty"` // actor-scoped receipt when available
-	CreatedAt      int64         `json:"created_at"`
-	UpdatedAt      int64         `json:"updated_at"`
-	Status         string        `json:"status,omitempty"`
-	ReplyTo        *ReplyToData  `json:"reply_to,omitempty"`
-	SeenBy         []SeenByEntry `json:"seen_by,omitempty"`
+	ID             int             `json:"id"`
+	SenderID       string          `json:"sender_id"` // UUID
+	SenderName     string          `json:"sender_name,omitempty"`
+	SenderUsername string          `json:"sender_username,omitempty"`
+	ConversationID int             `json:"conversation_id"`
+	GroupID        int             `json:"group_id,omitempty"` // legacy alias
+	Content        string          `json:"content"`
+	Attachment     string          `json:"attachment,omitempty"`
+	AttachmentInfo *AttachmentInfo `json:"attachment_info,omitempty"`
+	ReceivedAt     int64           `json:"received_at,omitempty"` // actor-scoped receipt when available
+	CreatedAt      int64           `json:"created_at"`
+	UpdatedAt      int64           `json:"updated_at"`
+	Status         string          `json:"status,omitempty"`
+	ReplyTo        *ReplyToData    `json:"reply_to,omitempty"`
+	SeenBy         []SeenByEntry   `json:"seen_by,omitempty"`
+	Mentions       []string        `json:"mentions,omitempty"` // UUID des membres mentionnés
+	LinkPreview    *LinkPreview    `json:"link_preview,omitempty"`
+	PollID         int             `json:"poll_id,omitempty"`
+	Kind           string          `json:"kind,omitempty"` // user | system
+	System         *SystemEvent    `json:"system,omitempty"`
 }
 
 // SendMessageError représente une erreur dans la réponse message
@@ -76,20 +112,26 @@ type GetMessageResponse struct {
 
 // GetMessageData : id (int), sender_id (UUID), conversation_id (int).
 type GetMessageData struct {
-	ID             int           `json:"id"`
-	SenderID       string        `json:"sender_id"`
-	SenderName     string        `json:"sender_name,omitempty"`
-	SenderUsername string        `json:"sender_username,omitempty"`
-	ConversationID int           `json:"conversation_id"`
-	GroupID        int           `json:"group_id,omitempty"` // legacy alias
-	Content        string        `json:"content"`
-	Attachment     string        `json:"attachment,omitempty"`
-	ReceivedAt     int64         `json:"received_at,omitempty"` // actor-scoped receipt when available
-	CreatedAt      int64         `json:"created_at"`
-	UpdatedAt      int64         `json:"updated_at"`
-	Status         string        `json:"status,omitempty"`
-	ReplyTo        *ReplyToData  `json:"reply_to,omitempty"`
-	SeenBy         []SeenByEntry `json:"seen_by,omitempty"`
+	ID             int             `json:"id"`
+	SenderID       string          `json:"sender_id"`
+	SenderName     string          `json:"sender_name,omitempty"`
+	SenderUsername string          `json:"sender_username,omitempty"`
+	ConversationID int             `json:"conversation_id"`
+	GroupID        int             `json:"group_id,omitempty"` // legacy alias
+	Content        string          `json:"content"`
+	Attachment     string          `json:"attachment,omitempty"`
+	AttachmentInfo *AttachmentInfo `json:"attachment_info,omitempty"`
+	ReceivedAt     int64           `json:"received_at,omitempty"` // actor-scoped receipt when available
+	CreatedAt      int64           `json:"created_at"`
+	UpdatedAt      int64           `json:"updated_at"`
+	Status         string          `json:"status,omitempty"`
+	ReplyTo        *ReplyToData    `json:"reply_to,omitempty"`
+	SeenBy         []SeenByEntry   `json:"seen_by,omitempty"`
+	Mentions       []string        `json:"mentions,omitempty"` // UUID des membres mentionnés
+	LinkPreview    *LinkPreview    `json:"link_preview,omitempty"`
+	PollID         int             `json:"poll_id,omitempty"`
+	Kind           string          `json:"kind,omitempty"` // user | system
+	System         *SystemEvent    `json:"system,omitempty"`
 }
 
 // ListMessagesResponse est la réponse de GET /api/messages
@@ -139,13 +181,37 @@ type DeleteMessageResponse struct {
 	Error *SendMessageError `json:"error,omitempty"`
 }
 
+// Group : conversation ; kind "direct" pour un 1:1 (peer_id = l'autre participant).
 type Group struct {
-	ID        int    `json:"id"`
-	Name      string `json:"name"`
-	AvatarURL string `json:"avatar_url,omitempty"`
-	CreatedBy string `json:"created_by,omitempty"`
-	CreatedAt int64  `json:"created_at"`
-	UpdatedAt int64  `json:"updated_at"`
+	ID          int    `json:"id"`
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	AvatarURL   string `json:"avatar_url,omitempty"`
+	Description string `json:"description,omitempty"`
+	CreatedBy   string `json:"created_by,omitempty"`
+	PeerID      string `json:"peer_id,omitempty"`
+	CreatedAt   int64  `json:"created_at"`
+	UpdatedAt   int64  `json:"updated_at"`
+	// Permissions : absentes pour une conversation directe.
+	Permissions *GroupPermissions `json:"permissions,omitempty"`
+}
+
+// GroupPermissions : réglages d'une conversation, modifiables par l'owner.
+type GroupPermissions struct {
+	OnlyAdminsCanPost    bool `json:"only_admins_can_post"`
+	MembersCanAddMembers bool `json:"members_can_add_members"`
+	MembersCanPin        bool `json:"members_can_pin"`
+	SlowModeSeconds      int  `json:"slow_mode_seconds"`
+	JoinRequestsEnabled  bool `json:"join_requests_enabled"`
+}
+
+// UpdateGroupPermissionsRequest est le payload de PATCH /api/groups/{id}/permissions (champs absents inchangés).
+type UpdateGroupPermissionsRequest struct {
+	OnlyAdminsCanPost    *bool `json:"only_admins_can_post,omitempty"`
+	MembersCanAddMembers *bool `json:"members_can_add_members,omitempty"`
+	MembersCanPin        *bool `json:"members_can_pin,omitempty"`
+	SlowModeSeconds      *int  `json:"slow_mode_seconds,omitempty"`
+	JoinRequestsEnabled  *bool `json:"join_requests_enabled,omitempty"`
 }
 
 type GroupMember struct {
@@ -166,6 +232,18 @@ type CreateGroupRequest struct {
 	AvatarURL string `json:"avatar_url,omitempty"`
 }
 
+// UpdateGroupRequest est le payload de PATCH /api/groups/{id} ; champ absent = inchangé.
+type UpdateGroupRequest struct {
+	Name        *string `json:"name,omitempty"`
+	AvatarURL   *string `json:"avatar_url,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// DirectConversationRequest est le payload de POST /api/groups/direct.
+type DirectConversationRequest struct {
+	UserID string `json:"user_id"`
+}
+
 type GroupResponse struct {
 	OK    bool              `json:"ok"`
 	Data  *Group            `json:"data,omitempty"`
@@ -200,3 +278,361 @@ type GroupMembersResponse struct {
 	Data  []GroupMember     `json:"data"`
 	Error *SendMessageError `json:"error,omitempty"`
 }
+
+// ScheduleMessageRequest est le payload de POST /api/messages/scheduled (send_at : Unix timestamp).
+type ScheduleMessageRequest struct {
+	ConversationID int    `json:"conversation_id,omitempty"`
+	GroupID        int    `json:"group_id,omitempty"` // legacy alias
+	Content        string `json:"content"`
+	Attachment     string `json:"attachment,omitempty"`
+	ReplyToID      *int   `json:"reply_to_id,omitempty"`
+	SendAt         int64  `json:"send_at"`
+}
+
+// ScheduledMessage : message programmé (status pending | processing | sent | cancelled | failed).
+type ScheduledMessage struct {
+	ID             int    `json:"id"`
+	SenderID       string `json:"sender_id"`
+	ConversationID int    `json:"conversation_id"`
+	Content        string `json:"content"`
+	Attachment     string `json:"attachment,omitempty"`
+	ReplyToID      int    `json:"reply_to_id,omitempty"`
+	SendAt         int64  `json:"send_at"`
+	Status         string `json:"status"`
+	MessageID      int    `json:"message_id,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+	UpdatedAt      int64  `json:"updated_at"`
+}
+
+type ScheduledMessageResponse struct {
+	OK    bool              `json:"ok"`
+	Data  *ScheduledMessage `json:"data,omitempty"`
+	Error *SendMessageError `json:"error,omitempty"`
+}
+
+type ScheduledMessagesResponse struct {
+	OK    bool               `json:"ok"`
+	Data  []ScheduledMessage `json:"data"`
+	Error *SendMessageError  `json:"error,omitempty"`
+}
+
+// PinMessageRequest est le payload de POST /api/groups/{id}/pins.
+type PinMessageRequest struct {
+	MessageID int `json:"message_id"`
+}
+
+// MessagePin : message épinglé d'une conversation (message rempli quand disponible).
+type MessagePin struct {
+	ConversationID int              `json:"conversation_id"`
+	MessageID      int              `json:"message_id"`
+	PinnedBy       string           `json:"pinned_by"`
+	PinnedAt       int64            `json:"pinned_at"`
+	Message        *SendMessageData `json:"message,omitempty"`
+}
+
+type MessagePinResponse struct {
+	OK    bool              `json:"ok"`
+	Data  *MessagePin       `json:"data,omitempty"`
+	Error *SendMessageError `json:"error,omitempty"`
+}
+
+type MessagePinsResponse struct {
+	OK    bool              `json:"ok"`
+	Data  []MessagePin      `json:"data"`
+	Error *SendMessageError `json:"error,omitempty"`
+}
+
+// CreatePollRequest est le payload de POST /api/polls.
+type CreatePollRequest struct {
+	ConversationID int      `json:"conversation_id"`
+	Question       string   `json:"question"`
+	Options        []string `json:"options"`
+	MultiChoice    bool     `json:"multi_choice"`
+	Anonymous      bool     `json:"anonymous"`
+	ClosesAt       int64    `json:"closes_at,omitempty"` // Unix timestamp, 0 = pas d'échéance
+}
+
+// VotePollRequest est le payload de POST /api/polls/{id}/vote ; option_ids vide retire le vote.
+type VotePollRequest struct {
+	OptionIDs []int `json:"option_ids"`
+}
+
+// PollOption : voter_ids reste vide pour un sondage anonyme.
+type PollOption struct {
+	ID        int      `json:"id"`
+	Label     string   `json:"label"`
+	VoteCount int      `json:"vote_count"`
+	VoterIDs  []string `json:"voter_ids,omitempty"`
+}
+
+// Poll : sondage et décompte courant (événement WS "poll_update" à chaque vote).
+type Poll struct {
+	ID             int          `json:"id"`
+	MessageID      int          `json:"message_id"`
+	ConversationID int          `json:"conversation_id"`
+	CreatedBy      string       `json:"created_by"`
+	Question       string       `json:"question"`
+	MultiChoice    bool         `json:"multi_choice"`
+	Anonymous      bool         `json:"anonymous"`
+	ClosesAt       int64        `json:"closes_at,omitempty"`
+	ClosedAt       int64        `json:"closed_at,omitempty"`
+	Closed         bool         `json:"closed"`
+	CreatedAt      int64        `json:"created_at"`
+	Options        []PollOption `json:"options"`
+	TotalVoters    int          `json:"total_voters"`
+	MyOptionIDs    []int        `json:"my_option_ids,omitempty"`
+}
+
+type PollResponse struct {
+	OK    bool              `json:"ok"`
+	Data  *Poll             `json:"data,omitempty"`
+	Error *SendMessageError `json:"error,omitempty"`
+}
+
+// CreateInviteRequest est le payload de POST /api/groups/{id}/invites (expires_at : Unix timestamp, 0 = jamais).
+type CreateInviteRequest struct {
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+	MaxUses   int   `json:"max_uses,omitempty"` // 0 = illimité
+}
+
+// ConversationInvite : lien d'invitation vers un groupe, rejoint via POST /api/invites/{token}/join.
+type ConversationInvite struct {
+	ID             int    `json:"id"`
+	Token          string `json:"token"`
+	ConversationID int    `json:"conversation_id"`
+	CreatedBy      string `json:"created_by"`
+	ExpiresAt      int64  `json:"expires_at,omitempty"`
+	MaxUses        int    `json:"max_uses"`
+	UseCount       int    `json:"use_count"`
+	RevokedAt      int64  `json:"revoked_at,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
+type ConversationInviteResponse struct {
+	OK    bool                `json:"ok"`
+	Data  *ConversationInvite `json:"data,omitempty"`
+	Error *SendMessageError   `json:"error,omitempty"`
+}
+
+type ConversationInvitesResponse struct {
+	OK    bool                 `json:"ok"`
+	Data  []ConversationInvite `json:"data"`
+	Error *SendMessageError    `json:"error,omitempty"`
+}
+
+// JoinInviteResponse : groupe rejoint et membership créée.
+type JoinInviteResponse struct {
+	OK     bool              `json:"ok"`
+	Data   *Group            `json:"data,omitempty"`
+	Member *GroupMember      `json:"member,omitempty"`
+	Error  *SendMessageError `json:"error,omitempty"`
+}
+
+// JoinGroupRequest est le payload (facultatif) de POST /api/groups/{id}/join-requests.
+type JoinGroupRequest struct {
+	Message string `json:"message,omitempty"`
+}
+
+// DecideJoinRequest est le payload de POST /api/groups/{id}/join-requests/{request_id}.
+type DecideJoinRequest struct {
+	Approve bool `json:"approve"`
+}
+
+// JoinRequest : demande d'adhésion à un groupe (status : pending | approved | denied).
+type JoinRequest struct {
+	ID             int    `json:"id"`
+	ConversationID int    `json:"conversation_id"`
+	UserID         string `json:"user_id"`
+	Message        string `json:"message,omitempty"`
+	Status         string `json:"status"`
+	DecidedBy      string `json:"decided_by,omitempty"`
+	DecidedAt      int64  `json:"decided_at,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
+type JoinRequestResponse struct {
+	OK     bool              `json:"ok"`
+	Data   *JoinRequest      `json:"data,omitempty"`
+	Member *GroupMember      `json:"member,omitempty"`
+	Error  *SendMessageError `json:"error,omitempty"`
+}
+
+type JoinRequestsResponse struct {
+	OK    bool              `json:"ok"`
+	Data  []JoinRequest     `json:"data"`
+	Error *SendMessageError `json:"error,omitempty"`
+}
+
+// TransferOwnershipRequest est le payload de POST /api/groups/{id}/transfer-ownership.
+type TransferOwnershipRequest struct {
+	UserID string `json:"user_id"`
+}
+
+// TransferOwnershipResponse : l'ancien owner devient admin, le nouveau devient owner.
+type TransferOwnershipResponse struct {
+	OK            bool              `json:"ok"`
+	PreviousOwner *GroupMember      `json:"previous_owner,omitempty"`
+	NewOwner      *GroupMember      `json:"new_owner,omitempty"`
+	Error         *SendMessageError `json:"error,omitempty"`
+}
+
+// BulkMembersRequest est le payload de POST /api/groups/{id}/members/bulk et /members/bulk-remove
+// (role ignoré pour le retrait).
+type BulkMembersRequest struct {
+	UserIDs []string `json:"user_ids"`
+	Role    int      `json:"role"`
+}
+
+// MemberResult : résultat d'une opération groupée pour un utilisateur.
+type MemberResult struct {
+	UserID string            `json:"user_id"`
+	OK     bool              `json:"ok"`
+	Member *GroupMember      `json:"member,omitempty"`
+	Error  *SendMessageError `json:"error,omitempty"`
+}
+
+// ArchiveGroupRequest est le payload de POST /api/groups/{id}/archive ; archived absent vaut true.
+type ArchiveGroupRequest struct {
+	Archived *bool `json:"archived"`
+}
+
+// ArchiveGroupResponse : état d'archivage de la conversation pour l'acteur uniquement.
+type ArchiveGroupResponse struct {
+	OK         bool              `json:"ok"`
+	Archived   bool              `json:"archived"`
+	ArchivedAt int64             `json:"archived_at,omitempty"`
+	Error      *SendMessageError `json:"error,omitempty"`
+}
+
+// BulkMembersResponse : ok concerne la requête entière, results le détail par utilisateur.
+type BulkMembersResponse struct {
+	OK      bool              `json:"ok"`
+	Results []MemberResult    `json:"results"`
+	Error   *SendMessageError `json:"error,omitempty"`
+}
+
+// BlockUserRequest est le payload de POST /api/blocks.
+type BlockUserRequest struct {
+	UserID string `json:"user_id"`
+}
+
+// UserBlock : blocker_id a bloqué blocked_id (à sens unique).
+type UserBlock struct {
+	BlockerID string `json:"blocker_id"`
+	BlockedID string `json:"blocked_id"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type BlockResponse struct {
+	OK    bool              `json:"ok"`
+	Data  *UserBlock        `json:"data,omitempty"`
+	Error *SendMessageError `json:"error,omitempty"`
+}
+
+type BlocksResponse struct {
+	OK    bool              `json:"ok"`
+	Data  []UserBlock       `json:"data"`
+	Error *SendMessageError `json:"error,omitempty"`
+}
+
+// ReportMessageRequest est le payload de POST /api/messages/{id}/report.
+type ReportMessageRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ResolveReportRequest est le payload de POST /api/groups/{id}/reports/{report_id} (status : resolved | dismissed).
+type ResolveReportRequest struct {
+	Status string `json:"status"`
+}
+
+// MessageReport : signalement d'un message (status : pending | resolved | dismissed).
+type MessageReport struct {
+	ID             int    `json:"id"`
+	MessageID      int    `json:"message_id"`
+	ConversationID int    `json:"conversation_id"`
+	ReporterID     string `json:"reporter_id"`
+	Reason         string `json:"reason"`
+	Status         string `json:"status"`
+	ResolvedBy     string `json:"resolved_by,omitempty"`
+	ResolvedAt     int64  `json:"resolved_at,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
+type ReportResponse struct {
+	OK    bool              `json:"ok"`
+	Data  *MessageReport    `json:"data,omitempty"`
+	Error *SendMessageError `json:"error,omitempty"`
+}
+
+type ReportsResponse struct {
+	OK    bool              `json:"ok"`
+	Data  []MessageReport   `json:"data"`
+	Error *SendMessageError `json:"error,omitempty"`
+}
+
+// BanMemberRequest est le payload de POST /api/groups/{id}/bans.
+type BanMemberRequest struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// MuteMemberRequest est le payload de POST /api/groups/{id}/mutes (durée : 1 s à 30 jours).
+type MuteMemberRequest struct {
+	UserID          string `json:"user_id"`
+	DurationSeconds int64  `json:"duration_seconds"`
+	Reason          string `json:"reason,omitempty"`
+}
+
+// KickMemberRequest est le payload (optionnel) de POST /api/groups/{id}/members/{user_id}/kick.
+type KickMemberRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+type GroupBan struct {
+	ConversationID int    `json:"conversation_id"`
+	UserID         string `json:"user_id"`
+	BannedBy       string `json:"banned_by"`
+	Reason         string `json:"reason,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
+type GroupMute struct {
+	ConversationID int    `json:"conversation_id"`
+	UserID         string `json:"user_id"`
+	MutedBy        string `json:"muted_by"`
+	MutedUntil     int64  `json:"muted_until"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
+// ModerationResponse : ban renseigné pour un bannissement, mute pour une sourdine, rien sinon.
+type ModerationResponse struct {
+	OK    bool              `json:"ok"`
+	Ban   *GroupBan         `json:"ban,omitempty"`
+	Mute  *GroupMute        `json:"mute,omitempty"`
+	Error *SendMessageError `json:"error,omitempty"`
+}
+
+type BansResponse struct {
+	OK    bool              `json:"ok"`
+	Data  []GroupBan        `json:"data"`
+	Error *SendMessageError `json:"error,omitempty"`
+}
+
+// AuditLogEntry : action de modération (action : ban | unban | mute | unmute | kick).
+type AuditLogEntry struct {
+	ID             int    `json:"id"`
+	ConversationID int    `json:"conversation_id"`
+	ActorID        string `json:"actor_id"`
+	Action         string `json:"action"`
+	TargetID       string `json:"target_id"`
+	Reason         string `json:"reason,omitempty"`
+	ExpiresAt      int64  `json:"expires_at,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
+type AuditLogResponse struct {
+	OK    bool              `json:"ok"`
+	Data  []AuditLogEntry   `json:"data"`
+	Error *SendMessageError `json:"error,omitempty"`
+}
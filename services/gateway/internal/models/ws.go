@@ -21,10 +21,16 @@ type InputMessage struct {
 	AttachmentFilename    string `json:"attachmentFilename,omitempty"`
 	AttachmentContentType string `json:"attachmentContentType,omitempty"`
 	Attachment            string `json:"attachment,omitempty"`
+	// AttachmentVoice : le fichier base64 est une note vocale (audio, durée et forme d'onde extraites).
+	AttachmentVoice bool `json:"attachmentVoice,omitempty"`
 	// ID / message_id : PK ligne `messages` (même valeur que l’API REST) pour le front (édition, delivered, etc.).
 	ID        int    `json:"id,omitempty"`
 	MessageID string `json:"message_id,omitempty"`
 	// Réponse à un message : même forme que GET /api/messages pour afficher la citation sans attendre le resync.
 	ReplyToID *int          `json:"reply_to_id,omitempty"`
 	ReplyTo   *ReplyToData  `json:"reply_to,omitempty"`
+	// Mentions : UUID des membres mentionnés (@username), renseigné par le message-service.
+	Mentions []string `json:"mentions,omitempty"`
+	// AttachmentInfo : type, taille et durée de la pièce jointe, renseignés par le message-service.
+	AttachmentInfo *AttachmentInfo `json:"attachment_info,omitempty"`
 }
@@ -0,0 +1,58 @@
+package media
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Accès aux médias : réservé au propriétaire et aux membres de la conversation du média.
+// GET    /media/{mediaId...}[?variant=thumbnail|medium] → {mediaId, url, expiresAt} (URL signée, quelques minutes)
+// GET    /media/info/{mediaId...} → métadonnées, statut et variantes avec URLs signées
+// DELETE /media/{mediaId...} → {status: "deleted"}
+const (
+	subjectMediaURL    = "media.url.requested"
+	subjectMediaGet    = "media.get"
+	subjectMediaDelete = "media.delete.requested"
+)
+
+type accessRequest struct {
+	MediaID     string `json:"mediaId"`
+	RequesterID string `json:"requesterId"`
+	Variant     string `json:"variant,omitempty"`
+}
+
+// GetURL gère GET /media/*.
+func (h *Handler) GetURL(w http.ResponseWriter, r *http.Request) {
+	h.forwardAccess(w, r, subjectMediaURL)
+}
+
+// GetInfo gère GET /media/info/*.
+func (h *Handler) GetInfo(w http.ResponseWriter, r *http.Request) {
+	h.forwardAccess(w, r, subjectMediaGet)
+}
+
+// Delete gère DELETE /media/*.
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	h.forwardAccess(w, r, subjectMediaDelete)
+}
+
+func (h *Handler) forwardAccess(w http.ResponseWriter, r *http.Request, subject string) {
+	userID, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	// Le media ID contient un « / » (media/<nanos>_<nom>) : route wildcard.
+	mediaID := chi.URLParam(r, "*")
+	if mediaID == "" {
+		http.Error(w, "mediaId required", http.StatusBadRequest)
+		return
+	}
+
+	req := accessRequest{MediaID: mediaID, RequesterID: userID}
+	if subject == subjectMediaURL {
+		req.Variant = r.URL.Query().Get("variant")
+	}
+	h.forwardMedia(w, subject, req)
+}
@@ -5,6 +5,7 @@ import (
     "encoding/json"
     "io"
     "net/http"
+    "strconv"
     "time"
 
     "gateway/internal/common"
@@ -90,16 +91,42 @@ func (h *Handler) Upload(w http.ResponseWriter, r *http.Request) {
 	// Encode to base64 for NATS path
 	dataBase64 := base64.StdEncoding.EncodeToString(buf.Bytes())
 
+	// conversationId (optionnel) : conversation à laquelle le média est destiné, l'utilisateur doit en être membre
+	conversationID := 0
+	if raw := r.FormValue("conversationId"); raw != "" {
+		conversationID, err = strconv.Atoi(raw)
+		if err != nil || conversationID <= 0 {
+			http.Error(w, "invalid conversationId", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// voice (optionnel) : note vocale, audio uniquement, durée et forme d'onde extraites
+	voice := false
+	if raw := r.FormValue("voice"); raw != "" {
+		voice, err = strconv.ParseBool(raw)
+		if err != nil {
+			http.Error(w, "invalid voice", http.StatusBadRequest)
+			return
+		}
+	}
+
 	req := struct {
-		Filename    string `json:"filename"`
-		ContentType string `json:"contentType"`
-		Size        int64  `json:"size"`
-		DataBase64  string `json:"dataBase64"`
+		Filename       string `json:"filename"`
+		ContentType    string `json:"contentType"`
+		Size           int64  `json:"size"`
+		DataBase64     string `json:"dataBase64"`
+		OwnerID        string `json:"ownerId"`
+		ConversationID int    `json:"conversationId"`
+		Voice          bool   `json:"voice,omitempty"`
 	}{
-		Filename:    header.Filename,
-		ContentType: contentType,
-		Size:        int64(buf.Len()),
-		DataBase64:  dataBase64,
+		Filename:       header.Filename,
+		ContentType:    contentType,
+		Size:           int64(buf.Len()),
+		DataBase64:     dataBase64,
+		OwnerID:        valResult.User.ID,
+		ConversationID: conversationID,
+		Voice:          voice,
 	}
 
 	payload, err := json.Marshal(req)
@@ -129,7 +156,7 @@ func (h *Handler) Upload(w http.ResponseWriter, r *http.Request) {
 	if errVal, ok := resp["error"]; ok {
 		// forward error message
 		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusBadRequest)
+		w.WriteHeader(statusFromMediaCode(resp["code"]))
 		_ = json.NewEncoder(w).Encode(map[string]any{"error": errVal})
 		return
 	}
@@ -0,0 +1,155 @@
+package media
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"gateway/internal/modules/auth"
+)
+
+// Upload direct vers le stockage : le fichier ne transite plus par le gateway.
+// 1. POST /media/upload/presign  {filename, contentType, size} → {mediaId, uploadUrl, method, headers, expiresAt}
+// 2. le client envoie le fichier sur uploadUrl (method + headers tels quels)
+// 3. POST /media/upload/complete {mediaId, conversationId?} → {mediaId, key, url, size, contentType} (mediaId définitif)
+// Le propriétaire (ownerId) est toujours l'utilisateur du token, jamais une valeur du client.
+const (
+	subjectUploadPresign  = "media.upload.presign"
+	subjectUploadComplete = "media.upload.complete"
+)
+
+type presignRequest struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	OwnerID     string `json:"ownerId"`
+	Voice       bool   `json:"voice,omitempty"`
+}
+
+type completeRequest struct {
+	MediaID        string `json:"mediaId"`
+	ConversationID int    `json:"conversationId"`
+	OwnerID        string `json:"ownerId"`
+	Voice          bool   `json:"voice,omitempty"`
+}
+
+// PresignUpload gère POST /media/upload/presign.
+func (h *Handler) PresignUpload(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req presignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Filename) == "" || req.ContentType == "" || req.Size <= 0 {
+		http.Error(w, "filename, contentType and size required", http.StatusBadRequest)
+		return
+	}
+	if req.Size > maxUploadSize {
+		http.Error(w, "file too large", http.StatusBadRequest)
+		return
+	}
+	req.OwnerID = userID
+
+	h.forwardMedia(w, subjectUploadPresign, req)
+}
+
+// CompleteUpload gère POST /media/upload/complete.
+func (h *Handler) CompleteUpload(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req completeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if req.MediaID == "" {
+		http.Error(w, "mediaId required", http.StatusBadRequest)
+		return
+	}
+	if req.ConversationID < 0 {
+		http.Error(w, "invalid conversationId", http.StatusBadRequest)
+		return
+	}
+	req.OwnerID = userID
+
+	h.forwardMedia(w, subjectUploadComplete, req)
+}
+
+// authenticate valide le Bearer token via le service auth et retourne l'ID utilisateur ;
+// false si une erreur a déjà été répondue.
+func (h *Handler) authenticate(w http.ResponseWriter, r *http.Request) (string, bool) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		http.Error(w, "Unauthorized: missing token", http.StatusUnauthorized)
+		return "", false
+	}
+	valResult, err := auth.ValidateToken(token)
+	if err != nil {
+		log.Printf("auth service error: %v", err)
+		http.Error(w, "Authentication service unavailable", http.StatusServiceUnavailable)
+		return "", false
+	}
+	if !valResult.IsValid || valResult.User.ID == "" {
+		http.Error(w, "Unauthorized: invalid token", http.StatusUnauthorized)
+		return "", false
+	}
+	return valResult.User.ID, true
+}
+
+// forwardMedia relaie req au media-service et renvoie sa réponse JSON
+// ({ error } → 400, 403 ou 404 selon { code }).
+func (h *Handler) forwardMedia(w http.ResponseWriter, subject string, req any) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	reply, err := h.nc.Request(subject, payload, 10*time.Second)
+	if err != nil {
+		log.Printf("nats request error (%s): %v", subject, err)
+		http.Error(w, "media service unavailable: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	var resp map[string]any
+	if err := json.Unmarshal(reply.Data, &resp); err != nil {
+		http.Error(w, "invalid response from media service", http.StatusBadGateway)
+		return
+	}
+
+	status := http.StatusOK
+	if _, ok := resp["error"]; ok {
+		status = statusFromMediaCode(resp["code"])
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// statusFromMediaCode traduit ErrorResponse.code du media-service.
+func statusFromMediaCode(code any) int {
+	switch code {
+	case "FORBIDDEN":
+		return http.StatusForbidden
+	case "NOT_FOUND":
+		return http.StatusNotFound
+	case "SCAN_PENDING":
+		// Analyse antivirus en cours : le client réessaie après media_scan_completed.
+		return http.StatusConflict
+	case "QUARANTINED":
+		return http.StatusLocked
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,105 @@
+package media
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Upload reprenable (gros fichiers, vidéos jusqu'à 2 GB) : les parties vont directement au stockage.
+// 1. POST /media/uploads {filename, contentType, size, conversationId?} → {uploadId, offset, size, chunkSize, expiresAt}
+// 2. POST /media/uploads/{uploadId}/chunks {offset} → {offset, size, uploadUrl, method, headers, expiresAt},
+// puis le client envoie les octets [offset, offset+size) sur uploadUrl (method + headers tels quels)
+// 3. GET /media/uploads/{uploadId} → progression (offset : octets reçus d'un seul tenant), pour reprendre
+// 4. POST /media/uploads/{uploadId}/complete → {mediaId, key, url, size, contentType}
+// DELETE /media/uploads/{uploadId} annule l'upload. Une session n'est accessible qu'à son propriétaire
+// (ownerId = utilisateur du token).
+const (
+	subjectSessionCreate   = "media.upload.session.create"
+	subjectSessionStatus   = "media.upload.session.status"
+	subjectSessionChunk    = "media.upload.session.chunk"
+	subjectSessionComplete = "media.upload.session.complete"
+	subjectSessionAbort    = "media.upload.session.abort"
+)
+
+type sessionCreateRequest struct {
+	Filename       string `json:"filename"`
+	ContentType    string `json:"contentType"`
+	Size           int64  `json:"size"`
+	ConversationID int    `json:"conversationId"`
+	OwnerID        string `json:"ownerId"`
+}
+
+type sessionRequest struct {
+	UploadID string `json:"uploadId"`
+	OwnerID  string `json:"ownerId"`
+	Offset   int64  `json:"offset,omitempty"`
+}
+
+// CreateUploadSession gère POST /media/uploads.
+func (h *Handler) CreateUploadSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	var req sessionCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Filename) == "" || req.ContentType == "" || req.Size <= 0 {
+		http.Error(w, "filename, contentType and size required", http.StatusBadRequest)
+		return
+	}
+	if req.ConversationID < 0 {
+		http.Error(w, "invalid conversationId", http.StatusBadRequest)
+		return
+	}
+	req.OwnerID = userID
+
+	h.forwardMedia(w, subjectSessionCreate, req)
+}
+
+// GetUploadSession gère GET /media/uploads/{uploadId}.
+func (h *Handler) GetUploadSession(w http.ResponseWriter, r *http.Request) {
+	h.forwardSession(w, r, subjectSessionStatus, 0)
+}
+
+// PresignChunk gère POST /media/uploads/{uploadId}/chunks.
+func (h *Handler) PresignChunk(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Offset *int64 `json:"offset"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Offset == nil {
+		http.Error(w, "offset required", http.StatusBadRequest)
+		return
+	}
+	h.forwardSession(w, r, subjectSessionChunk, *body.Offset)
+}
+
+// CompleteUploadSession gère POST /media/uploads/{uploadId}/complete.
+func (h *Handler) CompleteUploadSession(w http.ResponseWriter, r *http.Request) {
+	h.forwardSession(w, r, subjectSessionComplete, 0)
+}
+
+// AbortUploadSession gère DELETE /media/uploads/{uploadId}.
+func (h *Handler) AbortUploadSession(w http.ResponseWriter, r *http.Request) {
+	h.forwardSession(w, r, subjectSessionAbort, 0)
+}
+
+func (h *Handler) forwardSession(w http.ResponseWriter, r *http.Request, subject string, offset int64) {
+	userID, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	uploadID := chi.URLParam(r, "uploadId")
+	if uploadID == "" {
+		http.Error(w, "uploadId required", http.StatusBadRequest)
+		return
+	}
+	h.forwardMedia(w, subject, sessionRequest{UploadID: uploadID, OwnerID: userID, Offset: offset})
+}
@@ -0,0 +1,174 @@
+package message
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"gateway/internal/models"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// ArchiveGroup gère POST /api/groups/{id}/archive : body optionnel {"archived": false} pour désarchiver.
+// L'archivage ne concerne que l'acteur : la conversation sort de GET /api/groups et apparaît
+// dans GET /api/groups?archived=true.
+func (h *Handler) ArchiveGroup(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.ArchiveGroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.ArchiveGroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	var req models.ArchiveGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondJSON(w, http.StatusBadRequest, models.ArchiveGroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+	archived := req.Archived == nil || *req.Archived
+
+	data, err := proto.Marshal(&apiv1.GroupArchiveRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+		Archived:       archived,
+	})
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.ArchiveGroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectGroupArchive, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ArchiveGroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.GroupArchiveResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ArchiveGroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.ArchiveGroupResponse{
+		OK:         resp.GetOk(),
+		Archived:   resp.GetArchived(),
+		ArchivedAt: resp.GetArchivedAt(),
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+// RestoreGroup gère POST /api/groups/{id}/restore : annule la suppression (owner, pendant le délai de grâce).
+// Chaque membre réintégré reçoit conversation_created sur sa user room.
+func (h *Handler) RestoreGroup(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	data, err := proto.Marshal(&apiv1.GroupRestoreRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+	})
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectGroupRestore, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.GroupRestoreResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.GroupResponse{OK: resp.GetOk(), Data: toGroupModel(resp.GetData())}
+	if out.Data != nil {
+		h.resolveGroupDisplayName(out.Data, actorID)
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	if resp.GetOk() {
+		payload, _ := json.Marshal(map[string]interface{}{
+			"action":          "conversation_created",
+			"group_id":        conversationID,
+			"conversation_id": conversationID,
+			"id":              conversationID,
+		})
+		for _, memberID := range resp.GetMemberIds() {
+			_ = h.nc.Publish("message.broadcast.user:"+memberID, payload)
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
@@ -0,0 +1,170 @@
+package message
+
+import (
+	"bytes"
+	"encoding/json"
+	"gateway/internal/common"
+	"gateway/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHandler_ArchiveGroup_DefaultsToArchived(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectGroupArchive {
+				t.Fatalf("expected subject %s, got %s", subjectGroupArchive, subject)
+			}
+			var req apiv1.GroupArchiveRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetActorId() != testActorID || req.GetConversationId() != 7 || !req.GetArchived() {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.GroupArchiveResponse{Ok: true, Archived: true, ArchivedAt: 1700000000})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/groups/7/archive", nil)
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.ArchiveGroup(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.ArchiveGroupResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if !out.Archived || out.ArchivedAt != 1700000000 {
+		t.Fatalf("unexpected response %+v", out)
+	}
+}
+
+func TestHandler_ArchiveGroup_Unarchive(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			var req apiv1.GroupArchiveRequest
+			_ = proto.Unmarshal(data, &req)
+			if req.GetArchived() {
+				t.Fatal("expected archived=false")
+			}
+			respBytes, _ := proto.Marshal(&apiv1.GroupArchiveResponse{Ok: true})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/groups/7/archive", bytes.NewBufferString(`{"archived":false}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.ArchiveGroup(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+}
+
+func TestHandler_ListGroups_Archived(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			var req apiv1.GroupListForUserRequest
+			_ = proto.Unmarshal(data, &req)
+			if !req.GetArchived() {
+				t.Fatal("expected archived=true to be forwarded")
+			}
+			respBytes, _ := proto.Marshal(&apiv1.GroupListForUserResponse{Ok: true})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("GET", "/api/groups?archived=true", nil)
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	w := httptest.NewRecorder()
+
+	handler.ListGroups(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+}
+
+func TestHandler_RestoreGroup_NotifiesMembers(t *testing.T) {
+	var published []string
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectGroupRestore {
+				t.Fatalf("expected subject %s, got %s", subjectGroupRestore, subject)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.GroupRestoreResponse{
+				Ok:        true,
+				Data:      &apiv1.Group{Id: 7, Name: "Équipe", Kind: "group"},
+				MemberIds: []string{testActorID, testPeerID},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+		PublishFunc: func(subject string, data []byte) error {
+			published = append(published, subject)
+			return nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/groups/7/restore", nil)
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.RestoreGroup(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	if len(published) != 2 || !strings.HasSuffix(published[1], testPeerID) {
+		t.Fatalf("expected conversation_created for each member, got %v", published)
+	}
+}
+
+func TestHandler_RestoreGroup_WindowExpired(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			respBytes, _ := proto.Marshal(&apiv1.GroupRestoreResponse{
+				Ok:    false,
+				Error: &apiv1.Error{Code: "CONFLICT", Message: "restore window expired"},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+		PublishFunc: func(subject string, data []byte) error {
+			t.Fatalf("unexpected publish on %s", subject)
+			return nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/groups/7/restore", nil)
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.RestoreGroup(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", w.Code)
+	}
+}
@@ -0,0 +1,185 @@
+package message
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"gateway/internal/models"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// BlockUser gère POST /api/blocks : body {"user_id": "<uuid>"}.
+// Le bloqué ne peut plus ouvrir de DM avec l'acteur, l'ajouter à un groupe ni le mentionner,
+// et ses messages sont masqués pour l'acteur.
+func (h *Handler) BlockUser(w http.ResponseWriter, r *http.Request) {
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.BlockResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	var req models.BlockUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, models.BlockResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+	if strings.TrimSpace(req.UserID) == "" {
+		respondJSON(w, http.StatusBadRequest, models.BlockResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "user_id required"},
+		})
+		return
+	}
+
+	h.forwardUserBlock(w, subjectUserBlock, &apiv1.UserBlockRequest{
+		ActorId: actorID,
+		UserId:  strings.TrimSpace(req.UserID),
+	})
+}
+
+// UnblockUser gère DELETE /api/blocks/{user_id}.
+func (h *Handler) UnblockUser(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "user_id")
+	if userID == "" {
+		respondJSON(w, http.StatusBadRequest, models.BlockResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "user_id required"},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.BlockResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	h.forwardUserBlock(w, subjectUserUnblock, &apiv1.UserBlockRequest{
+		ActorId: actorID,
+		UserId:  userID,
+	})
+}
+
+// ListBlocks gère GET /api/blocks : utilisateurs bloqués par l'acteur, plus récents d'abord.
+func (h *Handler) ListBlocks(w http.ResponseWriter, r *http.Request) {
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.BlocksResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	data, err := proto.Marshal(&apiv1.UserBlockListRequest{ActorId: actorID})
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.BlocksResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectUserBlockList, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.BlocksResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.UserBlockListResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.BlocksResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.BlocksResponse{OK: resp.GetOk(), Data: make([]models.UserBlock, 0, len(resp.GetData()))}
+	for _, item := range resp.GetData() {
+		if mapped := toUserBlockModel(item); mapped != nil {
+			out.Data = append(out.Data, *mapped)
+		}
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+func (h *Handler) forwardUserBlock(w http.ResponseWriter, subject string, req *apiv1.UserBlockRequest) {
+	data, err := proto.Marshal(req)
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.BlockResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subject, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.BlockResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.UserBlockResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.BlockResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.BlockResponse{OK: resp.GetOk(), Data: toUserBlockModel(resp.GetData())}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+func toUserBlockModel(block *apiv1.UserBlock) *models.UserBlock {
+	if block == nil {
+		return nil
+	}
+	return &models.UserBlock{
+		BlockerID: block.GetBlockerId(),
+		BlockedID: block.GetBlockedId(),
+		CreatedAt: block.GetCreatedAt(),
+	}
+}
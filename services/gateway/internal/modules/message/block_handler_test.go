@@ -0,0 +1,102 @@
+package message
+
+import (
+	"bytes"
+	"encoding/json"
+	"gateway/internal/common"
+	"gateway/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHandler_BlockUser(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectUserBlock {
+				t.Fatalf("expected subject %s, got %s", subjectUserBlock, subject)
+			}
+			var req apiv1.UserBlockRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetActorId() != testActorID || req.GetUserId() != testPeerID {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.UserBlockResponse{
+				Ok:   true,
+				Data: &apiv1.UserBlock{BlockerId: testActorID, BlockedId: testPeerID, CreatedAt: 1700000000},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	body, _ := json.Marshal(models.BlockUserRequest{UserID: testPeerID})
+	req := httptest.NewRequest("POST", "/api/blocks", bytes.NewReader(body))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	w := httptest.NewRecorder()
+
+	handler.BlockUser(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.BlockResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if out.Data == nil || out.Data.BlockedID != testPeerID {
+		t.Fatalf("unexpected block %+v", out.Data)
+	}
+}
+
+func TestHandler_BlockUser_MissingUserID(t *testing.T) {
+	handler := NewHandler(&common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			t.Fatalf("no request expected, got %s", subject)
+			return nil, nil
+		},
+	})
+	req := httptest.NewRequest("POST", "/api/blocks", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	w := httptest.NewRecorder()
+
+	handler.BlockUser(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestHandler_UnblockUser_NotFound(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectUserUnblock {
+				t.Fatalf("expected subject %s, got %s", subjectUserUnblock, subject)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.UserBlockResponse{
+				Ok:    false,
+				Error: &apiv1.Error{Code: "NOT_FOUND", Message: "block not found"},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("DELETE", "/api/blocks/"+testPeerID, nil)
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"user_id": testPeerID})
+	w := httptest.NewRecorder()
+
+	handler.UnblockUser(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d (%s)", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,105 @@
+package message
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"gateway/internal/models"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// GetOrCreateDirect gère POST /api/groups/direct : body {"user_id": "<uuid>"}.
+// Retourne la conversation directe existante avec user_id, ou la crée.
+func (h *Handler) GetOrCreateDirect(w http.ResponseWriter, r *http.Request) {
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	var req models.DirectConversationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+	userID := strings.TrimSpace(req.UserID)
+	if userID == "" {
+		respondJSON(w, http.StatusBadRequest, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "user_id required"},
+		})
+		return
+	}
+
+	data, err := proto.Marshal(&apiv1.DirectGetOrCreateRequest{
+		ActorId: actorID,
+		UserId:  userID,
+	})
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectDirectGetOrCreate, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.DirectGetOrCreateResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.GroupResponse{OK: resp.GetOk()}
+	if resp.GetData() != nil {
+		out.Data = toGroupModel(resp.GetData())
+		h.resolveGroupDisplayName(out.Data, actorID)
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+
+	// Nouvelle conversation directe → notifier les deux participants via leur user room.
+	if resp.GetOk() && resp.GetCreated() && out.Data != nil {
+		payload, _ := json.Marshal(map[string]interface{}{
+			"action":          "conversation_created",
+			"group_id":        out.Data.ID,
+			"conversation_id": out.Data.ID,
+			"id":              out.Data.ID,
+			"kind":            conversationKindDirect,
+		})
+		for _, participant := range []string{actorID, userID} {
+			_ = h.nc.Publish("message.broadcast.user:"+participant, payload)
+		}
+	}
+
+	respondJSON(w, status, out)
+}
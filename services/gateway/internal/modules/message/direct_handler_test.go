@@ -0,0 +1,116 @@
+package message
+
+import (
+	"bytes"
+	"encoding/json"
+	"gateway/internal/common"
+	"gateway/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+const testPeerID = "a0000003-0000-0000-0000-000000000003"
+
+func TestHandler_GetOrCreateDirect(t *testing.T) {
+	var published []string
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			switch subject {
+			case subjectDirectGetOrCreate:
+				var req apiv1.DirectGetOrCreateRequest
+				if err := proto.Unmarshal(data, &req); err != nil {
+					t.Fatalf("invalid request payload: %v", err)
+				}
+				if req.GetActorId() != testActorID || req.GetUserId() != testPeerID {
+					t.Fatalf("unexpected request %+v", &req)
+				}
+				respBytes, _ := proto.Marshal(&apiv1.DirectGetOrCreateResponse{
+					Ok:      true,
+					Created: true,
+					Data:    &apiv1.Group{Id: 12, Kind: "direct", PeerId: testPeerID},
+				})
+				return &nats.Msg{Data: respBytes}, nil
+			case "user.get":
+				return &nats.Msg{Data: []byte(`{"response":{"username":"bob"}}`)}, nil
+			}
+			t.Fatalf("unexpected subject %s", subject)
+			return nil, nil
+		},
+		PublishFunc: func(subject string, data []byte) error {
+			published = append(published, subject)
+			return nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	body := `{"user_id":"` + testPeerID + `"}`
+	req := httptest.NewRequest("POST", "/api/groups/direct", bytes.NewBufferString(body))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	w := httptest.NewRecorder()
+
+	handler.GetOrCreateDirect(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.GroupResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if out.Data == nil || out.Data.Kind != "direct" || out.Data.PeerID != testPeerID || out.Data.Name != "bob" {
+		t.Fatalf("unexpected conversation %+v", out.Data)
+	}
+	if len(published) != 2 || !strings.HasSuffix(published[0], testActorID) || !strings.HasSuffix(published[1], testPeerID) {
+		t.Fatalf("expected conversation_created for both participants, got %v", published)
+	}
+}
+
+func TestHandler_GetOrCreateDirect_ExistingDoesNotNotify(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject == "user.get" {
+				return &nats.Msg{Data: []byte(`{"response":{"username":"bob"}}`)}, nil
+			}
+			respBytes, _ := proto.Marshal(&apiv1.DirectGetOrCreateResponse{
+				Ok:   true,
+				Data: &apiv1.Group{Id: 12, Kind: "direct", PeerId: testPeerID},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+		PublishFunc: func(subject string, data []byte) error {
+			t.Fatalf("existing direct conversation should not be announced (%s)", subject)
+			return nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/groups/direct", bytes.NewBufferString(`{"user_id":"`+testPeerID+`"}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	w := httptest.NewRecorder()
+
+	handler.GetOrCreateDirect(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+}
+
+func TestHandler_GetOrCreateDirect_RequiresUserID(t *testing.T) {
+	handler := NewHandler(&common.MockNatsConn{})
+	req := httptest.NewRequest("POST", "/api/groups/direct", bytes.NewBufferString(`{}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	w := httptest.NewRecorder()
+
+	handler.GetOrCreateDirect(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+}
@@ -178,7 +178,9 @@ func (h *Handler) ListGroups(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	protoReq := &apiv1.GroupListForUserRequest{UserId: actorID}
+	// ?archived=true : conversations archivées par l'acteur (masquées de la liste par défaut).
+	archived, _ := strconv.ParseBool(r.URL.Query().Get("archived"))
+	protoReq := &apiv1.GroupListForUserRequest{UserId: actorID, Archived: archived}
 	data, err := proto.Marshal(protoReq)
 	if err != nil {
 		respondJSON(w, http.StatusInternalServerError, models.GroupsResponse{
@@ -698,17 +700,137 @@ func (h *Handler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, status, out)
 }
 
+// UpdateGroup gère PATCH /api/groups/{id} (admin/owner) : seuls les champs présents dans le body sont modifiés.
+func (h *Handler) UpdateGroup(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	var req models.UpdateGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+
+	protoReq := &apiv1.GroupUpdateRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+	}
+	if req.Name != nil {
+		protoReq.Name = *req.Name
+		protoReq.UpdateMask = append(protoReq.UpdateMask, "name")
+	}
+	if req.AvatarURL != nil {
+		protoReq.AvatarUrl = *req.AvatarURL
+		protoReq.UpdateMask = append(protoReq.UpdateMask, "avatar_url")
+	}
+	if req.Description != nil {
+		protoReq.Description = *req.Description
+		protoReq.UpdateMask = append(protoReq.UpdateMask, "description")
+	}
+	if len(protoReq.UpdateMask) == 0 {
+		respondJSON(w, http.StatusBadRequest, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "name, avatar_url or description required"},
+		})
+		return
+	}
+
+	data, err := proto.Marshal(protoReq)
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectGroupUpdate, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.GroupUpdateResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.GroupResponse{OK: resp.GetOk()}
+	if resp.GetData() != nil {
+		out.Data = toGroupModel(resp.GetData())
+		h.resolveGroupDisplayName(out.Data, actorID)
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
 func toGroupModel(group *apiv1.Group) *models.Group {
 	if group == nil {
 		return nil
 	}
+	kind := group.GetKind()
+	if kind == "" {
+		kind = conversationKindGroup
+	}
 	return &models.Group{
-		ID:        int(group.GetId()),
-		Name:      group.GetName(),
-		AvatarURL: group.GetAvatarUrl(),
-		CreatedBy: group.GetCreatedBy(),
-		CreatedAt: group.GetCreatedAt(),
-		UpdatedAt: group.GetUpdatedAt(),
+		ID:          int(group.GetId()),
+		Kind:        kind,
+		Name:        group.GetName(),
+		AvatarURL:   group.GetAvatarUrl(),
+		Description: group.GetDescription(),
+		CreatedBy:   group.GetCreatedBy(),
+		PeerID:      group.GetPeerId(),
+		CreatedAt:   group.GetCreatedAt(),
+		UpdatedAt:   group.GetUpdatedAt(),
+		Permissions: toGroupPermissionsModel(group.GetPermissions(), kind),
+	}
+}
+
+func toGroupPermissionsModel(permissions *apiv1.GroupPermissions, kind string) *models.GroupPermissions {
+	if permissions == nil || kind == conversationKindDirect {
+		return nil
+	}
+	return &models.GroupPermissions{
+		OnlyAdminsCanPost:    permissions.GetOnlyAdminsCanPost(),
+		MembersCanAddMembers: permissions.GetMembersCanAddMembers(),
+		MembersCanPin:        permissions.GetMembersCanPin(),
+		SlowModeSeconds:      int(permissions.GetSlowModeSeconds()),
+		JoinRequestsEnabled:  permissions.GetJoinRequestsEnabled(),
 	}
 }
 
@@ -739,17 +861,30 @@ func groupIDFromPath(r *http.Request) (int, bool) {
 // legacyPlaceholderConversationName : anciennes conv. créées avec nom par défaut côté API.
 const legacyPlaceholderConversationName = "Untitled conversation"
 
+const (
+	conversationKindGroup  = "group"
+	conversationKindDirect = "direct"
+)
+
 func shouldResolveConversationDisplayName(name string) bool {
 	return name == "" || name == legacyPlaceholderConversationName
 }
 
-// resolveGroupDisplayName résout le nom d'affichage d'une conversation si le nom en DB est vide
-// (ou encore l’ancien placeholder "Untitled conversation").
-// - Conv à 2 membres : nom de l'autre membre
-// - Conv à 3+ membres : "user1, user2, user3"
-// Si le nom est déjà renseigné (renommé par l'utilisateur), on le garde tel quel.
+// resolveGroupDisplayName résout le nom d'affichage d'une conversation :
+// - conversation directe : nom de l'autre participant (peer_id), sans lister les membres
+// - groupe sans nom (ou ancien placeholder "Untitled conversation") : "user1, user2, user3"
+// Si le nom d'un groupe est renseigné (renommé par l'utilisateur), on le garde tel quel.
 func (h *Handler) resolveGroupDisplayName(group *models.Group, actorID string) {
-	if group == nil || !shouldResolveConversationDisplayName(group.Name) {
+	if group == nil {
+		return
+	}
+	if group.Kind == conversationKindDirect && group.PeerID != "" {
+		if name := h.fetchUsername(group.PeerID); name != "" {
+			group.Name = name
+		}
+		return
+	}
+	if !shouldResolveConversationDisplayName(group.Name) {
 		return
 	}
 
@@ -109,3 +109,59 @@ func TestHandler_AddGroupMember_RequiresUserID(t *testing.T) {
 		t.Fatalf("expected status 400, got %d", w.Code)
 	}
 }
+
+func TestHandler_UpdateGroup_SendsUpdateMask(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectGroupUpdate {
+				t.Fatalf("expected subject %s, got %s", subjectGroupUpdate, subject)
+			}
+			var req apiv1.GroupUpdateRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			mask := req.GetUpdateMask()
+			if req.GetConversationId() != 10 || len(mask) != 2 || mask[0] != "name" || mask[1] != "description" {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			if req.GetName() != "Backend v2" || req.GetDescription() != "" {
+				t.Fatalf("unexpected values %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.GroupUpdateResponse{
+				Ok:   true,
+				Data: &apiv1.Group{Id: 10, Name: "Backend v2", Kind: "group"},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("PATCH", "/api/groups/10", bytes.NewBufferString(`{"name":"Backend v2","description":""}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "10")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.UpdateGroup(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+}
+
+func TestHandler_UpdateGroup_RequiresField(t *testing.T) {
+	handler := NewHandler(&common.MockNatsConn{})
+	req := httptest.NewRequest("PATCH", "/api/groups/10", bytes.NewBufferString(`{}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "10")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.UpdateGroup(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+}
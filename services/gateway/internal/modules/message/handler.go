@@ -33,6 +33,56 @@ const (
 	subjectGroupUpdateRole  = "GROUP_UPDATE_ROLE"
 	subjectGroupLeave       = "GROUP_LEAVE"
 	subjectGroupDelete      = "GROUP_DELETE"
+	subjectGroupUpdate      = "GROUP_UPDATE"
+
+	subjectGroupTransferOwnership = "GROUP_TRANSFER_OWNERSHIP"
+	subjectGroupAddMembersBulk    = "GROUP_ADD_MEMBERS_BULK"
+	subjectGroupRemoveMembersBulk = "GROUP_REMOVE_MEMBERS_BULK"
+
+	subjectGroupInviteCreate = "GROUP_INVITE_CREATE"
+	subjectGroupInviteList   = "GROUP_INVITE_LIST"
+	subjectGroupInviteRevoke = "GROUP_INVITE_REVOKE"
+	subjectGroupInviteJoin   = "GROUP_INVITE_JOIN"
+
+	subjectGroupJoinRequest = "GROUP_JOIN_REQUEST"
+	subjectGroupJoinDecide  = "GROUP_JOIN_DECIDE"
+	subjectGroupJoinList    = "GROUP_JOIN_LIST"
+
+	subjectGroupArchive = "GROUP_ARCHIVE"
+	subjectGroupRestore = "GROUP_RESTORE"
+
+	subjectGroupUpdatePermissions = "GROUP_UPDATE_PERMISSIONS"
+
+	subjectDirectGetOrCreate = "DIRECT_GET_OR_CREATE"
+
+	subjectScheduleMessage        = "SCHEDULE_MESSAGE"
+	subjectListScheduledMessages  = "LIST_SCHEDULED_MESSAGES"
+	subjectCancelScheduledMessage = "CANCEL_SCHEDULED_MESSAGE"
+
+	subjectPinMessage   = "PIN_MESSAGE"
+	subjectUnpinMessage = "UNPIN_MESSAGE"
+	subjectListPins     = "LIST_PINS"
+
+	subjectPollCreate = "POLL_CREATE"
+	subjectPollVote   = "POLL_VOTE"
+	subjectPollClose  = "POLL_CLOSE"
+	subjectPollGet    = "POLL_GET"
+
+	subjectUserBlock     = "USER_BLOCK"
+	subjectUserUnblock   = "USER_UNBLOCK"
+	subjectUserBlockList = "USER_BLOCK_LIST"
+
+	subjectMessageReport        = "MESSAGE_REPORT"
+	subjectMessageReportList    = "MESSAGE_REPORT_LIST"
+	subjectMessageReportResolve = "MESSAGE_REPORT_RESOLVE"
+
+	subjectGroupBan      = "GROUP_BAN"
+	subjectGroupUnban    = "GROUP_UNBAN"
+	subjectGroupBanList  = "GROUP_BAN_LIST"
+	subjectGroupMute     = "GROUP_MUTE"
+	subjectGroupUnmute   = "GROUP_UNMUTE"
+	subjectGroupKick     = "GROUP_KICK"
+	subjectGroupAuditLog = "GROUP_AUDIT_LOG"
 
 	requestTimeout = 5 * time.Second
 )
@@ -156,6 +206,7 @@ func (h *Handler) Send(w http.ResponseWriter, r *http.Request) {
 			Content:   req.Content,
 			ID:        mid,
 			MessageID: strconv.Itoa(mid),
+			Mentions:  d.GetMentions(),
 		}
 		if rto := d.GetReplyTo(); rto != nil && rto.GetId() != 0 {
 			rid := int(rto.GetId())
@@ -224,12 +275,18 @@ func (h *Handler) GetById(w http.ResponseWriter, r *http.Request) {
 				GroupID:        mapped.GroupID,
 				Content:        mapped.Content,
 				Attachment:     mapped.Attachment,
+				AttachmentInfo: mapped.AttachmentInfo,
 				ReceivedAt:     mapped.ReceivedAt,
 				CreatedAt:      mapped.CreatedAt,
 				UpdatedAt:      mapped.UpdatedAt,
 				Status:         mapped.Status,
 				ReplyTo:        mapped.ReplyTo,
 				SeenBy:         mapped.SeenBy,
+				Mentions:       mapped.Mentions,
+				LinkPreview:    mapped.LinkPreview,
+				PollID:         mapped.PollID,
+				Kind:           mapped.Kind,
+				System:         mapped.System,
 			}
 			h.enrichSingleMessageData(out.Data)
 		}
@@ -623,6 +680,8 @@ func statusFromServiceCode(code string, fallback int) int {
 		return http.StatusNotFound
 	case "CONFLICT":
 		return http.StatusConflict
+	case "RATE_LIMITED":
+		return http.StatusTooManyRequests
 	default:
 		return fallback
 	}
@@ -647,6 +706,40 @@ func toSendMessageData(d *apiv1.ChatMessage) *models.SendMessageData {
 		CreatedAt:      d.GetCreatedAt(),
 		UpdatedAt:      d.GetUpdatedAt(),
 		Status:         d.GetStatus(),
+		Mentions:       d.GetMentions(),
+		PollID:         int(d.GetPollId()),
+		Kind:           d.GetKind(),
+	}
+	if info := d.GetAttachmentInfo(); info != nil {
+		out.AttachmentInfo = &models.AttachmentInfo{
+			MediaID:     info.GetMediaId(),
+			Type:        info.GetType(),
+			ContentType: info.GetContentType(),
+			Filename:    info.GetFilename(),
+			Size:        info.GetSize(),
+			DurationMs:  info.GetDurationMs(),
+		}
+		for _, peak := range info.GetWaveform() {
+			out.AttachmentInfo.Waveform = append(out.AttachmentInfo.Waveform, int(peak))
+		}
+	}
+	if ev := d.GetSystem(); ev != nil {
+		out.System = &models.SystemEvent{
+			Type:     ev.GetType(),
+			ActorID:  ev.GetActorId(),
+			TargetID: ev.GetTargetId(),
+			Role:     int(ev.GetRole()),
+			Name:     ev.GetName(),
+		}
+	}
+	if lp := d.GetLinkPreview(); lp != nil {
+		out.LinkPreview = &models.LinkPreview{
+			URL:         lp.GetUrl(),
+			Title:       lp.GetTitle(),
+			Description: lp.GetDescription(),
+			ImageURL:    lp.GetImageUrl(),
+			SiteName:    lp.GetSiteName(),
+		}
 	}
 	if d.GetReplyTo() != nil {
 		out.ReplyTo = &models.ReplyToData{
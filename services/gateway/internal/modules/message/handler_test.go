@@ -163,6 +163,49 @@ func TestHandler_List(t *testing.T) {
 	}
 }
 
+func TestHandler_List_SystemMessage(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			resp := &apiv1.ListMessagesResponse{
+				Ok: true,
+				Data: []*apiv1.ChatMessage{
+					{
+						Id:      2,
+						Content: "role_changed",
+						Kind:    "system",
+						System: &apiv1.SystemEvent{
+							Type:     "role_changed",
+							ActorId:  "a0000001-0000-0000-0000-000000000001",
+							TargetId: "a0000002-0000-0000-0000-000000000002",
+							Role:     1,
+						},
+					},
+				},
+			}
+			respBytes, _ := proto.Marshal(resp)
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("GET", "/api/messages?conversation_id=123", nil)
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	w := httptest.NewRecorder()
+	handler.GetByGroupId(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status OK, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.ListMessagesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if len(out.Data) != 1 || out.Data[0].Kind != "system" || out.Data[0].System == nil {
+		t.Fatalf("expected a system message, got %+v", out.Data)
+	}
+	if ev := out.Data[0].System; ev.Type != "role_changed" || ev.TargetID != "a0000002-0000-0000-0000-000000000002" || ev.Role != 1 {
+		t.Fatalf("unexpected system event %+v", ev)
+	}
+}
+
 func TestHandler_List_WithConversationID(t *testing.T) {
 	mockNc := &common.MockNatsConn{
 		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
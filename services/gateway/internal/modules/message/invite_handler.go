@@ -0,0 +1,301 @@
+package message
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gateway/internal/models"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// inviteReply : forme commune des réponses GROUP_INVITE_CREATE / GROUP_INVITE_REVOKE.
+type inviteReply interface {
+	proto.Message
+	GetOk() bool
+	GetData() *apiv1.ConversationInvite
+	GetError() *apiv1.Error
+}
+
+// CreateInvite gère POST /api/groups/{id}/invites (admin/owner) : body {"expires_at": ..., "max_uses": ...}.
+func (h *Handler) CreateInvite(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.ConversationInviteResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.ConversationInviteResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	var req models.CreateInviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, models.ConversationInviteResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+
+	h.forwardInviteRequest(w, subjectGroupInviteCreate, &apiv1.GroupInviteCreateRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+		ExpiresAt:      req.ExpiresAt,
+		MaxUses:        int32(req.MaxUses),
+	}, &apiv1.GroupInviteCreateResponse{})
+}
+
+// ListInvites gère GET /api/groups/{id}/invites (admin/owner) : révoquées et expirées comprises.
+func (h *Handler) ListInvites(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.ConversationInvitesResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.ConversationInvitesResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	data, err := proto.Marshal(&apiv1.GroupInviteListRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+	})
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.ConversationInvitesResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectGroupInviteList, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ConversationInvitesResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.GroupInviteListResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ConversationInvitesResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.ConversationInvitesResponse{OK: resp.GetOk(), Data: make([]models.ConversationInvite, 0, len(resp.GetData()))}
+	for _, item := range resp.GetData() {
+		if mapped := toConversationInviteModel(item); mapped != nil {
+			out.Data = append(out.Data, *mapped)
+		}
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+// RevokeInvite gère DELETE /api/groups/{id}/invites/{invite_id} (admin/owner).
+func (h *Handler) RevokeInvite(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.ConversationInviteResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	inviteID, err := strconv.ParseInt(chi.URLParam(r, "invite_id"), 10, 32)
+	if err != nil || inviteID <= 0 {
+		respondJSON(w, http.StatusBadRequest, models.ConversationInviteResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid invite_id"},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.ConversationInviteResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	h.forwardInviteRequest(w, subjectGroupInviteRevoke, &apiv1.GroupInviteRevokeRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+		InviteId:       int32(inviteID),
+	}, &apiv1.GroupInviteRevokeResponse{})
+}
+
+// JoinInvite gère POST /api/invites/{token}/join : tout utilisateur authentifié rejoint le groupe comme membre.
+func (h *Handler) JoinInvite(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSpace(chi.URLParam(r, "token"))
+	if token == "" {
+		respondJSON(w, http.StatusBadRequest, models.JoinInviteResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid token"},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.JoinInviteResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	data, err := proto.Marshal(&apiv1.GroupInviteJoinRequest{
+		ActorId: actorID,
+		Token:   token,
+	})
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.JoinInviteResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectGroupInviteJoin, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.JoinInviteResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.GroupInviteJoinResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.JoinInviteResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.JoinInviteResponse{
+		OK:     resp.GetOk(),
+		Data:   toGroupModel(resp.GetData()),
+		Member: toGroupMemberModel(resp.GetMember()),
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+
+	// Même contrat front que GROUP_ADD_MEMBER : le nouveau membre reçoit conversation_created sur sa user room.
+	if resp.GetOk() && out.Data != nil {
+		payload, _ := json.Marshal(map[string]interface{}{
+			"action":          "conversation_created",
+			"group_id":        out.Data.ID,
+			"conversation_id": out.Data.ID,
+			"id":              out.Data.ID,
+			"name":            out.Data.Name,
+		})
+		_ = h.nc.Publish("message.broadcast.user:"+actorID, payload)
+	}
+
+	respondJSON(w, status, out)
+}
+
+func (h *Handler) forwardInviteRequest(w http.ResponseWriter, subject string, req proto.Message, resp inviteReply) {
+	data, err := proto.Marshal(req)
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.ConversationInviteResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subject, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ConversationInviteResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	if err := proto.Unmarshal(reply.Data, resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ConversationInviteResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.ConversationInviteResponse{OK: resp.GetOk(), Data: toConversationInviteModel(resp.GetData())}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+func toConversationInviteModel(invite *apiv1.ConversationInvite) *models.ConversationInvite {
+	if invite == nil {
+		return nil
+	}
+	return &models.ConversationInvite{
+		ID:             int(invite.GetId()),
+		Token:          invite.GetToken(),
+		ConversationID: int(invite.GetConversationId()),
+		CreatedBy:      invite.GetCreatedBy(),
+		ExpiresAt:      invite.GetExpiresAt(),
+		MaxUses:        int(invite.GetMaxUses()),
+		UseCount:       int(invite.GetUseCount()),
+		RevokedAt:      invite.GetRevokedAt(),
+		CreatedAt:      invite.GetCreatedAt(),
+	}
+}
@@ -0,0 +1,150 @@
+package message
+
+import (
+	"bytes"
+	"encoding/json"
+	"gateway/internal/common"
+	"gateway/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHandler_CreateInvite(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectGroupInviteCreate {
+				t.Fatalf("expected subject %s, got %s", subjectGroupInviteCreate, subject)
+			}
+			var req apiv1.GroupInviteCreateRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetActorId() != testActorID || req.GetConversationId() != 7 || req.GetMaxUses() != 10 || req.GetExpiresAt() != 1800000000 {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.GroupInviteCreateResponse{
+				Ok:   true,
+				Data: &apiv1.ConversationInvite{Id: 3, Token: "tok", ConversationId: 7, MaxUses: 10, ExpiresAt: 1800000000},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/groups/7/invites", bytes.NewBufferString(`{"expires_at":1800000000,"max_uses":10}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.CreateInvite(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.ConversationInviteResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if out.Data == nil || out.Data.Token != "tok" || out.Data.MaxUses != 10 {
+		t.Fatalf("unexpected invite %+v", out.Data)
+	}
+}
+
+func TestHandler_RevokeInvite_InvalidID(t *testing.T) {
+	handler := NewHandler(&common.MockNatsConn{})
+	req := httptest.NewRequest("DELETE", "/api/groups/7/invites/abc", nil)
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7", "invite_id": "abc"})
+	w := httptest.NewRecorder()
+
+	handler.RevokeInvite(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestHandler_JoinInvite(t *testing.T) {
+	var published []string
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectGroupInviteJoin {
+				t.Fatalf("expected subject %s, got %s", subjectGroupInviteJoin, subject)
+			}
+			var req apiv1.GroupInviteJoinRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetActorId() != testActorID || req.GetToken() != "tok" {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.GroupInviteJoinResponse{
+				Ok:     true,
+				Data:   &apiv1.Group{Id: 7, Name: "Équipe", Kind: "group"},
+				Member: &apiv1.GroupMember{Id: 9, ConversationId: 7, UserId: testActorID},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+		PublishFunc: func(subject string, data []byte) error {
+			published = append(published, subject)
+			return nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/invites/tok/join", nil)
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"token": "tok"})
+	w := httptest.NewRecorder()
+
+	handler.JoinInvite(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.JoinInviteResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if out.Data == nil || out.Data.ID != 7 || out.Member == nil || out.Member.Role != 0 {
+		t.Fatalf("unexpected join response %+v", out)
+	}
+	if len(published) != 1 || !strings.HasSuffix(published[0], testActorID) {
+		t.Fatalf("expected conversation_created on the joiner's user room, got %v", published)
+	}
+}
+
+func TestHandler_JoinInvite_MapsConflict(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			respBytes, _ := proto.Marshal(&apiv1.GroupInviteJoinResponse{
+				Ok:    false,
+				Error: &apiv1.Error{Code: "CONFLICT", Message: "invite expired"},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+		PublishFunc: func(subject string, data []byte) error {
+			t.Fatalf("failed join should not publish (%s)", subject)
+			return nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/invites/tok/join", nil)
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"token": "tok"})
+	w := httptest.NewRecorder()
+
+	handler.JoinInvite(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", w.Code)
+	}
+}
@@ -0,0 +1,233 @@
+package message
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"gateway/internal/models"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// joinRequestReply : forme commune des réponses GROUP_JOIN_REQUEST / GROUP_JOIN_DECIDE.
+type joinRequestReply interface {
+	proto.Message
+	GetOk() bool
+	GetData() *apiv1.ConversationJoinRequest
+	GetError() *apiv1.Error
+}
+
+// RequestToJoinGroup gère POST /api/groups/{id}/join-requests : body facultatif {"message": "..."}.
+// Les admins/owners du groupe sont notifiés par le message-service.
+func (h *Handler) RequestToJoinGroup(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.JoinRequestResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.JoinRequestResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	var req models.JoinGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		respondJSON(w, http.StatusBadRequest, models.JoinRequestResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+
+	h.forwardJoinRequest(w, subjectGroupJoinRequest, &apiv1.GroupJoinRequestRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+		Message:        req.Message,
+	}, &apiv1.GroupJoinRequestResponse{})
+}
+
+// ListJoinRequests gère GET /api/groups/{id}/join-requests (admin/owner) : demandes en attente.
+func (h *Handler) ListJoinRequests(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.JoinRequestsResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.JoinRequestsResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	data, err := proto.Marshal(&apiv1.GroupJoinListRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+	})
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.JoinRequestsResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectGroupJoinList, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.JoinRequestsResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.GroupJoinListResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.JoinRequestsResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.JoinRequestsResponse{OK: resp.GetOk(), Data: make([]models.JoinRequest, 0, len(resp.GetData()))}
+	for _, item := range resp.GetData() {
+		if mapped := toJoinRequestModel(item); mapped != nil {
+			out.Data = append(out.Data, *mapped)
+		}
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+// DecideJoinRequest gère POST /api/groups/{id}/join-requests/{request_id} (admin/owner) : body {"approve": true|false}.
+// Le demandeur reçoit join_request_decided sur sa user room (publié par le message-service).
+func (h *Handler) DecideJoinRequest(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.JoinRequestResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	requestID, err := strconv.ParseInt(chi.URLParam(r, "request_id"), 10, 32)
+	if err != nil || requestID <= 0 {
+		respondJSON(w, http.StatusBadRequest, models.JoinRequestResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid request_id"},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.JoinRequestResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	var req models.DecideJoinRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, models.JoinRequestResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+
+	h.forwardJoinRequest(w, subjectGroupJoinDecide, &apiv1.GroupJoinDecideRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+		RequestId:      int32(requestID),
+		Approve:        req.Approve,
+	}, &apiv1.GroupJoinDecideResponse{})
+}
+
+func (h *Handler) forwardJoinRequest(w http.ResponseWriter, subject string, req proto.Message, resp joinRequestReply) {
+	data, err := proto.Marshal(req)
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.JoinRequestResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subject, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.JoinRequestResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	if err := proto.Unmarshal(reply.Data, resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.JoinRequestResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.JoinRequestResponse{OK: resp.GetOk(), Data: toJoinRequestModel(resp.GetData())}
+	if decided, ok := resp.(*apiv1.GroupJoinDecideResponse); ok {
+		out.Member = toGroupMemberModel(decided.GetMember())
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+func toJoinRequestModel(request *apiv1.ConversationJoinRequest) *models.JoinRequest {
+	if request == nil {
+		return nil
+	}
+	return &models.JoinRequest{
+		ID:             int(request.GetId()),
+		ConversationID: int(request.GetConversationId()),
+		UserID:         request.GetUserId(),
+		Message:        request.GetMessage(),
+		Status:         request.GetStatus(),
+		DecidedBy:      request.GetDecidedBy(),
+		DecidedAt:      request.GetDecidedAt(),
+		CreatedAt:      request.GetCreatedAt(),
+	}
+}
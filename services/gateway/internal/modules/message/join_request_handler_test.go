@@ -0,0 +1,123 @@
+package message
+
+import (
+	"bytes"
+	"encoding/json"
+	"gateway/internal/common"
+	"gateway/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHandler_RequestToJoinGroup_EmptyBody(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectGroupJoinRequest {
+				t.Fatalf("expected subject %s, got %s", subjectGroupJoinRequest, subject)
+			}
+			var req apiv1.GroupJoinRequestRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetActorId() != testActorID || req.GetConversationId() != 7 || req.GetMessage() != "" {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.GroupJoinRequestResponse{
+				Ok:   true,
+				Data: &apiv1.ConversationJoinRequest{Id: 4, ConversationId: 7, UserId: testActorID, Status: "pending"},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/groups/7/join-requests", nil)
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.RequestToJoinGroup(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.JoinRequestResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if out.Data == nil || out.Data.ID != 4 || out.Data.Status != "pending" {
+		t.Fatalf("unexpected join request %+v", out.Data)
+	}
+}
+
+func TestHandler_DecideJoinRequest(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectGroupJoinDecide {
+				t.Fatalf("expected subject %s, got %s", subjectGroupJoinDecide, subject)
+			}
+			var req apiv1.GroupJoinDecideRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetConversationId() != 7 || req.GetRequestId() != 4 || !req.GetApprove() {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.GroupJoinDecideResponse{
+				Ok:     true,
+				Data:   &apiv1.ConversationJoinRequest{Id: 4, ConversationId: 7, Status: "approved", DecidedBy: testActorID},
+				Member: &apiv1.GroupMember{Id: 11, ConversationId: 7, UserId: testPeerID},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/groups/7/join-requests/4", bytes.NewBufferString(`{"approve":true}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7", "request_id": "4"})
+	w := httptest.NewRecorder()
+
+	handler.DecideJoinRequest(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.JoinRequestResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if out.Data == nil || out.Data.Status != "approved" || out.Member == nil || out.Member.UserID != testPeerID {
+		t.Fatalf("unexpected decision %+v", out)
+	}
+}
+
+func TestHandler_DecideJoinRequest_MapsConflict(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			respBytes, _ := proto.Marshal(&apiv1.GroupJoinDecideResponse{
+				Ok:    false,
+				Error: &apiv1.Error{Code: "CONFLICT", Message: "join request already decided"},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/groups/7/join-requests/4", bytes.NewBufferString(`{"approve":false}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7", "request_id": "4"})
+	w := httptest.NewRecorder()
+
+	handler.DecideJoinRequest(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", w.Code)
+	}
+}
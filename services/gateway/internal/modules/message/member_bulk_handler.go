@@ -0,0 +1,248 @@
+package message
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"gateway/internal/models"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// bulkMembersReply : forme commune des réponses GROUP_ADD_MEMBERS_BULK / GROUP_REMOVE_MEMBERS_BULK.
+type bulkMembersReply interface {
+	proto.Message
+	GetOk() bool
+	GetResults() []*apiv1.GroupMemberResult
+	GetError() *apiv1.Error
+}
+
+// TransferOwnership gère POST /api/groups/{id}/transfer-ownership (owner) : body {"user_id": "<uuid>"}.
+// Le membre désigné devient owner et l'acteur devient admin, atomiquement.
+func (h *Handler) TransferOwnership(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.TransferOwnershipResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.TransferOwnershipResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	var req models.TransferOwnershipRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, models.TransferOwnershipResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+	userID := strings.TrimSpace(req.UserID)
+	if userID == "" {
+		respondJSON(w, http.StatusBadRequest, models.TransferOwnershipResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "user_id required"},
+		})
+		return
+	}
+
+	data, err := proto.Marshal(&apiv1.GroupTransferOwnershipRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+		UserId:         userID,
+	})
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.TransferOwnershipResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectGroupTransferOwnership, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.TransferOwnershipResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.GroupTransferOwnershipResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.TransferOwnershipResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.TransferOwnershipResponse{
+		OK:            resp.GetOk(),
+		PreviousOwner: toGroupMemberModel(resp.GetPreviousOwner()),
+		NewOwner:      toGroupMemberModel(resp.GetNewOwner()),
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+// AddGroupMembersBulk gère POST /api/groups/{id}/members/bulk : body {"user_ids": [...], "role": 0}.
+// Chaque utilisateur ajouté reçoit conversation_created sur sa user room, comme AddGroupMember.
+func (h *Handler) AddGroupMembersBulk(w http.ResponseWriter, r *http.Request) {
+	conversationID, req, ok := h.decodeBulkMembersRequest(w, r)
+	if !ok {
+		return
+	}
+
+	resp := &apiv1.GroupAddMembersBulkResponse{}
+	out, ok := h.forwardBulkMembersRequest(w, subjectGroupAddMembersBulk, &apiv1.GroupAddMembersBulkRequest{
+		ActorId:        h.actorIDFromToken(r),
+		ConversationId: int32(conversationID),
+		UserIds:        req.UserIDs,
+		Role:           int32(req.Role),
+	}, resp)
+	if !ok {
+		return
+	}
+
+	if resp.GetOk() {
+		payload, _ := json.Marshal(map[string]interface{}{
+			"action":          "conversation_created",
+			"group_id":        conversationID,
+			"conversation_id": conversationID,
+			"id":              conversationID,
+		})
+		for _, result := range out.Results {
+			if result.OK {
+				_ = h.nc.Publish("message.broadcast.user:"+result.UserID, payload)
+			}
+		}
+	}
+}
+
+// RemoveGroupMembersBulk gère POST /api/groups/{id}/members/bulk-remove : body {"user_ids": [...]}.
+func (h *Handler) RemoveGroupMembersBulk(w http.ResponseWriter, r *http.Request) {
+	conversationID, req, ok := h.decodeBulkMembersRequest(w, r)
+	if !ok {
+		return
+	}
+
+	h.forwardBulkMembersRequest(w, subjectGroupRemoveMembersBulk, &apiv1.GroupRemoveMembersBulkRequest{
+		ActorId:        h.actorIDFromToken(r),
+		ConversationId: int32(conversationID),
+		UserIds:        req.UserIDs,
+	}, &apiv1.GroupRemoveMembersBulkResponse{})
+}
+
+// decodeBulkMembersRequest valide le chemin, le token et le body ; répond lui-même en cas d'erreur.
+func (h *Handler) decodeBulkMembersRequest(w http.ResponseWriter, r *http.Request) (int, models.BulkMembersRequest, bool) {
+	var req models.BulkMembersRequest
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.BulkMembersResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return 0, req, false
+	}
+	if h.actorIDFromToken(r) == "" {
+		respondJSON(w, http.StatusUnauthorized, models.BulkMembersResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return 0, req, false
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, models.BulkMembersResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return 0, req, false
+	}
+	if len(req.UserIDs) == 0 {
+		respondJSON(w, http.StatusBadRequest, models.BulkMembersResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "user_ids required"},
+		})
+		return 0, req, false
+	}
+	return conversationID, req, true
+}
+
+// forwardBulkMembersRequest relaie la requête et répond ; retourne la réponse écrite (ok = false si erreur gateway).
+func (h *Handler) forwardBulkMembersRequest(w http.ResponseWriter, subject string, req proto.Message, resp bulkMembersReply) (models.BulkMembersResponse, bool) {
+	data, err := proto.Marshal(req)
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.BulkMembersResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return models.BulkMembersResponse{}, false
+	}
+
+	reply, err := h.nc.Request(subject, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.BulkMembersResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return models.BulkMembersResponse{}, false
+	}
+
+	if err := proto.Unmarshal(reply.Data, resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.BulkMembersResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return models.BulkMembersResponse{}, false
+	}
+
+	out := models.BulkMembersResponse{OK: resp.GetOk(), Results: make([]models.MemberResult, 0, len(resp.GetResults()))}
+	for _, result := range resp.GetResults() {
+		item := models.MemberResult{
+			UserID: result.GetUserId(),
+			OK:     result.GetOk(),
+			Member: toGroupMemberModel(result.GetMember()),
+		}
+		if result.GetError() != nil {
+			item.Error = &models.SendMessageError{
+				Code:    result.GetError().GetCode(),
+				Message: result.GetError().GetMessage(),
+			}
+		}
+		out.Results = append(out.Results, item)
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+	return out, true
+}
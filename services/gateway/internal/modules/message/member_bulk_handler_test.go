@@ -0,0 +1,111 @@
+package message
+
+import (
+	"bytes"
+	"encoding/json"
+	"gateway/internal/common"
+	"gateway/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHandler_AddGroupMembersBulk(t *testing.T) {
+	var published []string
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectGroupAddMembersBulk {
+				t.Fatalf("expected subject %s, got %s", subjectGroupAddMembersBulk, subject)
+			}
+			var req apiv1.GroupAddMembersBulkRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetActorId() != testActorID || req.GetConversationId() != 7 || len(req.GetUserIds()) != 2 {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.GroupAddMembersBulkResponse{
+				Ok: true,
+				Results: []*apiv1.GroupMemberResult{
+					{UserId: testPeerID, Ok: true, Member: &apiv1.GroupMember{Id: 3, ConversationId: 7, UserId: testPeerID}},
+					{UserId: testActorID, Ok: false, Error: &apiv1.Error{Code: "CONFLICT", Message: "membership already exists"}},
+				},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+		PublishFunc: func(subject string, data []byte) error {
+			published = append(published, subject)
+			return nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	body := `{"user_ids":["` + testPeerID + `","` + testActorID + `"]}`
+	req := httptest.NewRequest("POST", "/api/groups/7/members/bulk", bytes.NewBufferString(body))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.AddGroupMembersBulk(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.BulkMembersResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if len(out.Results) != 2 || !out.Results[0].OK || out.Results[0].Member == nil || out.Results[1].OK || out.Results[1].Error.Code != "CONFLICT" {
+		t.Fatalf("unexpected results %+v", out.Results)
+	}
+	if len(published) != 1 || !strings.HasSuffix(published[0], testPeerID) {
+		t.Fatalf("expected conversation_created for the added user only, got %v", published)
+	}
+}
+
+func TestHandler_RemoveGroupMembersBulk_RequiresUserIDs(t *testing.T) {
+	handler := NewHandler(&common.MockNatsConn{})
+	req := httptest.NewRequest("POST", "/api/groups/7/members/bulk-remove", bytes.NewBufferString(`{"user_ids":[]}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.RemoveGroupMembersBulk(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestHandler_TransferOwnership_Forbidden(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectGroupTransferOwnership {
+				t.Fatalf("expected subject %s, got %s", subjectGroupTransferOwnership, subject)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.GroupTransferOwnershipResponse{
+				Ok:    false,
+				Error: &apiv1.Error{Code: "FORBIDDEN", Message: "forbidden"},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/groups/7/transfer-ownership", bytes.NewBufferString(`{"user_id":"`+testPeerID+`"}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.TransferOwnership(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d (%s)", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,439 @@
+package message
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gateway/internal/models"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// BanGroupMember gère POST /api/groups/{id}/bans (admin/owner) : body {"user_id": "<uuid>", "reason": "..."}.
+// Le banni est retiré du groupe et ne peut plus y être ajouté ni le rejoindre (invitation, demande).
+func (h *Handler) BanGroupMember(w http.ResponseWriter, r *http.Request) {
+	conversationID, actorID, ok := h.moderationContext(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.BanMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, models.ModerationResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+	if strings.TrimSpace(req.UserID) == "" {
+		respondJSON(w, http.StatusBadRequest, models.ModerationResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "user_id required"},
+		})
+		return
+	}
+
+	h.forwardModeration(w, subjectGroupBan, &apiv1.GroupModerationRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+		UserId:         strings.TrimSpace(req.UserID),
+		Reason:         req.Reason,
+	})
+}
+
+// UnbanGroupMember gère DELETE /api/groups/{id}/bans/{user_id} (admin/owner).
+func (h *Handler) UnbanGroupMember(w http.ResponseWriter, r *http.Request) {
+	conversationID, actorID, ok := h.moderationContext(w, r)
+	if !ok {
+		return
+	}
+	userID := chi.URLParam(r, "user_id")
+	if userID == "" {
+		respondJSON(w, http.StatusBadRequest, models.ModerationResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "user_id required"},
+		})
+		return
+	}
+
+	h.forwardModeration(w, subjectGroupUnban, &apiv1.GroupModerationRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+		UserId:         userID,
+	})
+}
+
+// MuteGroupMember gère POST /api/groups/{id}/mutes (admin/owner) :
+// body {"user_id": "<uuid>", "duration_seconds": 3600, "reason": "..."}. Le membre lit mais ne publie plus.
+func (h *Handler) MuteGroupMember(w http.ResponseWriter, r *http.Request) {
+	conversationID, actorID, ok := h.moderationContext(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.MuteMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, models.ModerationResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+	if strings.TrimSpace(req.UserID) == "" {
+		respondJSON(w, http.StatusBadRequest, models.ModerationResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "user_id required"},
+		})
+		return
+	}
+	if req.DurationSeconds <= 0 {
+		respondJSON(w, http.StatusBadRequest, models.ModerationResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "duration_seconds must be positive"},
+		})
+		return
+	}
+
+	h.forwardModeration(w, subjectGroupMute, &apiv1.GroupModerationRequest{
+		ActorId:         actorID,
+		ConversationId:  int32(conversationID),
+		UserId:          strings.TrimSpace(req.UserID),
+		Reason:          req.Reason,
+		DurationSeconds: req.DurationSeconds,
+	})
+}
+
+// UnmuteGroupMember gère DELETE /api/groups/{id}/mutes/{user_id} (admin/owner).
+func (h *Handler) UnmuteGroupMember(w http.ResponseWriter, r *http.Request) {
+	conversationID, actorID, ok := h.moderationContext(w, r)
+	if !ok {
+		return
+	}
+	userID := chi.URLParam(r, "user_id")
+	if userID == "" {
+		respondJSON(w, http.StatusBadRequest, models.ModerationResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "user_id required"},
+		})
+		return
+	}
+
+	h.forwardModeration(w, subjectGroupUnmute, &apiv1.GroupModerationRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+		UserId:         userID,
+	})
+}
+
+// KickGroupMember gère POST /api/groups/{id}/members/{user_id}/kick (admin/owner) : body optionnel {"reason": "..."}.
+// Contrairement à DELETE /members/{user_id}, l'exclusion est journalisée ; le membre peut être ré-ajouté.
+func (h *Handler) KickGroupMember(w http.ResponseWriter, r *http.Request) {
+	conversationID, actorID, ok := h.moderationContext(w, r)
+	if !ok {
+		return
+	}
+	userID := chi.URLParam(r, "user_id")
+	if userID == "" {
+		respondJSON(w, http.StatusBadRequest, models.ModerationResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "user_id required"},
+		})
+		return
+	}
+
+	var req models.KickMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		respondJSON(w, http.StatusBadRequest, models.ModerationResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+
+	h.forwardModeration(w, subjectGroupKick, &apiv1.GroupModerationRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+		UserId:         userID,
+		Reason:         req.Reason,
+	})
+}
+
+// ListGroupBans gère GET /api/groups/{id}/bans (admin/owner) : plus récents d'abord.
+func (h *Handler) ListGroupBans(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.BansResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.BansResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	data, err := proto.Marshal(&apiv1.GroupBanListRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+	})
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.BansResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectGroupBanList, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.BansResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.GroupBanListResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.BansResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.BansResponse{OK: resp.GetOk(), Data: make([]models.GroupBan, 0, len(resp.GetData()))}
+	for _, item := range resp.GetData() {
+		if mapped := toGroupBanModel(item); mapped != nil {
+			out.Data = append(out.Data, *mapped)
+		}
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+// GetGroupAuditLog gère GET /api/groups/{id}/audit-log?before_id=&limit= (admin/owner).
+// Plus récent d'abord ; before_id = id de la dernière entrée reçue pour la page suivante.
+func (h *Handler) GetGroupAuditLog(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.AuditLogResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.AuditLogResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	var beforeID, limit int64
+	if raw := r.URL.Query().Get("before_id"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil || parsed < 0 {
+			respondJSON(w, http.StatusBadRequest, models.AuditLogResponse{
+				OK:    false,
+				Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid before_id"},
+			})
+			return
+		}
+		beforeID = parsed
+	}
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil || parsed < 0 {
+			respondJSON(w, http.StatusBadRequest, models.AuditLogResponse{
+				OK:    false,
+				Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid limit"},
+			})
+			return
+		}
+		limit = parsed
+	}
+
+	data, err := proto.Marshal(&apiv1.GroupAuditLogRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+		BeforeId:       int32(beforeID),
+		Limit:          int32(limit),
+	})
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.AuditLogResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectGroupAuditLog, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.AuditLogResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.GroupAuditLogResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.AuditLogResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.AuditLogResponse{OK: resp.GetOk(), Data: make([]models.AuditLogEntry, 0, len(resp.GetData()))}
+	for _, item := range resp.GetData() {
+		if mapped := toAuditLogEntryModel(item); mapped != nil {
+			out.Data = append(out.Data, *mapped)
+		}
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+// moderationContext lit l'id du groupe et l'acteur ; false si une erreur a déjà été répondue.
+func (h *Handler) moderationContext(w http.ResponseWriter, r *http.Request) (int, string, bool) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.ModerationResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return 0, "", false
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.ModerationResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return 0, "", false
+	}
+	return conversationID, actorID, true
+}
+
+func (h *Handler) forwardModeration(w http.ResponseWriter, subject string, req *apiv1.GroupModerationRequest) {
+	data, err := proto.Marshal(req)
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.ModerationResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subject, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ModerationResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.GroupModerationResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ModerationResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.ModerationResponse{
+		OK:   resp.GetOk(),
+		Ban:  toGroupBanModel(resp.GetBan()),
+		Mute: toGroupMuteModel(resp.GetMute()),
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+func toGroupBanModel(ban *apiv1.GroupBan) *models.GroupBan {
+	if ban == nil {
+		return nil
+	}
+	return &models.GroupBan{
+		ConversationID: int(ban.GetConversationId()),
+		UserID:         ban.GetUserId(),
+		BannedBy:       ban.GetBannedBy(),
+		Reason:         ban.GetReason(),
+		CreatedAt:      ban.GetCreatedAt(),
+	}
+}
+
+func toGroupMuteModel(mute *apiv1.GroupMute) *models.GroupMute {
+	if mute == nil {
+		return nil
+	}
+	return &models.GroupMute{
+		ConversationID: int(mute.GetConversationId()),
+		UserID:         mute.GetUserId(),
+		MutedBy:        mute.GetMutedBy(),
+		MutedUntil:     mute.GetMutedUntil(),
+		CreatedAt:      mute.GetCreatedAt(),
+	}
+}
+
+func toAuditLogEntryModel(entry *apiv1.AuditLogEntry) *models.AuditLogEntry {
+	if entry == nil {
+		return nil
+	}
+	return &models.AuditLogEntry{
+		ID:             int(entry.GetId()),
+		ConversationID: int(entry.GetConversationId()),
+		ActorID:        entry.GetActorId(),
+		Action:         entry.GetAction(),
+		TargetID:       entry.GetTargetId(),
+		Reason:         entry.GetReason(),
+		ExpiresAt:      entry.GetExpiresAt(),
+		CreatedAt:      entry.GetCreatedAt(),
+	}
+}
@@ -0,0 +1,234 @@
+package message
+
+import (
+	"bytes"
+	"encoding/json"
+	"gateway/internal/common"
+	"gateway/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHandler_BanGroupMember(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectGroupBan {
+				t.Fatalf("expected subject %s, got %s", subjectGroupBan, subject)
+			}
+			var req apiv1.GroupModerationRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetActorId() != testActorID || req.GetConversationId() != 7 || req.GetUserId() != testPeerID || req.GetReason() != "spam" {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.GroupModerationResponse{
+				Ok:  true,
+				Ban: &apiv1.GroupBan{ConversationId: 7, UserId: testPeerID, BannedBy: testActorID, Reason: "spam"},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	body, _ := json.Marshal(models.BanMemberRequest{UserID: testPeerID, Reason: "spam"})
+	req := httptest.NewRequest("POST", "/api/groups/7/bans", bytes.NewReader(body))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.BanGroupMember(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.ModerationResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if out.Ban == nil || out.Ban.UserID != testPeerID || out.Mute != nil {
+		t.Fatalf("unexpected response %+v", out)
+	}
+}
+
+func TestHandler_BanGroupMember_AlreadyBanned(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			respBytes, _ := proto.Marshal(&apiv1.GroupModerationResponse{
+				Ok:    false,
+				Error: &apiv1.Error{Code: "CONFLICT", Message: "user is already banned"},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/groups/7/bans", bytes.NewReader([]byte(`{"user_id":"`+testPeerID+`"}`)))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.BanGroupMember(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d (%s)", w.Code, w.Body.String())
+	}
+}
+
+func TestHandler_MuteGroupMember_RequiresDuration(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			t.Fatalf("no request expected, got %s", subject)
+			return nil, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/groups/7/mutes", bytes.NewReader([]byte(`{"user_id":"`+testPeerID+`"}`)))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.MuteGroupMember(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d (%s)", w.Code, w.Body.String())
+	}
+}
+
+func TestHandler_MuteGroupMember(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectGroupMute {
+				t.Fatalf("expected subject %s, got %s", subjectGroupMute, subject)
+			}
+			var req apiv1.GroupModerationRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetDurationSeconds() != 600 || req.GetUserId() != testPeerID {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.GroupModerationResponse{
+				Ok:   true,
+				Mute: &apiv1.GroupMute{ConversationId: 7, UserId: testPeerID, MutedBy: testActorID, MutedUntil: 1700000600},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	body, _ := json.Marshal(models.MuteMemberRequest{UserID: testPeerID, DurationSeconds: 600})
+	req := httptest.NewRequest("POST", "/api/groups/7/mutes", bytes.NewReader(body))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.MuteGroupMember(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.ModerationResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if out.Mute == nil || out.Mute.MutedUntil != 1700000600 {
+		t.Fatalf("unexpected response %+v", out)
+	}
+}
+
+func TestHandler_KickGroupMember_WithoutBody(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectGroupKick {
+				t.Fatalf("expected subject %s, got %s", subjectGroupKick, subject)
+			}
+			var req apiv1.GroupModerationRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetUserId() != testPeerID || req.GetReason() != "" {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.GroupModerationResponse{Ok: true})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/groups/7/members/"+testPeerID+"/kick", nil)
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7", "user_id": testPeerID})
+	w := httptest.NewRecorder()
+
+	handler.KickGroupMember(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+}
+
+func TestHandler_GetGroupAuditLog(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectGroupAuditLog {
+				t.Fatalf("expected subject %s, got %s", subjectGroupAuditLog, subject)
+			}
+			var req apiv1.GroupAuditLogRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetConversationId() != 7 || req.GetBeforeId() != 20 || req.GetLimit() != 10 {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.GroupAuditLogResponse{
+				Ok: true,
+				Data: []*apiv1.AuditLogEntry{
+					{Id: 19, ConversationId: 7, ActorId: testActorID, Action: "mute", TargetId: testPeerID, ExpiresAt: 1700000600},
+					{Id: 18, ConversationId: 7, ActorId: testActorID, Action: "kick", TargetId: testPeerID, Reason: "spam"},
+				},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("GET", "/api/groups/7/audit-log?before_id=20&limit=10", nil)
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.GetGroupAuditLog(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.AuditLogResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if len(out.Data) != 2 || out.Data[0].Action != "mute" || out.Data[0].ExpiresAt != 1700000600 || out.Data[1].Reason != "spam" {
+		t.Fatalf("unexpected audit log %+v", out.Data)
+	}
+}
+
+func TestHandler_GetGroupAuditLog_InvalidBeforeID(t *testing.T) {
+	handler := NewHandler(&common.MockNatsConn{})
+	req := httptest.NewRequest("GET", "/api/groups/7/audit-log?before_id=abc", nil)
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.GetGroupAuditLog(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d (%s)", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,122 @@
+package message
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"gateway/internal/models"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// UpdateGroupPermissions gère PATCH /api/groups/{id}/permissions (owner) :
+// body {"only_admins_can_post": true, "members_can_add_members": false, "members_can_pin": true, "slow_mode_seconds": 30,
+// "join_requests_enabled": true}.
+// Seuls les champs présents sont modifiés ; le message-service diffuse permissions_updated.
+func (h *Handler) UpdateGroupPermissions(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	var req models.UpdateGroupPermissionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+
+	protoReq := &apiv1.GroupUpdatePermissionsRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+		Permissions:    &apiv1.GroupPermissions{},
+	}
+	if req.OnlyAdminsCanPost != nil {
+		protoReq.Permissions.OnlyAdminsCanPost = *req.OnlyAdminsCanPost
+		protoReq.UpdateMask = append(protoReq.UpdateMask, "only_admins_can_post")
+	}
+	if req.MembersCanAddMembers != nil {
+		protoReq.Permissions.MembersCanAddMembers = *req.MembersCanAddMembers
+		protoReq.UpdateMask = append(protoReq.UpdateMask, "members_can_add_members")
+	}
+	if req.MembersCanPin != nil {
+		protoReq.Permissions.MembersCanPin = *req.MembersCanPin
+		protoReq.UpdateMask = append(protoReq.UpdateMask, "members_can_pin")
+	}
+	if req.SlowModeSeconds != nil {
+		protoReq.Permissions.SlowModeSeconds = int32(*req.SlowModeSeconds)
+		protoReq.UpdateMask = append(protoReq.UpdateMask, "slow_mode_seconds")
+	}
+	if req.JoinRequestsEnabled != nil {
+		protoReq.Permissions.JoinRequestsEnabled = *req.JoinRequestsEnabled
+		protoReq.UpdateMask = append(protoReq.UpdateMask, "join_requests_enabled")
+	}
+	if len(protoReq.UpdateMask) == 0 {
+		respondJSON(w, http.StatusBadRequest, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "at least one permission required"},
+		})
+		return
+	}
+
+	data, err := proto.Marshal(protoReq)
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectGroupUpdatePermissions, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.GroupUpdatePermissionsResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.GroupResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.GroupResponse{OK: resp.GetOk()}
+	if resp.GetData() != nil {
+		out.Data = toGroupModel(resp.GetData())
+		h.resolveGroupDisplayName(out.Data, actorID)
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
@@ -0,0 +1,106 @@
+package message
+
+import (
+	"bytes"
+	"encoding/json"
+	"gateway/internal/common"
+	"gateway/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHandler_UpdateGroupPermissions(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectGroupUpdatePermissions {
+				t.Fatalf("expected subject %s, got %s", subjectGroupUpdatePermissions, subject)
+			}
+			var req apiv1.GroupUpdatePermissionsRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if len(req.GetUpdateMask()) != 2 || req.GetUpdateMask()[0] != "only_admins_can_post" || req.GetUpdateMask()[1] != "slow_mode_seconds" {
+				t.Fatalf("unexpected update_mask %v", req.GetUpdateMask())
+			}
+			if !req.GetPermissions().GetOnlyAdminsCanPost() || req.GetPermissions().GetSlowModeSeconds() != 30 {
+				t.Fatalf("unexpected permissions %+v", req.GetPermissions())
+			}
+			respBytes, _ := proto.Marshal(&apiv1.GroupUpdatePermissionsResponse{
+				Ok: true,
+				Data: &apiv1.Group{
+					Id:          7,
+					Name:        "Annonces",
+					Kind:        "group",
+					Permissions: &apiv1.GroupPermissions{OnlyAdminsCanPost: true, SlowModeSeconds: 30},
+				},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("PATCH", "/api/groups/7/permissions", bytes.NewBufferString(`{"only_admins_can_post":true,"slow_mode_seconds":30}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.UpdateGroupPermissions(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.GroupResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if out.Data == nil || out.Data.Permissions == nil || !out.Data.Permissions.OnlyAdminsCanPost || out.Data.Permissions.SlowModeSeconds != 30 {
+		t.Fatalf("unexpected response %+v", out.Data)
+	}
+}
+
+func TestHandler_UpdateGroupPermissions_RequiresField(t *testing.T) {
+	handler := NewHandler(&common.MockNatsConn{})
+	req := httptest.NewRequest("PATCH", "/api/groups/7/permissions", bytes.NewBufferString(`{}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.UpdateGroupPermissions(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestHandler_Send_SlowModeIs429(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			respBytes, _ := proto.Marshal(&apiv1.SendMessageResponse{
+				Ok:    false,
+				Error: &apiv1.Error{Code: "RATE_LIMITED", Message: "slow mode: wait before sending another message (retry in 12s)"},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+		PublishFunc: func(subject string, data []byte) error {
+			t.Fatalf("unexpected publish on %s", subject)
+			return nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/messages", bytes.NewBufferString(`{"conversation_id":7,"content":"hello"}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	w := httptest.NewRecorder()
+
+	handler.Send(w, req)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d (%s)", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,249 @@
+package message
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"gateway/internal/models"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// PinMessage gère POST /api/groups/{id}/pins (admin/owner) : body {"message_id": ...}.
+func (h *Handler) PinMessage(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.MessagePinResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.MessagePinResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	var req models.PinMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, models.MessagePinResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+	if req.MessageID <= 0 {
+		respondJSON(w, http.StatusBadRequest, models.MessagePinResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "message_id required"},
+		})
+		return
+	}
+
+	data, err := proto.Marshal(&apiv1.PinMessageRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+		MessageId:      int32(req.MessageID),
+	})
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.MessagePinResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectPinMessage, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.MessagePinResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.PinMessageResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.MessagePinResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.MessagePinResponse{OK: resp.GetOk(), Data: toMessagePinModel(resp.GetData())}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+// ListPins gère GET /api/groups/{id}/pins : messages épinglés, du plus récent au plus ancien.
+func (h *Handler) ListPins(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.MessagePinsResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.MessagePinsResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	data, err := proto.Marshal(&apiv1.ListPinsRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+	})
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.MessagePinsResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectListPins, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.MessagePinsResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.ListPinsResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.MessagePinsResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.MessagePinsResponse{OK: resp.GetOk(), Data: make([]models.MessagePin, 0, len(resp.GetData()))}
+	for _, item := range resp.GetData() {
+		if mapped := toMessagePinModel(item); mapped != nil {
+			out.Data = append(out.Data, *mapped)
+		}
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+// UnpinMessage gère DELETE /api/groups/{id}/pins/{message_id} (admin/owner).
+func (h *Handler) UnpinMessage(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.MessagePinResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	messageID, err := strconv.ParseInt(chi.URLParam(r, "message_id"), 10, 32)
+	if err != nil || messageID <= 0 {
+		respondJSON(w, http.StatusBadRequest, models.MessagePinResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid message_id"},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.MessagePinResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	data, err := proto.Marshal(&apiv1.UnpinMessageRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+		MessageId:      int32(messageID),
+	})
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.MessagePinResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectUnpinMessage, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.MessagePinResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.UnpinMessageResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.MessagePinResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.MessagePinResponse{OK: resp.GetOk()}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+func toMessagePinModel(pin *apiv1.MessagePin) *models.MessagePin {
+	if pin == nil {
+		return nil
+	}
+	return &models.MessagePin{
+		ConversationID: int(pin.GetConversationId()),
+		MessageID:      int(pin.GetMessageId()),
+		PinnedBy:       pin.GetPinnedBy(),
+		PinnedAt:       pin.GetPinnedAt(),
+		Message:        toSendMessageData(pin.GetMessage()),
+	}
+}
@@ -0,0 +1,144 @@
+package message
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"gateway/internal/common"
+	"gateway/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/go-chi/chi/v5"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func withURLParams(req *http.Request, params map[string]string) *http.Request {
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHandler_PinMessage(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectPinMessage {
+				t.Fatalf("expected subject %s, got %s", subjectPinMessage, subject)
+			}
+			var req apiv1.PinMessageRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetActorId() != testActorID || req.GetConversationId() != 7 || req.GetMessageId() != 42 {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.PinMessageResponse{
+				Ok:   true,
+				Data: &apiv1.MessagePin{ConversationId: 7, MessageId: 42, PinnedBy: testActorID, PinnedAt: 1700000000},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/groups/7/pins", bytes.NewBufferString(`{"message_id":42}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.PinMessage(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.MessagePinResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if out.Data == nil || out.Data.MessageID != 42 || out.Data.PinnedBy != testActorID {
+		t.Fatalf("unexpected pin %+v", out.Data)
+	}
+}
+
+func TestHandler_PinMessage_MapsForbidden(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			respBytes, _ := proto.Marshal(&apiv1.PinMessageResponse{
+				Ok:    false,
+				Error: &apiv1.Error{Code: "FORBIDDEN", Message: "forbidden"},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/groups/7/pins", bytes.NewBufferString(`{"message_id":42}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.PinMessage(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", w.Code)
+	}
+}
+
+func TestHandler_UnpinMessage_InvalidMessageID(t *testing.T) {
+	handler := NewHandler(&common.MockNatsConn{})
+	req := httptest.NewRequest("DELETE", "/api/groups/7/pins/abc", nil)
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7", "message_id": "abc"})
+	w := httptest.NewRecorder()
+
+	handler.UnpinMessage(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestHandler_ListPins(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectListPins {
+				t.Fatalf("expected subject %s, got %s", subjectListPins, subject)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.ListPinsResponse{
+				Ok: true,
+				Data: []*apiv1.MessagePin{{
+					ConversationId: 7,
+					MessageId:      42,
+					PinnedBy:       testActorID,
+					Message:        &apiv1.ChatMessage{Id: 42, ConversationId: 7, Content: "important"},
+				}},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("GET", "/api/groups/7/pins", nil)
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.ListPins(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.MessagePinsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if len(out.Data) != 1 || out.Data[0].Message == nil || out.Data[0].Message.Content != "important" {
+		t.Fatalf("unexpected pins %+v", out.Data)
+	}
+}
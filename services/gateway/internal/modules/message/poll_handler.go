@@ -0,0 +1,221 @@
+package message
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"gateway/internal/models"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// pollReply : forme commune des réponses POLL_* (ok, data, error).
+type pollReply interface {
+	proto.Message
+	GetOk() bool
+	GetData() *apiv1.Poll
+	GetError() *apiv1.Error
+}
+
+// CreatePoll gère POST /api/polls : crée le message sondage dans la conversation (membres).
+func (h *Handler) CreatePoll(w http.ResponseWriter, r *http.Request) {
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.PollResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	var req models.CreatePollRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, models.PollResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+	if req.ConversationID <= 0 {
+		respondJSON(w, http.StatusBadRequest, models.PollResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "conversation_id required"},
+		})
+		return
+	}
+
+	h.forwardPollRequest(w, subjectPollCreate, &apiv1.PollCreateRequest{
+		ActorId:        actorID,
+		ConversationId: int32(req.ConversationID),
+		Question:       req.Question,
+		Options:        req.Options,
+		MultiChoice:    req.MultiChoice,
+		Anonymous:      req.Anonymous,
+		ClosesAt:       req.ClosesAt,
+	}, &apiv1.PollCreateResponse{})
+}
+
+// GetPoll gère GET /api/polls/{id} : sondage et décompte courant (membres).
+func (h *Handler) GetPoll(w http.ResponseWriter, r *http.Request) {
+	pollID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.PollResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.PollResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	h.forwardPollRequest(w, subjectPollGet, &apiv1.PollGetRequest{
+		ActorId: actorID,
+		PollId:  int32(pollID),
+	}, &apiv1.PollGetResponse{})
+}
+
+// VotePoll gère POST /api/polls/{id}/vote : body {"option_ids": [...]} ; remplace le vote précédent.
+func (h *Handler) VotePoll(w http.ResponseWriter, r *http.Request) {
+	pollID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.PollResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.PollResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	var req models.VotePollRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, models.PollResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+	optionIDs := make([]int32, 0, len(req.OptionIDs))
+	for _, id := range req.OptionIDs {
+		optionIDs = append(optionIDs, int32(id))
+	}
+
+	h.forwardPollRequest(w, subjectPollVote, &apiv1.PollVoteRequest{
+		ActorId:   actorID,
+		PollId:    int32(pollID),
+		OptionIds: optionIDs,
+	}, &apiv1.PollVoteResponse{})
+}
+
+// ClosePoll gère POST /api/polls/{id}/close (auteur du sondage ou admin/owner).
+func (h *Handler) ClosePoll(w http.ResponseWriter, r *http.Request) {
+	pollID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.PollResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.PollResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	h.forwardPollRequest(w, subjectPollClose, &apiv1.PollCloseRequest{
+		ActorId: actorID,
+		PollId:  int32(pollID),
+	}, &apiv1.PollCloseResponse{})
+}
+
+func (h *Handler) forwardPollRequest(w http.ResponseWriter, subject string, req proto.Message, resp pollReply) {
+	data, err := proto.Marshal(req)
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.PollResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subject, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.PollResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	if err := proto.Unmarshal(reply.Data, resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.PollResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.PollResponse{OK: resp.GetOk(), Data: toPollModel(resp.GetData())}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+func toPollModel(poll *apiv1.Poll) *models.Poll {
+	if poll == nil {
+		return nil
+	}
+	out := &models.Poll{
+		ID:             int(poll.GetId()),
+		MessageID:      int(poll.GetMessageId()),
+		ConversationID: int(poll.GetConversationId()),
+		CreatedBy:      poll.GetCreatedBy(),
+		Question:       poll.GetQuestion(),
+		MultiChoice:    poll.GetMultiChoice(),
+		Anonymous:      poll.GetAnonymous(),
+		ClosesAt:       poll.GetClosesAt(),
+		ClosedAt:       poll.GetClosedAt(),
+		Closed:         poll.GetClosed(),
+		CreatedAt:      poll.GetCreatedAt(),
+		Options:        make([]models.PollOption, 0, len(poll.GetOptions())),
+		TotalVoters:    int(poll.GetTotalVoters()),
+	}
+	for _, option := range poll.GetOptions() {
+		out.Options = append(out.Options, models.PollOption{
+			ID:        int(option.GetId()),
+			Label:     option.GetLabel(),
+			VoteCount: int(option.GetVoteCount()),
+			VoterIDs:  option.GetVoterIds(),
+		})
+	}
+	for _, id := range poll.GetMyOptionIds() {
+		out.MyOptionIDs = append(out.MyOptionIDs, int(id))
+	}
+	return out
+}
@@ -0,0 +1,163 @@
+package message
+
+import (
+	"bytes"
+	"encoding/json"
+	"gateway/internal/common"
+	"gateway/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHandler_CreatePoll(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectPollCreate {
+				t.Fatalf("expected subject %s, got %s", subjectPollCreate, subject)
+			}
+			var req apiv1.PollCreateRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetActorId() != testActorID || req.GetConversationId() != 7 || len(req.GetOptions()) != 2 || !req.GetMultiChoice() {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.PollCreateResponse{
+				Ok: true,
+				Data: &apiv1.Poll{
+					Id:             3,
+					MessageId:      42,
+					ConversationId: 7,
+					Question:       req.GetQuestion(),
+					MultiChoice:    true,
+					Options: []*apiv1.PollOption{
+						{Id: 10, Label: "Oui"},
+						{Id: 11, Label: "Non"},
+					},
+				},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	body := `{"conversation_id":7,"question":"Réunion ?","options":["Oui","Non"],"multi_choice":true}`
+	req := httptest.NewRequest("POST", "/api/polls", bytes.NewBufferString(body))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	w := httptest.NewRecorder()
+
+	handler.CreatePoll(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.PollResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if out.Data == nil || out.Data.ID != 3 || out.Data.MessageID != 42 || len(out.Data.Options) != 2 {
+		t.Fatalf("unexpected poll %+v", out.Data)
+	}
+}
+
+func TestHandler_CreatePoll_RequiresConversation(t *testing.T) {
+	handler := NewHandler(&common.MockNatsConn{})
+	req := httptest.NewRequest("POST", "/api/polls", bytes.NewBufferString(`{"question":"Q ?","options":["a","b"]}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	w := httptest.NewRecorder()
+
+	handler.CreatePoll(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestHandler_VotePoll(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectPollVote {
+				t.Fatalf("expected subject %s, got %s", subjectPollVote, subject)
+			}
+			var req apiv1.PollVoteRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetPollId() != 3 || len(req.GetOptionIds()) != 1 || req.GetOptionIds()[0] != 11 {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.PollVoteResponse{
+				Ok: true,
+				Data: &apiv1.Poll{
+					Id:          3,
+					Options:     []*apiv1.PollOption{{Id: 10}, {Id: 11, VoteCount: 1}},
+					TotalVoters: 1,
+					MyOptionIds: []int32{11},
+				},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/polls/3/vote", bytes.NewBufferString(`{"option_ids":[11]}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "3"})
+	w := httptest.NewRecorder()
+
+	handler.VotePoll(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.PollResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if out.Data == nil || out.Data.TotalVoters != 1 || len(out.Data.MyOptionIDs) != 1 || out.Data.MyOptionIDs[0] != 11 {
+		t.Fatalf("unexpected poll %+v", out.Data)
+	}
+}
+
+func TestHandler_VotePoll_MapsConflictWhenClosed(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			respBytes, _ := proto.Marshal(&apiv1.PollVoteResponse{
+				Ok:    false,
+				Error: &apiv1.Error{Code: "CONFLICT", Message: "poll is closed"},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/polls/3/vote", bytes.NewBufferString(`{"option_ids":[10]}`))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "3"})
+	w := httptest.NewRecorder()
+
+	handler.VotePoll(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", w.Code)
+	}
+}
+
+func TestHandler_ClosePoll_RequiresAuth(t *testing.T) {
+	handler := NewHandler(&common.MockNatsConn{})
+	req := httptest.NewRequest("POST", "/api/polls/3/close", nil)
+	req = withURLParams(req, map[string]string{"id": "3"})
+	w := httptest.NewRecorder()
+
+	handler.ClosePoll(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", w.Code)
+	}
+}
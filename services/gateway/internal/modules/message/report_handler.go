@@ -0,0 +1,238 @@
+package message
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gateway/internal/models"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// reportReply : forme commune des réponses MESSAGE_REPORT / MESSAGE_REPORT_RESOLVE.
+type reportReply interface {
+	proto.Message
+	GetOk() bool
+	GetData() *apiv1.MessageReport
+	GetError() *apiv1.Error
+}
+
+// ReportMessage gère POST /api/messages/{id}/report : body {"reason": "..."}.
+// Les admins/owners de la conversation sont notifiés par le message-service.
+func (h *Handler) ReportMessage(w http.ResponseWriter, r *http.Request) {
+	messageID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+	if err != nil || messageID <= 0 {
+		respondJSON(w, http.StatusBadRequest, models.ReportResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.ReportResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	var req models.ReportMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, models.ReportResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		respondJSON(w, http.StatusBadRequest, models.ReportResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "reason required"},
+		})
+		return
+	}
+
+	h.forwardReport(w, subjectMessageReport, &apiv1.MessageReportRequest{
+		ActorId:   actorID,
+		MessageId: int32(messageID),
+		Reason:    req.Reason,
+	}, &apiv1.MessageReportResponse{})
+}
+
+// ListReports gère GET /api/groups/{id}/reports?status=pending|resolved|dismissed (admin/owner).
+func (h *Handler) ListReports(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.ReportsResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.ReportsResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	data, err := proto.Marshal(&apiv1.MessageReportListRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+		Status:         r.URL.Query().Get("status"),
+	})
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.ReportsResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectMessageReportList, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ReportsResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.MessageReportListResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ReportsResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.ReportsResponse{OK: resp.GetOk(), Data: make([]models.MessageReport, 0, len(resp.GetData()))}
+	for _, item := range resp.GetData() {
+		if mapped := toMessageReportModel(item); mapped != nil {
+			out.Data = append(out.Data, *mapped)
+		}
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+// ResolveReport gère POST /api/groups/{id}/reports/{report_id} (admin/owner) : body {"status": "resolved"|"dismissed"}.
+func (h *Handler) ResolveReport(w http.ResponseWriter, r *http.Request) {
+	conversationID, ok := groupIDFromPath(r)
+	if !ok {
+		respondJSON(w, http.StatusBadRequest, models.ReportResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	reportID, err := strconv.ParseInt(chi.URLParam(r, "report_id"), 10, 32)
+	if err != nil || reportID <= 0 {
+		respondJSON(w, http.StatusBadRequest, models.ReportResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid report_id"},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.ReportResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	var req models.ResolveReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, models.ReportResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+
+	h.forwardReport(w, subjectMessageReportResolve, &apiv1.MessageReportResolveRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+		ReportId:       int32(reportID),
+		Status:         req.Status,
+	}, &apiv1.MessageReportResolveResponse{})
+}
+
+func (h *Handler) forwardReport(w http.ResponseWriter, subject string, req proto.Message, resp reportReply) {
+	data, err := proto.Marshal(req)
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.ReportResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subject, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ReportResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	if err := proto.Unmarshal(reply.Data, resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ReportResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.ReportResponse{OK: resp.GetOk(), Data: toMessageReportModel(resp.GetData())}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+func toMessageReportModel(report *apiv1.MessageReport) *models.MessageReport {
+	if report == nil {
+		return nil
+	}
+	return &models.MessageReport{
+		ID:             int(report.GetId()),
+		MessageID:      int(report.GetMessageId()),
+		ConversationID: int(report.GetConversationId()),
+		ReporterID:     report.GetReporterId(),
+		Reason:         report.GetReason(),
+		Status:         report.GetStatus(),
+		ResolvedBy:     report.GetResolvedBy(),
+		ResolvedAt:     report.GetResolvedAt(),
+		CreatedAt:      report.GetCreatedAt(),
+	}
+}
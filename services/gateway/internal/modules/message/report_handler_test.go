@@ -0,0 +1,157 @@
+package message
+
+import (
+	"bytes"
+	"encoding/json"
+	"gateway/internal/common"
+	"gateway/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHandler_ReportMessage(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectMessageReport {
+				t.Fatalf("expected subject %s, got %s", subjectMessageReport, subject)
+			}
+			var req apiv1.MessageReportRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetActorId() != testActorID || req.GetMessageId() != 42 || req.GetReason() != "spam" {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.MessageReportResponse{
+				Ok:   true,
+				Data: &apiv1.MessageReport{Id: 3, MessageId: 42, ConversationId: 7, ReporterId: testActorID, Reason: "spam", Status: "pending"},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	body, _ := json.Marshal(models.ReportMessageRequest{Reason: "spam"})
+	req := httptest.NewRequest("POST", "/api/messages/42/report", bytes.NewReader(body))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "42"})
+	w := httptest.NewRecorder()
+
+	handler.ReportMessage(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.ReportResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if out.Data == nil || out.Data.ID != 3 || out.Data.Status != "pending" {
+		t.Fatalf("unexpected report %+v", out.Data)
+	}
+}
+
+func TestHandler_ReportMessage_Duplicate(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			respBytes, _ := proto.Marshal(&apiv1.MessageReportResponse{
+				Ok:    false,
+				Error: &apiv1.Error{Code: "CONFLICT", Message: "message already reported"},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/messages/42/report", bytes.NewReader([]byte(`{"reason":"spam"}`)))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "42"})
+	w := httptest.NewRecorder()
+
+	handler.ReportMessage(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d (%s)", w.Code, w.Body.String())
+	}
+}
+
+func TestHandler_ListReports_ForwardsStatus(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectMessageReportList {
+				t.Fatalf("expected subject %s, got %s", subjectMessageReportList, subject)
+			}
+			var req apiv1.MessageReportListRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetConversationId() != 7 || req.GetStatus() != "resolved" {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.MessageReportListResponse{
+				Ok:   true,
+				Data: []*apiv1.MessageReport{{Id: 3, ConversationId: 7, Status: "resolved", ResolvedBy: testActorID}},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("GET", "/api/groups/7/reports?status=resolved", nil)
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7"})
+	w := httptest.NewRecorder()
+
+	handler.ListReports(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+	var out models.ReportsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if len(out.Data) != 1 || out.Data[0].ResolvedBy != testActorID {
+		t.Fatalf("unexpected reports %+v", out.Data)
+	}
+}
+
+func TestHandler_ResolveReport_Forbidden(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectMessageReportResolve {
+				t.Fatalf("expected subject %s, got %s", subjectMessageReportResolve, subject)
+			}
+			var req apiv1.MessageReportResolveRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetConversationId() != 7 || req.GetReportId() != 3 || req.GetStatus() != "dismissed" {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.MessageReportResolveResponse{
+				Ok:    false,
+				Error: &apiv1.Error{Code: "FORBIDDEN", Message: "forbidden"},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("POST", "/api/groups/7/reports/3", bytes.NewReader([]byte(`{"status":"dismissed"}`)))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	req = withURLParams(req, map[string]string{"id": "7", "report_id": "3"})
+	w := httptest.NewRecorder()
+
+	handler.ResolveReport(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d (%s)", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,239 @@
+package message
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"gateway/internal/models"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// ScheduleMessage gère POST /api/messages/scheduled : le message est envoyé par le message-service à send_at.
+func (h *Handler) ScheduleMessage(w http.ResponseWriter, r *http.Request) {
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.ScheduledMessageResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	var req models.ScheduleMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondJSON(w, http.StatusBadRequest, models.ScheduledMessageResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "invalid JSON"},
+		})
+		return
+	}
+	conversationID := resolveConversationID(req.ConversationID, req.GroupID)
+	if conversationID == 0 {
+		respondJSON(w, http.StatusBadRequest, models.ScheduledMessageResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: "conversation_id (or legacy group_id) required"},
+		})
+		return
+	}
+
+	protoReq := &apiv1.ScheduleMessageRequest{
+		SenderId:       actorID,
+		ConversationId: int32(conversationID),
+		Content:        req.Content,
+		Attachment:     req.Attachment,
+		SendAt:         req.SendAt,
+	}
+	if req.ReplyToID != nil && *req.ReplyToID > 0 {
+		protoReq.ReplyToId = int32(*req.ReplyToID)
+	}
+	data, err := proto.Marshal(protoReq)
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.ScheduledMessageResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectScheduleMessage, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ScheduledMessageResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.ScheduleMessageResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ScheduledMessageResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.ScheduledMessageResponse{OK: resp.GetOk(), Data: toScheduledMessageModel(resp.GetData())}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+// ListScheduledMessages gère GET /api/messages/scheduled[?conversation_id=] : messages programmés de l'utilisateur.
+func (h *Handler) ListScheduledMessages(w http.ResponseWriter, r *http.Request) {
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.ScheduledMessagesResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+	conversationID, _ := queryConversationID(r)
+
+	data, err := proto.Marshal(&apiv1.ListScheduledMessagesRequest{
+		ActorId:        actorID,
+		ConversationId: int32(conversationID),
+	})
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.ScheduledMessagesResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectListScheduledMessages, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ScheduledMessagesResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.ListScheduledMessagesResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ScheduledMessagesResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.ScheduledMessagesResponse{OK: resp.GetOk(), Data: []models.ScheduledMessage{}}
+	for _, item := range resp.GetData() {
+		if mapped := toScheduledMessageModel(item); mapped != nil {
+			out.Data = append(out.Data, *mapped)
+		}
+	}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusUnprocessableEntity)
+	}
+	respondJSON(w, status, out)
+}
+
+// CancelScheduledMessage gère DELETE /api/messages/scheduled/{id} (auteur uniquement, message encore pending).
+func (h *Handler) CancelScheduledMessage(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+	if err != nil || id <= 0 {
+		respondJSON(w, http.StatusBadRequest, models.ScheduledMessageResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "BAD_REQUEST", Message: invalidId},
+		})
+		return
+	}
+	actorID := h.actorIDFromToken(r)
+	if actorID == "" {
+		respondJSON(w, http.StatusUnauthorized, models.ScheduledMessageResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "UNAUTHORIZED", Message: "invalid or missing token"},
+		})
+		return
+	}
+
+	data, err := proto.Marshal(&apiv1.CancelScheduledMessageRequest{
+		Id:      int32(id),
+		ActorId: actorID,
+	})
+	if err != nil {
+		respondJSON(w, http.StatusInternalServerError, models.ScheduledMessageResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "INTERNAL", Message: err.Error()},
+		})
+		return
+	}
+
+	reply, err := h.nc.Request(subjectCancelScheduledMessage, data, requestTimeout)
+	if err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ScheduledMessageResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "message-service unreachable: " + err.Error()},
+		})
+		return
+	}
+
+	var resp apiv1.CancelScheduledMessageResponse
+	if err := proto.Unmarshal(reply.Data, &resp); err != nil {
+		respondJSON(w, http.StatusBadGateway, models.ScheduledMessageResponse{
+			OK:    false,
+			Error: &models.SendMessageError{Code: "GATEWAY_ERROR", Message: "invalid response from message-service"},
+		})
+		return
+	}
+
+	out := models.ScheduledMessageResponse{OK: resp.GetOk(), Data: toScheduledMessageModel(resp.GetData())}
+	if resp.GetError() != nil {
+		out.Error = &models.SendMessageError{
+			Code:    resp.GetError().GetCode(),
+			Message: resp.GetError().GetMessage(),
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.GetOk() && resp.GetError() != nil {
+		status = statusFromServiceCode(resp.GetError().GetCode(), http.StatusNotFound)
+	}
+	respondJSON(w, status, out)
+}
+
+func toScheduledMessageModel(m *apiv1.ScheduledMessage) *models.ScheduledMessage {
+	if m == nil {
+		return nil
+	}
+	return &models.ScheduledMessage{
+		ID:             int(m.GetId()),
+		SenderID:       m.GetSenderId(),
+		ConversationID: int(m.GetConversationId()),
+		Content:        m.GetContent(),
+		Attachment:     m.GetAttachment(),
+		ReplyToID:      int(m.GetReplyToId()),
+		SendAt:         m.GetSendAt(),
+		Status:         m.GetStatus(),
+		MessageID:      int(m.GetMessageId()),
+		LastError:      m.GetLastError(),
+		CreatedAt:      m.GetCreatedAt(),
+		UpdatedAt:      m.GetUpdatedAt(),
+	}
+}
@@ -0,0 +1,109 @@
+package message
+
+import (
+	"bytes"
+	"context"
+	"gateway/internal/common"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+const testActorID = "a0000001-0000-0000-0000-000000000001"
+
+// bearerToken signe un JWT avec le secret par défaut du gateway (JWT_SECRET non défini en test).
+func bearerToken(t *testing.T, userID string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":      userID,
+		"username": "tester",
+		"exp":      time.Now().Add(time.Hour).Unix(),
+	})
+	signed, err := token.SignedString([]byte("storm-secret-key"))
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	return "Bearer " + signed
+}
+
+func TestHandler_ScheduleMessage(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			if subject != subjectScheduleMessage {
+				t.Fatalf("expected subject %s, got %s", subjectScheduleMessage, subject)
+			}
+			var req apiv1.ScheduleMessageRequest
+			if err := proto.Unmarshal(data, &req); err != nil {
+				t.Fatalf("invalid request payload: %v", err)
+			}
+			if req.GetSenderId() != testActorID {
+				t.Fatalf("sender_id must come from the token, got %q", req.GetSenderId())
+			}
+			if req.GetConversationId() != 7 || req.GetSendAt() != 1900000000 {
+				t.Fatalf("unexpected request %+v", &req)
+			}
+			respBytes, _ := proto.Marshal(&apiv1.ScheduleMessageResponse{
+				Ok:   true,
+				Data: &apiv1.ScheduledMessage{Id: 3, ConversationId: 7, SendAt: 1900000000, Status: "pending"},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	body := `{"conversation_id":7,"content":"plus tard","send_at":1900000000}`
+	req := httptest.NewRequest("POST", "/api/messages/scheduled", bytes.NewBufferString(body))
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	w := httptest.NewRecorder()
+
+	handler.ScheduleMessage(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d (%s)", w.Code, w.Body.String())
+	}
+}
+
+func TestHandler_ScheduleMessage_RequiresToken(t *testing.T) {
+	handler := NewHandler(&common.MockNatsConn{})
+	req := httptest.NewRequest("POST", "/api/messages/scheduled", bytes.NewBufferString(`{"conversation_id":7}`))
+	w := httptest.NewRecorder()
+
+	handler.ScheduleMessage(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", w.Code)
+	}
+}
+
+func TestHandler_CancelScheduledMessage_MapsConflict(t *testing.T) {
+	mockNc := &common.MockNatsConn{
+		RequestFunc: func(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+			respBytes, _ := proto.Marshal(&apiv1.CancelScheduledMessageResponse{
+				Ok:    false,
+				Error: &apiv1.Error{Code: "CONFLICT", Message: "scheduled message is no longer pending"},
+			})
+			return &nats.Msg{Data: respBytes}, nil
+		},
+	}
+
+	handler := NewHandler(mockNc)
+	req := httptest.NewRequest("DELETE", "/api/messages/scheduled/3", nil)
+	req.Header.Set("Authorization", bearerToken(t, testActorID))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "3")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.CancelScheduledMessage(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", w.Code)
+	}
+}
@@ -102,16 +102,15 @@ func (h *Handler) onMessage(socket Socket, message WSMessage) {
 	switch msg.Action {
 
 	case models.WSActionJoin:
+		// user:<id> porte des événements privés (blocages, exclusions...) : réservée à son propriétaire.
+		if isUserRoom(msg.Room) && !isOwnUserRoom(socket, msg.Room) {
+			log.Printf("Acces refuse a la room %s", msg.Room)
+			denyJoin(socket, msg.Room, "private user room")
+			return
+		}
 		if isConversationRoom(msg.Room) && !h.canJoinConversationRoom(socket, msg.Room) {
 			log.Printf("Acces refuse a la room %s", msg.Room)
-			// Retour explicite : sinon le front croit être dans la room alors qu'aucun broadcast n'arrivera.
-			feedback, _ := json.Marshal(map[string]any{
-				"action": "error",
-				"code":   "JOIN_DENIED",
-				"room":   msg.Room,
-				"detail": "GROUP_GET forbidden or failed — check membership and message-service / migrations",
-			})
-			_ = socket.WriteMessage(gws.OpcodeText, feedback)
+			denyJoin(socket, msg.Room, "GROUP_GET forbidden or failed — check membership and message-service / migrations")
 			return
 		}
 		h.hub.Join(msg.Room, socket)
@@ -138,16 +137,23 @@ func (h *Handler) onMessage(socket Socket, message WSMessage) {
 		// Pour un message permanent, on passe par le message-service via Request/Reply
 		// If the client included a base64 attachment, upload it first via NATS to media-service
 		if msg.AttachmentBase64 != "" {
+			// Le média est rattaché à la conversation : ses membres pourront le télécharger.
 			uploadReq := struct {
-				Filename    string `json:"filename"`
-				ContentType string `json:"contentType"`
-				Size        int64  `json:"size"`
-				DataBase64  string `json:"dataBase64"`
+				Filename       string `json:"filename"`
+				ContentType    string `json:"contentType"`
+				Size           int64  `json:"size"`
+				DataBase64     string `json:"dataBase64"`
+				OwnerID        string `json:"ownerId"`
+				ConversationID int    `json:"conversationId"`
+				Voice          bool   `json:"voice,omitempty"`
 			}{
-				Filename:    msg.AttachmentFilename,
-				ContentType: msg.AttachmentContentType,
-				Size:        int64(len(msg.AttachmentBase64)),
-				DataBase64:  msg.AttachmentBase64,
+				Filename:       msg.AttachmentFilename,
+				ContentType:    msg.AttachmentContentType,
+				// Size omis (taille décodée inconnue ici) : le media-service mesure le contenu lui-même.
+				DataBase64:     msg.AttachmentBase64,
+				OwnerID:        msg.User,
+				ConversationID: conversationID,
+				Voice:          msg.AttachmentVoice,
 			}
 
 			payload, err := json.Marshal(uploadReq)
@@ -182,6 +188,7 @@ func (h *Handler) onMessage(socket Socket, message WSMessage) {
 			msg.AttachmentBase64 = ""
 			msg.AttachmentFilename = ""
 			msg.AttachmentContentType = ""
+			msg.AttachmentVoice = false
 		}
 
 		// Pour un message permanent, on passe par le message-service via Request/Reply
@@ -219,6 +226,8 @@ func (h *Handler) onMessage(socket Socket, message WSMessage) {
 			mid := int(d.GetId())
 			msg.ID = mid
 			msg.MessageID = strconv.Itoa(mid)
+			msg.Mentions = d.GetMentions()
+			msg.AttachmentInfo = attachmentInfoFromProto(d.GetAttachmentInfo())
 			if rto := d.GetReplyTo(); rto != nil && rto.GetId() != 0 {
 				rid := int(rto.GetId())
 				msg.ReplyToID = &rid
@@ -339,6 +348,25 @@ func (h *Handler) displayNameForUser(userID string) string {
 	return wrapper.Response.Username
 }
 
+// attachmentInfoFromProto : même forme que GET /api/messages (models.AttachmentInfo).
+func attachmentInfoFromProto(info *apiv1.AttachmentInfo) *models.AttachmentInfo {
+	if info == nil {
+		return nil
+	}
+	out := &models.AttachmentInfo{
+		MediaID:     info.GetMediaId(),
+		Type:        info.GetType(),
+		ContentType: info.GetContentType(),
+		Filename:    info.GetFilename(),
+		Size:        info.GetSize(),
+		DurationMs:  info.GetDurationMs(),
+	}
+	for _, peak := range info.GetWaveform() {
+		out.Waveform = append(out.Waveform, int(peak))
+	}
+	return out
+}
+
 func parseMessageID(s string) int {
 	id, _ := strconv.ParseInt(s, 10, 32)
 	return int(id)
@@ -366,6 +394,31 @@ func isConversationRoom(room string) bool {
 	return strings.HasPrefix(room, "group:") || strings.HasPrefix(room, "conversation:")
 }
 
+func isUserRoom(room string) bool {
+	return strings.HasPrefix(room, "user:")
+}
+
+// isOwnUserRoom : la room user:<id> de l'utilisateur authentifié de la socket.
+func isOwnUserRoom(socket Socket, room string) bool {
+	userID, ok := socket.Session().Load("userId")
+	if !ok {
+		return false
+	}
+	id, ok := userID.(string)
+	return ok && strings.TrimSpace(id) != "" && room == "user:"+id
+}
+
+// denyJoin : retour explicite, sinon le front croit être dans la room alors qu'aucun broadcast n'arrivera.
+func denyJoin(socket Socket, room, detail string) {
+	feedback, _ := json.Marshal(map[string]any{
+		"action": "error",
+		"code":   "JOIN_DENIED",
+		"room":   room,
+		"detail": detail,
+	})
+	_ = socket.WriteMessage(gws.OpcodeText, feedback)
+}
+
 func (h *Handler) canJoinConversationRoom(socket Socket, room string) bool {
 	userIDRaw, ok := socket.Session().Load("userId")
 	if !ok {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"gateway/internal/models"
 	"net"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -124,6 +125,28 @@ func TestHandler_OnMessage_Join_Forbidden(t *testing.T) {
 	}
 }
 
+func TestHandler_OnMessage_Join_OtherUserRoom(t *testing.T) {
+	hub := NewHub()
+	handler := NewHandler(hub, &MockNatsConn{})
+	socket := &MockSocket{addr: "1"}
+	socket.Session().Store("userId", "a0000001-0000-0000-0000-000000000001")
+
+	for _, room := range []string{"user:a0000002-0000-0000-0000-000000000002", "user:a0000001-0000-0000-0000-000000000001"} {
+		payload, _ := json.Marshal(models.InputMessage{Action: models.WSActionJoin, Room: room})
+		handler.onMessage(socket, &MockMessage{payload: payload})
+	}
+
+	if _, exists := hub.Rooms["user:a0000002-0000-0000-0000-000000000002"]; exists {
+		t.Error("User should not have joined another user's private room")
+	}
+	if socket.WriteCount != 1 || !strings.Contains(string(socket.LastPayload), "JOIN_DENIED") {
+		t.Errorf("Expected a JOIN_DENIED feedback, got %d writes: %s", socket.WriteCount, socket.LastPayload)
+	}
+	if len(hub.Rooms["user:a0000001-0000-0000-0000-000000000001"]) != 1 {
+		t.Error("User should be able to join their own private room")
+	}
+}
+
 func TestHandler_OnMessage_Message(t *testing.T) {
 	hub := NewHub()
 	mockNats := &MockNatsConn{
@@ -1,7 +1,9 @@
 package ws
 
 import (
+	"encoding/json"
 	"log"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -42,6 +44,7 @@ func (h *Hub) StartNatsSubscription(nc NatsConn) error {
 
 		log.Printf("[Hub] Message reçu de NATS pour la room %s", roomID)
 		h.BroadcastToRoom(roomID, m.Data)
+		h.applyRemoval(roomID, m.Data)
 	})
 
 	if err == nil {
@@ -82,6 +85,49 @@ func (h *Hub) Leave(roomName string, socket Socket) {
 	}
 }
 
+// LeaveUser retire de la room toutes les connexions de userID (session "userId").
+func (h *Hub) LeaveUser(roomName, userID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	clients, exists := h.Rooms[roomName]
+	if !exists {
+		return
+	}
+	for socketID, socket := range clients {
+		if id, ok := socket.Session().Load("userId"); ok && id == userID {
+			delete(clients, socketID)
+			log.Printf("[Hub] Client %s retiré de la room %s", socketID, roomName)
+		}
+	}
+	if len(clients) == 0 {
+		delete(h.Rooms, roomName)
+	}
+}
+
+// applyRemoval : un membre exclu ou banni ne reçoit plus les messages de la conversation, sans attendre
+// sa déconnexion. L'événement lui-même vient d'être diffusé, il le reçoit encore (et sur user:<id>).
+func (h *Hub) applyRemoval(roomID string, payload []byte) {
+	if !isConversationRoom(roomID) {
+		return
+	}
+	var event struct {
+		Action         string `json:"action"`
+		ConversationID int    `json:"conversation_id"`
+		UserID         string `json:"user_id"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil || event.UserID == "" || event.ConversationID <= 0 {
+		return
+	}
+	if event.Action != "member_kicked" && event.Action != "member_banned" {
+		return
+	}
+	id := strconv.Itoa(event.ConversationID)
+	// Room actuelle et room legacy group:<id>.
+	h.LeaveUser("conversation:"+id, event.UserID)
+	h.LeaveUser("group:"+id, event.UserID)
+}
+
 func (h *Hub) BroadcastToRoom(roomName string, payload []byte) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
@@ -77,6 +77,51 @@ func TestHub(t *testing.T) {
 		}
 	})
 
+	t.Run("Kick removes the member's sockets from the conversation room", func(t *testing.T) {
+		hub := NewHub()
+		var handler nats.MsgHandler
+		mockNats := &MockNatsConn{
+			SubscribeFunc: func(subject string, cb nats.MsgHandler) (*nats.Subscription, error) {
+				handler = cb
+				return &nats.Subscription{}, nil
+			},
+		}
+		if err := hub.StartNatsSubscription(mockNats); err != nil {
+			t.Fatalf("Failed to start NATS subscription: %v", err)
+		}
+
+		kicked := &MockSocket{addr: "1"}
+		kicked.Session().Store("userId", "user-kicked")
+		kickedLegacy := &MockSocket{addr: "2"}
+		kickedLegacy.Session().Store("userId", "user-kicked")
+		other := &MockSocket{addr: "3"}
+		other.Session().Store("userId", "user-other")
+		hub.Join("conversation:42", kicked)
+		hub.Join("group:42", kickedLegacy)
+		hub.Join("conversation:42", other)
+		hub.Join("user:user-kicked", kicked)
+
+		handler(&nats.Msg{
+			Subject: "message.broadcast.conversation:42",
+			Data:    []byte(`{"action":"member_kicked","conversation_id":42,"user_id":"user-kicked"}`),
+		})
+		if kicked.WriteCount != 1 {
+			t.Errorf("Expected the kicked member to receive the event, got %d writes", kicked.WriteCount)
+		}
+
+		handler(&nats.Msg{Subject: "message.broadcast.conversation:42", Data: []byte(`{"action":"message"}`)})
+		handler(&nats.Msg{Subject: "message.broadcast.group:42", Data: []byte(`{"action":"message"}`)})
+		if kicked.WriteCount != 1 || kickedLegacy.WriteCount != 0 {
+			t.Errorf("Kicked member should not receive new messages, got %d and %d writes", kicked.WriteCount, kickedLegacy.WriteCount)
+		}
+		if other.WriteCount != 2 {
+			t.Errorf("Other member should keep receiving messages, got %d writes", other.WriteCount)
+		}
+		if len(hub.Rooms["user:user-kicked"]) != 1 {
+			t.Errorf("Kicked member should stay in their private room")
+		}
+	})
+
 	t.Run("BroadcastToNonExistentRoom", func(t *testing.T) {
 		hub := NewHub()
 		hub.BroadcastToRoom("ghost", []byte("ignore me"))
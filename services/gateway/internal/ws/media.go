@@ -0,0 +1,72 @@
+package ws
+
+import (
+	"encoding/json"
+	"log"
+	"strconv"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Événements du media-service relayés aux clients, avec l'action WebSocket correspondante.
+const (
+	// subjectMediaProcessed : variantes d'une image prêtes (ou en échec).
+	subjectMediaProcessed = "media.processed"
+	// subjectMediaScanCompleted : verdict de l'analyse antivirus (clean, infected, failed).
+	subjectMediaScanCompleted = "media.scan.completed"
+)
+
+var mediaEventActions = map[string]string{
+	subjectMediaProcessed:     "media_processed",
+	subjectMediaScanCompleted: "media_scan_completed",
+}
+
+// mediaEvent : champs communs aux événements média, utilisés pour le routage.
+type mediaEvent struct {
+	MediaID        string `json:"mediaId"`
+	OwnerID        string `json:"ownerId"`
+	ConversationID int    `json:"conversationId"`
+}
+
+// StartMediaSubscription relaie media.processed et media.scan.completed à la room de la conversation du
+// média, ou à celle de son propriétaire pour un média hors conversation (actions "media_processed",
+// "media_scan_completed").
+func (h *Hub) StartMediaSubscription(nc NatsConn) error {
+	for _, subject := range []string{subjectMediaProcessed, subjectMediaScanCompleted} {
+		action := mediaEventActions[subject]
+		_, err := nc.Subscribe(subject, func(m *nats.Msg) {
+			h.relayMediaEvent(m, action)
+		})
+		if err != nil {
+			return err
+		}
+		log.Printf("[Hub] Abonnement NATS à %s actif", subject)
+	}
+	return nil
+}
+
+func (h *Hub) relayMediaEvent(m *nats.Msg, action string) {
+	var event mediaEvent
+	if err := json.Unmarshal(m.Data, &event); err != nil || event.MediaID == "" {
+		log.Printf("[Hub] %s invalide : %v", m.Subject, err)
+		return
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(m.Data, &fields); err != nil {
+		return
+	}
+	fields["action"] = action
+	payload, err := json.Marshal(fields)
+	if err != nil {
+		return
+	}
+
+	room := "user:" + event.OwnerID
+	if event.ConversationID > 0 {
+		room = "conversation:" + strconv.Itoa(event.ConversationID)
+	} else if event.OwnerID == "" {
+		return
+	}
+	h.BroadcastToRoom(room, payload)
+}
@@ -0,0 +1,66 @@
+package ws
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+)
+
+func TestHubStartMediaSubscription(t *testing.T) {
+	hub := NewHub()
+	handlers := map[string]nats.MsgHandler{}
+	mockNats := &MockNatsConn{
+		SubscribeFunc: func(s string, cb nats.MsgHandler) (*nats.Subscription, error) {
+			handlers[s] = cb
+			return &nats.Subscription{}, nil
+		},
+	}
+	if err := hub.StartMediaSubscription(mockNats); err != nil {
+		t.Fatalf("StartMediaSubscription() error = %v", err)
+	}
+	handler, scanHandler := handlers["media.processed"], handlers["media.scan.completed"]
+	if len(handlers) != 2 || handler == nil || scanHandler == nil {
+		t.Fatalf("unexpected subscriptions %v", handlers)
+	}
+
+	member := &MockSocket{addr: "1"}
+	owner := &MockSocket{addr: "2"}
+	hub.Join("conversation:42", member)
+	hub.Join("user:owner-1", owner)
+
+	handler(&nats.Msg{Data: []byte(`{"mediaId":"media/1_a.png","ownerId":"owner-1","conversationId":42,"status":"ready"}`)})
+	if member.WriteCount != 1 || owner.WriteCount != 0 {
+		t.Fatalf("conversation media should reach the conversation room only, got %d/%d", member.WriteCount, owner.WriteCount)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(member.LastPayload, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if payload["action"] != "media_processed" || payload["status"] != "ready" || payload["mediaId"] != "media/1_a.png" {
+		t.Fatalf("unexpected payload %v", payload)
+	}
+
+	// Média hors conversation : room du propriétaire.
+	handler(&nats.Msg{Data: []byte(`{"mediaId":"media/2_b.png","ownerId":"owner-1","status":"failed"}`)})
+	if owner.WriteCount != 1 || member.WriteCount != 1 {
+		t.Fatalf("personal media should reach the owner room only, got %d/%d", member.WriteCount, owner.WriteCount)
+	}
+
+	handler(&nats.Msg{Data: []byte(`not json`)})
+	if owner.WriteCount != 1 || member.WriteCount != 1 {
+		t.Fatal("invalid events must be ignored")
+	}
+
+	// Verdict antivirus : même routage, action dédiée.
+	scanHandler(&nats.Msg{Data: []byte(`{"mediaId":"media/3_c.pdf","ownerId":"owner-1","conversationId":42,"scanStatus":"clean"}`)})
+	if member.WriteCount != 2 || owner.WriteCount != 1 {
+		t.Fatalf("scan verdict should reach the conversation room only, got %d/%d", member.WriteCount, owner.WriteCount)
+	}
+	if err := json.Unmarshal(member.LastPayload, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if payload["action"] != "media_scan_completed" || payload["scanStatus"] != "clean" || payload["mediaId"] != "media/3_c.pdf" {
+		t.Fatalf("unexpected scan payload %v", payload)
+	}
+}
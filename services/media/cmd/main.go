@@ -1,13 +1,24 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
+	"github.com/Mathis-brgs/storm-project/services/media/internal/cleanup"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/gc"
 	"github.com/Mathis-brgs/storm-project/services/media/internal/handlers"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/membership"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/processing"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/references"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/repo/memory"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/repo/postgres"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/scanning"
 	"github.com/Mathis-brgs/storm-project/services/media/internal/service"
 	"github.com/Mathis-brgs/storm-project/services/media/internal/storage"
 	"github.com/Mathis-brgs/storm-project/services/media/internal/subscribers"
@@ -42,13 +53,85 @@ func main() {
 	}
 	defer nc.Close()
 
-	// Client S3/MinIO
-	minioClient, err := storage.NewMinIOClient(bucket)
+	// Stockage objet : S3/MinIO, système de fichiers ou Azure Blob (OBJECT_STORAGE)
+	objectStore, err := storage.NewObjectStore(bucket)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	mediaService := service.NewMediaService(minioClient)
+	// Registre des métadonnées média
+	var mediaRepo repo.MediaRepo
+	var sessionRepo repo.UploadSessionRepo
+	persistent := strings.ToLower(os.Getenv("STORAGE")) == "postgres"
+	if persistent {
+		db, err := postgres.NewDB()
+		if err != nil {
+			log.Fatalf("postgres connect: %v", err)
+		}
+		defer db.Close()
+		mediaRepo = postgres.NewMediaRepo(db)
+		sessionRepo = postgres.NewUploadSessionRepo(db)
+		log.Println("storage: postgres")
+	} else {
+		mediaRepo = memory.NewMediaRepo()
+		sessionRepo = memory.NewUploadSessionRepo()
+		log.Println("storage: memory")
+	}
+
+	mediaService := service.NewMediaService(objectStore, mediaRepo, sessionRepo, membership.NewChecker(nc))
+
+	// Types acceptés par catégorie : MEDIA_ALLOWED_IMAGE, _VIDEO, _AUDIO, _VOICE, _DOCUMENT (listes par défaut sinon)
+	typePolicy, err := service.TypePolicyFromEnv()
+	if err != nil {
+		log.Fatalf("types de médias: %v", err)
+	}
+	mediaService.SetTypePolicy(typePolicy)
+
+	// Analyse antivirus (clamd à MEDIA_SCAN_CLAMD_ADDR) : médias bloqués jusqu'au verdict, annoncé sur media.scan.completed
+	scanner, err := scanning.FromEnv()
+	if err != nil {
+		log.Fatalf("analyse antivirus: %v", err)
+	}
+	if scanner != nil {
+		oversize, err := scanning.OversizePolicyFromEnv()
+		if err != nil {
+			log.Fatalf("analyse antivirus: %v", err)
+		}
+		mediaService.SetScanner(scanner)
+		mediaService.SetOversizePolicy(oversize)
+		log.Printf("analyse antivirus: clamd (fichiers trop volumineux : %s)", oversize)
+	}
+
+	// Variantes d'image (miniature, moyenne) générées en tâche de fond, annoncées sur media.processed
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	worker := processing.NewWorker(mediaService, nc)
+	mediaService.SetProcessingQueue(worker)
+	go worker.Run(ctx)
+	// La file est en mémoire : médias en attente d'analyse ou de variantes au dernier arrêt
+	if resumed, err := mediaService.ResumeProcessing(); err != nil {
+		log.Printf("reprise du traitement: %v", err)
+	} else if resumed > 0 {
+		log.Printf("reprise du traitement: %d média(s) replanifié(s)", resumed)
+	}
+
+	// Uploads reprenables abandonnés : parties multipart et objets en attente supprimés après SessionTTL
+	go cleanup.New(mediaService).Run(ctx)
+
+	// Ramasse-miettes : références et objets qu'aucun message n'utilise plus, après MEDIA_GC_GRACE
+	gcConfig, err := gc.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("ramasse-miettes: %v", err)
+	}
+	if !persistent && !gcConfig.DryRun {
+		// Registre vidé à chaque redémarrage : tous les objets sembleraient orphelins.
+		log.Println("ramasse-miettes: registre en mémoire, dry-run forcé")
+		gcConfig.DryRun = true
+	}
+	if err := mediaService.SetGarbageCollection(references.NewChecker(nc), gcConfig.Grace); err != nil {
+		log.Fatalf("ramasse-miettes: %v", err)
+	}
+	go gc.New(mediaService, gcConfig).Run(ctx)
 
 	// Démarrer les subscribers NATS
 	if err := subscribers.StartMediaSubscribers(nc, mediaService); err != nil {
@@ -61,6 +144,10 @@ func main() {
 	handler := handlers.NewMediaHandler(mediaService)
 	handler.RegisterRoutes(mux)
 	mux.Handle("/metrics", promhttp.Handler())
+	// URLs signées du stockage local (upload direct, téléchargement)
+	if fsStore, ok := objectStore.(*storage.FSStore); ok {
+		mux.Handle(storage.FSRoutePrefix+"/", http.StripPrefix(storage.FSRoutePrefix, fsStore.Handler()))
+	}
 
 	go func() {
 		log.Printf("HTTP server démarré sur :%s", port)
@@ -74,4 +161,4 @@ func main() {
 	<-stop
 
 	log.Println("Media service shutting down")
-}
\ No newline at end of file
+}
@@ -6,7 +6,9 @@ require (
 	github.com/aws/aws-sdk-go-v2 v1.41.1
 	github.com/aws/aws-sdk-go-v2/credentials v1.19.7
 	github.com/aws/aws-sdk-go-v2/service/s3 v1.96.0
+	github.com/lib/pq v1.11.2
 	github.com/nats-io/nats.go v1.48.0
+	golang.org/x/image v0.25.0
 )
 
 require (
@@ -0,0 +1,105 @@
+// Package audio lit la durée et une forme d'onde approchée des fichiers audio (Ogg, MP3, MP4/M4A,
+// WebM) dans leur conteneur, sans décoder le son.
+package audio
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// WaveformPeaks : nombre de barres de la forme d'onde d'une note vocale.
+const WaveformPeaks = 64
+
+var ErrUnsupported = errors.New("format audio non reconnu")
+
+// Info : durée et forme d'onde d'un fichier audio.
+type Info struct {
+	Duration time.Duration
+	// Peaks : amplitude relative (0-100) de tranches successives, estimée d'après la taille des paquets
+	// compressés (à débit variable, un passage plus fort produit des paquets plus gros).
+	Peaks []int
+}
+
+// Detect reconnaît les fichiers audio sur leurs premiers octets : audio/ogg (Opus ou Vorbis),
+// audio/mp4 (marques M4A) ou audio/mpeg (tag ID3 ou trames MPEG) ; "" sinon.
+func Detect(head []byte) string {
+	switch {
+	case isOggAudio(head):
+		return "audio/ogg"
+	case len(head) >= 12 && string(head[4:8]) == "ftyp" && m4aBrands[string(head[8:12])]:
+		return "audio/mp4"
+	case bytes.HasPrefix(head, []byte("ID3")):
+		return "audio/mpeg"
+	}
+	// MP3 sans tag : deux trames valides consécutives (une seule si le début ne contient qu'elle).
+	if frame, ok := parseMPEGFrame(head); ok {
+		if len(head) < frame.length+4 {
+			return "audio/mpeg"
+		}
+		if _, ok := parseMPEGFrame(head[frame.length:]); ok {
+			return "audio/mpeg"
+		}
+	}
+	return ""
+}
+
+// Analyze lit la durée de data et sa forme d'onde en peaks tranches.
+func Analyze(data []byte, contentType string, peaks int) (*Info, error) {
+	var (
+		duration time.Duration
+		sizes    []int
+		err      error
+	)
+	switch contentType {
+	case "audio/ogg":
+		duration, sizes, err = parseOgg(data)
+	case "audio/mpeg":
+		duration, sizes, err = parseMP3(data)
+	case "audio/mp4":
+		duration, sizes, err = parseMP4(data)
+	case "audio/webm":
+		duration, sizes, err = parseWebM(data)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupported, contentType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Info{Duration: duration, Peaks: waveform(sizes, peaks)}, nil
+}
+
+// waveform regroupe les tailles de paquets en n tranches (maximum de chaque tranche), ramenées à 0-100.
+func waveform(sizes []int, n int) []int {
+	if len(sizes) == 0 || n <= 0 {
+		return nil
+	}
+	n = min(n, len(sizes))
+	peaks := make([]int, n)
+	for i := range peaks {
+		for _, size := range sizes[i*len(sizes)/n : (i+1)*len(sizes)/n] {
+			peaks[i] = max(peaks[i], size)
+		}
+	}
+	lo, hi := peaks[0], peaks[0]
+	for _, peak := range peaks {
+		lo, hi = min(lo, peak), max(hi, peak)
+	}
+	for i, peak := range peaks {
+		if hi == lo {
+			peaks[i] = 100
+			continue
+		}
+		peaks[i] = (peak - lo) * 100 / (hi - lo)
+	}
+	return peaks
+}
+
+// samplesDuration : durée de samples échantillons à rate Hz.
+func samplesDuration(samples, rate int64) time.Duration {
+	if rate <= 0 || samples <= 0 {
+		return 0
+	}
+	return time.Duration(samples/rate*int64(time.Second) + samples%rate*int64(time.Second)/rate)
+}
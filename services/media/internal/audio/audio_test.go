@@ -0,0 +1,170 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// oggPage : page Ogg (CRC non vérifié) contenant des paquets complets.
+func oggPage(granule int64, packets ...[]byte) []byte {
+	var lacing, body []byte
+	for _, packet := range packets {
+		n := len(packet)
+		for ; n >= 255; n -= 255 {
+			lacing = append(lacing, 255)
+		}
+		lacing = append(lacing, byte(n))
+		body = append(body, packet...)
+	}
+	page := []byte("OggS\x00\x00")
+	page = binary.LittleEndian.AppendUint64(page, uint64(granule))
+	page = binary.LittleEndian.AppendUint32(page, 1) // numéro de flux
+	page = append(page, make([]byte, 8)...)          // séquence, CRC
+	page = append(page, byte(len(lacing)))
+	return append(append(page, lacing...), body...)
+}
+
+// opusFile : 50 paquets de 20 ms (1 s), de plus en plus gros, pre-skip de 312 échantillons.
+func opusFile() []byte {
+	head := []byte("OpusHead\x01\x01")
+	head = binary.LittleEndian.AppendUint16(head, 312)
+	head = binary.LittleEndian.AppendUint32(head, 48000)
+	head = append(head, 0, 0, 0)
+	data := append(oggPage(0, head), oggPage(0, []byte("OpusTags"))...)
+	for page := 0; page < 5; page++ {
+		packets := make([][]byte, 10)
+		for i := range packets {
+			packets[i] = make([]byte, 20+page*100)
+		}
+		data = append(data, oggPage(int64(312+(page+1)*10*960), packets...)...)
+	}
+	return data
+}
+
+// mp3File : frames trames MPEG-1 couche III à 128 kbit/s, 44,1 kHz, après un tag ID3v2 optionnel.
+func mp3File(frames int, id3 bool) []byte {
+	var data []byte
+	if id3 {
+		data = append([]byte("ID3\x03\x00\x00\x00\x00\x00\x0A"), make([]byte, 10)...)
+	}
+	for i := 0; i < frames; i++ {
+		frame := make([]byte, 417)
+		copy(frame, []byte{0xFF, 0xFB, 0x90, 0x00})
+		data = append(data, frame...)
+	}
+	return data
+}
+
+func box(kind string, parts ...[]byte) []byte {
+	body := bytes.Join(parts, nil)
+	b := binary.BigEndian.AppendUint32(nil, uint32(8+len(body)))
+	return append(append(b, kind...), body...)
+}
+
+// m4aFile : 2,5 s (timescale 1000), piste son de quatre échantillons.
+func m4aFile() []byte {
+	mvhd := make([]byte, 20)
+	binary.BigEndian.PutUint32(mvhd[12:], 1000)
+	binary.BigEndian.PutUint32(mvhd[16:], 2500)
+	hdlr := append(make([]byte, 8), "soun"...)
+	stsz := make([]byte, 12)
+	binary.BigEndian.PutUint32(stsz[8:], 4)
+	for _, size := range []uint32{10, 40, 20, 30} {
+		stsz = binary.BigEndian.AppendUint32(stsz, size)
+	}
+	stbl := box("stbl", box("stsz", stsz))
+	trak := box("trak", box("mdia", box("hdlr", hdlr), box("minf", stbl)))
+	return append(box("ftyp", []byte("M4A \x00\x00\x00\x00isom")), box("moov", box("mvhd", mvhd), trak)...)
+}
+
+// ebml : élément EBML, taille codée sur 8 octets.
+func ebml(id uint32, parts ...[]byte) []byte {
+	body := bytes.Join(parts, nil)
+	b := binary.BigEndian.AppendUint32(nil, id)
+	for b[0] == 0 {
+		b = b[1:]
+	}
+	b = append(b, 0x01)
+	b = append(b, binary.BigEndian.AppendUint64(nil, uint64(len(body)))[1:]...)
+	return append(b, body...)
+}
+
+func webmFile(duration float64) []byte {
+	info := ebml(ebmlTimecodeScale, []byte{0x0F, 0x42, 0x40}) // 1 ms
+	if duration > 0 {
+		info = append(info, ebml(ebmlDuration, binary.BigEndian.AppendUint64(nil, math.Float64bits(duration)))...)
+	}
+	cluster := ebml(ebmlTimecode, []byte{0x03, 0xE8}) // 1000 ms
+	for i, size := range []int{30, 90, 60} {
+		block := []byte{0x81, 0x00, byte(i * 100), 0x80}
+		cluster = append(cluster, ebml(ebmlSimpleBlock, block, make([]byte, size))...)
+	}
+	return append(ebml(ebmlHeader, ebml(0x4282, []byte("webm"))), ebml(ebmlSegment, ebml(ebmlInfo, info), ebml(ebmlCluster, cluster))...)
+}
+
+func TestDetect(t *testing.T) {
+	cases := map[string][]byte{
+		"ogg":      opusFile(),
+		"mp3 id3":  mp3File(1, true),
+		"mp3 brut": mp3File(3, false),
+		"m4a":      m4aFile(),
+		"inconnu":  []byte("\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom"),
+	}
+	want := map[string]string{"ogg": "audio/ogg", "mp3 id3": "audio/mpeg", "mp3 brut": "audio/mpeg", "m4a": "audio/mp4", "inconnu": ""}
+	for name, data := range cases {
+		if got := Detect(data); got != want[name] {
+			t.Fatalf("%s: Detect() = %q, want %q", name, got, want[name])
+		}
+	}
+	if got := Detect([]byte{0xFF, 0xFB, 0x90, 0x00, 0xAB, 0xCD}); got != "audio/mpeg" {
+		t.Fatalf("single frame header: Detect() = %q", got)
+	}
+	if got := Detect(append([]byte{0xFF, 0xFB, 0x90, 0x00}, make([]byte, 600)...)); got != "" {
+		t.Fatalf("isolated frame sync should not be detected, got %q", got)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	cases := []struct {
+		name        string
+		data        []byte
+		contentType string
+		duration    time.Duration
+		peaks       int
+	}{
+		{"opus", opusFile(), "audio/ogg", time.Second, 50},
+		{"mp3", mp3File(10, true), "audio/mpeg", 261224489 * time.Nanosecond, 10},
+		{"m4a", m4aFile(), "audio/mp4", 2500 * time.Millisecond, 4},
+		{"webm", webmFile(1500), "audio/webm", 1500 * time.Millisecond, 3},
+		{"webm sans durée", webmFile(0), "audio/webm", 1200 * time.Millisecond, 3},
+	}
+	for _, tc := range cases {
+		info, err := Analyze(tc.data, tc.contentType, WaveformPeaks)
+		if err != nil {
+			t.Fatalf("%s: Analyze() error = %v", tc.name, err)
+		}
+		if info.Duration != tc.duration || len(info.Peaks) != tc.peaks {
+			t.Fatalf("%s: Analyze() = %v, %d peaks; want %v, %d", tc.name, info.Duration, len(info.Peaks), tc.duration, tc.peaks)
+		}
+	}
+
+	info, _ := Analyze(m4aFile(), "audio/mp4", WaveformPeaks)
+	if !reflect.DeepEqual(info.Peaks, []int{0, 100, 33, 66}) {
+		t.Fatalf("m4a peaks = %v", info.Peaks)
+	}
+	info, _ = Analyze(opusFile(), "audio/ogg", 5)
+	if !reflect.DeepEqual(info.Peaks, []int{0, 25, 50, 75, 100}) {
+		t.Fatalf("opus peaks = %v", info.Peaks)
+	}
+
+	for _, contentType := range []string{"audio/ogg", "audio/mpeg", "audio/mp4", "audio/webm", "audio/wav"} {
+		if _, err := Analyze([]byte("pas de l'audio"), contentType, WaveformPeaks); !errors.Is(err, ErrUnsupported) {
+			t.Fatalf("%s: expected ErrUnsupported, got %v", contentType, err)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package audio
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+// Débits (kbit/s) par index : MPEG-1 couches I, II, III puis MPEG-2/2.5 couche I, couches II et III.
+var (
+	bitratesV1L1  = [15]int{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448}
+	bitratesV1L2  = [15]int{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384}
+	bitratesV1L3  = [15]int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320}
+	bitratesV2L1  = [15]int{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256}
+	bitratesV2L23 = [15]int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160}
+
+	sampleRatesV1 = [3]int{44100, 48000, 32000}
+)
+
+// mpegFrame : trame audio MPEG (longueur en octets, échantillons, fréquence).
+type mpegFrame struct {
+	length  int
+	samples int
+	rate    int
+}
+
+// parseMPEGFrame décode l'en-tête de 4 octets d'une trame MPEG audio.
+func parseMPEGFrame(b []byte) (mpegFrame, bool) {
+	if len(b) < 4 || b[0] != 0xFF || b[1]&0xE0 != 0xE0 {
+		return mpegFrame{}, false
+	}
+	version := (b[1] >> 3) & 3 // 0 : MPEG-2.5, 2 : MPEG-2, 3 : MPEG-1
+	layer := (b[1] >> 1) & 3   // 1 : couche III, 2 : couche II, 3 : couche I
+	bitrateIndex := b[2] >> 4
+	rateIndex := (b[2] >> 2) & 3
+	padding := int(b[2]>>1) & 1
+	if version == 1 || layer == 0 || bitrateIndex == 0 || bitrateIndex == 15 || rateIndex == 3 {
+		return mpegFrame{}, false
+	}
+
+	rate := sampleRatesV1[rateIndex]
+	lsf := version != 3 // MPEG-2 et 2.5 : demi (ou quart de) fréquence
+	switch version {
+	case 2:
+		rate /= 2
+	case 0:
+		rate /= 4
+	}
+	var kbps int
+	switch {
+	case !lsf && layer == 3:
+		kbps = bitratesV1L1[bitrateIndex]
+	case !lsf && layer == 2:
+		kbps = bitratesV1L2[bitrateIndex]
+	case !lsf:
+		kbps = bitratesV1L3[bitrateIndex]
+	case layer == 3:
+		kbps = bitratesV2L1[bitrateIndex]
+	default:
+		kbps = bitratesV2L23[bitrateIndex]
+	}
+	bitrate := kbps * 1000
+
+	frame := mpegFrame{rate: rate}
+	switch {
+	case layer == 3:
+		frame.samples = 384
+		frame.length = (12*bitrate/rate + padding) * 4
+	case layer == 2 || !lsf:
+		frame.samples = 1152
+		frame.length = 144*bitrate/rate + padding
+	default:
+		frame.samples = 576
+		frame.length = 72*bitrate/rate + padding
+	}
+	return frame, frame.length > 4
+}
+
+// parseMP3 : durée = somme des échantillons des trames ; une taille par trame. Le tag ID3v2 est sauté,
+// la trame d'information Xing/Info (sans audio) ignorée.
+func parseMP3(data []byte) (time.Duration, []int, error) {
+	pos := id3v2Size(data)
+	var samples int64
+	rate := 0
+	seenFrame := false
+	var sizes []int
+	for pos+4 <= len(data) {
+		frame, ok := parseMPEGFrame(data[pos:])
+		if !ok || pos+frame.length > len(data) {
+			if !ok && bytes.HasPrefix(data[pos:], []byte("TAG")) {
+				break // ID3v1 en fin de fichier
+			}
+			// Resynchronisation sur l'octet 0xFF suivant.
+			next := bytes.IndexByte(data[pos+1:], 0xFF)
+			if next < 0 {
+				break
+			}
+			pos += next + 1
+			continue
+		}
+		if rate == 0 {
+			rate = frame.rate
+		}
+		first := data[pos+4 : pos+min(frame.length, 64)]
+		if !seenFrame && (bytes.Contains(first, []byte("Xing")) || bytes.Contains(first, []byte("Info"))) {
+			seenFrame = true
+			pos += frame.length
+			continue
+		}
+		seenFrame = true
+		samples += int64(frame.samples)
+		sizes = append(sizes, frame.length)
+		pos += frame.length
+	}
+	if len(sizes) == 0 {
+		return 0, nil, fmt.Errorf("%w: aucune trame MPEG", ErrUnsupported)
+	}
+	return samplesDuration(samples, int64(rate)), sizes, nil
+}
+
+// id3v2Size : taille du tag ID3v2 en tête de data (taille « synchsafe » sur 4 × 7 bits), 0 sans tag.
+func id3v2Size(data []byte) int {
+	if len(data) < 10 || string(data[:3]) != "ID3" {
+		return 0
+	}
+	size := int(data[6]&0x7F)<<21 | int(data[7]&0x7F)<<14 | int(data[8]&0x7F)<<7 | int(data[9]&0x7F)
+	size += 10
+	if data[5]&0x10 != 0 {
+		size += 10 // pied de tag
+	}
+	return min(size, len(data))
+}
@@ -0,0 +1,115 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// m4aBrands : marques ftyp des fichiers MP4 audio seuls.
+var m4aBrands = map[string]bool{"M4A ": true, "M4B ": true, "M4P ": true}
+
+// eachBox parcourt les boîtes ISO BMFF de data ; fn retourne false pour arrêter le parcours.
+func eachBox(data []byte, fn func(kind string, body []byte) bool) {
+	for len(data) >= 8 {
+		size, header := uint64(binary.BigEndian.Uint32(data)), uint64(8)
+		switch size {
+		case 0: // jusqu'à la fin du fichier
+			size = uint64(len(data))
+		case 1: // taille sur 64 bits
+			if len(data) < 16 {
+				return
+			}
+			size, header = binary.BigEndian.Uint64(data[8:]), 16
+		}
+		if size < header {
+			return
+		}
+		size = min(size, uint64(len(data)))
+		if !fn(string(data[4:8]), data[header:size]) {
+			return
+		}
+		data = data[size:]
+	}
+}
+
+// findBox : contenu de la première boîte kind de data, nil si absente.
+func findBox(data []byte, kind string) []byte {
+	var found []byte
+	eachBox(data, func(k string, body []byte) bool {
+		if k == kind {
+			found = body
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// parseMP4 : durée lue dans moov/mvhd (ou mvex/mehd pour un MP4 fragmenté) ; une taille par échantillon
+// de la piste son (stsz).
+func parseMP4(data []byte) (time.Duration, []int, error) {
+	moov := findBox(data, "moov")
+	mvhd := findBox(moov, "mvhd")
+	if mvhd == nil {
+		return 0, nil, fmt.Errorf("%w: boîte moov/mvhd absente", ErrUnsupported)
+	}
+	var timescale, duration uint64
+	switch {
+	case mvhd[0] == 1 && len(mvhd) >= 32:
+		timescale, duration = uint64(binary.BigEndian.Uint32(mvhd[20:])), binary.BigEndian.Uint64(mvhd[24:])
+	case mvhd[0] == 0 && len(mvhd) >= 20:
+		timescale, duration = uint64(binary.BigEndian.Uint32(mvhd[12:])), uint64(binary.BigEndian.Uint32(mvhd[16:]))
+	default:
+		return 0, nil, fmt.Errorf("%w: boîte mvhd invalide", ErrUnsupported)
+	}
+	if duration == 0 {
+		// Enregistrement fragmenté (MediaRecorder) : durée totale dans mvex/mehd, si renseignée.
+		if mehd := findBox(findBox(moov, "mvex"), "mehd"); len(mehd) >= 8 {
+			if mehd[0] == 1 && len(mehd) >= 12 {
+				duration = binary.BigEndian.Uint64(mehd[4:])
+			} else {
+				duration = uint64(binary.BigEndian.Uint32(mehd[4:]))
+			}
+		}
+	}
+
+	var sizes []int
+	eachBox(moov, func(kind string, trak []byte) bool {
+		if kind != "trak" {
+			return true
+		}
+		mdia := findBox(trak, "mdia")
+		if hdlr := findBox(mdia, "hdlr"); len(hdlr) < 12 || string(hdlr[8:12]) != "soun" {
+			return true
+		}
+		sizes = sampleSizes(findBox(findBox(findBox(mdia, "minf"), "stbl"), "stsz"))
+		return false
+	})
+	return samplesDuration(int64(duration), int64(timescale)), sizes, nil
+}
+
+// sampleSizes lit une boîte stsz : taille commune ou une taille par échantillon.
+func sampleSizes(stsz []byte) []int {
+	if len(stsz) < 12 {
+		return nil
+	}
+	common := int(binary.BigEndian.Uint32(stsz[4:]))
+	count := int(binary.BigEndian.Uint32(stsz[8:]))
+	if common != 0 {
+		// Une taille commune n'apporte aucune variation : on borne le nombre d'entrées.
+		count = min(count, WaveformPeaks)
+		sizes := make([]int, count)
+		for i := range sizes {
+			sizes[i] = common
+		}
+		return sizes
+	}
+	entries := stsz[12:]
+	count = min(count, len(entries)/4)
+	sizes := make([]int, count)
+	for i := range sizes {
+		sizes[i] = int(binary.BigEndian.Uint32(entries[4*i:]))
+	}
+	return sizes
+}
@@ -0,0 +1,89 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// Pages Ogg : en-tête de 27 octets (« OggS », position granule, numéro de flux, table des segments)
+// suivi des segments. Seul le premier flux logique est lu.
+const oggHeaderLen = 27
+
+// isOggAudio : première page d'un flux Opus ou Vorbis.
+func isOggAudio(head []byte) bool {
+	if len(head) < oggHeaderLen || string(head[:4]) != "OggS" {
+		return false
+	}
+	body := head[min(len(head), oggHeaderLen+int(head[26])):]
+	return bytes.HasPrefix(body, []byte("OpusHead")) || bytes.HasPrefix(body, []byte("\x01vorbis"))
+}
+
+// parseOgg : durée = dernière position granule (échantillons, moins le pre-skip Opus) ; une taille par
+// paquet audio, paquets d'en-tête exclus.
+func parseOgg(data []byte) (time.Duration, []int, error) {
+	if !isOggAudio(data) {
+		return 0, nil, fmt.Errorf("%w: flux Ogg sans Opus ni Vorbis", ErrUnsupported)
+	}
+	body := data[oggHeaderLen+int(data[26]):]
+	var rate, preSkip int64
+	headerPackets := 0
+	if bytes.HasPrefix(body, []byte("OpusHead")) {
+		if len(body) < 12 {
+			return 0, nil, fmt.Errorf("%w: en-tête Opus tronqué", ErrUnsupported)
+		}
+		// Positions granule Opus toujours à 48 kHz.
+		rate, preSkip, headerPackets = 48000, int64(binary.LittleEndian.Uint16(body[10:])), 2
+	} else {
+		if len(body) < 16 {
+			return 0, nil, fmt.Errorf("%w: en-tête Vorbis tronqué", ErrUnsupported)
+		}
+		rate, headerPackets = int64(binary.LittleEndian.Uint32(body[12:])), 3
+	}
+
+	serial := binary.LittleEndian.Uint32(data[14:])
+	granule := int64(-1)
+	packets, pending := 0, 0
+	var sizes []int
+	for pos := 0; pos+oggHeaderLen <= len(data); {
+		if string(data[pos:pos+4]) != "OggS" {
+			break
+		}
+		segments := int(data[pos+26])
+		start := pos + oggHeaderLen + segments
+		if start > len(data) {
+			break
+		}
+		lacing := data[pos+oggHeaderLen : start]
+		length := 0
+		for _, l := range lacing {
+			length += int(l)
+		}
+		if start+length > len(data) {
+			break // page tronquée
+		}
+		if binary.LittleEndian.Uint32(data[pos+14:]) == serial {
+			if g := int64(binary.LittleEndian.Uint64(data[pos+6:])); g >= 0 {
+				granule = g
+			}
+			// Un paquet se termine sur un segment de moins de 255 octets, éventuellement sur une page suivante.
+			for _, l := range lacing {
+				pending += int(l)
+				if l == 255 {
+					continue
+				}
+				packets++
+				if packets > headerPackets {
+					sizes = append(sizes, pending)
+				}
+				pending = 0
+			}
+		}
+		pos = start + length
+	}
+	if granule < 0 {
+		return 0, nil, fmt.Errorf("%w: aucune position dans le flux Ogg", ErrUnsupported)
+	}
+	return samplesDuration(granule-preSkip, rate), sizes, nil
+}
@@ -0,0 +1,115 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Identifiants EBML (Matroska/WebM) utilisés.
+const (
+	ebmlHeader        = 0x1A45DFA3
+	ebmlSegment       = 0x18538067
+	ebmlInfo          = 0x1549A966
+	ebmlTimecodeScale = 0x2AD7B1
+	ebmlDuration      = 0x4489
+	ebmlCluster       = 0x1F43B675
+	ebmlTimecode      = 0xE7
+	ebmlBlockGroup    = 0xA0
+	ebmlBlock         = 0xA1
+	ebmlSimpleBlock   = 0xA3
+)
+
+// readVint lit un entier à longueur variable EBML : longueur, valeur (marqueur de longueur conservé
+// pour un identifiant) et taille inconnue (tous les bits de valeur à 1). n vaut 0 si b est invalide.
+func readVint(b []byte, keepMarker bool) (value uint64, n int, unknown bool) {
+	if len(b) == 0 || b[0] == 0 {
+		return 0, 0, false
+	}
+	n = 1
+	for mask := byte(0x80); b[0]&mask == 0; mask >>= 1 {
+		n++
+	}
+	if len(b) < n {
+		return 0, 0, false
+	}
+	value = uint64(b[0])
+	if !keepMarker {
+		value &= uint64(0xFF >> n)
+	}
+	for _, c := range b[1:n] {
+		value = value<<8 | uint64(c)
+	}
+	unknown = !keepMarker && value == 1<<(7*n)-1
+	return value, n, unknown
+}
+
+func readUint(b []byte) uint64 {
+	var value uint64
+	for _, c := range b {
+		value = value<<8 | uint64(c)
+	}
+	return value
+}
+
+// parseWebM : durée lue dans Segment/Info (sinon, horodatage du dernier bloc) ; une taille par bloc.
+func parseWebM(data []byte) (time.Duration, []int, error) {
+	if id, _, _ := readVint(data, true); id != ebmlHeader {
+		return 0, nil, fmt.Errorf("%w: en-tête EBML absent", ErrUnsupported)
+	}
+	scale := uint64(1_000_000) // ns par unité de timecode
+	var duration float64
+	var cluster, last int64
+	var sizes []int
+	for pos := 0; pos < len(data); {
+		id, n, _ := readVint(data[pos:], true)
+		if n == 0 {
+			break
+		}
+		size, m, unknown := readVint(data[pos+n:], false)
+		if m == 0 {
+			break
+		}
+		body := pos + n + m
+		switch id {
+		case ebmlSegment, ebmlInfo, ebmlCluster, ebmlBlockGroup:
+			// Éléments conteneurs : on descend dans leurs enfants.
+			pos = body
+			continue
+		}
+		if unknown || uint64(len(data)-body) < size {
+			break // élément tronqué
+		}
+		element := data[body : body+int(size)]
+		switch id {
+		case ebmlTimecodeScale:
+			if value := readUint(element); value > 0 {
+				scale = value
+			}
+		case ebmlDuration:
+			switch len(element) {
+			case 4:
+				duration = float64(math.Float32frombits(binary.BigEndian.Uint32(element)))
+			case 8:
+				duration = math.Float64frombits(binary.BigEndian.Uint64(element))
+			}
+		case ebmlTimecode:
+			cluster = int64(readUint(element))
+		case ebmlSimpleBlock, ebmlBlock:
+			// Numéro de piste (vint) puis horodatage relatif au cluster (int16).
+			if _, t, _ := readVint(element, false); t > 0 && len(element) >= t+2 {
+				last = max(last, cluster+int64(int16(binary.BigEndian.Uint16(element[t:]))))
+				sizes = append(sizes, len(element))
+			}
+		}
+		pos = body + int(size)
+	}
+	if duration <= 0 && len(sizes) == 0 {
+		return 0, nil, fmt.Errorf("%w: aucun bloc WebM", ErrUnsupported)
+	}
+	if duration <= 0 {
+		duration = float64(last)
+	}
+	return time.Duration(duration * float64(scale)), sizes, nil
+}
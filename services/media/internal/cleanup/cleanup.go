@@ -0,0 +1,60 @@
+package cleanup
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/service"
+)
+
+const (
+	defaultInterval  = 15 * time.Minute
+	defaultBatchSize = 100
+)
+
+// Cleaner annule les uploads reprenables expirés : parties multipart et objets en attente sont
+// supprimés du stockage, puis la session. Un nettoyage interrompu est repris au passage suivant.
+type Cleaner struct {
+	svc *service.MediaService
+
+	interval  time.Duration
+	batchSize int
+}
+
+func New(svc *service.MediaService) *Cleaner {
+	return &Cleaner{svc: svc, interval: defaultInterval, batchSize: defaultBatchSize}
+}
+
+// Run nettoie toutes les 15 minutes jusqu'à l'annulation du contexte.
+func (c *Cleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := c.RunOnce(ctx, time.Now()); err != nil {
+				log.Printf("[cleanup] run: %v", err)
+			}
+		}
+	}
+}
+
+// RunOnce annule les sessions expirées à now, par lots ; retourne le nombre de sessions nettoyées.
+func (c *Cleaner) RunOnce(ctx context.Context, now time.Time) (int, error) {
+	total := 0
+	for {
+		cleaned, err := c.svc.CleanupStaleSessions(ctx, now, c.batchSize)
+		total += cleaned
+		if err != nil {
+			return total, err
+		}
+		// Lot incomplet, ou sessions en échec qui reviendraient indéfiniment : au prochain passage.
+		if cleaned < c.batchSize {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package gc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/service"
+)
+
+const defaultInterval = 6 * time.Hour
+
+// Config : MEDIA_GC_INTERVAL (0 désactive le passage périodique), MEDIA_GC_GRACE et MEDIA_GC_DRY_RUN
+// (true par défaut : la suppression effective doit être activée explicitement).
+type Config struct {
+	Interval time.Duration
+	Grace    time.Duration
+	DryRun   bool
+}
+
+// ConfigFromEnv lit la configuration du ramasse-miettes ; les variables absentes gardent les valeurs
+// par défaut (toutes les 6 h, délai de grâce service.DefaultGCGrace, dry-run).
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{Interval: defaultInterval, Grace: service.DefaultGCGrace, DryRun: true}
+	var err error
+	if raw := os.Getenv("MEDIA_GC_INTERVAL"); raw != "" {
+		if cfg.Interval, err = time.ParseDuration(raw); err != nil || cfg.Interval < 0 {
+			return Config{}, fmt.Errorf("MEDIA_GC_INTERVAL invalide: %q", raw)
+		}
+	}
+	if raw := os.Getenv("MEDIA_GC_GRACE"); raw != "" {
+		if cfg.Grace, err = time.ParseDuration(raw); err != nil {
+			return Config{}, fmt.Errorf("MEDIA_GC_GRACE invalide: %q", raw)
+		}
+	}
+	if raw := os.Getenv("MEDIA_GC_DRY_RUN"); raw != "" {
+		if cfg.DryRun, err = strconv.ParseBool(raw); err != nil {
+			return Config{}, fmt.Errorf("MEDIA_GC_DRY_RUN invalide: %q", raw)
+		}
+	}
+	return cfg, nil
+}
+
+// Collector lance périodiquement le ramasse-miettes des médias orphelins (service.CollectGarbage).
+// En dry-run, chaque passage journalise seulement ce qui serait supprimé.
+type Collector struct {
+	svc *service.MediaService
+
+	interval time.Duration
+	dryRun   bool
+}
+
+func New(svc *service.MediaService, cfg Config) *Collector {
+	return &Collector{svc: svc, interval: cfg.Interval, dryRun: cfg.DryRun}
+}
+
+// Run passe à chaque intervalle jusqu'à l'annulation du contexte ; ne fait rien si l'intervalle est nul.
+func (c *Collector) Run(ctx context.Context) {
+	if c.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := c.RunOnce(ctx, time.Now()); err != nil {
+				log.Printf("[gc] run: %v", err)
+			}
+		}
+	}
+}
+
+// RunOnce fait un passage à now et journalise son bilan.
+func (c *Collector) RunOnce(ctx context.Context, now time.Time) (*service.GCReport, error) {
+	report, err := c.svc.CollectGarbage(ctx, now, c.dryRun)
+	if report != nil {
+		verb := "supprimés"
+		if report.DryRun {
+			verb = "à supprimer (dry-run)"
+		}
+		log.Printf("[gc] %d/%d médias et %d/%d objets (%d octets) %s, %d échecs",
+			report.OrphanedMedia, report.ScannedMedia, report.OrphanedObjects, report.ScannedObjects,
+			report.ReclaimedBytes, verb, report.Failed)
+	}
+	return report, err
+}
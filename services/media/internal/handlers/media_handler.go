@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/Mathis-brgs/storm-project/services/media/internal/service"
@@ -11,6 +13,9 @@ import (
 
 const maxUploadSize = 50 << 20 // 50 MB
 
+// headerUserID : utilisateur authentifié, renseigné par le gateway (routes internes, non exposées).
+const headerUserID = "X-User-ID"
+
 type MediaHandler struct {
 	service *service.MediaService
 }
@@ -31,7 +36,7 @@ func (h *MediaHandler) healthHandler(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("OK"))
 }
 
-// POST /media/upload — multipart file upload
+// POST /media/upload — multipart file upload (champs optionnels conversationId, voice=true pour une note vocale)
 func (h *MediaHandler) uploadHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
@@ -52,14 +57,40 @@ func (h *MediaHandler) uploadHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	defer file.Close()
 
-	contentType := header.Header.Get("Content-Type")
-	if contentType == "" {
-		contentType = "application/octet-stream"
+	conversationID := 0
+	if raw := r.FormValue("conversationId"); raw != "" {
+		conversationID, err = strconv.Atoi(raw)
+		if err != nil || conversationID <= 0 {
+			http.Error(w, "conversationId invalide", http.StatusBadRequest)
+			return
+		}
+	}
+
+	voice, err := parseVoice(r.FormValue("voice"))
+	if err != nil {
+		http.Error(w, "voice invalide", http.StatusBadRequest)
+		return
 	}
 
-	resp, err := h.service.UploadFromReader(r.Context(), header.Filename, contentType, file)
+	// Type et taille déclarés par le client : contrôlés contre le contenu réel par le service.
+	resp, err := h.service.UploadFromReader(r.Context(), service.UploadRequest{
+		Filename:       header.Filename,
+		ContentType:    header.Header.Get("Content-Type"),
+		Size:           header.Size,
+		OwnerID:        r.Header.Get(headerUserID),
+		ConversationID: conversationID,
+		Voice:          voice,
+	}, file)
 	if err != nil {
-		if strings.Contains(err.Error(), "non autorisé") {
+		if errors.Is(err, service.ErrOwnerRequired) {
+			http.Error(w, "en-tête "+headerUserID+" requis", http.StatusUnauthorized)
+			return
+		}
+		if errors.Is(err, service.ErrForbidden) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, service.ErrInvalidUpload) {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
@@ -73,7 +104,7 @@ func (h *MediaHandler) uploadHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
-// GET /media/{key...} — retourne l'URL du fichier ou redirige
+// GET /media/{key...}[?variant=thumbnail|medium] — redirige vers une URL signée de courte durée si l'utilisateur a accès au média
 func (h *MediaHandler) getMediaHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
@@ -87,12 +118,32 @@ func (h *MediaHandler) getMediaHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	url, err := h.service.GetURL(mediaID)
+	download, err := h.service.GetURL(r.Context(), r.Header.Get(headerUserID), mediaID, r.URL.Query().Get("variant"))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		switch {
+		case errors.Is(err, service.ErrForbidden):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case errors.Is(err, service.ErrMediaNotFound), errors.Is(err, service.ErrVariantNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, service.ErrScanPending):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, service.ErrMediaQuarantined):
+			http.Error(w, err.Error(), http.StatusLocked)
+		default:
+			log.Printf("download url error: %v", err)
+			http.Error(w, "erreur interne", http.StatusInternalServerError)
+		}
 		return
 	}
 
-	// Redirige vers l'URL MinIO/S3 du fichier
-	http.Redirect(w, r, url, http.StatusTemporaryRedirect)
+	// Redirige vers l'URL MinIO/S3 signée du fichier
+	http.Redirect(w, r, download.URL, http.StatusTemporaryRedirect)
+}
+
+// parseVoice : champ voice du formulaire, absent = false.
+func parseVoice(raw string) (bool, error) {
+	if raw == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(raw)
 }
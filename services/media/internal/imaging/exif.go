@@ -0,0 +1,238 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+const exifOrientationTag = 0x0112
+
+// Orientation lit l'orientation EXIF (1 à 8) d'un JPEG (segment APP1) ou d'un WebP (chunk EXIF).
+// Retourne 1 (aucune transformation) si elle est absente ou illisible.
+func Orientation(data []byte) int {
+	var tiff []byte
+	switch {
+	case len(data) > 2 && data[0] == 0xFF && data[1] == 0xD8:
+		tiff = jpegExif(data)
+	case len(data) > 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		tiff = webpExif(data)
+	}
+	if orientation := tiffOrientation(tiff); orientation >= 1 && orientation <= 8 {
+		return orientation
+	}
+	return 1
+}
+
+// jpegExif parcourt les segments jusqu'au début des données (SOS) à la recherche de APP1 « Exif ».
+func jpegExif(data []byte) []byte {
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return nil
+		}
+		marker := data[pos+1]
+		if marker == 0xD8 || (marker >= 0xD0 && marker <= 0xD7) || marker == 0x01 {
+			pos += 2
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 {
+			return nil
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			return nil
+		}
+		segment := data[pos+4 : end]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:]
+		}
+		pos = end
+	}
+	return nil
+}
+
+// webpExif parcourt les chunks RIFF à la recherche de « EXIF » (préfixe « Exif\0\0 » toléré).
+func webpExif(data []byte) []byte {
+	pos := 12
+	for pos+8 <= len(data) {
+		size := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		end := pos + 8 + size
+		if size < 0 || end > len(data) {
+			return nil
+		}
+		if string(data[pos:pos+4]) == "EXIF" {
+			return bytes.TrimPrefix(data[pos+8:end], []byte("Exif\x00\x00"))
+		}
+		pos = end + size%2 // les chunks sont alignés sur 2 octets
+	}
+	return nil
+}
+
+// tiffOrientation lit le tag Orientation de l'IFD0 d'un bloc TIFF ; 0 si absent.
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[0:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == exifOrientationTag {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 0
+}
+
+// StripMetadata retire de l'original les métadonnées (EXIF dont GPS, XMP, IPTC, commentaires, textes PNG)
+// sans le réencoder ; seule l'orientation EXIF est conservée, dans un bloc EXIF minimal, pour que
+// l'affichage reste redressé. Les profils de couleur (ICC) sont gardés. Retourne data inchangé si le
+// format n'est pas reconnu ou si sa structure est illisible.
+func StripMetadata(data []byte) []byte {
+	var stripped []byte
+	switch {
+	case len(data) > 2 && data[0] == 0xFF && data[1] == 0xD8:
+		stripped = stripJPEG(data, Orientation(data))
+	case len(data) > 8 && bytes.HasPrefix(data, pngSignature):
+		stripped = stripPNG(data)
+	case len(data) > 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		stripped = stripWebP(data, Orientation(data))
+	}
+	if stripped == nil {
+		return data
+	}
+	return stripped
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// orientationTIFF : bloc TIFF (big-endian) dont l'IFD0 ne porte que le tag Orientation.
+func orientationTIFF(orientation int) []byte {
+	tiff := []byte("MM\x00\x2A\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, exifOrientationTag)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3) // SHORT
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, uint16(orientation))
+	return append(tiff, 0, 0, 0, 0, 0, 0) // bourrage de la valeur, pas d'IFD suivant
+}
+
+// stripJPEG retire les segments APP1 (EXIF, XMP), APP13 (IPTC) et COM avant les données (SOS).
+func stripJPEG(data []byte, orientation int) []byte {
+	out := make([]byte, 0, len(data))
+	out = append(out, 0xFF, 0xD8)
+	if orientation > 1 {
+		payload := append([]byte("Exif\x00\x00"), orientationTIFF(orientation)...)
+		out = append(out, 0xFF, 0xE1)
+		out = binary.BigEndian.AppendUint16(out, uint16(len(payload)+2))
+		out = append(out, payload...)
+	}
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return nil
+		}
+		marker := data[pos+1]
+		if marker == 0xD8 || (marker >= 0xD0 && marker <= 0xD7) || marker == 0x01 {
+			out = append(out, data[pos:pos+2]...)
+			pos += 2
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 {
+			return append(out, data[pos:]...)
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			return nil
+		}
+		if marker != 0xE1 && marker != 0xED && marker != 0xFE {
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+	}
+	return nil
+}
+
+// stripPNG retire les chunks eXIf, tEXt, zTXt, iTXt et tIME.
+func stripPNG(data []byte) []byte {
+	out := append(make([]byte, 0, len(data)), pngSignature...)
+	pos := len(pngSignature)
+	for pos+12 <= len(data) {
+		size := int(binary.BigEndian.Uint32(data[pos:]))
+		end := pos + 12 + size
+		if size < 0 || end > len(data) {
+			return nil
+		}
+		switch string(data[pos+4 : pos+8]) {
+		case "eXIf", "tEXt", "zTXt", "iTXt", "tIME":
+		default:
+			out = append(out, data[pos:end]...)
+		}
+		if string(data[pos+4:pos+8]) == "IEND" {
+			return out
+		}
+		pos = end
+	}
+	return nil
+}
+
+// vp8xExifFlag et vp8xXMPFlag : bits du chunk VP8X annonçant les chunks EXIF et XMP.
+const (
+	vp8xExifFlag = 0x08
+	vp8xXMPFlag  = 0x04
+)
+
+// stripWebP retire les chunks EXIF et « XMP  » ; l'orientation est réécrite dans un chunk EXIF minimal.
+func stripWebP(data []byte, orientation int) []byte {
+	out := append(make([]byte, 0, len(data)), data[:12]...)
+	pos := 12
+	vp8x := -1
+	for pos+8 <= len(data) {
+		size := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		end := pos + 8 + size + size%2 // les chunks sont alignés sur 2 octets
+		if size < 0 || end > len(data) {
+			return nil
+		}
+		switch string(data[pos : pos+4]) {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			vp8x = len(out)
+			out = append(out, data[pos:end]...)
+		default:
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+	}
+	if pos != len(data) {
+		return nil
+	}
+	if vp8x >= 0 && vp8x+8 < len(out) {
+		out[vp8x+8] &^= vp8xExifFlag | vp8xXMPFlag
+		if orientation > 1 {
+			tiff := orientationTIFF(orientation)
+			out = append(out, "EXIF"...)
+			out = binary.LittleEndian.AppendUint32(out, uint32(len(tiff)))
+			out = append(out, tiff...)
+			out[vp8x+8] |= vp8xExifFlag
+		}
+	}
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out
+}
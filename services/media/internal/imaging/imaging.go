@@ -0,0 +1,229 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// MaxPixels borne la taille décodée (protection contre les « bombes » de décompression).
+	MaxPixels = 24_000_000
+	// MaxDecodedBytes borne la mémoire de l'original décodé (estimée au pire selon le modèle de couleur :
+	// 3 octets par pixel pour un JPEG couleur, 4 pour un PNG RGBA). Avec un seul décodage à la fois
+	// (decoding), le traitement tient dans la limite mémoire du pod (128 Mi).
+	MaxDecodedBytes = 40 << 20
+	// JPEGQuality : qualité des variantes opaques.
+	JPEGQuality = 82
+)
+
+var ErrTooLarge = errors.New("image trop grande")
+
+// decoding : un seul original décodé en mémoire à la fois, quel que soit le nombre de workers.
+var decoding sync.Mutex
+
+// Spec : variante à générer, bornée à MaxSize pixels sur son plus grand côté.
+type Spec struct {
+	Name    string
+	MaxSize int
+}
+
+// Specs : variantes générées après chaque upload d'image.
+var Specs = []Spec{
+	{Name: "thumbnail", MaxSize: 256},
+	{Name: "medium", MaxSize: 1024},
+}
+
+// Variant : image encodée. Les métadonnées (EXIF, GPS) de l'original ne sont jamais recopiées.
+type Variant struct {
+	Name        string
+	Data        []byte
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// Supported indique si le type MIME est décodable (les GIF, souvent animés, et les vidéos sont ignorés).
+func Supported(contentType string) bool {
+	switch strings.ToLower(strings.TrimSpace(contentType)) {
+	case "image/jpeg", "image/png", "image/webp":
+		return true
+	}
+	return false
+}
+
+// Generate décode data, redresse l'image selon son orientation EXIF puis produit une variante par spec,
+// sans jamais agrandir. Les variantes opaques sont encodées en JPEG, les autres en PNG (transparence conservée).
+func Generate(data []byte, specs []Spec) ([]Variant, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("décodage image: %w", err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MaxPixels ||
+		int64(config.Width)*int64(config.Height)*bytesPerPixel(config.ColorModel) > MaxDecodedBytes {
+		return nil, fmt.Errorf("%w: %dx%d", ErrTooLarge, config.Width, config.Height)
+	}
+
+	base, err := decodeReduced(data, largestSize(specs))
+	if err != nil {
+		return nil, err
+	}
+	oriented := orient(base, Orientation(data))
+	opaque := oriented.Opaque()
+
+	variants := make([]Variant, 0, len(specs))
+	for _, spec := range specs {
+		resized := resize(oriented, spec.MaxSize)
+		encoded, contentType, err := encode(resized, opaque)
+		if err != nil {
+			return nil, fmt.Errorf("encodage %s: %w", spec.Name, err)
+		}
+		bounds := resized.Bounds()
+		variants = append(variants, Variant{
+			Name:        spec.Name,
+			Data:        encoded,
+			ContentType: contentType,
+			Width:       bounds.Dx(),
+			Height:      bounds.Dy(),
+		})
+	}
+	return variants, nil
+}
+
+// decodeReduced décode data puis le réduit aussitôt à maxSize : seule la plus grande variante, et non
+// l'original pleine résolution, est recopiée (les autres en sont tirées).
+func decodeReduced(data []byte, maxSize int) (*image.NRGBA, error) {
+	decoding.Lock()
+	defer decoding.Unlock()
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("décodage image: %w", err)
+	}
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if maxSize <= 0 || (w <= maxSize && h <= maxSize) {
+		return toNRGBA(src), nil
+	}
+	dw, dh := scaledSize(w, h, maxSize)
+	// Destination RGBA : chemin rapide de draw pour les sources YCbCr, RGBA et NRGBA.
+	scaled := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	draw.CatmullRom.Scale(scaled, scaled.Bounds(), src, bounds, draw.Src, nil)
+	return toNRGBA(scaled), nil
+}
+
+// bytesPerPixel : mémoire d'un pixel décodé, au pire (JPEG YCbCr sans sous-échantillonnage).
+func bytesPerPixel(model color.Model) int64 {
+	switch model {
+	case color.GrayModel:
+		return 1
+	case color.Gray16Model:
+		return 2
+	case color.YCbCrModel:
+		return 3
+	case color.RGBA64Model, color.NRGBA64Model:
+		return 8
+	}
+	if _, ok := model.(color.Palette); ok {
+		return 1
+	}
+	return 4
+}
+
+func largestSize(specs []Spec) int {
+	largest := 0
+	for _, spec := range specs {
+		if spec.MaxSize <= 0 {
+			return 0
+		}
+		largest = max(largest, spec.MaxSize)
+	}
+	return largest
+}
+
+func toNRGBA(src image.Image) *image.NRGBA {
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	return dst
+}
+
+// orient applique l'orientation EXIF : 2-4 miroirs/rotation 180°, 5-8 transpositions (largeur et hauteur échangées).
+func orient(src *image.NRGBA, orientation int) *image.NRGBA {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // miroir horizontal
+				dx, dy = w-1-x, y
+			case 3: // rotation 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // miroir vertical
+				dx, dy = x, h-1-y
+			case 5: // transposition
+				dx, dy = y, x
+			case 6: // rotation 90° horaire
+				dx, dy = h-1-y, x
+			case 7: // transverse
+				dx, dy = h-1-y, w-1-x
+			case 8: // rotation 90° anti-horaire
+				dx, dy = y, w-1-x
+			}
+			si := src.PixOffset(x, y)
+			di := dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+	return dst
+}
+
+func resize(src *image.NRGBA, maxSize int) *image.NRGBA {
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	if maxSize <= 0 || (w <= maxSize && h <= maxSize) {
+		return src
+	}
+	dw, dh := scaledSize(w, h, maxSize)
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, src.Bounds(), draw.Src, nil)
+	return dst
+}
+
+// scaledSize : dimensions réduites pour que le plus grand côté vaille maxSize.
+func scaledSize(w, h, maxSize int) (int, int) {
+	dw, dh := maxSize, h*maxSize/w
+	if h > w {
+		dw, dh = w*maxSize/h, maxSize
+	}
+	return max(dw, 1), max(dh, 1)
+}
+
+func encode(img *image.NRGBA, opaque bool) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if opaque {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: JPEGQuality}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	}
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/png", nil
+}
@@ -0,0 +1,212 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// exifSegment : APP1 « Exif » minimal (TIFF little-endian, IFD0 avec le seul tag Orientation).
+func exifSegment(orientation uint16) []byte {
+	tiff := []byte("II\x2A\x00\x08\x00\x00\x00")
+	tiff = binary.LittleEndian.AppendUint16(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, exifOrientationTag)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3) // SHORT
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// halfRedHalfBlue : moitié gauche rouge, moitié droite bleue.
+func halfRedHalfBlue(w, h int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.NRGBA{R: 255, A: 255}
+			if x >= w/2 {
+				c = color.NRGBA{B: 255, A: 255}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func jpegWithOrientation(t *testing.T, img image.Image, orientation uint16) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatalf("jpeg.Encode() error = %v", err)
+	}
+	data := buf.Bytes()
+	// Segment EXIF inséré juste après SOI, comme le font les appareils photo.
+	return append(append([]byte{0xFF, 0xD8}, exifSegment(orientation)...), data[2:]...)
+}
+
+func isRed(c color.Color) bool {
+	r, _, b, _ := c.RGBA()
+	return r > 0xC000 && b < 0x4000
+}
+
+func isBlue(c color.Color) bool {
+	r, _, b, _ := c.RGBA()
+	return b > 0xC000 && r < 0x4000
+}
+
+func TestOrientation(t *testing.T) {
+	data := jpegWithOrientation(t, halfRedHalfBlue(8, 4), 6)
+	if got := Orientation(data); got != 6 {
+		t.Fatalf("Orientation(jpeg) = %d, want 6", got)
+	}
+
+	tiff := exifSegment(3)[10:] // sans marqueur, longueur ni préfixe « Exif »
+	chunk := append([]byte("EXIF"), binary.LittleEndian.AppendUint32(nil, uint32(len(tiff)))...)
+	chunk = append(chunk, tiff...)
+	webp := append([]byte("RIFF\x00\x00\x00\x00WEBPVP8X"), binary.LittleEndian.AppendUint32(nil, 10)...)
+	webp = append(webp, make([]byte, 10)...)
+	webp = append(webp, chunk...)
+	if got := Orientation(webp); got != 3 {
+		t.Fatalf("Orientation(webp) = %d, want 3", got)
+	}
+
+	if got := Orientation([]byte("not an image")); got != 1 {
+		t.Fatalf("Orientation(garbage) = %d, want 1", got)
+	}
+}
+
+func TestGenerateAppliesOrientationAndStripsExif(t *testing.T) {
+	data := jpegWithOrientation(t, halfRedHalfBlue(40, 20), 6)
+
+	variants, err := Generate(data, []Spec{{Name: "thumbnail", MaxSize: 10}, {Name: "medium", MaxSize: 100}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(variants) != 2 {
+		t.Fatalf("expected 2 variants, got %d", len(variants))
+	}
+
+	thumb := variants[0]
+	// 40x20 tourné de 90° → 20x40, réduit à 10 px de haut.
+	if thumb.Width != 5 || thumb.Height != 10 || thumb.ContentType != "image/jpeg" {
+		t.Fatalf("unexpected thumbnail %dx%d %s", thumb.Width, thumb.Height, thumb.ContentType)
+	}
+	// Jamais agrandi.
+	if medium := variants[1]; medium.Width != 20 || medium.Height != 40 {
+		t.Fatalf("unexpected medium %dx%d", medium.Width, medium.Height)
+	}
+
+	for _, variant := range variants {
+		if bytes.Contains(variant.Data, []byte("Exif")) {
+			t.Fatalf("%s variant must not carry EXIF metadata", variant.Name)
+		}
+		if Orientation(variant.Data) != 1 {
+			t.Fatalf("%s variant must not carry an orientation", variant.Name)
+		}
+	}
+
+	decoded, err := jpeg.Decode(bytes.NewReader(variants[1].Data))
+	if err != nil {
+		t.Fatalf("jpeg.Decode() error = %v", err)
+	}
+	// Rotation horaire : la moitié gauche (rouge) de l'original se retrouve en haut.
+	if !isRed(decoded.At(10, 2)) || !isBlue(decoded.At(10, 37)) {
+		t.Fatalf("variant not rotated: top %v, bottom %v", decoded.At(10, 2), decoded.At(10, 37))
+	}
+}
+
+func TestGenerateKeepsTransparencyAsPNG(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 30, 30))
+	img.SetNRGBA(1, 1, color.NRGBA{G: 255, A: 128})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+
+	variants, err := Generate(buf.Bytes(), []Spec{{Name: "thumbnail", MaxSize: 15}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if variants[0].ContentType != "image/png" || variants[0].Width != 15 || variants[0].Height != 15 {
+		t.Fatalf("unexpected variant %+v", variants[0])
+	}
+}
+
+func TestGenerateRejectsInvalidData(t *testing.T) {
+	if _, err := Generate([]byte("fake png content"), Specs); err == nil {
+		t.Fatal("invalid image data should be rejected")
+	}
+	if Supported("image/gif") || Supported("video/mp4") || !Supported("image/webp") {
+		t.Fatal("unexpected Supported() result")
+	}
+}
+
+func TestStripMetadata(t *testing.T) {
+	// JPEG : EXIF complet (GPS), XMP et commentaire retirés, orientation conservée.
+	gps := exifSegment(6)
+	gps = append(gps[:len(gps)-4], []byte("GPSLatitude=48.85")...)
+	binary.BigEndian.PutUint16(gps[2:], uint16(len(gps)-2))
+	xmp := append([]byte{0xFF, 0xE1, 0x00, 0x1B}, []byte("http://ns.adobe.com/xap/1.0/")[:25]...)
+	comment := append([]byte{0xFF, 0xFE, 0x00, 0x08}, []byte("Paris!")...)
+	original := jpegWithOrientation(t, halfRedHalfBlue(8, 4), 1)
+	data := append([]byte{0xFF, 0xD8}, gps...)
+	data = append(append(append(data, xmp...), comment...), original[2:]...)
+
+	stripped := StripMetadata(data)
+	for _, leak := range []string{"GPSLatitude", "adobe", "Paris"} {
+		if bytes.Contains(stripped, []byte(leak)) {
+			t.Fatalf("stripped JPEG still contains %q", leak)
+		}
+	}
+	if got := Orientation(stripped); got != 6 {
+		t.Fatalf("Orientation(stripped JPEG) = %d, want 6", got)
+	}
+	if _, err := jpeg.Decode(bytes.NewReader(stripped)); err != nil {
+		t.Fatalf("jpeg.Decode(stripped) error = %v", err)
+	}
+	if plain := StripMetadata(original); bytes.Contains(plain, []byte("Exif")) || len(plain) >= len(original) {
+		t.Fatalf("orientation 1 must not be rewritten (%d -> %d bytes)", len(original), len(plain))
+	}
+
+	// PNG : chunks texte retirés.
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, halfRedHalfBlue(4, 4)); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	encoded := buf.Bytes()
+	text := binary.BigEndian.AppendUint32(nil, 10)
+	text = append(append(text, "tEXtGPS\x0048.85N"...), 0, 0, 0, 0)
+	withText := append(append(append([]byte(nil), encoded[:33]...), text...), encoded[33:]...) // après IHDR
+	if stripped := StripMetadata(withText); !bytes.Equal(stripped, encoded) {
+		t.Fatalf("stripped PNG = %d bytes, want the original %d bytes", len(stripped), len(encoded))
+	}
+
+	// WebP : chunks EXIF et XMP retirés, orientation réécrite, drapeaux VP8X et taille RIFF à jour.
+	webp := append([]byte("RIFF\x00\x00\x00\x00WEBPVP8X"), binary.LittleEndian.AppendUint32(nil, 10)...)
+	webp = append(webp, vp8xExifFlag|vp8xXMPFlag, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+	tiff := append(exifSegment(8)[10:], "GPS!"...)
+	webp = append(append(webp, "EXIF"...), binary.LittleEndian.AppendUint32(nil, uint32(len(tiff)))...)
+	webp = append(webp, tiff...)
+	webp = append(append(webp, "XMP "...), binary.LittleEndian.AppendUint32(nil, 4)...)
+	webp = append(webp, "<x/>"...)
+	binary.LittleEndian.PutUint32(webp[4:], uint32(len(webp)-8))
+	stripped = StripMetadata(webp)
+	if bytes.Contains(stripped, []byte("GPS!")) || bytes.Contains(stripped, []byte("<x/>")) || Orientation(stripped) != 8 {
+		t.Fatalf("unexpected stripped WebP %q", stripped)
+	}
+	if stripped[20]&vp8xXMPFlag != 0 || stripped[20]&vp8xExifFlag == 0 || int(binary.LittleEndian.Uint32(stripped[4:])) != len(stripped)-8 {
+		t.Fatalf("unexpected VP8X flags or RIFF size in %q", stripped)
+	}
+
+	if garbage := []byte("not an image"); !bytes.Equal(StripMetadata(garbage), garbage) {
+		t.Fatal("unknown formats must be returned unchanged")
+	}
+}
@@ -0,0 +1,60 @@
+package membership
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	// subjectMembershipCheck : request/reply JSON servi par le message-service.
+	subjectMembershipCheck = "GROUP_MEMBERSHIP_CHECK"
+	checkTimeout           = 2 * time.Second
+)
+
+// Requester est le sous-ensemble de *nats.Conn utilisé.
+type Requester interface {
+	Request(subject string, data []byte, timeout time.Duration) (*nats.Msg, error)
+}
+
+// Checker interroge le message-service pour savoir si un utilisateur est membre d'une conversation.
+type Checker struct {
+	nc Requester
+}
+
+func NewChecker(nc Requester) *Checker {
+	return &Checker{nc: nc}
+}
+
+type checkRequest struct {
+	UserID         string `json:"user_id"`
+	ConversationID int    `json:"conversation_id"`
+}
+
+type checkResponse struct {
+	OK     bool   `json:"ok"`
+	Member bool   `json:"member"`
+	Error  string `json:"error"`
+}
+
+func (c *Checker) IsMember(userID string, conversationID int) (bool, error) {
+	payload, err := json.Marshal(checkRequest{UserID: userID, ConversationID: conversationID})
+	if err != nil {
+		return false, err
+	}
+	reply, err := c.nc.Request(subjectMembershipCheck, payload, checkTimeout)
+	if err != nil {
+		return false, err
+	}
+
+	var resp checkResponse
+	if err := json.Unmarshal(reply.Data, &resp); err != nil {
+		return false, err
+	}
+	if !resp.OK {
+		return false, errors.New(resp.Error)
+	}
+	return resp.Member, nil
+}
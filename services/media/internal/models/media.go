@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Statuts de génération des variantes ; vide pour un média sans variantes (vidéo, GIF).
+const (
+	ProcessingPending = "processing"
+	ProcessingReady   = "ready"
+	ProcessingFailed  = "failed"
+)
+
+// Statuts de l'analyse antivirus ; vide si aucun scanner n'est branché. Seul un média « clean » (ou non
+// analysé) est téléchargeable : « infected » est en quarantaine, « failed » (contenu illisible) aussi.
+// « too_large » : au-delà de la limite du moteur, téléchargeable selon MEDIA_SCAN_OVERSIZE.
+const (
+	ScanPending  = "pending"
+	ScanClean    = "clean"
+	ScanInfected = "infected"
+	ScanFailed   = "failed"
+	ScanTooLarge = "too_large"
+)
+
+// Media : référence à un fichier stocké, créée à chaque upload. ID (media/<nanos>_<nom>) identifie la
+// référence ; ObjectKey est la clé de l'objet, adressée par contenu (objects/<sha256>) et partagée par
+// tous les uploads identiques. ConversationID vaut 0 pour un média rattaché à aucune conversation.
+type Media struct {
+	ID             string    `json:"id"`
+	ObjectKey      string    `json:"objectKey"`
+	OwnerID        string    `json:"ownerId"`
+	ConversationID int       `json:"conversationId,omitempty"`
+	ContentType    string    `json:"contentType"`
+	Size           int64     `json:"size"`
+	Checksum       string    `json:"checksum"`
+	CreatedAt      time.Time `json:"createdAt"`
+	// Category (image, video, audio, voice, document) et Filename : nom d'origine, affiché pour les documents.
+	Category string `json:"category,omitempty"`
+	Filename string `json:"filename,omitempty"`
+	// DurationMs (audio, notes vocales) et Waveform (notes vocales : amplitudes 0-100), lus à l'upload.
+	DurationMs int64 `json:"durationMs,omitempty"`
+	Waveform   []int `json:"waveform,omitempty"`
+	// Status et Variants : miniatures générées en tâche de fond pour les images.
+	Status   string         `json:"status,omitempty"`
+	Variants []MediaVariant `json:"variants,omitempty"`
+	// ScanStatus et ScanSignature : verdict de l'analyse antivirus, signature détectée si infecté.
+	ScanStatus    string `json:"scanStatus,omitempty"`
+	ScanSignature string `json:"scanSignature,omitempty"`
+}
+
+// MediaVariant : version redimensionnée d'une image, stockée sous une clé dérivée de l'original.
+// Width, Height et Size sont renseignés une fois la variante générée.
+type MediaVariant struct {
+	Name        string `json:"name"`
+	Key         string `json:"key"`
+	ContentType string `json:"contentType,omitempty"`
+	Width       int    `json:"width,omitempty"`
+	Height      int    `json:"height,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// UploadSession : upload reprenable d'un gros fichier, envoyé en parties de ChunkSize octets sur un
+// upload multipart S3 (StorageUploadID) vers Key (pending/...). MediaID est renseigné une fois le
+// média finalisé ; la session est supprimée à ExpiresAt.
+type UploadSession struct {
+	ID              string    `json:"id"`
+	OwnerID         string    `json:"ownerId"`
+	ConversationID  int       `json:"conversationId,omitempty"`
+	Filename        string    `json:"filename"`
+	ContentType     string    `json:"contentType"`
+	Size            int64     `json:"size"`
+	ChunkSize       int64     `json:"chunkSize"`
+	Key             string    `json:"key"`
+	StorageUploadID string    `json:"-"`
+	MediaID         string    `json:"mediaId,omitempty"`
+	CreatedAt       time.Time `json:"createdAt"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+}
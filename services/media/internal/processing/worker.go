@@ -0,0 +1,194 @@
+package processing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/service"
+)
+
+const (
+	// SubjectMediaProcessed : publié à la fin du traitement d'un média (prêt ou en échec).
+	SubjectMediaProcessed = "media.processed"
+	// SubjectMediaScanCompleted : publié après l'analyse antivirus d'un média (clean, infected ou failed).
+	SubjectMediaScanCompleted = "media.scan.completed"
+
+	defaultWorkers   = 2
+	defaultQueueSize = 128
+
+	// Analyse retentée tant que le moteur ne répond pas : délai doublé à chaque échec, plafonné.
+	scanRetryBase = 30 * time.Second
+	scanRetryMax  = 15 * time.Minute
+)
+
+// Publisher est le sous-ensemble de *nats.Conn utilisé.
+type Publisher interface {
+	Publish(subject string, data []byte) error
+}
+
+// ProcessedEvent : payload de media.processed, relayé par le gateway à la room de la conversation
+// (ou à celle du propriétaire pour un média hors conversation).
+type ProcessedEvent struct {
+	MediaID        string                `json:"mediaId"`
+	OwnerID        string                `json:"ownerId"`
+	ConversationID int                   `json:"conversationId,omitempty"`
+	Status         string                `json:"status"`
+	Variants       []models.MediaVariant `json:"variants,omitempty"`
+}
+
+// ScanCompletedEvent : payload de media.scan.completed, relayé comme media.processed ; un média « clean »
+// devient téléchargeable, « infected » reste en quarantaine (Signature : logiciel malveillant détecté),
+// « too_large » selon MEDIA_SCAN_OVERSIZE.
+type ScanCompletedEvent struct {
+	MediaID        string `json:"mediaId"`
+	OwnerID        string `json:"ownerId"`
+	ConversationID int    `json:"conversationId,omitempty"`
+	ScanStatus     string `json:"scanStatus"`
+	Signature      string `json:"signature,omitempty"`
+}
+
+// Worker analyse les nouveaux médias (antivirus) puis génère les variantes d'image en tâche de fond :
+// l'upload n'attend ni l'analyse ni le redimensionnement.
+// Le décodage est coûteux en mémoire, d'où un nombre de workers volontairement faible.
+type Worker struct {
+	svc       *service.MediaService
+	publisher Publisher
+	queue     chan string
+	workers   int
+	retryBase time.Duration
+	retryMax  time.Duration
+
+	mu sync.Mutex
+	// retries : échecs consécutifs de l'analyse par média (moteur injoignable).
+	retries map[string]int
+}
+
+func NewWorker(svc *service.MediaService, publisher Publisher) *Worker {
+	return &Worker{
+		svc:       svc,
+		publisher: publisher,
+		queue:     make(chan string, defaultQueueSize),
+		workers:   defaultWorkers,
+		retryBase: scanRetryBase,
+		retryMax:  scanRetryMax,
+		retries:   make(map[string]int),
+	}
+}
+
+// Enqueue planifie le traitement de mediaID ; si la file est pleine, il est replanifié après retryBase
+// (le média reste « pending » : une file pleine n'est pas un verdict).
+func (w *Worker) Enqueue(mediaID string) {
+	select {
+	case w.queue <- mediaID:
+	default:
+		log.Printf("[processing] queue full, retrying %s in %s", mediaID, w.retryBase)
+		time.AfterFunc(w.retryBase, func() { w.Enqueue(mediaID) })
+	}
+}
+
+// Run démarre les workers jusqu'à l'annulation du contexte.
+func (w *Worker) Run(ctx context.Context) {
+	for i := 0; i < w.workers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case mediaID := <-w.queue:
+					if _, err := w.Process(ctx, mediaID); err != nil {
+						log.Printf("[processing] %s: %v", mediaID, err)
+					}
+				}
+			}
+		}()
+	}
+	<-ctx.Done()
+}
+
+// Process traite mediaID de façon synchrone (utilisé par les tests et par les workers) : analyse
+// antivirus (media.scan.completed) puis variantes (media.processed), publiés y compris en cas d'échec.
+// Moteur d'analyse injoignable : le média reste « pending » et est replanifié (retryLater).
+func (w *Worker) Process(ctx context.Context, mediaID string) (*models.Media, error) {
+	scanned, ok, scanErr := w.svc.ScanMedia(ctx, mediaID)
+	if errors.Is(scanErr, service.ErrScanUnavailable) {
+		w.retryLater(mediaID)
+		return scanned, scanErr
+	}
+	w.forgetRetries(mediaID)
+	if ok {
+		w.publishScan(scanned)
+	}
+	if scanned == nil {
+		return nil, scanErr
+	}
+	media, err := w.svc.GenerateVariants(ctx, mediaID)
+	w.publish(media)
+	return media, errors.Join(scanErr, err)
+}
+
+// retryLater replanifie mediaID après scanRetryBase, doublé à chaque échec consécutif (scanRetryMax au plus).
+func (w *Worker) retryLater(mediaID string) {
+	w.mu.Lock()
+	attempt := w.retries[mediaID]
+	w.retries[mediaID] = attempt + 1
+	w.mu.Unlock()
+
+	delay := w.retryMax
+	if attempt < 16 {
+		delay = min(w.retryBase<<attempt, w.retryMax)
+	}
+	log.Printf("[processing] %s: analyse retentée dans %s", mediaID, delay)
+	time.AfterFunc(delay, func() { w.Enqueue(mediaID) })
+}
+
+func (w *Worker) forgetRetries(mediaID string) {
+	w.mu.Lock()
+	delete(w.retries, mediaID)
+	w.mu.Unlock()
+}
+
+func (w *Worker) publish(media *models.Media) {
+	if media == nil || w.publisher == nil {
+		return
+	}
+	if media.Status != models.ProcessingReady && media.Status != models.ProcessingFailed {
+		return
+	}
+	payload, err := json.Marshal(ProcessedEvent{
+		MediaID:        media.ID,
+		OwnerID:        media.OwnerID,
+		ConversationID: media.ConversationID,
+		Status:         media.Status,
+		Variants:       media.Variants,
+	})
+	if err != nil {
+		return
+	}
+	if err := w.publisher.Publish(SubjectMediaProcessed, payload); err != nil {
+		log.Printf("[processing] publish %s: %v", media.ID, err)
+	}
+}
+
+func (w *Worker) publishScan(media *models.Media) {
+	if w.publisher == nil {
+		return
+	}
+	payload, err := json.Marshal(ScanCompletedEvent{
+		MediaID:        media.ID,
+		OwnerID:        media.OwnerID,
+		ConversationID: media.ConversationID,
+		ScanStatus:     media.ScanStatus,
+		Signature:      media.ScanSignature,
+	})
+	if err != nil {
+		return
+	}
+	if err := w.publisher.Publish(SubjectMediaScanCompleted, payload); err != nil {
+		log.Printf("[processing] publish scan %s: %v", media.ID, err)
+	}
+}
@@ -0,0 +1,122 @@
+package references
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	// subjectMediaReferences : request/reply JSON servi par le message-service (messages, avatars de groupe).
+	subjectMediaReferences = "MEDIA_REFERENCES_CHECK"
+	// subjectUserMediaReferences : pattern NestJS du user-service (avatars des utilisateurs).
+	subjectUserMediaReferences = "user.media_references"
+	checkTimeout               = 5 * time.Second
+)
+
+// Requester est le sous-ensemble de *nats.Conn utilisé.
+type Requester interface {
+	Request(subject string, data []byte, timeout time.Duration) (*nats.Msg, error)
+}
+
+// Checker interroge le message-service et le user-service pour savoir quels médias sont encore utilisés.
+type Checker struct {
+	nc Requester
+}
+
+func NewChecker(nc Requester) *Checker {
+	return &Checker{nc: nc}
+}
+
+type checkRequest struct {
+	MediaIDs []string `json:"media_ids"`
+}
+
+type checkResponse struct {
+	OK         bool     `json:"ok"`
+	Referenced []string `json:"referenced"`
+	Error      string   `json:"error"`
+}
+
+// userCheckRequest : enveloppe NestJS de la requête user.media_references.
+type userCheckRequest struct {
+	Pattern string       `json:"pattern"`
+	Data    checkRequest `json:"data"`
+	ID      string       `json:"id"`
+}
+
+type userCheckResponse struct {
+	Response struct {
+		Referenced []string `json:"referenced"`
+	} `json:"response"`
+	Err json.RawMessage `json:"err"`
+}
+
+// Referenced retourne les media IDs de mediaIDs attachés à un message non supprimé ou à un message
+// programmé pas encore envoyé, ou utilisés comme avatar d'un groupe ou d'un utilisateur. Une erreur de
+// l'un des services empêche toute suppression.
+func (c *Checker) Referenced(mediaIDs []string) (map[string]bool, error) {
+	referenced, err := c.messageReferences(mediaIDs)
+	if err != nil {
+		return nil, err
+	}
+	avatars, err := c.userReferences(mediaIDs)
+	if err != nil {
+		return nil, fmt.Errorf("avatars utilisateurs: %w", err)
+	}
+	for _, id := range avatars {
+		referenced[id] = true
+	}
+	return referenced, nil
+}
+
+func (c *Checker) messageReferences(mediaIDs []string) (map[string]bool, error) {
+	payload, err := json.Marshal(checkRequest{MediaIDs: mediaIDs})
+	if err != nil {
+		return nil, err
+	}
+	reply, err := c.nc.Request(subjectMediaReferences, payload, checkTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp checkResponse
+	if err := json.Unmarshal(reply.Data, &resp); err != nil {
+		return nil, err
+	}
+	if !resp.OK {
+		return nil, errors.New(resp.Error)
+	}
+	referenced := make(map[string]bool, len(resp.Referenced))
+	for _, id := range resp.Referenced {
+		referenced[id] = true
+	}
+	return referenced, nil
+}
+
+func (c *Checker) userReferences(mediaIDs []string) ([]string, error) {
+	payload, err := json.Marshal(userCheckRequest{
+		Pattern: subjectUserMediaReferences,
+		Data:    checkRequest{MediaIDs: mediaIDs},
+		ID:      time.Now().String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	reply, err := c.nc.Request(subjectUserMediaReferences, payload, checkTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp userCheckResponse
+	if err := json.Unmarshal(reply.Data, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Err) > 0 && string(resp.Err) != "null" {
+		return nil, errors.New(string(resp.Err))
+	}
+	return resp.Response.Referenced, nil
+}
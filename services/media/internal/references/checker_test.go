@@ -0,0 +1,44 @@
+package references
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// fakeRequester : réponses par sujet.
+type fakeRequester struct {
+	replies map[string]string
+}
+
+func (f *fakeRequester) Request(subject string, _ []byte, _ time.Duration) (*nats.Msg, error) {
+	return &nats.Msg{Data: []byte(f.replies[subject])}, nil
+}
+
+func TestCheckerReferenced(t *testing.T) {
+	requester := &fakeRequester{replies: map[string]string{
+		subjectMediaReferences:     `{"ok":true,"referenced":["media/1_photo.png","media/2_logo.png"]}`,
+		subjectUserMediaReferences: `{"response":{"referenced":["media/3_avatar.png"]},"isDisposed":true}`,
+	}}
+	checker := NewChecker(requester)
+	ids := []string{"media/1_photo.png", "media/2_logo.png", "media/3_avatar.png", "media/4_orphelin.png"}
+
+	referenced, err := checker.Referenced(ids)
+	want := map[string]bool{"media/1_photo.png": true, "media/2_logo.png": true, "media/3_avatar.png": true}
+	if err != nil || !reflect.DeepEqual(referenced, want) {
+		t.Fatalf("Referenced() = %v, %v, want %v", referenced, err, want)
+	}
+
+	// Un service en erreur : rien n'est considéré comme libre.
+	requester.replies[subjectUserMediaReferences] = `{"err":{"status":"error","message":"Internal server error"}}`
+	if _, err := checker.Referenced(ids); err == nil {
+		t.Fatal("Referenced() must fail when the user-service fails")
+	}
+	requester.replies[subjectUserMediaReferences] = `{"response":{"referenced":[]}}`
+	requester.replies[subjectMediaReferences] = `{"ok":false,"error":"db down"}`
+	if _, err := checker.Referenced(ids); err == nil {
+		t.Fatal("Referenced() must fail when the message-service fails")
+	}
+}
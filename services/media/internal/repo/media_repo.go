@@ -0,0 +1,38 @@
+package repo
+
+import (
+	"errors"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/models"
+)
+
+var (
+	ErrMediaNotFound = errors.New("media not found")
+	ErrMediaExists   = errors.New("media already exists")
+)
+
+// MediaRepo : références aux médias et compteur de références de chaque objet stocké.
+type MediaRepo interface {
+	// CreateMedia enregistre une référence et incrémente le compteur de son objet (ObjectKey), sous le
+	// verrou de l'objet. ensure (optionnel) est appelé sous ce verrou si aucune référence ne retient
+	// l'objet, pour vérifier ou renvoyer son contenu ; son erreur annule l'enregistrement.
+	// ErrMediaExists si l'ID est déjà pris.
+	CreateMedia(media *models.Media, ensure func() error) (*models.Media, error)
+	// GetMedia : ErrMediaNotFound si l'ID est inconnu.
+	GetMedia(id string) (*models.Media, error)
+	// FindByObjectKey retourne une référence existante à l'objet, de préférence aux variantes prêtes ;
+	// ErrMediaNotFound si l'objet n'est pas référencé.
+	FindByObjectKey(objectKey string) (*models.Media, error)
+	// UpdateProcessing remplace le statut et les variantes ; ErrMediaNotFound si l'ID est inconnu.
+	UpdateProcessing(id, status string, variants []models.MediaVariant) (*models.Media, error)
+	// UpdateScan enregistre le verdict de l'analyse antivirus ; ErrMediaNotFound si l'ID est inconnu.
+	UpdateScan(id, status, signature string) (*models.Media, error)
+	// ListMedia retourne au plus limit références d'ID supérieur à afterID, par ID croissant (pagination).
+	ListMedia(afterID string, limit int) ([]*models.Media, error)
+	// DeleteMedia supprime la référence et décrémente le compteur de son objet ; retourne le nombre
+	// de références restantes (0 : l'objet peut être supprimé). ErrMediaNotFound si l'ID est inconnu.
+	DeleteMedia(id string) (int, error)
+	// DiscardObject appelle discard sous le verrou de l'objet s'il n'a plus aucune référence ; sans effet
+	// sinon. Un upload concurrent du même contenu attend la fin de la suppression.
+	DiscardObject(objectKey string, discard func() error) error
+}
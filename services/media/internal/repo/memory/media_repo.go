@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/repo"
+)
+
+type mediaRepo struct {
+	mu    sync.RWMutex
+	media map[string]*models.Media
+	refs  map[string]int // références par clé objet
+}
+
+func NewMediaRepo() repo.MediaRepo {
+	return &mediaRepo{media: make(map[string]*models.Media), refs: make(map[string]int)}
+}
+
+func (r *mediaRepo) CreateMedia(media *models.Media, ensure func() error) (*models.Media, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.media[media.ID]; ok {
+		return nil, repo.ErrMediaExists
+	}
+	if r.refs[media.ObjectKey] == 0 && ensure != nil {
+		if err := ensure(); err != nil {
+			return nil, err
+		}
+	}
+	stored := copyMedia(media)
+	r.media[media.ID] = stored
+	r.refs[media.ObjectKey]++
+	return copyMedia(stored), nil
+}
+
+func (r *mediaRepo) GetMedia(id string) (*models.Media, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	media, ok := r.media[id]
+	if !ok {
+		return nil, repo.ErrMediaNotFound
+	}
+	return copyMedia(media), nil
+}
+
+func (r *mediaRepo) FindByObjectKey(objectKey string) (*models.Media, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found *models.Media
+	for _, media := range r.media {
+		if media.ObjectKey != objectKey {
+			continue
+		}
+		if found == nil || (media.Status == models.ProcessingReady && found.Status != models.ProcessingReady) ||
+			(media.Status == found.Status && media.CreatedAt.After(found.CreatedAt)) {
+			found = media
+		}
+	}
+	if found == nil {
+		return nil, repo.ErrMediaNotFound
+	}
+	return copyMedia(found), nil
+}
+
+func (r *mediaRepo) UpdateProcessing(id, status string, variants []models.MediaVariant) (*models.Media, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	media, ok := r.media[id]
+	if !ok {
+		return nil, repo.ErrMediaNotFound
+	}
+	media.Status = status
+	media.Variants = append([]models.MediaVariant(nil), variants...)
+	return copyMedia(media), nil
+}
+
+func (r *mediaRepo) UpdateScan(id, status, signature string) (*models.Media, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	media, ok := r.media[id]
+	if !ok {
+		return nil, repo.ErrMediaNotFound
+	}
+	media.ScanStatus = status
+	media.ScanSignature = signature
+	return copyMedia(media), nil
+}
+
+func (r *mediaRepo) ListMedia(afterID string, limit int) ([]*models.Media, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var list []*models.Media
+	for id, media := range r.media {
+		if id > afterID {
+			list = append(list, copyMedia(media))
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	return list, nil
+}
+
+func (r *mediaRepo) DeleteMedia(id string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	media, ok := r.media[id]
+	if !ok {
+		return 0, repo.ErrMediaNotFound
+	}
+	delete(r.media, id)
+	r.refs[media.ObjectKey]--
+	remaining := r.refs[media.ObjectKey]
+	if remaining <= 0 {
+		delete(r.refs, media.ObjectKey)
+		remaining = 0
+	}
+	return remaining, nil
+}
+
+func (r *mediaRepo) DiscardObject(objectKey string, discard func() error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.refs[objectKey] > 0 {
+		return nil
+	}
+	return discard()
+}
+
+func copyMedia(media *models.Media) *models.Media {
+	cpy := *media
+	cpy.Variants = append([]models.MediaVariant(nil), media.Variants...)
+	cpy.Waveform = append([]int(nil), media.Waveform...)
+	return &cpy
+}
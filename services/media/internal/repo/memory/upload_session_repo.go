@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/repo"
+)
+
+type uploadSessionRepo struct {
+	mu       sync.RWMutex
+	sessions map[string]*models.UploadSession
+}
+
+func NewUploadSessionRepo() repo.UploadSessionRepo {
+	return &uploadSessionRepo{sessions: make(map[string]*models.UploadSession)}
+}
+
+func (r *uploadSessionRepo) CreateSession(session *models.UploadSession) (*models.UploadSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *session
+	r.sessions[session.ID] = &stored
+	cpy := stored
+	return &cpy, nil
+}
+
+func (r *uploadSessionRepo) GetSession(id string) (*models.UploadSession, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, repo.ErrSessionNotFound
+	}
+	cpy := *session
+	return &cpy, nil
+}
+
+func (r *uploadSessionRepo) CompleteSession(id, mediaID string) (*models.UploadSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, repo.ErrSessionNotFound
+	}
+	session.MediaID = mediaID
+	cpy := *session
+	return &cpy, nil
+}
+
+func (r *uploadSessionRepo) DeleteSession(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sessions[id]; !ok {
+		return repo.ErrSessionNotFound
+	}
+	delete(r.sessions, id)
+	return nil
+}
+
+func (r *uploadSessionRepo) ListExpiredSessions(now time.Time, limit int) ([]*models.UploadSession, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var expired []*models.UploadSession
+	for _, session := range r.sessions {
+		if !session.ExpiresAt.After(now) {
+			cpy := *session
+			expired = append(expired, &cpy)
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool { return expired[i].ExpiresAt.Before(expired[j].ExpiresAt) })
+	if limit > 0 && len(expired) > limit {
+		expired = expired[:limit]
+	}
+	return expired, nil
+}
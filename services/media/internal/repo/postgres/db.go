@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+
+	_ "github.com/lib/pq"
+)
+
+// NewDB ouvre une connexion PostgreSQL à partir des variables d'environnement.
+// DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME
+func NewDB() (*sql.DB, error) {
+	host := getEnv("DB_HOST", "localhost")
+	port := getEnv("DB_PORT", "5433")
+	user := getEnv("DB_USER", "storm")
+	password := getEnv("DB_PASSWORD", "password")
+	dbname := getEnv("DB_NAME", "storm_media_db")
+	sslmode := getEnv("DB_SSLMODE", "disable")
+
+	connStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		host, port, user, password, dbname, sslmode)
+
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		return nil, fmt.Errorf("opening db: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("pinging db: %w", err)
+	}
+
+	return db, nil
+}
+
+func getEnv(key, defaultVal string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultVal
+}
@@ -0,0 +1,261 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/repo"
+	"github.com/lib/pq"
+)
+
+const mediaColumns = `id, object_key, owner_id::text, COALESCE(conversation_id, 0), content_type, size, checksum, created_at,
+	processing_status, variants, category, filename, duration_ms, waveform, scan_status, scan_signature`
+
+type mediaRepo struct {
+	db *sql.DB
+}
+
+func NewMediaRepo(db *sql.DB) repo.MediaRepo {
+	return &mediaRepo{db: db}
+}
+
+func (r *mediaRepo) CreateMedia(media *models.Media, ensure func() error) (*models.Media, error) {
+	variants, err := marshalVariants(media.Variants)
+	if err != nil {
+		return nil, err
+	}
+	waveform, err := marshalWaveform(media.Waveform)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	refs, err := lockObject(tx, media.ObjectKey)
+	if err != nil {
+		return nil, err
+	}
+	if refs == 0 && ensure != nil {
+		if err := ensure(); err != nil {
+			return nil, err
+		}
+	}
+
+	query := `
+		INSERT INTO media (id, object_key, owner_id, conversation_id, content_type, size, checksum, created_at, processing_status, variants,
+			category, filename, duration_ms, waveform, scan_status, scan_signature)
+		VALUES ($1, $2, $3::uuid, NULLIF($4, 0), $5, $6, $7, $8, $9, $10::jsonb, $11, $12, $13, $14::jsonb, $15, $16)
+		RETURNING ` + mediaColumns
+
+	created, err := scanMedia(tx.QueryRow(query,
+		media.ID, media.ObjectKey, media.OwnerID, media.ConversationID, media.ContentType, media.Size, media.Checksum,
+		media.CreatedAt, media.Status, variants, media.Category, media.Filename, media.DurationMs, waveform,
+		media.ScanStatus, media.ScanSignature))
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return nil, repo.ErrMediaExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(`UPDATE media_objects SET ref_count = ref_count + 1 WHERE object_key = $1`, media.ObjectKey); err != nil {
+		return nil, err
+	}
+	return created, tx.Commit()
+}
+
+func (r *mediaRepo) DiscardObject(objectKey string, discard func() error) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	refs, err := lockObject(tx, objectKey)
+	if err != nil {
+		return err
+	}
+	if refs > 0 {
+		return nil
+	}
+	if err := discard(); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM media_objects WHERE object_key = $1`, objectKey); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// lockObject verrouille la ligne media_objects de l'objet, créée à 0 référence si besoin, et retourne
+// son compteur : uploads et suppressions d'un même contenu sont sérialisés jusqu'à la fin de tx.
+func lockObject(tx *sql.Tx, objectKey string) (int, error) {
+	if _, err := tx.Exec(`
+		INSERT INTO media_objects (object_key, ref_count) VALUES ($1, 0)
+		ON CONFLICT (object_key) DO NOTHING`, objectKey); err != nil {
+		return 0, err
+	}
+	var refs int
+	err := tx.QueryRow(`SELECT ref_count FROM media_objects WHERE object_key = $1 FOR UPDATE`, objectKey).Scan(&refs)
+	return refs, err
+}
+
+func (r *mediaRepo) GetMedia(id string) (*models.Media, error) {
+	media, err := scanMedia(r.db.QueryRow(`SELECT `+mediaColumns+` FROM media WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrMediaNotFound
+	}
+	return media, err
+}
+
+func (r *mediaRepo) FindByObjectKey(objectKey string) (*models.Media, error) {
+	media, err := scanMedia(r.db.QueryRow(`
+		SELECT `+mediaColumns+` FROM media
+		WHERE object_key = $1
+		ORDER BY processing_status = 'ready' DESC, created_at DESC
+		LIMIT 1`, objectKey))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrMediaNotFound
+	}
+	return media, err
+}
+
+func (r *mediaRepo) UpdateProcessing(id, status string, variants []models.MediaVariant) (*models.Media, error) {
+	encoded, err := marshalVariants(variants)
+	if err != nil {
+		return nil, err
+	}
+	media, err := scanMedia(r.db.QueryRow(`
+		UPDATE media
+		SET processing_status = $2, variants = $3::jsonb
+		WHERE id = $1
+		RETURNING `+mediaColumns, id, status, encoded))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrMediaNotFound
+	}
+	return media, err
+}
+
+func (r *mediaRepo) UpdateScan(id, status, signature string) (*models.Media, error) {
+	media, err := scanMedia(r.db.QueryRow(`
+		UPDATE media
+		SET scan_status = $2, scan_signature = $3
+		WHERE id = $1
+		RETURNING `+mediaColumns, id, status, signature))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrMediaNotFound
+	}
+	return media, err
+}
+
+func (r *mediaRepo) ListMedia(afterID string, limit int) ([]*models.Media, error) {
+	rows, err := r.db.Query(`
+		SELECT `+mediaColumns+` FROM media
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2`, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*models.Media
+	for rows.Next() {
+		media, err := scanMedia(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, media)
+	}
+	return list, rows.Err()
+}
+
+func (r *mediaRepo) DeleteMedia(id string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var objectKey string
+	if err := tx.QueryRow(`DELETE FROM media WHERE id = $1 RETURNING object_key`, id).Scan(&objectKey); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, repo.ErrMediaNotFound
+		}
+		return 0, err
+	}
+	// Le verrou de ligne sérialise les uploads et suppressions concurrents d'un même objet ; l'objet
+	// lui-même n'est supprimé que par DiscardObject, sous ce même verrou.
+	var remaining int
+	err = tx.QueryRow(`
+		UPDATE media_objects SET ref_count = ref_count - 1
+		WHERE object_key = $1
+		RETURNING ref_count`, objectKey).Scan(&remaining)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+	if remaining <= 0 {
+		remaining = 0
+		if _, err := tx.Exec(`DELETE FROM media_objects WHERE object_key = $1`, objectKey); err != nil {
+			return 0, err
+		}
+	}
+	return remaining, tx.Commit()
+}
+
+func marshalVariants(variants []models.MediaVariant) ([]byte, error) {
+	if variants == nil {
+		variants = []models.MediaVariant{}
+	}
+	return json.Marshal(variants)
+}
+
+func marshalWaveform(waveform []int) ([]byte, error) {
+	if waveform == nil {
+		waveform = []int{}
+	}
+	return json.Marshal(waveform)
+}
+
+func scanMedia(row interface{ Scan(dest ...any) error }) (*models.Media, error) {
+	var media models.Media
+	var variants, waveform []byte
+	if err := row.Scan(
+		&media.ID,
+		&media.ObjectKey,
+		&media.OwnerID,
+		&media.ConversationID,
+		&media.ContentType,
+		&media.Size,
+		&media.Checksum,
+		&media.CreatedAt,
+		&media.Status,
+		&variants,
+		&media.Category,
+		&media.Filename,
+		&media.DurationMs,
+		&waveform,
+		&media.ScanStatus,
+		&media.ScanSignature,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(variants, &media.Variants); err != nil {
+		return nil, err
+	}
+	if len(media.Variants) == 0 {
+		media.Variants = nil
+	}
+	if err := json.Unmarshal(waveform, &media.Waveform); err != nil {
+		return nil, err
+	}
+	if len(media.Waveform) == 0 {
+		media.Waveform = nil
+	}
+	return &media, nil
+}
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/repo"
+)
+
+const sessionColumns = `id, owner_id::text, COALESCE(conversation_id, 0), filename, content_type, size, chunk_size,
+	object_key, storage_upload_id, media_id, created_at, expires_at`
+
+type uploadSessionRepo struct {
+	db *sql.DB
+}
+
+func NewUploadSessionRepo(db *sql.DB) repo.UploadSessionRepo {
+	return &uploadSessionRepo{db: db}
+}
+
+func (r *uploadSessionRepo) CreateSession(session *models.UploadSession) (*models.UploadSession, error) {
+	return scanSession(r.db.QueryRow(`
+		INSERT INTO upload_sessions (id, owner_id, conversation_id, filename, content_type, size, chunk_size,
+			object_key, storage_upload_id, media_id, created_at, expires_at)
+		VALUES ($1, $2::uuid, NULLIF($3, 0), $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING `+sessionColumns,
+		session.ID, session.OwnerID, session.ConversationID, session.Filename, session.ContentType, session.Size,
+		session.ChunkSize, session.Key, session.StorageUploadID, session.MediaID, session.CreatedAt, session.ExpiresAt))
+}
+
+func (r *uploadSessionRepo) GetSession(id string) (*models.UploadSession, error) {
+	session, err := scanSession(r.db.QueryRow(`SELECT `+sessionColumns+` FROM upload_sessions WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrSessionNotFound
+	}
+	return session, err
+}
+
+func (r *uploadSessionRepo) CompleteSession(id, mediaID string) (*models.UploadSession, error) {
+	session, err := scanSession(r.db.QueryRow(`
+		UPDATE upload_sessions SET media_id = $2
+		WHERE id = $1
+		RETURNING `+sessionColumns, id, mediaID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrSessionNotFound
+	}
+	return session, err
+}
+
+func (r *uploadSessionRepo) DeleteSession(id string) error {
+	result, err := r.db.Exec(`DELETE FROM upload_sessions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (r *uploadSessionRepo) ListExpiredSessions(now time.Time, limit int) ([]*models.UploadSession, error) {
+	rows, err := r.db.Query(`
+		SELECT `+sessionColumns+` FROM upload_sessions
+		WHERE expires_at <= $1
+		ORDER BY expires_at
+		LIMIT $2`, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*models.UploadSession
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+func scanSession(row interface{ Scan(dest ...any) error }) (*models.UploadSession, error) {
+	var session models.UploadSession
+	if err := row.Scan(
+		&session.ID,
+		&session.OwnerID,
+		&session.ConversationID,
+		&session.Filename,
+		&session.ContentType,
+		&session.Size,
+		&session.ChunkSize,
+		&session.Key,
+		&session.StorageUploadID,
+		&session.MediaID,
+		&session.CreatedAt,
+		&session.ExpiresAt,
+	); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
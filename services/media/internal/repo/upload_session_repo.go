@@ -0,0 +1,22 @@
+package repo
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/models"
+)
+
+var ErrSessionNotFound = errors.New("upload session not found")
+
+type UploadSessionRepo interface {
+	CreateSession(session *models.UploadSession) (*models.UploadSession, error)
+	// GetSession : ErrSessionNotFound si l'ID est inconnu.
+	GetSession(id string) (*models.UploadSession, error)
+	// CompleteSession rattache le média finalisé à la session ; ErrSessionNotFound si l'ID est inconnu.
+	CompleteSession(id, mediaID string) (*models.UploadSession, error)
+	// DeleteSession : ErrSessionNotFound si l'ID est inconnu.
+	DeleteSession(id string) error
+	// ListExpiredSessions retourne au plus limit sessions expirées à now, les plus anciennes d'abord.
+	ListExpiredSessions(now time.Time, limit int) ([]*models.UploadSession, error)
+}
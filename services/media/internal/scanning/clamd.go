@@ -0,0 +1,113 @@
+package scanning
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// chunkSize : taille des blocs INSTREAM, sous la limite StreamMaxLength par défaut de clamd.
+	chunkSize = 64 << 10
+	// maxReplySize : une réponse clamd tient sur une ligne (« stream: <signature> FOUND »).
+	maxReplySize = 4 << 10
+)
+
+// errReadFile : échec de lecture du fichier à analyser, par opposition à une connexion coupée par clamd.
+var errReadFile = errors.New("lecture du fichier")
+
+// Clamd analyse les fichiers avec clamd (ClamAV) en TCP, commande INSTREAM : le contenu est envoyé par
+// blocs préfixés de leur taille (uint32 big-endian) puis un bloc vide ; clamd répond
+// « stream: OK », « stream: <signature> FOUND » ou « <message> ERROR ».
+type Clamd struct {
+	addr    string
+	timeout time.Duration
+	dialer  net.Dialer
+}
+
+func NewClamd(addr string, timeout time.Duration) *Clamd {
+	return &Clamd{addr: addr, timeout: timeout}
+}
+
+func (c *Clamd) Scan(ctx context.Context, r io.Reader) (Verdict, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	conn, err := c.dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return Verdict{}, fmt.Errorf("clamd: %w", err)
+	}
+	defer conn.Close()
+	// L'annulation du contexte débloque les lectures et écritures en cours.
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	if err := stream(conn, r); err != nil {
+		// clamd coupe la connexion au-delà de StreamMaxLength, après avoir envoyé sa réponse.
+		if !errors.Is(err, errReadFile) {
+			if reply, replyErr := readReply(conn); replyErr == nil {
+				return parseReply(reply)
+			}
+		}
+		return Verdict{}, fmt.Errorf("clamd: %w", err)
+	}
+	reply, err := readReply(conn)
+	if err != nil {
+		return Verdict{}, fmt.Errorf("clamd: %w", err)
+	}
+	return parseReply(reply)
+}
+
+func stream(conn net.Conn, r io.Reader) error {
+	if _, err := io.WriteString(conn, "zINSTREAM\x00"); err != nil {
+		return err
+	}
+	buf := make([]byte, 4+chunkSize)
+	for {
+		n, err := r.Read(buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf, uint32(n))
+			if _, err := conn.Write(buf[:4+n]); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", errReadFile, err)
+		}
+	}
+	_, err := conn.Write(make([]byte, 4))
+	return err
+}
+
+// readReply lit la réponse, terminée par un octet nul (commandes préfixées par « z »).
+func readReply(conn net.Conn) (string, error) {
+	reply, err := bufio.NewReader(io.LimitReader(conn, maxReplySize)).ReadString(0)
+	if err != nil && (!errors.Is(err, io.EOF) || reply == "") {
+		return "", err
+	}
+	return strings.TrimSpace(strings.TrimSuffix(reply, "\x00")), nil
+}
+
+func parseReply(reply string) (Verdict, error) {
+	result := strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case result == "OK":
+		return Verdict{}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return Verdict{Infected: true, Signature: strings.TrimSuffix(result, " FOUND")}, nil
+	case strings.Contains(result, "size limit exceeded"):
+		// « INSTREAM size limit exceeded. ERROR » : flux au-delà de StreamMaxLength.
+		return Verdict{}, fmt.Errorf("clamd: %w (%s)", ErrTooLarge, result)
+	default:
+		return Verdict{}, fmt.Errorf("clamd: %s", result)
+	}
+}
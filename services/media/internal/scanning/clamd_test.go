@@ -0,0 +1,137 @@
+package scanning
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// fakeClamd : serveur INSTREAM minimal ; détecte la chaîne EICAR et refuse les flux au-delà de limit.
+func fakeClamd(t *testing.T, limit int) (string, func() []string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	var mu sync.Mutex
+	var commands []string
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			command := make([]byte, len("zINSTREAM\x00"))
+			if _, err := io.ReadFull(conn, command); err != nil {
+				conn.Close()
+				continue
+			}
+			mu.Lock()
+			commands = append(commands, string(command))
+			mu.Unlock()
+			var data []byte
+			reply := "stream: OK"
+			for {
+				var size uint32
+				if err := binary.Read(conn, binary.BigEndian, &size); err != nil || size == 0 {
+					break
+				}
+				if len(data)+int(size) > limit {
+					reply = "INSTREAM size limit exceeded. ERROR"
+					break
+				}
+				chunk := make([]byte, size)
+				if _, err := io.ReadFull(conn, chunk); err != nil {
+					break
+				}
+				data = append(data, chunk...)
+			}
+			if reply == "stream: OK" && bytes.Contains(data, []byte(eicar)) {
+				reply = "stream: Eicar-Test-Signature FOUND"
+			}
+			conn.Write([]byte(reply + "\x00"))
+			conn.Close()
+		}
+	}()
+	return listener.Addr().String(), func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), commands...)
+	}
+}
+
+func TestClamdScan(t *testing.T) {
+	addr, commands := fakeClamd(t, 1<<20)
+	clamd := NewClamd(addr, 5*time.Second)
+	ctx := context.Background()
+
+	// Plusieurs blocs : le contenu infecté chevauche la limite d'un bloc.
+	infected := append(bytes.Repeat([]byte("a"), chunkSize-10), eicar...)
+	verdict, err := clamd.Scan(ctx, bytes.NewReader(infected))
+	if err != nil || !verdict.Infected || verdict.Signature != "Eicar-Test-Signature" {
+		t.Fatalf("Scan(eicar) = %+v, %v", verdict, err)
+	}
+	if verdict, err := clamd.Scan(ctx, strings.NewReader("bonjour")); err != nil || verdict.Infected {
+		t.Fatalf("Scan(clean) = %+v, %v", verdict, err)
+	}
+	if verdict, err := clamd.Scan(ctx, bytes.NewReader(nil)); err != nil || verdict.Infected {
+		t.Fatalf("Scan(empty) = %+v, %v", verdict, err)
+	}
+	if got := commands(); len(got) != 3 || got[0] != "zINSTREAM\x00" {
+		t.Fatalf("unexpected commands %q", got)
+	}
+
+	// Flux refusé par clamd : erreur, jamais un verdict sain.
+	if verdict, err := clamd.Scan(ctx, bytes.NewReader(make([]byte, 2<<20))); !errors.Is(err, ErrTooLarge) || !strings.Contains(err.Error(), "size limit") {
+		t.Fatalf("Scan(oversized) = %+v, %v", verdict, err)
+	}
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	unreachable := closed.Addr().String()
+	closed.Close()
+	if _, err := NewClamd(unreachable, time.Second).Scan(ctx, strings.NewReader("x")); err == nil || errors.Is(err, ErrTooLarge) {
+		t.Fatalf("Scan() must fail with a transient error when clamd is unreachable, got %v", err)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("MEDIA_SCAN_CLAMD_ADDR", "")
+	if scanner, err := FromEnv(); scanner != nil || err != nil {
+		t.Fatalf("FromEnv() without address = %v, %v", scanner, err)
+	}
+	t.Setenv("MEDIA_SCAN_CLAMD_ADDR", "clamav:3310")
+	t.Setenv("MEDIA_SCAN_TIMEOUT", "30s")
+	scanner, err := FromEnv()
+	if clamd, ok := scanner.(*Clamd); err != nil || !ok || clamd.addr != "clamav:3310" || clamd.timeout != 30*time.Second {
+		t.Fatalf("FromEnv() = %+v, %v", scanner, err)
+	}
+	t.Setenv("MEDIA_SCAN_TIMEOUT", "bientôt")
+	if _, err := FromEnv(); err == nil {
+		t.Fatal("invalid MEDIA_SCAN_TIMEOUT should be rejected")
+	}
+
+	for value, want := range map[string]string{"": OversizeBlock, "block": OversizeBlock, "allow": OversizeAllow} {
+		t.Setenv("MEDIA_SCAN_OVERSIZE", value)
+		if policy, err := OversizePolicyFromEnv(); err != nil || policy != want {
+			t.Fatalf("OversizePolicyFromEnv(%q) = %q, %v", value, policy, err)
+		}
+	}
+	t.Setenv("MEDIA_SCAN_OVERSIZE", "ignore")
+	if _, err := OversizePolicyFromEnv(); err == nil {
+		t.Fatal("invalid MEDIA_SCAN_OVERSIZE should be rejected")
+	}
+}
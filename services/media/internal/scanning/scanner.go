@@ -0,0 +1,66 @@
+package scanning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+const defaultTimeout = 2 * time.Minute
+
+// Politiques MEDIA_SCAN_OVERSIZE pour un fichier au-delà de la limite du moteur (ErrTooLarge).
+const (
+	// OversizeBlock : le fichier reste en quarantaine.
+	OversizeBlock = "block"
+	// OversizeAllow : le fichier est téléchargeable sans avoir été analysé.
+	OversizeAllow = "allow"
+)
+
+// ErrTooLarge : le moteur refuse le fichier, plus gros que sa limite (clamd : StreamMaxLength). Ce n'est
+// pas une panne : une nouvelle analyse du même fichier échouerait de la même façon.
+var ErrTooLarge = errors.New("fichier au-delà de la limite d'analyse")
+
+// Verdict : résultat d'une analyse ; Signature nomme le logiciel malveillant détecté.
+type Verdict struct {
+	Infected  bool
+	Signature string
+}
+
+// Scanner analyse un fichier avant qu'il ne soit téléchargeable. Une erreur signifie que le fichier
+// n'a pas pu être analysé, pas qu'il est sain : ErrTooLarge s'il dépasse la limite du moteur, panne
+// passagère sinon (moteur injoignable, délai dépassé).
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (Verdict, error)
+}
+
+// FromEnv : clamd à MEDIA_SCAN_CLAMD_ADDR (hôte:port), délai MEDIA_SCAN_TIMEOUT (2 min par défaut).
+// Nil sans adresse : les médias ne sont pas analysés.
+func FromEnv() (Scanner, error) {
+	addr := os.Getenv("MEDIA_SCAN_CLAMD_ADDR")
+	if addr == "" {
+		return nil, nil
+	}
+	timeout := defaultTimeout
+	if raw := os.Getenv("MEDIA_SCAN_TIMEOUT"); raw != "" {
+		var err error
+		if timeout, err = time.ParseDuration(raw); err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("MEDIA_SCAN_TIMEOUT invalide: %q", raw)
+		}
+	}
+	return NewClamd(addr, timeout), nil
+}
+
+// OversizePolicyFromEnv : MEDIA_SCAN_OVERSIZE (OversizeBlock par défaut, ou OversizeAllow).
+func OversizePolicyFromEnv() (string, error) {
+	switch policy := os.Getenv("MEDIA_SCAN_OVERSIZE"); policy {
+	case "", OversizeBlock:
+		return OversizeBlock, nil
+	case OversizeAllow:
+		return OversizeAllow, nil
+	default:
+		return "", fmt.Errorf("MEDIA_SCAN_OVERSIZE invalide: %q (block ou allow)", policy)
+	}
+}
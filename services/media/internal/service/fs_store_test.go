@@ -0,0 +1,100 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/repo/memory"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/storage"
+)
+
+// newFSTestService : service sur le stockage local, URLs signées servies comme dans cmd/main.go.
+func newFSTestService(t *testing.T) (*MediaService, *storage.FSStore) {
+	t.Helper()
+	var store *storage.FSStore
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.StripPrefix(storage.FSRoutePrefix, store.Handler()).ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	store, err := storage.NewFSStore(storage.FSConfig{Root: t.TempDir(), PublicURL: server.URL + storage.FSRoutePrefix})
+	if err != nil {
+		t.Fatalf("NewFSStore() error = %v", err)
+	}
+	return NewMediaService(store, memory.NewMediaRepo(), memory.NewUploadSessionRepo(), fakeMembers{}), store
+}
+
+func download(t *testing.T, url string) []byte {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s error = %v", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET %s status = %d", url, resp.StatusCode)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	return data
+}
+
+func TestMediaServiceWithFSStore(t *testing.T) {
+	svc, store := newFSTestService(t)
+	ctx := context.Background()
+	body := pngBytes(t, 4, 4)
+
+	uploaded, err := svc.Upload(ctx, UploadRequest{Filename: "photo.png", DataBase64: base64.StdEncoding.EncodeToString(body), OwnerID: testOwner, ConversationID: testConversation})
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	link, err := svc.GetURL(ctx, testMember, uploaded.MediaID, "")
+	if err != nil {
+		t.Fatalf("GetURL() error = %v", err)
+	}
+	if data := download(t, link.URL); !bytes.Equal(data, body) {
+		t.Fatal("signed URL should serve the uploaded file")
+	}
+
+	// Upload direct puis upload reprenable du même contenu : dédupliqués sur le même objet.
+	presign, err := svc.PresignUpload(ctx, PresignRequest{Filename: "encore.png", ContentType: "image/png", Size: int64(len(body)), OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("PresignUpload() error = %v", err)
+	}
+	putPresigned(t, presign, "image/png", body)
+	direct, err := svc.CompleteUpload(ctx, testOwner, 0, presign.MediaID, false)
+	if err != nil || direct.Key != uploaded.Key {
+		t.Fatalf("CompleteUpload() = %+v, %v", direct, err)
+	}
+
+	video := testVideo(ChunkSize + 10)
+	session, err := svc.CreateUploadSession(ctx, SessionRequest{Filename: "film.mp4", ContentType: "video/mp4", Size: int64(len(video)), OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("CreateUploadSession() error = %v", err)
+	}
+	uploadChunk(t, svc, session.UploadID, video, 0)
+	uploadChunk(t, svc, session.UploadID, video, ChunkSize)
+	film, err := svc.CompleteUploadSession(ctx, testOwner, session.UploadID)
+	if err != nil {
+		t.Fatalf("CompleteUploadSession() error = %v", err)
+	}
+
+	keys, err := store.ListFiles(ctx, "")
+	if err != nil || !reflect.DeepEqual(keys, []string{uploaded.Key, film.Key}) && !reflect.DeepEqual(keys, []string{film.Key, uploaded.Key}) {
+		t.Fatalf("expected only the two content objects, got %v (%v)", keys, err)
+	}
+
+	for _, mediaID := range []string{uploaded.MediaID, direct.MediaID, film.MediaID} {
+		if err := svc.Delete(ctx, testOwner, mediaID); err != nil {
+			t.Fatalf("Delete(%s) error = %v", mediaID, err)
+		}
+	}
+	if keys, _ := store.ListFiles(ctx, ""); len(keys) != 0 {
+		t.Fatalf("objects should be deleted with their last reference, got %v", keys)
+	}
+}
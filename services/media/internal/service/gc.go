@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/storage"
+)
+
+const (
+	// DefaultGCGrace : âge minimal d'un média ou d'un objet avant suppression par le ramasse-miettes ;
+	// laisse le temps d'envoyer le message qui suit l'upload.
+	DefaultGCGrace = 72 * time.Hour
+
+	gcBatchSize = 100
+	// maxReportedKeys : nombre de clés détaillées dans un rapport ; les compteurs restent exacts.
+	maxReportedKeys = 1000
+)
+
+var (
+	ErrGCDisabled      = errors.New("ramasse-miettes non configuré (vérification des messages indisponible)")
+	ErrGCGraceTooShort = fmt.Errorf("délai de grâce du ramasse-miettes inférieur à la durée d'une session d'upload (%s)", SessionTTL)
+)
+
+// ReferenceChecker : médias attachés à des messages (message-service, references.Checker).
+type ReferenceChecker interface {
+	Referenced(mediaIDs []string) (map[string]bool, error)
+}
+
+// GCReport : bilan d'un passage du ramasse-miettes. En dry-run rien n'est supprimé, le rapport liste
+// ce qui le serait.
+type GCReport struct {
+	DryRun bool `json:"dryRun"`
+	// Cutoff (Unix) : seuls les médias et objets antérieurs peuvent être supprimés.
+	Cutoff          int64 `json:"cutoff"`
+	ScannedMedia    int   `json:"scannedMedia"`
+	OrphanedMedia   int   `json:"orphanedMedia"`
+	ScannedObjects  int   `json:"scannedObjects"`
+	OrphanedObjects int   `json:"orphanedObjects"`
+	ReclaimedBytes  int64 `json:"reclaimedBytes"`
+	// Failed : suppressions en échec, retentées au passage suivant.
+	Failed int `json:"failed"`
+	// MediaIDs et ObjectKeys : références et objets orphelins (maxReportedKeys au plus chacun).
+	MediaIDs   []string `json:"mediaIds,omitempty"`
+	ObjectKeys []string `json:"objectKeys,omitempty"`
+}
+
+// SetGarbageCollection branche la vérification des références côté messages ; grace est l'âge minimal
+// d'un média ou d'un objet supprimable, au moins SessionTTL (objets pending/ des uploads reprenables).
+func (s *MediaService) SetGarbageCollection(refs ReferenceChecker, grace time.Duration) error {
+	if grace < SessionTTL {
+		return ErrGCGraceTooShort
+	}
+	s.refs = refs
+	s.gcGrace = grace
+	return nil
+}
+
+// CollectGarbage supprime ce qu'aucun message n'utilise plus, s'il est plus ancien que le délai de grâce :
+//   - les références (media/...) attachées à aucun message non supprimé ni message programmé en attente ;
+//   - les objets du stockage sans référence : contenus objects/<sha256>, objets en attente pending/...
+//     jamais finalisés, objets antérieurs au registre (clé = media ID) qu'aucun message ne cite, et
+//     variantes dont l'original a disparu.
+//
+// Le message-service est consulté avant toute suppression : s'il ne répond pas, le passage s'arrête.
+func (s *MediaService) CollectGarbage(ctx context.Context, now time.Time, dryRun bool) (*GCReport, error) {
+	if s.refs == nil {
+		return nil, ErrGCDisabled
+	}
+	cutoff := now.Add(-s.gcGrace)
+	report := &GCReport{DryRun: dryRun, Cutoff: cutoff.Unix()}
+
+	kept, err := s.collectMedia(cutoff, dryRun, report)
+	if err != nil {
+		return report, err
+	}
+	return report, s.collectObjects(ctx, cutoff, dryRun, kept, report)
+}
+
+// collectMedia supprime les références orphelines ; retourne les clés objet encore référencées.
+func (s *MediaService) collectMedia(cutoff time.Time, dryRun bool, report *GCReport) (map[string]bool, error) {
+	kept := make(map[string]bool)
+	afterID := ""
+	for {
+		batch, err := s.repo.ListMedia(afterID, gcBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			return kept, nil
+		}
+		afterID = batch[len(batch)-1].ID
+		report.ScannedMedia += len(batch)
+
+		var ids []string
+		for _, media := range batch {
+			if media.CreatedAt.Before(cutoff) {
+				ids = append(ids, media.ID)
+			}
+		}
+		referenced := map[string]bool{}
+		if len(ids) > 0 {
+			if referenced, err = s.refs.Referenced(ids); err != nil {
+				return nil, fmt.Errorf("références des messages: %w", err)
+			}
+		}
+		for _, media := range batch {
+			if !media.CreatedAt.Before(cutoff) || referenced[media.ID] {
+				kept[media.ObjectKey] = true
+				continue
+			}
+			if !dryRun {
+				// L'objet, s'il n'a plus de référence, part avec les objets orphelins.
+				if _, err := s.repo.DeleteMedia(media.ID); err != nil && !errors.Is(err, repo.ErrMediaNotFound) {
+					report.Failed++
+					kept[media.ObjectKey] = true
+					continue
+				}
+			}
+			report.OrphanedMedia++
+			report.MediaIDs = appendReported(report.MediaIDs, media.ID)
+		}
+	}
+}
+
+// collectObjects supprime les objets sans référence : d'abord les originaux, puis les variantes, dont
+// l'original a pu disparaître au même passage.
+func (s *MediaService) collectObjects(ctx context.Context, cutoff time.Time, dryRun bool, kept map[string]bool, report *GCReport) error {
+	keys, err := s.storage.ListFiles(ctx, "")
+	if err != nil {
+		return err
+	}
+	present := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		present[key] = true
+	}
+	unreferenced := func(key string) bool {
+		if kept[key] {
+			return false
+		}
+		// En dry-run les références orphelines sont toujours en base : seul kept fait foi.
+		if !dryRun {
+			if _, err := s.repo.FindByObjectKey(key); !errors.Is(err, repo.ErrMediaNotFound) {
+				return false
+			}
+		}
+		return true
+	}
+
+	var legacy, variants []string
+	for _, key := range keys {
+		switch {
+		case strings.HasPrefix(key, objectPrefix):
+			report.ScannedObjects++
+			if !unreferenced(key) {
+				continue
+			}
+			if dryRun {
+				s.collectObject(ctx, key, cutoff, dryRun, present, report)
+				continue
+			}
+			// Sous le verrou de l'objet : un upload du même contenu attend, ou garde l'objet qu'il référence.
+			err := s.repo.DiscardObject(key, func() error {
+				s.collectObject(ctx, key, cutoff, dryRun, present, report)
+				return nil
+			})
+			if err != nil {
+				report.Failed++
+			}
+		case strings.HasPrefix(key, pendingPrefix):
+			report.ScannedObjects++
+			s.collectObject(ctx, key, cutoff, dryRun, present, report)
+		case strings.HasPrefix(key, mediaPrefix):
+			report.ScannedObjects++
+			if unreferenced(key) {
+				legacy = append(legacy, key)
+			}
+		case strings.HasPrefix(key, variantPrefix):
+			report.ScannedObjects++
+			variants = append(variants, key)
+		}
+	}
+
+	// Objets antérieurs au registre : le media ID est la clé, cité tel quel par les messages.
+	for start := 0; start < len(legacy); start += gcBatchSize {
+		batch := legacy[start:min(start+gcBatchSize, len(legacy))]
+		referenced, err := s.refs.Referenced(batch)
+		if err != nil {
+			return fmt.Errorf("références des messages: %w", err)
+		}
+		for _, key := range batch {
+			if !referenced[key] {
+				s.collectObject(ctx, key, cutoff, dryRun, present, report)
+			}
+		}
+	}
+
+	for _, key := range variants {
+		name := strings.TrimPrefix(key, variantPrefix)
+		name = name[:max(strings.LastIndexByte(name, '/'), 0)]
+		if name == "" || (!present[objectPrefix+name] && !present[mediaPrefix+name]) {
+			s.collectObject(ctx, key, cutoff, dryRun, present, report)
+		}
+	}
+	return nil
+}
+
+// collectObject supprime key s'il est antérieur à cutoff (une date inconnue le protège).
+func (s *MediaService) collectObject(ctx context.Context, key string, cutoff time.Time, dryRun bool, present map[string]bool, report *GCReport) {
+	info, err := s.storage.HeadFile(ctx, key)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		delete(present, key)
+		return
+	}
+	if err != nil {
+		report.Failed++
+		return
+	}
+	if info.LastModified.IsZero() || !info.LastModified.Before(cutoff) {
+		return
+	}
+	if !dryRun {
+		if err := s.storage.DeleteFile(ctx, key); err != nil {
+			report.Failed++
+			return
+		}
+	}
+	delete(present, key)
+	report.OrphanedObjects++
+	report.ReclaimedBytes += info.Size
+	report.ObjectKeys = appendReported(report.ObjectKeys, key)
+}
+
+func appendReported(list []string, key string) []string {
+	if len(list) >= maxReportedKeys {
+		return list
+	}
+	return append(list, key)
+}
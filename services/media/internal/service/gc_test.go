@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/storage/s3test"
+)
+
+// fakeRefs simule MEDIA_REFERENCES_CHECK : media IDs attachés à un message.
+type fakeRefs struct {
+	referenced map[string]bool
+	err        error
+}
+
+func (f *fakeRefs) Referenced(mediaIDs []string) (map[string]bool, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	out := make(map[string]bool)
+	for _, id := range mediaIDs {
+		if f.referenced[id] {
+			out[id] = true
+		}
+	}
+	return out, nil
+}
+
+func sortedKeys(server *s3test.Server) []string {
+	keys := server.Keys(testBucket)
+	sort.Strings(keys)
+	return keys
+}
+
+func TestMediaServiceCollectGarbage(t *testing.T) {
+	svc, server := newTestService(t)
+	ctx := context.Background()
+	refs := &fakeRefs{referenced: map[string]bool{}}
+
+	if _, err := svc.CollectGarbage(ctx, time.Now(), true); !errors.Is(err, ErrGCDisabled) {
+		t.Fatalf("CollectGarbage() without references: expected ErrGCDisabled, got %v", err)
+	}
+	if err := svc.SetGarbageCollection(refs, time.Hour); !errors.Is(err, ErrGCGraceTooShort) {
+		t.Fatalf("SetGarbageCollection(1h): expected ErrGCGraceTooShort, got %v", err)
+	}
+	if err := svc.SetGarbageCollection(refs, DefaultGCGrace); err != nil {
+		t.Fatalf("SetGarbageCollection() error = %v", err)
+	}
+	upload := func(filename string, w int) UploadResponse {
+		t.Helper()
+		resp, err := svc.Upload(ctx, UploadRequest{Filename: filename, DataBase64: pngBase64(t, w, w), OwnerID: testOwner})
+		if err != nil {
+			t.Fatalf("Upload(%s) error = %v", filename, err)
+		}
+		return resp
+	}
+
+	sent := upload("envoyee.png", 2)
+	abandoned := upload("abandonnee.png", 3)
+	shared := upload("partagee.png", 3) // même contenu que abandoned, attachée à un message
+	failed := upload("echec.png", 4)
+	refs.referenced[sent.MediaID] = true
+	refs.referenced[shared.MediaID] = true
+
+	presign, err := svc.PresignUpload(ctx, PresignRequest{Filename: "jamais.png", ContentType: "image/png", Size: int64(len(pngBytes(t, 5, 5))), OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("PresignUpload() error = %v", err)
+	}
+	putPresigned(t, presign, "image/png", pngBytes(t, 5, 5))
+
+	now := time.Now().Add(DefaultGCGrace + time.Hour)
+	variant := variantKey(failed.Key, "thumbnail")
+	server.Put(testBucket, variant, s3test.Object{Data: []byte("miniature"), ContentType: "image/jpeg"})
+	server.Put(testBucket, "pending/recent_photo.png", s3test.Object{Data: []byte("x"), ModTime: now})
+	server.Put(testBucket, "media/1_ancienne.png", s3test.Object{Data: []byte("cite")})
+	server.Put(testBucket, "media/2_oubliee.png", s3test.Object{Data: []byte("orpheline")})
+	server.Put(testBucket, "avatars/inconnu.png", s3test.Object{Data: []byte("hors périmètre")})
+	refs.referenced["media/1_ancienne.png"] = true
+
+	before := sortedKeys(server)
+	wantMedia := []string{abandoned.MediaID, failed.MediaID}
+	sort.Strings(wantMedia)
+	wantObjects := []string{failed.Key, presign.MediaID, "media/2_oubliee.png", variant}
+	sort.Strings(wantObjects)
+	check := func(report *GCReport, dryRun bool) {
+		t.Helper()
+		sort.Strings(report.MediaIDs)
+		sort.Strings(report.ObjectKeys)
+		if report.DryRun != dryRun || report.ScannedMedia != 4 || report.OrphanedMedia != 2 || report.ScannedObjects != 8 ||
+			report.OrphanedObjects != 4 || report.Failed != 0 || report.ReclaimedBytes == 0 ||
+			!reflect.DeepEqual(report.MediaIDs, wantMedia) || !reflect.DeepEqual(report.ObjectKeys, wantObjects) {
+			t.Fatalf("unexpected report %+v", report)
+		}
+	}
+
+	report, err := svc.CollectGarbage(ctx, now, true)
+	if err != nil {
+		t.Fatalf("CollectGarbage(dry-run) error = %v", err)
+	}
+	check(report, true)
+	if after := sortedKeys(server); !reflect.DeepEqual(after, before) {
+		t.Fatalf("dry-run must not delete anything: %v -> %v", before, after)
+	}
+	if _, err := svc.GetMedia(ctx, testOwner, failed.MediaID); err != nil {
+		t.Fatalf("dry-run must keep references, got %v", err)
+	}
+
+	// Message-service injoignable : rien n'est supprimé.
+	refs.err = errors.New("nats: timeout")
+	if _, err := svc.CollectGarbage(ctx, now, false); err == nil {
+		t.Fatal("CollectGarbage() must fail when message references are unavailable")
+	}
+	refs.err = nil
+	if after := sortedKeys(server); !reflect.DeepEqual(after, before) {
+		t.Fatalf("failed run must not delete anything: %v -> %v", before, after)
+	}
+
+	report, err = svc.CollectGarbage(ctx, now, false)
+	if err != nil {
+		t.Fatalf("CollectGarbage() error = %v", err)
+	}
+	check(report, false)
+	for _, key := range wantObjects {
+		if server.Get(testBucket, key) != nil {
+			t.Fatalf("orphaned object %s should be deleted", key)
+		}
+	}
+	for _, key := range []string{sent.Key, shared.Key, "pending/recent_photo.png", "media/1_ancienne.png", "avatars/inconnu.png"} {
+		if server.Get(testBucket, key) == nil {
+			t.Fatalf("object %s should be kept", key)
+		}
+	}
+	for _, id := range wantMedia {
+		if _, err := svc.GetMedia(ctx, testOwner, id); !errors.Is(err, ErrMediaNotFound) {
+			t.Fatalf("GetMedia(%s): expected ErrMediaNotFound, got %v", id, err)
+		}
+	}
+	if info, err := svc.GetMedia(ctx, testOwner, shared.MediaID); err != nil || !strings.HasPrefix(info.Key, objectPrefix) {
+		t.Fatalf("GetMedia(shared) = %+v, %v", info, err)
+	}
+
+	// Tout est propre : un second passage ne trouve rien.
+	if report, err := svc.CollectGarbage(ctx, now, false); err != nil || report.OrphanedMedia != 0 || report.OrphanedObjects != 0 {
+		t.Fatalf("second CollectGarbage() = %+v, %v", report, err)
+	}
+}
@@ -3,120 +3,625 @@ package service
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"log"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
+	"github.com/Mathis-brgs/storm-project/services/media/internal/audio"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/scanning"
 	"github.com/Mathis-brgs/storm-project/services/media/internal/storage"
 )
 
-// Types MIME autorisés pour l'upload
-var allowedMimeTypes = map[string]bool{
-	"image/jpeg": true,
-	"image/png":  true,
-	"image/gif":  true,
-	"image/webp": true,
-	"video/mp4":  true,
-	"video/webm": true,
-	"video/avi":  true,
+// MembershipChecker : appartenance à une conversation (message-service), pour l'accès aux médias partagés.
+type MembershipChecker interface {
+	IsMember(userID string, conversationID int) (bool, error)
 }
 
 type MediaService struct {
-	storage *storage.MinIOClient
+	storage  storage.ObjectStore
+	repo     repo.MediaRepo
+	sessions repo.UploadSessionRepo
+	members  MembershipChecker
+	queue    ProcessingQueue
+	policy   *TypePolicy
+	scanner  scanning.Scanner
+	// allowOversize : MEDIA_SCAN_OVERSIZE=allow, un média trop gros pour le moteur reste téléchargeable.
+	allowOversize bool
+	// refs et gcGrace : ramasse-miettes (SetGarbageCollection).
+	refs    ReferenceChecker
+	gcGrace time.Duration
 }
 
+// UploadRequest : OwnerID est l'utilisateur authentifié ; ConversationID (optionnel) rattache le média
+// à une conversation dont il doit être membre. Voice : note vocale (durée et forme d'onde extraites).
 type UploadRequest struct {
-	Filename    string
-	ContentType string
-	Size        int64
-	DataBase64  string
+	Filename       string
+	ContentType    string
+	Size           int64
+	DataBase64     string
+	OwnerID        string
+	ConversationID int
+	Voice          bool
 }
 
 type UploadResponse struct {
-	MediaID string `json:"mediaId"`
-	Key     string `json:"key"`
-	URL     string `json:"url"`
+	MediaID     string `json:"mediaId"`
+	Key         string `json:"key"`
+	URL         string `json:"url"`
+	Size        int64  `json:"size,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Category    string `json:"category,omitempty"`
+	Filename    string `json:"filename,omitempty"`
+	// DurationMs (audio, notes vocales) et Waveform (notes vocales : amplitudes 0-100).
+	DurationMs int64 `json:"durationMs,omitempty"`
+	Waveform   []int `json:"waveform,omitempty"`
+	// Status "processing" : les variantes (clés déjà réservées) seront annoncées par media.processed.
+	Status   string                `json:"status,omitempty"`
+	Variants []models.MediaVariant `json:"variants,omitempty"`
+	// ScanStatus "pending" : URL absente jusqu'au verdict, annoncé par media.scan.completed.
+	ScanStatus string `json:"scanStatus,omitempty"`
+}
+
+// DownloadURL : URL GET signée, valable jusqu'à ExpiresAt (Unix).
+type DownloadURL struct {
+	MediaID   string `json:"mediaId"`
+	URL       string `json:"url"`
+	ExpiresAt int64  `json:"expiresAt"`
 }
 
-func NewMediaService(storageClient *storage.MinIOClient) *MediaService {
-	return &MediaService{storage: storageClient}
+// members peut être nil : seul le propriétaire accède alors à ses médias.
+func NewMediaService(storageClient storage.ObjectStore, mediaRepo repo.MediaRepo, sessions repo.UploadSessionRepo, members MembershipChecker) *MediaService {
+	return &MediaService{storage: storageClient, repo: mediaRepo, sessions: sessions, members: members, policy: DefaultTypePolicy()}
 }
 
-// ValidateContentType vérifie que le type MIME est autorisé (image ou vidéo)
-func ValidateContentType(contentType string) error {
-	ct := strings.ToLower(strings.TrimSpace(contentType))
-	if !allowedMimeTypes[ct] {
-		return fmt.Errorf("type de fichier non autorisé: %s (autorisés: image/jpeg, image/png, image/gif, image/webp, video/mp4, video/webm, video/avi)", ct)
+// SetTypePolicy remplace les types autorisés par défaut (TypePolicyFromEnv).
+func (s *MediaService) SetTypePolicy(policy *TypePolicy) {
+	s.policy = policy
+}
+
+// uploadPolicy : types acceptés pour un upload, restreints aux notes vocales si voice.
+func (s *MediaService) uploadPolicy(voice bool) *TypePolicy {
+	if voice {
+		return s.policy.Voice()
 	}
-	return nil
+	return s.policy
 }
 
 // Upload via base64 (NATS)
 func (s *MediaService) Upload(ctx context.Context, req UploadRequest) (UploadResponse, error) {
+	decoded, err := base64.StdEncoding.DecodeString(req.DataBase64)
+	if err != nil {
+		return UploadResponse{}, fmt.Errorf("%w: erreur décodage base64: %v", ErrInvalidUpload, err)
+	}
+	return s.upload(ctx, req, bytes.NewReader(decoded))
+}
+
+// UploadFromReader upload un fichier depuis un io.Reader (HTTP multipart) ; req.DataBase64 est ignoré.
+func (s *MediaService) UploadFromReader(ctx context.Context, req UploadRequest, reader io.Reader) (UploadResponse, error) {
+	return s.upload(ctx, req, reader)
+}
+
+// upload : chemin commun HTTP / NATS, le contenu passe par ReadUpload avant tout stockage.
+func (s *MediaService) upload(ctx context.Context, req UploadRequest, reader io.Reader) (UploadResponse, error) {
+	req.Filename = safeFilename(req.Filename)
 	if req.Filename == "" {
 		return UploadResponse{}, fmt.Errorf("filename is required")
 	}
+	if err := s.authorizeUpload(req.OwnerID, req.ConversationID); err != nil {
+		return UploadResponse{}, err
+	}
+	file, err := s.uploadPolicy(req.Voice).ReadUpload(reader, req.ContentType, req.Size)
+	if err != nil {
+		return UploadResponse{}, err
+	}
 
-	if req.ContentType != "" {
-		if err := ValidateContentType(req.ContentType); err != nil {
-			return UploadResponse{}, err
+	mediaID := fmt.Sprintf("%s%d_%s", mediaPrefix, time.Now().UnixNano(), req.Filename)
+	return s.store(ctx, mediaID, req.Filename, file, req.OwnerID, req.ConversationID)
+}
+
+// store enregistre une nouvelle référence au fichier validé ; l'objet n'est envoyé que si ce contenu
+// n'est pas déjà stocké. Si l'enregistrement échoue, un objet devenu orphelin est supprimé.
+func (s *MediaService) store(ctx context.Context, mediaID, filename string, file *ValidatedFile, ownerID string, conversationID int) (UploadResponse, error) {
+	media, err := s.publish(ctx, mediaID, filename, file, ownerID, conversationID, func(objectKey string) error {
+		metadata := map[string]string{metadataSHA256: file.SHA256}
+		return s.storage.UploadFileWithMetadata(ctx, objectKey, bytes.NewReader(file.Data), file.ContentType, metadata)
+	})
+	if err != nil {
+		s.discardUnreferenced(ctx, contentKey(file.SHA256))
+		return UploadResponse{}, err
+	}
+	s.enqueueProcessing(media)
+	return s.uploadResponse(ctx, media)
+}
+
+// GetURL retourne une URL de téléchargement signée et de courte durée, si requesterID a accès au média.
+// variant (optionnel) désigne une variante générée (« thumbnail », « medium ») plutôt que l'original.
+func (s *MediaService) GetURL(ctx context.Context, requesterID, mediaID, variant string) (DownloadURL, error) {
+	media, err := s.authorizedMedia(requesterID, mediaID)
+	if err != nil {
+		return DownloadURL{}, err
+	}
+	if err := s.checkScan(media); err != nil {
+		return DownloadURL{}, err
+	}
+	key := media.ObjectKey
+	if variant != "" {
+		if key, err = variantObjectKey(media, variant); err != nil {
+			return DownloadURL{}, err
 		}
 	}
+	url, expiresAt, err := s.storage.PresignGet(ctx, key, DownloadTTL)
+	if err != nil {
+		return DownloadURL{}, err
+	}
+	return DownloadURL{MediaID: media.ID, URL: url, ExpiresAt: expiresAt.Unix()}, nil
+}
 
-	key := fmt.Sprintf("media/%d_%s", time.Now().UnixNano(), req.Filename)
+// Delete supprime le média si requesterID y a accès ; le fichier et ses variantes ne sont supprimés
+// qu'avec la dernière référence à ce contenu.
+func (s *MediaService) Delete(ctx context.Context, requesterID, mediaID string) error {
+	media, err := s.authorizedMedia(requesterID, mediaID)
+	if err != nil {
+		return err
+	}
+	return s.release(ctx, media)
+}
 
-	var body io.Reader
-	if req.DataBase64 != "" {
-		decoded, err := base64.StdEncoding.DecodeString(req.DataBase64)
-		if err != nil {
-			return UploadResponse{}, fmt.Errorf("erreur décodage base64: %w", err)
+// Purge supprime un média de la conversation purgée, sans contrôle d'utilisateur : réservé au job de
+// purge des conversations supprimées. Un média rattaché à une autre conversation (ou à aucune) est
+// refusé (ErrForbidden) : la pièce jointe d'un message peut citer le média d'un autre groupe. Idempotent.
+func (s *MediaService) Purge(ctx context.Context, conversationID int, mediaID string) error {
+	if mediaID == "" {
+		return fmt.Errorf("mediaId is required")
+	}
+	if conversationID <= 0 {
+		return fmt.Errorf("conversationId is required")
+	}
+	media, err := s.repo.GetMedia(mediaID)
+	if errors.Is(err, repo.ErrMediaNotFound) {
+		// Déjà purgé, ou objet antérieur au registre : sans conversation connue, il n'est pas supprimé.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if media.ConversationID != conversationID {
+		return fmt.Errorf("%w : le média n'appartient pas à la conversation %d", ErrForbidden, conversationID)
+	}
+	return s.release(ctx, media)
+}
+
+// release supprime la référence ; l'objet et ses variantes partent avec la dernière.
+func (s *MediaService) release(ctx context.Context, media *models.Media) error {
+	remaining, err := s.repo.DeleteMedia(media.ID)
+	if errors.Is(err, repo.ErrMediaNotFound) {
+		// Suppression concurrente : la référence a déjà été libérée.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if remaining > 0 {
+		return nil
+	}
+	// Sous le verrou de l'objet : un upload du même contenu ne peut pas le reprendre pendant la suppression.
+	return s.repo.DiscardObject(media.ObjectKey, func() error {
+		if err := s.storage.DeleteFile(ctx, media.ObjectKey); err != nil {
+			return err
 		}
-		body = bytes.NewReader(decoded)
-	} else {
-		body = bytes.NewReader(nil)
+		s.discardVariants(ctx, media.ObjectKey)
+		return nil
+	})
+}
+
+const (
+	// MaxUploadSize : taille maximale d'un média, upload direct compris.
+	MaxUploadSize = 50 << 20 // 50 MB
+	// PresignTTL : durée de validité d'une URL d'upload direct.
+	PresignTTL = 15 * time.Minute
+	// DownloadTTL : durée de validité d'une URL de téléchargement.
+	DownloadTTL = 5 * time.Minute
+
+	pendingPrefix = "pending/"
+	mediaPrefix   = "media/"
+	// objectPrefix : objets adressés par contenu, objects/<sha256>.
+	objectPrefix = "objects/"
+	// maxPublishAttempts : enregistrements tentés quand le contenu dédupliqué est libéré entre-temps.
+	maxPublishAttempts = 3
+)
+
+var (
+	ErrUploadNotFound = errors.New("upload introuvable : fichier non envoyé ou URL expirée")
+	ErrInvalidMediaID = errors.New("mediaId invalide")
+	ErrMediaNotFound  = errors.New("média introuvable")
+	ErrForbidden      = errors.New("accès au média refusé")
+	ErrOwnerRequired  = errors.New("ownerId is required")
+
+	// errReferenceReleased : la référence reprise par la déduplication a été libérée avant l'enregistrement.
+	errReferenceReleased = errors.New("contenu libéré pendant l'upload")
+)
+
+type PresignRequest struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	OwnerID     string
+	Voice       bool
+}
+
+// PresignResponse : le client envoie le fichier par Method sur UploadURL avec Headers,
+// puis confirme avec MediaID (en attente) sur media.upload.complete, qui enregistre le propriétaire.
+type PresignResponse struct {
+	MediaID   string            `json:"mediaId"`
+	UploadURL string            `json:"uploadUrl"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt int64             `json:"expiresAt"`
+}
+
+// PresignUpload réserve un media ID en attente (pending/...) et signe un PUT direct vers le stockage :
+// le fichier ne transite plus par le gateway ni par NATS.
+func (s *MediaService) PresignUpload(ctx context.Context, req PresignRequest) (PresignResponse, error) {
+	req.Filename = safeFilename(req.Filename)
+	if req.Filename == "" {
+		return PresignResponse{}, fmt.Errorf("filename is required")
+	}
+	category, err := s.uploadPolicy(req.Voice).Category(req.ContentType)
+	if err != nil {
+		return PresignResponse{}, err
+	}
+	if req.Size <= 0 {
+		return PresignResponse{}, fmt.Errorf("%w: taille invalide: %d", ErrInvalidUpload, req.Size)
+	}
+	if limit := MaxSizeFor(category); req.Size > limit {
+		return PresignResponse{}, fmt.Errorf("%w: %d octets (max %d)", ErrFileTooLarge, req.Size, limit)
+	}
+	if req.OwnerID == "" {
+		return PresignResponse{}, ErrOwnerRequired
 	}
 
-	if err := s.storage.UploadFile(ctx, key, body, req.ContentType); err != nil {
-		return UploadResponse{}, err
+	token := make([]byte, 8)
+	if _, err := rand.Read(token); err != nil {
+		return PresignResponse{}, fmt.Errorf("génération media ID: %w", err)
 	}
+	key := fmt.Sprintf("%s%d_%s_%s", pendingPrefix, time.Now().UnixNano(), hex.EncodeToString(token), req.Filename)
+	contentType := canonicalType(normalizeContentType(req.ContentType))
 
-	url := s.storage.GetFileURL(key)
-	return UploadResponse{MediaID: key, Key: key, URL: url}, nil
+	upload, err := s.storage.PresignPut(ctx, key, contentType, req.Size, PresignTTL)
+	if err != nil {
+		return PresignResponse{}, err
+	}
+	return PresignResponse{
+		MediaID:   key,
+		UploadURL: upload.URL,
+		Method:    upload.Method,
+		Headers:   upload.Headers,
+		ExpiresAt: upload.ExpiresAt.Unix(),
+	}, nil
 }
 
-// UploadFromReader upload un fichier depuis un io.Reader (HTTP multipart)
-func (s *MediaService) UploadFromReader(ctx context.Context, filename, contentType string, reader io.Reader) (UploadResponse, error) {
-	if filename == "" {
-		return UploadResponse{}, fmt.Errorf("filename is required")
+// CompleteUpload vérifie (HEAD) que le fichier en attente a bien été envoyé, valide son contenu
+// (ValidateUpload), le copie sous sa clé de contenu (sauf s'il y est déjà) et enregistre ownerID comme
+// propriétaire du média media/<nom>. Idempotent : un second appel du même propriétaire retourne le
+// média déjà finalisé. voice : note vocale, comme annoncé à PresignUpload.
+func (s *MediaService) CompleteUpload(ctx context.Context, ownerID string, conversationID int, pendingID string, voice bool) (UploadResponse, error) {
+	if !strings.HasPrefix(pendingID, pendingPrefix) || len(pendingID) == len(pendingPrefix) {
+		return UploadResponse{}, ErrInvalidMediaID
+	}
+	if err := s.authorizeUpload(ownerID, conversationID); err != nil {
+		return UploadResponse{}, err
 	}
+	key := mediaPrefix + strings.TrimPrefix(pendingID, pendingPrefix)
 
-	if err := ValidateContentType(contentType); err != nil {
+	info, err := s.storage.HeadFile(ctx, pendingID)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		// Déjà finalisé par un appel précédent ?
+		media, getErr := s.repo.GetMedia(key)
+		if errors.Is(getErr, repo.ErrMediaNotFound) {
+			return UploadResponse{}, ErrUploadNotFound
+		}
+		if getErr != nil {
+			return UploadResponse{}, getErr
+		}
+		if media.OwnerID != ownerID {
+			return UploadResponse{}, ErrForbidden
+		}
+		return s.uploadResponse(ctx, media)
+	}
+	if err != nil {
 		return UploadResponse{}, err
 	}
 
-	key := fmt.Sprintf("media/%d_%s", time.Now().UnixNano(), filename)
+	// La signature impose type et taille déclarés, pas le contenu : l'objet est relu et passe par
+	// le même pipeline que les autres uploads avant d'être publié.
+	data, err := s.storage.GetFile(ctx, pendingID, MaxUploadSize)
+	if err != nil {
+		return UploadResponse{}, err
+	}
+	file, err := s.uploadPolicy(voice).ValidateUpload(data, info.ContentType, info.Size)
+	if err != nil {
+		s.discardObject(ctx, pendingID)
+		return UploadResponse{}, err
+	}
 
-	if err := s.storage.UploadFile(ctx, key, reader, contentType); err != nil {
+	// L'objet en attente n'est supprimé qu'une fois le média enregistré : un échec ici se rejoue.
+	media, err := s.publish(ctx, key, pendingFilename(pendingID), file, ownerID, conversationID, func(objectKey string) error {
+		metadata := map[string]string{
+			"size":         strconv.FormatInt(file.Size, 10),
+			"pending-id":   pendingID,
+			metadataSHA256: file.SHA256,
+		}
+		return s.putValidated(ctx, pendingID, objectKey, file, metadata)
+	})
+	if errors.Is(err, repo.ErrMediaExists) {
+		media, err = s.repo.GetMedia(key)
+		if err == nil && media.OwnerID != ownerID {
+			return UploadResponse{}, ErrForbidden
+		}
+	}
+	if err != nil {
 		return UploadResponse{}, err
 	}
+	s.discardObject(ctx, pendingID)
+	s.enqueueProcessing(media)
+	return s.uploadResponse(ctx, media)
+}
 
-	url := s.storage.GetFileURL(key)
-	return UploadResponse{MediaID: key, Key: key, URL: url}, nil
+// publish enregistre la référence mediaID vers le contenu validé file ; put le stocke sous sa clé de
+// contenu s'il n'y est pas déjà. Si la dernière référence au contenu est libérée entre la déduplication
+// et l'enregistrement, le contenu est renvoyé plutôt que de référencer un objet supprimé.
+func (s *MediaService) publish(ctx context.Context, mediaID, filename string, file *ValidatedFile, ownerID string, conversationID int, put func(objectKey string) error) (*models.Media, error) {
+	objectKey := contentKey(file.SHA256)
+	for attempt := 1; ; attempt++ {
+		existing, err := s.existingReference(objectKey)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			if err := put(objectKey); err != nil {
+				return nil, err
+			}
+		}
+		// Appelé sous le verrou de l'objet, quand plus aucune référence ne le retient.
+		ensure := func() error {
+			if existing != nil {
+				return errReferenceReleased
+			}
+			if _, err := s.storage.HeadFile(ctx, objectKey); !errors.Is(err, storage.ErrObjectNotFound) {
+				return err
+			}
+			return put(objectKey)
+		}
+		media, err := s.record(ctx, mediaID, objectKey, filename, file, ownerID, conversationID, existing, ensure)
+		if !errors.Is(err, errReferenceReleased) || attempt == maxPublishAttempts {
+			return media, err
+		}
+	}
 }
 
-// GetURL retourne l'URL publique d'un media par sa clé
-func (s *MediaService) GetURL(mediaID string) (string, error) {
-	if mediaID == "" {
-		return "", fmt.Errorf("mediaId is required")
+// putValidated stocke file sous objectKey : copie de l'objet reçu src, ou envoi de file.Data quand les
+// métadonnées en ont été retirées.
+func (s *MediaService) putValidated(ctx context.Context, src, objectKey string, file *ValidatedFile, metadata map[string]string) error {
+	if file.Stripped {
+		return s.storage.UploadFileWithMetadata(ctx, objectKey, bytes.NewReader(file.Data), file.ContentType, metadata)
+	}
+	return s.storage.CopyFile(ctx, src, objectKey, file.ContentType, metadata)
+}
+
+// record enregistre la référence mediaID vers objectKey ; les variantes déjà générées pour ce contenu
+// (existing) sont reprises telles quelles.
+func (s *MediaService) record(ctx context.Context, mediaID, objectKey, filename string, file *ValidatedFile, ownerID string, conversationID int, existing *models.Media, ensure func() error) (*models.Media, error) {
+	status, variants := s.plannedVariants(objectKey, file.ContentType)
+	if existing != nil && existing.Status == models.ProcessingReady {
+		status, variants = existing.Status, existing.Variants
+	}
+	durationMs, waveform := s.audioDetails(ctx, objectKey, file, existing)
+	scanStatus, scanSignature := s.plannedScan(existing)
+	return s.repo.CreateMedia(&models.Media{
+		ID:             mediaID,
+		ObjectKey:      objectKey,
+		OwnerID:        ownerID,
+		ConversationID: conversationID,
+		ContentType:    file.ContentType,
+		Category:       file.Category,
+		Filename:       filename,
+		Size:           file.Size,
+		Checksum:       file.SHA256,
+		CreatedAt:      time.Now().UTC(),
+		DurationMs:     durationMs,
+		Waveform:       waveform,
+		Status:         status,
+		Variants:       variants,
+		ScanStatus:     scanStatus,
+		ScanSignature:  scanSignature,
+	}, ensure)
+}
+
+// audioDetails : durée d'un fichier audio et, pour une note vocale, sa forme d'onde ; reprises d'une
+// référence existante au même contenu quand elle les porte. Best effort : un conteneur illisible
+// n'empêche pas l'upload, le média reste sans durée.
+func (s *MediaService) audioDetails(ctx context.Context, objectKey string, file *ValidatedFile, existing *models.Media) (int64, []int) {
+	voice := file.Category == CategoryVoice
+	if file.Category != CategoryAudio && !voice {
+		return 0, nil
+	}
+	if existing != nil && existing.DurationMs > 0 && (!voice || len(existing.Waveform) > 0) {
+		if !voice {
+			return existing.DurationMs, nil
+		}
+		return existing.DurationMs, existing.Waveform
+	}
+	data := file.Data
+	if data == nil {
+		// Upload reprenable, validé en continu : l'objet est relu s'il tient en mémoire.
+		if file.Size > MaxUploadSize {
+			return 0, nil
+		}
+		var err error
+		if data, err = s.storage.GetFile(ctx, objectKey, MaxUploadSize); err != nil {
+			log.Printf("lecture audio %s: %v", objectKey, err)
+			return 0, nil
+		}
+	}
+	info, err := audio.Analyze(data, file.ContentType, audio.WaveformPeaks)
+	if err != nil {
+		log.Printf("analyse audio %s: %v", objectKey, err)
+		return 0, nil
+	}
+	if !voice {
+		return info.Duration.Milliseconds(), nil
+	}
+	return info.Duration.Milliseconds(), info.Peaks
+}
+
+// safeFilename : nom de fichier sans séparateur de chemin, intégré tel quel au media ID. Un « / » ferait
+// de media/<nanos>_<nom> une clé à plusieurs segments, que les références des messages ne reconnaissent
+// pas ; "" si rien d'utilisable ne reste.
+func safeFilename(name string) string {
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		switch {
+		case r == '/' || r == '\\':
+			return '_'
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, name))
+	if name == "." || name == ".." {
+		return ""
+	}
+	return name
+}
+
+// pendingFilename : nom de fichier d'une clé en attente pending/<nanos>_<jeton>_<nom>.
+func pendingFilename(key string) string {
+	parts := strings.SplitN(strings.TrimPrefix(key, pendingPrefix), "_", 3)
+	if len(parts) < 3 {
+		return ""
 	}
-	return s.storage.GetFileURL(mediaID), nil
+	return parts[2]
 }
 
-func (s *MediaService) Delete(ctx context.Context, mediaID string) error {
+// uploadResponse : l'URL retournée est signée (DownloadTTL), le media ID reste la référence durable.
+// Pas d'URL tant que l'analyse antivirus n'a pas conclu à un fichier sain.
+func (s *MediaService) uploadResponse(ctx context.Context, media *models.Media) (UploadResponse, error) {
+	var url string
+	if s.checkScan(media) == nil {
+		var err error
+		if url, _, err = s.storage.PresignGet(ctx, media.ObjectKey, DownloadTTL); err != nil {
+			return UploadResponse{}, err
+		}
+	}
+	return UploadResponse{
+		MediaID:     media.ID,
+		Key:         media.ObjectKey,
+		URL:         url,
+		Size:        media.Size,
+		ContentType: media.ContentType,
+		Category:    media.Category,
+		Filename:    media.Filename,
+		DurationMs:  media.DurationMs,
+		Waveform:    media.Waveform,
+		Status:      media.Status,
+		Variants:    media.Variants,
+		ScanStatus:  media.ScanStatus,
+	}, nil
+}
+
+// authorizeUpload : un média a toujours un propriétaire ; le rattacher à une conversation exige d'en être membre.
+func (s *MediaService) authorizeUpload(ownerID string, conversationID int) error {
+	if ownerID == "" {
+		return ErrOwnerRequired
+	}
+	if conversationID < 0 {
+		return fmt.Errorf("conversationId invalide: %d", conversationID)
+	}
+	if conversationID == 0 {
+		return nil
+	}
+	return s.requireMember(ownerID, conversationID)
+}
+
+// authorizedMedia charge le média si requesterID en est le propriétaire ou membre de sa conversation.
+func (s *MediaService) authorizedMedia(requesterID, mediaID string) (*models.Media, error) {
 	if mediaID == "" {
-		return fmt.Errorf("mediaId is required")
+		return nil, fmt.Errorf("mediaId is required")
+	}
+	if requesterID == "" {
+		return nil, ErrForbidden
+	}
+	media, err := s.repo.GetMedia(mediaID)
+	if errors.Is(err, repo.ErrMediaNotFound) {
+		return nil, ErrMediaNotFound
+	}
+	if err != nil {
+		return nil, err
 	}
-	return s.storage.DeleteFile(ctx, mediaID)
-}
\ No newline at end of file
+	if media.OwnerID == requesterID {
+		return media, nil
+	}
+	if media.ConversationID == 0 {
+		return nil, ErrForbidden
+	}
+	if err := s.requireMember(requesterID, media.ConversationID); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+func (s *MediaService) requireMember(userID string, conversationID int) error {
+	if s.members == nil {
+		return ErrForbidden
+	}
+	isMember, err := s.members.IsMember(userID, conversationID)
+	if err != nil {
+		return fmt.Errorf("vérification membre conversation %d: %w", conversationID, err)
+	}
+	if !isMember {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// contentKey : clé objet d'un contenu, dérivée de son SHA-256.
+func contentKey(sha256Hex string) string {
+	return objectPrefix + sha256Hex
+}
+
+// existingReference retourne une référence au contenu déjà stocké sous objectKey, nil s'il ne l'est pas.
+func (s *MediaService) existingReference(objectKey string) (*models.Media, error) {
+	media, err := s.repo.FindByObjectKey(objectKey)
+	if errors.Is(err, repo.ErrMediaNotFound) {
+		return nil, nil
+	}
+	return media, err
+}
+
+// discardUnreferenced supprime un objet et ses variantes si plus aucun média n'y fait référence (best effort).
+func (s *MediaService) discardUnreferenced(ctx context.Context, objectKey string) {
+	err := s.repo.DiscardObject(objectKey, func() error {
+		s.discardObject(ctx, objectKey)
+		s.discardVariants(ctx, objectKey)
+		return nil
+	})
+	if err != nil {
+		log.Printf("suppression objet %s: %v", objectKey, err)
+	}
+}
+
+// discardObject supprime un objet (best effort : journalisé en cas d'échec).
+func (s *MediaService) discardObject(ctx context.Context, key string) {
+	if err := s.storage.DeleteFile(ctx, key); err != nil {
+		log.Printf("suppression objet %s: %v", key, err)
+	}
+}
@@ -0,0 +1,562 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/repo/memory"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/storage"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/storage/s3test"
+)
+
+const (
+	testBucket       = "media"
+	testOwner        = "11111111-1111-1111-1111-111111111111"
+	testMember       = "22222222-2222-2222-2222-222222222222"
+	testOutsider     = "33333333-3333-3333-3333-333333333333"
+	testConversation = 42
+)
+
+// fakeMembers : testOwner et testMember sont membres de testConversation.
+type fakeMembers struct{}
+
+func (fakeMembers) IsMember(userID string, conversationID int) (bool, error) {
+	return conversationID == testConversation && (userID == testOwner || userID == testMember), nil
+}
+
+func newTestService(t *testing.T) (*MediaService, *s3test.Server) {
+	t.Helper()
+	server := s3test.NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := storage.NewMinIOClientWithConfig(storage.Config{
+		Endpoint:  server.URL,
+		AccessKey: "admin",
+		SecretKey: "password",
+		Bucket:    testBucket,
+	})
+	if err != nil {
+		t.Fatalf("NewMinIOClientWithConfig() error = %v", err)
+	}
+	return NewMediaService(client, memory.NewMediaRepo(), memory.NewUploadSessionRepo(), fakeMembers{}), server
+}
+
+// putPresigned joue le rôle du client : PUT direct sur l'URL signée.
+func putPresigned(t *testing.T, presign PresignResponse, contentType string, body []byte) {
+	t.Helper()
+	req, err := http.NewRequest(presign.Method, presign.UploadURL, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	for name, value := range presign.Headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("presigned PUT error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("presigned PUT status = %d", resp.StatusCode)
+	}
+}
+
+func TestMediaServicePresignUploadValidation(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	cases := []PresignRequest{
+		{Filename: "", ContentType: "image/png", Size: 10},
+		{Filename: "doc.pdf", ContentType: "application/pdf", Size: 10},
+		{Filename: "photo.png", ContentType: "image/png", Size: 0},
+		{Filename: "film.mp4", ContentType: "video/mp4", Size: MaxUploadSize + 1, OwnerID: testOwner},
+		{Filename: "photo.png", ContentType: "image/png", Size: MaxImageSize + 1, OwnerID: testOwner},
+		{Filename: "photo.png", ContentType: "image/png", Size: 10},
+	}
+	for _, req := range cases {
+		if _, err := svc.PresignUpload(ctx, req); err == nil {
+			t.Fatalf("PresignUpload(%+v) should fail", req)
+		}
+	}
+}
+
+func TestMediaServicePresignAndComplete(t *testing.T) {
+	svc, server := newTestService(t)
+	ctx := context.Background()
+	body := pngBytes(t, 4, 4)
+
+	presign, err := svc.PresignUpload(ctx, PresignRequest{Filename: "photo.png", ContentType: "image/png", Size: int64(len(body)), OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("PresignUpload() error = %v", err)
+	}
+	if !strings.HasPrefix(presign.MediaID, "pending/") || !strings.HasSuffix(presign.MediaID, "_photo.png") {
+		t.Fatalf("unexpected pending media ID %q", presign.MediaID)
+	}
+	if presign.Method != http.MethodPut || presign.ExpiresAt == 0 {
+		t.Fatalf("unexpected presign response %+v", presign)
+	}
+
+	// Confirmation avant envoi : rien à finaliser.
+	if _, err := svc.CompleteUpload(ctx, testOwner, 0, presign.MediaID, false); !errors.Is(err, ErrUploadNotFound) {
+		t.Fatalf("complete before upload: expected ErrUploadNotFound, got %v", err)
+	}
+
+	putPresigned(t, presign, "image/png", body)
+
+	done, err := svc.CompleteUpload(ctx, testOwner, 0, presign.MediaID, false)
+	if err != nil {
+		t.Fatalf("CompleteUpload() error = %v", err)
+	}
+	if !strings.HasPrefix(done.MediaID, "media/") || done.Size != int64(len(body)) || done.ContentType != "image/png" {
+		t.Fatalf("unexpected completed media %+v", done)
+	}
+	if !strings.Contains(done.URL, "/"+testBucket+"/"+done.Key+"?") || !strings.Contains(done.URL, "X-Amz-Signature=") {
+		t.Fatalf("unexpected URL %q", done.URL)
+	}
+	if server.Get(testBucket, presign.MediaID) != nil {
+		t.Fatal("pending object should have been removed")
+	}
+	final := server.Get(testBucket, done.Key)
+	if final == nil || !bytes.Equal(final.Data, body) || final.Metadata["size"] != strconv.Itoa(len(body)) || final.Metadata["sha256"] != sha256Hex(body) {
+		t.Fatalf("unexpected final object %+v", final)
+	}
+
+	// Idempotent : une seconde confirmation retourne le même média.
+	again, err := svc.CompleteUpload(ctx, testOwner, 0, presign.MediaID, false)
+	if err != nil || again.MediaID != done.MediaID {
+		t.Fatalf("second CompleteUpload() = %+v, %v", again, err)
+	}
+	if _, err := svc.CompleteUpload(ctx, testOutsider, 0, presign.MediaID, false); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("completion by another user: expected ErrForbidden, got %v", err)
+	}
+
+	media, err := svc.repo.GetMedia(done.MediaID)
+	if err != nil {
+		t.Fatalf("GetMedia() error = %v", err)
+	}
+	if media.OwnerID != testOwner || media.Size != int64(len(body)) || media.ContentType != "image/png" || media.Checksum != sha256Hex(body) {
+		t.Fatalf("unexpected media record %+v", media)
+	}
+}
+
+// pngWithLocation : pngBytes avec un chunk texte portant une position GPS, inséré après IHDR.
+func pngWithLocation(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	clean := pngBytes(t, 4, 4)
+	chunk := append(binary.BigEndian.AppendUint32(nil, 10), "tEXtGPS\x0048.85N"...)
+	chunk = append(chunk, 0, 0, 0, 0)
+	return append(append(append([]byte(nil), clean[:33]...), chunk...), clean[33:]...), clean
+}
+
+func TestMediaServiceStripsImageMetadata(t *testing.T) {
+	svc, server := newTestService(t)
+	ctx := context.Background()
+	body, clean := pngWithLocation(t)
+	check := func(resp UploadResponse) {
+		t.Helper()
+		stored := server.Get(testBucket, resp.Key)
+		if stored == nil || !bytes.Equal(stored.Data, clean) || stored.Metadata["sha256"] != sha256Hex(clean) || resp.Size != int64(len(clean)) {
+			t.Fatalf("stored original must be stripped: %+v", resp)
+		}
+	}
+
+	direct, err := svc.Upload(ctx, UploadRequest{Filename: "photo.png", DataBase64: base64.StdEncoding.EncodeToString(body), Size: int64(len(body)), OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	check(direct)
+
+	// Upload direct : l'objet reçu n'est pas recopié tel quel.
+	presign, err := svc.PresignUpload(ctx, PresignRequest{Filename: "photo.png", ContentType: "image/png", Size: int64(len(body)), OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("PresignUpload() error = %v", err)
+	}
+	putPresigned(t, presign, "image/png", body)
+	done, err := svc.CompleteUpload(ctx, testOwner, 0, presign.MediaID, false)
+	if err != nil {
+		t.Fatalf("CompleteUpload() error = %v", err)
+	}
+	check(done)
+}
+
+func TestMediaServiceCompleteUploadRejects(t *testing.T) {
+	svc, server := newTestService(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"", "pending/", "media/123_photo.png"} {
+		if _, err := svc.CompleteUpload(ctx, testOwner, 0, id, false); !errors.Is(err, ErrInvalidMediaID) {
+			t.Fatalf("CompleteUpload(%q): expected ErrInvalidMediaID, got %v", id, err)
+		}
+	}
+
+	// Objet déposé hors URL signée avec un type interdit : refusé et supprimé.
+	server.Put(testBucket, "pending/1_abc_script.sh", s3test.Object{Data: []byte("#!/bin/sh"), ContentType: "text/x-shellscript"})
+	if _, err := svc.CompleteUpload(ctx, testOwner, 0, "pending/1_abc_script.sh", false); err == nil {
+		t.Fatal("disallowed content type should be rejected")
+	}
+	if server.Get(testBucket, "pending/1_abc_script.sh") != nil {
+		t.Fatal("rejected pending object should have been deleted")
+	}
+	if server.Get(testBucket, "media/1_abc_script.sh") != nil {
+		t.Fatal("rejected object must not be published")
+	}
+
+	// Type autorisé annoncé, contenu d'un autre type : refusé par le sniffing.
+	server.Put(testBucket, "pending/2_abc_photo.png", s3test.Object{Data: []byte("<html><script>alert(1)</script>"), ContentType: "image/png"})
+	if _, err := svc.CompleteUpload(ctx, testOwner, 0, "pending/2_abc_photo.png", false); !errors.Is(err, ErrInvalidUpload) {
+		t.Fatalf("disguised content: expected ErrInvalidUpload, got %v", err)
+	}
+	if server.Get(testBucket, "pending/2_abc_photo.png") != nil || server.Get(testBucket, "media/2_abc_photo.png") != nil {
+		t.Fatal("disguised object should have been deleted, not published")
+	}
+}
+
+func TestMediaServiceAccessControl(t *testing.T) {
+	svc, server := newTestService(t)
+	ctx := context.Background()
+	data := pngBase64(t, 4, 4)
+
+	if _, err := svc.Upload(ctx, UploadRequest{Filename: "photo.png", ContentType: "image/png", DataBase64: data}); !errors.Is(err, ErrOwnerRequired) {
+		t.Fatalf("upload without owner: expected ErrOwnerRequired, got %v", err)
+	}
+	if _, err := svc.Upload(ctx, UploadRequest{Filename: "photo.png", ContentType: "image/png", DataBase64: data, OwnerID: testOutsider, ConversationID: testConversation}); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("upload to a foreign conversation: expected ErrForbidden, got %v", err)
+	}
+
+	shared, err := svc.Upload(ctx, UploadRequest{Filename: "photo.png", ContentType: "image/png", DataBase64: data, OwnerID: testOwner, ConversationID: testConversation})
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	private, err := svc.Upload(ctx, UploadRequest{Filename: "perso.png", ContentType: "image/png", DataBase64: data, OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	// Membre de la conversation : accès au média partagé, pas au média privé.
+	download, err := svc.GetURL(ctx, testMember, shared.MediaID, "")
+	if err != nil {
+		t.Fatalf("GetURL(member) error = %v", err)
+	}
+	if !strings.Contains(download.URL, "X-Amz-Expires=300") || download.ExpiresAt == 0 {
+		t.Fatalf("expected a short-lived presigned URL, got %+v", download)
+	}
+	if _, err := svc.GetURL(ctx, testMember, private.MediaID, ""); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("private media: expected ErrForbidden, got %v", err)
+	}
+	if _, err := svc.GetURL(ctx, testOutsider, shared.MediaID, ""); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("outsider: expected ErrForbidden, got %v", err)
+	}
+	if _, err := svc.GetURL(ctx, testOwner, "media/0_inconnu.png", ""); !errors.Is(err, ErrMediaNotFound) {
+		t.Fatalf("unknown media: expected ErrMediaNotFound, got %v", err)
+	}
+
+	if err := svc.Delete(ctx, testOutsider, shared.MediaID); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("outsider delete: expected ErrForbidden, got %v", err)
+	}
+	if err := svc.Delete(ctx, testOwner, shared.MediaID); err != nil {
+		t.Fatalf("Delete(owner) error = %v", err)
+	}
+	// Même contenu que le média privé : l'objet reste tant qu'une référence subsiste.
+	if shared.Key != private.Key || server.Get(testBucket, shared.Key) == nil {
+		t.Fatal("shared object should be kept while still referenced")
+	}
+	if _, err := svc.GetURL(ctx, testOwner, shared.MediaID, ""); !errors.Is(err, ErrMediaNotFound) {
+		t.Fatalf("deleted media: expected ErrMediaNotFound, got %v", err)
+	}
+
+	// Purge interne : limitée aux médias de la conversation purgée, idempotente.
+	if err := svc.Purge(ctx, testConversation, private.MediaID); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("purge of a media outside the conversation: expected ErrForbidden, got %v", err)
+	}
+	if server.Get(testBucket, private.Key) == nil {
+		t.Fatal("object of a media outside the purged conversation must be kept")
+	}
+	attached, err := svc.Upload(ctx, UploadRequest{Filename: "piece.png", ContentType: "image/png", DataBase64: data, OwnerID: testOwner, ConversationID: testConversation})
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if err := svc.Purge(ctx, testConversation+1, attached.MediaID); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("purge from another conversation: expected ErrForbidden, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := svc.Purge(ctx, testConversation, attached.MediaID); err != nil {
+			t.Fatalf("Purge() error = %v", err)
+		}
+	}
+	if err := svc.Delete(ctx, testOwner, private.MediaID); err != nil {
+		t.Fatalf("Delete(private) error = %v", err)
+	}
+	if server.Get(testBucket, private.Key) != nil {
+		t.Fatal("object should be removed with its last reference")
+	}
+}
+
+func TestMediaServiceDeduplication(t *testing.T) {
+	svc, server := newTestService(t)
+	queue := &queueRecorder{}
+	svc.SetProcessingQueue(queue)
+	ctx := context.Background()
+	body := pngBytes(t, 300, 300)
+	data := base64.StdEncoding.EncodeToString(body)
+
+	first, err := svc.Upload(ctx, UploadRequest{Filename: "meme.png", DataBase64: data, OwnerID: testOwner, ConversationID: testConversation})
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if first.Key != "objects/"+sha256Hex(body) {
+		t.Fatalf("object should be keyed by content hash, got %q", first.Key)
+	}
+	if _, err := svc.GenerateVariants(ctx, first.MediaID); err != nil {
+		t.Fatalf("GenerateVariants() error = %v", err)
+	}
+
+	// Même contenu, autre utilisateur et autre nom : nouvelle référence, objet et variantes réutilisés.
+	second, err := svc.Upload(ctx, UploadRequest{Filename: "transfert.png", DataBase64: data, OwnerID: testOutsider})
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if second.MediaID == first.MediaID || second.Key != first.Key {
+		t.Fatalf("expected a new reference to the same object, got %+v / %+v", first, second)
+	}
+	if second.Status != models.ProcessingReady || len(second.Variants) != 2 || len(queue.ids) != 1 {
+		t.Fatalf("ready variants should be reused without processing, got %+v (queue %v)", second, queue.ids)
+	}
+
+	// Upload direct du même contenu : l'objet en attente est supprimé sans copie.
+	presign, err := svc.PresignUpload(ctx, PresignRequest{Filename: "encore.png", ContentType: "image/png", Size: int64(len(body)), OwnerID: testMember})
+	if err != nil {
+		t.Fatalf("PresignUpload() error = %v", err)
+	}
+	putPresigned(t, presign, "image/png", body)
+	third, err := svc.CompleteUpload(ctx, testMember, 0, presign.MediaID, false)
+	if err != nil {
+		t.Fatalf("CompleteUpload() error = %v", err)
+	}
+	if third.Key != first.Key {
+		t.Fatalf("completed upload should reuse the object, got %q", third.Key)
+	}
+	// Un seul objet et ses deux variantes.
+	if keys := server.Keys(testBucket); len(keys) != 3 {
+		t.Fatalf("expected one object and two variants, got %v", keys)
+	}
+
+	// L'objet ne disparaît qu'avec la dernière référence.
+	if err := svc.Delete(ctx, testOwner, first.MediaID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := svc.Delete(ctx, testOutsider, second.MediaID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if download, err := svc.GetURL(ctx, testMember, third.MediaID, "thumbnail"); err != nil || download.URL == "" {
+		t.Fatalf("remaining reference should stay readable: %+v, %v", download, err)
+	}
+	if server.Get(testBucket, first.Key) == nil {
+		t.Fatal("object should be kept while still referenced")
+	}
+	if err := svc.Delete(ctx, testMember, third.MediaID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if keys := server.Keys(testBucket); len(keys) != 0 {
+		t.Fatalf("object and variants should be removed with the last reference, got %v", keys)
+	}
+}
+
+func TestMediaServiceUploadFilename(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	resp, err := svc.Upload(ctx, UploadRequest{Filename: "vacances/plage\\photo.png", DataBase64: pngBase64(t, 4, 4), OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	// Le media ID reste une clé à un seul segment après media/.
+	if strings.Count(resp.MediaID, "/") != 1 || !strings.HasSuffix(resp.MediaID, "_vacances_plage_photo.png") || resp.Filename != "vacances_plage_photo.png" {
+		t.Fatalf("unexpected media ID %q / filename %q", resp.MediaID, resp.Filename)
+	}
+	for _, name := range []string{"", " ", "..", "\x00"} {
+		if _, err := svc.PresignUpload(ctx, PresignRequest{Filename: name, ContentType: "image/png", Size: 10, OwnerID: testOwner}); err == nil {
+			t.Fatalf("PresignUpload(%q) should be rejected", name)
+		}
+	}
+}
+
+// releasingRepo libère une référence juste avant l'enregistrement suivant : course entre la
+// déduplication d'un upload et la suppression de la dernière référence au même contenu.
+type releasingRepo struct {
+	repo.MediaRepo
+	release func()
+}
+
+func (r *releasingRepo) CreateMedia(media *models.Media, ensure func() error) (*models.Media, error) {
+	if release := r.release; release != nil {
+		r.release = nil
+		release()
+	}
+	return r.MediaRepo.CreateMedia(media, ensure)
+}
+
+func TestMediaServiceDeduplicationConcurrentRelease(t *testing.T) {
+	svc, server := newTestService(t)
+	ctx := context.Background()
+	data := pngBase64(t, 8, 8)
+
+	first, err := svc.Upload(ctx, UploadRequest{Filename: "meme.png", DataBase64: data, OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	// La dernière référence part entre la déduplication et l'enregistrement du second upload.
+	svc.repo = &releasingRepo{MediaRepo: svc.repo, release: func() {
+		if err := svc.Delete(ctx, testOwner, first.MediaID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+	}}
+	second, err := svc.Upload(ctx, UploadRequest{Filename: "copie.png", DataBase64: data, OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if second.Key != first.Key || server.Get(testBucket, second.Key) == nil {
+		t.Fatalf("the released content should be stored again, got %+v (keys %v)", second, server.Keys(testBucket))
+	}
+	if _, err := svc.GetURL(ctx, testOwner, second.MediaID, ""); err != nil {
+		t.Fatalf("GetURL() error = %v", err)
+	}
+}
+
+// queueRecorder remplace le worker : les médias sont traités explicitement par le test.
+type queueRecorder struct {
+	ids []string
+}
+
+func (q *queueRecorder) Enqueue(mediaID string) {
+	q.ids = append(q.ids, mediaID)
+}
+
+func pngBytes(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func pngBase64(t *testing.T, w, h int) string {
+	t.Helper()
+	return base64.StdEncoding.EncodeToString(pngBytes(t, w, h))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestMediaServiceGenerateVariants(t *testing.T) {
+	svc, server := newTestService(t)
+	queue := &queueRecorder{}
+	svc.SetProcessingQueue(queue)
+	ctx := context.Background()
+
+	uploaded, err := svc.Upload(ctx, UploadRequest{Filename: "photo.png", ContentType: "image/png", DataBase64: pngBase64(t, 2000, 1000), OwnerID: testOwner, ConversationID: testConversation})
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if uploaded.Status != models.ProcessingPending || len(uploaded.Variants) != 2 || uploaded.Variants[0].Key == "" {
+		t.Fatalf("upload response should announce pending variants, got %+v", uploaded)
+	}
+	if len(queue.ids) != 1 || queue.ids[0] != uploaded.MediaID {
+		t.Fatalf("media should be queued for processing, got %v", queue.ids)
+	}
+	if _, err := svc.GetURL(ctx, testMember, uploaded.MediaID, "thumbnail"); !errors.Is(err, ErrVariantNotFound) {
+		t.Fatalf("variant before processing: expected ErrVariantNotFound, got %v", err)
+	}
+
+	media, err := svc.GenerateVariants(ctx, uploaded.MediaID)
+	if err != nil {
+		t.Fatalf("GenerateVariants() error = %v", err)
+	}
+	if media.Status != models.ProcessingReady {
+		t.Fatalf("expected ready status, got %q", media.Status)
+	}
+	sizes := map[string][2]int{"thumbnail": {256, 128}, "medium": {1024, 512}}
+	for _, variant := range media.Variants {
+		want := sizes[variant.Name]
+		if variant.Width != want[0] || variant.Height != want[1] || variant.ContentType != "image/jpeg" {
+			t.Fatalf("unexpected variant %+v", variant)
+		}
+		stored := server.Get(testBucket, variant.Key)
+		if stored == nil || stored.ContentType != "image/jpeg" || int64(len(stored.Data)) != variant.Size {
+			t.Fatalf("variant %s not stored correctly", variant.Key)
+		}
+	}
+
+	download, err := svc.GetURL(ctx, testMember, uploaded.MediaID, "thumbnail")
+	if err != nil || !strings.Contains(download.URL, "/thumbnail?") {
+		t.Fatalf("GetURL(thumbnail) = %+v, %v", download, err)
+	}
+	info, err := svc.GetMedia(ctx, testMember, uploaded.MediaID)
+	if err != nil {
+		t.Fatalf("GetMedia() error = %v", err)
+	}
+	if info.Status != models.ProcessingReady || len(info.Variants) != 2 || info.Variants[0].URL == "" || info.URL == "" {
+		t.Fatalf("unexpected media info %+v", info)
+	}
+	if _, err := svc.GetMedia(ctx, testOutsider, uploaded.MediaID); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("outsider GetMedia: expected ErrForbidden, got %v", err)
+	}
+
+	// Les variantes disparaissent avec le média.
+	if err := svc.Delete(ctx, testOwner, uploaded.MediaID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	for _, variant := range media.Variants {
+		if server.Get(testBucket, variant.Key) != nil {
+			t.Fatalf("variant %s should have been deleted", variant.Key)
+		}
+	}
+}
+
+func TestMediaServiceGenerateVariantsFailure(t *testing.T) {
+	svc, _ := newTestService(t)
+	svc.SetProcessingQueue(&queueRecorder{})
+	ctx := context.Background()
+
+	// Signature PNG valide, contenu indécodable.
+	uploaded, err := svc.Upload(ctx, UploadRequest{Filename: "faux.png", ContentType: "image/png", DataBase64: base64.StdEncoding.EncodeToString([]byte("\x89PNG\r\n\x1a\ntronqué")), OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	media, err := svc.GenerateVariants(ctx, uploaded.MediaID)
+	if err == nil || media == nil || media.Status != models.ProcessingFailed || len(media.Variants) != 0 {
+		t.Fatalf("expected failed processing, got %+v, %v", media, err)
+	}
+
+	// Vidéo : pas de variantes.
+	video, err := svc.Upload(ctx, UploadRequest{Filename: "film.mp4", ContentType: "video/mp4", DataBase64: base64.StdEncoding.EncodeToString(mp4Header), OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("Upload(video) error = %v", err)
+	}
+	if video.Status != "" || len(video.Variants) != 0 {
+		t.Fatalf("video should not have variants, got %+v", video)
+	}
+}
@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/storage"
+)
+
+const (
+	// ChunkSize : taille des parties d'un upload reprenable (5 MB minimum côté S3, sauf la dernière).
+	ChunkSize = 8 << 20 // 8 MB
+	// MaxResumableUploadSize : taille maximale d'une vidéo envoyée en upload reprenable.
+	MaxResumableUploadSize = 2 << 30 // 2 GB
+	// SessionTTL : durée de vie d'une session d'upload ; au-delà, elle est annulée par CleanupStaleSessions.
+	SessionTTL = 24 * time.Hour
+)
+
+var (
+	ErrSessionNotFound  = errors.New("session d'upload introuvable ou expirée")
+	ErrInvalidOffset    = fmt.Errorf("%w: offset invalide", ErrInvalidUpload)
+	ErrIncompleteUpload = fmt.Errorf("%w: upload incomplet", ErrInvalidUpload)
+)
+
+type SessionRequest struct {
+	Filename       string
+	ContentType    string
+	Size           int64
+	OwnerID        string
+	ConversationID int
+}
+
+// SessionResponse : état d'une session. Offset est le nombre d'octets reçus d'un seul tenant depuis
+// le début : le client reprend à partir de là.
+type SessionResponse struct {
+	UploadID  string `json:"uploadId"`
+	Offset    int64  `json:"offset"`
+	Size      int64  `json:"size"`
+	ChunkSize int64  `json:"chunkSize"`
+	ExpiresAt int64  `json:"expiresAt"`
+	MediaID   string `json:"mediaId,omitempty"`
+}
+
+// ChunkResponse : le client envoie la partie [Offset, Offset+Size) par Method sur UploadURL avec Headers.
+type ChunkResponse struct {
+	UploadID  string            `json:"uploadId"`
+	Offset    int64             `json:"offset"`
+	Size      int64             `json:"size"`
+	UploadURL string            `json:"uploadUrl"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt int64             `json:"expiresAt"`
+}
+
+// MaxResumableSizeFor : limite d'un upload reprenable ; images et notes vocales gardent leur limite habituelle.
+func MaxResumableSizeFor(category string) int64 {
+	if category == CategoryImage || category == CategoryVoice {
+		return MaxSizeFor(category)
+	}
+	return MaxResumableUploadSize
+}
+
+// CreateUploadSession démarre un upload reprenable : upload multipart S3 vers un objet en attente,
+// envoyé ensuite partie par partie (PresignChunk) directement au stockage.
+func (s *MediaService) CreateUploadSession(ctx context.Context, req SessionRequest) (SessionResponse, error) {
+	req.Filename = safeFilename(req.Filename)
+	if req.Filename == "" {
+		return SessionResponse{}, fmt.Errorf("filename is required")
+	}
+	if err := s.authorizeUpload(req.OwnerID, req.ConversationID); err != nil {
+		return SessionResponse{}, err
+	}
+	category, err := s.policy.Category(req.ContentType)
+	if err != nil {
+		return SessionResponse{}, err
+	}
+	if req.Size <= 0 {
+		return SessionResponse{}, fmt.Errorf("%w: taille invalide: %d", ErrInvalidUpload, req.Size)
+	}
+	if limit := MaxResumableSizeFor(category); req.Size > limit {
+		return SessionResponse{}, fmt.Errorf("%w: %d octets (max %d)", ErrFileTooLarge, req.Size, limit)
+	}
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return SessionResponse{}, fmt.Errorf("génération upload ID: %w", err)
+	}
+	id := hex.EncodeToString(token)
+	key := fmt.Sprintf("%s%d_%s_%s", pendingPrefix, time.Now().UnixNano(), id[:16], req.Filename)
+	contentType := canonicalType(normalizeContentType(req.ContentType))
+
+	storageUploadID, err := s.storage.CreateMultipartUpload(ctx, key, contentType)
+	if err != nil {
+		return SessionResponse{}, err
+	}
+	now := time.Now().UTC()
+	session, err := s.sessions.CreateSession(&models.UploadSession{
+		ID:              id,
+		OwnerID:         req.OwnerID,
+		ConversationID:  req.ConversationID,
+		Filename:        req.Filename,
+		ContentType:     contentType,
+		Size:            req.Size,
+		ChunkSize:       ChunkSize,
+		Key:             key,
+		StorageUploadID: storageUploadID,
+		CreatedAt:       now,
+		ExpiresAt:       now.Add(SessionTTL),
+	})
+	if err != nil {
+		s.abortStorageUpload(ctx, key, storageUploadID)
+		return SessionResponse{}, err
+	}
+	return sessionResponse(session, 0), nil
+}
+
+// GetUploadSession retourne la progression d'une session (parties reçues, lues sur le stockage).
+func (s *MediaService) GetUploadSession(ctx context.Context, ownerID, uploadID string) (SessionResponse, error) {
+	session, err := s.ownedSession(ownerID, uploadID)
+	if err != nil {
+		return SessionResponse{}, err
+	}
+	if session.MediaID != "" {
+		return sessionResponse(session, session.Size), nil
+	}
+	parts, err := s.storage.ListParts(ctx, session.Key, session.StorageUploadID)
+	if errors.Is(err, storage.ErrUploadNotFound) {
+		return SessionResponse{}, ErrSessionNotFound
+	}
+	if err != nil {
+		return SessionResponse{}, err
+	}
+	offset, _ := receivedParts(session, parts)
+	return sessionResponse(session, offset), nil
+}
+
+// PresignChunk signe l'envoi direct de la partie commençant à offset (multiple de ChunkSize) ;
+// une partie déjà reçue peut être renvoyée, elle remplace la précédente.
+func (s *MediaService) PresignChunk(ctx context.Context, ownerID, uploadID string, offset int64) (ChunkResponse, error) {
+	session, err := s.ownedSession(ownerID, uploadID)
+	if err != nil {
+		return ChunkResponse{}, err
+	}
+	if session.MediaID != "" {
+		return ChunkResponse{}, fmt.Errorf("%w: upload déjà finalisé", ErrInvalidUpload)
+	}
+	if offset < 0 || offset >= session.Size || offset%session.ChunkSize != 0 {
+		return ChunkResponse{}, fmt.Errorf("%w: %d (multiple de %d, inférieur à %d)", ErrInvalidOffset, offset, session.ChunkSize, session.Size)
+	}
+	size := min(session.ChunkSize, session.Size-offset)
+	partNumber := int32(offset/session.ChunkSize) + 1
+
+	upload, err := s.storage.PresignUploadPart(ctx, session.Key, session.StorageUploadID, partNumber, size, PresignTTL)
+	if err != nil {
+		return ChunkResponse{}, err
+	}
+	return ChunkResponse{
+		UploadID:  session.ID,
+		Offset:    offset,
+		Size:      size,
+		UploadURL: upload.URL,
+		Method:    upload.Method,
+		Headers:   upload.Headers,
+		ExpiresAt: upload.ExpiresAt.Unix(),
+	}, nil
+}
+
+// CompleteUploadSession assemble les parties, valide le fichier en continu (ValidateStream) puis
+// l'enregistre comme un upload direct (déduplication comprise). Idempotent : une session déjà finalisée
+// retourne son média.
+func (s *MediaService) CompleteUploadSession(ctx context.Context, ownerID, uploadID string) (UploadResponse, error) {
+	session, err := s.ownedSession(ownerID, uploadID)
+	if err != nil {
+		return UploadResponse{}, err
+	}
+	if session.MediaID != "" {
+		media, err := s.repo.GetMedia(session.MediaID)
+		if errors.Is(err, repo.ErrMediaNotFound) {
+			return UploadResponse{}, ErrMediaNotFound
+		}
+		if err != nil {
+			return UploadResponse{}, err
+		}
+		return s.uploadResponse(ctx, media)
+	}
+	if err := s.authorizeUpload(session.OwnerID, session.ConversationID); err != nil {
+		return UploadResponse{}, err
+	}
+
+	parts, err := s.storage.ListParts(ctx, session.Key, session.StorageUploadID)
+	switch {
+	case errors.Is(err, storage.ErrUploadNotFound):
+		// Déjà assemblé par un appel interrompu avant l'enregistrement ?
+		if _, headErr := s.storage.HeadFile(ctx, session.Key); headErr != nil {
+			return UploadResponse{}, ErrSessionNotFound
+		}
+	case err != nil:
+		return UploadResponse{}, err
+	default:
+		offset, complete := receivedParts(session, parts)
+		if !complete {
+			return UploadResponse{}, fmt.Errorf("%w: %d octets reçus sur %d", ErrIncompleteUpload, offset, session.Size)
+		}
+		if err := s.storage.CompleteMultipartUpload(ctx, session.Key, session.StorageUploadID, parts[:partCount(session)]); err != nil {
+			return UploadResponse{}, err
+		}
+	}
+
+	reader, err := s.storage.OpenFile(ctx, session.Key)
+	if err != nil {
+		return UploadResponse{}, err
+	}
+	file, err := s.policy.ValidateStream(reader, session.ContentType, session.Size)
+	reader.Close()
+	if errors.Is(err, ErrInvalidUpload) {
+		s.discardObject(ctx, session.Key)
+		if delErr := s.sessions.DeleteSession(session.ID); delErr != nil && !errors.Is(delErr, repo.ErrSessionNotFound) {
+			log.Printf("suppression session %s: %v", session.ID, delErr)
+		}
+		return UploadResponse{}, err
+	}
+	if err == nil && file.Category == CategoryImage {
+		// Image (MaxImageSize au plus) : relue en entier pour en retirer les métadonnées.
+		var data []byte
+		if data, err = s.storage.GetFile(ctx, session.Key, MaxImageSize); err == nil {
+			file, err = s.policy.ValidateUpload(data, session.ContentType, session.Size)
+		}
+	}
+	if err != nil {
+		return UploadResponse{}, err
+	}
+
+	mediaID := mediaPrefix + strings.TrimPrefix(session.Key, pendingPrefix)
+	media, err := s.publish(ctx, mediaID, session.Filename, file, session.OwnerID, session.ConversationID, func(objectKey string) error {
+		metadata := map[string]string{
+			"size":         strconv.FormatInt(file.Size, 10),
+			"upload-id":    session.ID,
+			metadataSHA256: file.SHA256,
+		}
+		return s.putValidated(ctx, session.Key, objectKey, file, metadata)
+	})
+	if errors.Is(err, repo.ErrMediaExists) {
+		media, err = s.repo.GetMedia(mediaID)
+	}
+	if err != nil {
+		return UploadResponse{}, err
+	}
+	if _, err := s.sessions.CompleteSession(session.ID, media.ID); err != nil {
+		log.Printf("finalisation session %s: %v", session.ID, err)
+	}
+	s.discardObject(ctx, session.Key)
+	s.enqueueProcessing(media)
+	return s.uploadResponse(ctx, media)
+}
+
+// AbortUploadSession annule l'upload : parties et objet en attente supprimés, session oubliée.
+func (s *MediaService) AbortUploadSession(ctx context.Context, ownerID, uploadID string) error {
+	session, err := s.ownedSession(ownerID, uploadID)
+	if err != nil {
+		return err
+	}
+	return s.dropSession(ctx, session)
+}
+
+// CleanupStaleSessions annule les sessions expirées à now (au plus limit) ; retourne leur nombre.
+func (s *MediaService) CleanupStaleSessions(ctx context.Context, now time.Time, limit int) (int, error) {
+	sessions, err := s.sessions.ListExpiredSessions(now, limit)
+	if err != nil {
+		return 0, err
+	}
+	cleaned := 0
+	for _, session := range sessions {
+		if err := s.dropSession(ctx, session); err != nil {
+			log.Printf("nettoyage session %s: %v", session.ID, err)
+			continue
+		}
+		cleaned++
+	}
+	return cleaned, nil
+}
+
+// dropSession : une session finalisée n'a plus rien à annuler, le média lui survit.
+func (s *MediaService) dropSession(ctx context.Context, session *models.UploadSession) error {
+	if session.MediaID == "" {
+		if err := s.storage.AbortMultipartUpload(ctx, session.Key, session.StorageUploadID); err != nil {
+			return err
+		}
+		s.discardObject(ctx, session.Key)
+	}
+	if err := s.sessions.DeleteSession(session.ID); err != nil && !errors.Is(err, repo.ErrSessionNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (s *MediaService) ownedSession(ownerID, uploadID string) (*models.UploadSession, error) {
+	if ownerID == "" {
+		return nil, ErrOwnerRequired
+	}
+	if uploadID == "" {
+		return nil, fmt.Errorf("uploadId is required")
+	}
+	session, err := s.sessions.GetSession(uploadID)
+	if errors.Is(err, repo.ErrSessionNotFound) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if session.OwnerID != ownerID {
+		return nil, ErrForbidden
+	}
+	return session, nil
+}
+
+func (s *MediaService) abortStorageUpload(ctx context.Context, key, storageUploadID string) {
+	if err := s.storage.AbortMultipartUpload(ctx, key, storageUploadID); err != nil {
+		log.Printf("annulation upload %s: %v", key, err)
+	}
+}
+
+func partCount(session *models.UploadSession) int {
+	return int((session.Size + session.ChunkSize - 1) / session.ChunkSize)
+}
+
+// receivedParts : octets reçus d'un seul tenant depuis le début (parties 1..n de la bonne taille),
+// et si le fichier est complet.
+func receivedParts(session *models.UploadSession, parts []storage.Part) (int64, bool) {
+	var offset int64
+	for i, part := range parts {
+		expected := min(session.ChunkSize, session.Size-offset)
+		if part.Number != int32(i+1) || part.Size != expected {
+			break
+		}
+		offset += part.Size
+		if offset == session.Size {
+			return offset, true
+		}
+	}
+	return offset, false
+}
+
+func sessionResponse(session *models.UploadSession, offset int64) SessionResponse {
+	return SessionResponse{
+		UploadID:  session.ID,
+		Offset:    offset,
+		Size:      session.Size,
+		ChunkSize: session.ChunkSize,
+		ExpiresAt: session.ExpiresAt.Unix(),
+		MediaID:   session.MediaID,
+	}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// putChunk joue le rôle du client : PUT direct de la partie sur l'URL signée.
+func putChunk(t *testing.T, chunk ChunkResponse, body []byte) {
+	t.Helper()
+	req, err := http.NewRequest(chunk.Method, chunk.UploadURL, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	for name, value := range chunk.Headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("chunk PUT error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("chunk PUT status = %d", resp.StatusCode)
+	}
+}
+
+// uploadChunk signe puis envoie la partie de video commençant à offset.
+func uploadChunk(t *testing.T, svc *MediaService, uploadID string, video []byte, offset int64) {
+	t.Helper()
+	chunk, err := svc.PresignChunk(context.Background(), testOwner, uploadID, offset)
+	if err != nil {
+		t.Fatalf("PresignChunk(%d) error = %v", offset, err)
+	}
+	if chunk.Offset != offset || chunk.Method != http.MethodPut {
+		t.Fatalf("unexpected chunk %+v", chunk)
+	}
+	putChunk(t, chunk, video[offset:offset+chunk.Size])
+}
+
+func testVideo(size int) []byte {
+	video := make([]byte, size)
+	copy(video, mp4Header)
+	for i := len(mp4Header); i < size; i++ {
+		video[i] = byte(i)
+	}
+	return video
+}
+
+func TestMediaServiceResumableUpload(t *testing.T) {
+	svc, server := newTestService(t)
+	ctx := context.Background()
+	video := testVideo(ChunkSize + 1000)
+
+	session, err := svc.CreateUploadSession(ctx, SessionRequest{Filename: "film.mp4", ContentType: "video/mp4", Size: int64(len(video)), OwnerID: testOwner, ConversationID: testConversation})
+	if err != nil {
+		t.Fatalf("CreateUploadSession() error = %v", err)
+	}
+	if session.UploadID == "" || session.Offset != 0 || session.ChunkSize != ChunkSize {
+		t.Fatalf("unexpected session %+v", session)
+	}
+	if _, err := svc.GetUploadSession(ctx, testOutsider, session.UploadID); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("foreign session: expected ErrForbidden, got %v", err)
+	}
+	for _, offset := range []int64{-1, 100, int64(len(video))} {
+		if _, err := svc.PresignChunk(ctx, testOwner, session.UploadID, offset); !errors.Is(err, ErrInvalidOffset) {
+			t.Fatalf("offset %d: expected ErrInvalidOffset, got %v", offset, err)
+		}
+	}
+
+	// Première partie reçue, connexion perdue : la progression permet de reprendre.
+	uploadChunk(t, svc, session.UploadID, video, 0)
+	status, err := svc.GetUploadSession(ctx, testOwner, session.UploadID)
+	if err != nil || status.Offset != ChunkSize {
+		t.Fatalf("GetUploadSession() = %+v, %v", status, err)
+	}
+	if _, err := svc.CompleteUploadSession(ctx, testOwner, session.UploadID); !errors.Is(err, ErrIncompleteUpload) {
+		t.Fatalf("incomplete upload: expected ErrIncompleteUpload, got %v", err)
+	}
+
+	uploadChunk(t, svc, session.UploadID, video, status.Offset)
+	done, err := svc.CompleteUploadSession(ctx, testOwner, session.UploadID)
+	if err != nil {
+		t.Fatalf("CompleteUploadSession() error = %v", err)
+	}
+	if !strings.HasPrefix(done.MediaID, "media/") || done.Key != "objects/"+sha256Hex(video) || done.Size != int64(len(video)) || done.ContentType != "video/mp4" {
+		t.Fatalf("unexpected completed media %+v", done)
+	}
+	stored := server.Get(testBucket, done.Key)
+	if stored == nil || !bytes.Equal(stored.Data, video) || stored.Metadata["sha256"] != sha256Hex(video) {
+		t.Fatal("assembled video not stored under its content key")
+	}
+	if keys := server.Keys(testBucket); len(keys) != 1 || server.Uploads() != 0 {
+		t.Fatalf("pending object and parts should be gone, got %v (%d uploads)", keys, server.Uploads())
+	}
+	if _, err := svc.GetURL(ctx, testMember, done.MediaID, ""); err != nil {
+		t.Fatalf("conversation member should access the video: %v", err)
+	}
+
+	// Idempotent : une seconde finalisation retourne le même média.
+	again, err := svc.CompleteUploadSession(ctx, testOwner, session.UploadID)
+	if err != nil || again.MediaID != done.MediaID {
+		t.Fatalf("second CompleteUploadSession() = %+v, %v", again, err)
+	}
+	if status, err := svc.GetUploadSession(ctx, testOwner, session.UploadID); err != nil || status.MediaID != done.MediaID || status.Offset != status.Size {
+		t.Fatalf("completed session status = %+v, %v", status, err)
+	}
+}
+
+func TestMediaServiceResumableUploadRejects(t *testing.T) {
+	svc, server := newTestService(t)
+	ctx := context.Background()
+
+	cases := []SessionRequest{
+		{Filename: "film.mp4", ContentType: "video/mp4", Size: MaxResumableUploadSize + 1, OwnerID: testOwner},
+		{Filename: "photo.png", ContentType: "image/png", Size: MaxImageSize + 1, OwnerID: testOwner},
+		{Filename: "setup.exe", ContentType: "application/x-msdownload", Size: 10, OwnerID: testOwner},
+		{Filename: "film.mp4", ContentType: "video/mp4", Size: 10},
+	}
+	for _, req := range cases {
+		if _, err := svc.CreateUploadSession(ctx, req); err == nil {
+			t.Fatalf("CreateUploadSession(%+v) should fail", req)
+		}
+	}
+
+	// Contenu qui n'est pas une vidéo : refusé à la finalisation, rien n'est publié.
+	fake := []byte(strings.Repeat("not a video ", 10))
+	session, err := svc.CreateUploadSession(ctx, SessionRequest{Filename: "film.mp4", ContentType: "video/mp4", Size: int64(len(fake)), OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("CreateUploadSession() error = %v", err)
+	}
+	uploadChunk(t, svc, session.UploadID, fake, 0)
+	if _, err := svc.CompleteUploadSession(ctx, testOwner, session.UploadID); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("disguised content: expected ErrUnsupportedType, got %v", err)
+	}
+	if keys := server.Keys(testBucket); len(keys) != 0 {
+		t.Fatalf("rejected upload must not be stored, got %v", keys)
+	}
+	if _, err := svc.GetUploadSession(ctx, testOwner, session.UploadID); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("rejected session should be dropped, got %v", err)
+	}
+}
+
+func TestMediaServiceAbortAndCleanupSessions(t *testing.T) {
+	svc, server := newTestService(t)
+	ctx := context.Background()
+	video := testVideo(ChunkSize + 10)
+
+	aborted, err := svc.CreateUploadSession(ctx, SessionRequest{Filename: "a.mp4", ContentType: "video/mp4", Size: int64(len(video)), OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("CreateUploadSession() error = %v", err)
+	}
+	uploadChunk(t, svc, aborted.UploadID, video, 0)
+	if err := svc.AbortUploadSession(ctx, testOutsider, aborted.UploadID); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("foreign abort: expected ErrForbidden, got %v", err)
+	}
+	if err := svc.AbortUploadSession(ctx, testOwner, aborted.UploadID); err != nil {
+		t.Fatalf("AbortUploadSession() error = %v", err)
+	}
+	if _, err := svc.PresignChunk(ctx, testOwner, aborted.UploadID, 0); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("aborted session: expected ErrSessionNotFound, got %v", err)
+	}
+
+	stale, err := svc.CreateUploadSession(ctx, SessionRequest{Filename: "b.mp4", ContentType: "video/mp4", Size: int64(len(video)), OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("CreateUploadSession() error = %v", err)
+	}
+	uploadChunk(t, svc, stale.UploadID, video, 0)
+
+	if cleaned, err := svc.CleanupStaleSessions(ctx, time.Now(), 10); err != nil || cleaned != 0 {
+		t.Fatalf("fresh session should be kept: %d, %v", cleaned, err)
+	}
+	if cleaned, err := svc.CleanupStaleSessions(ctx, time.Now().Add(SessionTTL+time.Minute), 10); err != nil || cleaned != 1 {
+		t.Fatalf("CleanupStaleSessions() = %d, %v", cleaned, err)
+	}
+	if server.Uploads() != 0 {
+		t.Fatalf("stale multipart uploads should be aborted, %d left", server.Uploads())
+	}
+	if _, err := svc.GetUploadSession(ctx, testOwner, stale.UploadID); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("stale session: expected ErrSessionNotFound, got %v", err)
+	}
+}
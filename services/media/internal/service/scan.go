@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/scanning"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/storage"
+)
+
+var (
+	ErrScanPending      = errors.New("média en cours d'analyse antivirus")
+	ErrMediaQuarantined = errors.New("média en quarantaine (fichier infecté ou analyse impossible)")
+	// ErrScanUnavailable : le moteur n'a pas pu analyser le fichier (injoignable, délai dépassé) ; le
+	// média reste « pending » et l'analyse doit être retentée.
+	ErrScanUnavailable = errors.New("analyse antivirus indisponible")
+)
+
+// SetScanner branche l'analyse antivirus : chaque nouveau média reste « pending », non téléchargeable,
+// jusqu'au verdict rendu par la file de traitement (SetProcessingQueue). Sans scanner, les médias sont
+// téléchargeables dès l'upload.
+func (s *MediaService) SetScanner(scanner scanning.Scanner) {
+	s.scanner = scanner
+}
+
+// SetOversizePolicy : sort d'un média au-delà de la limite du moteur (scanning.OversizeBlock : quarantaine,
+// scanning.OversizeAllow : téléchargeable sans analyse).
+func (s *MediaService) SetOversizePolicy(policy string) {
+	s.allowOversize = policy == scanning.OversizeAllow
+}
+
+// plannedScan : statut d'analyse d'un nouveau média ; le verdict d'une référence existante au même
+// contenu est repris, un échec d'analyse est retenté.
+func (s *MediaService) plannedScan(existing *models.Media) (string, string) {
+	if s.scanner == nil {
+		return "", ""
+	}
+	if existing != nil {
+		switch existing.ScanStatus {
+		case models.ScanClean, models.ScanInfected, models.ScanTooLarge:
+			return existing.ScanStatus, existing.ScanSignature
+		}
+	}
+	return models.ScanPending, ""
+}
+
+// ScanMedia analyse le contenu d'un média en attente et enregistre le verdict ; scanned vaut false si le
+// média n'attendait pas d'analyse ou si le moteur n'a pas répondu (ErrScanUnavailable : le média reste
+// « pending », à retenter). Un fichier au-delà de la limite du moteur passe à « too_large », un objet
+// introuvable à « failed ».
+func (s *MediaService) ScanMedia(ctx context.Context, mediaID string) (*models.Media, bool, error) {
+	media, err := s.repo.GetMedia(mediaID)
+	if errors.Is(err, repo.ErrMediaNotFound) {
+		return nil, false, ErrMediaNotFound
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if media.ScanStatus != models.ScanPending || s.scanner == nil {
+		return media, false, nil
+	}
+
+	status, signature := models.ScanClean, ""
+	verdict, scanErr := s.scanObject(ctx, media.ObjectKey)
+	switch {
+	case errors.Is(scanErr, scanning.ErrTooLarge):
+		status = models.ScanTooLarge
+		log.Printf("média %s trop volumineux pour l'analyse (%d octets)", media.ID, media.Size)
+	case errors.Is(scanErr, storage.ErrObjectNotFound):
+		status = models.ScanFailed
+	case scanErr != nil:
+		return media, false, fmt.Errorf("%w: %s: %v", ErrScanUnavailable, media.ID, scanErr)
+	case verdict.Infected:
+		status, signature = models.ScanInfected, verdict.Signature
+		log.Printf("média %s en quarantaine : %s", media.ID, signature)
+	}
+	updated, err := s.repo.UpdateScan(media.ID, status, signature)
+	if errors.Is(err, repo.ErrMediaNotFound) {
+		return nil, false, ErrMediaNotFound
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if status == models.ScanFailed {
+		return updated, true, fmt.Errorf("analyse %s: %w", media.ID, scanErr)
+	}
+	return updated, true, nil
+}
+
+func (s *MediaService) scanObject(ctx context.Context, key string) (scanning.Verdict, error) {
+	reader, err := s.storage.OpenFile(ctx, key)
+	if err != nil {
+		return scanning.Verdict{}, err
+	}
+	defer reader.Close()
+	return s.scanner.Scan(ctx, reader)
+}
+
+// checkScan : le média n'est téléchargeable que sain ou non analysé ; trop gros pour le moteur, selon
+// la politique MEDIA_SCAN_OVERSIZE.
+func (s *MediaService) checkScan(media *models.Media) error {
+	switch media.ScanStatus {
+	case models.ScanPending:
+		return ErrScanPending
+	case models.ScanInfected, models.ScanFailed:
+		return ErrMediaQuarantined
+	case models.ScanTooLarge:
+		if !s.allowOversize {
+			return ErrMediaQuarantined
+		}
+	}
+	return nil
+}
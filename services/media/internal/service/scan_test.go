@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/scanning"
+)
+
+// fakeScanner : contenus infectés indexés par SHA-256 ; err simule un moteur injoignable.
+type fakeScanner struct {
+	infected map[string]string
+	err      error
+	scans    int
+}
+
+func (f *fakeScanner) Scan(_ context.Context, r io.Reader) (scanning.Verdict, error) {
+	f.scans++
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return scanning.Verdict{}, err
+	}
+	if f.err != nil {
+		return scanning.Verdict{}, f.err
+	}
+	if signature, ok := f.infected[sha256Hex(data)]; ok {
+		return scanning.Verdict{Infected: true, Signature: signature}, nil
+	}
+	return scanning.Verdict{}, nil
+}
+
+func TestMediaServiceScanning(t *testing.T) {
+	svc, _ := newTestService(t)
+	queue := &queueRecorder{}
+	svc.SetProcessingQueue(queue)
+	virus := pngBytes(t, 6, 6)
+	scanner := &fakeScanner{infected: map[string]string{sha256Hex(virus): "Eicar-Test-Signature"}}
+	svc.SetScanner(scanner)
+	ctx := context.Background()
+	upload := func(filename string, data []byte) UploadResponse {
+		t.Helper()
+		resp, err := svc.Upload(ctx, UploadRequest{Filename: filename, DataBase64: base64.StdEncoding.EncodeToString(data), OwnerID: testOwner, ConversationID: testConversation})
+		if err != nil {
+			t.Fatalf("Upload(%s) error = %v", filename, err)
+		}
+		return resp
+	}
+
+	// En attente d'analyse : ni URL ni variantes, métadonnées lisibles.
+	clean := upload("photo.png", pngBytes(t, 4, 4))
+	if clean.ScanStatus != models.ScanPending || clean.URL != "" || len(queue.ids) != 1 {
+		t.Fatalf("unexpected pending upload %+v (queue %v)", clean, queue.ids)
+	}
+	if _, err := svc.GetURL(ctx, testMember, clean.MediaID, ""); !errors.Is(err, ErrScanPending) {
+		t.Fatalf("GetURL(pending): expected ErrScanPending, got %v", err)
+	}
+	if info, err := svc.GetMedia(ctx, testMember, clean.MediaID); err != nil || info.ScanStatus != models.ScanPending || info.URL != "" || info.ExpiresAt != 0 {
+		t.Fatalf("GetMedia(pending) = %+v, %v", info, err)
+	}
+	if media, err := svc.GenerateVariants(ctx, clean.MediaID); err != nil || media.Status != models.ProcessingPending {
+		t.Fatalf("variants must wait for the scan, got %+v, %v", media, err)
+	}
+
+	media, scanned, err := svc.ScanMedia(ctx, clean.MediaID)
+	if err != nil || !scanned || media.ScanStatus != models.ScanClean {
+		t.Fatalf("ScanMedia(clean) = %+v, %v, %v", media, scanned, err)
+	}
+	if _, scanned, err := svc.ScanMedia(ctx, clean.MediaID); err != nil || scanned {
+		t.Fatalf("second ScanMedia() must be a no-op, got %v, %v", scanned, err)
+	}
+	if _, err := svc.GenerateVariants(ctx, clean.MediaID); err != nil {
+		t.Fatalf("GenerateVariants() error = %v", err)
+	}
+	if _, err := svc.GetURL(ctx, testMember, clean.MediaID, "thumbnail"); err != nil {
+		t.Fatalf("GetURL(clean) error = %v", err)
+	}
+	// Même contenu : verdict repris sans nouvelle analyse.
+	if again := upload("copie.png", pngBytes(t, 4, 4)); again.ScanStatus != models.ScanClean || again.URL == "" || scanner.scans != 1 {
+		t.Fatalf("duplicate upload %+v after %d scans", again, scanner.scans)
+	}
+
+	// Infecté : quarantaine, pas de variantes, verdict repris pour les copies.
+	infected := upload("virus.png", virus)
+	media, _, err = svc.ScanMedia(ctx, infected.MediaID)
+	if err != nil || media.ScanStatus != models.ScanInfected || media.ScanSignature != "Eicar-Test-Signature" {
+		t.Fatalf("ScanMedia(infected) = %+v, %v", media, err)
+	}
+	if media, err := svc.GenerateVariants(ctx, infected.MediaID); !errors.Is(err, ErrMediaQuarantined) || media.Status != models.ProcessingFailed {
+		t.Fatalf("GenerateVariants(infected) = %+v, %v", media, err)
+	}
+	for _, requester := range []string{testOwner, testMember} {
+		if _, err := svc.GetURL(ctx, requester, infected.MediaID, ""); !errors.Is(err, ErrMediaQuarantined) {
+			t.Fatalf("GetURL(infected) by %s: expected ErrMediaQuarantined, got %v", requester, err)
+		}
+	}
+	if duplicate := upload("virus-copie.png", virus); duplicate.ScanStatus != models.ScanInfected || duplicate.URL != "" {
+		t.Fatalf("duplicate infected upload %+v", duplicate)
+	}
+
+	// Moteur injoignable : le média reste « pending », l'analyse est retentée.
+	scanner.err = errors.New("clamd: connection refused")
+	doc := []byte("compte rendu de réunion")
+	retry, err := svc.Upload(ctx, UploadRequest{Filename: "notes.txt", ContentType: "text/plain", DataBase64: base64.StdEncoding.EncodeToString(doc), OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("Upload(notes.txt) error = %v", err)
+	}
+	if media, scanned, err := svc.ScanMedia(ctx, retry.MediaID); !errors.Is(err, ErrScanUnavailable) || scanned || media.ScanStatus != models.ScanPending {
+		t.Fatalf("ScanMedia(unavailable) = %+v, %v, %v", media, scanned, err)
+	}
+	if _, err := svc.GetURL(ctx, testOwner, retry.MediaID, ""); !errors.Is(err, ErrScanPending) {
+		t.Fatalf("GetURL(unavailable): expected ErrScanPending, got %v", err)
+	}
+	scanner.err = nil
+	if media, scanned, err := svc.ScanMedia(ctx, retry.MediaID); err != nil || !scanned || media.ScanStatus != models.ScanClean {
+		t.Fatalf("ScanMedia(retry) = %+v, %v, %v", media, scanned, err)
+	}
+
+	// Au-delà de la limite du moteur : « too_large », bloqué sauf politique « allow ».
+	scanner.err = fmt.Errorf("clamd: %w (INSTREAM size limit exceeded. ERROR)", scanning.ErrTooLarge)
+	video, err := svc.Upload(ctx, UploadRequest{Filename: "film.txt", ContentType: "text/plain", DataBase64: base64.StdEncoding.EncodeToString([]byte("très long métrage")), OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("Upload(film.txt) error = %v", err)
+	}
+	if media, scanned, err := svc.ScanMedia(ctx, video.MediaID); err != nil || !scanned || media.ScanStatus != models.ScanTooLarge {
+		t.Fatalf("ScanMedia(too large) = %+v, %v, %v", media, scanned, err)
+	}
+	if _, err := svc.GetURL(ctx, testOwner, video.MediaID, ""); !errors.Is(err, ErrMediaQuarantined) {
+		t.Fatalf("GetURL(too large): expected ErrMediaQuarantined, got %v", err)
+	}
+	svc.SetOversizePolicy(scanning.OversizeAllow)
+	if _, err := svc.GetURL(ctx, testOwner, video.MediaID, ""); err != nil {
+		t.Fatalf("GetURL(too large, allowed) error = %v", err)
+	}
+	scanner.err = nil
+
+	// Redémarrage : la file en mémoire est perdue, les médias en attente d'analyse ou de variantes (ici la
+	// copie infectée, jamais traitée) sont replanifiés.
+	pending := upload("attente.png", pngBytes(t, 5, 5))
+	restarted := &queueRecorder{}
+	svc.SetProcessingQueue(restarted)
+	if resumed, err := svc.ResumeProcessing(); err != nil || resumed != 2 || !slices.Contains(restarted.ids, pending.MediaID) || slices.Contains(restarted.ids, clean.MediaID) {
+		t.Fatalf("ResumeProcessing() = %d, %v (queue %v)", resumed, err, restarted.ids)
+	}
+}
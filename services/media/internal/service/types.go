@@ -0,0 +1,182 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Catégories de médias (Media.Category). Une note vocale est un fichier audio envoyé comme tel (voice).
+const (
+	CategoryImage    = "image"
+	CategoryVideo    = "video"
+	CategoryAudio    = "audio"
+	CategoryVoice    = "voice"
+	CategoryDocument = "document"
+)
+
+// MaxVoiceSize : taille maximale d'une note vocale.
+const MaxVoiceSize = 10 << 20 // 10 MB
+
+// Types Office : formats binaires historiques (conteneur OLE) et Office Open XML (archive ZIP).
+const (
+	typeDoc  = "application/msword"
+	typeXls  = "application/vnd.ms-excel"
+	typePpt  = "application/vnd.ms-powerpoint"
+	typeDocx = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	typeXlsx = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	typePptx = "application/vnd.openxmlformats-officedocument.presentationml.presentation"
+)
+
+// defaultAllowedTypes : types autorisés par catégorie, remplaçables une catégorie à la fois
+// (MEDIA_ALLOWED_IMAGE, ..._VIDEO, ..._AUDIO, ..._VOICE, ..._DOCUMENT).
+var defaultAllowedTypes = map[string][]string{
+	CategoryImage:    {"image/jpeg", "image/png", "image/gif", "image/webp"},
+	CategoryVideo:    {"video/mp4", "video/webm", "video/avi"},
+	CategoryAudio:    {"audio/ogg", "audio/mpeg", "audio/mp4", "audio/webm"},
+	CategoryVoice:    {"audio/ogg", "audio/mp4", "audio/webm", "audio/mpeg"},
+	CategoryDocument: {"application/pdf", typeDoc, typeXls, typePpt, typeDocx, typeXlsx, typePptx, "text/plain", "text/csv", "text/markdown"},
+}
+
+// searchOrder : catégories d'un fichier ordinaire, dans l'ordre de recherche (voice n'en fait pas partie).
+var searchOrder = []string{CategoryImage, CategoryVideo, CategoryAudio, CategoryDocument}
+
+// typeAliases : variantes courantes de types déclarés par les clients, ramenées au type détecté.
+var typeAliases = map[string]string{
+	"audio/mp3":       "audio/mpeg",
+	"audio/x-mp3":     "audio/mpeg",
+	"audio/mpeg3":     "audio/mpeg",
+	"audio/x-m4a":     "audio/mp4",
+	"audio/m4a":       "audio/mp4",
+	"audio/opus":      "audio/ogg",
+	"application/ogg": "audio/ogg",
+	"video/x-msvideo": "video/avi",
+	"text/x-markdown": "text/markdown",
+}
+
+// TypePolicy : types MIME acceptés, par catégorie. Voice en restreint une copie aux notes vocales.
+type TypePolicy struct {
+	categories []allowedTypes
+	voice      allowedTypes
+}
+
+type allowedTypes struct {
+	category string
+	types    map[string]bool
+}
+
+// DefaultTypePolicy : listes par défaut (defaultAllowedTypes).
+func DefaultTypePolicy() *TypePolicy {
+	policy, _ := NewTypePolicy(nil)
+	return policy
+}
+
+// NewTypePolicy remplace les listes par défaut des catégories présentes dans lists ; une liste vide
+// désactive la catégorie. Les notes vocales n'acceptent que des types audio.
+func NewTypePolicy(lists map[string][]string) (*TypePolicy, error) {
+	for category := range lists {
+		if _, ok := defaultAllowedTypes[category]; !ok {
+			return nil, fmt.Errorf("catégorie de média inconnue: %s", category)
+		}
+	}
+	build := func(category string) (allowedTypes, error) {
+		list, ok := lists[category]
+		if !ok {
+			list = defaultAllowedTypes[category]
+		}
+		allowed := allowedTypes{category: category, types: make(map[string]bool, len(list))}
+		for _, raw := range list {
+			ct := canonicalType(normalizeContentType(raw))
+			if !strings.Contains(ct, "/") {
+				return allowedTypes{}, fmt.Errorf("type MIME invalide pour %s: %q", category, raw)
+			}
+			if category == CategoryVoice && !strings.HasPrefix(ct, "audio/") {
+				return allowedTypes{}, fmt.Errorf("note vocale: type audio attendu, pas %s", ct)
+			}
+			allowed.types[ct] = true
+		}
+		return allowed, nil
+	}
+
+	policy := &TypePolicy{}
+	for _, category := range searchOrder {
+		allowed, err := build(category)
+		if err != nil {
+			return nil, err
+		}
+		policy.categories = append(policy.categories, allowed)
+	}
+	voice, err := build(CategoryVoice)
+	if err != nil {
+		return nil, err
+	}
+	policy.voice = voice
+	return policy, nil
+}
+
+// TypePolicyFromEnv lit les listes MEDIA_ALLOWED_<CATÉGORIE> (types séparés par des virgules) ;
+// une variable absente garde la liste par défaut, une variable vide désactive la catégorie.
+func TypePolicyFromEnv() (*TypePolicy, error) {
+	lists := make(map[string][]string)
+	for category := range defaultAllowedTypes {
+		raw, ok := os.LookupEnv("MEDIA_ALLOWED_" + strings.ToUpper(category))
+		if !ok {
+			continue
+		}
+		list := []string{}
+		for _, ct := range strings.Split(raw, ",") {
+			if ct = strings.TrimSpace(ct); ct != "" {
+				list = append(list, ct)
+			}
+		}
+		lists[category] = list
+	}
+	return NewTypePolicy(lists)
+}
+
+// Voice : politique des notes vocales (catégorie voice seule).
+func (p *TypePolicy) Voice() *TypePolicy {
+	return &TypePolicy{categories: []allowedTypes{p.voice}, voice: p.voice}
+}
+
+// Category retourne la catégorie d'un type MIME autorisé, ErrUnsupportedType sinon.
+func (p *TypePolicy) Category(contentType string) (string, error) {
+	ct := canonicalType(normalizeContentType(contentType))
+	for _, allowed := range p.categories {
+		if allowed.types[ct] {
+			return allowed.category, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s (autorisés: %s)", ErrUnsupportedType, ct, strings.Join(p.allowed(), ", "))
+}
+
+func (p *TypePolicy) allowed() []string {
+	var types []string
+	for _, allowed := range p.categories {
+		for ct := range allowed.types {
+			types = append(types, ct)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// MaxSizeFor retourne la taille maximale d'un upload direct pour une catégorie.
+func MaxSizeFor(category string) int64 {
+	switch category {
+	case CategoryImage:
+		return MaxImageSize
+	case CategoryVoice:
+		return MaxVoiceSize
+	}
+	return MaxUploadSize
+}
+
+// canonicalType ramène un type déclaré à sa forme usuelle (typeAliases).
+func canonicalType(contentType string) string {
+	if alias, ok := typeAliases[contentType]; ok {
+		return alias
+	}
+	return contentType
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// oggPage : page Ogg (CRC non vérifié) de paquets complets, sur le flux 1.
+func oggPage(granule int64, packets ...[]byte) []byte {
+	var lacing, body []byte
+	for _, packet := range packets {
+		lacing = append(lacing, byte(len(packet)))
+		body = append(body, packet...)
+	}
+	page := []byte("OggS\x00\x00")
+	page = binary.LittleEndian.AppendUint64(page, uint64(granule))
+	page = binary.LittleEndian.AppendUint32(page, 1)
+	page = append(page, make([]byte, 8)...)
+	page = append(page, byte(len(lacing)))
+	return append(append(page, lacing...), body...)
+}
+
+// opusNote : note vocale Ogg Opus de 2 s (100 paquets de 20 ms), sans pre-skip.
+func opusNote() []byte {
+	head := append([]byte("OpusHead\x01\x01\x00\x00"), binary.LittleEndian.AppendUint32(nil, 48000)...)
+	data := append(oggPage(0, append(head, 0, 0, 0)), oggPage(0, []byte("OpusTags"))...)
+	for page := 0; page < 10; page++ {
+		packets := make([][]byte, 10)
+		for i := range packets {
+			packets[i] = make([]byte, 10+page*10)
+		}
+		data = append(data, oggPage(int64((page+1)*10*960), packets...)...)
+	}
+	return data
+}
+
+func zipBytes(t *testing.T, names ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, name := range names {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("zip Create() error = %v", err)
+		}
+		w.Write([]byte("<xml/>"))
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("zip Close() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestTypePolicyDetection(t *testing.T) {
+	policy := DefaultTypePolicy()
+	docx := zipBytes(t, "[Content_Types].xml", "_rels/.rels", "word/document.xml")
+	ole := append([]byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}, make([]byte, 64)...)
+	mp3 := append([]byte("ID3\x03\x00\x00\x00\x00\x00\x00"), 0xFF, 0xFB, 0x90, 0x00)
+	webm := []byte("\x1a\x45\xdf\xa3\x9f\x42\x86\x81\x01\x42\xf7\x81\x01\x42\xf2\x81\x04\x42\xf3\x81\x08\x42\x82\x84webm\x42\x87\x81\x02\x42\x85\x81\x02")
+
+	cases := []struct {
+		name     string
+		data     []byte
+		declared string
+		want     string
+		category string
+	}{
+		{"pdf", []byte("%PDF-1.7\n1 0 obj"), "", "application/pdf", CategoryDocument},
+		{"docx", docx, "application/octet-stream", typeDocx, CategoryDocument},
+		{"doc", ole, typeDoc, typeDoc, CategoryDocument},
+		{"texte", []byte("bonjour"), "text/plain; charset=utf-8", "text/plain", CategoryDocument},
+		{"csv", []byte("a,b\n1,2\n"), "text/csv", "text/csv", CategoryDocument},
+		{"opus", opusNote(), "audio/opus", "audio/ogg", CategoryAudio},
+		{"mp3", mp3, "audio/mp3", "audio/mpeg", CategoryAudio},
+		{"webm audio", webm, "audio/webm", "audio/webm", CategoryAudio},
+		{"webm vidéo", webm, "", "video/webm", CategoryVideo},
+	}
+	for _, tc := range cases {
+		file, err := policy.ValidateUpload(tc.data, tc.declared, 0)
+		if err != nil || file.ContentType != tc.want || file.Category != tc.category {
+			t.Fatalf("%s: ValidateUpload() = %+v, %v", tc.name, file, err)
+		}
+	}
+
+	rejected := []struct {
+		name     string
+		data     []byte
+		declared string
+		want     error
+	}{
+		{"texte non déclaré", []byte("bonjour"), "", ErrUnsupportedType},
+		{"archive zip", zipBytes(t, "notes.txt"), "application/zip", ErrUnsupportedType},
+		{"ole non déclaré", ole, "", ErrUnsupportedType},
+		{"pdf déclaré audio", []byte("%PDF-1.7\n"), "audio/mpeg", ErrContentTypeMismatch},
+	}
+	for _, tc := range rejected {
+		if _, err := policy.ValidateUpload(tc.data, tc.declared, 0); !errors.Is(err, tc.want) {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	// Notes vocales : audio uniquement, 10 MB au plus.
+	voice := policy.Voice()
+	if file, err := voice.ValidateUpload(opusNote(), "", 0); err != nil || file.Category != CategoryVoice {
+		t.Fatalf("voice ValidateUpload() = %+v, %v", file, err)
+	}
+	if _, err := voice.ValidateUpload([]byte("%PDF-1.7\n"), "", 0); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("voice note must be audio, got %v", err)
+	}
+	oversized := append(opusNote(), make([]byte, MaxVoiceSize)...)
+	if _, err := voice.ValidateUpload(oversized, "", 0); !errors.Is(err, ErrFileTooLarge) {
+		t.Fatalf("voice note above MaxVoiceSize: expected ErrFileTooLarge, got %v", err)
+	}
+}
+
+func TestTypePolicyFromEnv(t *testing.T) {
+	t.Setenv("MEDIA_ALLOWED_DOCUMENT", "application/pdf, text/plain")
+	t.Setenv("MEDIA_ALLOWED_AUDIO", "")
+	policy, err := TypePolicyFromEnv()
+	if err != nil {
+		t.Fatalf("TypePolicyFromEnv() error = %v", err)
+	}
+	for ct, want := range map[string]string{"application/pdf": CategoryDocument, "image/png": CategoryImage, "text/plain": CategoryDocument} {
+		if category, err := policy.Category(ct); err != nil || category != want {
+			t.Fatalf("Category(%s) = %q, %v", ct, category, err)
+		}
+	}
+	for _, ct := range []string{typeDocx, "audio/ogg"} {
+		if _, err := policy.Category(ct); !errors.Is(err, ErrUnsupportedType) {
+			t.Fatalf("Category(%s): expected ErrUnsupportedType, got %v", ct, err)
+		}
+	}
+	// Catégorie audio désactivée, notes vocales toujours acceptées.
+	if category, err := policy.Voice().Category("audio/ogg"); err != nil || category != CategoryVoice {
+		t.Fatalf("voice Category(audio/ogg) = %q, %v", category, err)
+	}
+
+	if _, err := NewTypePolicy(map[string][]string{CategoryVoice: {"application/pdf"}}); err == nil {
+		t.Fatal("voice notes must only accept audio types")
+	}
+	if _, err := NewTypePolicy(map[string][]string{"archive": {"application/zip"}}); err == nil {
+		t.Fatal("unknown category should be rejected")
+	}
+}
+
+func TestMediaServiceVoiceAndDocumentUploads(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	note, err := svc.Upload(ctx, UploadRequest{Filename: "note.ogg", DataBase64: base64.StdEncoding.EncodeToString(opusNote()), OwnerID: testOwner, Voice: true})
+	if err != nil {
+		t.Fatalf("Upload(voice) error = %v", err)
+	}
+	if note.Category != CategoryVoice || note.ContentType != "audio/ogg" || note.DurationMs != 2000 || len(note.Waveform) != 64 ||
+		note.Waveform[0] != 0 || note.Waveform[63] != 100 {
+		t.Fatalf("unexpected voice note %+v", note)
+	}
+	info, err := svc.GetMedia(ctx, testOwner, note.MediaID)
+	if err != nil || info.DurationMs != 2000 || len(info.Waveform) != 64 || info.Filename != "note.ogg" {
+		t.Fatalf("GetMedia(voice) = %+v, %v", info, err)
+	}
+
+	// Même contenu envoyé comme simple fichier audio : durée reprise, sans forme d'onde.
+	song, err := svc.Upload(ctx, UploadRequest{Filename: "chanson.ogg", DataBase64: base64.StdEncoding.EncodeToString(opusNote()), OwnerID: testOwner})
+	if err != nil || song.Category != CategoryAudio || song.DurationMs != 2000 || song.Waveform != nil || song.Key != note.Key {
+		t.Fatalf("Upload(audio) = %+v, %v", song, err)
+	}
+
+	if _, err := svc.Upload(ctx, UploadRequest{Filename: "photo.png", DataBase64: pngBase64(t, 4, 4), OwnerID: testOwner, Voice: true}); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("Upload(voice png): expected ErrUnsupportedType, got %v", err)
+	}
+
+	pdf := []byte("%PDF-1.7\n1 0 obj\n")
+	presign, err := svc.PresignUpload(ctx, PresignRequest{Filename: "rapport.pdf", ContentType: "application/pdf", Size: int64(len(pdf)), OwnerID: testOwner})
+	if err != nil {
+		t.Fatalf("PresignUpload(pdf) error = %v", err)
+	}
+	putPresigned(t, presign, "application/pdf", pdf)
+	doc, err := svc.CompleteUpload(ctx, testOwner, 0, presign.MediaID, false)
+	if err != nil || doc.Category != CategoryDocument || doc.Filename != "rapport.pdf" || doc.DurationMs != 0 {
+		t.Fatalf("CompleteUpload(pdf) = %+v, %v", doc, err)
+	}
+	if _, err := svc.PresignUpload(ctx, PresignRequest{Filename: "rapport.pdf", ContentType: "application/pdf", Size: 10, OwnerID: testOwner, Voice: true}); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("PresignUpload(voice pdf): expected ErrUnsupportedType, got %v", err)
+	}
+}
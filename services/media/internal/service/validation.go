@@ -0,0 +1,222 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/audio"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/imaging"
+)
+
+// MaxImageSize : taille maximale d'une image ; les autres catégories sont limitées par MaxSizeFor.
+const MaxImageSize = 10 << 20 // 10 MB
+
+// metadataSHA256 : métadonnée objet portant l'empreinte SHA-256 (hex) du contenu.
+const metadataSHA256 = "sha256"
+
+// ErrInvalidUpload regroupe les refus de validation (400 côté HTTP, « INVALID » côté NATS).
+var (
+	ErrInvalidUpload       = errors.New("fichier invalide")
+	ErrEmptyFile           = fmt.Errorf("%w: fichier vide", ErrInvalidUpload)
+	ErrUnsupportedType     = fmt.Errorf("%w: type de fichier non autorisé", ErrInvalidUpload)
+	ErrContentTypeMismatch = fmt.Errorf("%w: type déclaré différent du contenu", ErrInvalidUpload)
+	ErrSizeMismatch        = fmt.Errorf("%w: taille déclarée différente du contenu", ErrInvalidUpload)
+	ErrFileTooLarge        = fmt.Errorf("%w: fichier trop volumineux", ErrInvalidUpload)
+)
+
+// ValidatedFile : contenu d'un upload accepté, avec son type réel (détecté), sa catégorie et son empreinte.
+// Stripped : métadonnées retirées d'une image, Data diffère de l'objet reçu et doit être stocké tel quel.
+type ValidatedFile struct {
+	Data        []byte
+	ContentType string
+	Category    string
+	Size        int64
+	SHA256      string
+	Stripped    bool
+}
+
+// ReadUpload lit un upload (au plus MaxUploadSize octets) puis le valide avec ValidateUpload.
+func (p *TypePolicy) ReadUpload(reader io.Reader, declaredType string, declaredSize int64) (*ValidatedFile, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, MaxUploadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("lecture du fichier: %w", err)
+	}
+	if int64(len(data)) > MaxUploadSize {
+		return nil, fmt.Errorf("%w (max %d octets)", ErrFileTooLarge, MaxUploadSize)
+	}
+	return p.ValidateUpload(data, declaredType, declaredSize)
+}
+
+// ValidateUpload est le pipeline commun à tous les uploads (HTTP, NATS, upload direct) : type réel détecté
+// par les magic bytes, type et taille déclarés (optionnels) comparés au contenu, limite par catégorie,
+// métadonnées des images retirées (imaging.StripMetadata : EXIF, GPS), SHA-256 du contenu stocké.
+func (p *TypePolicy) ValidateUpload(data []byte, declaredType string, declaredSize int64) (*ValidatedFile, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyFile
+	}
+	detected, category, err := p.detectType(data[:min(len(data), headLen)], declaredType)
+	if err != nil {
+		return nil, err
+	}
+	size := int64(len(data))
+	if err := checkSize(detected, size, declaredSize, MaxSizeFor(category)); err != nil {
+		return nil, err
+	}
+	stripped := false
+	if category == CategoryImage {
+		if clean := imaging.StripMetadata(data); !bytes.Equal(clean, data) {
+			data, size, stripped = clean, int64(len(clean)), true
+		}
+	}
+	sum := sha256.Sum256(data)
+	return &ValidatedFile{
+		Data:        data,
+		ContentType: detected,
+		Category:    category,
+		Size:        size,
+		SHA256:      hex.EncodeToString(sum[:]),
+		Stripped:    stripped,
+	}, nil
+}
+
+// ValidateStream applique le même pipeline à un fichier lu en continu (uploads reprenables, trop gros
+// pour la mémoire), avec la limite MaxResumableSizeFor ; Data reste vide.
+func (p *TypePolicy) ValidateStream(reader io.Reader, declaredType string, declaredSize int64) (*ValidatedFile, error) {
+	head := make([]byte, headLen)
+	n, err := io.ReadFull(reader, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("lecture du fichier: %w", err)
+	}
+	if n == 0 {
+		return nil, ErrEmptyFile
+	}
+	detected, category, err := p.detectType(head[:n], declaredType)
+	if err != nil {
+		return nil, err
+	}
+	limit := MaxResumableSizeFor(category)
+	hash := sha256.New()
+	hash.Write(head[:n])
+	rest, err := io.Copy(hash, io.LimitReader(reader, max(limit+1-int64(n), 0)))
+	if err != nil {
+		return nil, fmt.Errorf("lecture du fichier: %w", err)
+	}
+	size := int64(n) + rest
+	if err := checkSize(detected, size, declaredSize, limit); err != nil {
+		return nil, err
+	}
+	return &ValidatedFile{ContentType: detected, Category: category, Size: size, SHA256: hex.EncodeToString(hash.Sum(nil))}, nil
+}
+
+// headLen : octets examinés pour détecter le type (http.DetectContentType n'en lit que 512 ; les
+// documents Office sont reconnus aux noms des entrées de l'archive, plus loin).
+const headLen = 64 << 10
+
+// detectType détecte le type réel sur les premiers octets, vérifie qu'il est autorisé et le compare au
+// type déclaré.
+func (p *TypePolicy) detectType(head []byte, declaredType string) (string, string, error) {
+	declared := canonicalType(normalizeContentType(declaredType))
+	detected := refineType(head, normalizeContentType(http.DetectContentType(head)), declared)
+	// Tout contenu sans octet binaire passe pour du texte : il doit être annoncé comme tel.
+	if strings.HasPrefix(detected, "text/") && !strings.HasPrefix(declared, "text/") {
+		return "", "", fmt.Errorf("%w: contenu texte non déclaré comme document texte", ErrUnsupportedType)
+	}
+	category, err := p.Category(detected)
+	if err != nil {
+		return "", "", err
+	}
+	// application/octet-stream : type inconnu du client (navigateurs), seul le contenu fait foi.
+	if declared != "" && declared != "application/octet-stream" && declared != detected {
+		return "", "", fmt.Errorf("%w: déclaré %s, détecté %s", ErrContentTypeMismatch, declared, detected)
+	}
+	return detected, category, nil
+}
+
+// cfbMagic : conteneur OLE des documents Office binaires (.doc, .xls, .ppt).
+var cfbMagic = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+// refineType précise le type renvoyé par http.DetectContentType : audio (Ogg, M4A, MP3 sans tag),
+// enregistrements audio seuls dans un conteneur vidéo, documents Office et variantes du texte brut.
+func refineType(head []byte, detected, declared string) string {
+	if audioType := audio.Detect(head); audioType != "" {
+		return audioType
+	}
+	switch detected {
+	case "video/mp4", "video/webm":
+		// MediaRecorder produit le même conteneur pour l'audio seul : le type déclaré tranche.
+		if declared == "audio/"+strings.TrimPrefix(detected, "video/") {
+			return declared
+		}
+	case "application/zip":
+		if officeType := ooxmlType(head); officeType != "" {
+			return officeType
+		}
+	case "application/octet-stream":
+		if bytes.HasPrefix(head, cfbMagic) && (declared == typeDoc || declared == typeXls || declared == typePpt) {
+			return declared
+		}
+	case "text/plain":
+		if declared == "text/csv" || declared == "text/markdown" {
+			return declared
+		}
+	}
+	return detected
+}
+
+// ooxmlType reconnaît un document Office Open XML aux noms des entrées (en-têtes locaux) de l'archive.
+func ooxmlType(head []byte) string {
+	signature := []byte("PK\x03\x04")
+	for pos := 0; ; pos++ {
+		next := bytes.Index(head[pos:], signature)
+		if next < 0 {
+			return ""
+		}
+		pos += next
+		if pos+30 > len(head) {
+			return ""
+		}
+		nameLen := int(binary.LittleEndian.Uint16(head[pos+26:]))
+		name := string(head[pos+30 : min(pos+30+nameLen, len(head))])
+		switch {
+		case strings.HasPrefix(name, "word/"):
+			return typeDocx
+		case strings.HasPrefix(name, "xl/"):
+			return typeXlsx
+		case strings.HasPrefix(name, "ppt/"):
+			return typePptx
+		}
+	}
+}
+
+func checkSize(detected string, size, declaredSize, limit int64) error {
+	if size > limit {
+		return fmt.Errorf("%w: %d octets (max %d pour %s)", ErrFileTooLarge, size, limit, detected)
+	}
+	if declaredSize > 0 && declaredSize != size {
+		return fmt.Errorf("%w: déclaré %d, reçu %d octets", ErrSizeMismatch, declaredSize, size)
+	}
+	return nil
+}
+
+// normalizeContentType : type MIME en minuscules, sans paramètres (« ; charset=... »).
+func normalizeContentType(contentType string) string {
+	ct := strings.ToLower(strings.TrimSpace(contentType))
+	if ct == "" {
+		return ""
+	}
+	if parsed, _, err := mime.ParseMediaType(ct); err == nil {
+		return parsed
+	}
+	if i := strings.IndexByte(ct, ';'); i >= 0 {
+		return strings.TrimSpace(ct[:i])
+	}
+	return ct
+}
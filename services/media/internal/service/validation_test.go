@@ -0,0 +1,101 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// mp4Header : boîte ftyp minimale, reconnue comme video/mp4.
+var mp4Header = []byte("\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom")
+
+func TestValidateUpload(t *testing.T) {
+	policy := DefaultTypePolicy()
+	png := pngBytes(t, 4, 4)
+
+	file, err := policy.ValidateUpload(png, "image/PNG; name=photo.png", int64(len(png)))
+	if err != nil {
+		t.Fatalf("ValidateUpload() error = %v", err)
+	}
+	if file.ContentType != "image/png" || file.Size != int64(len(png)) || file.SHA256 != sha256Hex(png) {
+		t.Fatalf("unexpected validated file %+v", file)
+	}
+	// Type inconnu du client, taille non déclarée : le contenu fait foi.
+	for _, declared := range []string{"", "application/octet-stream"} {
+		if file, err := policy.ValidateUpload(png, declared, 0); err != nil || file.ContentType != "image/png" {
+			t.Fatalf("ValidateUpload(%q) = %+v, %v", declared, file, err)
+		}
+	}
+	if file, err := policy.ValidateUpload(mp4Header, "video/mp4", 0); err != nil || file.ContentType != "video/mp4" {
+		t.Fatalf("ValidateUpload(mp4) = %+v, %v", file, err)
+	}
+
+	oversized := append(append([]byte{}, png...), make([]byte, MaxImageSize)...)
+	cases := []struct {
+		name     string
+		data     []byte
+		declared string
+		size     int64
+		want     error
+	}{
+		{"vide", nil, "image/png", 0, ErrEmptyFile},
+		{"texte", []byte("fake png content"), "image/png", 0, ErrUnsupportedType},
+		{"html déguisé", []byte("<html><script>alert(1)</script>"), "", 0, ErrUnsupportedType},
+		{"type déclaré différent", png, "image/jpeg", 0, ErrContentTypeMismatch},
+		{"taille déclarée différente", png, "image/png", int64(len(png)) + 1, ErrSizeMismatch},
+		{"image trop volumineuse", oversized, "image/png", 0, ErrFileTooLarge},
+	}
+	for _, tc := range cases {
+		_, err := policy.ValidateUpload(tc.data, tc.declared, tc.size)
+		if !errors.Is(err, tc.want) || !errors.Is(err, ErrInvalidUpload) {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+func TestReadUploadTooLarge(t *testing.T) {
+	policy := DefaultTypePolicy()
+	data := append(append([]byte{}, mp4Header...), make([]byte, MaxUploadSize)...)
+	if _, err := policy.ReadUpload(bytes.NewReader(data), "video/mp4", 0); !errors.Is(err, ErrFileTooLarge) {
+		t.Fatalf("expected ErrFileTooLarge, got %v", err)
+	}
+}
+
+// HTTP et NATS passent par le même pipeline : rien n'est stocké si le contenu est refusé.
+func TestMediaServiceUploadRejectsInvalidContent(t *testing.T) {
+	svc, server := newTestService(t)
+	ctx := context.Background()
+	fake := []byte("fake png content")
+
+	if _, err := svc.Upload(ctx, UploadRequest{Filename: "faux.png", DataBase64: base64.StdEncoding.EncodeToString(fake), OwnerID: testOwner}); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("Upload() without content type: expected ErrUnsupportedType, got %v", err)
+	}
+	if _, err := svc.UploadFromReader(ctx, UploadRequest{Filename: "faux.png", ContentType: "image/png", OwnerID: testOwner}, bytes.NewReader(fake)); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("UploadFromReader(): expected ErrUnsupportedType, got %v", err)
+	}
+	if _, err := svc.Upload(ctx, UploadRequest{Filename: "photo.jpg", ContentType: "image/jpeg", DataBase64: pngBase64(t, 4, 4), OwnerID: testOwner}); !errors.Is(err, ErrContentTypeMismatch) {
+		t.Fatalf("Upload() with wrong type: expected ErrContentTypeMismatch, got %v", err)
+	}
+	if _, err := svc.Upload(ctx, UploadRequest{Filename: "photo.png", DataBase64: "%%%", OwnerID: testOwner}); !errors.Is(err, ErrInvalidUpload) {
+		t.Fatalf("Upload() with invalid base64: expected ErrInvalidUpload, got %v", err)
+	}
+	if keys := server.Keys(testBucket); len(keys) != 0 {
+		t.Fatalf("rejected uploads must not be stored, got %v", keys)
+	}
+
+	png := pngBytes(t, 4, 4)
+	resp, err := svc.UploadFromReader(ctx, UploadRequest{Filename: "photo.png", Size: int64(len(png)), OwnerID: testOwner}, bytes.NewReader(png))
+	if err != nil {
+		t.Fatalf("UploadFromReader() error = %v", err)
+	}
+	stored := server.Get(testBucket, resp.Key)
+	if resp.ContentType != "image/png" || stored == nil || stored.ContentType != "image/png" || stored.Metadata["sha256"] != sha256Hex(png) {
+		t.Fatalf("unexpected stored object %+v for %+v", stored, resp)
+	}
+	if !strings.HasPrefix(resp.MediaID, "media/") || resp.Key != "objects/"+sha256Hex(png) {
+		t.Fatalf("unexpected media ID %q or key %q", resp.MediaID, resp.Key)
+	}
+}
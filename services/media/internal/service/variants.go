@@ -0,0 +1,246 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/imaging"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/media/internal/repo"
+)
+
+// variantPrefix : les variantes de l'objet objects/<sha256> sont stockées sous variants/<sha256>/<variante>
+// (variants/<nom>/<variante> pour un objet antérieur media/<nom>).
+const variantPrefix = "variants/"
+
+var ErrVariantNotFound = errors.New("variante introuvable ou pas encore générée")
+
+// ProcessingQueue planifie la génération des variantes d'un média (processing.Worker).
+type ProcessingQueue interface {
+	Enqueue(mediaID string)
+}
+
+// MediaInfo : métadonnées d'un média avec des URLs signées (DownloadTTL) pour l'original et ses variantes prêtes.
+type MediaInfo struct {
+	ID             string        `json:"mediaId"`
+	Key            string        `json:"key"`
+	OwnerID        string        `json:"ownerId"`
+	ConversationID int           `json:"conversationId,omitempty"`
+	ContentType    string        `json:"contentType"`
+	Category       string        `json:"category,omitempty"`
+	Filename       string        `json:"filename,omitempty"`
+	DurationMs     int64         `json:"durationMs,omitempty"`
+	Waveform       []int         `json:"waveform,omitempty"`
+	Size           int64         `json:"size"`
+	Checksum       string        `json:"checksum"`
+	CreatedAt      int64         `json:"createdAt"`
+	Status         string        `json:"status,omitempty"`
+	ScanStatus     string        `json:"scanStatus,omitempty"`
+	URL            string        `json:"url"`
+	ExpiresAt      int64         `json:"expiresAt"`
+	Variants       []VariantInfo `json:"variants,omitempty"`
+}
+
+type VariantInfo struct {
+	models.MediaVariant
+	URL string `json:"url,omitempty"`
+}
+
+// SetProcessingQueue branche la génération des variantes ; sans file, les images restent sans variantes.
+func (s *MediaService) SetProcessingQueue(queue ProcessingQueue) {
+	s.queue = queue
+}
+
+func variantKey(objectKey, name string) string {
+	return variantPrefix + objectKey[strings.IndexByte(objectKey, '/')+1:] + "/" + name
+}
+
+// plannedVariants : clés des variantes d'une image, connues dès l'upload ; aucune pour les autres types
+// ou si la génération n'est pas branchée.
+func (s *MediaService) plannedVariants(objectKey, contentType string) (string, []models.MediaVariant) {
+	if s.queue == nil || !imaging.Supported(contentType) {
+		return "", nil
+	}
+	variants := make([]models.MediaVariant, 0, len(imaging.Specs))
+	for _, spec := range imaging.Specs {
+		variants = append(variants, models.MediaVariant{Name: spec.Name, Key: variantKey(objectKey, spec.Name)})
+	}
+	return models.ProcessingPending, variants
+}
+
+func (s *MediaService) enqueueProcessing(media *models.Media) {
+	if s.queue != nil && (media.Status == models.ProcessingPending || media.ScanStatus == models.ScanPending) {
+		s.queue.Enqueue(media.ID)
+	}
+}
+
+// ResumeProcessing replanifie, au démarrage, les médias en attente d'analyse ou de variantes : la file
+// de traitement ne survit pas à un redémarrage. Retourne le nombre de médias replanifiés.
+func (s *MediaService) ResumeProcessing() (int, error) {
+	if s.queue == nil {
+		return 0, nil
+	}
+	resumed, afterID := 0, ""
+	for {
+		batch, err := s.repo.ListMedia(afterID, gcBatchSize)
+		if err != nil {
+			return resumed, err
+		}
+		if len(batch) == 0 {
+			return resumed, nil
+		}
+		afterID = batch[len(batch)-1].ID
+		for _, media := range batch {
+			if media.Status == models.ProcessingPending || media.ScanStatus == models.ScanPending {
+				s.enqueueProcessing(media)
+				resumed++
+			}
+		}
+	}
+}
+
+// GenerateVariants décode l'original, génère et stocke ses variantes (orientation EXIF appliquée,
+// métadonnées EXIF/GPS retirées) puis passe le média à « ready ». Sans effet si le média n'est pas
+// en attente de traitement ou d'analyse antivirus ; un média en quarantaine n'a pas de variantes. En cas
+// d'échec, le média passe à « failed » et est retourné avec l'erreur.
+func (s *MediaService) GenerateVariants(ctx context.Context, mediaID string) (*models.Media, error) {
+	media, err := s.repo.GetMedia(mediaID)
+	if errors.Is(err, repo.ErrMediaNotFound) {
+		return nil, ErrMediaNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if media.Status != models.ProcessingPending || media.ScanStatus == models.ScanPending {
+		return media, nil
+	}
+	if err := s.checkScan(media); err != nil {
+		return s.failProcessing(media.ID, err)
+	}
+	// Contenu partagé dont les variantes ont été générées entre-temps pour une autre référence.
+	if ready, err := s.repo.FindByObjectKey(media.ObjectKey); err == nil && ready.Status == models.ProcessingReady {
+		updated, err := s.repo.UpdateProcessing(media.ID, models.ProcessingReady, ready.Variants)
+		if errors.Is(err, repo.ErrMediaNotFound) {
+			return nil, ErrMediaNotFound
+		}
+		return updated, err
+	}
+
+	data, err := s.storage.GetFile(ctx, media.ObjectKey, MaxUploadSize)
+	if err != nil {
+		return s.failProcessing(media.ID, err)
+	}
+	outputs, err := imaging.Generate(data, imaging.Specs)
+	if err != nil {
+		return s.failProcessing(media.ID, err)
+	}
+
+	variants := make([]models.MediaVariant, 0, len(outputs))
+	for _, output := range outputs {
+		key := variantKey(media.ObjectKey, output.Name)
+		sum := sha256.Sum256(output.Data)
+		metadata := map[string]string{metadataSHA256: hex.EncodeToString(sum[:])}
+		if err := s.storage.UploadFileWithMetadata(ctx, key, bytes.NewReader(output.Data), output.ContentType, metadata); err != nil {
+			return s.failProcessing(media.ID, err)
+		}
+		variants = append(variants, models.MediaVariant{
+			Name:        output.Name,
+			Key:         key,
+			ContentType: output.ContentType,
+			Width:       output.Width,
+			Height:      output.Height,
+			Size:        int64(len(output.Data)),
+		})
+	}
+
+	updated, err := s.repo.UpdateProcessing(media.ID, models.ProcessingReady, variants)
+	if errors.Is(err, repo.ErrMediaNotFound) {
+		// Supprimé pendant le traitement : les variantes ne doivent pas survivre au contenu.
+		s.discardUnreferenced(ctx, media.ObjectKey)
+		return nil, ErrMediaNotFound
+	}
+	return updated, err
+}
+
+func (s *MediaService) failProcessing(mediaID string, cause error) (*models.Media, error) {
+	media, err := s.repo.UpdateProcessing(mediaID, models.ProcessingFailed, nil)
+	if err != nil {
+		return nil, errors.Join(cause, err)
+	}
+	return media, fmt.Errorf("variantes %s: %w", mediaID, cause)
+}
+
+// GetMedia retourne les métadonnées d'un média et des URLs signées, si requesterID y a accès.
+func (s *MediaService) GetMedia(ctx context.Context, requesterID, mediaID string) (MediaInfo, error) {
+	media, err := s.authorizedMedia(requesterID, mediaID)
+	if err != nil {
+		return MediaInfo{}, err
+	}
+	// Métadonnées toujours lisibles ; URLs seulement pour un média sain ou non analysé.
+	downloadable := s.checkScan(media) == nil
+	var url string
+	var expiresAt time.Time
+	if downloadable {
+		if url, expiresAt, err = s.storage.PresignGet(ctx, media.ObjectKey, DownloadTTL); err != nil {
+			return MediaInfo{}, err
+		}
+	}
+
+	info := MediaInfo{
+		ID:             media.ID,
+		Key:            media.ObjectKey,
+		OwnerID:        media.OwnerID,
+		ConversationID: media.ConversationID,
+		ContentType:    media.ContentType,
+		Category:       media.Category,
+		Filename:       media.Filename,
+		DurationMs:     media.DurationMs,
+		Waveform:       media.Waveform,
+		Size:           media.Size,
+		Checksum:       media.Checksum,
+		CreatedAt:      media.CreatedAt.Unix(),
+		Status:         media.Status,
+		ScanStatus:     media.ScanStatus,
+		URL:            url,
+	}
+	if downloadable {
+		info.ExpiresAt = expiresAt.Unix()
+	}
+	for _, variant := range media.Variants {
+		variantInfo := VariantInfo{MediaVariant: variant}
+		if downloadable && media.Status == models.ProcessingReady {
+			variantInfo.URL, _, err = s.storage.PresignGet(ctx, variant.Key, DownloadTTL)
+			if err != nil {
+				return MediaInfo{}, err
+			}
+		}
+		info.Variants = append(info.Variants, variantInfo)
+	}
+	return info, nil
+}
+
+// variantObjectKey : clé de la variante name d'un média, si elle a été générée.
+func variantObjectKey(media *models.Media, name string) (string, error) {
+	if media.Status != models.ProcessingReady {
+		return "", ErrVariantNotFound
+	}
+	for _, variant := range media.Variants {
+		if variant.Name == name {
+			return variant.Key, nil
+		}
+	}
+	return "", ErrVariantNotFound
+}
+
+// discardVariants supprime les variantes possibles d'un objet (best effort, clés absentes ignorées par S3).
+func (s *MediaService) discardVariants(ctx context.Context, objectKey string) {
+	for _, spec := range imaging.Specs {
+		s.discardObject(ctx, variantKey(objectKey, spec.Name))
+	}
+}
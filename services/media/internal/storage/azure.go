@@ -0,0 +1,517 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// azureAPIVersion : version de l'API REST Blob (Put Blob From URL, SAS avec signedEncryptionScope).
+const azureAPIVersion = "2021-08-06"
+
+// azureCopyTTL : validité de l'URL SAS de lecture passée comme source à Put Blob From URL.
+const azureCopyTTL = 15 * time.Minute
+
+// AzureConfig : compte de stockage et clé d'accès (base64, « Access keys » du portail ou sortie Terraform
+// storage_account_primary_access_key). Endpoint (optionnel) : https://<compte>.blob.core.windows.net
+// par défaut, ou l'adresse d'Azurite (http://localhost:10000/devstoreaccount1).
+type AzureConfig struct {
+	Endpoint  string
+	Account   string
+	Key       string
+	Container string
+}
+
+// AzureBlobStore implémente ObjectStore sur Azure Blob Storage via l'API REST (authentification Shared Key,
+// URLs présignées en SAS de service). Les uploads multipart correspondent aux blocs d'un block blob :
+// chaque partie est un bloc (Put Block) et l'assemblage un Put Block List.
+type AzureBlobStore struct {
+	client    *http.Client
+	endpoint  string
+	account   string
+	key       []byte
+	container string
+}
+
+// NewAzureBlobStore lit AZURE_STORAGE_ACCOUNT, AZURE_STORAGE_KEY, AZURE_BLOB_ENDPOINT (optionnel) et
+// AZURE_BLOB_CONTAINER (défaut container).
+func NewAzureBlobStore(container string) (*AzureBlobStore, error) {
+	account := os.Getenv("AZURE_STORAGE_ACCOUNT")
+	key := os.Getenv("AZURE_STORAGE_KEY")
+	if account == "" || key == "" {
+		return nil, fmt.Errorf("AZURE_STORAGE_ACCOUNT ou AZURE_STORAGE_KEY manquant")
+	}
+	if name := os.Getenv("AZURE_BLOB_CONTAINER"); name != "" {
+		container = name
+	}
+	return NewAzureBlobStoreWithConfig(AzureConfig{
+		Endpoint:  os.Getenv("AZURE_BLOB_ENDPOINT"),
+		Account:   account,
+		Key:       key,
+		Container: container,
+	})
+}
+
+// NewAzureBlobStoreWithConfig initialise le client sans lire l'environnement (tests, outils).
+func NewAzureBlobStoreWithConfig(cfg AzureConfig) (*AzureBlobStore, error) {
+	if cfg.Account == "" {
+		return nil, fmt.Errorf("compte de stockage manquant")
+	}
+	if cfg.Container == "" {
+		return nil, fmt.Errorf("container manquant")
+	}
+	key, err := base64.StdEncoding.DecodeString(cfg.Key)
+	if err != nil || len(key) == 0 {
+		return nil, fmt.Errorf("clé de stockage invalide (base64 attendu)")
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = "https://" + cfg.Account + ".blob.core.windows.net"
+	}
+	return &AzureBlobStore{
+		client:    &http.Client{},
+		endpoint:  resolveEndpoint(endpoint),
+		account:   cfg.Account,
+		key:       key,
+		container: cfg.Container,
+	}, nil
+}
+
+// UploadFileWithMetadata envoie un blob (Put Blob) ; les métadonnées deviennent des x-ms-meta-*.
+func (s *AzureBlobStore) UploadFileWithMetadata(ctx context.Context, key string, body io.Reader, contentType string, metadata map[string]string) error {
+	// Put Blob exige Content-Length : un corps de taille inconnue est d'abord lu en mémoire.
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("erreur upload Azure: %w", err)
+	}
+	header := http.Header{}
+	header.Set("x-ms-blob-type", "BlockBlob")
+	header.Set("Content-Type", contentType)
+	setAzureMetadata(header, metadata)
+	resp, err := s.do(ctx, http.MethodPut, key, nil, header, data)
+	if err != nil {
+		return fmt.Errorf("erreur upload Azure: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// GetFile lit un blob entier, dans la limite de maxBytes ; ErrObjectNotFound s'il n'existe pas.
+func (s *AzureBlobStore) GetFile(ctx context.Context, key string, maxBytes int64) ([]byte, error) {
+	body, err := s.OpenFile(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("erreur lecture Azure: %w", err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("objet %s trop volumineux (max %d octets)", key, maxBytes)
+	}
+	return data, nil
+}
+
+// OpenFile ouvre un blob en lecture continue ; ErrObjectNotFound s'il n'existe pas.
+func (s *AzureBlobStore) OpenFile(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil, nil)
+	if err != nil {
+		if isAzureNotFound(err) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("erreur lecture Azure: %w", err)
+	}
+	return resp.Body, nil
+}
+
+// HeadFile retourne les propriétés d'un blob ; ErrObjectNotFound s'il n'existe pas.
+func (s *AzureBlobStore) HeadFile(ctx context.Context, key string) (*ObjectInfo, error) {
+	resp, err := s.do(ctx, http.MethodHead, key, nil, nil, nil)
+	if err != nil {
+		if isAzureNotFound(err) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("erreur head Azure: %w", err)
+	}
+	resp.Body.Close()
+	size, _ := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+	lastModified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	return &ObjectInfo{
+		Size:         size,
+		ContentType:  resp.Header.Get("Content-Type"),
+		ETag:         strings.Trim(resp.Header.Get("ETag"), `"`),
+		LastModified: lastModified,
+	}, nil
+}
+
+// CopyFile copie srcKey vers dstKey de façon synchrone (Put Blob From URL, source lue via une URL SAS)
+// en remplaçant Content-Type et métadonnées.
+func (s *AzureBlobStore) CopyFile(ctx context.Context, srcKey, dstKey, contentType string, metadata map[string]string) error {
+	source, _ := s.sasURL(srcKey, "r", time.Now().Add(azureCopyTTL), nil)
+	header := http.Header{}
+	header.Set("x-ms-blob-type", "BlockBlob")
+	header.Set("x-ms-copy-source", source)
+	header.Set("x-ms-blob-content-type", contentType)
+	setAzureMetadata(header, metadata)
+	resp, err := s.do(ctx, http.MethodPut, dstKey, nil, header, nil)
+	if err != nil {
+		if isAzureNotFound(err) {
+			return ErrObjectNotFound
+		}
+		return fmt.Errorf("erreur copie Azure: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// DeleteFile supprime un blob ; sans erreur s'il n'existe pas (comme S3).
+func (s *AzureBlobStore) DeleteFile(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil, nil)
+	if err != nil {
+		if isAzureNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("erreur delete Azure: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// ListFiles retourne les noms de blobs commençant par prefix, dans l'ordre lexicographique.
+func (s *AzureBlobStore) ListFiles(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	query := url.Values{"restype": {"container"}, "comp": {"list"}}
+	if prefix != "" {
+		query.Set("prefix", prefix)
+	}
+	for {
+		resp, err := s.do(ctx, http.MethodGet, "", query, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("erreur liste Azure: %w", err)
+		}
+		var page struct {
+			Blobs []string `xml:"Blobs>Blob>Name"`
+			Next  string   `xml:"NextMarker"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("erreur liste Azure: %w", err)
+		}
+		keys = append(keys, page.Blobs...)
+		if page.Next == "" {
+			break
+		}
+		query.Set("marker", page.Next)
+	}
+	return keys, nil
+}
+
+// PresignPut signe (SAS) l'envoi direct d'un blob, valable ttl. Le client doit renvoyer les en-têtes
+// retournés (x-ms-blob-type, Content-Type) ; contrairement à S3 la taille n'est pas signée, elle est
+// revérifiée à la finalisation de l'upload.
+func (s *AzureBlobStore) PresignPut(ctx context.Context, key, contentType string, size int64, ttl time.Duration) (*PresignedUpload, error) {
+	signedURL, expiresAt := s.sasURL(key, "cw", time.Now().Add(ttl), nil)
+	return &PresignedUpload{
+		URL:    signedURL,
+		Method: http.MethodPut,
+		Headers: map[string]string{
+			"x-ms-blob-type": "BlockBlob",
+			"Content-Type":   contentType,
+		},
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// PresignGet signe (SAS, lecture seule) un GET de key valable ttl.
+func (s *AzureBlobStore) PresignGet(ctx context.Context, key string, ttl time.Duration) (string, time.Time, error) {
+	signedURL, expiresAt := s.sasURL(key, "r", time.Now().Add(ttl), nil)
+	return signedURL, expiresAt, nil
+}
+
+// CreateMultipartUpload retourne un identifiant d'upload : Azure n'a pas d'upload à créer, les blocs
+// non validés sont rattachés au blob et préfixés par cet identifiant. Le type est fixé à la copie finale.
+func (s *AzureBlobStore) CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error) {
+	token := make([]byte, 8)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("génération de l'identifiant: %w", err)
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// PresignUploadPart signe (SAS) le Put Block de la partie partNumber.
+func (s *AzureBlobStore) PresignUploadPart(ctx context.Context, key, uploadID string, partNumber int32, size int64, ttl time.Duration) (*PresignedUpload, error) {
+	extra := url.Values{"comp": {"block"}, "blockid": {azureBlockID(uploadID, partNumber)}}
+	signedURL, expiresAt := s.sasURL(key, "w", time.Now().Add(ttl), extra)
+	return &PresignedUpload{URL: signedURL, Method: http.MethodPut, Headers: map[string]string{}, ExpiresAt: expiresAt}, nil
+}
+
+// azureBlockList : réponse de Get Block List.
+type azureBlockList struct {
+	Committed   []azureBlock `xml:"CommittedBlocks>Block"`
+	Uncommitted []azureBlock `xml:"UncommittedBlocks>Block"`
+}
+
+type azureBlock struct {
+	Name string `xml:"Name"`
+	Size int64  `xml:"Size"`
+}
+
+// ListParts retourne les blocs non validés de l'upload, triés par numéro (ETag = identifiant du bloc).
+// ErrUploadNotFound si le blob est déjà assemblé et qu'aucun bloc de l'upload n'est en attente.
+func (s *AzureBlobStore) ListParts(ctx context.Context, key, uploadID string) ([]Part, error) {
+	query := url.Values{"comp": {"blocklist"}, "blocklisttype": {"all"}}
+	resp, err := s.do(ctx, http.MethodGet, key, query, nil, nil)
+	if err != nil {
+		if isAzureNotFound(err) {
+			// Ni blob ni bloc : aucune partie reçue pour l'instant.
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erreur liste des parties Azure: %w", err)
+	}
+	var list azureBlockList
+	err = xml.NewDecoder(resp.Body).Decode(&list)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("erreur liste des parties Azure: %w", err)
+	}
+
+	var parts []Part
+	for _, block := range list.Uncommitted {
+		if number, ok := azurePartNumber(uploadID, block.Name); ok {
+			parts = append(parts, Part{Number: number, ETag: block.Name, Size: block.Size})
+		}
+	}
+	if len(parts) == 0 && len(list.Committed) > 0 {
+		return nil, ErrUploadNotFound
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].Number < parts[j].Number })
+	return parts, nil
+}
+
+// CompleteMultipartUpload valide les blocs dans l'ordre des parties (Put Block List).
+func (s *AzureBlobStore) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []Part) error {
+	var body bytes.Buffer
+	body.WriteString(`<?xml version="1.0" encoding="utf-8"?><BlockList>`)
+	for _, part := range parts {
+		body.WriteString("<Latest>")
+		_ = xml.EscapeText(&body, []byte(azureBlockID(uploadID, part.Number)))
+		body.WriteString("</Latest>")
+	}
+	body.WriteString("</BlockList>")
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/xml")
+	resp, err := s.do(ctx, http.MethodPut, key, url.Values{"comp": {"blocklist"}}, header, body.Bytes())
+	if err != nil {
+		return fmt.Errorf("erreur assemblage upload multipart Azure: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// AbortMultipartUpload : Azure ne permet pas de supprimer des blocs non validés, ils sont purgés
+// automatiquement après 7 jours ; l'objet éventuellement assemblé est supprimé par l'appelant.
+func (s *AzureBlobStore) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	return nil
+}
+
+// azureBlockID : identifiant (base64, longueur fixe pour un upload donné) du bloc d'une partie.
+func azureBlockID(uploadID string, partNumber int32) string {
+	return base64.StdEncoding.EncodeToString(fmt.Appendf(nil, "%s-%05d", uploadID, partNumber))
+}
+
+func azurePartNumber(uploadID, blockID string) (int32, bool) {
+	decoded, err := base64.StdEncoding.DecodeString(blockID)
+	if err != nil {
+		return 0, false
+	}
+	suffix, ok := strings.CutPrefix(string(decoded), uploadID+"-")
+	if !ok {
+		return 0, false
+	}
+	number, err := strconv.ParseInt(suffix, 10, 32)
+	if err != nil || number < 1 {
+		return 0, false
+	}
+	return int32(number), true
+}
+
+// azureError : réponse d'erreur de l'API Blob.
+type azureError struct {
+	Status  int
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func (e *azureError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("statut %d", e.Status)
+	}
+	return fmt.Sprintf("statut %d: %s", e.Status, e.Code)
+}
+
+func isAzureNotFound(err error) bool {
+	var azErr *azureError
+	return errors.As(err, &azErr) && azErr.Status == http.StatusNotFound
+}
+
+// do exécute une requête signée (Shared Key) sur key (le container si key est vide) ; une réponse
+// hors 2xx devient une *azureError.
+func (s *AzureBlobStore) do(ctx context.Context, method, key string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	target := s.blobURL(key)
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[http.CanonicalHeaderKey(name)] = values
+	}
+	req.ContentLength = int64(len(body))
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureAPIVersion)
+	req.Header.Set("Authorization", "SharedKey "+s.account+":"+s.sign(s.stringToSign(req)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		azErr := &azureError{Status: resp.StatusCode}
+		if data, readErr := io.ReadAll(io.LimitReader(resp.Body, 64<<10)); readErr == nil && len(data) > 0 {
+			_ = xml.Unmarshal(data, azErr)
+		}
+		if azErr.Code == "" {
+			azErr.Code = resp.Header.Get("x-ms-error-code")
+		}
+		return nil, azErr
+	}
+	return resp, nil
+}
+
+// stringToSign : format Shared Key (versions 2009-09-19 et suivantes).
+func (s *AzureBlobStore) stringToSign(req *http.Request) string {
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+	fields := []string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"", // Date : x-ms-date est utilisé à la place
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+	}
+	return strings.Join(fields, "\n") + "\n" + canonicalizedHeaders(req.Header) + s.canonicalizedResource(req.URL)
+}
+
+func canonicalizedHeaders(header http.Header) string {
+	var names []string
+	for name := range header {
+		if lower := strings.ToLower(name); strings.HasPrefix(lower, "x-ms-") {
+			names = append(names, lower)
+		}
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name + ":" + strings.TrimSpace(header.Get(name)) + "\n")
+	}
+	return b.String()
+}
+
+func (s *AzureBlobStore) canonicalizedResource(u *url.URL) string {
+	resource := "/" + s.account + u.EscapedPath()
+	query := u.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+		resource += "\n" + strings.ToLower(name) + ":" + strings.Join(values, ",")
+	}
+	return resource
+}
+
+// sasURL signe une SAS de service sur le blob key (permissions : r, w, cw...) ; extra est ajouté à la
+// requête sans faire partie de la signature (comp, blockid).
+func (s *AzureBlobStore) sasURL(key, permissions string, expiresAt time.Time, extra url.Values) (string, time.Time) {
+	expiry := expiresAt.UTC().Format("2006-01-02T15:04:05Z")
+	fields := []string{
+		permissions,
+		"", // signedStart
+		expiry,
+		"/blob/" + s.account + "/" + s.container + "/" + key,
+		"", // signedIdentifier
+		"", // signedIP
+		"", // signedProtocol
+		azureAPIVersion,
+		"b",                // signedResource : blob
+		"",                 // signedSnapshotTime
+		"",                 // signedEncryptionScope
+		"", "", "", "", "", // rscc, rscd, rsce, rscl, rsct
+	}
+	query := url.Values{}
+	for name, values := range extra {
+		query[name] = values
+	}
+	query.Set("sv", azureAPIVersion)
+	query.Set("sr", "b")
+	query.Set("sp", permissions)
+	query.Set("se", expiry)
+	query.Set("sig", s.sign(strings.Join(fields, "\n")))
+	return s.blobURL(key) + "?" + query.Encode(), expiresAt
+}
+
+func (s *AzureBlobStore) sign(stringToSign string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *AzureBlobStore) blobURL(key string) string {
+	base := s.endpoint + "/" + s.container
+	if key == "" {
+		return base
+	}
+	return base + "/" + (&url.URL{Path: key}).EscapedPath()
+}
+
+// setAzureMetadata : les noms de métadonnées Azure doivent être des identifiants C# (« upload-id »
+// devient « upload_id »).
+func setAzureMetadata(header http.Header, metadata map[string]string) {
+	for name, value := range metadata {
+		header.Set("x-ms-meta-"+strings.ReplaceAll(name, "-", "_"), value)
+	}
+}
@@ -0,0 +1,333 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testAzureAccount = "devstoreaccount1"
+	testAzureKey     = "a2V5LWRlLXRlc3Q=" // base64("key-de-test")
+)
+
+// fakeAzure : sous-ensemble de l'API Blob (block blobs) suffisant pour AzureBlobStore ; vérifie la
+// présence d'une authentification Shared Key ou SAS.
+type fakeAzure struct {
+	mu        sync.Mutex
+	blobs     map[string]*fakeBlob
+	blocks    map[string]map[string][]byte // blocs non validés par blob
+	requests  []*http.Request
+	container string
+}
+
+type fakeBlob struct {
+	data        []byte
+	contentType string
+	metadata    map[string]string
+}
+
+func newTestAzureStore(t *testing.T) (*AzureBlobStore, *fakeAzure) {
+	t.Helper()
+	fake := &fakeAzure{blobs: map[string]*fakeBlob{}, blocks: map[string]map[string][]byte{}, container: testBucket}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store, err := NewAzureBlobStoreWithConfig(AzureConfig{
+		Endpoint:  server.URL + "/" + testAzureAccount,
+		Account:   testAzureAccount,
+		Key:       testAzureKey,
+		Container: testBucket,
+	})
+	if err != nil {
+		t.Fatalf("NewAzureBlobStoreWithConfig() error = %v", err)
+	}
+	return store, fake
+}
+
+func (f *fakeAzure) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "SharedKey "+testAzureAccount+":") && query.Get("sig") == "" {
+		writeAzureError(w, http.StatusForbidden, "AuthenticationFailed")
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r)
+
+	path := strings.TrimPrefix(r.URL.Path, "/"+testAzureAccount+"/"+f.container)
+	if path == "" && query.Get("comp") == "list" {
+		f.list(w, query.Get("prefix"))
+		return
+	}
+	name := strings.TrimPrefix(path, "/")
+	switch {
+	case r.Method == http.MethodPut && query.Get("comp") == "block":
+		data, _ := io.ReadAll(r.Body)
+		if f.blocks[name] == nil {
+			f.blocks[name] = map[string][]byte{}
+		}
+		f.blocks[name][query.Get("blockid")] = data
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodGet && query.Get("comp") == "blocklist":
+		f.blockList(w, name)
+	case r.Method == http.MethodPut && query.Get("comp") == "blocklist":
+		var list struct {
+			Latest []string `xml:"Latest"`
+		}
+		_ = xml.NewDecoder(r.Body).Decode(&list)
+		var data []byte
+		for _, id := range list.Latest {
+			block, ok := f.blocks[name][id]
+			if !ok {
+				writeAzureError(w, http.StatusBadRequest, "InvalidBlockList")
+				return
+			}
+			data = append(data, block...)
+		}
+		delete(f.blocks, name)
+		f.blobs[name] = &fakeBlob{data: data, contentType: "application/octet-stream"}
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && r.Header.Get("x-ms-copy-source") != "":
+		source, _ := url.Parse(r.Header.Get("x-ms-copy-source"))
+		src, ok := f.blobs[strings.TrimPrefix(source.Path, "/"+testAzureAccount+"/"+f.container+"/")]
+		if !ok || source.Query().Get("sp") != "r" {
+			writeAzureError(w, http.StatusNotFound, "CannotVerifyCopySource")
+			return
+		}
+		f.blobs[name] = &fakeBlob{data: src.data, contentType: r.Header.Get("x-ms-blob-content-type"), metadata: azureMetadata(r.Header)}
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut:
+		if r.Header.Get("x-ms-blob-type") != "BlockBlob" {
+			writeAzureError(w, http.StatusBadRequest, "MissingRequiredHeader")
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		f.blobs[name] = &fakeBlob{data: data, contentType: r.Header.Get("Content-Type"), metadata: azureMetadata(r.Header)}
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		blob, ok := f.blobs[name]
+		if !ok {
+			writeAzureError(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		w.Header().Set("Content-Type", blob.contentType)
+		w.Header().Set("Content-Length", fmt.Sprint(len(blob.data)))
+		w.Header().Set("ETag", `"0x8D`+fmt.Sprint(len(blob.data))+`"`)
+		w.Header().Set("Last-Modified", "Mon, 19 Oct 2026 08:00:00 GMT")
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(blob.data)
+		}
+	case r.Method == http.MethodDelete:
+		if _, ok := f.blobs[name]; !ok {
+			writeAzureError(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		delete(f.blobs, name)
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		writeAzureError(w, http.StatusMethodNotAllowed, "UnsupportedHttpVerb")
+	}
+}
+
+func (f *fakeAzure) list(w http.ResponseWriter, prefix string) {
+	var names []string
+	for name := range f.blobs {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><EnumerationResults><Blobs>`)
+	for _, name := range names {
+		fmt.Fprintf(w, "<Blob><Name>%s</Name></Blob>", name)
+	}
+	fmt.Fprint(w, "</Blobs><NextMarker /></EnumerationResults>")
+}
+
+func (f *fakeAzure) blockList(w http.ResponseWriter, name string) {
+	blob, committed := f.blobs[name]
+	uncommitted := f.blocks[name]
+	if !committed && len(uncommitted) == 0 {
+		writeAzureError(w, http.StatusNotFound, "BlobNotFound")
+		return
+	}
+	fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><BlockList><CommittedBlocks>`)
+	if committed {
+		fmt.Fprintf(w, "<Block><Name>%s</Name><Size>%d</Size></Block>", base64.StdEncoding.EncodeToString([]byte("commit")), len(blob.data))
+	}
+	fmt.Fprint(w, "</CommittedBlocks><UncommittedBlocks>")
+	for id, data := range uncommitted {
+		fmt.Fprintf(w, "<Block><Name>%s</Name><Size>%d</Size></Block>", id, len(data))
+	}
+	fmt.Fprint(w, "</UncommittedBlocks></BlockList>")
+}
+
+func azureMetadata(header http.Header) map[string]string {
+	metadata := map[string]string{}
+	for name := range header {
+		if lower := strings.ToLower(name); strings.HasPrefix(lower, "x-ms-meta-") {
+			metadata[strings.TrimPrefix(lower, "x-ms-meta-")] = header.Get(name)
+		}
+	}
+	return metadata
+}
+
+func writeAzureError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("x-ms-error-code", code)
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?><Error><Code>%s</Code><Message>%s</Message></Error>`, code, code)
+}
+
+func TestAzureBlobStoreSharedKey(t *testing.T) {
+	store, fake := newTestAzureStore(t)
+	if _, err := store.HeadFile(context.Background(), "media/1_photo.png"); !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("HeadFile() of missing blob: expected ErrObjectNotFound, got %v", err)
+	}
+
+	req := fake.requests[0]
+	expected := strings.Join([]string{
+		"HEAD", "", "", "", "", "", "", "", "", "", "", "",
+		"x-ms-date:" + req.Header.Get("x-ms-date"),
+		"x-ms-version:" + azureAPIVersion,
+		"/" + testAzureAccount + "/" + testAzureAccount + "/" + testBucket + "/media/1_photo.png",
+	}, "\n")
+	if got := req.Header.Get("Authorization"); got != "SharedKey "+testAzureAccount+":"+store.sign(expected) {
+		t.Fatalf("unexpected Authorization %q for string to sign %q", got, expected)
+	}
+}
+
+func TestAzureBlobStoreObjects(t *testing.T) {
+	store, fake := newTestAzureStore(t)
+	ctx := context.Background()
+	body := []byte("contenu")
+
+	metadata := map[string]string{"sha256": "abc", "upload-id": "42"}
+	if err := store.UploadFileWithMetadata(ctx, "objects/abc", bytes.NewReader(body), "image/png", metadata); err != nil {
+		t.Fatalf("UploadFileWithMetadata() error = %v", err)
+	}
+	if stored := fake.blobs["objects/abc"]; stored == nil || stored.metadata["upload_id"] != "42" || stored.contentType != "image/png" {
+		t.Fatalf("unexpected stored blob %+v", stored)
+	}
+	info, err := store.HeadFile(ctx, "objects/abc")
+	if err != nil || info.Size != int64(len(body)) || info.ContentType != "image/png" || info.ETag == "" ||
+		!info.LastModified.Equal(time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)) {
+		t.Fatalf("HeadFile() = %+v, %v", info, err)
+	}
+	if data, err := store.GetFile(ctx, "objects/abc", 100); err != nil || !bytes.Equal(data, body) {
+		t.Fatalf("GetFile() = %q, %v", data, err)
+	}
+
+	if err := store.CopyFile(ctx, "objects/abc", "variants/abc/thumbnail", "image/jpeg", nil); err != nil {
+		t.Fatalf("CopyFile() error = %v", err)
+	}
+	if copied := fake.blobs["variants/abc/thumbnail"]; copied == nil || copied.contentType != "image/jpeg" || !bytes.Equal(copied.data, body) {
+		t.Fatalf("unexpected copied blob %+v", copied)
+	}
+	if err := store.CopyFile(ctx, "objects/inconnu", "objects/x", "image/png", nil); !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("CopyFile() of missing source: expected ErrObjectNotFound, got %v", err)
+	}
+
+	keys, err := store.ListFiles(ctx, "variants/")
+	if err != nil || !reflect.DeepEqual(keys, []string{"variants/abc/thumbnail"}) {
+		t.Fatalf("ListFiles() = %v, %v", keys, err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := store.DeleteFile(ctx, "objects/abc"); err != nil {
+			t.Fatalf("DeleteFile() error = %v", err)
+		}
+	}
+	if _, err := store.GetFile(ctx, "objects/abc", 100); !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("expected ErrObjectNotFound after delete, got %v", err)
+	}
+}
+
+func TestAzureBlobStorePresign(t *testing.T) {
+	store, fake := newTestAzureStore(t)
+	ctx := context.Background()
+	body := []byte("fake png content")
+
+	upload, err := store.PresignPut(ctx, "pending/abc_photo.png", "image/png", int64(len(body)), 15*time.Minute)
+	if err != nil {
+		t.Fatalf("PresignPut() error = %v", err)
+	}
+	parsed, _ := url.Parse(upload.URL)
+	if q := parsed.Query(); q.Get("sp") != "cw" || q.Get("sr") != "b" || q.Get("sv") != azureAPIVersion || q.Get("sig") == "" || q.Get("se") == "" {
+		t.Fatalf("unexpected SAS query %v", parsed.Query())
+	}
+	if status, _ := doSigned(t, upload.Method, upload.URL, upload.Headers, body); status != http.StatusCreated {
+		t.Fatalf("presigned PUT status = %d", status)
+	}
+	if stored := fake.blobs["pending/abc_photo.png"]; stored == nil || stored.contentType != "image/png" {
+		t.Fatalf("unexpected uploaded blob %+v", stored)
+	}
+
+	download, expiresAt, err := store.PresignGet(ctx, "pending/abc_photo.png", time.Minute)
+	if err != nil || time.Until(expiresAt) > time.Minute {
+		t.Fatalf("PresignGet() = %v, %v", expiresAt, err)
+	}
+	if parsed, _ := url.Parse(download); parsed.Query().Get("sp") != "r" {
+		t.Fatalf("download SAS must be read-only: %s", download)
+	}
+	if status, data := doSigned(t, http.MethodGet, download, nil, nil); status != http.StatusOK || !bytes.Equal(data, body) {
+		t.Fatalf("presigned GET = %d %q", status, data)
+	}
+}
+
+func TestAzureBlobStoreMultipartUpload(t *testing.T) {
+	store, fake := newTestAzureStore(t)
+	ctx := context.Background()
+	key := "pending/abc_film.mp4"
+	chunks := [][]byte{bytes.Repeat([]byte("a"), 64), []byte("fin")}
+
+	uploadID, err := store.CreateMultipartUpload(ctx, key, "video/mp4")
+	if err != nil || uploadID == "" {
+		t.Fatalf("CreateMultipartUpload() = %q, %v", uploadID, err)
+	}
+	if parts, err := store.ListParts(ctx, key, uploadID); err != nil || len(parts) != 0 {
+		t.Fatalf("ListParts() before upload = %+v, %v", parts, err)
+	}
+	for i, chunk := range chunks {
+		part, err := store.PresignUploadPart(ctx, key, uploadID, int32(i+1), int64(len(chunk)), 15*time.Minute)
+		if err != nil {
+			t.Fatalf("PresignUploadPart() error = %v", err)
+		}
+		if status, _ := doSigned(t, part.Method, part.URL, part.Headers, chunk); status != http.StatusCreated {
+			t.Fatalf("part PUT status = %d", status)
+		}
+	}
+	// Bloc d'un autre upload du même blob : ignoré.
+	fake.blocks[key][azureBlockID("ffffffffffffffff", 1)] = []byte("autre")
+
+	parts, err := store.ListParts(ctx, key, uploadID)
+	if err != nil || len(parts) != 2 || parts[0].Number != 1 || parts[0].Size != 64 || parts[1].Size != 3 {
+		t.Fatalf("ListParts() = %+v, %v", parts, err)
+	}
+	if err := store.CompleteMultipartUpload(ctx, key, uploadID, parts); err != nil {
+		t.Fatalf("CompleteMultipartUpload() error = %v", err)
+	}
+	if stored := fake.blobs[key]; stored == nil || !bytes.Equal(stored.data, bytes.Join(chunks, nil)) {
+		t.Fatalf("unexpected assembled blob %+v", stored)
+	}
+	if _, err := store.ListParts(ctx, key, uploadID); !errors.Is(err, ErrUploadNotFound) {
+		t.Fatalf("completed upload: expected ErrUploadNotFound, got %v", err)
+	}
+	if err := store.AbortMultipartUpload(ctx, key, uploadID); err != nil {
+		t.Fatalf("AbortMultipartUpload() error = %v", err)
+	}
+}
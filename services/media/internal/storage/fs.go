@@ -0,0 +1,561 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FSRoutePrefix : chemin sous lequel le media service sert FSStore.Handler (URLs signées).
+const FSRoutePrefix = "/storage"
+
+// ErrInvalidKey : clé d'objet invalide pour le système de fichiers (vide, absolue ou contenant « .. »).
+var ErrInvalidKey = errors.New("clé d'objet invalide")
+
+// FSConfig : Root est le répertoire des objets ; PublicURL l'adresse de FSStore.Handler joignable par les
+// clients (ex. http://localhost:8080/storage) ; Secret la clé HMAC des URLs signées (aléatoire si vide :
+// les URLs émises ne survivent alors pas à un redémarrage).
+type FSConfig struct {
+	Root      string
+	PublicURL string
+	Secret    string
+}
+
+// FSStore stocke les objets sur le système de fichiers local (développement, tests, déploiement mono-instance).
+// Arborescence : data/<clé> (contenu), meta/<clé>.json (type et métadonnées), uploads/<id>/ (parties
+// d'uploads multipart), tmp/ (écritures en cours, renommées une fois complètes).
+// Les URLs présignées pointent vers Handler, qui vérifie leur signature HMAC et leur expiration.
+type FSStore struct {
+	root      string
+	publicURL string
+	secret    []byte
+}
+
+// fsObjectMeta : fichier meta/<clé>.json.
+type fsObjectMeta struct {
+	ContentType string            `json:"contentType"`
+	ETag        string            `json:"etag"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// fsUpload : fichier uploads/<id>/upload.json.
+type fsUpload struct {
+	Key         string `json:"key"`
+	ContentType string `json:"contentType"`
+}
+
+// NewFSStore crée les répertoires de Root si besoin.
+func NewFSStore(cfg FSConfig) (*FSStore, error) {
+	if cfg.Root == "" {
+		return nil, fmt.Errorf("FS_STORAGE_ROOT manquant")
+	}
+	if cfg.PublicURL == "" {
+		return nil, fmt.Errorf("FS_STORAGE_PUBLIC_URL manquant")
+	}
+	secret := []byte(cfg.Secret)
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("génération du secret: %w", err)
+		}
+	}
+	for _, dir := range []string{"data", "meta", "uploads", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(cfg.Root, dir), 0o755); err != nil {
+			return nil, fmt.Errorf("erreur création %s: %w", dir, err)
+		}
+	}
+	return &FSStore{root: cfg.Root, publicURL: strings.TrimRight(cfg.PublicURL, "/"), secret: secret}, nil
+}
+
+// UploadFileWithMetadata écrit un objet (remplacé de façon atomique s'il existe).
+func (s *FSStore) UploadFileWithMetadata(ctx context.Context, key string, body io.Reader, contentType string, metadata map[string]string) error {
+	if err := validKey(key); err != nil {
+		return err
+	}
+	return s.writeObject(key, body, contentType, metadata)
+}
+
+// GetFile lit un objet entier, dans la limite de maxBytes ; ErrObjectNotFound s'il n'existe pas.
+func (s *FSStore) GetFile(ctx context.Context, key string, maxBytes int64) ([]byte, error) {
+	file, err := s.OpenFile(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("erreur lecture fichier: %w", err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("objet %s trop volumineux (max %d octets)", key, maxBytes)
+	}
+	return data, nil
+}
+
+// OpenFile ouvre un objet en lecture continue ; ErrObjectNotFound s'il n'existe pas.
+func (s *FSStore) OpenFile(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+	file, err := os.Open(s.dataPath(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("erreur lecture fichier: %w", err)
+	}
+	return file, nil
+}
+
+// HeadFile retourne les métadonnées d'un objet ; ErrObjectNotFound s'il n'existe pas.
+func (s *FSStore) HeadFile(ctx context.Context, key string) (*ObjectInfo, error) {
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+	stat, err := os.Stat(s.dataPath(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("erreur head fichier: %w", err)
+	}
+	meta, err := s.readMeta(key)
+	if err != nil {
+		return nil, err
+	}
+	return &ObjectInfo{Size: stat.Size(), ContentType: meta.ContentType, ETag: meta.ETag, LastModified: stat.ModTime()}, nil
+}
+
+// CopyFile copie srcKey vers dstKey en remplaçant Content-Type et métadonnées.
+func (s *FSStore) CopyFile(ctx context.Context, srcKey, dstKey, contentType string, metadata map[string]string) error {
+	if err := validKey(dstKey); err != nil {
+		return err
+	}
+	src, err := s.OpenFile(ctx, srcKey)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	return s.writeObject(dstKey, src, contentType, metadata)
+}
+
+// DeleteFile supprime un objet ; sans erreur s'il n'existe pas (comme S3).
+func (s *FSStore) DeleteFile(ctx context.Context, key string) error {
+	if err := validKey(key); err != nil {
+		return err
+	}
+	for _, name := range []string{s.dataPath(key), s.metaPath(key)} {
+		if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("erreur suppression fichier: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListFiles retourne les clés commençant par prefix, dans l'ordre lexicographique.
+func (s *FSStore) ListFiles(ctx context.Context, prefix string) ([]string, error) {
+	dataRoot := filepath.Join(s.root, "data")
+	var keys []string
+	err := filepath.WalkDir(dataRoot, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dataRoot, name)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erreur liste fichiers: %w", err)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// PresignPut signe un PUT vers Handler, valable ttl. Content-Type et Content-Length font partie
+// de la signature : le client doit envoyer exactement ces valeurs.
+func (s *FSStore) PresignPut(ctx context.Context, key, contentType string, size int64, ttl time.Duration) (*PresignedUpload, error) {
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(ttl)
+	signed := s.signedURL(http.MethodPut, key, expiresAt, fsSignedRequest{ContentType: contentType, Size: size})
+	return &PresignedUpload{
+		URL:       signed,
+		Method:    http.MethodPut,
+		Headers:   map[string]string{"Content-Type": contentType},
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// PresignGet signe un GET de key vers Handler, valable ttl.
+func (s *FSStore) PresignGet(ctx context.Context, key string, ttl time.Duration) (string, time.Time, error) {
+	if err := validKey(key); err != nil {
+		return "", time.Time{}, err
+	}
+	expiresAt := time.Now().Add(ttl)
+	return s.signedURL(http.MethodGet, key, expiresAt, fsSignedRequest{}), expiresAt, nil
+}
+
+// CreateMultipartUpload prépare le répertoire des parties et retourne l'identifiant de l'upload.
+func (s *FSStore) CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error) {
+	if err := validKey(key); err != nil {
+		return "", err
+	}
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("génération de l'identifiant: %w", err)
+	}
+	uploadID := hex.EncodeToString(token)
+	dir := s.uploadPath(uploadID)
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return "", fmt.Errorf("erreur création upload multipart: %w", err)
+	}
+	payload, _ := json.Marshal(fsUpload{Key: key, ContentType: contentType})
+	if err := s.writeFile(filepath.Join(dir, "upload.json"), payload); err != nil {
+		return "", err
+	}
+	return uploadID, nil
+}
+
+// PresignUploadPart signe le PUT de la partie partNumber (size octets exactement) vers Handler.
+func (s *FSStore) PresignUploadPart(ctx context.Context, key, uploadID string, partNumber int32, size int64, ttl time.Duration) (*PresignedUpload, error) {
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(ttl)
+	signed := s.signedURL(http.MethodPut, key, expiresAt, fsSignedRequest{Size: size, UploadID: uploadID, PartNumber: partNumber})
+	return &PresignedUpload{URL: signed, Method: http.MethodPut, Headers: map[string]string{}, ExpiresAt: expiresAt}, nil
+}
+
+// ListParts retourne les parties reçues, triées par numéro ; ErrUploadNotFound si l'upload n'existe plus.
+func (s *FSStore) ListParts(ctx context.Context, key, uploadID string) ([]Part, error) {
+	if _, err := s.openUpload(key, uploadID); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(s.uploadPath(uploadID))
+	if err != nil {
+		return nil, fmt.Errorf("erreur liste des parties: %w", err)
+	}
+	var parts []Part
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".part")
+		if !ok {
+			continue
+		}
+		number, err := strconv.ParseInt(name, 10, 32)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("erreur liste des parties: %w", err)
+		}
+		etag, err := os.ReadFile(filepath.Join(s.uploadPath(uploadID), name+".etag"))
+		if err != nil {
+			// Partie en cours d'écriture : son ETag n'est pas encore enregistré.
+			continue
+		}
+		parts = append(parts, Part{Number: int32(number), ETag: string(etag), Size: info.Size()})
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].Number < parts[j].Number })
+	return parts, nil
+}
+
+// CompleteMultipartUpload assemble les parties en un seul objet key et supprime l'upload.
+func (s *FSStore) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []Part) error {
+	upload, err := s.openUpload(key, uploadID)
+	if err != nil {
+		return err
+	}
+	dir := s.uploadPath(uploadID)
+	readers := make([]io.Reader, 0, len(parts))
+	for _, part := range parts {
+		name := filepath.Join(dir, strconv.Itoa(int(part.Number)))
+		etag, err := os.ReadFile(name + ".etag")
+		if err != nil || string(etag) != part.ETag {
+			return fmt.Errorf("partie %d invalide (ETag %s)", part.Number, part.ETag)
+		}
+		file, err := os.Open(name + ".part")
+		if err != nil {
+			return fmt.Errorf("erreur lecture partie %d: %w", part.Number, err)
+		}
+		defer file.Close()
+		readers = append(readers, file)
+	}
+	if err := s.writeObject(key, io.MultiReader(readers...), upload.ContentType, nil); err != nil {
+		return err
+	}
+	return s.AbortMultipartUpload(ctx, key, uploadID)
+}
+
+// AbortMultipartUpload supprime les parties d'un upload ; idempotent.
+func (s *FSStore) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	if !validUploadID(uploadID) {
+		return nil
+	}
+	if err := os.RemoveAll(s.uploadPath(uploadID)); err != nil {
+		return fmt.Errorf("erreur annulation upload multipart: %w", err)
+	}
+	return nil
+}
+
+// Handler sert les URLs signées (GET d'un objet, PUT d'un objet ou d'une partie), à monter sous
+// FSRoutePrefix : le chemin restant est la clé. Les en-têtes CORS permettent l'upload depuis le front.
+func (s *FSStore) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, PUT")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Expose-Headers", "ETag")
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, "/")
+		if validKey(key) != nil {
+			http.Error(w, "clé invalide", http.StatusBadRequest)
+			return
+		}
+		query := r.URL.Query()
+		signed := fsSignedRequest{UploadID: query.Get("uploadId")}
+		if number := query.Get("partNumber"); number != "" {
+			n, err := strconv.ParseInt(number, 10, 32)
+			if err != nil || n < 1 {
+				http.Error(w, "partNumber invalide", http.StatusBadRequest)
+				return
+			}
+			signed.PartNumber = int32(n)
+		}
+		if r.Method == http.MethodPut {
+			signed.Size = r.ContentLength
+			if signed.PartNumber == 0 {
+				signed.ContentType = r.Header.Get("Content-Type")
+			}
+		}
+		if !s.verify(r.Method, key, query, signed) {
+			http.Error(w, "signature invalide ou expirée", http.StatusForbidden)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet:
+			s.serveObject(w, r, key)
+		case r.Method == http.MethodPut && signed.PartNumber > 0:
+			s.putPart(w, r, key, signed)
+		case r.Method == http.MethodPut:
+			if err := s.writeObject(key, io.LimitReader(r.Body, signed.Size), signed.ContentType, nil); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if meta, err := s.readMeta(key); err == nil {
+				w.Header().Set("ETag", `"`+meta.ETag+`"`)
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "méthode non autorisée", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func (s *FSStore) serveObject(w http.ResponseWriter, r *http.Request, key string) {
+	file, err := os.Open(s.dataPath(key))
+	if err != nil {
+		http.Error(w, "objet introuvable", http.StatusNotFound)
+		return
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if meta, err := s.readMeta(key); err == nil {
+		w.Header().Set("Content-Type", meta.ContentType)
+		w.Header().Set("ETag", `"`+meta.ETag+`"`)
+	}
+	http.ServeContent(w, r, "", stat.ModTime(), file)
+}
+
+func (s *FSStore) putPart(w http.ResponseWriter, r *http.Request, key string, signed fsSignedRequest) {
+	if _, err := s.openUpload(key, signed.UploadID); err != nil {
+		http.Error(w, "upload introuvable", http.StatusNotFound)
+		return
+	}
+	name := filepath.Join(s.uploadPath(signed.UploadID), strconv.Itoa(int(signed.PartNumber)))
+	etag, err := s.writeAtomic(name+".part", io.LimitReader(r.Body, signed.Size))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := s.writeFile(name+".etag", []byte(etag)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("ETag", `"`+etag+`"`)
+	w.WriteHeader(http.StatusOK)
+}
+
+// fsSignedRequest : éléments de la requête couverts par la signature, en plus de la méthode,
+// de la clé et de l'expiration.
+type fsSignedRequest struct {
+	ContentType string
+	Size        int64
+	UploadID    string
+	PartNumber  int32
+}
+
+func (s *FSStore) signedURL(method, key string, expiresAt time.Time, signed fsSignedRequest) string {
+	query := url.Values{}
+	if signed.UploadID != "" {
+		query.Set("uploadId", signed.UploadID)
+		query.Set("partNumber", strconv.Itoa(int(signed.PartNumber)))
+	}
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	query.Set("expires", expires)
+	query.Set("signature", s.signature(method, key, expires, signed))
+	return s.publicURL + "/" + (&url.URL{Path: key}).EscapedPath() + "?" + query.Encode()
+}
+
+func (s *FSStore) verify(method, key string, query url.Values, signed fsSignedRequest) bool {
+	expires := query.Get("expires")
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > unix {
+		return false
+	}
+	expected := s.signature(method, key, expires, signed)
+	return hmac.Equal([]byte(expected), []byte(query.Get("signature")))
+}
+
+func (s *FSStore) signature(method, key, expires string, signed fsSignedRequest) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s\n%d\n%s\n%d", method, key, expires, signed.ContentType, signed.Size, signed.UploadID, signed.PartNumber)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// writeObject écrit le contenu puis ses métadonnées ; l'ETag est le MD5 du contenu (comme S3).
+func (s *FSStore) writeObject(key string, body io.Reader, contentType string, metadata map[string]string) error {
+	etag, err := s.writeAtomic(s.dataPath(key), body)
+	if err != nil {
+		return err
+	}
+	payload, _ := json.Marshal(fsObjectMeta{ContentType: contentType, ETag: etag, Metadata: metadata})
+	return s.writeFile(s.metaPath(key), payload)
+}
+
+func (s *FSStore) readMeta(key string) (*fsObjectMeta, error) {
+	payload, err := os.ReadFile(s.metaPath(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return &fsObjectMeta{ContentType: "application/octet-stream"}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erreur lecture métadonnées: %w", err)
+	}
+	var meta fsObjectMeta
+	if err := json.Unmarshal(payload, &meta); err != nil {
+		return nil, fmt.Errorf("métadonnées invalides pour %s: %w", key, err)
+	}
+	return &meta, nil
+}
+
+func (s *FSStore) openUpload(key, uploadID string) (*fsUpload, error) {
+	if !validUploadID(uploadID) {
+		return nil, ErrUploadNotFound
+	}
+	payload, err := os.ReadFile(filepath.Join(s.uploadPath(uploadID), "upload.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrUploadNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erreur lecture upload multipart: %w", err)
+	}
+	var upload fsUpload
+	if err := json.Unmarshal(payload, &upload); err != nil || upload.Key != key {
+		return nil, ErrUploadNotFound
+	}
+	return &upload, nil
+}
+
+// writeAtomic écrit dans tmp/ puis renomme : un lecteur ne voit jamais de fichier partiel.
+// Retourne le MD5 (hex) du contenu.
+func (s *FSStore) writeAtomic(name string, body io.Reader) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return "", fmt.Errorf("erreur écriture fichier: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Join(s.root, "tmp"), "write-*")
+	if err != nil {
+		return "", fmt.Errorf("erreur écriture fichier: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := md5.New()
+	_, err = io.Copy(io.MultiWriter(tmp, hash), body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("erreur écriture fichier: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return "", fmt.Errorf("erreur écriture fichier: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (s *FSStore) writeFile(name string, data []byte) error {
+	_, err := s.writeAtomic(name, bytes.NewReader(data))
+	return err
+}
+
+func (s *FSStore) dataPath(key string) string {
+	return filepath.Join(s.root, "data", filepath.FromSlash(key))
+}
+
+func (s *FSStore) metaPath(key string) string {
+	return filepath.Join(s.root, "meta", filepath.FromSlash(key)+".json")
+}
+
+func (s *FSStore) uploadPath(uploadID string) string {
+	return filepath.Join(s.root, "uploads", uploadID)
+}
+
+// validKey refuse les clés qui sortiraient de Root.
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) || path.Clean(key) != key ||
+		key == ".." || strings.HasPrefix(key, "../") {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return nil
+}
+
+func validUploadID(uploadID string) bool {
+	_, err := hex.DecodeString(uploadID)
+	return err == nil && uploadID != ""
+}
@@ -0,0 +1,209 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestFSStore sert le store sous FSRoutePrefix, comme le media service.
+func newTestFSStore(t *testing.T) *FSStore {
+	t.Helper()
+	var store *FSStore
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.StripPrefix(FSRoutePrefix, store.Handler()).ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	store, err := NewFSStore(FSConfig{Root: t.TempDir(), PublicURL: server.URL + FSRoutePrefix, Secret: "secret"})
+	if err != nil {
+		t.Fatalf("NewFSStore() error = %v", err)
+	}
+	return store
+}
+
+func doSigned(t *testing.T, method, target string, headers map[string]string, body []byte) (int, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(method, target, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s error = %v", method, target, err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, data
+}
+
+func TestFSStoreObjects(t *testing.T) {
+	store := newTestFSStore(t)
+	ctx := context.Background()
+	body := []byte("contenu")
+
+	if err := store.UploadFileWithMetadata(ctx, "objects/abc", bytes.NewReader(body), "image/png", map[string]string{"sha256": "abc"}); err != nil {
+		t.Fatalf("UploadFileWithMetadata() error = %v", err)
+	}
+	info, err := store.HeadFile(ctx, "objects/abc")
+	if err != nil || info.Size != int64(len(body)) || info.ContentType != "image/png" || info.ETag == "" || info.LastModified.IsZero() {
+		t.Fatalf("HeadFile() = %+v, %v", info, err)
+	}
+	if data, err := store.GetFile(ctx, "objects/abc", 100); err != nil || !bytes.Equal(data, body) {
+		t.Fatalf("GetFile() = %q, %v", data, err)
+	}
+	if _, err := store.GetFile(ctx, "objects/abc", 3); err == nil {
+		t.Fatal("expected error above maxBytes")
+	}
+
+	if err := store.CopyFile(ctx, "objects/abc", "variants/abc/thumbnail", "image/jpeg", nil); err != nil {
+		t.Fatalf("CopyFile() error = %v", err)
+	}
+	if copied, err := store.HeadFile(ctx, "variants/abc/thumbnail"); err != nil || copied.ContentType != "image/jpeg" || copied.ETag != info.ETag {
+		t.Fatalf("copied HeadFile() = %+v, %v", copied, err)
+	}
+	if err := store.CopyFile(ctx, "objects/inconnu", "objects/x", "image/png", nil); !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("CopyFile() of missing source: expected ErrObjectNotFound, got %v", err)
+	}
+
+	keys, err := store.ListFiles(ctx, "")
+	if err != nil || !reflect.DeepEqual(keys, []string{"objects/abc", "variants/abc/thumbnail"}) {
+		t.Fatalf("ListFiles() = %v, %v", keys, err)
+	}
+	if keys, _ := store.ListFiles(ctx, "variants/"); !reflect.DeepEqual(keys, []string{"variants/abc/thumbnail"}) {
+		t.Fatalf("ListFiles(variants/) = %v", keys)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := store.DeleteFile(ctx, "objects/abc"); err != nil {
+			t.Fatalf("DeleteFile() error = %v", err)
+		}
+	}
+	if _, err := store.HeadFile(ctx, "objects/abc"); !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("expected ErrObjectNotFound after delete, got %v", err)
+	}
+	if _, err := store.OpenFile(ctx, "objects/abc"); !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("OpenFile() after delete: expected ErrObjectNotFound, got %v", err)
+	}
+
+	for _, key := range []string{"", "../secret", "/etc/passwd", "media/../../x", "a//b"} {
+		if err := store.UploadFileWithMetadata(ctx, key, bytes.NewReader(body), "image/png", nil); !errors.Is(err, ErrInvalidKey) {
+			t.Fatalf("key %q: expected ErrInvalidKey, got %v", key, err)
+		}
+	}
+}
+
+func TestFSStorePresignedRequests(t *testing.T) {
+	store := newTestFSStore(t)
+	ctx := context.Background()
+	body := []byte("fake png content")
+
+	upload, err := store.PresignPut(ctx, "pending/abc_photo.png", "image/png", int64(len(body)), 15*time.Minute)
+	if err != nil {
+		t.Fatalf("PresignPut() error = %v", err)
+	}
+	// Type ou taille différents de ceux signés : refusé.
+	if status, _ := doSigned(t, upload.Method, upload.URL, map[string]string{"Content-Type": "image/gif"}, body); status != http.StatusForbidden {
+		t.Fatalf("wrong Content-Type: status = %d", status)
+	}
+	if status, _ := doSigned(t, upload.Method, upload.URL, upload.Headers, body[:4]); status != http.StatusForbidden {
+		t.Fatalf("wrong size: status = %d", status)
+	}
+	if status, _ := doSigned(t, upload.Method, upload.URL, upload.Headers, body); status != http.StatusOK {
+		t.Fatalf("presigned PUT status = %d", status)
+	}
+	if info, err := store.HeadFile(ctx, "pending/abc_photo.png"); err != nil || info.Size != int64(len(body)) || info.ContentType != "image/png" {
+		t.Fatalf("HeadFile() = %+v, %v", info, err)
+	}
+
+	download, _, err := store.PresignGet(ctx, "pending/abc_photo.png", time.Minute)
+	if err != nil {
+		t.Fatalf("PresignGet() error = %v", err)
+	}
+	if status, data := doSigned(t, http.MethodGet, download, nil, nil); status != http.StatusOK || !bytes.Equal(data, body) {
+		t.Fatalf("presigned GET = %d %q", status, data)
+	}
+	// Une URL GET ne permet pas d'écrire, ni d'accéder à une autre clé.
+	if status, _ := doSigned(t, http.MethodPut, download, nil, body); status != http.StatusForbidden {
+		t.Fatalf("PUT with GET signature: status = %d", status)
+	}
+	parsed, _ := url.Parse(download)
+	parsed.Path = FSRoutePrefix + "/pending/autre.png"
+	if status, _ := doSigned(t, http.MethodGet, parsed.String(), nil, nil); status != http.StatusForbidden {
+		t.Fatalf("other key: status = %d", status)
+	}
+
+	expired, _, _ := store.PresignGet(ctx, "pending/abc_photo.png", -time.Second)
+	if status, _ := doSigned(t, http.MethodGet, expired, nil, nil); status != http.StatusForbidden {
+		t.Fatalf("expired URL: status = %d", status)
+	}
+}
+
+func TestFSStoreMultipartUpload(t *testing.T) {
+	store := newTestFSStore(t)
+	ctx := context.Background()
+	key := "pending/abc_film.mp4"
+	chunks := [][]byte{bytes.Repeat([]byte("a"), 64), []byte("fin")}
+
+	uploadID, err := store.CreateMultipartUpload(ctx, key, "video/mp4")
+	if err != nil || uploadID == "" {
+		t.Fatalf("CreateMultipartUpload() = %q, %v", uploadID, err)
+	}
+	if parts, err := store.ListParts(ctx, key, uploadID); err != nil || len(parts) != 0 {
+		t.Fatalf("ListParts() before upload = %+v, %v", parts, err)
+	}
+	for i, chunk := range chunks {
+		part, err := store.PresignUploadPart(ctx, key, uploadID, int32(i+1), int64(len(chunk)), 15*time.Minute)
+		if err != nil {
+			t.Fatalf("PresignUploadPart() error = %v", err)
+		}
+		if status, _ := doSigned(t, part.Method, part.URL, part.Headers, chunk); status != http.StatusOK {
+			t.Fatalf("part PUT status = %d", status)
+		}
+	}
+
+	parts, err := store.ListParts(ctx, key, uploadID)
+	if err != nil || len(parts) != 2 || parts[0].Number != 1 || parts[0].Size != 64 || parts[1].Size != 3 {
+		t.Fatalf("ListParts() = %+v, %v", parts, err)
+	}
+	if err := store.CompleteMultipartUpload(ctx, key, uploadID, parts); err != nil {
+		t.Fatalf("CompleteMultipartUpload() error = %v", err)
+	}
+	data, err := store.GetFile(ctx, key, 1<<10)
+	if err != nil || !bytes.Equal(data, bytes.Join(chunks, nil)) {
+		t.Fatalf("assembled object = %q, %v", data, err)
+	}
+	if info, _ := store.HeadFile(ctx, key); info == nil || info.ContentType != "video/mp4" {
+		t.Fatalf("unexpected assembled object info %+v", info)
+	}
+	if _, err := store.ListParts(ctx, key, uploadID); !errors.Is(err, ErrUploadNotFound) {
+		t.Fatalf("completed upload: expected ErrUploadNotFound, got %v", err)
+	}
+	if _, err := store.ListParts(ctx, key, "../../data"); !errors.Is(err, ErrUploadNotFound) {
+		t.Fatalf("invalid upload ID: expected ErrUploadNotFound, got %v", err)
+	}
+
+	// Annulation idempotente.
+	aborted, err := store.CreateMultipartUpload(ctx, "pending/def_film.mp4", "video/mp4")
+	if err != nil {
+		t.Fatalf("CreateMultipartUpload() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := store.AbortMultipartUpload(ctx, "pending/def_film.mp4", aborted); err != nil {
+			t.Fatalf("AbortMultipartUpload() error = %v", err)
+		}
+	}
+	if _, err := store.ListParts(ctx, "pending/def_film.mp4", aborted); !errors.Is(err, ErrUploadNotFound) {
+		t.Fatalf("aborted upload: expected ErrUploadNotFound, got %v", err)
+	}
+}
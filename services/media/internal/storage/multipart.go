@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// ErrUploadNotFound : l'upload multipart n'existe pas (terminé, annulé ou purgé par le stockage).
+var ErrUploadNotFound = errors.New("upload multipart introuvable")
+
+// Part : partie déjà reçue d'un upload multipart.
+type Part struct {
+	Number int32
+	ETag   string
+	Size   int64
+}
+
+// CreateMultipartUpload démarre un upload multipart vers key et retourne son identifiant S3.
+func (s *MinIOClient) CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error) {
+	out, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(s.bucketName),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return "", fmt.Errorf("erreur création upload multipart MinIO: %w", err)
+	}
+	return aws.ToString(out.UploadId), nil
+}
+
+// PresignUploadPart signe le PUT direct de la partie partNumber (size octets exactement), valable ttl.
+func (s *MinIOClient) PresignUploadPart(ctx context.Context, key, uploadID string, partNumber int32, size int64, ttl time.Duration) (*PresignedUpload, error) {
+	req, err := s.presigner.PresignUploadPart(ctx, &s3.UploadPartInput{
+		Bucket:        aws.String(s.bucketName),
+		Key:           aws.String(key),
+		UploadId:      aws.String(uploadID),
+		PartNumber:    aws.Int32(partNumber),
+		ContentLength: aws.Int64(size),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return nil, fmt.Errorf("erreur presign MinIO: %w", err)
+	}
+	return presignedUpload(req.URL, req.Method, req.SignedHeader, ttl), nil
+}
+
+// ListParts retourne les parties reçues, triées par numéro ; ErrUploadNotFound si l'upload n'existe plus.
+func (s *MinIOClient) ListParts(ctx context.Context, key, uploadID string) ([]Part, error) {
+	var parts []Part
+	input := &s3.ListPartsInput{
+		Bucket:   aws.String(s.bucketName),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	}
+	for {
+		out, err := s.client.ListParts(ctx, input)
+		if err != nil {
+			if isNoSuchUpload(err) {
+				return nil, ErrUploadNotFound
+			}
+			return nil, fmt.Errorf("erreur liste des parties MinIO: %w", err)
+		}
+		for _, part := range out.Parts {
+			parts = append(parts, Part{
+				Number: aws.ToInt32(part.PartNumber),
+				ETag:   aws.ToString(part.ETag),
+				Size:   aws.ToInt64(part.Size),
+			})
+		}
+		if !aws.ToBool(out.IsTruncated) {
+			break
+		}
+		input.PartNumberMarker = out.NextPartNumberMarker
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].Number < parts[j].Number })
+	return parts, nil
+}
+
+// CompleteMultipartUpload assemble les parties en un seul objet key.
+func (s *MinIOClient) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []Part) error {
+	completed := make([]types.CompletedPart, 0, len(parts))
+	for _, part := range parts {
+		completed = append(completed, types.CompletedPart{
+			PartNumber: aws.Int32(part.Number),
+			ETag:       aws.String(part.ETag),
+		})
+	}
+	_, err := s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(s.bucketName),
+		Key:             aws.String(key),
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: completed},
+	})
+	if err != nil {
+		if isNoSuchUpload(err) {
+			return ErrUploadNotFound
+		}
+		return fmt.Errorf("erreur assemblage upload multipart MinIO: %w", err)
+	}
+	return nil
+}
+
+// AbortMultipartUpload annule un upload multipart et libère ses parties ; idempotent.
+func (s *MinIOClient) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	_, err := s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(s.bucketName),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	if err != nil && !isNoSuchUpload(err) {
+		return fmt.Errorf("erreur annulation upload multipart MinIO: %w", err)
+	}
+	return nil
+}
+
+// OpenFile ouvre un objet en lecture continue (gros fichiers) ; ErrObjectNotFound s'il n'existe pas.
+func (s *MinIOClient) OpenFile(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("erreur lecture MinIO: %w", err)
+	}
+	return out.Body, nil
+}
+
+func isNoSuchUpload(err error) bool {
+	var noSuchUpload *types.NoSuchUpload
+	if errors.As(err, &noSuchUpload) {
+		return true
+	}
+	var withStatus interface{ HTTPStatusCode() int }
+	return errors.As(err, &withStatus) && withStatus.HTTPStatusCode() == http.StatusNotFound
+}
@@ -2,24 +2,59 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
-// MinIOClient gère la connexion à MinIO (stockage objet local compatible S3).
-// En production Azure, le media service utilise Azure Blob Storage à la place.
+// ErrObjectNotFound : l'objet demandé n'existe pas dans le bucket.
+var ErrObjectNotFound = errors.New("objet introuvable")
+
+// MinIOClient gère la connexion à MinIO (stockage objet local compatible S3) ou à tout service S3.
+// En production Azure, le media service utilise AzureBlobStore à la place (voir ObjectStore).
 type MinIOClient struct {
 	client     *s3.Client
+	presigner  *s3.PresignClient
 	bucketName string
 	endpoint   string
 }
 
+// Config : paramètres de connexion. PublicEndpoint (optionnel) est l'adresse joignable par les
+// clients, utilisée pour signer les URLs d'upload direct ; par défaut Endpoint.
+type Config struct {
+	Endpoint       string
+	PublicEndpoint string
+	AccessKey      string
+	SecretKey      string
+	Bucket         string
+}
+
+// PresignedUpload : requête PUT signée à exécuter par le client, en-têtes compris.
+type PresignedUpload struct {
+	URL       string
+	Method    string
+	Headers   map[string]string
+	ExpiresAt time.Time
+}
+
+// ObjectInfo : métadonnées d'un objet (HEAD).
+type ObjectInfo struct {
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
 // Endpoint retourne l'URL de base MinIO (ex: http://localhost:9000)
 func (s *MinIOClient) Endpoint() string {
 	return s.endpoint
@@ -48,35 +83,69 @@ func NewMinIOClient(bucketName string) (*MinIOClient, error) {
 		return nil, fmt.Errorf("MINIO_ACCESS_KEY ou MINIO_SECRET_KEY manquant")
 	}
 
-	// Si l'endpoint contient déjà un schéma (http:// ou https://), on l'utilise tel quel
-	resolvedEndpoint := endpoint
+	return NewMinIOClientWithConfig(Config{
+		Endpoint:       endpoint,
+		PublicEndpoint: os.Getenv("MINIO_PUBLIC_ENDPOINT"),
+		AccessKey:      accessKey,
+		SecretKey:      secretKey,
+		Bucket:         bucketName,
+	})
+}
+
+// NewMinIOClientWithConfig initialise le client sans lire l'environnement (tests, outils).
+func NewMinIOClientWithConfig(cfg Config) (*MinIOClient, error) {
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("endpoint manquant")
+	}
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("bucket manquant")
+	}
+
+	resolvedEndpoint := resolveEndpoint(cfg.Endpoint)
+	publicEndpoint := resolvedEndpoint
+	if cfg.PublicEndpoint != "" {
+		publicEndpoint = resolveEndpoint(cfg.PublicEndpoint)
+	}
+
+	return &MinIOClient{
+		client:     newS3Client(resolvedEndpoint, cfg.AccessKey, cfg.SecretKey),
+		presigner:  s3.NewPresignClient(newS3Client(publicEndpoint, cfg.AccessKey, cfg.SecretKey)),
+		bucketName: cfg.Bucket,
+		endpoint:   resolvedEndpoint,
+	}, nil
+}
+
+// resolveEndpoint ajoute le schéma http:// s'il manque et retire le slash final (le SDK AWS l'ajoute lui-même).
+func resolveEndpoint(endpoint string) string {
+	resolved := endpoint
 	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
-		resolvedEndpoint = "http://" + endpoint
+		resolved = "http://" + endpoint
 	}
-	// Supprimer le slash final si présent (le SDK AWS l'ajoute lui-même)
-	resolvedEndpoint = strings.TrimRight(resolvedEndpoint, "/")
+	return strings.TrimRight(resolved, "/")
+}
 
-	client := s3.New(s3.Options{
-		BaseEndpoint: aws.String(resolvedEndpoint),
+func newS3Client(endpoint, accessKey, secretKey string) *s3.Client {
+	return s3.New(s3.Options{
+		BaseEndpoint: aws.String(endpoint),
 		Region:       "us-east-1", // Requis par le SDK mais ignoré par MinIO/Azure
 		Credentials:  credentials.NewStaticCredentialsProvider(accessKey, secretKey, ""),
 		UsePathStyle: true,
 	})
-
-	return &MinIOClient{
-		client:     client,
-		bucketName: bucketName,
-		endpoint:   resolvedEndpoint,
-	}, nil
 }
 
 // UploadFile envoie un fichier (io.Reader) vers le bucket
 func (s *MinIOClient) UploadFile(ctx context.Context, key string, body io.Reader, contentType string) error {
+	return s.UploadFileWithMetadata(ctx, key, body, contentType, nil)
+}
+
+// UploadFileWithMetadata envoie un fichier avec des métadonnées objet (x-amz-meta-*).
+func (s *MinIOClient) UploadFileWithMetadata(ctx context.Context, key string, body io.Reader, contentType string, metadata map[string]string) error {
 	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
 		Bucket:      aws.String(s.bucketName),
 		Key:         aws.String(key),
 		Body:        body,
 		ContentType: aws.String(contentType),
+		Metadata:    metadata,
 	})
 	if err != nil {
 		return fmt.Errorf("erreur upload MinIO: %w", err)
@@ -84,6 +153,30 @@ func (s *MinIOClient) UploadFile(ctx context.Context, key string, body io.Reader
 	return nil
 }
 
+// GetFile lit un objet entier, dans la limite de maxBytes ; ErrObjectNotFound s'il n'existe pas.
+func (s *MinIOClient) GetFile(ctx context.Context, key string, maxBytes int64) ([]byte, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("erreur lecture MinIO: %w", err)
+	}
+	defer out.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(out.Body, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("erreur lecture MinIO: %w", err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("objet %s trop volumineux (max %d octets)", key, maxBytes)
+	}
+	return data, nil
+}
+
 // DeleteFile supprime un objet du bucket
 func (s *MinIOClient) DeleteFile(ctx context.Context, key string) error {
 	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
@@ -94,4 +187,123 @@ func (s *MinIOClient) DeleteFile(ctx context.Context, key string) error {
 		return fmt.Errorf("erreur delete MinIO: %w", err)
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// ListFiles retourne les clés commençant par prefix, dans l'ordre lexicographique.
+func (s *MinIOClient) ListFiles(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucketName),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("erreur liste MinIO: %w", err)
+		}
+		for _, object := range page.Contents {
+			keys = append(keys, aws.ToString(object.Key))
+		}
+	}
+	return keys, nil
+}
+
+// PresignPut signe un PUT direct vers key, valable ttl. Content-Type et Content-Length font partie
+// de la signature : le client doit envoyer exactement ces valeurs.
+func (s *MinIOClient) PresignPut(ctx context.Context, key, contentType string, size int64, ttl time.Duration) (*PresignedUpload, error) {
+	req, err := s.presigner.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucketName),
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(size),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return nil, fmt.Errorf("erreur presign MinIO: %w", err)
+	}
+
+	return presignedUpload(req.URL, req.Method, req.SignedHeader, ttl), nil
+}
+
+// presignedUpload : en-têtes signés à renvoyer tels quels par le client.
+func presignedUpload(signedURL, method string, signed http.Header, ttl time.Duration) *PresignedUpload {
+	headers := make(map[string]string, len(signed))
+	for name, values := range signed {
+		// Host est fixé par l'URL elle-même.
+		if strings.EqualFold(name, "Host") || len(values) == 0 {
+			continue
+		}
+		headers[name] = values[0]
+	}
+	return &PresignedUpload{
+		URL:       signedURL,
+		Method:    method,
+		Headers:   headers,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+}
+
+// PresignGet signe un GET de key valable ttl : le bucket n'a pas à être public,
+// l'URL expire avec la signature.
+func (s *MinIOClient) PresignGet(ctx context.Context, key string, ttl time.Duration) (string, time.Time, error) {
+	req, err := s.presigner.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("erreur presign MinIO: %w", err)
+	}
+	return req.URL, time.Now().Add(ttl), nil
+}
+
+// HeadFile retourne les métadonnées d'un objet ; ErrObjectNotFound s'il n'existe pas.
+func (s *MinIOClient) HeadFile(ctx context.Context, key string) (*ObjectInfo, error) {
+	out, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("erreur head MinIO: %w", err)
+	}
+	return &ObjectInfo{
+		Size:         aws.ToInt64(out.ContentLength),
+		ContentType:  aws.ToString(out.ContentType),
+		ETag:         strings.Trim(aws.ToString(out.ETag), `"`),
+		LastModified: aws.ToTime(out.LastModified),
+	}, nil
+}
+
+// CopyFile copie srcKey vers dstKey côté serveur en remplaçant Content-Type et métadonnées.
+func (s *MinIOClient) CopyFile(ctx context.Context, srcKey, dstKey, contentType string, metadata map[string]string) error {
+	_, err := s.client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:            aws.String(s.bucketName),
+		Key:               aws.String(dstKey),
+		CopySource:        aws.String((&url.URL{Path: s.bucketName + "/" + srcKey}).EscapedPath()),
+		ContentType:       aws.String(contentType),
+		Metadata:          metadata,
+		MetadataDirective: types.MetadataDirectiveReplace,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return ErrObjectNotFound
+		}
+		return fmt.Errorf("erreur copie MinIO: %w", err)
+	}
+	return nil
+}
+
+// isNotFound : HEAD ne renvoie pas de corps d'erreur, seul le statut HTTP est fiable.
+func isNotFound(err error) bool {
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return true
+	}
+	var notFound *types.NotFound
+	if errors.As(err, &notFound) {
+		return true
+	}
+	var withStatus interface{ HTTPStatusCode() int }
+	return errors.As(err, &withStatus) && withStatus.HTTPStatusCode() == http.StatusNotFound
+}
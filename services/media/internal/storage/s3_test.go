@@ -0,0 +1,218 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/storage/s3test"
+)
+
+const testBucket = "media"
+
+func newTestClient(t *testing.T) (*MinIOClient, *s3test.Server) {
+	t.Helper()
+	server := s3test.NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := NewMinIOClientWithConfig(Config{
+		Endpoint:  server.URL,
+		AccessKey: "admin",
+		SecretKey: "password",
+		Bucket:    testBucket,
+	})
+	if err != nil {
+		t.Fatalf("NewMinIOClientWithConfig() error = %v", err)
+	}
+	return client, server
+}
+
+func TestMinIOClientPresignPut(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+	body := []byte("fake png content")
+
+	upload, err := client.PresignPut(ctx, "pending/abc_photo.png", "image/png", int64(len(body)), 15*time.Minute)
+	if err != nil {
+		t.Fatalf("PresignPut() error = %v", err)
+	}
+	if upload.Method != http.MethodPut {
+		t.Fatalf("expected PUT, got %s", upload.Method)
+	}
+	parsed, err := url.Parse(upload.URL)
+	if err != nil {
+		t.Fatalf("invalid presigned URL %q: %v", upload.URL, err)
+	}
+	if parsed.Query().Get("X-Amz-Signature") == "" || parsed.Query().Get("X-Amz-Expires") != "900" {
+		t.Fatalf("unexpected presigned query %v", parsed.Query())
+	}
+	if _, ok := upload.Headers["Host"]; ok {
+		t.Fatal("Host must not be returned as a header to send")
+	}
+
+	// Le client exécute la requête signée telle quelle, sans identifiants.
+	req, err := http.NewRequest(upload.Method, upload.URL, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	for name, value := range upload.Headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", "image/png")
+	req.ContentLength = int64(len(body))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("presigned PUT error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("presigned PUT status = %d", resp.StatusCode)
+	}
+
+	stored := server.Get(testBucket, "pending/abc_photo.png")
+	if stored == nil || !bytes.Equal(stored.Data, body) || stored.ContentType != "image/png" {
+		t.Fatalf("unexpected stored object %+v", stored)
+	}
+}
+
+func TestMinIOClientPresignUsesPublicEndpoint(t *testing.T) {
+	client, err := NewMinIOClientWithConfig(Config{
+		Endpoint:       "minio:9000",
+		PublicEndpoint: "https://media.example.com/",
+		AccessKey:      "admin",
+		SecretKey:      "password",
+		Bucket:         testBucket,
+	})
+	if err != nil {
+		t.Fatalf("NewMinIOClientWithConfig() error = %v", err)
+	}
+	upload, err := client.PresignPut(context.Background(), "pending/x.png", "image/png", 10, time.Minute)
+	if err != nil {
+		t.Fatalf("PresignPut() error = %v", err)
+	}
+	parsed, _ := url.Parse(upload.URL)
+	if parsed.Scheme != "https" || parsed.Host != "media.example.com" || parsed.Path != "/media/pending/x.png" {
+		t.Fatalf("presigned URL should target the public endpoint, got %s", upload.URL)
+	}
+	if client.Endpoint() != "http://minio:9000" {
+		t.Fatalf("internal endpoint should stay unchanged, got %s", client.Endpoint())
+	}
+}
+
+func TestMinIOClientHeadAndCopy(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := client.HeadFile(ctx, "pending/missing.png"); !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("missing object: expected ErrObjectNotFound, got %v", err)
+	}
+	if err := client.CopyFile(ctx, "pending/missing.png", "media/missing.png", "image/png", nil); !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("copy missing object: expected ErrObjectNotFound, got %v", err)
+	}
+
+	server.Put(testBucket, "pending/a b.png", s3test.Object{Data: []byte("12345"), ContentType: "image/png"})
+	info, err := client.HeadFile(ctx, "pending/a b.png")
+	if err != nil {
+		t.Fatalf("HeadFile() error = %v", err)
+	}
+	if info.Size != 5 || info.ContentType != "image/png" || info.ETag == "" {
+		t.Fatalf("unexpected object info %+v", info)
+	}
+
+	metadata := map[string]string{"original-filename": "a b.png", "size": strconv.Itoa(5)}
+	if err := client.CopyFile(ctx, "pending/a b.png", "media/a b.png", "image/png", metadata); err != nil {
+		t.Fatalf("CopyFile() error = %v", err)
+	}
+	copied := server.Get(testBucket, "media/a b.png")
+	if copied == nil || string(copied.Data) != "12345" || copied.Metadata["original-filename"] != "a b.png" {
+		t.Fatalf("unexpected copied object %+v", copied)
+	}
+
+	if err := client.DeleteFile(ctx, "pending/a b.png"); err != nil {
+		t.Fatalf("DeleteFile() error = %v", err)
+	}
+	if server.Get(testBucket, "pending/a b.png") != nil {
+		t.Fatal("pending object should have been deleted")
+	}
+}
+
+func TestMinIOClientMultipartUpload(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+	key := "pending/abc_film.mp4"
+	chunks := [][]byte{bytes.Repeat([]byte("a"), 64), []byte("fin")}
+
+	uploadID, err := client.CreateMultipartUpload(ctx, key, "video/mp4")
+	if err != nil || uploadID == "" {
+		t.Fatalf("CreateMultipartUpload() = %q, %v", uploadID, err)
+	}
+	for i, chunk := range chunks {
+		part, err := client.PresignUploadPart(ctx, key, uploadID, int32(i+1), int64(len(chunk)), 15*time.Minute)
+		if err != nil {
+			t.Fatalf("PresignUploadPart() error = %v", err)
+		}
+		parsed, _ := url.Parse(part.URL)
+		if parsed.Query().Get("uploadId") != uploadID || parsed.Query().Get("partNumber") != strconv.Itoa(i+1) {
+			t.Fatalf("unexpected part URL %q", part.URL)
+		}
+		req, _ := http.NewRequest(part.Method, part.URL, bytes.NewReader(chunk))
+		for name, value := range part.Headers {
+			req.Header.Set(name, value)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("part PUT error = %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("part PUT status = %d", resp.StatusCode)
+		}
+	}
+
+	parts, err := client.ListParts(ctx, key, uploadID)
+	if err != nil || len(parts) != 2 || parts[0].Number != 1 || parts[0].Size != 64 || parts[1].Size != 3 {
+		t.Fatalf("ListParts() = %+v, %v", parts, err)
+	}
+	if err := client.CompleteMultipartUpload(ctx, key, uploadID, parts); err != nil {
+		t.Fatalf("CompleteMultipartUpload() error = %v", err)
+	}
+	stored := server.Get(testBucket, key)
+	if stored == nil || !bytes.Equal(stored.Data, bytes.Join(chunks, nil)) || stored.ContentType != "video/mp4" {
+		t.Fatalf("unexpected assembled object %+v", stored)
+	}
+	if _, err := client.ListParts(ctx, key, uploadID); !errors.Is(err, ErrUploadNotFound) {
+		t.Fatalf("completed upload: expected ErrUploadNotFound, got %v", err)
+	}
+
+	// Annulation idempotente.
+	aborted, err := client.CreateMultipartUpload(ctx, "pending/def_film.mp4", "video/mp4")
+	if err != nil {
+		t.Fatalf("CreateMultipartUpload() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := client.AbortMultipartUpload(ctx, "pending/def_film.mp4", aborted); err != nil {
+			t.Fatalf("AbortMultipartUpload() error = %v", err)
+		}
+	}
+	if server.Uploads() != 0 {
+		t.Fatalf("expected no upload in progress, got %d", server.Uploads())
+	}
+}
+
+func TestMinIOClientListFiles(t *testing.T) {
+	client, server := newTestClient(t)
+	for _, key := range []string{"variants/abc/thumbnail", "objects/abc", "objects/def"} {
+		server.Put(testBucket, key, s3test.Object{Data: []byte(key)})
+	}
+
+	keys, err := client.ListFiles(context.Background(), "objects/")
+	if err != nil || !reflect.DeepEqual(keys, []string{"objects/abc", "objects/def"}) {
+		t.Fatalf("ListFiles() = %v, %v", keys, err)
+	}
+}
@@ -0,0 +1,166 @@
+package s3test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// multipartUpload : upload multipart en cours, parties indexées par numéro.
+type multipartUpload struct {
+	path        string
+	contentType string
+	parts       map[int][]byte
+}
+
+// Uploads retourne le nombre d'uploads multipart en cours (ni terminés, ni annulés).
+func (s *Server) Uploads() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.uploads)
+}
+
+// handleMultipart traite les requêtes multipart (?uploads, ?uploadId) ; false pour les autres.
+func (s *Server) handleMultipart(w http.ResponseWriter, r *http.Request, path string) bool {
+	query := r.URL.Query()
+	_, initiate := query["uploads"]
+	uploadID := query.Get("uploadId")
+	switch {
+	case r.Method == http.MethodPost && initiate:
+		s.initiateUpload(w, r, path)
+	case uploadID == "":
+		return false
+	case r.Method == http.MethodPut:
+		s.uploadPart(w, r, path, uploadID)
+	case r.Method == http.MethodGet:
+		s.listParts(w, path, uploadID)
+	case r.Method == http.MethodPost:
+		s.completeUpload(w, r, path, uploadID)
+	case r.Method == http.MethodDelete:
+		s.mu.Lock()
+		upload, ok := s.uploads[uploadID]
+		if ok && upload.path == path {
+			delete(s.uploads, uploadID)
+		}
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchUpload")
+			return true
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+	return true
+}
+
+func (s *Server) initiateUpload(w http.ResponseWriter, r *http.Request, path string) {
+	s.mu.Lock()
+	s.nextID++
+	uploadID := "upload-" + strconv.Itoa(s.nextID)
+	s.uploads[uploadID] = &multipartUpload{path: path, contentType: r.Header.Get("Content-Type"), parts: make(map[int][]byte)}
+	s.mu.Unlock()
+
+	bucket, key, _ := strings.Cut(path, "/")
+	w.Header().Set("Content-Type", "application/xml")
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><InitiateMultipartUploadResult><Bucket>%s</Bucket><Key>%s</Key><UploadId>%s</UploadId></InitiateMultipartUploadResult>`,
+		bucket, key, uploadID)
+}
+
+func (s *Server) uploadPart(w http.ResponseWriter, r *http.Request, path, uploadID string) {
+	number, err := strconv.Atoi(r.URL.Query().Get("partNumber"))
+	if err != nil || number < 1 {
+		writeError(w, http.StatusBadRequest, "InvalidArgument")
+		return
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil || (r.ContentLength >= 0 && int64(len(data)) != r.ContentLength) {
+		writeError(w, http.StatusBadRequest, "IncompleteBody")
+		return
+	}
+	s.mu.Lock()
+	upload, ok := s.uploads[uploadID]
+	if ok && upload.path == path {
+		upload.parts[number] = data
+	}
+	s.mu.Unlock()
+	if !ok || upload.path != path {
+		writeError(w, http.StatusNotFound, "NoSuchUpload")
+		return
+	}
+	w.Header().Set("ETag", etag(data))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) listParts(w http.ResponseWriter, path, uploadID string) {
+	s.mu.Lock()
+	upload, ok := s.uploads[uploadID]
+	var body strings.Builder
+	if ok && upload.path == path {
+		for _, number := range sortedParts(upload) {
+			data := upload.parts[number]
+			fmt.Fprintf(&body, `<Part><PartNumber>%d</PartNumber><ETag>%s</ETag><Size>%d</Size></Part>`, number, etag(data), len(data))
+		}
+	}
+	s.mu.Unlock()
+	if !ok || upload.path != path {
+		writeError(w, http.StatusNotFound, "NoSuchUpload")
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><ListPartsResult><UploadId>%s</UploadId><IsTruncated>false</IsTruncated>%s</ListPartsResult>`,
+		uploadID, body.String())
+}
+
+type completeRequest struct {
+	Parts []struct {
+		PartNumber int    `xml:"PartNumber"`
+		ETag       string `xml:"ETag"`
+	} `xml:"Part"`
+}
+
+// completeUpload assemble les parties demandées, qui doivent exister avec le bon ETag et être croissantes.
+func (s *Server) completeUpload(w http.ResponseWriter, r *http.Request, path, uploadID string) {
+	var req completeRequest
+	if err := xml.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Parts) == 0 {
+		writeError(w, http.StatusBadRequest, "MalformedXML")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	upload, ok := s.uploads[uploadID]
+	if !ok || upload.path != path {
+		writeError(w, http.StatusNotFound, "NoSuchUpload")
+		return
+	}
+	var data []byte
+	previous := 0
+	for _, part := range req.Parts {
+		content, ok := upload.parts[part.PartNumber]
+		if !ok || part.PartNumber <= previous || part.ETag != etag(content) {
+			writeError(w, http.StatusBadRequest, "InvalidPart")
+			return
+		}
+		previous = part.PartNumber
+		data = append(data, content...)
+	}
+	s.objects[path] = &Object{Data: data, ContentType: upload.contentType, Metadata: map[string]string{}}
+	delete(s.uploads, uploadID)
+
+	w.Header().Set("Content-Type", "application/xml")
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><CompleteMultipartUploadResult><Key>%s</Key><ETag>%s</ETag></CompleteMultipartUploadResult>`,
+		path, etag(data))
+}
+
+func sortedParts(upload *multipartUpload) []int {
+	numbers := make([]int, 0, len(upload.parts))
+	for number := range upload.parts {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+	return numbers
+}
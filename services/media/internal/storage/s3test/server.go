@@ -0,0 +1,231 @@
+// Package s3test fournit un faux serveur S3 en mémoire (style chemin, /bucket/key) pour tester
+// MinIOClient sans MinIO : PUT (upload direct ou copie), HEAD, GET, DELETE, liste (ListObjectsV2) et upload multipart.
+// Les signatures ne sont pas vérifiées, seule leur présence l'est.
+package s3test
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Object : objet stocké par le faux serveur.
+type Object struct {
+	Data        []byte
+	ContentType string
+	Metadata    map[string]string
+	// ModTime : date de dernière modification annoncée (Last-Modified) ; l'heure courante si zéro.
+	ModTime time.Time
+}
+
+// Server : serveur HTTP en mémoire ; URL est l'endpoint à passer au client.
+type Server struct {
+	URL string
+
+	srv     *httptest.Server
+	mu      sync.Mutex
+	objects map[string]*Object
+	uploads map[string]*multipartUpload
+	nextID  int
+}
+
+func NewServer() *Server {
+	s := &Server{objects: make(map[string]*Object), uploads: make(map[string]*multipartUpload)}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.handle))
+	s.URL = s.srv.URL
+	return s
+}
+
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Put dépose directement un objet (préparation de test).
+func (s *Server) Put(bucket, key string, obj Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[bucket+"/"+key] = &obj
+}
+
+// Get retourne une copie de l'objet, nil s'il n'existe pas.
+func (s *Server) Get(bucket, key string) *Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[bucket+"/"+key]
+	if !ok {
+		return nil
+	}
+	clone := *obj
+	return &clone
+}
+
+// Keys liste les clés présentes dans un bucket.
+func (s *Server) Keys(bucket string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []string
+	for path := range s.objects {
+		if key, ok := strings.CutPrefix(path, bucket+"/"); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") == "" && r.URL.Query().Get("X-Amz-Signature") == "" {
+		writeError(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	if s.handleMultipart(w, r, path) {
+		return
+	}
+	if bucket, isBucket := strings.CutSuffix(path, "/"); r.Method == http.MethodGet && (isBucket || !strings.Contains(path, "/")) {
+		s.listObjects(w, bucket, r.URL.Query().Get("prefix"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		if source := r.Header.Get("X-Amz-Copy-Source"); source != "" {
+			s.copyObject(w, r, path, source)
+			return
+		}
+		s.putObject(w, r, path)
+	case http.MethodHead, http.MethodGet:
+		s.mu.Lock()
+		obj, ok := s.objects[path]
+		s.mu.Unlock()
+		if !ok {
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writeError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		writeObjectHeaders(w, obj)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(obj.Data)
+		}
+	case http.MethodDelete:
+		s.mu.Lock()
+		delete(s.objects, path)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func (s *Server) putObject(w http.ResponseWriter, r *http.Request, path string) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "IncompleteBody")
+		return
+	}
+	if r.ContentLength >= 0 && int64(len(data)) != r.ContentLength {
+		writeError(w, http.StatusBadRequest, "IncompleteBody")
+		return
+	}
+	obj := &Object{
+		Data:        data,
+		ContentType: r.Header.Get("Content-Type"),
+		Metadata:    metadataFromHeaders(r.Header),
+	}
+	s.mu.Lock()
+	s.objects[path] = obj
+	s.mu.Unlock()
+	w.Header().Set("ETag", etag(data))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) copyObject(w http.ResponseWriter, r *http.Request, path, source string) {
+	decoded, err := url.PathUnescape(source)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidArgument")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	src, ok := s.objects[strings.TrimPrefix(decoded, "/")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchKey")
+		return
+	}
+	copied := &Object{Data: append([]byte(nil), src.Data...), ContentType: src.ContentType, Metadata: src.Metadata}
+	if strings.EqualFold(r.Header.Get("X-Amz-Metadata-Directive"), "REPLACE") {
+		copied.ContentType = r.Header.Get("Content-Type")
+		copied.Metadata = metadataFromHeaders(r.Header)
+	}
+	s.objects[path] = copied
+
+	w.Header().Set("Content-Type", "application/xml")
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><CopyObjectResult><LastModified>%s</LastModified><ETag>%s</ETag></CopyObjectResult>`,
+		time.Now().UTC().Format(time.RFC3339), etag(copied.Data))
+}
+
+// listObjects répond à ListObjectsV2 en une seule page.
+func (s *Server) listObjects(w http.ResponseWriter, bucket, prefix string) {
+	var keys []string
+	for _, key := range s.Keys(bucket) {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	w.Header().Set("Content-Type", "application/xml")
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><ListBucketResult><Name>%s</Name><Prefix>%s</Prefix><KeyCount>%d</KeyCount><IsTruncated>false</IsTruncated>`,
+		bucket, prefix, len(keys))
+	for _, key := range keys {
+		fmt.Fprintf(w, "<Contents><Key>%s</Key></Contents>", key)
+	}
+	fmt.Fprint(w, "</ListBucketResult>")
+}
+
+func writeObjectHeaders(w http.ResponseWriter, obj *Object) {
+	w.Header().Set("Content-Type", obj.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(obj.Data)))
+	w.Header().Set("ETag", etag(obj.Data))
+	modTime := obj.ModTime
+	if modTime.IsZero() {
+		modTime = time.Now()
+	}
+	w.Header().Set("Last-Modified", modTime.UTC().Format(http.TimeFormat))
+	for key, value := range obj.Metadata {
+		w.Header().Set("X-Amz-Meta-"+key, value)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func metadataFromHeaders(header http.Header) map[string]string {
+	metadata := make(map[string]string)
+	for name, values := range header {
+		if lower := strings.ToLower(name); strings.HasPrefix(lower, "x-amz-meta-") && len(values) > 0 {
+			metadata[strings.TrimPrefix(lower, "x-amz-meta-")] = values[0]
+		}
+	}
+	return metadata
+}
+
+func etag(data []byte) string {
+	sum := md5.Sum(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>%s</Code><Message>%s</Message></Error>`, code, code)
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// ObjectStore : stockage objet utilisé par le media service. Implémentations : S3/MinIO (MinIOClient),
+// système de fichiers local (FSStore) et Azure Blob Storage (AzureBlobStore).
+type ObjectStore interface {
+	UploadFileWithMetadata(ctx context.Context, key string, body io.Reader, contentType string, metadata map[string]string) error
+	GetFile(ctx context.Context, key string, maxBytes int64) ([]byte, error)
+	OpenFile(ctx context.Context, key string) (io.ReadCloser, error)
+	HeadFile(ctx context.Context, key string) (*ObjectInfo, error)
+	CopyFile(ctx context.Context, srcKey, dstKey, contentType string, metadata map[string]string) error
+	DeleteFile(ctx context.Context, key string) error
+	ListFiles(ctx context.Context, prefix string) ([]string, error)
+
+	PresignPut(ctx context.Context, key, contentType string, size int64, ttl time.Duration) (*PresignedUpload, error)
+	PresignGet(ctx context.Context, key string, ttl time.Duration) (string, time.Time, error)
+
+	// Upload en plusieurs parties (uploads reprenables), parties envoyées directement par le client.
+	CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error)
+	PresignUploadPart(ctx context.Context, key, uploadID string, partNumber int32, size int64, ttl time.Duration) (*PresignedUpload, error)
+	ListParts(ctx context.Context, key, uploadID string) ([]Part, error)
+	CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []Part) error
+	AbortMultipartUpload(ctx context.Context, key, uploadID string) error
+}
+
+var (
+	_ ObjectStore = (*MinIOClient)(nil)
+	_ ObjectStore = (*FSStore)(nil)
+	_ ObjectStore = (*AzureBlobStore)(nil)
+)
+
+// Backends sélectionnables par OBJECT_STORAGE.
+const (
+	BackendS3    = "s3"
+	BackendFS    = "fs"
+	BackendAzure = "azure"
+)
+
+// NewObjectStore crée le backend choisi par OBJECT_STORAGE (s3 par défaut, fs, azure) à partir
+// de l'environnement ; bucket est le bucket (S3) ou le container (Azure) par défaut.
+func NewObjectStore(bucket string) (ObjectStore, error) {
+	switch backend := strings.ToLower(os.Getenv("OBJECT_STORAGE")); backend {
+	case "", BackendS3, "minio":
+		return NewMinIOClient(bucket)
+	case BackendFS:
+		publicURL := os.Getenv("FS_STORAGE_PUBLIC_URL")
+		if publicURL == "" {
+			port := os.Getenv("PORT")
+			if port == "" {
+				port = "8080"
+			}
+			publicURL = "http://localhost:" + port + FSRoutePrefix
+		}
+		return NewFSStore(FSConfig{
+			Root:      os.Getenv("FS_STORAGE_ROOT"),
+			PublicURL: publicURL,
+			Secret:    os.Getenv("FS_STORAGE_SECRET"),
+		})
+	case BackendAzure:
+		return NewAzureBlobStore(bucket)
+	default:
+		return nil, fmt.Errorf("OBJECT_STORAGE inconnu: %s (s3, fs, azure)", backend)
+	}
+}
@@ -3,7 +3,9 @@ package subscribers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
+	"time"
 
 	"github.com/Mathis-brgs/storm-project/services/media/internal/service"
 	"github.com/nats-io/nats.go"
@@ -11,19 +13,73 @@ import (
 
 const respondErrorLogFormat = "nats respond error: %v"
 
+// Codes d'erreur (ErrorResponse.Code), alignés sur ceux du message-service.
+const (
+	errorCodeForbidden  = "FORBIDDEN"
+	errorCodeNotFound   = "NOT_FOUND"
+	errorCodeBadRequest = "BAD_REQUEST"
+	// errorCodeScanPending et errorCodeQuarantined : téléchargement bloqué par l'analyse antivirus.
+	errorCodeScanPending = "SCAN_PENDING"
+	errorCodeQuarantined = "QUARANTINED"
+)
+
+// UploadRequest : ownerId est l'utilisateur authentifié (renseigné par le gateway),
+// conversationId (optionnel) la conversation à laquelle le média est destiné, voice une note vocale.
 type UploadRequest struct {
+	Filename       string `json:"filename"`
+	ContentType    string `json:"contentType"`
+	Size           int64  `json:"size"`
+	DataBase64     string `json:"dataBase64"`
+	OwnerID        string `json:"ownerId"`
+	ConversationID int    `json:"conversationId"`
+	Voice          bool   `json:"voice"`
+}
+
+type DeleteRequest struct {
+	MediaID     string `json:"mediaId"`
+	RequesterID string `json:"requesterId"`
+}
+
+// URLRequest : URL de téléchargement signée pour requesterId (propriétaire ou membre de la conversation),
+// de l'original ou d'une variante (« thumbnail », « medium »).
+type URLRequest struct {
+	MediaID     string `json:"mediaId"`
+	RequesterID string `json:"requesterId"`
+	Variant     string `json:"variant"`
+}
+
+// GetRequest : métadonnées du média et de ses variantes, avec URLs signées.
+type GetRequest struct {
+	MediaID     string `json:"mediaId"`
+	RequesterID string `json:"requesterId"`
+}
+
+// PurgeRequest : suppression d'un média de la conversation purgée, réservée au job de purge du message-service.
+type PurgeRequest struct {
+	MediaID        string `json:"mediaId"`
+	ConversationID int    `json:"conversationId"`
+}
+
+// PresignRequest : fichier à envoyer directement au stockage (type et taille signés).
+type PresignRequest struct {
 	Filename    string `json:"filename"`
 	ContentType string `json:"contentType"`
 	Size        int64  `json:"size"`
-	DataBase64  string `json:"dataBase64"`
+	OwnerID     string `json:"ownerId"`
+	Voice       bool   `json:"voice"`
 }
 
-type DeleteRequest struct {
-	MediaID string `json:"mediaId"`
+// CompleteRequest : media ID en attente retourné par media.upload.presign (voice comme au presign).
+type CompleteRequest struct {
+	MediaID        string `json:"mediaId"`
+	OwnerID        string `json:"ownerId"`
+	ConversationID int    `json:"conversationId"`
+	Voice          bool   `json:"voice"`
 }
 
 type ErrorResponse struct {
 	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
 }
 
 func StartMediaSubscribers(nc *nats.Conn, mediaService *service.MediaService) error {
@@ -39,7 +95,44 @@ func StartMediaSubscribers(nc *nats.Conn, mediaService *service.MediaService) er
 		return err
 	}
 
-	return nil
+	if _, err := nc.QueueSubscribe("media.url.requested", "media", func(msg *nats.Msg) {
+		handleURL(msg, mediaService)
+	}); err != nil {
+		return err
+	}
+
+	if _, err := nc.QueueSubscribe("media.get", "media", func(msg *nats.Msg) {
+		handleGet(msg, mediaService)
+	}); err != nil {
+		return err
+	}
+
+	if _, err := nc.QueueSubscribe("media.purge.requested", "media", func(msg *nats.Msg) {
+		handlePurge(msg, mediaService)
+	}); err != nil {
+		return err
+	}
+
+	// Rapport du ramasse-miettes (dry-run : rien n'est supprimé), réservé aux services internes.
+	if _, err := nc.QueueSubscribe("media.gc.report", "media", func(msg *nats.Msg) {
+		handleGCReport(msg, mediaService)
+	}); err != nil {
+		return err
+	}
+
+	if _, err := nc.QueueSubscribe("media.upload.presign", "media", func(msg *nats.Msg) {
+		handlePresign(msg, mediaService)
+	}); err != nil {
+		return err
+	}
+
+	if _, err := nc.QueueSubscribe("media.upload.complete", "media", func(msg *nats.Msg) {
+		handleComplete(msg, mediaService)
+	}); err != nil {
+		return err
+	}
+
+	return startSessionSubscribers(nc, mediaService)
 }
 
 func handleUpload(msg *nats.Msg, mediaService *service.MediaService) {
@@ -50,13 +143,16 @@ func handleUpload(msg *nats.Msg, mediaService *service.MediaService) {
 	}
 
 	resp, err := mediaService.Upload(context.Background(), service.UploadRequest{
-		Filename:    req.Filename,
-		ContentType: req.ContentType,
-		Size:        req.Size,
-		DataBase64:  req.DataBase64,
+		Filename:       req.Filename,
+		ContentType:    req.ContentType,
+		Size:           req.Size,
+		DataBase64:     req.DataBase64,
+		OwnerID:        req.OwnerID,
+		ConversationID: req.ConversationID,
+		Voice:          req.Voice,
 	})
 	if err != nil {
-		respondError(msg, err.Error())
+		respondServiceError(msg, err)
 		return
 	}
 
@@ -73,8 +169,8 @@ func handleDelete(msg *nats.Msg, mediaService *service.MediaService) {
 		return
 	}
 
-	if err := mediaService.Delete(context.Background(), req.MediaID); err != nil {
-		respondError(msg, err.Error())
+	if err := mediaService.Delete(context.Background(), req.RequesterID, req.MediaID); err != nil {
+		respondServiceError(msg, err)
 		return
 	}
 
@@ -84,8 +180,145 @@ func handleDelete(msg *nats.Msg, mediaService *service.MediaService) {
 	}
 }
 
+func handleURL(msg *nats.Msg, mediaService *service.MediaService) {
+	var req URLRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		respondError(msg, "invalid json")
+		return
+	}
+
+	resp, err := mediaService.GetURL(context.Background(), req.RequesterID, req.MediaID, req.Variant)
+	if err != nil {
+		respondServiceError(msg, err)
+		return
+	}
+
+	payload, _ := json.Marshal(resp)
+	if err := msg.Respond(payload); err != nil {
+		log.Printf(respondErrorLogFormat, err)
+	}
+}
+
+func handleGet(msg *nats.Msg, mediaService *service.MediaService) {
+	var req GetRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		respondError(msg, "invalid json")
+		return
+	}
+
+	resp, err := mediaService.GetMedia(context.Background(), req.RequesterID, req.MediaID)
+	if err != nil {
+		respondServiceError(msg, err)
+		return
+	}
+
+	payload, _ := json.Marshal(resp)
+	if err := msg.Respond(payload); err != nil {
+		log.Printf(respondErrorLogFormat, err)
+	}
+}
+
+func handlePurge(msg *nats.Msg, mediaService *service.MediaService) {
+	var req PurgeRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		respondError(msg, "invalid json")
+		return
+	}
+
+	if err := mediaService.Purge(context.Background(), req.ConversationID, req.MediaID); err != nil {
+		respondServiceError(msg, err)
+		return
+	}
+
+	payload, _ := json.Marshal(map[string]string{"status": "deleted"})
+	if err := msg.Respond(payload); err != nil {
+		log.Printf(respondErrorLogFormat, err)
+	}
+}
+
+func handleGCReport(msg *nats.Msg, mediaService *service.MediaService) {
+	resp, err := mediaService.CollectGarbage(context.Background(), time.Now(), true)
+	if err != nil {
+		respondServiceError(msg, err)
+		return
+	}
+
+	payload, _ := json.Marshal(resp)
+	if err := msg.Respond(payload); err != nil {
+		log.Printf(respondErrorLogFormat, err)
+	}
+}
+
+func handlePresign(msg *nats.Msg, mediaService *service.MediaService) {
+	var req PresignRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		respondError(msg, "invalid json")
+		return
+	}
+
+	resp, err := mediaService.PresignUpload(context.Background(), service.PresignRequest{
+		Filename:    req.Filename,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+		OwnerID:     req.OwnerID,
+		Voice:       req.Voice,
+	})
+	if err != nil {
+		respondServiceError(msg, err)
+		return
+	}
+
+	payload, _ := json.Marshal(resp)
+	if err := msg.Respond(payload); err != nil {
+		log.Printf(respondErrorLogFormat, err)
+	}
+}
+
+func handleComplete(msg *nats.Msg, mediaService *service.MediaService) {
+	var req CompleteRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		respondError(msg, "invalid json")
+		return
+	}
+
+	resp, err := mediaService.CompleteUpload(context.Background(), req.OwnerID, req.ConversationID, req.MediaID, req.Voice)
+	if err != nil {
+		respondServiceError(msg, err)
+		return
+	}
+
+	payload, _ := json.Marshal(resp)
+	if err := msg.Respond(payload); err != nil {
+		log.Printf(respondErrorLogFormat, err)
+	}
+}
+
 func respondError(msg *nats.Msg, errMsg string) {
-	payload, _ := json.Marshal(ErrorResponse{Error: errMsg})
+	respondErrorResponse(msg, ErrorResponse{Error: errMsg})
+}
+
+// respondServiceError ajoute le code des erreurs d'accès, que le gateway traduit en 403/404 (409/423 pour
+// un média en cours d'analyse ou en quarantaine).
+func respondServiceError(msg *nats.Msg, err error) {
+	resp := ErrorResponse{Error: err.Error()}
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		resp.Code = errorCodeForbidden
+	case errors.Is(err, service.ErrMediaNotFound), errors.Is(err, service.ErrUploadNotFound), errors.Is(err, service.ErrVariantNotFound),
+		errors.Is(err, service.ErrSessionNotFound):
+		resp.Code = errorCodeNotFound
+	case errors.Is(err, service.ErrInvalidUpload):
+		resp.Code = errorCodeBadRequest
+	case errors.Is(err, service.ErrScanPending):
+		resp.Code = errorCodeScanPending
+	case errors.Is(err, service.ErrMediaQuarantined):
+		resp.Code = errorCodeQuarantined
+	}
+	respondErrorResponse(msg, resp)
+}
+
+func respondErrorResponse(msg *nats.Msg, resp ErrorResponse) {
+	payload, _ := json.Marshal(resp)
 	if err := msg.Respond(payload); err != nil {
 		log.Printf(respondErrorLogFormat, err)
 	}
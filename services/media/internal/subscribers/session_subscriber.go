@@ -0,0 +1,125 @@
+package subscribers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/Mathis-brgs/storm-project/services/media/internal/service"
+	"github.com/nats-io/nats.go"
+)
+
+// Uploads reprenables (gros fichiers) : session, parties envoyées directement au stockage, finalisation.
+const (
+	subjectSessionCreate   = "media.upload.session.create"
+	subjectSessionStatus   = "media.upload.session.status"
+	subjectSessionChunk    = "media.upload.session.chunk"
+	subjectSessionComplete = "media.upload.session.complete"
+	subjectSessionAbort    = "media.upload.session.abort"
+)
+
+// SessionCreateRequest : fichier à envoyer par parties ; ownerId est renseigné par le gateway.
+type SessionCreateRequest struct {
+	Filename       string `json:"filename"`
+	ContentType    string `json:"contentType"`
+	Size           int64  `json:"size"`
+	OwnerID        string `json:"ownerId"`
+	ConversationID int    `json:"conversationId"`
+}
+
+// SessionRequest : session existante, accessible à son seul propriétaire ; offset pour media.upload.session.chunk.
+type SessionRequest struct {
+	UploadID string `json:"uploadId"`
+	OwnerID  string `json:"ownerId"`
+	Offset   int64  `json:"offset"`
+}
+
+func startSessionSubscribers(nc *nats.Conn, mediaService *service.MediaService) error {
+	handlers := map[string]func(*nats.Msg, *service.MediaService){
+		subjectSessionCreate:   handleSessionCreate,
+		subjectSessionStatus:   handleSessionStatus,
+		subjectSessionChunk:    handleSessionChunk,
+		subjectSessionComplete: handleSessionComplete,
+		subjectSessionAbort:    handleSessionAbort,
+	}
+	for subject, handle := range handlers {
+		if _, err := nc.QueueSubscribe(subject, "media", func(msg *nats.Msg) {
+			handle(msg, mediaService)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func handleSessionCreate(msg *nats.Msg, mediaService *service.MediaService) {
+	var req SessionCreateRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		respondError(msg, "invalid json")
+		return
+	}
+
+	resp, err := mediaService.CreateUploadSession(context.Background(), service.SessionRequest{
+		Filename:       req.Filename,
+		ContentType:    req.ContentType,
+		Size:           req.Size,
+		OwnerID:        req.OwnerID,
+		ConversationID: req.ConversationID,
+	})
+	respondResult(msg, resp, err)
+}
+
+func handleSessionStatus(msg *nats.Msg, mediaService *service.MediaService) {
+	var req SessionRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		respondError(msg, "invalid json")
+		return
+	}
+
+	resp, err := mediaService.GetUploadSession(context.Background(), req.OwnerID, req.UploadID)
+	respondResult(msg, resp, err)
+}
+
+func handleSessionChunk(msg *nats.Msg, mediaService *service.MediaService) {
+	var req SessionRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		respondError(msg, "invalid json")
+		return
+	}
+
+	resp, err := mediaService.PresignChunk(context.Background(), req.OwnerID, req.UploadID, req.Offset)
+	respondResult(msg, resp, err)
+}
+
+func handleSessionComplete(msg *nats.Msg, mediaService *service.MediaService) {
+	var req SessionRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		respondError(msg, "invalid json")
+		return
+	}
+
+	resp, err := mediaService.CompleteUploadSession(context.Background(), req.OwnerID, req.UploadID)
+	respondResult(msg, resp, err)
+}
+
+func handleSessionAbort(msg *nats.Msg, mediaService *service.MediaService) {
+	var req SessionRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		respondError(msg, "invalid json")
+		return
+	}
+
+	err := mediaService.AbortUploadSession(context.Background(), req.OwnerID, req.UploadID)
+	respondResult(msg, map[string]string{"status": "aborted"}, err)
+}
+
+func respondResult(msg *nats.Msg, resp any, err error) {
+	if err != nil {
+		respondServiceError(msg, err)
+		return
+	}
+	payload, _ := json.Marshal(resp)
+	if err := msg.Respond(payload); err != nil {
+		log.Printf(respondErrorLogFormat, err)
+	}
+}
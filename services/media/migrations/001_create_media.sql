@@ -0,0 +1,15 @@
+-- Migration 001: registre des métadonnées média.
+-- id est la clé objet dans le bucket (media/<nanos>_<nom>).
+
+CREATE TABLE IF NOT EXISTS media (
+    id              TEXT PRIMARY KEY,
+    owner_id        UUID NOT NULL,
+    conversation_id INTEGER,
+    content_type    VARCHAR(100) NOT NULL,
+    size            BIGINT NOT NULL CHECK (size >= 0),
+    checksum        VARCHAR(128) NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_owner ON media (owner_id);
+CREATE INDEX IF NOT EXISTS idx_media_conversation ON media (conversation_id) WHERE conversation_id IS NOT NULL;
@@ -0,0 +1,5 @@
+-- Migration 002: variantes d'image (miniature, moyenne) générées en tâche de fond.
+-- processing_status : '' (pas de variantes), 'processing', 'ready' ou 'failed'.
+
+ALTER TABLE media ADD COLUMN IF NOT EXISTS processing_status VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE media ADD COLUMN IF NOT EXISTS variants JSONB NOT NULL DEFAULT '[]'::jsonb;
@@ -0,0 +1,19 @@
+-- Migration 003: déduplication par contenu.
+-- Chaque upload crée une référence (media) vers un objet adressé par son SHA-256 (object_key),
+-- partagé par les uploads identiques ; media_objects compte les références de chaque objet.
+-- Les médias existants gardent leur objet (clé = id), avec une référence chacun.
+
+ALTER TABLE media ADD COLUMN IF NOT EXISTS object_key TEXT;
+UPDATE media SET object_key = id WHERE object_key IS NULL;
+ALTER TABLE media ALTER COLUMN object_key SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_media_object_key ON media (object_key);
+
+CREATE TABLE IF NOT EXISTS media_objects (
+    object_key TEXT PRIMARY KEY,
+    ref_count  INTEGER NOT NULL CHECK (ref_count >= 0)
+);
+
+INSERT INTO media_objects (object_key, ref_count)
+SELECT object_key, COUNT(*) FROM media GROUP BY object_key
+ON CONFLICT (object_key) DO NOTHING;
@@ -0,0 +1,20 @@
+-- Migration 004: uploads reprenables (gros fichiers) sur upload multipart S3.
+-- object_key : objet en attente (pending/...) assemblé à la finalisation ; media_id renseigné une fois
+-- le média enregistré. Les sessions expirées sont annulées puis supprimées par le media-service.
+
+CREATE TABLE IF NOT EXISTS upload_sessions (
+    id                TEXT PRIMARY KEY,
+    owner_id          UUID NOT NULL,
+    conversation_id   INTEGER,
+    filename          TEXT NOT NULL,
+    content_type      VARCHAR(100) NOT NULL,
+    size              BIGINT NOT NULL CHECK (size > 0),
+    chunk_size        BIGINT NOT NULL CHECK (chunk_size > 0),
+    object_key        TEXT NOT NULL,
+    storage_upload_id TEXT NOT NULL,
+    media_id          TEXT NOT NULL DEFAULT '',
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at        TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_upload_sessions_expires ON upload_sessions (expires_at);
@@ -0,0 +1,15 @@
+-- Migration 005: catégorie (image, video, audio, voice, document), nom d'origine, durée (audio, notes
+-- vocales) et forme d'onde (notes vocales, amplitudes 0-100).
+
+ALTER TABLE media ADD COLUMN IF NOT EXISTS category VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE media ADD COLUMN IF NOT EXISTS filename TEXT NOT NULL DEFAULT '';
+ALTER TABLE media ADD COLUMN IF NOT EXISTS duration_ms BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE media ADD COLUMN IF NOT EXISTS waveform JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+-- Médias antérieurs : seules les images et les vidéos étaient acceptées.
+UPDATE media SET category = CASE
+    WHEN content_type LIKE 'image/%' THEN 'image'
+    WHEN content_type LIKE 'video/%' THEN 'video'
+    ELSE ''
+END
+WHERE category = '';
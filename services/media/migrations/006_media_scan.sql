@@ -0,0 +1,6 @@
+-- Migration 006: analyse antivirus des médias avant téléchargement.
+-- scan_status : '' (non analysé : scanner absent ou média antérieur), 'pending', 'clean', 'infected'
+-- (quarantaine, scan_signature = signature détectée) ou 'failed'. Seuls '' et 'clean' sont téléchargeables.
+
+ALTER TABLE media ADD COLUMN IF NOT EXISTS scan_status VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE media ADD COLUMN IF NOT EXISTS scan_signature TEXT NOT NULL DEFAULT '';
@@ -253,6 +253,12 @@ type ChatMessage struct {
 	ForwardFromId  int32                  `protobuf:"varint,12,opt,name=forward_from_id,json=forwardFromId,proto3" json:"forward_from_id,omitempty"` // optionnel (0 = absent)
 	ReplyTo        *ReplyToRef            `protobuf:"bytes,13,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`                      // rempli en liste si reply_to_id présent
 	SeenBy         []*SeenByEntry         `protobuf:"bytes,14,rep,name=seen_by,json=seenBy,proto3" json:"seen_by,omitempty"`
+	Mentions       []string               `protobuf:"bytes,15,rep,name=mentions,proto3" json:"mentions,omitempty"`                                   // UUID des membres mentionnés (@username)
+	LinkPreview    *LinkPreview           `protobuf:"bytes,16,opt,name=link_preview,json=linkPreview,proto3" json:"link_preview,omitempty"`          // aperçu du premier lien (ajouté en asynchrone)
+	PollId         int32                  `protobuf:"varint,17,opt,name=poll_id,json=pollId,proto3" json:"poll_id,omitempty"`                        // sondage porté par le message (0 = aucun)
+	Kind           string                 `protobuf:"bytes,18,opt,name=kind,proto3" json:"kind,omitempty"`                                           // "user" | "system"
+	System         *SystemEvent           `protobuf:"bytes,19,opt,name=system,proto3" json:"system,omitempty"`                                       // renseigné pour kind = "system"
+	AttachmentInfo *AttachmentInfo        `protobuf:"bytes,20,opt,name=attachment_info,json=attachmentInfo,proto3" json:"attachment_info,omitempty"` // métadonnées de la pièce jointe (absent si non résolue)
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -385,6 +391,303 @@ func (x *ChatMessage) GetSeenBy() []*SeenByEntry {
 	return nil
 }
 
+func (x *ChatMessage) GetMentions() []string {
+	if x != nil {
+		return x.Mentions
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetLinkPreview() *LinkPreview {
+	if x != nil {
+		return x.LinkPreview
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetPollId() int32 {
+	if x != nil {
+		return x.PollId
+	}
+	return 0
+}
+
+func (x *ChatMessage) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetSystem() *SystemEvent {
+	if x != nil {
+		return x.System
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetAttachmentInfo() *AttachmentInfo {
+	if x != nil {
+		return x.AttachmentInfo
+	}
+	return nil
+}
+
+// AttachmentInfo : pièce jointe telle que décrite par le media-service à l'envoi.
+type AttachmentInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MediaId       string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3" json:"media_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // image | video | audio | voice | document
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Filename      string                 `protobuf:"bytes,4,opt,name=filename,proto3" json:"filename,omitempty"`
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`                               // octets
+	DurationMs    int64                  `protobuf:"varint,6,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"` // audio et notes vocales
+	Waveform      []int32                `protobuf:"varint,7,rep,packed,name=waveform,proto3" json:"waveform,omitempty"`                // notes vocales : amplitudes 0-100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentInfo) Reset() {
+	*x = AttachmentInfo{}
+	mi := &file_api_v1_message_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentInfo) ProtoMessage() {}
+
+func (x *AttachmentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentInfo.ProtoReflect.Descriptor instead.
+func (*AttachmentInfo) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AttachmentInfo) GetMediaId() string {
+	if x != nil {
+		return x.MediaId
+	}
+	return ""
+}
+
+func (x *AttachmentInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AttachmentInfo) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *AttachmentInfo) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *AttachmentInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *AttachmentInfo) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *AttachmentInfo) GetWaveform() []int32 {
+	if x != nil {
+		return x.Waveform
+	}
+	return nil
+}
+
+// SystemEvent : événement de conversation porté par un message système.
+type SystemEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                         // member_added | member_removed | member_left | member_joined | role_changed | conversation_renamed
+	ActorId       string                 `protobuf:"bytes,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`    // UUID
+	TargetId      string                 `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"` // UUID du membre concerné (vide si sans objet)
+	Role          int32                  `protobuf:"varint,4,opt,name=role,proto3" json:"role,omitempty"`                        // rôle attribué (member_added, role_changed)
+	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`                         // nouveau nom (conversation_renamed)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemEvent) Reset() {
+	*x = SystemEvent{}
+	mi := &file_api_v1_message_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemEvent) ProtoMessage() {}
+
+func (x *SystemEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemEvent.ProtoReflect.Descriptor instead.
+func (*SystemEvent) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SystemEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SystemEvent) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *SystemEvent) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *SystemEvent) GetRole() int32 {
+	if x != nil {
+		return x.Role
+	}
+	return 0
+}
+
+func (x *SystemEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// LinkPreview : métadonnées OpenGraph/HTML d'un lien.
+type LinkPreview struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,4,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	SiteName      string                 `protobuf:"bytes,5,opt,name=site_name,json=siteName,proto3" json:"site_name,omitempty"`
+	FetchedAt     int64                  `protobuf:"varint,6,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkPreview) Reset() {
+	*x = LinkPreview{}
+	mi := &file_api_v1_message_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkPreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkPreview) ProtoMessage() {}
+
+func (x *LinkPreview) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkPreview.ProtoReflect.Descriptor instead.
+func (*LinkPreview) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LinkPreview) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetSiteName() string {
+	if x != nil {
+		return x.SiteName
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetFetchedAt() int64 {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return 0
+}
+
 // Error dans la réponse
 type Error struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -396,7 +699,7 @@ type Error struct {
 
 func (x *Error) Reset() {
 	*x = Error{}
-	mi := &file_api_v1_message_proto_msgTypes[4]
+	mi := &file_api_v1_message_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -408,7 +711,7 @@ func (x *Error) String() string {
 func (*Error) ProtoMessage() {}
 
 func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[4]
+	mi := &file_api_v1_message_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -421,7 +724,7 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Error.ProtoReflect.Descriptor instead.
 func (*Error) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{4}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Error) GetCode() string {
@@ -450,7 +753,7 @@ type SendMessageResponse struct {
 
 func (x *SendMessageResponse) Reset() {
 	*x = SendMessageResponse{}
-	mi := &file_api_v1_message_proto_msgTypes[5]
+	mi := &file_api_v1_message_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -462,7 +765,7 @@ func (x *SendMessageResponse) String() string {
 func (*SendMessageResponse) ProtoMessage() {}
 
 func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[5]
+	mi := &file_api_v1_message_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -475,7 +778,7 @@ func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendMessageResponse.ProtoReflect.Descriptor instead.
 func (*SendMessageResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{5}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *SendMessageResponse) GetOk() bool {
@@ -509,7 +812,7 @@ type GetMessageRequest struct {
 
 func (x *GetMessageRequest) Reset() {
 	*x = GetMessageRequest{}
-	mi := &file_api_v1_message_proto_msgTypes[6]
+	mi := &file_api_v1_message_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -521,7 +824,7 @@ func (x *GetMessageRequest) String() string {
 func (*GetMessageRequest) ProtoMessage() {}
 
 func (x *GetMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[6]
+	mi := &file_api_v1_message_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -534,7 +837,7 @@ func (x *GetMessageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMessageRequest.ProtoReflect.Descriptor instead.
 func (*GetMessageRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{6}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetMessageRequest) GetId() int32 {
@@ -556,7 +859,7 @@ type GetMessageResponse struct {
 
 func (x *GetMessageResponse) Reset() {
 	*x = GetMessageResponse{}
-	mi := &file_api_v1_message_proto_msgTypes[7]
+	mi := &file_api_v1_message_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -568,7 +871,7 @@ func (x *GetMessageResponse) String() string {
 func (*GetMessageResponse) ProtoMessage() {}
 
 func (x *GetMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[7]
+	mi := &file_api_v1_message_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -581,7 +884,7 @@ func (x *GetMessageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMessageResponse.ProtoReflect.Descriptor instead.
 func (*GetMessageResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{7}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetMessageResponse) GetOk() bool {
@@ -619,7 +922,7 @@ type ListMessagesRequest struct {
 
 func (x *ListMessagesRequest) Reset() {
 	*x = ListMessagesRequest{}
-	mi := &file_api_v1_message_proto_msgTypes[8]
+	mi := &file_api_v1_message_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -631,7 +934,7 @@ func (x *ListMessagesRequest) String() string {
 func (*ListMessagesRequest) ProtoMessage() {}
 
 func (x *ListMessagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[8]
+	mi := &file_api_v1_message_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -644,7 +947,7 @@ func (x *ListMessagesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListMessagesRequest.ProtoReflect.Descriptor instead.
 func (*ListMessagesRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{8}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ListMessagesRequest) GetGroupId() int32 {
@@ -695,7 +998,7 @@ type ListMessagesResponse struct {
 
 func (x *ListMessagesResponse) Reset() {
 	*x = ListMessagesResponse{}
-	mi := &file_api_v1_message_proto_msgTypes[9]
+	mi := &file_api_v1_message_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -707,7 +1010,7 @@ func (x *ListMessagesResponse) String() string {
 func (*ListMessagesResponse) ProtoMessage() {}
 
 func (x *ListMessagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[9]
+	mi := &file_api_v1_message_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -720,7 +1023,7 @@ func (x *ListMessagesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListMessagesResponse.ProtoReflect.Descriptor instead.
 func (*ListMessagesResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{9}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ListMessagesResponse) GetOk() bool {
@@ -763,7 +1066,7 @@ type UpdateMessageRequest struct {
 
 func (x *UpdateMessageRequest) Reset() {
 	*x = UpdateMessageRequest{}
-	mi := &file_api_v1_message_proto_msgTypes[10]
+	mi := &file_api_v1_message_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -775,7 +1078,7 @@ func (x *UpdateMessageRequest) String() string {
 func (*UpdateMessageRequest) ProtoMessage() {}
 
 func (x *UpdateMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[10]
+	mi := &file_api_v1_message_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -788,7 +1091,7 @@ func (x *UpdateMessageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateMessageRequest.ProtoReflect.Descriptor instead.
 func (*UpdateMessageRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{10}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *UpdateMessageRequest) GetId() int32 {
@@ -824,7 +1127,7 @@ type UpdateMessageResponse struct {
 
 func (x *UpdateMessageResponse) Reset() {
 	*x = UpdateMessageResponse{}
-	mi := &file_api_v1_message_proto_msgTypes[11]
+	mi := &file_api_v1_message_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -836,7 +1139,7 @@ func (x *UpdateMessageResponse) String() string {
 func (*UpdateMessageResponse) ProtoMessage() {}
 
 func (x *UpdateMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[11]
+	mi := &file_api_v1_message_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -849,7 +1152,7 @@ func (x *UpdateMessageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateMessageResponse.ProtoReflect.Descriptor instead.
 func (*UpdateMessageResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{11}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *UpdateMessageResponse) GetOk() bool {
@@ -884,7 +1187,7 @@ type DeleteMessageRequest struct {
 
 func (x *DeleteMessageRequest) Reset() {
 	*x = DeleteMessageRequest{}
-	mi := &file_api_v1_message_proto_msgTypes[12]
+	mi := &file_api_v1_message_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -896,7 +1199,7 @@ func (x *DeleteMessageRequest) String() string {
 func (*DeleteMessageRequest) ProtoMessage() {}
 
 func (x *DeleteMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[12]
+	mi := &file_api_v1_message_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -909,7 +1212,7 @@ func (x *DeleteMessageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteMessageRequest.ProtoReflect.Descriptor instead.
 func (*DeleteMessageRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{12}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *DeleteMessageRequest) GetId() int32 {
@@ -937,7 +1240,7 @@ type DeleteMessageResponse struct {
 
 func (x *DeleteMessageResponse) Reset() {
 	*x = DeleteMessageResponse{}
-	mi := &file_api_v1_message_proto_msgTypes[13]
+	mi := &file_api_v1_message_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -949,7 +1252,7 @@ func (x *DeleteMessageResponse) String() string {
 func (*DeleteMessageResponse) ProtoMessage() {}
 
 func (x *DeleteMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[13]
+	mi := &file_api_v1_message_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -962,7 +1265,7 @@ func (x *DeleteMessageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteMessageResponse.ProtoReflect.Descriptor instead.
 func (*DeleteMessageResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{13}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *DeleteMessageResponse) GetOk() bool {
@@ -991,7 +1294,7 @@ type AckMessageRequest struct {
 
 func (x *AckMessageRequest) Reset() {
 	*x = AckMessageRequest{}
-	mi := &file_api_v1_message_proto_msgTypes[14]
+	mi := &file_api_v1_message_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1003,7 +1306,7 @@ func (x *AckMessageRequest) String() string {
 func (*AckMessageRequest) ProtoMessage() {}
 
 func (x *AckMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[14]
+	mi := &file_api_v1_message_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1016,7 +1319,7 @@ func (x *AckMessageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AckMessageRequest.ProtoReflect.Descriptor instead.
 func (*AckMessageRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{14}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *AckMessageRequest) GetId() int32 {
@@ -1052,7 +1355,7 @@ type AckMessageResponse struct {
 
 func (x *AckMessageResponse) Reset() {
 	*x = AckMessageResponse{}
-	mi := &file_api_v1_message_proto_msgTypes[15]
+	mi := &file_api_v1_message_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1064,7 +1367,7 @@ func (x *AckMessageResponse) String() string {
 func (*AckMessageResponse) ProtoMessage() {}
 
 func (x *AckMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[15]
+	mi := &file_api_v1_message_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1077,7 +1380,7 @@ func (x *AckMessageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AckMessageResponse.ProtoReflect.Descriptor instead.
 func (*AckMessageResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{15}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *AckMessageResponse) GetOk() bool {
@@ -1110,13 +1413,17 @@ type Group struct {
 	CreatedBy     string                 `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"` // UUID
 	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     int64                  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Kind          string                 `protobuf:"bytes,7,opt,name=kind,proto3" json:"kind,omitempty"`                   // "group" | "direct"
+	PeerId        string                 `protobuf:"bytes,8,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"` // UUID de l'autre participant (kind = "direct", relatif à l'acteur)
+	Description   string                 `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	Permissions   *GroupPermissions      `protobuf:"bytes,10,opt,name=permissions,proto3" json:"permissions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Group) Reset() {
 	*x = Group{}
-	mi := &file_api_v1_message_proto_msgTypes[16]
+	mi := &file_api_v1_message_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1128,7 +1435,7 @@ func (x *Group) String() string {
 func (*Group) ProtoMessage() {}
 
 func (x *Group) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[16]
+	mi := &file_api_v1_message_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1141,7 +1448,7 @@ func (x *Group) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Group.ProtoReflect.Descriptor instead.
 func (*Group) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{16}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *Group) GetId() int32 {
@@ -1186,6 +1493,111 @@ func (x *Group) GetUpdatedAt() int64 {
 	return 0
 }
 
+func (x *Group) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Group) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *Group) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Group) GetPermissions() *GroupPermissions {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+// GroupPermissions : réglages de la conversation (GROUP_UPDATE_PERMISSIONS, owner).
+type GroupPermissions struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	OnlyAdminsCanPost    bool                   `protobuf:"varint,1,opt,name=only_admins_can_post,json=onlyAdminsCanPost,proto3" json:"only_admins_can_post,omitempty"`
+	MembersCanAddMembers bool                   `protobuf:"varint,2,opt,name=members_can_add_members,json=membersCanAddMembers,proto3" json:"members_can_add_members,omitempty"`
+	MembersCanPin        bool                   `protobuf:"varint,3,opt,name=members_can_pin,json=membersCanPin,proto3" json:"members_can_pin,omitempty"`
+	SlowModeSeconds      int32                  `protobuf:"varint,4,opt,name=slow_mode_seconds,json=slowModeSeconds,proto3" json:"slow_mode_seconds,omitempty"`             // 0 = désactivé
+	JoinRequestsEnabled  bool                   `protobuf:"varint,5,opt,name=join_requests_enabled,json=joinRequestsEnabled,proto3" json:"join_requests_enabled,omitempty"` // GROUP_JOIN_REQUEST accepté (désactivé par défaut)
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GroupPermissions) Reset() {
+	*x = GroupPermissions{}
+	mi := &file_api_v1_message_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupPermissions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupPermissions) ProtoMessage() {}
+
+func (x *GroupPermissions) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupPermissions.ProtoReflect.Descriptor instead.
+func (*GroupPermissions) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GroupPermissions) GetOnlyAdminsCanPost() bool {
+	if x != nil {
+		return x.OnlyAdminsCanPost
+	}
+	return false
+}
+
+func (x *GroupPermissions) GetMembersCanAddMembers() bool {
+	if x != nil {
+		return x.MembersCanAddMembers
+	}
+	return false
+}
+
+func (x *GroupPermissions) GetMembersCanPin() bool {
+	if x != nil {
+		return x.MembersCanPin
+	}
+	return false
+}
+
+func (x *GroupPermissions) GetSlowModeSeconds() int32 {
+	if x != nil {
+		return x.SlowModeSeconds
+	}
+	return 0
+}
+
+func (x *GroupPermissions) GetJoinRequestsEnabled() bool {
+	if x != nil {
+		return x.JoinRequestsEnabled
+	}
+	return false
+}
+
 // GroupMember représente un membership user <-> group.
 type GroupMember struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -1201,7 +1613,7 @@ type GroupMember struct {
 
 func (x *GroupMember) Reset() {
 	*x = GroupMember{}
-	mi := &file_api_v1_message_proto_msgTypes[17]
+	mi := &file_api_v1_message_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1213,7 +1625,7 @@ func (x *GroupMember) String() string {
 func (*GroupMember) ProtoMessage() {}
 
 func (x *GroupMember) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[17]
+	mi := &file_api_v1_message_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1226,7 +1638,7 @@ func (x *GroupMember) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupMember.ProtoReflect.Descriptor instead.
 func (*GroupMember) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{17}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *GroupMember) GetId() int32 {
@@ -1282,7 +1694,7 @@ type GroupCreateRequest struct {
 
 func (x *GroupCreateRequest) Reset() {
 	*x = GroupCreateRequest{}
-	mi := &file_api_v1_message_proto_msgTypes[18]
+	mi := &file_api_v1_message_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1294,7 +1706,7 @@ func (x *GroupCreateRequest) String() string {
 func (*GroupCreateRequest) ProtoMessage() {}
 
 func (x *GroupCreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[18]
+	mi := &file_api_v1_message_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1307,7 +1719,7 @@ func (x *GroupCreateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupCreateRequest.ProtoReflect.Descriptor instead.
 func (*GroupCreateRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{18}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GroupCreateRequest) GetActorId() string {
@@ -1342,7 +1754,7 @@ type GroupCreateResponse struct {
 
 func (x *GroupCreateResponse) Reset() {
 	*x = GroupCreateResponse{}
-	mi := &file_api_v1_message_proto_msgTypes[19]
+	mi := &file_api_v1_message_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1354,7 +1766,7 @@ func (x *GroupCreateResponse) String() string {
 func (*GroupCreateResponse) ProtoMessage() {}
 
 func (x *GroupCreateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[19]
+	mi := &file_api_v1_message_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1367,7 +1779,7 @@ func (x *GroupCreateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupCreateResponse.ProtoReflect.Descriptor instead.
 func (*GroupCreateResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{19}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GroupCreateResponse) GetOk() bool {
@@ -1402,7 +1814,7 @@ type GroupGetRequest struct {
 
 func (x *GroupGetRequest) Reset() {
 	*x = GroupGetRequest{}
-	mi := &file_api_v1_message_proto_msgTypes[20]
+	mi := &file_api_v1_message_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1414,7 +1826,7 @@ func (x *GroupGetRequest) String() string {
 func (*GroupGetRequest) ProtoMessage() {}
 
 func (x *GroupGetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[20]
+	mi := &file_api_v1_message_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1427,7 +1839,7 @@ func (x *GroupGetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupGetRequest.ProtoReflect.Descriptor instead.
 func (*GroupGetRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{20}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GroupGetRequest) GetActorId() string {
@@ -1462,7 +1874,7 @@ type GroupGetResponse struct {
 
 func (x *GroupGetResponse) Reset() {
 	*x = GroupGetResponse{}
-	mi := &file_api_v1_message_proto_msgTypes[21]
+	mi := &file_api_v1_message_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1474,7 +1886,7 @@ func (x *GroupGetResponse) String() string {
 func (*GroupGetResponse) ProtoMessage() {}
 
 func (x *GroupGetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[21]
+	mi := &file_api_v1_message_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1487,7 +1899,7 @@ func (x *GroupGetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupGetResponse.ProtoReflect.Descriptor instead.
 func (*GroupGetResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{21}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GroupGetResponse) GetOk() bool {
@@ -1514,13 +1926,14 @@ func (x *GroupGetResponse) GetError() *Error {
 type GroupListForUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID
+	Archived      bool                   `protobuf:"varint,2,opt,name=archived,proto3" json:"archived,omitempty"`          // true : uniquement les conversations archivées par user_id
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GroupListForUserRequest) Reset() {
 	*x = GroupListForUserRequest{}
-	mi := &file_api_v1_message_proto_msgTypes[22]
+	mi := &file_api_v1_message_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1532,7 +1945,7 @@ func (x *GroupListForUserRequest) String() string {
 func (*GroupListForUserRequest) ProtoMessage() {}
 
 func (x *GroupListForUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[22]
+	mi := &file_api_v1_message_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1545,7 +1958,7 @@ func (x *GroupListForUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupListForUserRequest.ProtoReflect.Descriptor instead.
 func (*GroupListForUserRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{22}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *GroupListForUserRequest) GetUserId() string {
@@ -1555,6 +1968,13 @@ func (x *GroupListForUserRequest) GetUserId() string {
 	return ""
 }
 
+func (x *GroupListForUserRequest) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
 type GroupListForUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
@@ -1566,7 +1986,7 @@ type GroupListForUserResponse struct {
 
 func (x *GroupListForUserResponse) Reset() {
 	*x = GroupListForUserResponse{}
-	mi := &file_api_v1_message_proto_msgTypes[23]
+	mi := &file_api_v1_message_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1578,7 +1998,7 @@ func (x *GroupListForUserResponse) String() string {
 func (*GroupListForUserResponse) ProtoMessage() {}
 
 func (x *GroupListForUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[23]
+	mi := &file_api_v1_message_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1591,7 +2011,7 @@ func (x *GroupListForUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupListForUserResponse.ProtoReflect.Descriptor instead.
 func (*GroupListForUserResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{23}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GroupListForUserResponse) GetOk() bool {
@@ -1628,7 +2048,7 @@ type GroupAddMemberRequest struct {
 
 func (x *GroupAddMemberRequest) Reset() {
 	*x = GroupAddMemberRequest{}
-	mi := &file_api_v1_message_proto_msgTypes[24]
+	mi := &file_api_v1_message_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1640,7 +2060,7 @@ func (x *GroupAddMemberRequest) String() string {
 func (*GroupAddMemberRequest) ProtoMessage() {}
 
 func (x *GroupAddMemberRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[24]
+	mi := &file_api_v1_message_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1653,7 +2073,7 @@ func (x *GroupAddMemberRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupAddMemberRequest.ProtoReflect.Descriptor instead.
 func (*GroupAddMemberRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{24}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *GroupAddMemberRequest) GetActorId() string {
@@ -1702,7 +2122,7 @@ type GroupAddMemberResponse struct {
 
 func (x *GroupAddMemberResponse) Reset() {
 	*x = GroupAddMemberResponse{}
-	mi := &file_api_v1_message_proto_msgTypes[25]
+	mi := &file_api_v1_message_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1714,7 +2134,7 @@ func (x *GroupAddMemberResponse) String() string {
 func (*GroupAddMemberResponse) ProtoMessage() {}
 
 func (x *GroupAddMemberResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[25]
+	mi := &file_api_v1_message_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1727,7 +2147,7 @@ func (x *GroupAddMemberResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupAddMemberResponse.ProtoReflect.Descriptor instead.
 func (*GroupAddMemberResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{25}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *GroupAddMemberResponse) GetOk() bool {
@@ -1763,7 +2183,7 @@ type GroupRemoveMemberRequest struct {
 
 func (x *GroupRemoveMemberRequest) Reset() {
 	*x = GroupRemoveMemberRequest{}
-	mi := &file_api_v1_message_proto_msgTypes[26]
+	mi := &file_api_v1_message_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1775,7 +2195,7 @@ func (x *GroupRemoveMemberRequest) String() string {
 func (*GroupRemoveMemberRequest) ProtoMessage() {}
 
 func (x *GroupRemoveMemberRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[26]
+	mi := &file_api_v1_message_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1788,7 +2208,7 @@ func (x *GroupRemoveMemberRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupRemoveMemberRequest.ProtoReflect.Descriptor instead.
 func (*GroupRemoveMemberRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{26}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *GroupRemoveMemberRequest) GetActorId() string {
@@ -1829,7 +2249,7 @@ type GroupRemoveMemberResponse struct {
 
 func (x *GroupRemoveMemberResponse) Reset() {
 	*x = GroupRemoveMemberResponse{}
-	mi := &file_api_v1_message_proto_msgTypes[27]
+	mi := &file_api_v1_message_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1841,7 +2261,7 @@ func (x *GroupRemoveMemberResponse) String() string {
 func (*GroupRemoveMemberResponse) ProtoMessage() {}
 
 func (x *GroupRemoveMemberResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[27]
+	mi := &file_api_v1_message_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1854,7 +2274,7 @@ func (x *GroupRemoveMemberResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupRemoveMemberResponse.ProtoReflect.Descriptor instead.
 func (*GroupRemoveMemberResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{27}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *GroupRemoveMemberResponse) GetOk() bool {
@@ -1882,7 +2302,7 @@ type GroupListMembersRequest struct {
 
 func (x *GroupListMembersRequest) Reset() {
 	*x = GroupListMembersRequest{}
-	mi := &file_api_v1_message_proto_msgTypes[28]
+	mi := &file_api_v1_message_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1894,7 +2314,7 @@ func (x *GroupListMembersRequest) String() string {
 func (*GroupListMembersRequest) ProtoMessage() {}
 
 func (x *GroupListMembersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[28]
+	mi := &file_api_v1_message_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1907,7 +2327,7 @@ func (x *GroupListMembersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupListMembersRequest.ProtoReflect.Descriptor instead.
 func (*GroupListMembersRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{28}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *GroupListMembersRequest) GetActorId() string {
@@ -1942,7 +2362,7 @@ type GroupListMembersResponse struct {
 
 func (x *GroupListMembersResponse) Reset() {
 	*x = GroupListMembersResponse{}
-	mi := &file_api_v1_message_proto_msgTypes[29]
+	mi := &file_api_v1_message_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1954,7 +2374,7 @@ func (x *GroupListMembersResponse) String() string {
 func (*GroupListMembersResponse) ProtoMessage() {}
 
 func (x *GroupListMembersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[29]
+	mi := &file_api_v1_message_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1967,7 +2387,7 @@ func (x *GroupListMembersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupListMembersResponse.ProtoReflect.Descriptor instead.
 func (*GroupListMembersResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{29}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *GroupListMembersResponse) GetOk() bool {
@@ -2004,7 +2424,7 @@ type GroupUpdateRoleRequest struct {
 
 func (x *GroupUpdateRoleRequest) Reset() {
 	*x = GroupUpdateRoleRequest{}
-	mi := &file_api_v1_message_proto_msgTypes[30]
+	mi := &file_api_v1_message_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2016,7 +2436,7 @@ func (x *GroupUpdateRoleRequest) String() string {
 func (*GroupUpdateRoleRequest) ProtoMessage() {}
 
 func (x *GroupUpdateRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[30]
+	mi := &file_api_v1_message_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2029,7 +2449,7 @@ func (x *GroupUpdateRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupUpdateRoleRequest.ProtoReflect.Descriptor instead.
 func (*GroupUpdateRoleRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{30}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *GroupUpdateRoleRequest) GetActorId() string {
@@ -2078,7 +2498,7 @@ type GroupUpdateRoleResponse struct {
 
 func (x *GroupUpdateRoleResponse) Reset() {
 	*x = GroupUpdateRoleResponse{}
-	mi := &file_api_v1_message_proto_msgTypes[31]
+	mi := &file_api_v1_message_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2090,7 +2510,7 @@ func (x *GroupUpdateRoleResponse) String() string {
 func (*GroupUpdateRoleResponse) ProtoMessage() {}
 
 func (x *GroupUpdateRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[31]
+	mi := &file_api_v1_message_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2103,7 +2523,7 @@ func (x *GroupUpdateRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupUpdateRoleResponse.ProtoReflect.Descriptor instead.
 func (*GroupUpdateRoleResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{31}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *GroupUpdateRoleResponse) GetOk() bool {
@@ -2138,7 +2558,7 @@ type GroupLeaveRequest struct {
 
 func (x *GroupLeaveRequest) Reset() {
 	*x = GroupLeaveRequest{}
-	mi := &file_api_v1_message_proto_msgTypes[32]
+	mi := &file_api_v1_message_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2150,7 +2570,7 @@ func (x *GroupLeaveRequest) String() string {
 func (*GroupLeaveRequest) ProtoMessage() {}
 
 func (x *GroupLeaveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[32]
+	mi := &file_api_v1_message_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2163,7 +2583,7 @@ func (x *GroupLeaveRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupLeaveRequest.ProtoReflect.Descriptor instead.
 func (*GroupLeaveRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{32}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *GroupLeaveRequest) GetUserId() string {
@@ -2197,7 +2617,7 @@ type GroupLeaveResponse struct {
 
 func (x *GroupLeaveResponse) Reset() {
 	*x = GroupLeaveResponse{}
-	mi := &file_api_v1_message_proto_msgTypes[33]
+	mi := &file_api_v1_message_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2209,7 +2629,7 @@ func (x *GroupLeaveResponse) String() string {
 func (*GroupLeaveResponse) ProtoMessage() {}
 
 func (x *GroupLeaveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[33]
+	mi := &file_api_v1_message_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2222,7 +2642,7 @@ func (x *GroupLeaveResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupLeaveResponse.ProtoReflect.Descriptor instead.
 func (*GroupLeaveResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{33}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *GroupLeaveResponse) GetOk() bool {
@@ -2250,7 +2670,7 @@ type GroupDeleteRequest struct {
 
 func (x *GroupDeleteRequest) Reset() {
 	*x = GroupDeleteRequest{}
-	mi := &file_api_v1_message_proto_msgTypes[34]
+	mi := &file_api_v1_message_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2262,7 +2682,7 @@ func (x *GroupDeleteRequest) String() string {
 func (*GroupDeleteRequest) ProtoMessage() {}
 
 func (x *GroupDeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[34]
+	mi := &file_api_v1_message_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2275,7 +2695,7 @@ func (x *GroupDeleteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupDeleteRequest.ProtoReflect.Descriptor instead.
 func (*GroupDeleteRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{34}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *GroupDeleteRequest) GetActorId() string {
@@ -2309,7 +2729,7 @@ type GroupDeleteResponse struct {
 
 func (x *GroupDeleteResponse) Reset() {
 	*x = GroupDeleteResponse{}
-	mi := &file_api_v1_message_proto_msgTypes[35]
+	mi := &file_api_v1_message_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2321,7 +2741,7 @@ func (x *GroupDeleteResponse) String() string {
 func (*GroupDeleteResponse) ProtoMessage() {}
 
 func (x *GroupDeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_message_proto_msgTypes[35]
+	mi := &file_api_v1_message_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2334,7 +2754,7 @@ func (x *GroupDeleteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupDeleteResponse.ProtoReflect.Descriptor instead.
 func (*GroupDeleteResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_message_proto_rawDescGZIP(), []int{35}
+	return file_api_v1_message_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *GroupDeleteResponse) GetOk() bool {
@@ -2351,52 +2771,5287 @@ func (x *GroupDeleteResponse) GetError() *Error {
 	return nil
 }
 
-var File_api_v1_message_proto protoreflect.FileDescriptor
+// ScheduledMessage : message programmé (envoyé par le scheduler à send_at).
+type ScheduledMessage struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SenderId       string                 `protobuf:"bytes,2,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,3,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Content        string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	Attachment     string                 `protobuf:"bytes,5,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	ReplyToId      int32                  `protobuf:"varint,6,opt,name=reply_to_id,json=replyToId,proto3" json:"reply_to_id,omitempty"` // optionnel (0 = absent)
+	SendAt         int64                  `protobuf:"varint,7,opt,name=send_at,json=sendAt,proto3" json:"send_at,omitempty"`            // Unix timestamp
+	Status         string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`                           // pending | processing | sent | cancelled | failed
+	MessageId      int32                  `protobuf:"varint,9,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`   // id du message envoyé (0 tant que non envoyé)
+	LastError      string                 `protobuf:"bytes,10,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreatedAt      int64                  `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      int64                  `protobuf:"varint,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
 
-const file_api_v1_message_proto_rawDesc = "" +
-	"\n" +
-	"\x14api/v1/message.proto\x12\n" +
-	"message.v1\"\xf7\x01\n" +
-	"\x12SendMessageRequest\x12\x19\n" +
-	"\bgroup_id\x18\x01 \x01(\x05R\agroupId\x12\x1b\n" +
-	"\tsender_id\x18\x02 \x01(\tR\bsenderId\x12\x18\n" +
-	"\acontent\x18\x03 \x01(\tR\acontent\x12\x1e\n" +
-	"\n" +
-	"attachment\x18\x04 \x01(\tR\n" +
-	"attachment\x12'\n" +
-	"\x0fconversation_id\x18\x05 \x01(\x05R\x0econversationId\x12\x1e\n" +
-	"\vreply_to_id\x18\x06 \x01(\x05R\treplyToId\x12&\n" +
-	"\x0fforward_from_id\x18\a \x01(\x05R\rforwardFromId\"S\n" +
-	"\n" +
-	"ReplyToRef\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1b\n" +
-	"\tsender_id\x18\x02 \x01(\tR\bsenderId\x12\x18\n" +
-	"\acontent\x18\x03 \x01(\tR\acontent\"b\n" +
-	"\vSeenByEntry\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
-	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12\x17\n" +
-	"\aseen_at\x18\x03 \x01(\x03R\x06seenAt\"\xdc\x03\n" +
-	"\vChatMessage\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1b\n" +
-	"\tsender_id\x18\x02 \x01(\tR\bsenderId\x12\x19\n" +
-	"\bgroup_id\x18\x03 \x01(\x05R\agroupId\x12\x18\n" +
-	"\acontent\x18\x04 \x01(\tR\acontent\x12\x1e\n" +
-	"\n" +
-	"attachment\x18\x05 \x01(\tR\n" +
-	"attachment\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1d\n" +
-	"\n" +
-	"updated_at\x18\a \x01(\x03R\tupdatedAt\x12'\n" +
-	"\x0fconversation_id\x18\b \x01(\x05R\x0econversationId\x12\x1f\n" +
-	"\vreceived_at\x18\t \x01(\x03R\n" +
-	"receivedAt\x12\x1e\n" +
-	"\vreply_to_id\x18\n" +
-	" \x01(\x05R\treplyToId\x12\x16\n" +
-	"\x06status\x18\v \x01(\tR\x06status\x12&\n" +
-	"\x0fforward_from_id\x18\f \x01(\x05R\rforwardFromId\x121\n" +
+func (x *ScheduledMessage) Reset() {
+	*x = ScheduledMessage{}
+	mi := &file_api_v1_message_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledMessage) ProtoMessage() {}
+
+func (x *ScheduledMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledMessage.ProtoReflect.Descriptor instead.
+func (*ScheduledMessage) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ScheduledMessage) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ScheduledMessage) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *ScheduledMessage) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetAttachment() string {
+	if x != nil {
+		return x.Attachment
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetReplyToId() int32 {
+	if x != nil {
+		return x.ReplyToId
+	}
+	return 0
+}
+
+func (x *ScheduledMessage) GetSendAt() int64 {
+	if x != nil {
+		return x.SendAt
+	}
+	return 0
+}
+
+func (x *ScheduledMessage) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetMessageId() int32 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *ScheduledMessage) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *ScheduledMessage) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ScheduledMessage) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// ScheduleMessageRequest est le payload reçu sur SCHEDULE_MESSAGE
+type ScheduleMessageRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SenderId       string                 `protobuf:"bytes,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Content        string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Attachment     string                 `protobuf:"bytes,4,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	ReplyToId      int32                  `protobuf:"varint,5,opt,name=reply_to_id,json=replyToId,proto3" json:"reply_to_id,omitempty"`
+	SendAt         int64                  `protobuf:"varint,6,opt,name=send_at,json=sendAt,proto3" json:"send_at,omitempty"` // Unix timestamp, strictement dans le futur
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ScheduleMessageRequest) Reset() {
+	*x = ScheduleMessageRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleMessageRequest) ProtoMessage() {}
+
+func (x *ScheduleMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleMessageRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleMessageRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ScheduleMessageRequest) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+func (x *ScheduleMessageRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *ScheduleMessageRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ScheduleMessageRequest) GetAttachment() string {
+	if x != nil {
+		return x.Attachment
+	}
+	return ""
+}
+
+func (x *ScheduleMessageRequest) GetReplyToId() int32 {
+	if x != nil {
+		return x.ReplyToId
+	}
+	return 0
+}
+
+func (x *ScheduleMessageRequest) GetSendAt() int64 {
+	if x != nil {
+		return x.SendAt
+	}
+	return 0
+}
+
+type ScheduleMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *ScheduledMessage      `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleMessageResponse) Reset() {
+	*x = ScheduleMessageResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleMessageResponse) ProtoMessage() {}
+
+func (x *ScheduleMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleMessageResponse.ProtoReflect.Descriptor instead.
+func (*ScheduleMessageResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ScheduleMessageResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *ScheduleMessageResponse) GetData() *ScheduledMessage {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ScheduleMessageResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// ListScheduledMessagesRequest : messages programmés de actor_id (conversation_id optionnel).
+type ListScheduledMessagesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListScheduledMessagesRequest) Reset() {
+	*x = ListScheduledMessagesRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledMessagesRequest) ProtoMessage() {}
+
+func (x *ListScheduledMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListScheduledMessagesRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *ListScheduledMessagesRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+type ListScheduledMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          []*ScheduledMessage    `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledMessagesResponse) Reset() {
+	*x = ListScheduledMessagesResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledMessagesResponse) ProtoMessage() {}
+
+func (x *ListScheduledMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListScheduledMessagesResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *ListScheduledMessagesResponse) GetData() []*ScheduledMessage {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListScheduledMessagesResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// CancelScheduledMessageRequest : annule un message encore pending (auteur uniquement).
+type CancelScheduledMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActorId       string                 `protobuf:"bytes,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledMessageRequest) Reset() {
+	*x = CancelScheduledMessageRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledMessageRequest) ProtoMessage() {}
+
+func (x *CancelScheduledMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledMessageRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledMessageRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CancelScheduledMessageRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CancelScheduledMessageRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+type CancelScheduledMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *ScheduledMessage      `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledMessageResponse) Reset() {
+	*x = CancelScheduledMessageResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledMessageResponse) ProtoMessage() {}
+
+func (x *CancelScheduledMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledMessageResponse.ProtoReflect.Descriptor instead.
+func (*CancelScheduledMessageResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CancelScheduledMessageResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *CancelScheduledMessageResponse) GetData() *ScheduledMessage {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *CancelScheduledMessageResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// MessagePin : message épinglé dans une conversation.
+type MessagePin struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId int32                  `protobuf:"varint,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	MessageId      int32                  `protobuf:"varint,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	PinnedBy       string                 `protobuf:"bytes,3,opt,name=pinned_by,json=pinnedBy,proto3" json:"pinned_by,omitempty"` // UUID
+	PinnedAt       int64                  `protobuf:"varint,4,opt,name=pinned_at,json=pinnedAt,proto3" json:"pinned_at,omitempty"`
+	Message        *ChatMessage           `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"` // rempli par LIST_PINS
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MessagePin) Reset() {
+	*x = MessagePin{}
+	mi := &file_api_v1_message_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessagePin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessagePin) ProtoMessage() {}
+
+func (x *MessagePin) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessagePin.ProtoReflect.Descriptor instead.
+func (*MessagePin) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *MessagePin) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *MessagePin) GetMessageId() int32 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *MessagePin) GetPinnedBy() string {
+	if x != nil {
+		return x.PinnedBy
+	}
+	return ""
+}
+
+func (x *MessagePin) GetPinnedAt() int64 {
+	if x != nil {
+		return x.PinnedAt
+	}
+	return 0
+}
+
+func (x *MessagePin) GetMessage() *ChatMessage {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+// PinMessageRequest est le payload reçu sur PIN_MESSAGE (admin/owner).
+type PinMessageRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	MessageId      int32                  `protobuf:"varint,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PinMessageRequest) Reset() {
+	*x = PinMessageRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinMessageRequest) ProtoMessage() {}
+
+func (x *PinMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinMessageRequest.ProtoReflect.Descriptor instead.
+func (*PinMessageRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *PinMessageRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *PinMessageRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *PinMessageRequest) GetMessageId() int32 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+type PinMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *MessagePin            `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinMessageResponse) Reset() {
+	*x = PinMessageResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinMessageResponse) ProtoMessage() {}
+
+func (x *PinMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinMessageResponse.ProtoReflect.Descriptor instead.
+func (*PinMessageResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *PinMessageResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *PinMessageResponse) GetData() *MessagePin {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *PinMessageResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// UnpinMessageRequest est le payload reçu sur UNPIN_MESSAGE (admin/owner).
+type UnpinMessageRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	MessageId      int32                  `protobuf:"varint,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UnpinMessageRequest) Reset() {
+	*x = UnpinMessageRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpinMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinMessageRequest) ProtoMessage() {}
+
+func (x *UnpinMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinMessageRequest.ProtoReflect.Descriptor instead.
+func (*UnpinMessageRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *UnpinMessageRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *UnpinMessageRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *UnpinMessageRequest) GetMessageId() int32 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+type UnpinMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Error         *Error                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpinMessageResponse) Reset() {
+	*x = UnpinMessageResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpinMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinMessageResponse) ProtoMessage() {}
+
+func (x *UnpinMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinMessageResponse.ProtoReflect.Descriptor instead.
+func (*UnpinMessageResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *UnpinMessageResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *UnpinMessageResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// ListPinsRequest est le payload reçu sur LIST_PINS (membres).
+type ListPinsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListPinsRequest) Reset() {
+	*x = ListPinsRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinsRequest) ProtoMessage() {}
+
+func (x *ListPinsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinsRequest.ProtoReflect.Descriptor instead.
+func (*ListPinsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListPinsRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *ListPinsRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+type ListPinsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          []*MessagePin          `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPinsResponse) Reset() {
+	*x = ListPinsResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinsResponse) ProtoMessage() {}
+
+func (x *ListPinsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinsResponse.ProtoReflect.Descriptor instead.
+func (*ListPinsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListPinsResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *ListPinsResponse) GetData() []*MessagePin {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ListPinsResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// PollOption : voter_ids vide pour un sondage anonyme.
+type PollOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	VoteCount     int32                  `protobuf:"varint,3,opt,name=vote_count,json=voteCount,proto3" json:"vote_count,omitempty"`
+	VoterIds      []string               `protobuf:"bytes,4,rep,name=voter_ids,json=voterIds,proto3" json:"voter_ids,omitempty"` // UUID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollOption) Reset() {
+	*x = PollOption{}
+	mi := &file_api_v1_message_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollOption) ProtoMessage() {}
+
+func (x *PollOption) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollOption.ProtoReflect.Descriptor instead.
+func (*PollOption) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *PollOption) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PollOption) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *PollOption) GetVoteCount() int32 {
+	if x != nil {
+		return x.VoteCount
+	}
+	return 0
+}
+
+func (x *PollOption) GetVoterIds() []string {
+	if x != nil {
+		return x.VoterIds
+	}
+	return nil
+}
+
+// Poll : sondage affiché dans la timeline par le message message_id.
+type Poll struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	MessageId      int32                  `protobuf:"varint,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ConversationId int32                  `protobuf:"varint,3,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	CreatedBy      string                 `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"` // UUID
+	Question       string                 `protobuf:"bytes,5,opt,name=question,proto3" json:"question,omitempty"`
+	MultiChoice    bool                   `protobuf:"varint,6,opt,name=multi_choice,json=multiChoice,proto3" json:"multi_choice,omitempty"`
+	Anonymous      bool                   `protobuf:"varint,7,opt,name=anonymous,proto3" json:"anonymous,omitempty"`
+	ClosesAt       int64                  `protobuf:"varint,8,opt,name=closes_at,json=closesAt,proto3" json:"closes_at,omitempty"` // 0 = pas d'échéance
+	ClosedAt       int64                  `protobuf:"varint,9,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"` // 0 = ouvert
+	Closed         bool                   `protobuf:"varint,10,opt,name=closed,proto3" json:"closed,omitempty"`                    // fermé explicitement ou échéance atteinte
+	CreatedAt      int64                  `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Options        []*PollOption          `protobuf:"bytes,12,rep,name=options,proto3" json:"options,omitempty"`
+	TotalVoters    int32                  `protobuf:"varint,13,opt,name=total_voters,json=totalVoters,proto3" json:"total_voters,omitempty"`
+	MyOptionIds    []int32                `protobuf:"varint,14,rep,packed,name=my_option_ids,json=myOptionIds,proto3" json:"my_option_ids,omitempty"` // votes de l'acteur de la requête
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Poll) Reset() {
+	*x = Poll{}
+	mi := &file_api_v1_message_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Poll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Poll) ProtoMessage() {}
+
+func (x *Poll) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Poll.ProtoReflect.Descriptor instead.
+func (*Poll) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *Poll) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Poll) GetMessageId() int32 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *Poll) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *Poll) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Poll) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *Poll) GetMultiChoice() bool {
+	if x != nil {
+		return x.MultiChoice
+	}
+	return false
+}
+
+func (x *Poll) GetAnonymous() bool {
+	if x != nil {
+		return x.Anonymous
+	}
+	return false
+}
+
+func (x *Poll) GetClosesAt() int64 {
+	if x != nil {
+		return x.ClosesAt
+	}
+	return 0
+}
+
+func (x *Poll) GetClosedAt() int64 {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return 0
+}
+
+func (x *Poll) GetClosed() bool {
+	if x != nil {
+		return x.Closed
+	}
+	return false
+}
+
+func (x *Poll) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Poll) GetOptions() []*PollOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Poll) GetTotalVoters() int32 {
+	if x != nil {
+		return x.TotalVoters
+	}
+	return 0
+}
+
+func (x *Poll) GetMyOptionIds() []int32 {
+	if x != nil {
+		return x.MyOptionIds
+	}
+	return nil
+}
+
+// PollCreateRequest est le payload reçu sur POLL_CREATE (membres).
+type PollCreateRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Question       string                 `protobuf:"bytes,3,opt,name=question,proto3" json:"question,omitempty"`
+	Options        []string               `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty"`
+	MultiChoice    bool                   `protobuf:"varint,5,opt,name=multi_choice,json=multiChoice,proto3" json:"multi_choice,omitempty"`
+	Anonymous      bool                   `protobuf:"varint,6,opt,name=anonymous,proto3" json:"anonymous,omitempty"`
+	ClosesAt       int64                  `protobuf:"varint,7,opt,name=closes_at,json=closesAt,proto3" json:"closes_at,omitempty"` // Unix timestamp, 0 = pas d'échéance
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PollCreateRequest) Reset() {
+	*x = PollCreateRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollCreateRequest) ProtoMessage() {}
+
+func (x *PollCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollCreateRequest.ProtoReflect.Descriptor instead.
+func (*PollCreateRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *PollCreateRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *PollCreateRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *PollCreateRequest) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *PollCreateRequest) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *PollCreateRequest) GetMultiChoice() bool {
+	if x != nil {
+		return x.MultiChoice
+	}
+	return false
+}
+
+func (x *PollCreateRequest) GetAnonymous() bool {
+	if x != nil {
+		return x.Anonymous
+	}
+	return false
+}
+
+func (x *PollCreateRequest) GetClosesAt() int64 {
+	if x != nil {
+		return x.ClosesAt
+	}
+	return 0
+}
+
+type PollCreateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *Poll                  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollCreateResponse) Reset() {
+	*x = PollCreateResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollCreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollCreateResponse) ProtoMessage() {}
+
+func (x *PollCreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollCreateResponse.ProtoReflect.Descriptor instead.
+func (*PollCreateResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *PollCreateResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *PollCreateResponse) GetData() *Poll {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *PollCreateResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// PollVoteRequest est le payload reçu sur POLL_VOTE ; option_ids vide retire le vote.
+type PollVoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	PollId        int32                  `protobuf:"varint,2,opt,name=poll_id,json=pollId,proto3" json:"poll_id,omitempty"`
+	OptionIds     []int32                `protobuf:"varint,3,rep,packed,name=option_ids,json=optionIds,proto3" json:"option_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollVoteRequest) Reset() {
+	*x = PollVoteRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollVoteRequest) ProtoMessage() {}
+
+func (x *PollVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollVoteRequest.ProtoReflect.Descriptor instead.
+func (*PollVoteRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *PollVoteRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *PollVoteRequest) GetPollId() int32 {
+	if x != nil {
+		return x.PollId
+	}
+	return 0
+}
+
+func (x *PollVoteRequest) GetOptionIds() []int32 {
+	if x != nil {
+		return x.OptionIds
+	}
+	return nil
+}
+
+type PollVoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *Poll                  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollVoteResponse) Reset() {
+	*x = PollVoteResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollVoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollVoteResponse) ProtoMessage() {}
+
+func (x *PollVoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollVoteResponse.ProtoReflect.Descriptor instead.
+func (*PollVoteResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *PollVoteResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *PollVoteResponse) GetData() *Poll {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *PollVoteResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// PollCloseRequest est le payload reçu sur POLL_CLOSE (auteur ou admin/owner).
+type PollCloseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	PollId        int32                  `protobuf:"varint,2,opt,name=poll_id,json=pollId,proto3" json:"poll_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollCloseRequest) Reset() {
+	*x = PollCloseRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollCloseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollCloseRequest) ProtoMessage() {}
+
+func (x *PollCloseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollCloseRequest.ProtoReflect.Descriptor instead.
+func (*PollCloseRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *PollCloseRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *PollCloseRequest) GetPollId() int32 {
+	if x != nil {
+		return x.PollId
+	}
+	return 0
+}
+
+type PollCloseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *Poll                  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollCloseResponse) Reset() {
+	*x = PollCloseResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollCloseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollCloseResponse) ProtoMessage() {}
+
+func (x *PollCloseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollCloseResponse.ProtoReflect.Descriptor instead.
+func (*PollCloseResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *PollCloseResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *PollCloseResponse) GetData() *Poll {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *PollCloseResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// PollGetRequest est le payload reçu sur POLL_GET (membres).
+type PollGetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	PollId        int32                  `protobuf:"varint,2,opt,name=poll_id,json=pollId,proto3" json:"poll_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollGetRequest) Reset() {
+	*x = PollGetRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollGetRequest) ProtoMessage() {}
+
+func (x *PollGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollGetRequest.ProtoReflect.Descriptor instead.
+func (*PollGetRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *PollGetRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *PollGetRequest) GetPollId() int32 {
+	if x != nil {
+		return x.PollId
+	}
+	return 0
+}
+
+type PollGetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *Poll                  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollGetResponse) Reset() {
+	*x = PollGetResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollGetResponse) ProtoMessage() {}
+
+func (x *PollGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollGetResponse.ProtoReflect.Descriptor instead.
+func (*PollGetResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *PollGetResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *PollGetResponse) GetData() *Poll {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *PollGetResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// DirectGetOrCreateRequest est le payload reçu sur DIRECT_GET_OR_CREATE.
+type DirectGetOrCreateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`    // UUID de l'autre participant
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DirectGetOrCreateRequest) Reset() {
+	*x = DirectGetOrCreateRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DirectGetOrCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DirectGetOrCreateRequest) ProtoMessage() {}
+
+func (x *DirectGetOrCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DirectGetOrCreateRequest.ProtoReflect.Descriptor instead.
+func (*DirectGetOrCreateRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *DirectGetOrCreateRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *DirectGetOrCreateRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DirectGetOrCreateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *Group                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Created       bool                   `protobuf:"varint,4,opt,name=created,proto3" json:"created,omitempty"` // false : conversation existante retournée
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DirectGetOrCreateResponse) Reset() {
+	*x = DirectGetOrCreateResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DirectGetOrCreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DirectGetOrCreateResponse) ProtoMessage() {}
+
+func (x *DirectGetOrCreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DirectGetOrCreateResponse.ProtoReflect.Descriptor instead.
+func (*DirectGetOrCreateResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *DirectGetOrCreateResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *DirectGetOrCreateResponse) GetData() *Group {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DirectGetOrCreateResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *DirectGetOrCreateResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+// GroupUpdateRequest est le payload reçu sur GROUP_UPDATE (admin/owner).
+// update_mask liste les champs à appliquer : "name", "avatar_url", "description".
+type GroupUpdateRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	AvatarUrl      string                 `protobuf:"bytes,4,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	Description    string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	UpdateMask     []string               `protobuf:"bytes,6,rep,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupUpdateRequest) Reset() {
+	*x = GroupUpdateRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupUpdateRequest) ProtoMessage() {}
+
+func (x *GroupUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupUpdateRequest.ProtoReflect.Descriptor instead.
+func (*GroupUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *GroupUpdateRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *GroupUpdateRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *GroupUpdateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GroupUpdateRequest) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *GroupUpdateRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *GroupUpdateRequest) GetUpdateMask() []string {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type GroupUpdateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *Group                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupUpdateResponse) Reset() {
+	*x = GroupUpdateResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupUpdateResponse) ProtoMessage() {}
+
+func (x *GroupUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupUpdateResponse.ProtoReflect.Descriptor instead.
+func (*GroupUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GroupUpdateResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupUpdateResponse) GetData() *Group {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GroupUpdateResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// ConversationInvite : lien d'invitation vers un groupe.
+type ConversationInvite struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Token          string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	ConversationId int32                  `protobuf:"varint,3,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	CreatedBy      string                 `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`  // UUID
+	ExpiresAt      int64                  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // 0 = pas d'expiration
+	MaxUses        int32                  `protobuf:"varint,6,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`       // 0 = illimité
+	UseCount       int32                  `protobuf:"varint,7,opt,name=use_count,json=useCount,proto3" json:"use_count,omitempty"`
+	RevokedAt      int64                  `protobuf:"varint,8,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"` // 0 = active
+	CreatedAt      int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ConversationInvite) Reset() {
+	*x = ConversationInvite{}
+	mi := &file_api_v1_message_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversationInvite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversationInvite) ProtoMessage() {}
+
+func (x *ConversationInvite) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversationInvite.ProtoReflect.Descriptor instead.
+func (*ConversationInvite) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ConversationInvite) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ConversationInvite) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ConversationInvite) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *ConversationInvite) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ConversationInvite) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *ConversationInvite) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *ConversationInvite) GetUseCount() int32 {
+	if x != nil {
+		return x.UseCount
+	}
+	return 0
+}
+
+func (x *ConversationInvite) GetRevokedAt() int64 {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return 0
+}
+
+func (x *ConversationInvite) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// GroupInviteCreateRequest est le payload reçu sur GROUP_INVITE_CREATE (admin/owner).
+type GroupInviteCreateRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	ExpiresAt      int64                  `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // unix, 0 = pas d'expiration
+	MaxUses        int32                  `protobuf:"varint,4,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`       // 0 = illimité
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupInviteCreateRequest) Reset() {
+	*x = GroupInviteCreateRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupInviteCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupInviteCreateRequest) ProtoMessage() {}
+
+func (x *GroupInviteCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupInviteCreateRequest.ProtoReflect.Descriptor instead.
+func (*GroupInviteCreateRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *GroupInviteCreateRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *GroupInviteCreateRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *GroupInviteCreateRequest) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *GroupInviteCreateRequest) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+type GroupInviteCreateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *ConversationInvite    `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupInviteCreateResponse) Reset() {
+	*x = GroupInviteCreateResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupInviteCreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupInviteCreateResponse) ProtoMessage() {}
+
+func (x *GroupInviteCreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupInviteCreateResponse.ProtoReflect.Descriptor instead.
+func (*GroupInviteCreateResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *GroupInviteCreateResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupInviteCreateResponse) GetData() *ConversationInvite {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GroupInviteCreateResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// GroupInviteListRequest est le payload reçu sur GROUP_INVITE_LIST (admin/owner).
+type GroupInviteListRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupInviteListRequest) Reset() {
+	*x = GroupInviteListRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupInviteListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupInviteListRequest) ProtoMessage() {}
+
+func (x *GroupInviteListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupInviteListRequest.ProtoReflect.Descriptor instead.
+func (*GroupInviteListRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *GroupInviteListRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *GroupInviteListRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+type GroupInviteListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          []*ConversationInvite  `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupInviteListResponse) Reset() {
+	*x = GroupInviteListResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupInviteListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupInviteListResponse) ProtoMessage() {}
+
+func (x *GroupInviteListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupInviteListResponse.ProtoReflect.Descriptor instead.
+func (*GroupInviteListResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *GroupInviteListResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupInviteListResponse) GetData() []*ConversationInvite {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GroupInviteListResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// GroupInviteRevokeRequest est le payload reçu sur GROUP_INVITE_REVOKE (admin/owner).
+type GroupInviteRevokeRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	InviteId       int32                  `protobuf:"varint,3,opt,name=invite_id,json=inviteId,proto3" json:"invite_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupInviteRevokeRequest) Reset() {
+	*x = GroupInviteRevokeRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupInviteRevokeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupInviteRevokeRequest) ProtoMessage() {}
+
+func (x *GroupInviteRevokeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupInviteRevokeRequest.ProtoReflect.Descriptor instead.
+func (*GroupInviteRevokeRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *GroupInviteRevokeRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *GroupInviteRevokeRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *GroupInviteRevokeRequest) GetInviteId() int32 {
+	if x != nil {
+		return x.InviteId
+	}
+	return 0
+}
+
+type GroupInviteRevokeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *ConversationInvite    `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupInviteRevokeResponse) Reset() {
+	*x = GroupInviteRevokeResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupInviteRevokeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupInviteRevokeResponse) ProtoMessage() {}
+
+func (x *GroupInviteRevokeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupInviteRevokeResponse.ProtoReflect.Descriptor instead.
+func (*GroupInviteRevokeResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *GroupInviteRevokeResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupInviteRevokeResponse) GetData() *ConversationInvite {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GroupInviteRevokeResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// GroupInviteJoinRequest est le payload reçu sur GROUP_INVITE_JOIN (tout utilisateur authentifié).
+type GroupInviteJoinRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupInviteJoinRequest) Reset() {
+	*x = GroupInviteJoinRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupInviteJoinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupInviteJoinRequest) ProtoMessage() {}
+
+func (x *GroupInviteJoinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupInviteJoinRequest.ProtoReflect.Descriptor instead.
+func (*GroupInviteJoinRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *GroupInviteJoinRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *GroupInviteJoinRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type GroupInviteJoinResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *Group                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Member        *GroupMember           `protobuf:"bytes,3,opt,name=member,proto3" json:"member,omitempty"`
+	Error         *Error                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupInviteJoinResponse) Reset() {
+	*x = GroupInviteJoinResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupInviteJoinResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupInviteJoinResponse) ProtoMessage() {}
+
+func (x *GroupInviteJoinResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupInviteJoinResponse.ProtoReflect.Descriptor instead.
+func (*GroupInviteJoinResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *GroupInviteJoinResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupInviteJoinResponse) GetData() *Group {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GroupInviteJoinResponse) GetMember() *GroupMember {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+func (x *GroupInviteJoinResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// ConversationJoinRequest : demande d'adhésion à un groupe.
+type ConversationJoinRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID du demandeur
+	Message        string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`                         // "pending" | "approved" | "denied"
+	DecidedBy      string                 `protobuf:"bytes,6,opt,name=decided_by,json=decidedBy,proto3" json:"decided_by,omitempty"`  // UUID, vide tant que pending
+	DecidedAt      int64                  `protobuf:"varint,7,opt,name=decided_at,json=decidedAt,proto3" json:"decided_at,omitempty"` // 0 tant que pending
+	CreatedAt      int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ConversationJoinRequest) Reset() {
+	*x = ConversationJoinRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversationJoinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversationJoinRequest) ProtoMessage() {}
+
+func (x *ConversationJoinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversationJoinRequest.ProtoReflect.Descriptor instead.
+func (*ConversationJoinRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ConversationJoinRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ConversationJoinRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *ConversationJoinRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ConversationJoinRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ConversationJoinRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ConversationJoinRequest) GetDecidedBy() string {
+	if x != nil {
+		return x.DecidedBy
+	}
+	return ""
+}
+
+func (x *ConversationJoinRequest) GetDecidedAt() int64 {
+	if x != nil {
+		return x.DecidedAt
+	}
+	return 0
+}
+
+func (x *ConversationJoinRequest) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// GroupJoinRequestRequest est le payload reçu sur GROUP_JOIN_REQUEST (utilisateur non membre).
+type GroupJoinRequestRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Message        string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"` // facultatif, 500 caractères max
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupJoinRequestRequest) Reset() {
+	*x = GroupJoinRequestRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupJoinRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupJoinRequestRequest) ProtoMessage() {}
+
+func (x *GroupJoinRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupJoinRequestRequest.ProtoReflect.Descriptor instead.
+func (*GroupJoinRequestRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *GroupJoinRequestRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *GroupJoinRequestRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *GroupJoinRequestRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GroupJoinRequestResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Ok            bool                     `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *ConversationJoinRequest `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupJoinRequestResponse) Reset() {
+	*x = GroupJoinRequestResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupJoinRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupJoinRequestResponse) ProtoMessage() {}
+
+func (x *GroupJoinRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupJoinRequestResponse.ProtoReflect.Descriptor instead.
+func (*GroupJoinRequestResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *GroupJoinRequestResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupJoinRequestResponse) GetData() *ConversationJoinRequest {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GroupJoinRequestResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// GroupJoinDecideRequest est le payload reçu sur GROUP_JOIN_DECIDE (admin/owner).
+type GroupJoinDecideRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	RequestId      int32                  `protobuf:"varint,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Approve        bool                   `protobuf:"varint,4,opt,name=approve,proto3" json:"approve,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupJoinDecideRequest) Reset() {
+	*x = GroupJoinDecideRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupJoinDecideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupJoinDecideRequest) ProtoMessage() {}
+
+func (x *GroupJoinDecideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupJoinDecideRequest.ProtoReflect.Descriptor instead.
+func (*GroupJoinDecideRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *GroupJoinDecideRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *GroupJoinDecideRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *GroupJoinDecideRequest) GetRequestId() int32 {
+	if x != nil {
+		return x.RequestId
+	}
+	return 0
+}
+
+func (x *GroupJoinDecideRequest) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
+type GroupJoinDecideResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Ok            bool                     `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *ConversationJoinRequest `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Member        *GroupMember             `protobuf:"bytes,3,opt,name=member,proto3" json:"member,omitempty"` // renseigné si approuvée
+	Error         *Error                   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupJoinDecideResponse) Reset() {
+	*x = GroupJoinDecideResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupJoinDecideResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupJoinDecideResponse) ProtoMessage() {}
+
+func (x *GroupJoinDecideResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupJoinDecideResponse.ProtoReflect.Descriptor instead.
+func (*GroupJoinDecideResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *GroupJoinDecideResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupJoinDecideResponse) GetData() *ConversationJoinRequest {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GroupJoinDecideResponse) GetMember() *GroupMember {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+func (x *GroupJoinDecideResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// GroupJoinListRequest est le payload reçu sur GROUP_JOIN_LIST (admin/owner) : demandes en attente.
+type GroupJoinListRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupJoinListRequest) Reset() {
+	*x = GroupJoinListRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupJoinListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupJoinListRequest) ProtoMessage() {}
+
+func (x *GroupJoinListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupJoinListRequest.ProtoReflect.Descriptor instead.
+func (*GroupJoinListRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GroupJoinListRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *GroupJoinListRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+type GroupJoinListResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Ok            bool                       `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          []*ConversationJoinRequest `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                     `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupJoinListResponse) Reset() {
+	*x = GroupJoinListResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupJoinListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupJoinListResponse) ProtoMessage() {}
+
+func (x *GroupJoinListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupJoinListResponse.ProtoReflect.Descriptor instead.
+func (*GroupJoinListResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *GroupJoinListResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupJoinListResponse) GetData() []*ConversationJoinRequest {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GroupJoinListResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// GroupTransferOwnershipRequest est le payload reçu sur GROUP_TRANSFER_OWNERSHIP (owner) :
+// user_id devient owner, l'acteur devient admin, atomiquement.
+type GroupTransferOwnershipRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID du nouveau owner (membre du groupe)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupTransferOwnershipRequest) Reset() {
+	*x = GroupTransferOwnershipRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupTransferOwnershipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupTransferOwnershipRequest) ProtoMessage() {}
+
+func (x *GroupTransferOwnershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupTransferOwnershipRequest.ProtoReflect.Descriptor instead.
+func (*GroupTransferOwnershipRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *GroupTransferOwnershipRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *GroupTransferOwnershipRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *GroupTransferOwnershipRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GroupTransferOwnershipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	PreviousOwner *GroupMember           `protobuf:"bytes,2,opt,name=previous_owner,json=previousOwner,proto3" json:"previous_owner,omitempty"`
+	NewOwner      *GroupMember           `protobuf:"bytes,3,opt,name=new_owner,json=newOwner,proto3" json:"new_owner,omitempty"`
+	Error         *Error                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupTransferOwnershipResponse) Reset() {
+	*x = GroupTransferOwnershipResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupTransferOwnershipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupTransferOwnershipResponse) ProtoMessage() {}
+
+func (x *GroupTransferOwnershipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupTransferOwnershipResponse.ProtoReflect.Descriptor instead.
+func (*GroupTransferOwnershipResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *GroupTransferOwnershipResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupTransferOwnershipResponse) GetPreviousOwner() *GroupMember {
+	if x != nil {
+		return x.PreviousOwner
+	}
+	return nil
+}
+
+func (x *GroupTransferOwnershipResponse) GetNewOwner() *GroupMember {
+	if x != nil {
+		return x.NewOwner
+	}
+	return nil
+}
+
+func (x *GroupTransferOwnershipResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// GroupMemberResult : résultat d'une opération groupée pour un utilisateur.
+type GroupMemberResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // UUID
+	Ok            bool                   `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	Member        *GroupMember           `protobuf:"bytes,3,opt,name=member,proto3" json:"member,omitempty"`
+	Error         *Error                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupMemberResult) Reset() {
+	*x = GroupMemberResult{}
+	mi := &file_api_v1_message_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupMemberResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupMemberResult) ProtoMessage() {}
+
+func (x *GroupMemberResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMemberResult.ProtoReflect.Descriptor instead.
+func (*GroupMemberResult) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *GroupMemberResult) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GroupMemberResult) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupMemberResult) GetMember() *GroupMember {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+func (x *GroupMemberResult) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// GroupAddMembersBulkRequest est le payload reçu sur GROUP_ADD_MEMBERS_BULK (100 utilisateurs max).
+type GroupAddMembersBulkRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserIds        []string               `protobuf:"bytes,3,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"` // UUID
+	Role           int32                  `protobuf:"varint,4,opt,name=role,proto3" json:"role,omitempty"`                     // 0=member,1=admin,2=owner
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupAddMembersBulkRequest) Reset() {
+	*x = GroupAddMembersBulkRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupAddMembersBulkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupAddMembersBulkRequest) ProtoMessage() {}
+
+func (x *GroupAddMembersBulkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupAddMembersBulkRequest.ProtoReflect.Descriptor instead.
+func (*GroupAddMembersBulkRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *GroupAddMembersBulkRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *GroupAddMembersBulkRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *GroupAddMembersBulkRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *GroupAddMembersBulkRequest) GetRole() int32 {
+	if x != nil {
+		return x.Role
+	}
+	return 0
+}
+
+// GroupAddMembersBulkResponse : ok concerne la requête ; chaque utilisateur a son résultat (ordre de la requête).
+type GroupAddMembersBulkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Results       []*GroupMemberResult   `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupAddMembersBulkResponse) Reset() {
+	*x = GroupAddMembersBulkResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupAddMembersBulkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupAddMembersBulkResponse) ProtoMessage() {}
+
+func (x *GroupAddMembersBulkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupAddMembersBulkResponse.ProtoReflect.Descriptor instead.
+func (*GroupAddMembersBulkResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *GroupAddMembersBulkResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupAddMembersBulkResponse) GetResults() []*GroupMemberResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *GroupAddMembersBulkResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// GroupRemoveMembersBulkRequest est le payload reçu sur GROUP_REMOVE_MEMBERS_BULK (100 utilisateurs max).
+type GroupRemoveMembersBulkRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserIds        []string               `protobuf:"bytes,3,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"` // UUID
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupRemoveMembersBulkRequest) Reset() {
+	*x = GroupRemoveMembersBulkRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupRemoveMembersBulkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupRemoveMembersBulkRequest) ProtoMessage() {}
+
+func (x *GroupRemoveMembersBulkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupRemoveMembersBulkRequest.ProtoReflect.Descriptor instead.
+func (*GroupRemoveMembersBulkRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *GroupRemoveMembersBulkRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *GroupRemoveMembersBulkRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *GroupRemoveMembersBulkRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type GroupRemoveMembersBulkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Results       []*GroupMemberResult   `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupRemoveMembersBulkResponse) Reset() {
+	*x = GroupRemoveMembersBulkResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupRemoveMembersBulkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupRemoveMembersBulkResponse) ProtoMessage() {}
+
+func (x *GroupRemoveMembersBulkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupRemoveMembersBulkResponse.ProtoReflect.Descriptor instead.
+func (*GroupRemoveMembersBulkResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *GroupRemoveMembersBulkResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupRemoveMembersBulkResponse) GetResults() []*GroupMemberResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *GroupRemoveMembersBulkResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// GroupArchiveRequest est le payload reçu sur GROUP_ARCHIVE : archivage propre à l'acteur.
+type GroupArchiveRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Archived       bool                   `protobuf:"varint,3,opt,name=archived,proto3" json:"archived,omitempty"` // false : désarchive
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupArchiveRequest) Reset() {
+	*x = GroupArchiveRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupArchiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupArchiveRequest) ProtoMessage() {}
+
+func (x *GroupArchiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupArchiveRequest.ProtoReflect.Descriptor instead.
+func (*GroupArchiveRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *GroupArchiveRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *GroupArchiveRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *GroupArchiveRequest) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+type GroupArchiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Archived      bool                   `protobuf:"varint,2,opt,name=archived,proto3" json:"archived,omitempty"`
+	ArchivedAt    int64                  `protobuf:"varint,3,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"` // 0 si non archivée
+	Error         *Error                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupArchiveResponse) Reset() {
+	*x = GroupArchiveResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupArchiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupArchiveResponse) ProtoMessage() {}
+
+func (x *GroupArchiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupArchiveResponse.ProtoReflect.Descriptor instead.
+func (*GroupArchiveResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *GroupArchiveResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupArchiveResponse) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *GroupArchiveResponse) GetArchivedAt() int64 {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return 0
+}
+
+func (x *GroupArchiveResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// GroupRestoreRequest est le payload reçu sur GROUP_RESTORE (owner, pendant le délai de grâce).
+type GroupRestoreRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupRestoreRequest) Reset() {
+	*x = GroupRestoreRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupRestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupRestoreRequest) ProtoMessage() {}
+
+func (x *GroupRestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupRestoreRequest.ProtoReflect.Descriptor instead.
+func (*GroupRestoreRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *GroupRestoreRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *GroupRestoreRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+type GroupRestoreResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *Group                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	MemberIds     []string               `protobuf:"bytes,3,rep,name=member_ids,json=memberIds,proto3" json:"member_ids,omitempty"` // UUID des membres réintégrés
+	Error         *Error                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupRestoreResponse) Reset() {
+	*x = GroupRestoreResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupRestoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupRestoreResponse) ProtoMessage() {}
+
+func (x *GroupRestoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupRestoreResponse.ProtoReflect.Descriptor instead.
+func (*GroupRestoreResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *GroupRestoreResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupRestoreResponse) GetData() *Group {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GroupRestoreResponse) GetMemberIds() []string {
+	if x != nil {
+		return x.MemberIds
+	}
+	return nil
+}
+
+func (x *GroupRestoreResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// GroupUpdatePermissionsRequest est le payload reçu sur GROUP_UPDATE_PERMISSIONS (owner).
+// update_mask liste les champs à appliquer : "only_admins_can_post", "members_can_add_members",
+// "members_can_pin", "slow_mode_seconds", "join_requests_enabled".
+type GroupUpdatePermissionsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Permissions    *GroupPermissions      `protobuf:"bytes,3,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	UpdateMask     []string               `protobuf:"bytes,4,rep,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupUpdatePermissionsRequest) Reset() {
+	*x = GroupUpdatePermissionsRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupUpdatePermissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupUpdatePermissionsRequest) ProtoMessage() {}
+
+func (x *GroupUpdatePermissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupUpdatePermissionsRequest.ProtoReflect.Descriptor instead.
+func (*GroupUpdatePermissionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *GroupUpdatePermissionsRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *GroupUpdatePermissionsRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *GroupUpdatePermissionsRequest) GetPermissions() *GroupPermissions {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *GroupUpdatePermissionsRequest) GetUpdateMask() []string {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type GroupUpdatePermissionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *Group                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupUpdatePermissionsResponse) Reset() {
+	*x = GroupUpdatePermissionsResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupUpdatePermissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupUpdatePermissionsResponse) ProtoMessage() {}
+
+func (x *GroupUpdatePermissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupUpdatePermissionsResponse.ProtoReflect.Descriptor instead.
+func (*GroupUpdatePermissionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *GroupUpdatePermissionsResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupUpdatePermissionsResponse) GetData() *Group {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GroupUpdatePermissionsResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type UserBlock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockerId     string                 `protobuf:"bytes,1,opt,name=blocker_id,json=blockerId,proto3" json:"blocker_id,omitempty"` // UUID
+	BlockedId     string                 `protobuf:"bytes,2,opt,name=blocked_id,json=blockedId,proto3" json:"blocked_id,omitempty"` // UUID
+	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserBlock) Reset() {
+	*x = UserBlock{}
+	mi := &file_api_v1_message_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserBlock) ProtoMessage() {}
+
+func (x *UserBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserBlock.ProtoReflect.Descriptor instead.
+func (*UserBlock) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *UserBlock) GetBlockerId() string {
+	if x != nil {
+		return x.BlockerId
+	}
+	return ""
+}
+
+func (x *UserBlock) GetBlockedId() string {
+	if x != nil {
+		return x.BlockedId
+	}
+	return ""
+}
+
+func (x *UserBlock) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// UserBlockRequest est le payload reçu sur USER_BLOCK et USER_UNBLOCK.
+type UserBlockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`    // UUID de l'utilisateur bloqué / débloqué
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserBlockRequest) Reset() {
+	*x = UserBlockRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserBlockRequest) ProtoMessage() {}
+
+func (x *UserBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserBlockRequest.ProtoReflect.Descriptor instead.
+func (*UserBlockRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *UserBlockRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *UserBlockRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UserBlockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *UserBlock             `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"` // vide pour USER_UNBLOCK
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserBlockResponse) Reset() {
+	*x = UserBlockResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserBlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserBlockResponse) ProtoMessage() {}
+
+func (x *UserBlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserBlockResponse.ProtoReflect.Descriptor instead.
+func (*UserBlockResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *UserBlockResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *UserBlockResponse) GetData() *UserBlock {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UserBlockResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// UserBlockListRequest est le payload reçu sur USER_BLOCK_LIST : utilisateurs bloqués par l'acteur.
+type UserBlockListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserBlockListRequest) Reset() {
+	*x = UserBlockListRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserBlockListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserBlockListRequest) ProtoMessage() {}
+
+func (x *UserBlockListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserBlockListRequest.ProtoReflect.Descriptor instead.
+func (*UserBlockListRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *UserBlockListRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+type UserBlockListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          []*UserBlock           `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserBlockListResponse) Reset() {
+	*x = UserBlockListResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserBlockListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserBlockListResponse) ProtoMessage() {}
+
+func (x *UserBlockListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserBlockListResponse.ProtoReflect.Descriptor instead.
+func (*UserBlockListResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *UserBlockListResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *UserBlockListResponse) GetData() []*UserBlock {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UserBlockListResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type MessageReport struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	MessageId      int32                  `protobuf:"varint,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ConversationId int32                  `protobuf:"varint,3,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	ReporterId     string                 `protobuf:"bytes,4,opt,name=reporter_id,json=reporterId,proto3" json:"reporter_id,omitempty"` // UUID
+	Reason         string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Status         string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                            // "pending" | "resolved" | "dismissed"
+	ResolvedBy     string                 `protobuf:"bytes,7,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`  // UUID, vide tant que pending
+	ResolvedAt     int64                  `protobuf:"varint,8,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"` // 0 tant que pending
+	CreatedAt      int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MessageReport) Reset() {
+	*x = MessageReport{}
+	mi := &file_api_v1_message_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageReport) ProtoMessage() {}
+
+func (x *MessageReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageReport.ProtoReflect.Descriptor instead.
+func (*MessageReport) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *MessageReport) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MessageReport) GetMessageId() int32 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *MessageReport) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *MessageReport) GetReporterId() string {
+	if x != nil {
+		return x.ReporterId
+	}
+	return ""
+}
+
+func (x *MessageReport) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *MessageReport) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MessageReport) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+func (x *MessageReport) GetResolvedAt() int64 {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return 0
+}
+
+func (x *MessageReport) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// MessageReportRequest est le payload reçu sur MESSAGE_REPORT (membre de la conversation).
+type MessageReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	MessageId     int32                  `protobuf:"varint,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // 1000 caractères max
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageReportRequest) Reset() {
+	*x = MessageReportRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageReportRequest) ProtoMessage() {}
+
+func (x *MessageReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageReportRequest.ProtoReflect.Descriptor instead.
+func (*MessageReportRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *MessageReportRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *MessageReportRequest) GetMessageId() int32 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *MessageReportRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type MessageReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *MessageReport         `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageReportResponse) Reset() {
+	*x = MessageReportResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageReportResponse) ProtoMessage() {}
+
+func (x *MessageReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageReportResponse.ProtoReflect.Descriptor instead.
+func (*MessageReportResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *MessageReportResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *MessageReportResponse) GetData() *MessageReport {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *MessageReportResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// MessageReportListRequest est le payload reçu sur MESSAGE_REPORT_LIST (admin/owner).
+type MessageReportListRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Status         string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // vide : "pending"
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MessageReportListRequest) Reset() {
+	*x = MessageReportListRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageReportListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageReportListRequest) ProtoMessage() {}
+
+func (x *MessageReportListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageReportListRequest.ProtoReflect.Descriptor instead.
+func (*MessageReportListRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *MessageReportListRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *MessageReportListRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *MessageReportListRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type MessageReportListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          []*MessageReport       `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageReportListResponse) Reset() {
+	*x = MessageReportListResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageReportListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageReportListResponse) ProtoMessage() {}
+
+func (x *MessageReportListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageReportListResponse.ProtoReflect.Descriptor instead.
+func (*MessageReportListResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *MessageReportListResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *MessageReportListResponse) GetData() []*MessageReport {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *MessageReportListResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// MessageReportResolveRequest est le payload reçu sur MESSAGE_REPORT_RESOLVE (admin/owner).
+type MessageReportResolveRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	ReportId       int32                  `protobuf:"varint,3,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Status         string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // "resolved" | "dismissed"
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MessageReportResolveRequest) Reset() {
+	*x = MessageReportResolveRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageReportResolveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageReportResolveRequest) ProtoMessage() {}
+
+func (x *MessageReportResolveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageReportResolveRequest.ProtoReflect.Descriptor instead.
+func (*MessageReportResolveRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *MessageReportResolveRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *MessageReportResolveRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *MessageReportResolveRequest) GetReportId() int32 {
+	if x != nil {
+		return x.ReportId
+	}
+	return 0
+}
+
+func (x *MessageReportResolveRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type MessageReportResolveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          *MessageReport         `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageReportResolveResponse) Reset() {
+	*x = MessageReportResolveResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageReportResolveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageReportResolveResponse) ProtoMessage() {}
+
+func (x *MessageReportResolveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageReportResolveResponse.ProtoReflect.Descriptor instead.
+func (*MessageReportResolveResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *MessageReportResolveResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *MessageReportResolveResponse) GetData() *MessageReport {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *MessageReportResolveResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type GroupBan struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId int32                  `protobuf:"varint,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`       // UUID
+	BannedBy       string                 `protobuf:"bytes,3,opt,name=banned_by,json=bannedBy,proto3" json:"banned_by,omitempty"` // UUID
+	Reason         string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt      int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupBan) Reset() {
+	*x = GroupBan{}
+	mi := &file_api_v1_message_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupBan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupBan) ProtoMessage() {}
+
+func (x *GroupBan) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupBan.ProtoReflect.Descriptor instead.
+func (*GroupBan) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *GroupBan) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *GroupBan) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GroupBan) GetBannedBy() string {
+	if x != nil {
+		return x.BannedBy
+	}
+	return ""
+}
+
+func (x *GroupBan) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *GroupBan) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type GroupMute struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId int32                  `protobuf:"varint,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`    // UUID
+	MutedBy        string                 `protobuf:"bytes,3,opt,name=muted_by,json=mutedBy,proto3" json:"muted_by,omitempty"` // UUID
+	MutedUntil     int64                  `protobuf:"varint,4,opt,name=muted_until,json=mutedUntil,proto3" json:"muted_until,omitempty"`
+	CreatedAt      int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupMute) Reset() {
+	*x = GroupMute{}
+	mi := &file_api_v1_message_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupMute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupMute) ProtoMessage() {}
+
+func (x *GroupMute) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMute.ProtoReflect.Descriptor instead.
+func (*GroupMute) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *GroupMute) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *GroupMute) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GroupMute) GetMutedBy() string {
+	if x != nil {
+		return x.MutedBy
+	}
+	return ""
+}
+
+func (x *GroupMute) GetMutedUntil() int64 {
+	if x != nil {
+		return x.MutedUntil
+	}
+	return 0
+}
+
+func (x *GroupMute) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// GroupModerationRequest est le payload reçu sur GROUP_BAN, GROUP_UNBAN, GROUP_MUTE, GROUP_UNMUTE
+// et GROUP_KICK (admin/owner, cible de rôle strictement inférieur).
+type GroupModerationRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActorId         string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId  int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserId          string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                             // UUID de la cible
+	Reason          string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`                                           // 500 caractères max, ignoré pour GROUP_UNBAN / GROUP_UNMUTE
+	DurationSeconds int64                  `protobuf:"varint,5,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // GROUP_MUTE uniquement : 1 s à 30 jours
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GroupModerationRequest) Reset() {
+	*x = GroupModerationRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupModerationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupModerationRequest) ProtoMessage() {}
+
+func (x *GroupModerationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupModerationRequest.ProtoReflect.Descriptor instead.
+func (*GroupModerationRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *GroupModerationRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *GroupModerationRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *GroupModerationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GroupModerationRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *GroupModerationRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+type GroupModerationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Ban           *GroupBan              `protobuf:"bytes,2,opt,name=ban,proto3" json:"ban,omitempty"`   // GROUP_BAN uniquement
+	Mute          *GroupMute             `protobuf:"bytes,3,opt,name=mute,proto3" json:"mute,omitempty"` // GROUP_MUTE uniquement
+	Error         *Error                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupModerationResponse) Reset() {
+	*x = GroupModerationResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupModerationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupModerationResponse) ProtoMessage() {}
+
+func (x *GroupModerationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupModerationResponse.ProtoReflect.Descriptor instead.
+func (*GroupModerationResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *GroupModerationResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupModerationResponse) GetBan() *GroupBan {
+	if x != nil {
+		return x.Ban
+	}
+	return nil
+}
+
+func (x *GroupModerationResponse) GetMute() *GroupMute {
+	if x != nil {
+		return x.Mute
+	}
+	return nil
+}
+
+func (x *GroupModerationResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+// GroupBanListRequest est le payload reçu sur GROUP_BAN_LIST (admin/owner).
+type GroupBanListRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupBanListRequest) Reset() {
+	*x = GroupBanListRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupBanListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupBanListRequest) ProtoMessage() {}
+
+func (x *GroupBanListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupBanListRequest.ProtoReflect.Descriptor instead.
+func (*GroupBanListRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *GroupBanListRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *GroupBanListRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+type GroupBanListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          []*GroupBan            `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupBanListResponse) Reset() {
+	*x = GroupBanListResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupBanListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupBanListResponse) ProtoMessage() {}
+
+func (x *GroupBanListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupBanListResponse.ProtoReflect.Descriptor instead.
+func (*GroupBanListResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *GroupBanListResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupBanListResponse) GetData() []*GroupBan {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GroupBanListResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type AuditLogEntry struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	ActorId        string                 `protobuf:"bytes,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`    // UUID
+	Action         string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`                     // "ban" | "unban" | "mute" | "unmute" | "kick"
+	TargetId       string                 `protobuf:"bytes,5,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"` // UUID
+	Reason         string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	ExpiresAt      int64                  `protobuf:"varint,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // fin de sourdine, 0 sinon
+	CreatedAt      int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AuditLogEntry) Reset() {
+	*x = AuditLogEntry{}
+	mi := &file_api_v1_message_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditLogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLogEntry) ProtoMessage() {}
+
+func (x *AuditLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLogEntry.ProtoReflect.Descriptor instead.
+func (*AuditLogEntry) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *AuditLogEntry) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditLogEntry) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *AuditLogEntry) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AuditLogEntry) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *AuditLogEntry) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// GroupAuditLogRequest est le payload reçu sur GROUP_AUDIT_LOG (admin/owner) : plus récent d'abord.
+type GroupAuditLogRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ActorId        string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // UUID
+	ConversationId int32                  `protobuf:"varint,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	BeforeId       int32                  `protobuf:"varint,3,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"` // pagination : entrées d'id < before_id, 0 pour la première page
+	Limit          int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                       // 50 par défaut, 200 max
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupAuditLogRequest) Reset() {
+	*x = GroupAuditLogRequest{}
+	mi := &file_api_v1_message_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupAuditLogRequest) ProtoMessage() {}
+
+func (x *GroupAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*GroupAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *GroupAuditLogRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *GroupAuditLogRequest) GetConversationId() int32 {
+	if x != nil {
+		return x.ConversationId
+	}
+	return 0
+}
+
+func (x *GroupAuditLogRequest) GetBeforeId() int32 {
+	if x != nil {
+		return x.BeforeId
+	}
+	return 0
+}
+
+func (x *GroupAuditLogRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GroupAuditLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Data          []*AuditLogEntry       `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	Error         *Error                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupAuditLogResponse) Reset() {
+	*x = GroupAuditLogResponse{}
+	mi := &file_api_v1_message_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupAuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupAuditLogResponse) ProtoMessage() {}
+
+func (x *GroupAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_message_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*GroupAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_message_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *GroupAuditLogResponse) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GroupAuditLogResponse) GetData() []*AuditLogEntry {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GroupAuditLogResponse) GetError() *Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+var File_api_v1_message_proto protoreflect.FileDescriptor
+
+const file_api_v1_message_proto_rawDesc = "" +
+	"\n" +
+	"\x14api/v1/message.proto\x12\n" +
+	"message.v1\"\xf7\x01\n" +
+	"\x12SendMessageRequest\x12\x19\n" +
+	"\bgroup_id\x18\x01 \x01(\x05R\agroupId\x12\x1b\n" +
+	"\tsender_id\x18\x02 \x01(\tR\bsenderId\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x1e\n" +
+	"\n" +
+	"attachment\x18\x04 \x01(\tR\n" +
+	"attachment\x12'\n" +
+	"\x0fconversation_id\x18\x05 \x01(\x05R\x0econversationId\x12\x1e\n" +
+	"\vreply_to_id\x18\x06 \x01(\x05R\treplyToId\x12&\n" +
+	"\x0fforward_from_id\x18\a \x01(\x05R\rforwardFromId\"S\n" +
+	"\n" +
+	"ReplyToRef\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1b\n" +
+	"\tsender_id\x18\x02 \x01(\tR\bsenderId\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\"b\n" +
+	"\vSeenByEntry\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12\x17\n" +
+	"\aseen_at\x18\x03 \x01(\x03R\x06seenAt\"\xd7\x05\n" +
+	"\vChatMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1b\n" +
+	"\tsender_id\x18\x02 \x01(\tR\bsenderId\x12\x19\n" +
+	"\bgroup_id\x18\x03 \x01(\x05R\agroupId\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x12\x1e\n" +
+	"\n" +
+	"attachment\x18\x05 \x01(\tR\n" +
+	"attachment\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\x12'\n" +
+	"\x0fconversation_id\x18\b \x01(\x05R\x0econversationId\x12\x1f\n" +
+	"\vreceived_at\x18\t \x01(\x03R\n" +
+	"receivedAt\x12\x1e\n" +
+	"\vreply_to_id\x18\n" +
+	" \x01(\x05R\treplyToId\x12\x16\n" +
+	"\x06status\x18\v \x01(\tR\x06status\x12&\n" +
+	"\x0fforward_from_id\x18\f \x01(\x05R\rforwardFromId\x121\n" +
 	"\breply_to\x18\r \x01(\v2\x16.message.v1.ReplyToRefR\areplyTo\x120\n" +
-	"\aseen_by\x18\x0e \x03(\v2\x17.message.v1.SeenByEntryR\x06seenBy\"5\n" +
+	"\aseen_by\x18\x0e \x03(\v2\x17.message.v1.SeenByEntryR\x06seenBy\x12\x1a\n" +
+	"\bmentions\x18\x0f \x03(\tR\bmentions\x12:\n" +
+	"\flink_preview\x18\x10 \x01(\v2\x17.message.v1.LinkPreviewR\vlinkPreview\x12\x17\n" +
+	"\apoll_id\x18\x11 \x01(\x05R\x06pollId\x12\x12\n" +
+	"\x04kind\x18\x12 \x01(\tR\x04kind\x12/\n" +
+	"\x06system\x18\x13 \x01(\v2\x17.message.v1.SystemEventR\x06system\x12C\n" +
+	"\x0fattachment_info\x18\x14 \x01(\v2\x1a.message.v1.AttachmentInfoR\x0eattachmentInfo\"\xcf\x01\n" +
+	"\x0eAttachmentInfo\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bfilename\x18\x04 \x01(\tR\bfilename\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x1f\n" +
+	"\vduration_ms\x18\x06 \x01(\x03R\n" +
+	"durationMs\x12\x1a\n" +
+	"\bwaveform\x18\a \x03(\x05R\bwaveform\"\x81\x01\n" +
+	"\vSystemEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x19\n" +
+	"\bactor_id\x18\x02 \x01(\tR\aactorId\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\tR\btargetId\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\x05R\x04role\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\"\xb0\x01\n" +
+	"\vLinkPreview\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
+	"\timage_url\x18\x04 \x01(\tR\bimageUrl\x12\x1b\n" +
+	"\tsite_name\x18\x05 \x01(\tR\bsiteName\x12\x1d\n" +
+	"\n" +
+	"fetched_at\x18\x06 \x01(\x03R\tfetchedAt\"5\n" +
 	"\x05Error\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"{\n" +
@@ -2444,7 +8099,7 @@ const file_api_v1_message_proto_rawDesc = "" +
 	"\x12AckMessageResponse\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\bR\x02ok\x12+\n" +
 	"\x04data\x18\x02 \x01(\v2\x17.message.v1.ChatMessageR\x04data\x12'\n" +
-	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"\xa7\x01\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"\xb6\x02\n" +
 	"\x05Group\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
@@ -2455,7 +8110,18 @@ const file_api_v1_message_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\"\xad\x01\n" +
+	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\x12\x12\n" +
+	"\x04kind\x18\a \x01(\tR\x04kind\x12\x17\n" +
+	"\apeer_id\x18\b \x01(\tR\x06peerId\x12 \n" +
+	"\vdescription\x18\t \x01(\tR\vdescription\x12>\n" +
+	"\vpermissions\x18\n" +
+	" \x01(\v2\x1c.message.v1.GroupPermissionsR\vpermissions\"\x82\x02\n" +
+	"\x10GroupPermissions\x12/\n" +
+	"\x14only_admins_can_post\x18\x01 \x01(\bR\x11onlyAdminsCanPost\x125\n" +
+	"\x17members_can_add_members\x18\x02 \x01(\bR\x14membersCanAddMembers\x12&\n" +
+	"\x0fmembers_can_pin\x18\x03 \x01(\bR\rmembersCanPin\x12*\n" +
+	"\x11slow_mode_seconds\x18\x04 \x01(\x05R\x0fslowModeSeconds\x122\n" +
+	"\x15join_requests_enabled\x18\x05 \x01(\bR\x13joinRequestsEnabled\"\xad\x01\n" +
 	"\vGroupMember\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12'\n" +
 	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x19\n" +
@@ -2480,9 +8146,10 @@ const file_api_v1_message_proto_rawDesc = "" +
 	"\x10GroupGetResponse\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\bR\x02ok\x12%\n" +
 	"\x04data\x18\x02 \x01(\v2\x11.message.v1.GroupR\x04data\x12'\n" +
-	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"2\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"N\n" +
 	"\x17GroupListForUserRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"z\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\barchived\x18\x02 \x01(\bR\barchived\"z\n" +
 	"\x18GroupListForUserResponse\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\bR\x02ok\x12%\n" +
 	"\x04data\x18\x02 \x03(\v2\x11.message.v1.GroupR\x04data\x12'\n" +
@@ -2536,7 +8203,427 @@ const file_api_v1_message_proto_rawDesc = "" +
 	"\bgroup_id\x18\x03 \x01(\x05R\agroupId\"N\n" +
 	"\x13GroupDeleteResponse\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\bR\x02ok\x12'\n" +
-	"\x05error\x18\x02 \x01(\v2\x11.message.v1.ErrorR\x05errorBDZBgithub.com/Mathis-brgs/storm-project/services/message/api/v1;apiv1b\x06proto3"
+	"\x05error\x18\x02 \x01(\v2\x11.message.v1.ErrorR\x05error\"\xef\x02\n" +
+	"\x10ScheduledMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1b\n" +
+	"\tsender_id\x18\x02 \x01(\tR\bsenderId\x12'\n" +
+	"\x0fconversation_id\x18\x03 \x01(\x05R\x0econversationId\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x12\x1e\n" +
+	"\n" +
+	"attachment\x18\x05 \x01(\tR\n" +
+	"attachment\x12\x1e\n" +
+	"\vreply_to_id\x18\x06 \x01(\x05R\treplyToId\x12\x17\n" +
+	"\asend_at\x18\a \x01(\x03R\x06sendAt\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\t \x01(\x05R\tmessageId\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\n" +
+	" \x01(\tR\tlastError\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\x03R\tupdatedAt\"\xd1\x01\n" +
+	"\x16ScheduleMessageRequest\x12\x1b\n" +
+	"\tsender_id\x18\x01 \x01(\tR\bsenderId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x1e\n" +
+	"\n" +
+	"attachment\x18\x04 \x01(\tR\n" +
+	"attachment\x12\x1e\n" +
+	"\vreply_to_id\x18\x05 \x01(\x05R\treplyToId\x12\x17\n" +
+	"\asend_at\x18\x06 \x01(\x03R\x06sendAt\"\x84\x01\n" +
+	"\x17ScheduleMessageResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x120\n" +
+	"\x04data\x18\x02 \x01(\v2\x1c.message.v1.ScheduledMessageR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"b\n" +
+	"\x1cListScheduledMessagesRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\"\x8a\x01\n" +
+	"\x1dListScheduledMessagesResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x120\n" +
+	"\x04data\x18\x02 \x03(\v2\x1c.message.v1.ScheduledMessageR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"J\n" +
+	"\x1dCancelScheduledMessageRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x19\n" +
+	"\bactor_id\x18\x02 \x01(\tR\aactorId\"\x8b\x01\n" +
+	"\x1eCancelScheduledMessageResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x120\n" +
+	"\x04data\x18\x02 \x01(\v2\x1c.message.v1.ScheduledMessageR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"\xc1\x01\n" +
+	"\n" +
+	"MessagePin\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\x05R\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\x05R\tmessageId\x12\x1b\n" +
+	"\tpinned_by\x18\x03 \x01(\tR\bpinnedBy\x12\x1b\n" +
+	"\tpinned_at\x18\x04 \x01(\x03R\bpinnedAt\x121\n" +
+	"\amessage\x18\x05 \x01(\v2\x17.message.v1.ChatMessageR\amessage\"v\n" +
+	"\x11PinMessageRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x03 \x01(\x05R\tmessageId\"y\n" +
+	"\x12PinMessageResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12*\n" +
+	"\x04data\x18\x02 \x01(\v2\x16.message.v1.MessagePinR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"x\n" +
+	"\x13UnpinMessageRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x03 \x01(\x05R\tmessageId\"O\n" +
+	"\x14UnpinMessageResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x11.message.v1.ErrorR\x05error\"U\n" +
+	"\x0fListPinsRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\"w\n" +
+	"\x10ListPinsResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12*\n" +
+	"\x04data\x18\x02 \x03(\v2\x16.message.v1.MessagePinR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"n\n" +
+	"\n" +
+	"PollOption\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x1d\n" +
+	"\n" +
+	"vote_count\x18\x03 \x01(\x05R\tvoteCount\x12\x1b\n" +
+	"\tvoter_ids\x18\x04 \x03(\tR\bvoterIds\"\xc4\x03\n" +
+	"\x04Poll\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\x05R\tmessageId\x12'\n" +
+	"\x0fconversation_id\x18\x03 \x01(\x05R\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x12\x1a\n" +
+	"\bquestion\x18\x05 \x01(\tR\bquestion\x12!\n" +
+	"\fmulti_choice\x18\x06 \x01(\bR\vmultiChoice\x12\x1c\n" +
+	"\tanonymous\x18\a \x01(\bR\tanonymous\x12\x1b\n" +
+	"\tcloses_at\x18\b \x01(\x03R\bclosesAt\x12\x1b\n" +
+	"\tclosed_at\x18\t \x01(\x03R\bclosedAt\x12\x16\n" +
+	"\x06closed\x18\n" +
+	" \x01(\bR\x06closed\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\x03R\tcreatedAt\x120\n" +
+	"\aoptions\x18\f \x03(\v2\x16.message.v1.PollOptionR\aoptions\x12!\n" +
+	"\ftotal_voters\x18\r \x01(\x05R\vtotalVoters\x12\"\n" +
+	"\rmy_option_ids\x18\x0e \x03(\x05R\vmyOptionIds\"\xeb\x01\n" +
+	"\x11PollCreateRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x1a\n" +
+	"\bquestion\x18\x03 \x01(\tR\bquestion\x12\x18\n" +
+	"\aoptions\x18\x04 \x03(\tR\aoptions\x12!\n" +
+	"\fmulti_choice\x18\x05 \x01(\bR\vmultiChoice\x12\x1c\n" +
+	"\tanonymous\x18\x06 \x01(\bR\tanonymous\x12\x1b\n" +
+	"\tcloses_at\x18\a \x01(\x03R\bclosesAt\"s\n" +
+	"\x12PollCreateResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12$\n" +
+	"\x04data\x18\x02 \x01(\v2\x10.message.v1.PollR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"d\n" +
+	"\x0fPollVoteRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12\x17\n" +
+	"\apoll_id\x18\x02 \x01(\x05R\x06pollId\x12\x1d\n" +
+	"\n" +
+	"option_ids\x18\x03 \x03(\x05R\toptionIds\"q\n" +
+	"\x10PollVoteResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12$\n" +
+	"\x04data\x18\x02 \x01(\v2\x10.message.v1.PollR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"F\n" +
+	"\x10PollCloseRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12\x17\n" +
+	"\apoll_id\x18\x02 \x01(\x05R\x06pollId\"r\n" +
+	"\x11PollCloseResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12$\n" +
+	"\x04data\x18\x02 \x01(\v2\x10.message.v1.PollR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"D\n" +
+	"\x0ePollGetRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12\x17\n" +
+	"\apoll_id\x18\x02 \x01(\x05R\x06pollId\"p\n" +
+	"\x0fPollGetResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12$\n" +
+	"\x04data\x18\x02 \x01(\v2\x10.message.v1.PollR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"N\n" +
+	"\x18DirectGetOrCreateRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x95\x01\n" +
+	"\x19DirectGetOrCreateResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12%\n" +
+	"\x04data\x18\x02 \x01(\v2\x11.message.v1.GroupR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\x12\x18\n" +
+	"\acreated\x18\x04 \x01(\bR\acreated\"\xce\x01\n" +
+	"\x12GroupUpdateRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x04 \x01(\tR\tavatarUrl\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vupdate_mask\x18\x06 \x03(\tR\n" +
+	"updateMask\"u\n" +
+	"\x13GroupUpdateResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12%\n" +
+	"\x04data\x18\x02 \x01(\v2\x11.message.v1.GroupR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"\x97\x02\n" +
+	"\x12ConversationInvite\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12'\n" +
+	"\x0fconversation_id\x18\x03 \x01(\x05R\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\x03R\texpiresAt\x12\x19\n" +
+	"\bmax_uses\x18\x06 \x01(\x05R\amaxUses\x12\x1b\n" +
+	"\tuse_count\x18\a \x01(\x05R\buseCount\x12\x1d\n" +
+	"\n" +
+	"revoked_at\x18\b \x01(\x03R\trevokedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\"\x98\x01\n" +
+	"\x18GroupInviteCreateRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\x03R\texpiresAt\x12\x19\n" +
+	"\bmax_uses\x18\x04 \x01(\x05R\amaxUses\"\x88\x01\n" +
+	"\x19GroupInviteCreateResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x122\n" +
+	"\x04data\x18\x02 \x01(\v2\x1e.message.v1.ConversationInviteR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"\\\n" +
+	"\x16GroupInviteListRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\"\x86\x01\n" +
+	"\x17GroupInviteListResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x122\n" +
+	"\x04data\x18\x02 \x03(\v2\x1e.message.v1.ConversationInviteR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"{\n" +
+	"\x18GroupInviteRevokeRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x1b\n" +
+	"\tinvite_id\x18\x03 \x01(\x05R\binviteId\"\x88\x01\n" +
+	"\x19GroupInviteRevokeResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x122\n" +
+	"\x04data\x18\x02 \x01(\v2\x1e.message.v1.ConversationInviteR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"I\n" +
+	"\x16GroupInviteJoinRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\xaa\x01\n" +
+	"\x17GroupInviteJoinResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12%\n" +
+	"\x04data\x18\x02 \x01(\v2\x11.message.v1.GroupR\x04data\x12/\n" +
+	"\x06member\x18\x03 \x01(\v2\x17.message.v1.GroupMemberR\x06member\x12'\n" +
+	"\x05error\x18\x04 \x01(\v2\x11.message.v1.ErrorR\x05error\"\xfa\x01\n" +
+	"\x17ConversationJoinRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"decided_by\x18\x06 \x01(\tR\tdecidedBy\x12\x1d\n" +
+	"\n" +
+	"decided_at\x18\a \x01(\x03R\tdecidedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\x03R\tcreatedAt\"w\n" +
+	"\x17GroupJoinRequestRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x8c\x01\n" +
+	"\x18GroupJoinRequestResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x127\n" +
+	"\x04data\x18\x02 \x01(\v2#.message.v1.ConversationJoinRequestR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"\x95\x01\n" +
+	"\x16GroupJoinDecideRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x03 \x01(\x05R\trequestId\x12\x18\n" +
+	"\aapprove\x18\x04 \x01(\bR\aapprove\"\xbc\x01\n" +
+	"\x17GroupJoinDecideResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x127\n" +
+	"\x04data\x18\x02 \x01(\v2#.message.v1.ConversationJoinRequestR\x04data\x12/\n" +
+	"\x06member\x18\x03 \x01(\v2\x17.message.v1.GroupMemberR\x06member\x12'\n" +
+	"\x05error\x18\x04 \x01(\v2\x11.message.v1.ErrorR\x05error\"Z\n" +
+	"\x14GroupJoinListRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\"\x89\x01\n" +
+	"\x15GroupJoinListResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x127\n" +
+	"\x04data\x18\x02 \x03(\v2#.message.v1.ConversationJoinRequestR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"|\n" +
+	"\x1dGroupTransferOwnershipRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"\xcf\x01\n" +
+	"\x1eGroupTransferOwnershipResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12>\n" +
+	"\x0eprevious_owner\x18\x02 \x01(\v2\x17.message.v1.GroupMemberR\rpreviousOwner\x124\n" +
+	"\tnew_owner\x18\x03 \x01(\v2\x17.message.v1.GroupMemberR\bnewOwner\x12'\n" +
+	"\x05error\x18\x04 \x01(\v2\x11.message.v1.ErrorR\x05error\"\x96\x01\n" +
+	"\x11GroupMemberResult\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x0e\n" +
+	"\x02ok\x18\x02 \x01(\bR\x02ok\x12/\n" +
+	"\x06member\x18\x03 \x01(\v2\x17.message.v1.GroupMemberR\x06member\x12'\n" +
+	"\x05error\x18\x04 \x01(\v2\x11.message.v1.ErrorR\x05error\"\x8f\x01\n" +
+	"\x1aGroupAddMembersBulkRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x19\n" +
+	"\buser_ids\x18\x03 \x03(\tR\auserIds\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\x05R\x04role\"\x8f\x01\n" +
+	"\x1bGroupAddMembersBulkResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x127\n" +
+	"\aresults\x18\x02 \x03(\v2\x1d.message.v1.GroupMemberResultR\aresults\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"~\n" +
+	"\x1dGroupRemoveMembersBulkRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x19\n" +
+	"\buser_ids\x18\x03 \x03(\tR\auserIds\"\x92\x01\n" +
+	"\x1eGroupRemoveMembersBulkResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x127\n" +
+	"\aresults\x18\x02 \x03(\v2\x1d.message.v1.GroupMemberResultR\aresults\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"u\n" +
+	"\x13GroupArchiveRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x1a\n" +
+	"\barchived\x18\x03 \x01(\bR\barchived\"\x8c\x01\n" +
+	"\x14GroupArchiveResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x1a\n" +
+	"\barchived\x18\x02 \x01(\bR\barchived\x12\x1f\n" +
+	"\varchived_at\x18\x03 \x01(\x03R\n" +
+	"archivedAt\x12'\n" +
+	"\x05error\x18\x04 \x01(\v2\x11.message.v1.ErrorR\x05error\"Y\n" +
+	"\x13GroupRestoreRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\"\x95\x01\n" +
+	"\x14GroupRestoreResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12%\n" +
+	"\x04data\x18\x02 \x01(\v2\x11.message.v1.GroupR\x04data\x12\x1d\n" +
+	"\n" +
+	"member_ids\x18\x03 \x03(\tR\tmemberIds\x12'\n" +
+	"\x05error\x18\x04 \x01(\v2\x11.message.v1.ErrorR\x05error\"\xc4\x01\n" +
+	"\x1dGroupUpdatePermissionsRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12>\n" +
+	"\vpermissions\x18\x03 \x01(\v2\x1c.message.v1.GroupPermissionsR\vpermissions\x12\x1f\n" +
+	"\vupdate_mask\x18\x04 \x03(\tR\n" +
+	"updateMask\"\x80\x01\n" +
+	"\x1eGroupUpdatePermissionsResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12%\n" +
+	"\x04data\x18\x02 \x01(\v2\x11.message.v1.GroupR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"h\n" +
+	"\tUserBlock\x12\x1d\n" +
+	"\n" +
+	"blocker_id\x18\x01 \x01(\tR\tblockerId\x12\x1d\n" +
+	"\n" +
+	"blocked_id\x18\x02 \x01(\tR\tblockedId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\x03R\tcreatedAt\"F\n" +
+	"\x10UserBlockRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"w\n" +
+	"\x11UserBlockResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12)\n" +
+	"\x04data\x18\x02 \x01(\v2\x15.message.v1.UserBlockR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"1\n" +
+	"\x14UserBlockListRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\"{\n" +
+	"\x15UserBlockListResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12)\n" +
+	"\x04data\x18\x02 \x03(\v2\x15.message.v1.UserBlockR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"\x99\x02\n" +
+	"\rMessageReport\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\x05R\tmessageId\x12'\n" +
+	"\x0fconversation_id\x18\x03 \x01(\x05R\x0econversationId\x12\x1f\n" +
+	"\vreporter_id\x18\x04 \x01(\tR\n" +
+	"reporterId\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1f\n" +
+	"\vresolved_by\x18\a \x01(\tR\n" +
+	"resolvedBy\x12\x1f\n" +
+	"\vresolved_at\x18\b \x01(\x03R\n" +
+	"resolvedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\"h\n" +
+	"\x14MessageReportRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\x05R\tmessageId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x7f\n" +
+	"\x15MessageReportResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12-\n" +
+	"\x04data\x18\x02 \x01(\v2\x19.message.v1.MessageReportR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"v\n" +
+	"\x18MessageReportListRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"\x83\x01\n" +
+	"\x19MessageReportListResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12-\n" +
+	"\x04data\x18\x02 \x03(\v2\x19.message.v1.MessageReportR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"\x96\x01\n" +
+	"\x1bMessageReportResolveRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x1b\n" +
+	"\treport_id\x18\x03 \x01(\x05R\breportId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"\x86\x01\n" +
+	"\x1cMessageReportResolveResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12-\n" +
+	"\x04data\x18\x02 \x01(\v2\x19.message.v1.MessageReportR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"\xa0\x01\n" +
+	"\bGroupBan\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\x05R\x0econversationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tbanned_by\x18\x03 \x01(\tR\bbannedBy\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\"\xa8\x01\n" +
+	"\tGroupMute\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\x05R\x0econversationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
+	"\bmuted_by\x18\x03 \x01(\tR\amutedBy\x12\x1f\n" +
+	"\vmuted_until\x18\x04 \x01(\x03R\n" +
+	"mutedUntil\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\"\xb8\x01\n" +
+	"\x16GroupModerationRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12)\n" +
+	"\x10duration_seconds\x18\x05 \x01(\x03R\x0fdurationSeconds\"\xa5\x01\n" +
+	"\x17GroupModerationResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12&\n" +
+	"\x03ban\x18\x02 \x01(\v2\x14.message.v1.GroupBanR\x03ban\x12)\n" +
+	"\x04mute\x18\x03 \x01(\v2\x15.message.v1.GroupMuteR\x04mute\x12'\n" +
+	"\x05error\x18\x04 \x01(\v2\x11.message.v1.ErrorR\x05error\"Y\n" +
+	"\x13GroupBanListRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\"y\n" +
+	"\x14GroupBanListResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12(\n" +
+	"\x04data\x18\x02 \x03(\v2\x14.message.v1.GroupBanR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05error\"\xee\x01\n" +
+	"\rAuditLogEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x19\n" +
+	"\bactor_id\x18\x03 \x01(\tR\aactorId\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x1b\n" +
+	"\ttarget_id\x18\x05 \x01(\tR\btargetId\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\x03R\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\x03R\tcreatedAt\"\x8d\x01\n" +
+	"\x14GroupAuditLogRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\x05R\x0econversationId\x12\x1b\n" +
+	"\tbefore_id\x18\x03 \x01(\x05R\bbeforeId\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\x7f\n" +
+	"\x15GroupAuditLogResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12-\n" +
+	"\x04data\x18\x02 \x03(\v2\x19.message.v1.AuditLogEntryR\x04data\x12'\n" +
+	"\x05error\x18\x03 \x01(\v2\x11.message.v1.ErrorR\x05errorBDZBgithub.com/Mathis-brgs/storm-project/services/message/api/v1;apiv1b\x06proto3"
 
 var (
 	file_api_v1_message_proto_rawDescOnce sync.Once
@@ -2550,79 +8637,238 @@ func file_api_v1_message_proto_rawDescGZIP() []byte {
 	return file_api_v1_message_proto_rawDescData
 }
 
-var file_api_v1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
+var file_api_v1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 118)
 var file_api_v1_message_proto_goTypes = []any{
-	(*SendMessageRequest)(nil),        // 0: message.v1.SendMessageRequest
-	(*ReplyToRef)(nil),                // 1: message.v1.ReplyToRef
-	(*SeenByEntry)(nil),               // 2: message.v1.SeenByEntry
-	(*ChatMessage)(nil),               // 3: message.v1.ChatMessage
-	(*Error)(nil),                     // 4: message.v1.Error
-	(*SendMessageResponse)(nil),       // 5: message.v1.SendMessageResponse
-	(*GetMessageRequest)(nil),         // 6: message.v1.GetMessageRequest
-	(*GetMessageResponse)(nil),        // 7: message.v1.GetMessageResponse
-	(*ListMessagesRequest)(nil),       // 8: message.v1.ListMessagesRequest
-	(*ListMessagesResponse)(nil),      // 9: message.v1.ListMessagesResponse
-	(*UpdateMessageRequest)(nil),      // 10: message.v1.UpdateMessageRequest
-	(*UpdateMessageResponse)(nil),     // 11: message.v1.UpdateMessageResponse
-	(*DeleteMessageRequest)(nil),      // 12: message.v1.DeleteMessageRequest
-	(*DeleteMessageResponse)(nil),     // 13: message.v1.DeleteMessageResponse
-	(*AckMessageRequest)(nil),         // 14: message.v1.AckMessageRequest
-	(*AckMessageResponse)(nil),        // 15: message.v1.AckMessageResponse
-	(*Group)(nil),                     // 16: message.v1.Group
-	(*GroupMember)(nil),               // 17: message.v1.GroupMember
-	(*GroupCreateRequest)(nil),        // 18: message.v1.GroupCreateRequest
-	(*GroupCreateResponse)(nil),       // 19: message.v1.GroupCreateResponse
-	(*GroupGetRequest)(nil),           // 20: message.v1.GroupGetRequest
-	(*GroupGetResponse)(nil),          // 21: message.v1.GroupGetResponse
-	(*GroupListForUserRequest)(nil),   // 22: message.v1.GroupListForUserRequest
-	(*GroupListForUserResponse)(nil),  // 23: message.v1.GroupListForUserResponse
-	(*GroupAddMemberRequest)(nil),     // 24: message.v1.GroupAddMemberRequest
-	(*GroupAddMemberResponse)(nil),    // 25: message.v1.GroupAddMemberResponse
-	(*GroupRemoveMemberRequest)(nil),  // 26: message.v1.GroupRemoveMemberRequest
-	(*GroupRemoveMemberResponse)(nil), // 27: message.v1.GroupRemoveMemberResponse
-	(*GroupListMembersRequest)(nil),   // 28: message.v1.GroupListMembersRequest
-	(*GroupListMembersResponse)(nil),  // 29: message.v1.GroupListMembersResponse
-	(*GroupUpdateRoleRequest)(nil),    // 30: message.v1.GroupUpdateRoleRequest
-	(*GroupUpdateRoleResponse)(nil),   // 31: message.v1.GroupUpdateRoleResponse
-	(*GroupLeaveRequest)(nil),         // 32: message.v1.GroupLeaveRequest
-	(*GroupLeaveResponse)(nil),        // 33: message.v1.GroupLeaveResponse
-	(*GroupDeleteRequest)(nil),        // 34: message.v1.GroupDeleteRequest
-	(*GroupDeleteResponse)(nil),       // 35: message.v1.GroupDeleteResponse
+	(*SendMessageRequest)(nil),             // 0: message.v1.SendMessageRequest
+	(*ReplyToRef)(nil),                     // 1: message.v1.ReplyToRef
+	(*SeenByEntry)(nil),                    // 2: message.v1.SeenByEntry
+	(*ChatMessage)(nil),                    // 3: message.v1.ChatMessage
+	(*AttachmentInfo)(nil),                 // 4: message.v1.AttachmentInfo
+	(*SystemEvent)(nil),                    // 5: message.v1.SystemEvent
+	(*LinkPreview)(nil),                    // 6: message.v1.LinkPreview
+	(*Error)(nil),                          // 7: message.v1.Error
+	(*SendMessageResponse)(nil),            // 8: message.v1.SendMessageResponse
+	(*GetMessageRequest)(nil),              // 9: message.v1.GetMessageRequest
+	(*GetMessageResponse)(nil),             // 10: message.v1.GetMessageResponse
+	(*ListMessagesRequest)(nil),            // 11: message.v1.ListMessagesRequest
+	(*ListMessagesResponse)(nil),           // 12: message.v1.ListMessagesResponse
+	(*UpdateMessageRequest)(nil),           // 13: message.v1.UpdateMessageRequest
+	(*UpdateMessageResponse)(nil),          // 14: message.v1.UpdateMessageResponse
+	(*DeleteMessageRequest)(nil),           // 15: message.v1.DeleteMessageRequest
+	(*DeleteMessageResponse)(nil),          // 16: message.v1.DeleteMessageResponse
+	(*AckMessageRequest)(nil),              // 17: message.v1.AckMessageRequest
+	(*AckMessageResponse)(nil),             // 18: message.v1.AckMessageResponse
+	(*Group)(nil),                          // 19: message.v1.Group
+	(*GroupPermissions)(nil),               // 20: message.v1.GroupPermissions
+	(*GroupMember)(nil),                    // 21: message.v1.GroupMember
+	(*GroupCreateRequest)(nil),             // 22: message.v1.GroupCreateRequest
+	(*GroupCreateResponse)(nil),            // 23: message.v1.GroupCreateResponse
+	(*GroupGetRequest)(nil),                // 24: message.v1.GroupGetRequest
+	(*GroupGetResponse)(nil),               // 25: message.v1.GroupGetResponse
+	(*GroupListForUserRequest)(nil),        // 26: message.v1.GroupListForUserRequest
+	(*GroupListForUserResponse)(nil),       // 27: message.v1.GroupListForUserResponse
+	(*GroupAddMemberRequest)(nil),          // 28: message.v1.GroupAddMemberRequest
+	(*GroupAddMemberResponse)(nil),         // 29: message.v1.GroupAddMemberResponse
+	(*GroupRemoveMemberRequest)(nil),       // 30: message.v1.GroupRemoveMemberRequest
+	(*GroupRemoveMemberResponse)(nil),      // 31: message.v1.GroupRemoveMemberResponse
+	(*GroupListMembersRequest)(nil),        // 32: message.v1.GroupListMembersRequest
+	(*GroupListMembersResponse)(nil),       // 33: message.v1.GroupListMembersResponse
+	(*GroupUpdateRoleRequest)(nil),         // 34: message.v1.GroupUpdateRoleRequest
+	(*GroupUpdateRoleResponse)(nil),        // 35: message.v1.GroupUpdateRoleResponse
+	(*GroupLeaveRequest)(nil),              // 36: message.v1.GroupLeaveRequest
+	(*GroupLeaveResponse)(nil),             // 37: message.v1.GroupLeaveResponse
+	(*GroupDeleteRequest)(nil),             // 38: message.v1.GroupDeleteRequest
+	(*GroupDeleteResponse)(nil),            // 39: message.v1.GroupDeleteResponse
+	(*ScheduledMessage)(nil),               // 40: message.v1.ScheduledMessage
+	(*ScheduleMessageRequest)(nil),         // 41: message.v1.ScheduleMessageRequest
+	(*ScheduleMessageResponse)(nil),        // 42: message.v1.ScheduleMessageResponse
+	(*ListScheduledMessagesRequest)(nil),   // 43: message.v1.ListScheduledMessagesRequest
+	(*ListScheduledMessagesResponse)(nil),  // 44: message.v1.ListScheduledMessagesResponse
+	(*CancelScheduledMessageRequest)(nil),  // 45: message.v1.CancelScheduledMessageRequest
+	(*CancelScheduledMessageResponse)(nil), // 46: message.v1.CancelScheduledMessageResponse
+	(*MessagePin)(nil),                     // 47: message.v1.MessagePin
+	(*PinMessageRequest)(nil),              // 48: message.v1.PinMessageRequest
+	(*PinMessageResponse)(nil),             // 49: message.v1.PinMessageResponse
+	(*UnpinMessageRequest)(nil),            // 50: message.v1.UnpinMessageRequest
+	(*UnpinMessageResponse)(nil),           // 51: message.v1.UnpinMessageResponse
+	(*ListPinsRequest)(nil),                // 52: message.v1.ListPinsRequest
+	(*ListPinsResponse)(nil),               // 53: message.v1.ListPinsResponse
+	(*PollOption)(nil),                     // 54: message.v1.PollOption
+	(*Poll)(nil),                           // 55: message.v1.Poll
+	(*PollCreateRequest)(nil),              // 56: message.v1.PollCreateRequest
+	(*PollCreateResponse)(nil),             // 57: message.v1.PollCreateResponse
+	(*PollVoteRequest)(nil),                // 58: message.v1.PollVoteRequest
+	(*PollVoteResponse)(nil),               // 59: message.v1.PollVoteResponse
+	(*PollCloseRequest)(nil),               // 60: message.v1.PollCloseRequest
+	(*PollCloseResponse)(nil),              // 61: message.v1.PollCloseResponse
+	(*PollGetRequest)(nil),                 // 62: message.v1.PollGetRequest
+	(*PollGetResponse)(nil),                // 63: message.v1.PollGetResponse
+	(*DirectGetOrCreateRequest)(nil),       // 64: message.v1.DirectGetOrCreateRequest
+	(*DirectGetOrCreateResponse)(nil),      // 65: message.v1.DirectGetOrCreateResponse
+	(*GroupUpdateRequest)(nil),             // 66: message.v1.GroupUpdateRequest
+	(*GroupUpdateResponse)(nil),            // 67: message.v1.GroupUpdateResponse
+	(*ConversationInvite)(nil),             // 68: message.v1.ConversationInvite
+	(*GroupInviteCreateRequest)(nil),       // 69: message.v1.GroupInviteCreateRequest
+	(*GroupInviteCreateResponse)(nil),      // 70: message.v1.GroupInviteCreateResponse
+	(*GroupInviteListRequest)(nil),         // 71: message.v1.GroupInviteListRequest
+	(*GroupInviteListResponse)(nil),        // 72: message.v1.GroupInviteListResponse
+	(*GroupInviteRevokeRequest)(nil),       // 73: message.v1.GroupInviteRevokeRequest
+	(*GroupInviteRevokeResponse)(nil),      // 74: message.v1.GroupInviteRevokeResponse
+	(*GroupInviteJoinRequest)(nil),         // 75: message.v1.GroupInviteJoinRequest
+	(*GroupInviteJoinResponse)(nil),        // 76: message.v1.GroupInviteJoinResponse
+	(*ConversationJoinRequest)(nil),        // 77: message.v1.ConversationJoinRequest
+	(*GroupJoinRequestRequest)(nil),        // 78: message.v1.GroupJoinRequestRequest
+	(*GroupJoinRequestResponse)(nil),       // 79: message.v1.GroupJoinRequestResponse
+	(*GroupJoinDecideRequest)(nil),         // 80: message.v1.GroupJoinDecideRequest
+	(*GroupJoinDecideResponse)(nil),        // 81: message.v1.GroupJoinDecideResponse
+	(*GroupJoinListRequest)(nil),           // 82: message.v1.GroupJoinListRequest
+	(*GroupJoinListResponse)(nil),          // 83: message.v1.GroupJoinListResponse
+	(*GroupTransferOwnershipRequest)(nil),  // 84: message.v1.GroupTransferOwnershipRequest
+	(*GroupTransferOwnershipResponse)(nil), // 85: message.v1.GroupTransferOwnershipResponse
+	(*GroupMemberResult)(nil),              // 86: message.v1.GroupMemberResult
+	(*GroupAddMembersBulkRequest)(nil),     // 87: message.v1.GroupAddMembersBulkRequest
+	(*GroupAddMembersBulkResponse)(nil),    // 88: message.v1.GroupAddMembersBulkResponse
+	(*GroupRemoveMembersBulkRequest)(nil),  // 89: message.v1.GroupRemoveMembersBulkRequest
+	(*GroupRemoveMembersBulkResponse)(nil), // 90: message.v1.GroupRemoveMembersBulkResponse
+	(*GroupArchiveRequest)(nil),            // 91: message.v1.GroupArchiveRequest
+	(*GroupArchiveResponse)(nil),           // 92: message.v1.GroupArchiveResponse
+	(*GroupRestoreRequest)(nil),            // 93: message.v1.GroupRestoreRequest
+	(*GroupRestoreResponse)(nil),           // 94: message.v1.GroupRestoreResponse
+	(*GroupUpdatePermissionsRequest)(nil),  // 95: message.v1.GroupUpdatePermissionsRequest
+	(*GroupUpdatePermissionsResponse)(nil), // 96: message.v1.GroupUpdatePermissionsResponse
+	(*UserBlock)(nil),                      // 97: message.v1.UserBlock
+	(*UserBlockRequest)(nil),               // 98: message.v1.UserBlockRequest
+	(*UserBlockResponse)(nil),              // 99: message.v1.UserBlockResponse
+	(*UserBlockListRequest)(nil),           // 100: message.v1.UserBlockListRequest
+	(*UserBlockListResponse)(nil),          // 101: message.v1.UserBlockListResponse
+	(*MessageReport)(nil),                  // 102: message.v1.MessageReport
+	(*MessageReportRequest)(nil),           // 103: message.v1.MessageReportRequest
+	(*MessageReportResponse)(nil),          // 104: message.v1.MessageReportResponse
+	(*MessageReportListRequest)(nil),       // 105: message.v1.MessageReportListRequest
+	(*MessageReportListResponse)(nil),      // 106: message.v1.MessageReportListResponse
+	(*MessageReportResolveRequest)(nil),    // 107: message.v1.MessageReportResolveRequest
+	(*MessageReportResolveResponse)(nil),   // 108: message.v1.MessageReportResolveResponse
+	(*GroupBan)(nil),                       // 109: message.v1.GroupBan
+	(*GroupMute)(nil),                      // 110: message.v1.GroupMute
+	(*GroupModerationRequest)(nil),         // 111: message.v1.GroupModerationRequest
+	(*GroupModerationResponse)(nil),        // 112: message.v1.GroupModerationResponse
+	(*GroupBanListRequest)(nil),            // 113: message.v1.GroupBanListRequest
+	(*GroupBanListResponse)(nil),           // 114: message.v1.GroupBanListResponse
+	(*AuditLogEntry)(nil),                  // 115: message.v1.AuditLogEntry
+	(*GroupAuditLogRequest)(nil),           // 116: message.v1.GroupAuditLogRequest
+	(*GroupAuditLogResponse)(nil),          // 117: message.v1.GroupAuditLogResponse
 }
 var file_api_v1_message_proto_depIdxs = []int32{
-	1,  // 0: message.v1.ChatMessage.reply_to:type_name -> message.v1.ReplyToRef
-	2,  // 1: message.v1.ChatMessage.seen_by:type_name -> message.v1.SeenByEntry
-	3,  // 2: message.v1.SendMessageResponse.data:type_name -> message.v1.ChatMessage
-	4,  // 3: message.v1.SendMessageResponse.error:type_name -> message.v1.Error
-	3,  // 4: message.v1.GetMessageResponse.data:type_name -> message.v1.ChatMessage
-	4,  // 5: message.v1.GetMessageResponse.error:type_name -> message.v1.Error
-	3,  // 6: message.v1.ListMessagesResponse.data:type_name -> message.v1.ChatMessage
-	4,  // 7: message.v1.ListMessagesResponse.error:type_name -> message.v1.Error
-	3,  // 8: message.v1.UpdateMessageResponse.data:type_name -> message.v1.ChatMessage
-	4,  // 9: message.v1.UpdateMessageResponse.error:type_name -> message.v1.Error
-	4,  // 10: message.v1.DeleteMessageResponse.error:type_name -> message.v1.Error
-	3,  // 11: message.v1.AckMessageResponse.data:type_name -> message.v1.ChatMessage
-	4,  // 12: message.v1.AckMessageResponse.error:type_name -> message.v1.Error
-	16, // 13: message.v1.GroupCreateResponse.data:type_name -> message.v1.Group
-	4,  // 14: message.v1.GroupCreateResponse.error:type_name -> message.v1.Error
-	16, // 15: message.v1.GroupGetResponse.data:type_name -> message.v1.Group
-	4,  // 16: message.v1.GroupGetResponse.error:type_name -> message.v1.Error
-	16, // 17: message.v1.GroupListForUserResponse.data:type_name -> message.v1.Group
-	4,  // 18: message.v1.GroupListForUserResponse.error:type_name -> message.v1.Error
-	17, // 19: message.v1.GroupAddMemberResponse.data:type_name -> message.v1.GroupMember
-	4,  // 20: message.v1.GroupAddMemberResponse.error:type_name -> message.v1.Error
-	4,  // 21: message.v1.GroupRemoveMemberResponse.error:type_name -> message.v1.Error
-	17, // 22: message.v1.GroupListMembersResponse.data:type_name -> message.v1.GroupMember
-	4,  // 23: message.v1.GroupListMembersResponse.error:type_name -> message.v1.Error
-	17, // 24: message.v1.GroupUpdateRoleResponse.data:type_name -> message.v1.GroupMember
-	4,  // 25: message.v1.GroupUpdateRoleResponse.error:type_name -> message.v1.Error
-	4,  // 26: message.v1.GroupLeaveResponse.error:type_name -> message.v1.Error
-	4,  // 27: message.v1.GroupDeleteResponse.error:type_name -> message.v1.Error
-	28, // [28:28] is the sub-list for method output_type
-	28, // [28:28] is the sub-list for method input_type
-	28, // [28:28] is the sub-list for extension type_name
-	28, // [28:28] is the sub-list for extension extendee
-	0,  // [0:28] is the sub-list for field type_name
+	1,   // 0: message.v1.ChatMessage.reply_to:type_name -> message.v1.ReplyToRef
+	2,   // 1: message.v1.ChatMessage.seen_by:type_name -> message.v1.SeenByEntry
+	6,   // 2: message.v1.ChatMessage.link_preview:type_name -> message.v1.LinkPreview
+	5,   // 3: message.v1.ChatMessage.system:type_name -> message.v1.SystemEvent
+	4,   // 4: message.v1.ChatMessage.attachment_info:type_name -> message.v1.AttachmentInfo
+	3,   // 5: message.v1.SendMessageResponse.data:type_name -> message.v1.ChatMessage
+	7,   // 6: message.v1.SendMessageResponse.error:type_name -> message.v1.Error
+	3,   // 7: message.v1.GetMessageResponse.data:type_name -> message.v1.ChatMessage
+	7,   // 8: message.v1.GetMessageResponse.error:type_name -> message.v1.Error
+	3,   // 9: message.v1.ListMessagesResponse.data:type_name -> message.v1.ChatMessage
+	7,   // 10: message.v1.ListMessagesResponse.error:type_name -> message.v1.Error
+	3,   // 11: message.v1.UpdateMessageResponse.data:type_name -> message.v1.ChatMessage
+	7,   // 12: message.v1.UpdateMessageResponse.error:type_name -> message.v1.Error
+	7,   // 13: message.v1.DeleteMessageResponse.error:type_name -> message.v1.Error
+	3,   // 14: message.v1.AckMessageResponse.data:type_name -> message.v1.ChatMessage
+	7,   // 15: message.v1.AckMessageResponse.error:type_name -> message.v1.Error
+	20,  // 16: message.v1.Group.permissions:type_name -> message.v1.GroupPermissions
+	19,  // 17: message.v1.GroupCreateResponse.data:type_name -> message.v1.Group
+	7,   // 18: message.v1.GroupCreateResponse.error:type_name -> message.v1.Error
+	19,  // 19: message.v1.GroupGetResponse.data:type_name -> message.v1.Group
+	7,   // 20: message.v1.GroupGetResponse.error:type_name -> message.v1.Error
+	19,  // 21: message.v1.GroupListForUserResponse.data:type_name -> message.v1.Group
+	7,   // 22: message.v1.GroupListForUserResponse.error:type_name -> message.v1.Error
+	21,  // 23: message.v1.GroupAddMemberResponse.data:type_name -> message.v1.GroupMember
+	7,   // 24: message.v1.GroupAddMemberResponse.error:type_name -> message.v1.Error
+	7,   // 25: message.v1.GroupRemoveMemberResponse.error:type_name -> message.v1.Error
+	21,  // 26: message.v1.GroupListMembersResponse.data:type_name -> message.v1.GroupMember
+	7,   // 27: message.v1.GroupListMembersResponse.error:type_name -> message.v1.Error
+	21,  // 28: message.v1.GroupUpdateRoleResponse.data:type_name -> message.v1.GroupMember
+	7,   // 29: message.v1.GroupUpdateRoleResponse.error:type_name -> message.v1.Error
+	7,   // 30: message.v1.GroupLeaveResponse.error:type_name -> message.v1.Error
+	7,   // 31: message.v1.GroupDeleteResponse.error:type_name -> message.v1.Error
+	40,  // 32: message.v1.ScheduleMessageResponse.data:type_name -> message.v1.ScheduledMessage
+	7,   // 33: message.v1.ScheduleMessageResponse.error:type_name -> message.v1.Error
+	40,  // 34: message.v1.ListScheduledMessagesResponse.data:type_name -> message.v1.ScheduledMessage
+	7,   // 35: message.v1.ListScheduledMessagesResponse.error:type_name -> message.v1.Error
+	40,  // 36: message.v1.CancelScheduledMessageResponse.data:type_name -> message.v1.ScheduledMessage
+	7,   // 37: message.v1.CancelScheduledMessageResponse.error:type_name -> message.v1.Error
+	3,   // 38: message.v1.MessagePin.message:type_name -> message.v1.ChatMessage
+	47,  // 39: message.v1.PinMessageResponse.data:type_name -> message.v1.MessagePin
+	7,   // 40: message.v1.PinMessageResponse.error:type_name -> message.v1.Error
+	7,   // 41: message.v1.UnpinMessageResponse.error:type_name -> message.v1.Error
+	47,  // 42: message.v1.ListPinsResponse.data:type_name -> message.v1.MessagePin
+	7,   // 43: message.v1.ListPinsResponse.error:type_name -> message.v1.Error
+	54,  // 44: message.v1.Poll.options:type_name -> message.v1.PollOption
+	55,  // 45: message.v1.PollCreateResponse.data:type_name -> message.v1.Poll
+	7,   // 46: message.v1.PollCreateResponse.error:type_name -> message.v1.Error
+	55,  // 47: message.v1.PollVoteResponse.data:type_name -> message.v1.Poll
+	7,   // 48: message.v1.PollVoteResponse.error:type_name -> message.v1.Error
+	55,  // 49: message.v1.PollCloseResponse.data:type_name -> message.v1.Poll
+	7,   // 50: message.v1.PollCloseResponse.error:type_name -> message.v1.Error
+	55,  // 51: message.v1.PollGetResponse.data:type_name -> message.v1.Poll
+	7,   // 52: message.v1.PollGetResponse.error:type_name -> message.v1.Error
+	19,  // 53: message.v1.DirectGetOrCreateResponse.data:type_name -> message.v1.Group
+	7,   // 54: message.v1.DirectGetOrCreateResponse.error:type_name -> message.v1.Error
+	19,  // 55: message.v1.GroupUpdateResponse.data:type_name -> message.v1.Group
+	7,   // 56: message.v1.GroupUpdateResponse.error:type_name -> message.v1.Error
+	68,  // 57: message.v1.GroupInviteCreateResponse.data:type_name -> message.v1.ConversationInvite
+	7,   // 58: message.v1.GroupInviteCreateResponse.error:type_name -> message.v1.Error
+	68,  // 59: message.v1.GroupInviteListResponse.data:type_name -> message.v1.ConversationInvite
+	7,   // 60: message.v1.GroupInviteListResponse.error:type_name -> message.v1.Error
+	68,  // 61: message.v1.GroupInviteRevokeResponse.data:type_name -> message.v1.ConversationInvite
+	7,   // 62: message.v1.GroupInviteRevokeResponse.error:type_name -> message.v1.Error
+	19,  // 63: message.v1.GroupInviteJoinResponse.data:type_name -> message.v1.Group
+	21,  // 64: message.v1.GroupInviteJoinResponse.member:type_name -> message.v1.GroupMember
+	7,   // 65: message.v1.GroupInviteJoinResponse.error:type_name -> message.v1.Error
+	77,  // 66: message.v1.GroupJoinRequestResponse.data:type_name -> message.v1.ConversationJoinRequest
+	7,   // 67: message.v1.GroupJoinRequestResponse.error:type_name -> message.v1.Error
+	77,  // 68: message.v1.GroupJoinDecideResponse.data:type_name -> message.v1.ConversationJoinRequest
+	21,  // 69: message.v1.GroupJoinDecideResponse.member:type_name -> message.v1.GroupMember
+	7,   // 70: message.v1.GroupJoinDecideResponse.error:type_name -> message.v1.Error
+	77,  // 71: message.v1.GroupJoinListResponse.data:type_name -> message.v1.ConversationJoinRequest
+	7,   // 72: message.v1.GroupJoinListResponse.error:type_name -> message.v1.Error
+	21,  // 73: message.v1.GroupTransferOwnershipResponse.previous_owner:type_name -> message.v1.GroupMember
+	21,  // 74: message.v1.GroupTransferOwnershipResponse.new_owner:type_name -> message.v1.GroupMember
+	7,   // 75: message.v1.GroupTransferOwnershipResponse.error:type_name -> message.v1.Error
+	21,  // 76: message.v1.GroupMemberResult.member:type_name -> message.v1.GroupMember
+	7,   // 77: message.v1.GroupMemberResult.error:type_name -> message.v1.Error
+	86,  // 78: message.v1.GroupAddMembersBulkResponse.results:type_name -> message.v1.GroupMemberResult
+	7,   // 79: message.v1.GroupAddMembersBulkResponse.error:type_name -> message.v1.Error
+	86,  // 80: message.v1.GroupRemoveMembersBulkResponse.results:type_name -> message.v1.GroupMemberResult
+	7,   // 81: message.v1.GroupRemoveMembersBulkResponse.error:type_name -> message.v1.Error
+	7,   // 82: message.v1.GroupArchiveResponse.error:type_name -> message.v1.Error
+	19,  // 83: message.v1.GroupRestoreResponse.data:type_name -> message.v1.Group
+	7,   // 84: message.v1.GroupRestoreResponse.error:type_name -> message.v1.Error
+	20,  // 85: message.v1.GroupUpdatePermissionsRequest.permissions:type_name -> message.v1.GroupPermissions
+	19,  // 86: message.v1.GroupUpdatePermissionsResponse.data:type_name -> message.v1.Group
+	7,   // 87: message.v1.GroupUpdatePermissionsResponse.error:type_name -> message.v1.Error
+	97,  // 88: message.v1.UserBlockResponse.data:type_name -> message.v1.UserBlock
+	7,   // 89: message.v1.UserBlockResponse.error:type_name -> message.v1.Error
+	97,  // 90: message.v1.UserBlockListResponse.data:type_name -> message.v1.UserBlock
+	7,   // 91: message.v1.UserBlockListResponse.error:type_name -> message.v1.Error
+	102, // 92: message.v1.MessageReportResponse.data:type_name -> message.v1.MessageReport
+	7,   // 93: message.v1.MessageReportResponse.error:type_name -> message.v1.Error
+	102, // 94: message.v1.MessageReportListResponse.data:type_name -> message.v1.MessageReport
+	7,   // 95: message.v1.MessageReportListResponse.error:type_name -> message.v1.Error
+	102, // 96: message.v1.MessageReportResolveResponse.data:type_name -> message.v1.MessageReport
+	7,   // 97: message.v1.MessageReportResolveResponse.error:type_name -> message.v1.Error
+	109, // 98: message.v1.GroupModerationResponse.ban:type_name -> message.v1.GroupBan
+	110, // 99: message.v1.GroupModerationResponse.mute:type_name -> message.v1.GroupMute
+	7,   // 100: message.v1.GroupModerationResponse.error:type_name -> message.v1.Error
+	109, // 101: message.v1.GroupBanListResponse.data:type_name -> message.v1.GroupBan
+	7,   // 102: message.v1.GroupBanListResponse.error:type_name -> message.v1.Error
+	115, // 103: message.v1.GroupAuditLogResponse.data:type_name -> message.v1.AuditLogEntry
+	7,   // 104: message.v1.GroupAuditLogResponse.error:type_name -> message.v1.Error
+	105, // [105:105] is the sub-list for method output_type
+	105, // [105:105] is the sub-list for method input_type
+	105, // [105:105] is the sub-list for extension type_name
+	105, // [105:105] is the sub-list for extension extendee
+	0,   // [0:105] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_message_proto_init() }
@@ -2636,7 +8882,7 @@ func file_api_v1_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_message_proto_rawDesc), len(file_api_v1_message_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   36,
+			NumMessages:   118,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
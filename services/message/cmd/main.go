@@ -1,17 +1,22 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
 	"strings"
 
+	"github.com/Mathis-brgs/storm-project/services/message/internal/attachments"
 	"github.com/Mathis-brgs/storm-project/services/message/internal/batch"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/mentions"
 	"github.com/Mathis-brgs/storm-project/services/message/internal/metrics"
 	natsh "github.com/Mathis-brgs/storm-project/services/message/internal/nats"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/purge"
 	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
 	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
 	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/postgres"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/scheduler"
 	"github.com/Mathis-brgs/storm-project/services/message/internal/service"
 	"github.com/nats-io/nats.go"
 )
@@ -59,6 +64,12 @@ func main() {
 		log.Fatalf("listen: %v", err)
 	}
 
+	// Messages programmés : chaque replica tourne, la réclamation en base évite les doublons.
+	go scheduler.New(messageSvc, conversationSvc, nc, mentions.NewResolver(nc, conversationSvc), attachments.NewResolver(nc)).Run(context.Background())
+
+	// Purge des conversations supprimées au-delà du délai de grâce (idempotente, tolère plusieurs replicas).
+	go purge.New(conversationSvc, messageSvc, nc).Run(context.Background())
+
 	startHTTPServer(m)
 
 	log.Println("ready, listening on NATS")
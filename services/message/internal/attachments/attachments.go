@@ -0,0 +1,125 @@
+package attachments
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	subjectMediaGet = "media.get"
+
+	codeNotFound  = "NOT_FOUND"
+	lookupTimeout = 2 * time.Second
+)
+
+var (
+	// ErrAttachmentForbidden : le média est inconnu, n'a pas été envoyé par l'expéditeur ou est rattaché à
+	// une autre conversation. Sans ce contrôle, un membre pourrait citer le média d'un autre groupe et le
+	// faire supprimer avec la purge de sa propre conversation.
+	ErrAttachmentForbidden = errors.New("attachment not allowed: media must be uploaded by the sender for this conversation")
+	// ErrMediaUnavailable : le media-service n'a pas répondu, le média n'a pas pu être vérifié.
+	ErrMediaUnavailable = errors.New("media-service unavailable")
+)
+
+// Conn est le sous-ensemble de *nats.Conn utilisé : request/reply vers le media-service.
+type Conn interface {
+	Request(subject string, data []byte, timeout time.Duration) (*nats.Msg, error)
+}
+
+// Resolver vérifie la pièce jointe d'un message et la décrit (type, taille, durée) d'après le media-service.
+// Un Resolver nil ne fait rien (tests, service démarré sans NATS).
+type Resolver struct {
+	nc Conn
+}
+
+func NewResolver(nc Conn) *Resolver {
+	return &Resolver{nc: nc}
+}
+
+// mediaInfo : sous-ensemble de la réponse media.get (service.MediaInfo côté media-service).
+type mediaInfo struct {
+	MediaID        string `json:"mediaId"`
+	OwnerID        string `json:"ownerId"`
+	ConversationID int    `json:"conversationId"`
+	ContentType    string `json:"contentType"`
+	Category       string `json:"category"`
+	Filename       string `json:"filename"`
+	Size           int64  `json:"size"`
+	DurationMs     int64  `json:"durationMs"`
+	Waveform       []int  `json:"waveform"`
+	Error          string `json:"error"`
+	Code           string `json:"code"`
+}
+
+// Authorize vérifie que le média attachment a été envoyé par senderID, pour conversationID ou pour
+// aucune conversation, et retourne ses métadonnées. Une URL contenant un media ID est vérifiée comme
+// le media ID lui-même (la purge en extrait la clé) ; une URL sans média connu reste opaque : (nil, nil).
+func (r *Resolver) Authorize(senderID uuid.UUID, conversationID int, attachment string) (*models.AttachmentInfo, error) {
+	mediaID := models.AttachmentMediaID(attachment)
+	if r == nil || mediaID == "" {
+		return nil, nil
+	}
+	media, err := r.lookup(senderID, mediaID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMediaUnavailable, err)
+	}
+	if media.Code == codeNotFound && mediaID != attachment {
+		return nil, nil
+	}
+	if media.Error != "" {
+		return nil, fmt.Errorf("%w (%s)", ErrAttachmentForbidden, media.Error)
+	}
+	if media.OwnerID != senderID.String() || (media.ConversationID != 0 && media.ConversationID != conversationID) {
+		return nil, ErrAttachmentForbidden
+	}
+	return &models.AttachmentInfo{
+		MediaID:     mediaID,
+		Type:        category(*media),
+		ContentType: media.ContentType,
+		Filename:    media.Filename,
+		Size:        media.Size,
+		DurationMs:  media.DurationMs,
+		Waveform:    media.Waveform,
+	}, nil
+}
+
+// lookup : media.get au nom de senderID ; une réponse d'erreur du media-service est dans mediaInfo.Error.
+func (r *Resolver) lookup(senderID uuid.UUID, mediaID string) (*mediaInfo, error) {
+	payload, err := json.Marshal(map[string]string{"mediaId": mediaID, "requesterId": senderID.String()})
+	if err != nil {
+		return nil, err
+	}
+	msg, err := r.nc.Request(subjectMediaGet, payload, lookupTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var media mediaInfo
+	if err := json.Unmarshal(msg.Data, &media); err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// category : médias enregistrés avant les catégories, déduites du type MIME.
+func category(media mediaInfo) string {
+	if media.Category != "" {
+		return media.Category
+	}
+	switch {
+	case strings.HasPrefix(media.ContentType, "image/"):
+		return "image"
+	case strings.HasPrefix(media.ContentType, "video/"):
+		return "video"
+	case strings.HasPrefix(media.ContentType, "audio/"):
+		return "audio"
+	}
+	return "document"
+}
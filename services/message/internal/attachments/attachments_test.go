@@ -0,0 +1,99 @@
+package attachments
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+)
+
+var senderID = uuid.MustParse("e1000001-0000-0000-0000-000000000001")
+
+// fakeConn simule media.get : réponses par media ID, requêtes enregistrées.
+type fakeConn struct {
+	media    map[string]interface{}
+	err      error
+	requests []map[string]string
+}
+
+func (c *fakeConn) Request(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+	var req map[string]string
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, err
+	}
+	c.requests = append(c.requests, req)
+	if c.err != nil {
+		return nil, c.err
+	}
+	resp, ok := c.media[req["mediaId"]]
+	if !ok {
+		resp = map[string]string{"error": "media not found", "code": "NOT_FOUND"}
+	}
+	body, _ := json.Marshal(resp)
+	return &nats.Msg{Data: body}, nil
+}
+
+func TestAuthorize(t *testing.T) {
+	otherID := uuid.MustParse("e1000002-0000-0000-0000-000000000002")
+	conn := &fakeConn{media: map[string]interface{}{
+		"media/1_note.ogg": map[string]interface{}{
+			"mediaId": "media/1_note.ogg", "ownerId": senderID.String(), "conversationId": 7, "contentType": "audio/ogg", "category": "voice",
+			"filename": "note.ogg", "size": 4096, "durationMs": 2000, "waveform": []int{0, 50, 100}, "url": "https://example.test/signed",
+		},
+		"media/2_loose.png":    map[string]interface{}{"mediaId": "media/2_loose.png", "ownerId": senderID.String(), "contentType": "image/png", "size": 10},
+		"media/3_stranger.png": map[string]interface{}{"mediaId": "media/3_stranger.png", "ownerId": otherID.String(), "conversationId": 7, "contentType": "image/png"},
+	}}
+	resolver := NewResolver(conn)
+
+	got, err := resolver.Authorize(senderID, 7, "media/1_note.ogg")
+	want := &models.AttachmentInfo{MediaID: "media/1_note.ogg", Type: "voice", ContentType: "audio/ogg", Filename: "note.ogg", Size: 4096, DurationMs: 2000, Waveform: []int{0, 50, 100}}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Fatalf("Authorize() = %+v, %v, want %+v", got, err, want)
+	}
+	if conn.requests[0]["requesterId"] != senderID.String() {
+		t.Fatalf("media.get must be made on behalf of the sender, got %v", conn.requests[0])
+	}
+	// Média sans conversation : utilisable par son auteur dans n'importe quelle conversation.
+	if got, err := resolver.Authorize(senderID, 9, "media/2_loose.png"); err != nil || got == nil || got.Type != "image" {
+		t.Fatalf("Authorize(loose) = %+v, %v", got, err)
+	}
+
+	// Média d'une autre conversation, d'un autre auteur ou inconnu : refusé.
+	for _, tc := range []struct {
+		conversationID int
+		attachment     string
+	}{
+		{9, "media/1_note.ogg"},
+		{7, "media/3_stranger.png"},
+		{7, "media/4_unknown.png"},
+		{9, "http://minio:9000/storm/media/1_note.ogg"},
+	} {
+		if got, err := resolver.Authorize(senderID, tc.conversationID, tc.attachment); !errors.Is(err, ErrAttachmentForbidden) || got != nil {
+			t.Fatalf("Authorize(%d, %s) = %+v, %v, want ErrAttachmentForbidden", tc.conversationID, tc.attachment, got, err)
+		}
+	}
+
+	// Liens externes : pas de média du service, pièce jointe opaque.
+	requests := len(conn.requests)
+	if got, err := resolver.Authorize(senderID, 7, "https://example.test/photo.png"); got != nil || err != nil || len(conn.requests) != requests {
+		t.Fatalf("non media attachments must not be looked up, got %+v, %v", got, err)
+	}
+	if got, err := resolver.Authorize(senderID, 7, "https://example.test/media/photo.png"); got != nil || err != nil {
+		t.Fatalf("external link to an unknown media = %+v, %v", got, err)
+	}
+
+	// Media-service injoignable : refusé, jamais accepté sans vérification.
+	conn.err = errors.New("nats: timeout")
+	if _, err := resolver.Authorize(senderID, 7, "media/1_note.ogg"); !errors.Is(err, ErrMediaUnavailable) {
+		t.Fatalf("Authorize() with media-service down: expected ErrMediaUnavailable, got %v", err)
+	}
+	var nilResolver *Resolver
+	if got, err := nilResolver.Authorize(senderID, 7, "media/1_note.ogg"); got != nil || err != nil {
+		t.Fatalf("nil Resolver should return nil, got %+v, %v", got, err)
+	}
+}
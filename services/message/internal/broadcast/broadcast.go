@@ -0,0 +1,79 @@
+package broadcast
+
+import (
+	"encoding/json"
+	"log"
+	"strconv"
+
+	"github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/google/uuid"
+)
+
+// subjectPrefix : le hub WS du gateway est abonné à message.broadcast.> et relaie vers la room.
+const subjectPrefix = "message.broadcast."
+
+// Publisher est le sous-ensemble de *nats.Conn utilisé pour publier les événements temps réel.
+type Publisher interface {
+	Publish(subject string, data []byte) error
+}
+
+func ConversationRoom(conversationID int) string {
+	return "conversation:" + strconv.Itoa(conversationID)
+}
+
+func UserRoom(userID uuid.UUID) string {
+	return "user:" + userID.String()
+}
+
+// Publish sérialise payload (complété par "room") et le publie sur message.broadcast.<room>.
+// Sans publisher (tests, service démarré sans NATS), l'appel est ignoré.
+func Publish(p Publisher, room string, payload map[string]interface{}) {
+	if p == nil || room == "" {
+		return
+	}
+	payload["room"] = room
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("broadcast marshal (%s): %v", room, err)
+		return
+	}
+	if err := p.Publish(subjectPrefix+room, data); err != nil {
+		log.Printf("broadcast publish (%s): %v", room, err)
+	}
+}
+
+// MessagePayload reprend la forme du broadcast "message" émis par le gateway (models.InputMessage)
+// pour qu'un message inséré côté service s'affiche comme un envoi en direct.
+func MessagePayload(msg *models.ChatMessage) map[string]interface{} {
+	payload := map[string]interface{}{
+		"action":          "message",
+		"user":            msg.SenderID.String(),
+		"content":         msg.Content,
+		"id":              msg.ID,
+		"message_id":      strconv.Itoa(msg.ID),
+		"conversation_id": msg.ConversationID,
+		"created_at":      msg.CreatedAt.Unix(),
+	}
+	if msg.Attachment != "" {
+		payload["attachment"] = msg.Attachment
+	}
+	if msg.AttachmentInfo != nil {
+		payload["attachment_info"] = msg.AttachmentInfo
+	}
+	if msg.ReplyToID != nil {
+		payload["reply_to_id"] = *msg.ReplyToID
+	}
+	if len(msg.Mentions) > 0 {
+		payload["mentions"] = msg.Mentions
+	}
+	if msg.PollID != nil {
+		payload["poll_id"] = *msg.PollID
+	}
+	if msg.Kind != "" {
+		payload["kind"] = msg.Kind
+	}
+	if msg.System != nil {
+		payload["system"] = msg.System
+	}
+	return payload
+}
@@ -0,0 +1,244 @@
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/message/internal/models"
+)
+
+const (
+	fetchTimeout  = 5 * time.Second
+	dialTimeout   = 2 * time.Second
+	maxBodyBytes  = 512 << 10
+	maxRedirects  = 3
+	maxTitleRunes = 300
+	maxDescRunes  = 1000
+	userAgent     = "StormLinkPreview/1.0"
+)
+
+var (
+	ErrBlockedAddress = errors.New("link preview: destination address not allowed")
+	ErrNotHTML        = errors.New("link preview: response is not HTML")
+	ErrNoMetadata     = errors.New("link preview: no metadata found")
+)
+
+var (
+	metaTagPattern   = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	attributePattern = regexp.MustCompile(`(?is)([a-z_:-]+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	titlePattern     = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+)
+
+// Plages non routables sur Internet en plus de celles couvertes par net.IP (IsPrivate, IsLoopback...).
+var blockedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",     // "this network"
+	"100.64.0.0/10", // CGNAT
+	"192.0.0.0/24",  // IETF protocol assignments
+	"198.18.0.0/15", // benchmarking
+	"64:ff9b::/96",  // NAT64 (peut router vers une IPv4 privée)
+)
+
+// Fetcher récupère les métadonnées OpenGraph/HTML d'une page.
+// Protection SSRF : l'adresse est vérifiée au moment du dial (après résolution DNS et à chaque redirection),
+// ce qui couvre aussi le DNS rebinding. Les réponses sont limitées en temps et en taille.
+type Fetcher struct {
+	client *http.Client
+	// allowPrivate désactive le filtrage d'adresses (tests contre httptest sur 127.0.0.1 uniquement).
+	allowPrivate bool
+}
+
+func NewFetcher() *Fetcher {
+	f := &Fetcher{}
+	dialer := &net.Dialer{
+		Timeout: dialTimeout,
+		Control: f.checkAddress,
+	}
+	f.client = &http.Client{
+		Timeout: fetchTimeout,
+		Transport: &http.Transport{
+			// Pas de proxy : le contrôle d'adresse doit porter sur la destination réelle.
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   dialTimeout,
+			ResponseHeaderTimeout: fetchTimeout,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return errors.New("link preview: too many redirects")
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return ErrBlockedAddress
+			}
+			return nil
+		},
+	}
+	return f
+}
+
+// Fetch télécharge rawURL (http/https) et en extrait titre, description, image et nom du site.
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*models.LinkPreview, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Hostname() == "" {
+		return nil, fmt.Errorf("link preview: invalid url %q", rawURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("link preview: unexpected status %d", resp.StatusCode)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, ErrNotHTML
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	preview := parseHTML(string(body), resp.Request.URL)
+	preview.URL = rawURL
+	if preview.Title == "" && preview.Description == "" && preview.ImageURL == "" {
+		return nil, ErrNoMetadata
+	}
+	return preview, nil
+}
+
+func (f *Fetcher) checkAddress(network, address string, _ syscall.RawConn) error {
+	if f.allowPrivate {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return ErrBlockedAddress
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return ErrBlockedAddress
+	}
+	return nil
+}
+
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseHTML lit les balises meta (og:*, twitter:*, description) et <title> ; base résout les URLs relatives.
+func parseHTML(doc string, base *url.URL) *models.LinkPreview {
+	meta := make(map[string]string)
+	for _, tag := range metaTagPattern.FindAllString(doc, -1) {
+		attrs := make(map[string]string)
+		for _, match := range attributePattern.FindAllStringSubmatch(tag, -1) {
+			value := match[2]
+			if value == "" {
+				value = match[3]
+			}
+			attrs[strings.ToLower(match[1])] = value
+		}
+		key := attrs["property"]
+		if key == "" {
+			key = attrs["name"]
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+		if _, exists := meta[key]; !exists {
+			meta[key] = clean(attrs["content"])
+		}
+	}
+
+	preview := &models.LinkPreview{
+		Title:       firstNonEmpty(meta["og:title"], meta["twitter:title"]),
+		Description: firstNonEmpty(meta["og:description"], meta["twitter:description"], meta["description"]),
+		SiteName:    meta["og:site_name"],
+	}
+	if preview.Title == "" {
+		if match := titlePattern.FindStringSubmatch(doc); match != nil {
+			preview.Title = clean(match[1])
+		}
+	}
+	preview.Title = truncate(preview.Title, maxTitleRunes)
+	preview.Description = truncate(preview.Description, maxDescRunes)
+
+	if image := firstNonEmpty(meta["og:image:secure_url"], meta["og:image"], meta["twitter:image"]); image != "" {
+		if ref, err := url.Parse(image); err == nil && base != nil {
+			resolved := base.ResolveReference(ref)
+			if resolved.Scheme == "http" || resolved.Scheme == "https" {
+				preview.ImageURL = resolved.String()
+			}
+		}
+	}
+	if preview.SiteName == "" && base != nil {
+		preview.SiteName = base.Hostname()
+	}
+	return preview
+}
+
+func clean(value string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(value)), " ")
+}
+
+func truncate(value string, maxRunes int) string {
+	runes := []rune(value)
+	if len(runes) <= maxRunes {
+		return value
+	}
+	return string(runes[:maxRunes]) + "…"
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -0,0 +1,209 @@
+package linkpreview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/service"
+	"github.com/google/uuid"
+)
+
+const articleHTML = `<!doctype html>
+<html><head>
+<title>Fallback title</title>
+<meta property="og:title" content="Storm &amp; friends">
+<meta property='og:description' content='Une   description
+ sur plusieurs lignes'>
+<meta property="og:image" content="/img/cover.png">
+<meta name="description" content="ignored when og:description exists">
+</head><body>hello</body></html>`
+
+func testFetcher() *Fetcher {
+	f := NewFetcher()
+	f.allowPrivate = true
+	return f
+}
+
+func TestFetchParsesOpenGraph(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(articleHTML))
+	}))
+	defer server.Close()
+
+	preview, err := testFetcher().Fetch(context.Background(), server.URL+"/article")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if preview.Title != "Storm & friends" {
+		t.Fatalf("unexpected title %q", preview.Title)
+	}
+	if preview.Description != "Une description sur plusieurs lignes" {
+		t.Fatalf("unexpected description %q", preview.Description)
+	}
+	if preview.ImageURL != server.URL+"/img/cover.png" {
+		t.Fatalf("expected relative og:image resolved against the page, got %q", preview.ImageURL)
+	}
+	if preview.SiteName != "127.0.0.1" {
+		t.Fatalf("expected host as site name fallback, got %q", preview.SiteName)
+	}
+}
+
+func TestFetchFallsBackToTitleTag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><title> Plain page </title></head></html>`))
+	}))
+	defer server.Close()
+
+	preview, err := testFetcher().Fetch(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if preview.Title != "Plain page" {
+		t.Fatalf("unexpected title %q", preview.Title)
+	}
+}
+
+func TestFetchBlocksPrivateAddresses(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(articleHTML))
+	}))
+	defer server.Close()
+
+	_, err := NewFetcher().Fetch(context.Background(), server.URL)
+	if !errors.Is(err, ErrBlockedAddress) {
+		t.Fatalf("expected ErrBlockedAddress for loopback target, got %v", err)
+	}
+	if hits != 0 {
+		t.Fatalf("blocked request must not reach the server, got %d hits", hits)
+	}
+}
+
+func TestFetchRejectsNonHTML(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte(articleHTML))
+	}))
+	defer server.Close()
+
+	if _, err := testFetcher().Fetch(context.Background(), server.URL); !errors.Is(err, ErrNotHTML) {
+		t.Fatalf("expected ErrNotHTML, got %v", err)
+	}
+}
+
+func TestFetchCapsBodySize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html><head>" + strings.Repeat(" ", maxBodyBytes+1)))
+		_, _ = w.Write([]byte(`<title>beyond the cap</title></head></html>`))
+	}))
+	defer server.Close()
+
+	if _, err := testFetcher().Fetch(context.Background(), server.URL); !errors.Is(err, ErrNoMetadata) {
+		t.Fatalf("expected metadata past the size cap to be ignored, got %v", err)
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	cases := map[string]bool{
+		"8.8.8.8":         true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::1":             false,
+		"fd00::1":         false,
+		"fe80::1":         false,
+	}
+	for raw, want := range cases {
+		if got := isPublicIP(net.ParseIP(raw)); got != want {
+			t.Errorf("isPublicIP(%s) = %v, want %v", raw, got, want)
+		}
+	}
+}
+
+func TestExtractURL(t *testing.T) {
+	cases := map[string]string{
+		"regarde https://example.com/a?b=1.":     "https://example.com/a?b=1",
+		"(voir http://example.org/page)":         "http://example.org/page",
+		"pas de lien ici":                        "",
+		"ftp://example.com puis https://x.io/y!": "https://x.io/y",
+	}
+	for content, want := range cases {
+		if got := ExtractURL(content); got != want {
+			t.Errorf("ExtractURL(%q) = %q, want %q", content, got, want)
+		}
+	}
+}
+
+type countingFetcher struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (f *countingFetcher) Fetch(ctx context.Context, rawURL string) (*models.LinkPreview, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return &models.LinkPreview{URL: rawURL, Title: "Example"}, nil
+}
+
+type recordingPublisher struct {
+	payloads []map[string]interface{}
+}
+
+func (p *recordingPublisher) Publish(subject string, data []byte) error {
+	var payload map[string]interface{}
+	_ = json.Unmarshal(data, &payload)
+	p.payloads = append(p.payloads, payload)
+	return nil
+}
+
+func TestUnfurlerAttachesCachesAndBroadcasts(t *testing.T) {
+	messageRepo := memory.NewMessageRepo()
+	svc := service.NewMessageService(messageRepo)
+	fetcher := &countingFetcher{}
+	publisher := &recordingPublisher{}
+	unfurler := NewUnfurler(svc, fetcher, publisher)
+
+	sender := uuid.MustParse("f1000001-0000-0000-0000-000000000001")
+	first, _ := messageRepo.SaveMessage(&models.ChatMessage{SenderID: sender, ConversationID: 3, Content: "lien https://example.com/x"})
+	second, _ := messageRepo.SaveMessage(&models.ChatMessage{SenderID: sender, ConversationID: 3, Content: "encore https://example.com/x"})
+
+	for _, msg := range []*models.ChatMessage{first, second} {
+		if _, err := unfurler.Process(context.Background(), msg); err != nil {
+			t.Fatalf("Process(%d) error = %v", msg.ID, err)
+		}
+	}
+
+	if fetcher.calls != 1 {
+		t.Fatalf("expected the second message to hit the cache, got %d fetches", fetcher.calls)
+	}
+	stored, err := svc.GetMessageById(second.ID)
+	if err != nil {
+		t.Fatalf("GetMessageById() error = %v", err)
+	}
+	if stored.LinkPreview == nil || stored.LinkPreview.Title != "Example" {
+		t.Fatalf("expected preview attached to message, got %+v", stored.LinkPreview)
+	}
+	if len(publisher.payloads) != 2 || publisher.payloads[0]["action"] != "message_preview" || publisher.payloads[0]["room"] != "conversation:3" {
+		t.Fatalf("unexpected broadcasts %+v", publisher.payloads)
+	}
+}
@@ -0,0 +1,147 @@
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/message/internal/broadcast"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/service"
+)
+
+const (
+	defaultWorkers   = 4
+	defaultQueueSize = 256
+	cacheTTL         = 24 * time.Hour
+)
+
+var urlPattern = regexp.MustCompile(`https?://[^\s<>"']+`)
+
+// ExtractURL retourne le premier lien http(s) du message (ponctuation finale retirée), "" sinon.
+func ExtractURL(content string) string {
+	match := urlPattern.FindString(content)
+	return strings.TrimRight(match, ".,;:!?)]}")
+}
+
+// PreviewFetcher est implémenté par *Fetcher ; remplaçable en test.
+type PreviewFetcher interface {
+	Fetch(ctx context.Context, rawURL string) (*models.LinkPreview, error)
+}
+
+type job struct {
+	messageID      int
+	conversationID int
+	url            string
+}
+
+// Unfurler génère les aperçus de liens en tâche de fond : l'envoi du message n'attend jamais le fetch.
+// Les aperçus sont mis en cache par URL (24 h) puis attachés au message et diffusés à la room.
+// Un Unfurler nil ne fait rien (tests, service démarré sans NATS).
+type Unfurler struct {
+	svc       *service.MessageService
+	fetcher   PreviewFetcher
+	publisher broadcast.Publisher
+	queue     chan job
+	workers   int
+}
+
+func NewUnfurler(svc *service.MessageService, fetcher PreviewFetcher, publisher broadcast.Publisher) *Unfurler {
+	return &Unfurler{
+		svc:       svc,
+		fetcher:   fetcher,
+		publisher: publisher,
+		queue:     make(chan job, defaultQueueSize),
+		workers:   defaultWorkers,
+	}
+}
+
+// Enqueue planifie l'aperçu du premier lien de msg ; ignoré si la file est pleine.
+func (u *Unfurler) Enqueue(msg *models.ChatMessage) {
+	if u == nil || msg == nil {
+		return
+	}
+	link := ExtractURL(msg.Content)
+	if link == "" {
+		return
+	}
+	select {
+	case u.queue <- job{messageID: msg.ID, conversationID: msg.ConversationID, url: link}:
+	default:
+		log.Printf("[linkpreview] queue full, skipping message %d", msg.ID)
+	}
+}
+
+// Run démarre les workers jusqu'à l'annulation du contexte.
+func (u *Unfurler) Run(ctx context.Context) {
+	for i := 0; i < u.workers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case j := <-u.queue:
+					if _, err := u.process(ctx, j); err != nil {
+						log.Printf("[linkpreview] message %d (%s): %v", j.messageID, j.url, err)
+					}
+				}
+			}
+		}()
+	}
+	<-ctx.Done()
+}
+
+// Process traite msg de façon synchrone (utilisé par les tests et par les workers).
+func (u *Unfurler) Process(ctx context.Context, msg *models.ChatMessage) (*models.LinkPreview, error) {
+	link := ExtractURL(msg.Content)
+	if link == "" {
+		return nil, nil
+	}
+	return u.process(ctx, job{messageID: msg.ID, conversationID: msg.ConversationID, url: link})
+}
+
+func (u *Unfurler) process(ctx context.Context, j job) (*models.LinkPreview, error) {
+	preview, err := u.lookup(ctx, j.url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.svc.AttachLinkPreview(j.messageID, preview); err != nil {
+		return nil, err
+	}
+
+	broadcast.Publish(u.publisher, broadcast.ConversationRoom(j.conversationID), map[string]interface{}{
+		"action":          "message_preview",
+		"id":              j.messageID,
+		"message_id":      j.messageID,
+		"conversation_id": j.conversationID,
+		"link_preview":    preview,
+	})
+	return preview, nil
+}
+
+// lookup sert l'aperçu depuis le cache s'il a moins de 24 h, sinon le récupère et le met en cache.
+func (u *Unfurler) lookup(ctx context.Context, link string) (*models.LinkPreview, error) {
+	cached, err := u.svc.GetLinkPreview(link)
+	if err == nil && time.Since(cached.FetchedAt) < cacheTTL {
+		return cached, nil
+	}
+	if err != nil && !errors.Is(err, repo.ErrLinkPreviewNotFound) {
+		return nil, err
+	}
+
+	preview, err := u.fetcher.Fetch(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+	preview.URL = link
+	preview.FetchedAt = time.Now().UTC()
+	if err := u.svc.SaveLinkPreview(preview); err != nil {
+		log.Printf("[linkpreview] cache %s: %v", link, err)
+	}
+	return preview, nil
+}
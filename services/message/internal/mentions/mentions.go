@@ -0,0 +1,223 @@
+package mentions
+
+import (
+	"encoding/json"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	subjectUsersByUsernames = "user.by_usernames"
+	subjectNotificationSend = "notification.send"
+
+	// NotificationType / PriorityHigh : une mention est notifiée même si la conversation est en sourdine.
+	NotificationType = "mention"
+	PriorityHigh     = "high"
+
+	maxMentionsPerMessage = 20
+	// resolveTimeout borne la résolution complète d'un message (annuaire + appartenance) : elle
+	// s'exécute sur le chemin d'envoi.
+	resolveTimeout  = 2 * time.Second
+	excerptMaxRunes = 100
+
+	// Mêmes contraintes que le user-service (update-user.dto.ts) : 3 à 20 caractères [a-z0-9_-].
+	minUsernameLength = 3
+	maxUsernameLength = 20
+)
+
+// Un @ n'est une mention qu'en début de texte ou après un caractère non alphanumérique
+// (évite les adresses e-mail du type bob@example.com).
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9_-]+)`)
+
+// Conn est le sous-ensemble de *nats.Conn utilisé : request/reply vers le user-service,
+// publish vers le notification-service.
+type Conn interface {
+	Request(subject string, data []byte, timeout time.Duration) (*nats.Msg, error)
+	Publish(subject string, data []byte) error
+}
+
+// MembershipChecker : seuls les membres de la conversation peuvent être mentionnés,
+// et pas par un utilisateur qu'ils ont bloqué.
+type MembershipChecker interface {
+	IsMember(userID uuid.UUID, conversationID int) (bool, error)
+	HasBlocked(blockerID, blockedID uuid.UUID) (bool, error)
+}
+
+// Parse extrait les usernames mentionnés (minuscules, sans doublon, dans l'ordre d'apparition).
+func Parse(content string) []string {
+	matches := mentionPattern.FindAllStringSubmatch(content, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(matches))
+	usernames := make([]string, 0, len(matches))
+	for _, match := range matches {
+		username := strings.ToLower(match[1])
+		if len(username) < minUsernameLength || len(username) > maxUsernameLength || seen[username] {
+			continue
+		}
+		seen[username] = true
+		usernames = append(usernames, username)
+		if len(usernames) == maxMentionsPerMessage {
+			break
+		}
+	}
+	if len(usernames) == 0 {
+		return nil
+	}
+	return usernames
+}
+
+// Resolver résout les @username d'un message en UUID de membres et notifie les personnes mentionnées.
+// Un Resolver nil ne fait rien (tests, service démarré sans NATS).
+type Resolver struct {
+	nc      Conn
+	members MembershipChecker
+}
+
+func NewResolver(nc Conn, members MembershipChecker) *Resolver {
+	return &Resolver{nc: nc, members: members}
+}
+
+// Resolve retourne les UUID des membres de la conversation mentionnés dans content par senderID.
+// Les usernames inconnus, non membres ou ayant bloqué l'auteur sont ignorés : le message part quand même.
+// Tous les usernames sont résolus en une requête ; au-delà de resolveTimeout, les mentions restantes
+// sont abandonnées.
+func (r *Resolver) Resolve(conversationID int, senderID uuid.UUID, content string) []uuid.UUID {
+	if r == nil {
+		return nil
+	}
+	usernames := Parse(content)
+	if len(usernames) == 0 {
+		return nil
+	}
+
+	deadline := time.Now().Add(resolveTimeout)
+	found, err := r.lookupUsernames(usernames, resolveTimeout)
+	if err != nil {
+		log.Printf("[mentions] %s %v: %v", subjectUsersByUsernames, usernames, err)
+		return nil
+	}
+
+	var ids []uuid.UUID
+	seen := make(map[uuid.UUID]bool, len(usernames))
+	for _, username := range usernames {
+		id, ok := found[username]
+		if !ok || seen[id] {
+			continue
+		}
+		if time.Now().After(deadline) {
+			log.Printf("[mentions] conversation %d: délai de résolution dépassé", conversationID)
+			break
+		}
+		if r.members != nil {
+			isMember, err := r.members.IsMember(id, conversationID)
+			if err != nil || !isMember {
+				continue
+			}
+			blocked, err := r.members.HasBlocked(id, senderID)
+			if err != nil || blocked {
+				continue
+			}
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Notify publie une notification haute priorité pour chaque membre mentionné (hors auteur).
+func (r *Resolver) Notify(msg *models.ChatMessage) {
+	if r == nil || msg == nil || len(msg.Mentions) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"conversationId": strconv.Itoa(msg.ConversationID),
+		"messageId":      strconv.Itoa(msg.ID),
+		"senderId":       msg.SenderID.String(),
+		"excerpt":        excerpt(msg.Content),
+	})
+	if err != nil {
+		log.Printf("[mentions] marshal payload: %v", err)
+		return
+	}
+
+	for _, userID := range msg.Mentions {
+		if userID == msg.SenderID {
+			continue
+		}
+		data, err := json.Marshal(map[string]string{
+			"userId":   userID.String(),
+			"type":     NotificationType,
+			"priority": PriorityHigh,
+			"payload":  string(payload),
+		})
+		if err != nil {
+			continue
+		}
+		if err := r.nc.Publish(subjectNotificationSend, data); err != nil {
+			log.Printf("[mentions] notification.send %s: %v", userID, err)
+		}
+	}
+}
+
+type userResult struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// lookupUsernames interroge user.by_usernames (correspondance exacte, une seule requête) et
+// retourne les UUID indexés par username en minuscules ; les usernames inconnus sont absents.
+func (r *Resolver) lookupUsernames(usernames []string, timeout time.Duration) (map[string]uuid.UUID, error) {
+	request := struct {
+		Pattern string              `json:"pattern"`
+		Data    map[string][]string `json:"data"`
+		ID      string              `json:"id"`
+	}{
+		Pattern: subjectUsersByUsernames,
+		Data:    map[string][]string{"usernames": usernames},
+		ID:      time.Now().String(),
+	}
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := r.nc.Request(subjectUsersByUsernames, payload, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var wrapper struct {
+		Response []userResult `json:"response"`
+	}
+	if err := json.Unmarshal(msg.Data, &wrapper); err != nil {
+		return nil, err
+	}
+	found := make(map[string]uuid.UUID, len(wrapper.Response))
+	for _, user := range wrapper.Response {
+		id, err := uuid.Parse(user.ID)
+		if err != nil || id == uuid.Nil {
+			continue
+		}
+		found[strings.ToLower(user.Username)] = id
+	}
+	return found, nil
+}
+
+func excerpt(content string) string {
+	runes := []rune(strings.TrimSpace(content))
+	if len(runes) <= excerptMaxRunes {
+		return string(runes)
+	}
+	return string(runes[:excerptMaxRunes]) + "…"
+}
@@ -0,0 +1,179 @@
+package mentions
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+)
+
+var (
+	aliceID = uuid.MustParse("e1000001-0000-0000-0000-000000000001")
+	bobID   = uuid.MustParse("e1000002-0000-0000-0000-000000000002")
+	carolID = uuid.MustParse("e1000003-0000-0000-0000-000000000003")
+)
+
+// fakeConn simule user.by_usernames (annuaire en mémoire) et enregistre les notification.send publiés.
+type fakeConn struct {
+	mu        sync.Mutex
+	users     map[string]uuid.UUID
+	searchErr error
+	searches  int
+	subjects  []string
+	published []map[string]string
+}
+
+func (c *fakeConn) Request(subject string, data []byte, timeout time.Duration) (*nats.Msg, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.searches++
+	c.subjects = append(c.subjects, subject)
+	if c.searchErr != nil {
+		return nil, c.searchErr
+	}
+	var req struct {
+		Data struct {
+			Usernames []string `json:"usernames"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, err
+	}
+	results := []userResult{}
+	for _, username := range req.Data.Usernames {
+		if id, ok := c.users[username]; ok {
+			results = append(results, userResult{ID: id.String(), Username: username})
+		}
+	}
+	body, _ := json.Marshal(map[string]interface{}{"response": results})
+	return &nats.Msg{Data: body}, nil
+}
+
+func (c *fakeConn) Publish(subject string, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var payload map[string]string
+	_ = json.Unmarshal(data, &payload)
+	payload["subject"] = subject
+	c.published = append(c.published, payload)
+	return nil
+}
+
+type staticMembers map[uuid.UUID]bool
+
+func (m staticMembers) IsMember(userID uuid.UUID, conversationID int) (bool, error) {
+	return m[userID], nil
+}
+
+func (m staticMembers) HasBlocked(blockerID, blockedID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+// blockingMembers : tous membres, blocks[bloqueur] = bloqué.
+type blockingMembers map[uuid.UUID]uuid.UUID
+
+func (m blockingMembers) IsMember(userID uuid.UUID, conversationID int) (bool, error) {
+	return true, nil
+}
+
+func (m blockingMembers) HasBlocked(blockerID, blockedID uuid.UUID) (bool, error) {
+	return m[blockerID] == blockedID, nil
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		content string
+		want    []string
+	}{
+		{"salut @alice et @Bob", []string{"alice", "bob"}},
+		{"@alice @alice, (@carol)", []string{"alice", "carol"}},
+		{"mail bob@example.com", nil},
+		{"@ab trop court, @@alice ignoré", nil},
+		{"", nil},
+	}
+	for _, tc := range cases {
+		if got := Parse(tc.content); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Parse(%q) = %v, want %v", tc.content, got, tc.want)
+		}
+	}
+}
+
+func TestResolveKeepsExactMatchingMembersOnly(t *testing.T) {
+	conn := &fakeConn{users: map[string]uuid.UUID{
+		"alice":   aliceID,
+		"alice_2": bobID,
+		"carol":   carolID,
+	}}
+	resolver := NewResolver(conn, staticMembers{aliceID: true, bobID: true})
+
+	got := resolver.Resolve(1, carolID, "@alice @carol @unknown")
+	if !reflect.DeepEqual(got, []uuid.UUID{aliceID}) {
+		t.Fatalf("Resolve() = %v, want [%s] (carol is not a member, alice_2 is not an exact match)", got, aliceID)
+	}
+	if conn.searches != 1 || conn.subjects[0] != subjectUsersByUsernames {
+		t.Fatalf("expected a single %s batch request, got %v", subjectUsersByUsernames, conn.subjects)
+	}
+}
+
+func TestResolveSkipsMembersWhoBlockedSender(t *testing.T) {
+	conn := &fakeConn{users: map[string]uuid.UUID{
+		"alice": aliceID,
+		"bob":   bobID,
+	}}
+	resolver := NewResolver(conn, blockingMembers{aliceID: carolID})
+
+	got := resolver.Resolve(1, carolID, "@alice @bob")
+	if !reflect.DeepEqual(got, []uuid.UUID{bobID}) {
+		t.Fatalf("Resolve() = %v, want [%s] (alice blocked the sender)", got, bobID)
+	}
+}
+
+func TestResolveStopsWhenUserServiceUnavailable(t *testing.T) {
+	conn := &fakeConn{searchErr: errors.New("nats: no responders available for request")}
+	resolver := NewResolver(conn, staticMembers{})
+
+	if got := resolver.Resolve(1, aliceID, "@alice @bob @carol"); got != nil {
+		t.Fatalf("expected no mentions, got %v", got)
+	}
+	if conn.searches != 1 {
+		t.Fatalf("expected a single lookup attempt, got %d", conn.searches)
+	}
+}
+
+func TestNotifySkipsSenderAndUsesHighPriority(t *testing.T) {
+	conn := &fakeConn{}
+	resolver := NewResolver(conn, staticMembers{})
+
+	resolver.Notify(&models.ChatMessage{
+		ID:             9,
+		SenderID:       aliceID,
+		ConversationID: 4,
+		Content:        "@alice @bob regarde ça",
+		Mentions:       []uuid.UUID{aliceID, bobID},
+	})
+
+	if len(conn.published) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(conn.published))
+	}
+	notif := conn.published[0]
+	if notif["subject"] != subjectNotificationSend || notif["userId"] != bobID.String() {
+		t.Fatalf("unexpected notification %+v", notif)
+	}
+	if notif["type"] != NotificationType || notif["priority"] != PriorityHigh {
+		t.Fatalf("expected high-priority mention notification, got %+v", notif)
+	}
+}
+
+func TestNilResolverIsNoop(t *testing.T) {
+	var resolver *Resolver
+	if got := resolver.Resolve(1, bobID, "@alice"); got != nil {
+		t.Fatalf("expected nil, got %v", got)
+	}
+	resolver.Notify(&models.ChatMessage{Mentions: []uuid.UUID{aliceID}})
+}
@@ -0,0 +1,27 @@
+package models
+
+import "regexp"
+
+// mediaIDPattern : media ID du media-service, media/<nanos>_<nom>, seul ou en fin d'ancienne URL
+// endpoint/bucket/key. Le nom va jusqu'au bout de la valeur : les médias envoyés avant la normalisation
+// des noms de fichiers peuvent y contenir des « / ». Même expression que les index de la migration 024.
+var mediaIDPattern = regexp.MustCompile(`media/[0-9]+_.*$`)
+
+// AttachmentMediaID retourne le media ID cité par attachment, "" si ce n'est pas un média du service.
+func AttachmentMediaID(attachment string) string {
+	return mediaIDPattern.FindString(attachment)
+}
+
+// AttachmentInfo : pièce jointe décrite par le media-service (media.get), résolue à l'envoi et
+// recopiée dans messages.attachment_info. ChatMessage.Attachment garde le media ID.
+// Type : image | video | audio | voice | document ; DurationMs (audio, notes vocales) et
+// Waveform (notes vocales, amplitudes 0-100) servent au rendu du lecteur.
+type AttachmentInfo struct {
+	MediaID     string `json:"media_id"`
+	Type        string `json:"type"`
+	ContentType string `json:"content_type,omitempty"`
+	Filename    string `json:"filename,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+	DurationMs  int64  `json:"duration_ms,omitempty"`
+	Waveform    []int  `json:"waveform,omitempty"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserBlock : BlockerID a bloqué BlockedID. Le bloqué ne peut plus ouvrir de DM avec le bloqueur,
+// l'ajouter à un groupe ni le mentionner ; ses messages sont masqués pour le bloqueur.
+type UserBlock struct {
+	BlockerID uuid.UUID `json:"blocker_id"`
+	BlockedID uuid.UUID `json:"blocked_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
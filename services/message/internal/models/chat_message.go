@@ -9,21 +9,32 @@ import (
 // ChatMessage : id (PK int), sender_id (UUID), conversation_id (int).
 // ReceivedAt est reserve au contexte d'un acteur (ACK), pas un etat global du message.
 // ReplyToID, ForwardFromID optionnels. Status: sent | delivered | seen.
+// Mentions : UUID des membres mentionnés (@username résolus à l'envoi).
+// AttachmentInfo : type, taille et durée de la pièce jointe (Attachment = media ID), résolus à l'envoi.
+// LinkPreview : aperçu du premier lien, ajouté de façon asynchrone après l'envoi.
+// PollID : renseigné quand le message porte un sondage (table polls).
+// Kind : user (message saisi) ou system (événement de la conversation, détaillé dans System).
 type ChatMessage struct {
-	ID             int        `json:"id"`
-	SenderID       uuid.UUID  `json:"sender_id"`
-	ConversationID int        `json:"conversation_id"`
-	Content        string     `json:"content"`
-	Attachment     string     `json:"attachment,omitempty"`
-	ReceivedAt     *time.Time `json:"received_at,omitempty"`
-	CreatedAt      time.Time  `json:"created_at"`
-	UpdatedAt      time.Time  `json:"updated_at"`
+	ID             int             `json:"id"`
+	SenderID       uuid.UUID       `json:"sender_id"`
+	ConversationID int             `json:"conversation_id"`
+	Content        string          `json:"content"`
+	Attachment     string          `json:"attachment,omitempty"`
+	AttachmentInfo *AttachmentInfo `json:"attachment_info,omitempty"`
+	ReceivedAt     *time.Time      `json:"received_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
 
 	ReplyToID     *int          `json:"reply_to_id,omitempty"`
 	Status        string        `json:"status"`
 	ForwardFromID *int          `json:"forward_from_id,omitempty"`
 	ReplyTo       *ReplyToRef   `json:"reply_to,omitempty"`
 	SeenBy        []SeenByEntry `json:"seen_by,omitempty"`
+	Mentions      []uuid.UUID   `json:"mentions,omitempty"`
+	LinkPreview   *LinkPreview  `json:"link_preview,omitempty"`
+	PollID        *int          `json:"poll_id,omitempty"`
+	Kind          MessageKind   `json:"kind"`
+	System        *SystemEvent  `json:"system,omitempty"`
 }
 
 // ReplyToRef : message référencé pour une réponse (GET /api/messages).
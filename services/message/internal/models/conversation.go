@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,14 +19,78 @@ func IsValidConversationRole(role ConversationRole) bool {
 	return role == ConversationRoleMember || role == ConversationRoleAdmin || role == ConversationRoleOwner
 }
 
+// ConversationKind : "group" (nommée, rôles) ou "direct" (1:1, unique par paire d'utilisateurs).
+type ConversationKind string
+
+const (
+	ConversationKindGroup  ConversationKind = "group"
+	ConversationKindDirect ConversationKind = "direct"
+)
+
 type Conversation struct {
-	ID        int        `json:"id"`
-	Name      string     `json:"name"`
-	AvatarURL string     `json:"avatar_url,omitempty"`
-	CreatedBy uuid.UUID  `json:"created_by"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	ID        int              `json:"id"`
+	Kind      ConversationKind `json:"kind"`
+	Name      string           `json:"name"`
+	AvatarURL string           `json:"avatar_url,omitempty"`
+	// Description : texte libre affiché sous le nom (sujet du groupe).
+	Description string     `json:"description,omitempty"`
+	CreatedBy   uuid.UUID  `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	// Permissions : réglages propres à la conversation (GROUP_UPDATE_PERMISSIONS, owner).
+	Permissions ConversationPermissions `json:"permissions"`
+	// DirectKey : "<uuid min>:<uuid max>" pour une conversation directe (clé unique), vide sinon.
+	DirectKey string `json:"-"`
+}
+
+// ConversationPermissions assouplit ou restreint les droits fixes des rôles. Les valeurs zéro
+// correspondent au comportement par défaut : tout membre écrit, seuls admins/owners ajoutent et épinglent.
+type ConversationPermissions struct {
+	// OnlyAdminsCanPost : canal d'annonces, les membres (rôle 0) lisent sans écrire.
+	OnlyAdminsCanPost bool `json:"only_admins_can_post"`
+	// MembersCanAddMembers : un membre peut ajouter d'autres membres (rôle 0 uniquement).
+	MembersCanAddMembers bool `json:"members_can_add_members"`
+	MembersCanPin        bool `json:"members_can_pin"`
+	// SlowModeSeconds : délai minimal entre deux messages d'un même membre (0 = désactivé).
+	// Les admins et owners n'y sont pas soumis.
+	SlowModeSeconds int `json:"slow_mode_seconds"`
+	// JoinRequestsEnabled : les non-membres peuvent demander à rejoindre le groupe (GROUP_JOIN_REQUEST).
+	JoinRequestsEnabled bool `json:"join_requests_enabled"`
+}
+
+// DirectKey construit la clé unique d'une conversation directe, indépendante de l'ordre des utilisateurs.
+func DirectKey(a, b uuid.UUID) string {
+	first, second := a.String(), b.String()
+	if second < first {
+		first, second = second, first
+	}
+	return first + ":" + second
+}
+
+// IsDirect indique une conversation 1:1.
+func (c *Conversation) IsDirect() bool {
+	return c.Kind == ConversationKindDirect
+}
+
+// DirectPeer retourne l'autre participant d'une conversation directe (uuid.Nil si non applicable).
+func (c *Conversation) DirectPeer(viewerID uuid.UUID) uuid.UUID {
+	if !c.IsDirect() {
+		return uuid.Nil
+	}
+	first, second, ok := strings.Cut(c.DirectKey, ":")
+	if !ok {
+		return uuid.Nil
+	}
+	peer := first
+	if first == viewerID.String() {
+		peer = second
+	}
+	parsed, err := uuid.Parse(peer)
+	if err != nil {
+		return uuid.Nil
+	}
+	return parsed
 }
 
 type ConversationMembership struct {
@@ -35,4 +100,6 @@ type ConversationMembership struct {
 	Role           ConversationRole `json:"role"`
 	CreatedAt      time.Time        `json:"created_at"`
 	DeletedAt      *time.Time       `json:"deleted_at,omitempty"`
+	// ArchivedAt : conversation masquée de la liste de ce membre (non nil = archivée).
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
 }
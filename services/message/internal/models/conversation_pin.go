@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConversationPin : message épinglé dans une conversation (table conversation_pins).
+type ConversationPin struct {
+	ConversationID int       `json:"conversation_id"`
+	MessageID      int       `json:"message_id"`
+	PinnedBy       uuid.UUID `json:"pinned_by"`
+	PinnedAt       time.Time `json:"pinned_at"`
+}
@@ -21,6 +21,56 @@ const (
 	EventGroupUpdateRole   = "GROUP_UPDATE_ROLE"
 	EventGroupLeave        = "GROUP_LEAVE"
 	EventGroupDelete       = "GROUP_DELETE"
+	EventGroupUpdate       = "GROUP_UPDATE"
+
+	EventGroupTransferOwnership = "GROUP_TRANSFER_OWNERSHIP"
+	EventGroupAddMembersBulk    = "GROUP_ADD_MEMBERS_BULK"
+	EventGroupRemoveMembersBulk = "GROUP_REMOVE_MEMBERS_BULK"
+
+	EventGroupInviteCreate = "GROUP_INVITE_CREATE"
+	EventGroupInviteList   = "GROUP_INVITE_LIST"
+	EventGroupInviteRevoke = "GROUP_INVITE_REVOKE"
+	EventGroupInviteJoin   = "GROUP_INVITE_JOIN"
+
+	EventGroupJoinRequest = "GROUP_JOIN_REQUEST"
+	EventGroupJoinDecide  = "GROUP_JOIN_DECIDE"
+	EventGroupJoinList    = "GROUP_JOIN_LIST"
+
+	EventGroupArchive = "GROUP_ARCHIVE"
+	EventGroupRestore = "GROUP_RESTORE"
+
+	EventGroupUpdatePermissions = "GROUP_UPDATE_PERMISSIONS"
+
+	EventDirectGetOrCreate = "DIRECT_GET_OR_CREATE"
+
+	EventScheduleMessage        = "SCHEDULE_MESSAGE"
+	EventListScheduledMessages  = "LIST_SCHEDULED_MESSAGES"
+	EventCancelScheduledMessage = "CANCEL_SCHEDULED_MESSAGE"
+
+	EventPinMessage   = "PIN_MESSAGE"
+	EventUnpinMessage = "UNPIN_MESSAGE"
+	EventListPins     = "LIST_PINS"
+
+	EventPollCreate = "POLL_CREATE"
+	EventPollVote   = "POLL_VOTE"
+	EventPollClose  = "POLL_CLOSE"
+	EventPollGet    = "POLL_GET"
+
+	EventUserBlock     = "USER_BLOCK"
+	EventUserUnblock   = "USER_UNBLOCK"
+	EventUserBlockList = "USER_BLOCK_LIST"
+
+	EventMessageReport        = "MESSAGE_REPORT"
+	EventMessageReportList    = "MESSAGE_REPORT_LIST"
+	EventMessageReportResolve = "MESSAGE_REPORT_RESOLVE"
+
+	EventGroupBan      = "GROUP_BAN"
+	EventGroupUnban    = "GROUP_UNBAN"
+	EventGroupBanList  = "GROUP_BAN_LIST"
+	EventGroupMute     = "GROUP_MUTE"
+	EventGroupUnmute   = "GROUP_UNMUTE"
+	EventGroupKick     = "GROUP_KICK"
+	EventGroupAuditLog = "GROUP_AUDIT_LOG"
 )
 
 type EventMessage struct {
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConversationInvite : lien d'invitation partageable vers un groupe (jeton opaque).
+// MaxUses = 0 : illimité ; ExpiresAt nil : pas d'expiration.
+type ConversationInvite struct {
+	ID             int        `json:"id"`
+	Token          string     `json:"token"`
+	ConversationID int        `json:"conversation_id"`
+	CreatedBy      uuid.UUID  `json:"created_by"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	MaxUses        int        `json:"max_uses"`
+	UseCount       int        `json:"use_count"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// ConversationInviteUse : trace d'audit d'une utilisation réussie d'une invitation.
+type ConversationInviteUse struct {
+	ID       int       `json:"id"`
+	InviteID int       `json:"invite_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	UsedAt   time.Time `json:"used_at"`
+}
+
+// IsExpired : échéance expires_at atteinte.
+func (i *ConversationInvite) IsExpired(now time.Time) bool {
+	return i.ExpiresAt != nil && !now.Before(*i.ExpiresAt)
+}
+
+// IsExhausted : nombre maximal d'utilisations atteint.
+func (i *ConversationInvite) IsExhausted() bool {
+	return i.MaxUses > 0 && i.UseCount >= i.MaxUses
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JoinRequestStatus string
+
+const (
+	JoinRequestPending  JoinRequestStatus = "pending"
+	JoinRequestApproved JoinRequestStatus = "approved"
+	JoinRequestDenied   JoinRequestStatus = "denied"
+)
+
+// ConversationJoinRequest : demande d'adhésion à un groupe, en attente de décision d'un admin/owner.
+// Une seule demande pending par (conversation, utilisateur) ; les demandes décidées restent en historique.
+type ConversationJoinRequest struct {
+	ID             int               `json:"id"`
+	ConversationID int               `json:"conversation_id"`
+	UserID         uuid.UUID         `json:"user_id"`
+	Message        string            `json:"message,omitempty"`
+	Status         JoinRequestStatus `json:"status"`
+	DecidedBy      uuid.UUID         `json:"decided_by,omitempty"`
+	DecidedAt      *time.Time        `json:"decided_at,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+}
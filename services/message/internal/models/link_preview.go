@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// LinkPreview : métadonnées OpenGraph/HTML du premier lien d'un message (table link_previews).
+type LinkPreview struct {
+	URL         string    `json:"url"`
+	Title       string    `json:"title,omitempty"`
+	Description string    `json:"description,omitempty"`
+	ImageURL    string    `json:"image_url,omitempty"`
+	SiteName    string    `json:"site_name,omitempty"`
+	FetchedAt   time.Time `json:"fetched_at"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MessageReportStatus string
+
+const (
+	MessageReportPending   MessageReportStatus = "pending"
+	MessageReportResolved  MessageReportStatus = "resolved"
+	MessageReportDismissed MessageReportStatus = "dismissed"
+)
+
+// MessageReport : signalement d'un message par un membre, traité par les admins/owners de la conversation.
+// Un seul signalement par (message, auteur du signalement).
+type MessageReport struct {
+	ID             int                 `json:"id"`
+	MessageID      int                 `json:"message_id"`
+	ConversationID int                 `json:"conversation_id"`
+	ReporterID     uuid.UUID           `json:"reporter_id"`
+	Reason         string              `json:"reason"`
+	Status         MessageReportStatus `json:"status"`
+	ResolvedBy     uuid.UUID           `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time          `json:"resolved_at,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+}
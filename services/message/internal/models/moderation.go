@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConversationBan : UserID ne peut plus être ajouté au groupe ni le rejoindre (invitation, demande d'adhésion).
+type ConversationBan struct {
+	ConversationID int       `json:"conversation_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	BannedBy       uuid.UUID `json:"banned_by"`
+	Reason         string    `json:"reason,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ConversationMute : UserID lit la conversation mais ne peut plus y publier avant MutedUntil.
+type ConversationMute struct {
+	ConversationID int       `json:"conversation_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	MutedBy        uuid.UUID `json:"muted_by"`
+	MutedUntil     time.Time `json:"muted_until"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// IsActive : la sourdine court encore à now.
+func (m *ConversationMute) IsActive(now time.Time) bool {
+	return m != nil && m.MutedUntil.After(now)
+}
+
+type AuditAction string
+
+const (
+	AuditActionBan    AuditAction = "ban"
+	AuditActionUnban  AuditAction = "unban"
+	AuditActionMute   AuditAction = "mute"
+	AuditActionUnmute AuditAction = "unmute"
+	AuditActionKick   AuditAction = "kick"
+)
+
+// AuditLogEntry : action de modération (journal en ajout seul, lisible par les admins/owners).
+// ExpiresAt n'est renseigné que pour une sourdine.
+type AuditLogEntry struct {
+	ID             int         `json:"id"`
+	ConversationID int         `json:"conversation_id"`
+	ActorID        uuid.UUID   `json:"actor_id"`
+	Action         AuditAction `json:"action"`
+	TargetID       uuid.UUID   `json:"target_id"`
+	Reason         string      `json:"reason,omitempty"`
+	ExpiresAt      *time.Time  `json:"expires_at,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Poll : sondage affiché dans la timeline via le message MessageID.
+// Les champs de décompte (VoteCount, VoterIDs, TotalVoters, MyOptionIDs) sont calculés par le service.
+type Poll struct {
+	ID             int          `json:"id"`
+	MessageID      int          `json:"message_id"`
+	ConversationID int          `json:"conversation_id"`
+	CreatedBy      uuid.UUID    `json:"created_by"`
+	Question       string       `json:"question"`
+	MultiChoice    bool         `json:"multi_choice"`
+	Anonymous      bool         `json:"anonymous"`
+	ClosesAt       *time.Time   `json:"closes_at,omitempty"`
+	ClosedAt       *time.Time   `json:"closed_at,omitempty"`
+	CreatedAt      time.Time    `json:"created_at"`
+	Options        []PollOption `json:"options"`
+
+	TotalVoters int   `json:"total_voters"`
+	MyOptionIDs []int `json:"my_option_ids,omitempty"`
+}
+
+// PollOption : VoterIDs reste vide pour un sondage anonyme.
+type PollOption struct {
+	ID        int         `json:"id"`
+	Position  int         `json:"position"`
+	Label     string      `json:"label"`
+	VoteCount int         `json:"vote_count"`
+	VoterIDs  []uuid.UUID `json:"voter_ids,omitempty"`
+}
+
+// PollVote : une ligne par (sondage, option, utilisateur).
+type PollVote struct {
+	PollID   int       `json:"poll_id"`
+	OptionID int       `json:"option_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	VotedAt  time.Time `json:"voted_at"`
+}
+
+// IsClosed : fermé explicitement (POLL_CLOSE) ou échéance closes_at atteinte.
+func (p *Poll) IsClosed(now time.Time) bool {
+	if p.ClosedAt != nil {
+		return true
+	}
+	return p.ClosesAt != nil && !now.Before(*p.ClosesAt)
+}
+
+// HasOption indique si optionID appartient au sondage.
+func (p *Poll) HasOption(optionID int) bool {
+	for _, option := range p.Options {
+		if option.ID == optionID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuts d'un message programmé (table scheduled_messages).
+const (
+	ScheduledStatusPending    = "pending"
+	ScheduledStatusProcessing = "processing"
+	ScheduledStatusSent       = "sent"
+	ScheduledStatusCancelled  = "cancelled"
+	ScheduledStatusFailed     = "failed"
+)
+
+// ScheduledMessage : message en attente d'envoi à SendAt.
+// MessageID est renseigné une fois le message réellement inséré dans messages.
+type ScheduledMessage struct {
+	ID             int        `json:"id"`
+	SenderID       uuid.UUID  `json:"sender_id"`
+	ConversationID int        `json:"conversation_id"`
+	Content        string     `json:"content"`
+	Attachment     string     `json:"attachment,omitempty"`
+	ReplyToID      *int       `json:"reply_to_id,omitempty"`
+	SendAt         time.Time  `json:"send_at"`
+	Status         string     `json:"status"`
+	MessageID      *int       `json:"message_id,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	ClaimedAt      *time.Time `json:"claimed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "github.com/google/uuid"
+
+type MessageKind string
+
+const (
+	MessageKindUser   MessageKind = "user"
+	MessageKindSystem MessageKind = "system"
+)
+
+// Types d'événements portés par un message système.
+const (
+	SystemEventMemberAdded          = "member_added"
+	SystemEventMemberRemoved        = "member_removed"
+	SystemEventMemberBanned         = "member_banned"
+	SystemEventMemberLeft           = "member_left"
+	SystemEventMemberJoined         = "member_joined"
+	SystemEventRoleChanged          = "role_changed"
+	SystemEventOwnershipTransferred = "ownership_transferred"
+	SystemEventConversationRenamed  = "conversation_renamed"
+)
+
+// SystemEvent : données structurées d'un message système (colonne messages.system_event).
+// Les clients composent le texte affiché (« Alice a ajouté Bob ») à partir de ces champs.
+type SystemEvent struct {
+	Type     string            `json:"type"`
+	ActorID  uuid.UUID         `json:"actor_id"`
+	TargetID *uuid.UUID        `json:"target_id,omitempty"`
+	Role     *ConversationRole `json:"role,omitempty"`
+	Name     string            `json:"name,omitempty"`
+}
+
+// IsSystem : message généré par le service, non modifiable ni supprimable par les membres.
+func (m *ChatMessage) IsSystem() bool {
+	return m.Kind == MessageKindSystem
+}
@@ -0,0 +1,111 @@
+package nats
+
+import (
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func (h *Handler) handleGroupArchive(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondGroupArchiveError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.GroupArchiveRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondGroupArchiveError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondGroupArchiveError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 {
+		h.respondGroupArchiveError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+
+	membership, err := h.conversationSvc.ArchiveConversation(actorID, int(req.GetConversationId()), req.GetArchived(), time.Now())
+	if err != nil {
+		h.respondGroupArchiveError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	resp := &apiv1.GroupArchiveResponse{Ok: true}
+	if membership.ArchivedAt != nil {
+		resp.Archived = true
+		resp.ArchivedAt = membership.ArchivedAt.Unix()
+	}
+	h.respondProto(msg, resp)
+}
+
+func (h *Handler) handleGroupRestore(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondGroupRestoreError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.GroupRestoreRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondGroupRestoreError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondGroupRestoreError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 {
+		h.respondGroupRestoreError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+
+	conversation, err := h.conversationSvc.RestoreConversation(actorID, int(req.GetConversationId()), time.Now())
+	if err != nil {
+		h.respondGroupRestoreError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	// Membres réintégrés : le gateway leur renvoie la conversation dans leur room utilisateur.
+	members, err := h.conversationSvc.ListMembers(actorID, conversation.ID)
+	if err != nil {
+		h.respondGroupRestoreError(msg, mapConversationError(err), err.Error())
+		return
+	}
+	memberIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		memberIDs = append(memberIDs, member.UserID.String())
+	}
+
+	h.respondProto(msg, &apiv1.GroupRestoreResponse{
+		Ok:        true,
+		Data:      conversationToProto(conversation, actorID),
+		MemberIds: memberIDs,
+	})
+}
+
+func (h *Handler) respondGroupArchiveError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.GroupArchiveResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondGroupRestoreError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.GroupRestoreResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
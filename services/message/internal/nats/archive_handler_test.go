@@ -0,0 +1,44 @@
+package nats
+
+import (
+	"testing"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+)
+
+func TestHandlerGroupArchiveAndRestore(t *testing.T) {
+	fix := newLot6Fixture(t)
+
+	dispatchNATSHandler(t, &apiv1.GroupArchiveRequest{
+		ActorId:        lot6MemberID.String(),
+		ConversationId: int32(fix.conversationID),
+		Archived:       true,
+	}, fix.handler.handleGroupArchive)
+	if conversations, err := fix.conversationSvc.ListConversationsByUser(lot6MemberID); err != nil || len(conversations) != 0 {
+		t.Fatalf("archived conversation should be hidden, got %d (%v)", len(conversations), err)
+	}
+	if conversations, err := fix.conversationSvc.ListConversationsByUser(lot6OwnerID); err != nil || len(conversations) != 1 {
+		t.Fatalf("archive should only affect the actor, got %d (%v)", len(conversations), err)
+	}
+
+	if err := fix.conversationSvc.DeleteConversation(lot6OwnerID, fix.conversationID); err != nil {
+		t.Fatalf("DeleteConversation() error = %v", err)
+	}
+
+	// Un admin ne peut pas restaurer.
+	dispatchNATSHandler(t, &apiv1.GroupRestoreRequest{
+		ActorId:        lot6AdminID.String(),
+		ConversationId: int32(fix.conversationID),
+	}, fix.handler.handleGroupRestore)
+	if ok, _ := fix.conversationSvc.IsMember(lot6AdminID, fix.conversationID); ok {
+		t.Fatal("admin restore should be rejected")
+	}
+
+	dispatchNATSHandler(t, &apiv1.GroupRestoreRequest{
+		ActorId:        lot6OwnerID.String(),
+		ConversationId: int32(fix.conversationID),
+	}, fix.handler.handleGroupRestore)
+	if ok, err := fix.conversationSvc.IsMember(lot6Member2ID, fix.conversationID); err != nil || !ok {
+		t.Fatalf("members should be restored (member = %v, err = %v)", ok, err)
+	}
+}
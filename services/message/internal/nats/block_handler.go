@@ -0,0 +1,151 @@
+package nats
+
+import (
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/broadcast"
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func (h *Handler) handleUserBlock(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondUserBlockError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.UserBlockRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondUserBlockError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondUserBlockError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	userID, err := parseUUID("user_id", req.GetUserId())
+	if err != nil {
+		h.respondUserBlockError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+
+	block, err := h.conversationSvc.BlockUser(actorID, userID)
+	if err != nil {
+		h.respondUserBlockError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	// Room personnelle du bloqueur : ses autres onglets masquent les messages du bloqué.
+	broadcast.Publish(h.publisher, broadcast.UserRoom(actorID), map[string]interface{}{
+		"action":  "user_blocked",
+		"user_id": userID.String(),
+	})
+
+	h.respondProto(msg, &apiv1.UserBlockResponse{
+		Ok:   true,
+		Data: userBlockToProto(block),
+	})
+}
+
+func (h *Handler) handleUserUnblock(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondUserBlockError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.UserBlockRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondUserBlockError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondUserBlockError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	userID, err := parseUUID("user_id", req.GetUserId())
+	if err != nil {
+		h.respondUserBlockError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+
+	if err := h.conversationSvc.UnblockUser(actorID, userID); err != nil {
+		h.respondUserBlockError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	broadcast.Publish(h.publisher, broadcast.UserRoom(actorID), map[string]interface{}{
+		"action":  "user_unblocked",
+		"user_id": userID.String(),
+	})
+
+	h.respondProto(msg, &apiv1.UserBlockResponse{Ok: true})
+}
+
+func (h *Handler) handleUserBlockList(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondUserBlockListError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.UserBlockListRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondUserBlockListError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondUserBlockListError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+
+	blocks, err := h.conversationSvc.ListBlockedUsers(actorID)
+	if err != nil {
+		h.respondUserBlockListError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	data := make([]*apiv1.UserBlock, 0, len(blocks))
+	for _, block := range blocks {
+		data = append(data, userBlockToProto(block))
+	}
+	h.respondProto(msg, &apiv1.UserBlockListResponse{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+func userBlockToProto(block *models.UserBlock) *apiv1.UserBlock {
+	if block == nil {
+		return nil
+	}
+	return &apiv1.UserBlock{
+		BlockerId: block.BlockerID.String(),
+		BlockedId: block.BlockedID.String(),
+		CreatedAt: block.CreatedAt.Unix(),
+	}
+}
+
+func (h *Handler) respondUserBlockError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.UserBlockResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondUserBlockListError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.UserBlockListResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
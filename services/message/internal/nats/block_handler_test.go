@@ -0,0 +1,47 @@
+package nats
+
+import (
+	"testing"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+)
+
+func TestHandlerBlockedSenderHiddenFromBlocker(t *testing.T) {
+	fix := newLot6Fixture(t)
+
+	dispatchNATSHandler(t, &apiv1.UserBlockRequest{
+		ActorId: lot6MemberID.String(),
+		UserId:  lot6Member2ID.String(),
+	}, fix.handler.handleUserBlock)
+
+	for _, sender := range []string{lot6Member2ID.String(), lot6AdminID.String()} {
+		dispatchNATSHandler(t, &apiv1.SendMessageRequest{
+			ConversationId: int32(fix.conversationID),
+			SenderId:       sender,
+			Content:        "bonjour",
+		}, fix.handler.handleSendMessage)
+	}
+	messages, err := fix.messageSvc.GetMessagesByConversationID(fix.conversationID)
+	if err != nil || len(messages) != 2 {
+		t.Fatalf("expected 2 stored messages, got %d (err = %v)", len(messages), err)
+	}
+
+	visible, err := fix.handler.hideBlockedMessages(lot6MemberID, messages)
+	if err != nil {
+		t.Fatalf("hideBlockedMessages() error = %v", err)
+	}
+	if len(visible) != 1 || visible[0].SenderID != lot6AdminID {
+		t.Fatalf("blocker should only see the admin message, got %+v", visible)
+	}
+	if others, _ := fix.handler.hideBlockedMessages(lot6OwnerID, messages); len(others) != 2 {
+		t.Fatalf("other members should see every message, got %d", len(others))
+	}
+
+	dispatchNATSHandler(t, &apiv1.UserBlockRequest{
+		ActorId: lot6MemberID.String(),
+		UserId:  lot6Member2ID.String(),
+	}, fix.handler.handleUserUnblock)
+	if blocked, _ := fix.conversationSvc.HasBlocked(lot6MemberID, lot6Member2ID); blocked {
+		t.Fatal("expected unblock to remove the block")
+	}
+}
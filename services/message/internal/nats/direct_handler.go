@@ -0,0 +1,53 @@
+package nats
+
+import (
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func (h *Handler) handleDirectGetOrCreate(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondDirectGetOrCreateError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.DirectGetOrCreateRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondDirectGetOrCreateError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondDirectGetOrCreateError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	userID, err := parseUUID("user_id", req.GetUserId())
+	if err != nil {
+		h.respondDirectGetOrCreateError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+
+	conversation, created, err := h.conversationSvc.GetOrCreateDirectConversation(actorID, userID)
+	if err != nil {
+		h.respondDirectGetOrCreateError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	h.respondProto(msg, &apiv1.DirectGetOrCreateResponse{
+		Ok:      true,
+		Data:    conversationToProto(conversation, actorID),
+		Created: created,
+	})
+}
+
+func (h *Handler) respondDirectGetOrCreateError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.DirectGetOrCreateResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
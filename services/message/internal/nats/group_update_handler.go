@@ -0,0 +1,84 @@
+package nats
+
+import (
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/broadcast"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/service"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func (h *Handler) handleGroupUpdate(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondGroupUpdateError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.GroupUpdateRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondGroupUpdateError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondGroupUpdateError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 {
+		h.respondGroupUpdateError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+
+	var update service.ConversationUpdate
+	for _, field := range req.GetUpdateMask() {
+		switch field {
+		case service.ConversationFieldName:
+			name := req.GetName()
+			update.Name = &name
+		case service.ConversationFieldAvatarURL:
+			avatarURL := req.GetAvatarUrl()
+			update.AvatarURL = &avatarURL
+		case service.ConversationFieldDescription:
+			description := req.GetDescription()
+			update.Description = &description
+		default:
+			h.respondGroupUpdateError(msg, errorCodeBadRequest, "unknown field in update_mask: "+field)
+			return
+		}
+	}
+
+	conversation, changed, err := h.conversationSvc.UpdateConversation(actorID, int(req.GetConversationId()), update)
+	if err != nil {
+		h.respondGroupUpdateError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	if len(changed) > 0 {
+		broadcast.Publish(h.publisher, broadcast.ConversationRoom(conversation.ID), map[string]interface{}{
+			"action":          "conversation_updated",
+			"conversation_id": conversation.ID,
+			"name":            conversation.Name,
+			"avatar_url":      conversation.AvatarURL,
+			"description":     conversation.Description,
+			"changed":         changed,
+			"updated_by":      actorID.String(),
+			"updated_at":      conversation.UpdatedAt.Unix(),
+		})
+	}
+
+	h.respondProto(msg, &apiv1.GroupUpdateResponse{
+		Ok:   true,
+		Data: conversationToProto(conversation, actorID),
+	})
+}
+
+func (h *Handler) respondGroupUpdateError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.GroupUpdateResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
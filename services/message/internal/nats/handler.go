@@ -1,6 +1,7 @@
 package nats
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"log"
@@ -8,7 +9,11 @@ import (
 	"time"
 
 	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/attachments"
 	"github.com/Mathis-brgs/storm-project/services/message/internal/batch"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/broadcast"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/linkpreview"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/mentions"
 	"github.com/Mathis-brgs/storm-project/services/message/internal/models"
 	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
 	"github.com/Mathis-brgs/storm-project/services/message/internal/service"
@@ -23,6 +28,9 @@ const (
 	errorCodeForbidden  = "FORBIDDEN"
 	errorCodeConflict   = "CONFLICT"
 	errorCodeInternal   = "INTERNAL"
+	// errorCodeRateLimited : mode lent de la conversation (ErrSlowMode) ou demande d'adhésion trop
+	// rapprochée (ErrJoinRequestTooSoon), le gateway répond 429.
+	errorCodeRateLimited = "RATE_LIMITED"
 )
 
 const (
@@ -42,9 +50,64 @@ const (
 	subjectGroupUpdateRole  = "GROUP_UPDATE_ROLE"
 	subjectGroupLeave       = "GROUP_LEAVE"
 	subjectGroupDelete      = "GROUP_DELETE"
+	subjectGroupUpdate      = "GROUP_UPDATE"
+
+	subjectGroupTransferOwnership = "GROUP_TRANSFER_OWNERSHIP"
+	subjectGroupAddMembersBulk    = "GROUP_ADD_MEMBERS_BULK"
+	subjectGroupRemoveMembersBulk = "GROUP_REMOVE_MEMBERS_BULK"
+
+	subjectGroupInviteCreate = "GROUP_INVITE_CREATE"
+	subjectGroupInviteList   = "GROUP_INVITE_LIST"
+	subjectGroupInviteRevoke = "GROUP_INVITE_REVOKE"
+	subjectGroupInviteJoin   = "GROUP_INVITE_JOIN"
+
+	subjectGroupJoinRequest = "GROUP_JOIN_REQUEST"
+	subjectGroupJoinDecide  = "GROUP_JOIN_DECIDE"
+	subjectGroupJoinList    = "GROUP_JOIN_LIST"
+
+	subjectGroupArchive = "GROUP_ARCHIVE"
+	subjectGroupRestore = "GROUP_RESTORE"
+
+	subjectGroupUpdatePermissions = "GROUP_UPDATE_PERMISSIONS"
+
+	// subjectGroupMembershipCheck : JSON, interrogé par le media-service pour l'accès aux médias partagés.
+	subjectGroupMembershipCheck = "GROUP_MEMBERSHIP_CHECK"
+	// subjectMediaReferencesCheck : JSON, interrogé par le ramasse-miettes du media-service.
+	subjectMediaReferencesCheck = "MEDIA_REFERENCES_CHECK"
+
+	subjectDirectGetOrCreate = "DIRECT_GET_OR_CREATE"
 
 	subjectSetMessageStatus = "MESSAGE_SET_STATUS"
 	subjectMarkMessageSeen  = "MESSAGE_MARK_SEEN"
+
+	subjectScheduleMessage        = "SCHEDULE_MESSAGE"
+	subjectListScheduledMessages  = "LIST_SCHEDULED_MESSAGES"
+	subjectCancelScheduledMessage = "CANCEL_SCHEDULED_MESSAGE"
+
+	subjectPinMessage   = "PIN_MESSAGE"
+	subjectUnpinMessage = "UNPIN_MESSAGE"
+	subjectListPins     = "LIST_PINS"
+
+	subjectPollCreate = "POLL_CREATE"
+	subjectPollVote   = "POLL_VOTE"
+	subjectPollClose  = "POLL_CLOSE"
+	subjectPollGet    = "POLL_GET"
+
+	subjectUserBlock     = "USER_BLOCK"
+	subjectUserUnblock   = "USER_UNBLOCK"
+	subjectUserBlockList = "USER_BLOCK_LIST"
+
+	subjectMessageReport        = "MESSAGE_REPORT"
+	subjectMessageReportList    = "MESSAGE_REPORT_LIST"
+	subjectMessageReportResolve = "MESSAGE_REPORT_RESOLVE"
+
+	subjectGroupBan      = "GROUP_BAN"
+	subjectGroupUnban    = "GROUP_UNBAN"
+	subjectGroupBanList  = "GROUP_BAN_LIST"
+	subjectGroupMute     = "GROUP_MUTE"
+	subjectGroupUnmute   = "GROUP_UNMUTE"
+	subjectGroupKick     = "GROUP_KICK"
+	subjectGroupAuditLog = "GROUP_AUDIT_LOG"
 )
 
 func NewMessageHandler(svc *service.MessageService, conversationSvc *service.ConversationService, bw *batch.Writer) *Handler {
@@ -59,6 +122,14 @@ type Handler struct {
 	svc             *service.MessageService
 	conversationSvc *service.ConversationService
 	batchWriter     *batch.Writer
+	// publisher diffuse les événements temps réel (message.broadcast.<room>) ; renseigné par Listen.
+	publisher broadcast.Publisher
+	// mentions résout les @username via user.by_usernames et notifie les mentionnés ; renseigné par Listen.
+	mentions *mentions.Resolver
+	// attachments vérifie et décrit la pièce jointe (type, taille, durée) via media.get ; renseigné par Listen.
+	attachments *attachments.Resolver
+	// previews génère les aperçus de liens en tâche de fond ; renseigné par Listen.
+	previews *linkpreview.Unfurler
 }
 
 func (h *Handler) handleSendMessage(msg *nats.Msg) {
@@ -81,7 +152,7 @@ func (h *Handler) handleSendMessage(msg *nats.Msg) {
 		h.respondSendMessageError(msg, errorCodeBadRequest, "content required")
 		return
 	}
-	if err := h.authorizeConversationMember(senderID, conversationID); err != nil {
+	if err := h.authorizePost(senderID, conversationID, h.svc); err != nil {
 		code := mapConversationError(err)
 		h.respondSendMessageError(msg, code, err.Error())
 		return
@@ -102,6 +173,12 @@ func (h *Handler) handleSendMessage(msg *nats.Msg) {
 		fwdID := int(req.GetForwardFromId())
 		chatMsg.ForwardFromID = &fwdID
 	}
+	chatMsg.AttachmentInfo, err = h.attachments.Authorize(chatMsg.SenderID, conversationID, chatMsg.Attachment)
+	if err != nil {
+		h.respondSendMessageError(msg, mapMessageError(err), err.Error())
+		return
+	}
+	chatMsg.Mentions = h.mentions.Resolve(conversationID, chatMsg.SenderID, chatMsg.Content)
 
 	result, err := h.batchWriter.Submit(chatMsg)
 	if err != nil {
@@ -109,6 +186,8 @@ func (h *Handler) handleSendMessage(msg *nats.Msg) {
 		h.respondSendMessageError(msg, code, err.Error())
 		return
 	}
+	h.mentions.Notify(result)
+	h.previews.Enqueue(result)
 
 	h.respondProto(msg, &apiv1.SendMessageResponse{
 		Ok:   true,
@@ -174,6 +253,11 @@ func (h *Handler) handleListMessages(msg *nats.Msg) {
 		h.respondListMessagesError(msg, code, err.Error())
 		return
 	}
+	result, err = h.hideBlockedMessages(actorID, result)
+	if err != nil {
+		h.respondListMessagesError(msg, errorCodeInternal, err.Error())
+		return
+	}
 
 	h.respondProto(msg, &apiv1.ListMessagesResponse{
 		Ok:   true,
@@ -385,6 +469,65 @@ func (h *Handler) handleMarkMessageSeen(msg *nats.Msg) {
 	respondJSON(msg, map[string]interface{}{"ok": true})
 }
 
+// handleGroupMembershipCheck répond {ok, member} : user_id est-il membre actif de conversation_id ?
+func (h *Handler) handleGroupMembershipCheck(msg *nats.Msg) {
+	var req struct {
+		UserID         string `json:"user_id"`
+		ConversationID int    `json:"conversation_id"`
+	}
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		respondJSON(msg, map[string]interface{}{"ok": false, "error": "invalid request"})
+		return
+	}
+	if req.ConversationID <= 0 || req.UserID == "" {
+		respondJSON(msg, map[string]interface{}{"ok": false, "error": "user_id and conversation_id required"})
+		return
+	}
+	userID, err := parseUUID("user_id", req.UserID)
+	if err != nil {
+		respondJSON(msg, map[string]interface{}{"ok": false, "error": err.Error()})
+		return
+	}
+	if h.conversationSvc == nil {
+		respondJSON(msg, map[string]interface{}{"ok": false, "error": "conversation service unavailable"})
+		return
+	}
+	isMember, err := h.conversationSvc.IsMember(userID, req.ConversationID)
+	if err != nil {
+		respondJSON(msg, map[string]interface{}{"ok": false, "error": err.Error()})
+		return
+	}
+	respondJSON(msg, map[string]interface{}{"ok": true, "member": isMember})
+}
+
+// handleMediaReferencesCheck répond avec les media IDs encore attachés à un message ou utilisés comme
+// avatar de groupe ; une erreur (ok=false) empêche le media-service de supprimer quoi que ce soit.
+func (h *Handler) handleMediaReferencesCheck(msg *nats.Msg) {
+	var req struct {
+		MediaIDs []string `json:"media_ids"`
+	}
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		respondJSON(msg, map[string]interface{}{"ok": false, "error": "invalid request"})
+		return
+	}
+	referenced, err := h.svc.ReferencedAttachments(req.MediaIDs)
+	if err != nil {
+		respondJSON(msg, map[string]interface{}{"ok": false, "error": err.Error()})
+		return
+	}
+	if h.conversationSvc == nil {
+		respondJSON(msg, map[string]interface{}{"ok": false, "error": "conversation service unavailable"})
+		return
+	}
+	avatars, err := h.conversationSvc.ReferencedAvatars(req.MediaIDs)
+	if err != nil {
+		respondJSON(msg, map[string]interface{}{"ok": false, "error": err.Error()})
+		return
+	}
+	referenced = append(referenced, avatars...)
+	respondJSON(msg, map[string]interface{}{"ok": true, "referenced": referenced})
+}
+
 func respondJSON(msg *nats.Msg, v interface{}) {
 	data, _ := json.Marshal(v)
 	_ = msg.Respond(data)
@@ -417,7 +560,7 @@ func (h *Handler) handleGroupCreate(msg *nats.Msg) {
 
 	h.respondProto(msg, &apiv1.GroupCreateResponse{
 		Ok:   true,
-		Data: conversationToProto(conversation),
+		Data: conversationToProto(conversation, actorID),
 	})
 }
 
@@ -463,7 +606,7 @@ func (h *Handler) handleGroupGet(msg *nats.Msg) {
 
 	h.respondProto(msg, &apiv1.GroupGetResponse{
 		Ok:   true,
-		Data: conversationToProto(conversation),
+		Data: conversationToProto(conversation, actorID),
 	})
 }
 
@@ -485,7 +628,12 @@ func (h *Handler) handleGroupListForUser(msg *nats.Msg) {
 		return
 	}
 
-	conversations, err := h.conversationSvc.ListConversationsByUser(userID)
+	var conversations []*models.Conversation
+	if req.GetArchived() {
+		conversations, err = h.conversationSvc.ListArchivedConversations(userID)
+	} else {
+		conversations, err = h.conversationSvc.ListConversationsByUser(userID)
+	}
 	if err != nil {
 		code := mapConversationError(err)
 		h.respondGroupListForUserError(msg, code, err.Error())
@@ -494,7 +642,7 @@ func (h *Handler) handleGroupListForUser(msg *nats.Msg) {
 
 	data := make([]*apiv1.Group, 0, len(conversations))
 	for _, conversation := range conversations {
-		data = append(data, conversationToProto(conversation))
+		data = append(data, conversationToProto(conversation, userID))
 	}
 
 	h.respondProto(msg, &apiv1.GroupListForUserResponse{
@@ -738,6 +886,15 @@ func (h *Handler) handleGroupDelete(msg *nats.Msg) {
 }
 
 func (h *Handler) Listen(nc *nats.Conn) error {
+	h.publisher = nc
+	h.attachments = attachments.NewResolver(nc)
+	if h.conversationSvc != nil {
+		h.mentions = mentions.NewResolver(nc, h.conversationSvc)
+		h.conversationSvc.EnableSystemMessages(h.svc, h.broadcastSystemMessage)
+	}
+	h.previews = linkpreview.NewUnfurler(h.svc, linkpreview.NewFetcher(), nc)
+	go h.previews.Run(context.Background())
+
 	if _, err := nc.QueueSubscribe(subjectNewMessage, "message", h.handleSendMessage); err != nil {
 		return err
 	}
@@ -762,10 +919,51 @@ func (h *Handler) Listen(nc *nats.Conn) error {
 	if _, err := nc.QueueSubscribe(subjectMarkMessageSeen, "message", h.handleMarkMessageSeen); err != nil {
 		return err
 	}
+	if _, err := nc.QueueSubscribe(subjectGroupMembershipCheck, "message", h.handleGroupMembershipCheck); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectMediaReferencesCheck, "message", h.handleMediaReferencesCheck); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectScheduleMessage, "message", h.handleScheduleMessage); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectListScheduledMessages, "message", h.handleListScheduledMessages); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectCancelScheduledMessage, "message", h.handleCancelScheduledMessage); err != nil {
+		return err
+	}
+
+	if _, err := nc.QueueSubscribe(subjectPinMessage, "message", h.handlePinMessage); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectUnpinMessage, "message", h.handleUnpinMessage); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectListPins, "message", h.handleListPins); err != nil {
+		return err
+	}
+
+	if _, err := nc.QueueSubscribe(subjectPollCreate, "message", h.handlePollCreate); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectPollVote, "message", h.handlePollVote); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectPollClose, "message", h.handlePollClose); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectPollGet, "message", h.handlePollGet); err != nil {
+		return err
+	}
 
 	if _, err := nc.QueueSubscribe(subjectGroupCreate, "message", h.handleGroupCreate); err != nil {
 		return err
 	}
+	if _, err := nc.QueueSubscribe(subjectDirectGetOrCreate, "message", h.handleDirectGetOrCreate); err != nil {
+		return err
+	}
 	if _, err := nc.QueueSubscribe(subjectGroupGet, "message", h.handleGroupGet); err != nil {
 		return err
 	}
@@ -790,6 +988,87 @@ func (h *Handler) Listen(nc *nats.Conn) error {
 	if _, err := nc.QueueSubscribe(subjectGroupDelete, "message", h.handleGroupDelete); err != nil {
 		return err
 	}
+	if _, err := nc.QueueSubscribe(subjectGroupUpdate, "message", h.handleGroupUpdate); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupTransferOwnership, "message", h.handleGroupTransferOwnership); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupAddMembersBulk, "message", h.handleGroupAddMembersBulk); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupRemoveMembersBulk, "message", h.handleGroupRemoveMembersBulk); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupInviteCreate, "message", h.handleGroupInviteCreate); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupInviteList, "message", h.handleGroupInviteList); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupInviteRevoke, "message", h.handleGroupInviteRevoke); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupInviteJoin, "message", h.handleGroupInviteJoin); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupJoinRequest, "message", h.handleGroupJoinRequest); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupJoinDecide, "message", h.handleGroupJoinDecide); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupJoinList, "message", h.handleGroupJoinList); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupArchive, "message", h.handleGroupArchive); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupRestore, "message", h.handleGroupRestore); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupUpdatePermissions, "message", h.handleGroupUpdatePermissions); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectUserBlock, "message", h.handleUserBlock); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectUserUnblock, "message", h.handleUserUnblock); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectUserBlockList, "message", h.handleUserBlockList); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectMessageReport, "message", h.handleMessageReport); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectMessageReportList, "message", h.handleMessageReportList); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectMessageReportResolve, "message", h.handleMessageReportResolve); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupBan, "message", h.handleGroupBan); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupUnban, "message", h.handleGroupUnban); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupBanList, "message", h.handleGroupBanList); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupMute, "message", h.handleGroupMute); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupUnmute, "message", h.handleGroupUnmute); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupKick, "message", h.handleGroupKick); err != nil {
+		return err
+	}
+	if _, err := nc.QueueSubscribe(subjectGroupAuditLog, "message", h.handleGroupAuditLog); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -825,6 +1104,7 @@ func chatMessageToProto(m *models.ChatMessage) *apiv1.ChatMessage {
 		ConversationId: conversationID,
 		ReceivedAt:     receivedAt,
 		Status:         m.Status,
+		Kind:           string(m.Kind),
 	}
 	if m.ReplyToID != nil {
 		out.ReplyToId = int32(*m.ReplyToID)
@@ -832,6 +1112,9 @@ func chatMessageToProto(m *models.ChatMessage) *apiv1.ChatMessage {
 	if m.ForwardFromID != nil {
 		out.ForwardFromId = int32(*m.ForwardFromID)
 	}
+	if m.PollID != nil {
+		out.PollId = int32(*m.PollID)
+	}
 	if m.ReplyTo != nil {
 		out.ReplyTo = &apiv1.ReplyToRef{
 			Id:       int32(m.ReplyTo.ID),
@@ -839,6 +1122,19 @@ func chatMessageToProto(m *models.ChatMessage) *apiv1.ChatMessage {
 			Content:  m.ReplyTo.Content,
 		}
 	}
+	for _, id := range m.Mentions {
+		out.Mentions = append(out.Mentions, id.String())
+	}
+	if m.LinkPreview != nil {
+		out.LinkPreview = &apiv1.LinkPreview{
+			Url:         m.LinkPreview.URL,
+			Title:       m.LinkPreview.Title,
+			Description: m.LinkPreview.Description,
+			ImageUrl:    m.LinkPreview.ImageURL,
+			SiteName:    m.LinkPreview.SiteName,
+			FetchedAt:   m.LinkPreview.FetchedAt.Unix(),
+		}
+	}
 	for _, e := range m.SeenBy {
 		out.SeenBy = append(out.SeenBy, &apiv1.SeenByEntry{
 			UserId:      e.UserID,
@@ -846,10 +1142,42 @@ func chatMessageToProto(m *models.ChatMessage) *apiv1.ChatMessage {
 			SeenAt:      e.SeenAt,
 		})
 	}
+	if m.System != nil {
+		out.System = &apiv1.SystemEvent{
+			Type:    m.System.Type,
+			ActorId: m.System.ActorID.String(),
+			Name:    m.System.Name,
+		}
+		if m.System.TargetID != nil {
+			out.System.TargetId = m.System.TargetID.String()
+		}
+		if m.System.Role != nil {
+			out.System.Role = int32(*m.System.Role)
+		}
+	}
+	if m.AttachmentInfo != nil {
+		out.AttachmentInfo = attachmentInfoToProto(m.AttachmentInfo)
+	}
 	return out
 }
 
-func conversationToProto(c *models.Conversation) *apiv1.Group {
+func attachmentInfoToProto(info *models.AttachmentInfo) *apiv1.AttachmentInfo {
+	out := &apiv1.AttachmentInfo{
+		MediaId:     info.MediaID,
+		Type:        info.Type,
+		ContentType: info.ContentType,
+		Filename:    info.Filename,
+		Size:        info.Size,
+		DurationMs:  info.DurationMs,
+	}
+	for _, peak := range info.Waveform {
+		out.Waveform = append(out.Waveform, int32(peak))
+	}
+	return out
+}
+
+// conversationToProto : viewerID sert à renseigner peer_id pour une conversation directe.
+func conversationToProto(c *models.Conversation, viewerID uuid.UUID) *apiv1.Group {
 	if c == nil {
 		return nil
 	}
@@ -857,13 +1185,35 @@ func conversationToProto(c *models.Conversation) *apiv1.Group {
 	if c.CreatedBy != uuid.Nil {
 		createdBy = c.CreatedBy.String()
 	}
+	kind := c.Kind
+	if kind == "" {
+		kind = models.ConversationKindGroup
+	}
+	peerID := ""
+	if peer := c.DirectPeer(viewerID); peer != uuid.Nil {
+		peerID = peer.String()
+	}
 	return &apiv1.Group{
-		Id:        int32(c.ID),
-		Name:      c.Name,
-		AvatarUrl: c.AvatarURL,
-		CreatedBy: createdBy,
-		CreatedAt: c.CreatedAt.Unix(),
-		UpdatedAt: c.UpdatedAt.Unix(),
+		Id:          int32(c.ID),
+		Name:        c.Name,
+		AvatarUrl:   c.AvatarURL,
+		CreatedBy:   createdBy,
+		CreatedAt:   c.CreatedAt.Unix(),
+		UpdatedAt:   c.UpdatedAt.Unix(),
+		Kind:        string(kind),
+		PeerId:      peerID,
+		Description: c.Description,
+		Permissions: permissionsToProto(c.Permissions),
+	}
+}
+
+func permissionsToProto(p models.ConversationPermissions) *apiv1.GroupPermissions {
+	return &apiv1.GroupPermissions{
+		OnlyAdminsCanPost:    p.OnlyAdminsCanPost,
+		MembersCanAddMembers: p.MembersCanAddMembers,
+		MembersCanPin:        p.MembersCanPin,
+		SlowModeSeconds:      int32(p.SlowModeSeconds),
+		JoinRequestsEnabled:  p.JoinRequestsEnabled,
 	}
 }
 
@@ -919,6 +1269,32 @@ func (h *Handler) authorizeConversationMember(userID uuid.UUID, conversationID i
 	return nil
 }
 
+// hideBlockedMessages retire les messages des utilisateurs bloqués par viewerID.
+// Les messages système restent visibles : ils retracent l'historique du groupe.
+func (h *Handler) hideBlockedMessages(viewerID uuid.UUID, messages []*models.ChatMessage) ([]*models.ChatMessage, error) {
+	blocked, err := h.conversationSvc.BlockedUserSet(viewerID)
+	if err != nil || len(blocked) == 0 {
+		return messages, err
+	}
+	visible := make([]*models.ChatMessage, 0, len(messages))
+	for _, message := range messages {
+		if _, hidden := blocked[message.SenderID]; hidden && !message.IsSystem() {
+			continue
+		}
+		visible = append(visible, message)
+	}
+	return visible, nil
+}
+
+// authorizePost applique les permissions d'écriture de la conversation (lecture seule, mode lent).
+// lastMessages nil : le mode lent n'est pas appliqué.
+func (h *Handler) authorizePost(senderID uuid.UUID, conversationID int, lastMessages service.LastMessageLookup) error {
+	if h.conversationSvc == nil {
+		return errors.New("conversation service unavailable")
+	}
+	return h.conversationSvc.AuthorizePost(senderID, conversationID, time.Now(), lastMessages)
+}
+
 func (h *Handler) authorizeMessageMutation(actorID uuid.UUID, message *models.ChatMessage) error {
 	if message == nil {
 		return errors.New("message not found")
@@ -926,6 +1302,10 @@ func (h *Handler) authorizeMessageMutation(actorID uuid.UUID, message *models.Ch
 	if err := h.authorizeConversationMember(actorID, message.ConversationID); err != nil {
 		return err
 	}
+	// Les messages système retracent l'historique du groupe : ni modifiables ni supprimables.
+	if message.IsSystem() {
+		return service.ErrForbidden
+	}
 
 	if actorID == message.SenderID {
 		return nil
@@ -953,6 +1333,17 @@ func mapMessageError(err error) string {
 	if err == nil {
 		return errorCodeInternal
 	}
+	switch {
+	case errors.Is(err, service.ErrForbidden),
+		errors.Is(err, repo.ErrNotOwner),
+		errors.Is(err, attachments.ErrAttachmentForbidden):
+		return errorCodeForbidden
+	case errors.Is(err, repo.ErrScheduledMessageNotPending),
+		errors.Is(err, repo.ErrPollClosed),
+		errors.Is(err, repo.ErrReportExists),
+		errors.Is(err, repo.ErrReportResolved):
+		return errorCodeConflict
+	}
 	text := strings.ToLower(err.Error())
 	switch {
 	case strings.Contains(text, "not found"):
@@ -974,15 +1365,36 @@ func mapConversationError(err error) string {
 	case errors.Is(err, service.ErrInvalidConversationID),
 		errors.Is(err, service.ErrInvalidUserID),
 		errors.Is(err, service.ErrInvalidConversation),
-		errors.Is(err, service.ErrInvalidMembershipRole):
+		errors.Is(err, service.ErrInvalidMembershipRole),
+		errors.Is(err, service.ErrInvalidMessageID),
+		errors.Is(err, service.ErrInvalidMuteDuration):
 		return errorCodeBadRequest
-	case errors.Is(err, service.ErrForbidden):
+	case errors.Is(err, service.ErrForbidden),
+		errors.Is(err, repo.ErrUserBanned):
 		return errorCodeForbidden
+	case errors.Is(err, service.ErrSlowMode),
+		errors.Is(err, service.ErrJoinRequestTooSoon):
+		return errorCodeRateLimited
 	case errors.Is(err, repo.ErrMembershipAlreadyExists),
-		errors.Is(err, service.ErrLastOwnerGuard):
+		errors.Is(err, service.ErrLastOwnerGuard),
+		errors.Is(err, repo.ErrPinAlreadyExists),
+		errors.Is(err, repo.ErrInviteExpired),
+		errors.Is(err, repo.ErrInviteRevoked),
+		errors.Is(err, repo.ErrInviteExhausted),
+		errors.Is(err, repo.ErrJoinRequestExists),
+		errors.Is(err, repo.ErrJoinRequestDecided),
+		errors.Is(err, repo.ErrDirectConversationExists),
+		errors.Is(err, service.ErrRestoreWindowExpired),
+		errors.Is(err, service.ErrAlreadyBanned):
 		return errorCodeConflict
 	case errors.Is(err, repo.ErrConversationNotFound),
-		errors.Is(err, repo.ErrMembershipNotFound):
+		errors.Is(err, repo.ErrMembershipNotFound),
+		errors.Is(err, repo.ErrPinNotFound),
+		errors.Is(err, repo.ErrInviteNotFound),
+		errors.Is(err, repo.ErrJoinRequestNotFound),
+		errors.Is(err, repo.ErrBlockNotFound),
+		errors.Is(err, repo.ErrBanNotFound),
+		errors.Is(err, repo.ErrMuteNotFound):
 		return errorCodeNotFound
 	default:
 		return errorCodeInternal
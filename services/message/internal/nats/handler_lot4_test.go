@@ -4,6 +4,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/Mathis-brgs/storm-project/services/message/internal/batch"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/metrics"
 	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
 	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
 	"github.com/Mathis-brgs/storm-project/services/message/internal/service"
@@ -82,5 +84,5 @@ func buildSecurityTestHandler(t *testing.T) (*Handler, int) {
 		t.Fatalf("AddMember(member2) error = %v", err)
 	}
 
-	return NewMessageHandler(messageSvc, conversationSvc), conversation.ID
+	return NewMessageHandler(messageSvc, conversationSvc, batch.New(messageRepo, metrics.New())), conversation.ID
 }
@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/batch"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/metrics"
 	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
 	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
 	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
@@ -350,7 +352,7 @@ func newLot6Fixture(t *testing.T) *lot6Fixture {
 	conversationRepo := memory.NewConversationRepo()
 	messageSvc := service.NewMessageService(messageRepo)
 	conversationSvc := service.NewConversationService(conversationRepo)
-	handler := NewMessageHandler(messageSvc, conversationSvc)
+	handler := NewMessageHandler(messageSvc, conversationSvc, batch.New(messageRepo, metrics.New()))
 
 	conversation, err := conversationSvc.CreateConversation(lot6OwnerID, "Lot6 validation", "")
 	if err != nil {
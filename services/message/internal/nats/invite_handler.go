@@ -0,0 +1,228 @@
+package nats
+
+import (
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/broadcast"
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func (h *Handler) handleGroupInviteCreate(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondGroupInviteCreateError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.GroupInviteCreateRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondGroupInviteCreateError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondGroupInviteCreateError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 {
+		h.respondGroupInviteCreateError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+
+	var expiresAt *time.Time
+	if req.GetExpiresAt() > 0 {
+		t := time.Unix(req.GetExpiresAt(), 0).UTC()
+		expiresAt = &t
+	}
+
+	invite, err := h.conversationSvc.CreateInvite(actorID, int(req.GetConversationId()), expiresAt, int(req.GetMaxUses()), time.Now())
+	if err != nil {
+		h.respondGroupInviteCreateError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	h.respondProto(msg, &apiv1.GroupInviteCreateResponse{
+		Ok:   true,
+		Data: inviteToProto(invite),
+	})
+}
+
+func (h *Handler) handleGroupInviteList(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondGroupInviteListError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.GroupInviteListRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondGroupInviteListError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondGroupInviteListError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 {
+		h.respondGroupInviteListError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+
+	invites, err := h.conversationSvc.ListInvites(actorID, int(req.GetConversationId()))
+	if err != nil {
+		h.respondGroupInviteListError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	data := make([]*apiv1.ConversationInvite, 0, len(invites))
+	for _, invite := range invites {
+		data = append(data, inviteToProto(invite))
+	}
+	h.respondProto(msg, &apiv1.GroupInviteListResponse{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+func (h *Handler) handleGroupInviteRevoke(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondGroupInviteRevokeError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.GroupInviteRevokeRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondGroupInviteRevokeError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondGroupInviteRevokeError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 || req.GetInviteId() <= 0 {
+		h.respondGroupInviteRevokeError(msg, errorCodeBadRequest, "conversation_id and invite_id required")
+		return
+	}
+
+	invite, err := h.conversationSvc.RevokeInvite(actorID, int(req.GetConversationId()), int(req.GetInviteId()), time.Now())
+	if err != nil {
+		h.respondGroupInviteRevokeError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	h.respondProto(msg, &apiv1.GroupInviteRevokeResponse{
+		Ok:   true,
+		Data: inviteToProto(invite),
+	})
+}
+
+func (h *Handler) handleGroupInviteJoin(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondGroupInviteJoinError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.GroupInviteJoinRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondGroupInviteJoinError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondGroupInviteJoinError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetToken() == "" {
+		h.respondGroupInviteJoinError(msg, errorCodeBadRequest, "token required")
+		return
+	}
+
+	conversation, membership, err := h.conversationSvc.JoinByInvite(actorID, req.GetToken(), time.Now())
+	if err != nil {
+		h.respondGroupInviteJoinError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	broadcast.Publish(h.publisher, broadcast.ConversationRoom(conversation.ID), map[string]interface{}{
+		"action":          "member_joined",
+		"conversation_id": conversation.ID,
+		"user_id":         actorID.String(),
+		"role":            int(membership.Role),
+		"via":             "invite",
+	})
+
+	h.respondProto(msg, &apiv1.GroupInviteJoinResponse{
+		Ok:     true,
+		Data:   conversationToProto(conversation, actorID),
+		Member: membershipToProto(membership),
+	})
+}
+
+func inviteToProto(invite *models.ConversationInvite) *apiv1.ConversationInvite {
+	if invite == nil {
+		return nil
+	}
+	out := &apiv1.ConversationInvite{
+		Id:             int32(invite.ID),
+		Token:          invite.Token,
+		ConversationId: int32(invite.ConversationID),
+		CreatedBy:      invite.CreatedBy.String(),
+		MaxUses:        int32(invite.MaxUses),
+		UseCount:       int32(invite.UseCount),
+		CreatedAt:      invite.CreatedAt.Unix(),
+	}
+	if invite.ExpiresAt != nil {
+		out.ExpiresAt = invite.ExpiresAt.Unix()
+	}
+	if invite.RevokedAt != nil {
+		out.RevokedAt = invite.RevokedAt.Unix()
+	}
+	return out
+}
+
+func (h *Handler) respondGroupInviteCreateError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.GroupInviteCreateResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondGroupInviteListError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.GroupInviteListResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondGroupInviteRevokeError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.GroupInviteRevokeResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondGroupInviteJoinError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.GroupInviteJoinResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
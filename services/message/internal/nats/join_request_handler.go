@@ -0,0 +1,237 @@
+package nats
+
+import (
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/broadcast"
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	subjectNotificationSend = "notification.send"
+
+	// joinRequestNotificationType : type des notifications envoyées aux admins/owners (notification-service).
+	joinRequestNotificationType = "join_request"
+)
+
+func (h *Handler) handleGroupJoinRequest(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondGroupJoinRequestError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.GroupJoinRequestRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondGroupJoinRequestError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondGroupJoinRequestError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 {
+		h.respondGroupJoinRequestError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+
+	request, err := h.conversationSvc.RequestToJoin(actorID, int(req.GetConversationId()), req.GetMessage(), time.Now())
+	if err != nil {
+		h.respondGroupJoinRequestError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	h.notifyJoinRequest(request)
+
+	h.respondProto(msg, &apiv1.GroupJoinRequestResponse{
+		Ok:   true,
+		Data: joinRequestToProto(request),
+	})
+}
+
+func (h *Handler) handleGroupJoinDecide(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondGroupJoinDecideError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.GroupJoinDecideRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondGroupJoinDecideError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondGroupJoinDecideError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 || req.GetRequestId() <= 0 {
+		h.respondGroupJoinDecideError(msg, errorCodeBadRequest, "conversation_id and request_id required")
+		return
+	}
+
+	request, membership, err := h.conversationSvc.DecideJoinRequest(actorID, int(req.GetConversationId()), int(req.GetRequestId()), req.GetApprove(), time.Now())
+	if err != nil {
+		h.respondGroupJoinDecideError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	// Décision → room personnelle du demandeur (tous ses onglets).
+	broadcast.Publish(h.publisher, broadcast.UserRoom(request.UserID), map[string]interface{}{
+		"action":          "join_request_decided",
+		"conversation_id": request.ConversationID,
+		"request_id":      request.ID,
+		"status":          string(request.Status),
+		"decided_by":      actorID.String(),
+	})
+	if membership != nil {
+		broadcast.Publish(h.publisher, broadcast.ConversationRoom(request.ConversationID), map[string]interface{}{
+			"action":          "member_joined",
+			"conversation_id": request.ConversationID,
+			"user_id":         request.UserID.String(),
+			"role":            int(membership.Role),
+			"via":             "join_request",
+		})
+	}
+
+	h.respondProto(msg, &apiv1.GroupJoinDecideResponse{
+		Ok:     true,
+		Data:   joinRequestToProto(request),
+		Member: membershipToProto(membership),
+	})
+}
+
+func (h *Handler) handleGroupJoinList(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondGroupJoinListError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.GroupJoinListRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondGroupJoinListError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondGroupJoinListError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 {
+		h.respondGroupJoinListError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+
+	requests, err := h.conversationSvc.ListJoinRequests(actorID, int(req.GetConversationId()))
+	if err != nil {
+		h.respondGroupJoinListError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	data := make([]*apiv1.ConversationJoinRequest, 0, len(requests))
+	for _, request := range requests {
+		data = append(data, joinRequestToProto(request))
+	}
+	h.respondProto(msg, &apiv1.GroupJoinListResponse{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// notifyJoinRequest prévient chaque admin/owner du groupe via le notification-service (best effort).
+func (h *Handler) notifyJoinRequest(request *models.ConversationJoinRequest) {
+	if h.publisher == nil {
+		return
+	}
+	managers, err := h.conversationSvc.ListManagerIDs(request.ConversationID)
+	if err != nil {
+		log.Printf("[join-requests] list managers (conversation %d): %v", request.ConversationID, err)
+		return
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"conversationId": strconv.Itoa(request.ConversationID),
+		"requestId":      strconv.Itoa(request.ID),
+		"userId":         request.UserID.String(),
+		"message":        request.Message,
+	})
+	if err != nil {
+		log.Printf("[join-requests] marshal payload: %v", err)
+		return
+	}
+
+	for _, managerID := range managers {
+		data, err := json.Marshal(map[string]string{
+			"userId":  managerID.String(),
+			"type":    joinRequestNotificationType,
+			"payload": string(payload),
+		})
+		if err != nil {
+			continue
+		}
+		if err := h.publisher.Publish(subjectNotificationSend, data); err != nil {
+			log.Printf("[join-requests] notification.send %s: %v", managerID, err)
+		}
+	}
+}
+
+func joinRequestToProto(request *models.ConversationJoinRequest) *apiv1.ConversationJoinRequest {
+	if request == nil {
+		return nil
+	}
+	out := &apiv1.ConversationJoinRequest{
+		Id:             int32(request.ID),
+		ConversationId: int32(request.ConversationID),
+		UserId:         request.UserID.String(),
+		Message:        request.Message,
+		Status:         string(request.Status),
+		CreatedAt:      request.CreatedAt.Unix(),
+	}
+	if request.DecidedBy != uuid.Nil {
+		out.DecidedBy = request.DecidedBy.String()
+	}
+	if request.DecidedAt != nil {
+		out.DecidedAt = request.DecidedAt.Unix()
+	}
+	return out
+}
+
+func (h *Handler) respondGroupJoinRequestError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.GroupJoinRequestResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondGroupJoinDecideError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.GroupJoinDecideResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondGroupJoinListError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.GroupJoinListResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
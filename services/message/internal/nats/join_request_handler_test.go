@@ -0,0 +1,111 @@
+package nats
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/broadcast"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/service"
+)
+
+type recordedPublish struct {
+	subject string
+	data    map[string]interface{}
+}
+
+type recordingPublisher struct {
+	published []recordedPublish
+}
+
+func (p *recordingPublisher) Publish(subject string, data []byte) error {
+	var decoded map[string]interface{}
+	_ = json.Unmarshal(data, &decoded)
+	p.published = append(p.published, recordedPublish{subject: subject, data: decoded})
+	return nil
+}
+
+// enableJoinRequests ouvre le groupe du fixture aux demandes d'adhésion (désactivées par défaut).
+func enableJoinRequests(t *testing.T, fix *lot6Fixture) {
+	t.Helper()
+	enabled := true
+	if _, _, err := fix.conversationSvc.UpdatePermissions(lot6OwnerID, fix.conversationID, service.ConversationPermissionsUpdate{JoinRequestsEnabled: &enabled}); err != nil {
+		t.Fatalf("UpdatePermissions() error = %v", err)
+	}
+}
+
+func TestHandlerGroupJoinRequestWorkflow(t *testing.T) {
+	fix := newLot6Fixture(t)
+	enableJoinRequests(t, fix)
+	publisher := &recordingPublisher{}
+	fix.handler.publisher = publisher
+
+	dispatchNATSHandler(t, &apiv1.GroupJoinRequestRequest{
+		ActorId:        lot6ExternalID.String(),
+		ConversationId: int32(fix.conversationID),
+		Message:        "Bonjour",
+	}, fix.handler.handleGroupJoinRequest)
+
+	notified := map[string]bool{}
+	for _, p := range publisher.published {
+		if p.subject != subjectNotificationSend || p.data["type"] != joinRequestNotificationType {
+			t.Fatalf("unexpected publish %s %v", p.subject, p.data)
+		}
+		notified[p.data["userId"].(string)] = true
+	}
+	if len(notified) != 2 || !notified[lot6OwnerID.String()] || !notified[lot6AdminID.String()] {
+		t.Fatalf("expected owner and admin to be notified, got %v", notified)
+	}
+
+	requests, err := fix.conversationSvc.ListJoinRequests(lot6OwnerID, fix.conversationID)
+	if err != nil || len(requests) != 1 {
+		t.Fatalf("expected one pending request, got %+v (err = %v)", requests, err)
+	}
+
+	publisher.published = nil
+	dispatchNATSHandler(t, &apiv1.GroupJoinDecideRequest{
+		ActorId:        lot6AdminID.String(),
+		ConversationId: int32(fix.conversationID),
+		RequestId:      int32(requests[0].ID),
+		Approve:        true,
+	}, fix.handler.handleGroupJoinDecide)
+
+	isMember, err := fix.conversationSvc.IsMember(lot6ExternalID, fix.conversationID)
+	if err != nil || !isMember {
+		t.Fatalf("approved requester should be a member (err = %v)", err)
+	}
+	if len(publisher.published) == 0 {
+		t.Fatalf("expected a decision event")
+	}
+	decision := publisher.published[0]
+	if decision.subject != "message.broadcast."+broadcast.UserRoom(lot6ExternalID) ||
+		decision.data["action"] != "join_request_decided" || decision.data["status"] != "approved" {
+		t.Fatalf("unexpected decision event %s %v", decision.subject, decision.data)
+	}
+}
+
+func TestHandlerGroupJoinDecideRequiresManager(t *testing.T) {
+	fix := newLot6Fixture(t)
+	enableJoinRequests(t, fix)
+
+	request, err := fix.conversationSvc.RequestToJoin(lot6ExternalID, fix.conversationID, "", time.Now())
+	if err != nil {
+		t.Fatalf("RequestToJoin() error = %v", err)
+	}
+
+	dispatchNATSHandler(t, &apiv1.GroupJoinDecideRequest{
+		ActorId:        lot6MemberID.String(),
+		ConversationId: int32(fix.conversationID),
+		RequestId:      int32(request.ID),
+		Approve:        true,
+	}, fix.handler.handleGroupJoinDecide)
+
+	isMember, err := fix.conversationSvc.IsMember(lot6ExternalID, fix.conversationID)
+	if err != nil {
+		t.Fatalf("IsMember() error = %v", err)
+	}
+	if isMember {
+		t.Fatalf("a simple member should not be able to approve a join request")
+	}
+}
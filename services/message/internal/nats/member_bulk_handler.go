@@ -0,0 +1,191 @@
+package nats
+
+import (
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func (h *Handler) handleGroupTransferOwnership(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondGroupTransferOwnershipError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.GroupTransferOwnershipRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondGroupTransferOwnershipError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondGroupTransferOwnershipError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	userID, err := parseUUID("user_id", req.GetUserId())
+	if err != nil {
+		h.respondGroupTransferOwnershipError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 {
+		h.respondGroupTransferOwnershipError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+
+	previousOwner, newOwner, err := h.conversationSvc.TransferOwnership(actorID, int(req.GetConversationId()), userID)
+	if err != nil {
+		h.respondGroupTransferOwnershipError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	h.respondProto(msg, &apiv1.GroupTransferOwnershipResponse{
+		Ok:            true,
+		PreviousOwner: membershipToProto(previousOwner),
+		NewOwner:      membershipToProto(newOwner),
+	})
+}
+
+func (h *Handler) handleGroupAddMembersBulk(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondGroupAddMembersBulkError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.GroupAddMembersBulkRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondGroupAddMembersBulkError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondGroupAddMembersBulkError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 {
+		h.respondGroupAddMembersBulkError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+	userIDs, err := parseUUIDList("user_ids", req.GetUserIds())
+	if err != nil {
+		h.respondGroupAddMembersBulkError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+
+	results, err := h.conversationSvc.AddMembers(actorID, int(req.GetConversationId()), userIDs, models.ConversationRole(req.GetRole()))
+	if err != nil {
+		h.respondGroupAddMembersBulkError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	h.respondProto(msg, &apiv1.GroupAddMembersBulkResponse{
+		Ok:      true,
+		Results: membershipResultsToProto(results),
+	})
+}
+
+func (h *Handler) handleGroupRemoveMembersBulk(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondGroupRemoveMembersBulkError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.GroupRemoveMembersBulkRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondGroupRemoveMembersBulkError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondGroupRemoveMembersBulkError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 {
+		h.respondGroupRemoveMembersBulkError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+	userIDs, err := parseUUIDList("user_ids", req.GetUserIds())
+	if err != nil {
+		h.respondGroupRemoveMembersBulkError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+
+	results, err := h.conversationSvc.RemoveMembers(actorID, int(req.GetConversationId()), userIDs)
+	if err != nil {
+		h.respondGroupRemoveMembersBulkError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	h.respondProto(msg, &apiv1.GroupRemoveMembersBulkResponse{
+		Ok:      true,
+		Results: membershipResultsToProto(results),
+	})
+}
+
+// parseUUIDList : tout identifiant invalide rejette la requête entière.
+func parseUUIDList(field string, values []string) ([]uuid.UUID, error) {
+	out := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		id, err := parseUUID(field, value)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, nil
+}
+
+func membershipResultsToProto(results []repo.MembershipResult) []*apiv1.GroupMemberResult {
+	out := make([]*apiv1.GroupMemberResult, 0, len(results))
+	for _, result := range results {
+		item := &apiv1.GroupMemberResult{
+			UserId: result.UserID.String(),
+			Ok:     result.Err == nil,
+		}
+		if result.Err != nil {
+			item.Error = &apiv1.Error{
+				Code:    mapConversationError(result.Err),
+				Message: result.Err.Error(),
+			}
+		} else {
+			item.Member = membershipToProto(result.Membership)
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+func (h *Handler) respondGroupTransferOwnershipError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.GroupTransferOwnershipResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondGroupAddMembersBulkError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.GroupAddMembersBulkResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondGroupRemoveMembersBulkError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.GroupRemoveMembersBulkResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
@@ -0,0 +1,74 @@
+package nats
+
+import (
+	"testing"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestHandlerGroupTransferOwnership(t *testing.T) {
+	fix := newLot6Fixture(t)
+
+	// Un admin ne peut pas transférer la propriété.
+	dispatchNATSHandler(t, &apiv1.GroupTransferOwnershipRequest{
+		ActorId:        lot6AdminID.String(),
+		ConversationId: int32(fix.conversationID),
+		UserId:         lot6MemberID.String(),
+	}, fix.handler.handleGroupTransferOwnership)
+	if role := memberRoleForUser(t, fix.conversationSvc, fix.conversationID, lot6MemberID); role != models.ConversationRoleMember {
+		t.Fatalf("admin transfer should be rejected, member role = %d", role)
+	}
+
+	dispatchNATSHandler(t, &apiv1.GroupTransferOwnershipRequest{
+		ActorId:        lot6OwnerID.String(),
+		ConversationId: int32(fix.conversationID),
+		UserId:         lot6MemberID.String(),
+	}, fix.handler.handleGroupTransferOwnership)
+
+	if role := memberRoleForUser(t, fix.conversationSvc, fix.conversationID, lot6MemberID); role != models.ConversationRoleOwner {
+		t.Fatalf("expected new owner, got role %d", role)
+	}
+	if role := memberRoleForUser(t, fix.conversationSvc, fix.conversationID, lot6OwnerID); role != models.ConversationRoleAdmin {
+		t.Fatalf("expected previous owner to be admin, got role %d", role)
+	}
+}
+
+func TestHandlerGroupMembersBulk(t *testing.T) {
+	fix := newLot6Fixture(t)
+
+	dispatchNATSHandler(t, &apiv1.GroupAddMembersBulkRequest{
+		ActorId:        lot6AdminID.String(),
+		ConversationId: int32(fix.conversationID),
+		UserIds:        []string{lot6ExternalID.String(), lot6MemberID.String()},
+	}, fix.handler.handleGroupAddMembersBulk)
+	if role := memberRoleForUser(t, fix.conversationSvc, fix.conversationID, lot6ExternalID); role != models.ConversationRoleMember {
+		t.Fatalf("external user should be added as member, got role %d", role)
+	}
+
+	// Un identifiant invalide rejette toute la requête.
+	dispatchNATSHandler(t, &apiv1.GroupRemoveMembersBulkRequest{
+		ActorId:        lot6AdminID.String(),
+		ConversationId: int32(fix.conversationID),
+		UserIds:        []string{lot6ExternalID.String(), "not-a-uuid"},
+	}, fix.handler.handleGroupRemoveMembersBulk)
+	if ok, err := fix.conversationSvc.IsMember(lot6ExternalID, fix.conversationID); err != nil || !ok {
+		t.Fatalf("invalid request should not remove anyone (member = %v, err = %v)", ok, err)
+	}
+
+	dispatchNATSHandler(t, &apiv1.GroupRemoveMembersBulkRequest{
+		ActorId:        lot6AdminID.String(),
+		ConversationId: int32(fix.conversationID),
+		UserIds:        []string{lot6ExternalID.String(), lot6MemberID.String(), lot6OwnerID.String()},
+	}, fix.handler.handleGroupRemoveMembersBulk)
+
+	for _, userID := range []uuid.UUID{lot6ExternalID, lot6MemberID} {
+		if ok, _ := fix.conversationSvc.IsMember(userID, fix.conversationID); ok {
+			t.Fatalf("%s should have been removed", userID)
+		}
+	}
+	if ok, _ := fix.conversationSvc.IsMember(lot6OwnerID, fix.conversationID); !ok {
+		t.Fatalf("admin must not be able to remove the owner")
+	}
+}
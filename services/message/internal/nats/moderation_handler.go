@@ -0,0 +1,300 @@
+package nats
+
+import (
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/broadcast"
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func (h *Handler) handleGroupBan(msg *nats.Msg) {
+	req, actorID, userID, ok := h.parseModerationRequest(msg)
+	if !ok {
+		return
+	}
+	conversationID := int(req.GetConversationId())
+
+	ban, removed, err := h.conversationSvc.BanMember(actorID, conversationID, userID, req.GetReason(), time.Now())
+	if err != nil {
+		h.respondGroupModerationError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	if removed {
+		h.publishModeration(conversationID, "member_banned", actorID, userID, nil)
+	}
+	h.respondProto(msg, &apiv1.GroupModerationResponse{
+		Ok:  true,
+		Ban: groupBanToProto(ban),
+	})
+}
+
+func (h *Handler) handleGroupUnban(msg *nats.Msg) {
+	req, actorID, userID, ok := h.parseModerationRequest(msg)
+	if !ok {
+		return
+	}
+
+	if err := h.conversationSvc.UnbanMember(actorID, int(req.GetConversationId()), userID, time.Now()); err != nil {
+		h.respondGroupModerationError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	h.respondProto(msg, &apiv1.GroupModerationResponse{Ok: true})
+}
+
+func (h *Handler) handleGroupMute(msg *nats.Msg) {
+	req, actorID, userID, ok := h.parseModerationRequest(msg)
+	if !ok {
+		return
+	}
+	conversationID := int(req.GetConversationId())
+	duration := time.Duration(req.GetDurationSeconds()) * time.Second
+
+	mute, err := h.conversationSvc.MuteMember(actorID, conversationID, userID, duration, req.GetReason(), time.Now())
+	if err != nil {
+		h.respondGroupModerationError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	h.publishModeration(conversationID, "member_muted", actorID, userID, map[string]interface{}{
+		"muted_until": mute.MutedUntil.Unix(),
+	})
+	h.respondProto(msg, &apiv1.GroupModerationResponse{
+		Ok:   true,
+		Mute: groupMuteToProto(mute),
+	})
+}
+
+func (h *Handler) handleGroupUnmute(msg *nats.Msg) {
+	req, actorID, userID, ok := h.parseModerationRequest(msg)
+	if !ok {
+		return
+	}
+	conversationID := int(req.GetConversationId())
+
+	if err := h.conversationSvc.UnmuteMember(actorID, conversationID, userID, time.Now()); err != nil {
+		h.respondGroupModerationError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	h.publishModeration(conversationID, "member_unmuted", actorID, userID, nil)
+	h.respondProto(msg, &apiv1.GroupModerationResponse{Ok: true})
+}
+
+func (h *Handler) handleGroupKick(msg *nats.Msg) {
+	req, actorID, userID, ok := h.parseModerationRequest(msg)
+	if !ok {
+		return
+	}
+	conversationID := int(req.GetConversationId())
+
+	if err := h.conversationSvc.KickMember(actorID, conversationID, userID, req.GetReason(), time.Now()); err != nil {
+		h.respondGroupModerationError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	h.publishModeration(conversationID, "member_kicked", actorID, userID, nil)
+	h.respondProto(msg, &apiv1.GroupModerationResponse{Ok: true})
+}
+
+func (h *Handler) handleGroupBanList(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondGroupBanListError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.GroupBanListRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondGroupBanListError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondGroupBanListError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 {
+		h.respondGroupBanListError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+
+	bans, err := h.conversationSvc.ListBans(actorID, int(req.GetConversationId()))
+	if err != nil {
+		h.respondGroupBanListError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	data := make([]*apiv1.GroupBan, 0, len(bans))
+	for _, ban := range bans {
+		data = append(data, groupBanToProto(ban))
+	}
+	h.respondProto(msg, &apiv1.GroupBanListResponse{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+func (h *Handler) handleGroupAuditLog(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondGroupAuditLogError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.GroupAuditLogRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondGroupAuditLogError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondGroupAuditLogError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 {
+		h.respondGroupAuditLogError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+
+	entries, err := h.conversationSvc.ListAuditLog(actorID, int(req.GetConversationId()), int(req.GetBeforeId()), int(req.GetLimit()))
+	if err != nil {
+		h.respondGroupAuditLogError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	data := make([]*apiv1.AuditLogEntry, 0, len(entries))
+	for _, entry := range entries {
+		data = append(data, auditLogEntryToProto(entry))
+	}
+	h.respondProto(msg, &apiv1.GroupAuditLogResponse{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// parseModerationRequest décode et valide un GroupModerationRequest ; false si une erreur a déjà été répondue.
+func (h *Handler) parseModerationRequest(msg *nats.Msg) (*apiv1.GroupModerationRequest, uuid.UUID, uuid.UUID, bool) {
+	if h.conversationSvc == nil {
+		h.respondGroupModerationError(msg, errorCodeInternal, "conversation service unavailable")
+		return nil, uuid.Nil, uuid.Nil, false
+	}
+
+	var req apiv1.GroupModerationRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondGroupModerationError(msg, errorCodeBadRequest, "invalid request format")
+		return nil, uuid.Nil, uuid.Nil, false
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondGroupModerationError(msg, errorCodeBadRequest, err.Error())
+		return nil, uuid.Nil, uuid.Nil, false
+	}
+	userID, err := parseUUID("user_id", req.GetUserId())
+	if err != nil {
+		h.respondGroupModerationError(msg, errorCodeBadRequest, err.Error())
+		return nil, uuid.Nil, uuid.Nil, false
+	}
+	if req.GetConversationId() <= 0 {
+		h.respondGroupModerationError(msg, errorCodeBadRequest, "conversation_id required")
+		return nil, uuid.Nil, uuid.Nil, false
+	}
+	return &req, actorID, userID, true
+}
+
+// publishModeration diffuse l'action à la conversation et à la room personnelle de la cible
+// (retirée de la conversation en cas de bannissement ou d'exclusion). La raison reste dans le journal.
+func (h *Handler) publishModeration(conversationID int, action string, actorID, userID uuid.UUID, extra map[string]interface{}) {
+	event := map[string]interface{}{
+		"action":          action,
+		"conversation_id": conversationID,
+		"user_id":         userID.String(),
+		"actor_id":        actorID.String(),
+	}
+	for key, value := range extra {
+		event[key] = value
+	}
+	broadcast.Publish(h.publisher, broadcast.ConversationRoom(conversationID), event)
+	broadcast.Publish(h.publisher, broadcast.UserRoom(userID), event)
+}
+
+func groupBanToProto(ban *models.ConversationBan) *apiv1.GroupBan {
+	if ban == nil {
+		return nil
+	}
+	return &apiv1.GroupBan{
+		ConversationId: int32(ban.ConversationID),
+		UserId:         ban.UserID.String(),
+		BannedBy:       ban.BannedBy.String(),
+		Reason:         ban.Reason,
+		CreatedAt:      ban.CreatedAt.Unix(),
+	}
+}
+
+func groupMuteToProto(mute *models.ConversationMute) *apiv1.GroupMute {
+	if mute == nil {
+		return nil
+	}
+	return &apiv1.GroupMute{
+		ConversationId: int32(mute.ConversationID),
+		UserId:         mute.UserID.String(),
+		MutedBy:        mute.MutedBy.String(),
+		MutedUntil:     mute.MutedUntil.Unix(),
+		CreatedAt:      mute.CreatedAt.Unix(),
+	}
+}
+
+func auditLogEntryToProto(entry *models.AuditLogEntry) *apiv1.AuditLogEntry {
+	if entry == nil {
+		return nil
+	}
+	out := &apiv1.AuditLogEntry{
+		Id:             int32(entry.ID),
+		ConversationId: int32(entry.ConversationID),
+		ActorId:        entry.ActorID.String(),
+		Action:         string(entry.Action),
+		TargetId:       entry.TargetID.String(),
+		Reason:         entry.Reason,
+		CreatedAt:      entry.CreatedAt.Unix(),
+	}
+	if entry.ExpiresAt != nil {
+		out.ExpiresAt = entry.ExpiresAt.Unix()
+	}
+	return out
+}
+
+func (h *Handler) respondGroupModerationError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.GroupModerationResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondGroupBanListError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.GroupBanListResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondGroupAuditLogError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.GroupAuditLogResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
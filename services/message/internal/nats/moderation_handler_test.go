@@ -0,0 +1,98 @@
+package nats
+
+import (
+	"fmt"
+	"testing"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/service"
+)
+
+func TestHandlerMutedMemberCannotSend(t *testing.T) {
+	fix := newLot6Fixture(t)
+
+	dispatchNATSHandler(t, &apiv1.GroupModerationRequest{
+		ActorId:         lot6AdminID.String(),
+		ConversationId:  int32(fix.conversationID),
+		UserId:          lot6MemberID.String(),
+		DurationSeconds: 3600,
+	}, fix.handler.handleGroupMute)
+
+	dispatchNATSHandler(t, &apiv1.SendMessageRequest{
+		ConversationId: int32(fix.conversationID),
+		SenderId:       lot6MemberID.String(),
+		Content:        "en sourdine",
+	}, fix.handler.handleSendMessage)
+	if count := countConversationMessages(t, fix); count != 0 {
+		t.Fatalf("muted member should not post, got %d messages", count)
+	}
+
+	dispatchNATSHandler(t, &apiv1.SendMessageRequest{
+		ConversationId: int32(fix.conversationID),
+		SenderId:       lot6Member2ID.String(),
+		Content:        "bonjour",
+	}, fix.handler.handleSendMessage)
+	if count := countConversationMessages(t, fix); count != 1 {
+		t.Fatalf("other members are not muted, got %d messages", count)
+	}
+
+	dispatchNATSHandler(t, &apiv1.GroupModerationRequest{
+		ActorId:        lot6AdminID.String(),
+		ConversationId: int32(fix.conversationID),
+		UserId:         lot6MemberID.String(),
+	}, fix.handler.handleGroupUnmute)
+	dispatchNATSHandler(t, &apiv1.SendMessageRequest{
+		ConversationId: int32(fix.conversationID),
+		SenderId:       lot6MemberID.String(),
+		Content:        "de retour",
+	}, fix.handler.handleSendMessage)
+	if count := countConversationMessages(t, fix); count != 2 {
+		t.Fatalf("unmuted member should post, got %d messages", count)
+	}
+}
+
+func TestHandlerBanRemovesMemberAndAudits(t *testing.T) {
+	fix := newLot6Fixture(t)
+
+	// Un membre ne peut pas bannir.
+	dispatchNATSHandler(t, &apiv1.GroupModerationRequest{
+		ActorId:        lot6MemberID.String(),
+		ConversationId: int32(fix.conversationID),
+		UserId:         lot6Member2ID.String(),
+	}, fix.handler.handleGroupBan)
+	if ok, _ := fix.conversationSvc.IsMember(lot6Member2ID, fix.conversationID); !ok {
+		t.Fatal("member should not be able to ban")
+	}
+
+	dispatchNATSHandler(t, &apiv1.GroupModerationRequest{
+		ActorId:        lot6OwnerID.String(),
+		ConversationId: int32(fix.conversationID),
+		UserId:         lot6Member2ID.String(),
+		Reason:         "spam",
+	}, fix.handler.handleGroupBan)
+	if ok, _ := fix.conversationSvc.IsMember(lot6Member2ID, fix.conversationID); ok {
+		t.Fatal("banned member should have been removed")
+	}
+
+	entries, err := fix.conversationSvc.ListAuditLog(lot6AdminID, fix.conversationID, 0, 0)
+	if err != nil || len(entries) != 1 || entries[0].TargetID != lot6Member2ID || entries[0].Reason != "spam" {
+		t.Fatalf("ListAuditLog() = %+v, %v", entries, err)
+	}
+}
+
+func TestMapConversationErrorModeration(t *testing.T) {
+	cases := map[error]string{
+		repo.ErrUserBanned:                                errorCodeForbidden,
+		service.ErrAlreadyBanned:                          errorCodeConflict,
+		repo.ErrBanNotFound:                               errorCodeNotFound,
+		repo.ErrMuteNotFound:                              errorCodeNotFound,
+		service.ErrInvalidMuteDuration:                    errorCodeBadRequest,
+		fmt.Errorf("%w until tomorrow", service.ErrMuted): errorCodeForbidden,
+	}
+	for err, want := range cases {
+		if code := mapConversationError(err); code != want {
+			t.Fatalf("%v should map to %s, got %s", err, want, code)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package nats
+
+import (
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/broadcast"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/service"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func (h *Handler) handleGroupUpdatePermissions(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondGroupUpdatePermissionsError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.GroupUpdatePermissionsRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondGroupUpdatePermissionsError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondGroupUpdatePermissionsError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 {
+		h.respondGroupUpdatePermissionsError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+
+	permissions := req.GetPermissions()
+	var update service.ConversationPermissionsUpdate
+	for _, field := range req.GetUpdateMask() {
+		switch field {
+		case service.PermissionOnlyAdminsCanPost:
+			value := permissions.GetOnlyAdminsCanPost()
+			update.OnlyAdminsCanPost = &value
+		case service.PermissionMembersCanAddMembers:
+			value := permissions.GetMembersCanAddMembers()
+			update.MembersCanAddMembers = &value
+		case service.PermissionMembersCanPin:
+			value := permissions.GetMembersCanPin()
+			update.MembersCanPin = &value
+		case service.PermissionSlowModeSeconds:
+			value := int(permissions.GetSlowModeSeconds())
+			update.SlowModeSeconds = &value
+		case service.PermissionJoinRequestsEnabled:
+			value := permissions.GetJoinRequestsEnabled()
+			update.JoinRequestsEnabled = &value
+		default:
+			h.respondGroupUpdatePermissionsError(msg, errorCodeBadRequest, "unknown field in update_mask: "+field)
+			return
+		}
+	}
+
+	conversation, changed, err := h.conversationSvc.UpdatePermissions(actorID, int(req.GetConversationId()), update)
+	if err != nil {
+		h.respondGroupUpdatePermissionsError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	if len(changed) > 0 {
+		broadcast.Publish(h.publisher, broadcast.ConversationRoom(conversation.ID), map[string]interface{}{
+			"action":          "permissions_updated",
+			"conversation_id": conversation.ID,
+			"permissions":     conversation.Permissions,
+			"changed":         changed,
+			"updated_by":      actorID.String(),
+			"updated_at":      conversation.UpdatedAt.Unix(),
+		})
+	}
+
+	h.respondProto(msg, &apiv1.GroupUpdatePermissionsResponse{
+		Ok:   true,
+		Data: conversationToProto(conversation, actorID),
+	})
+}
+
+func (h *Handler) respondGroupUpdatePermissionsError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.GroupUpdatePermissionsResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
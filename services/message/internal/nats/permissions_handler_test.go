@@ -0,0 +1,102 @@
+package nats
+
+import (
+	"testing"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/service"
+)
+
+func countConversationMessages(t *testing.T, fix *lot6Fixture) int {
+	t.Helper()
+	messages, err := fix.messageSvc.GetMessagesByConversationID(fix.conversationID)
+	if err != nil {
+		t.Fatalf("GetMessagesByConversationID() error = %v", err)
+	}
+	return len(messages)
+}
+
+func TestHandlerGroupUpdatePermissionsEnforcedOnSend(t *testing.T) {
+	fix := newLot6Fixture(t)
+
+	// Seul l'owner modifie les permissions.
+	dispatchNATSHandler(t, &apiv1.GroupUpdatePermissionsRequest{
+		ActorId:        lot6AdminID.String(),
+		ConversationId: int32(fix.conversationID),
+		Permissions:    &apiv1.GroupPermissions{OnlyAdminsCanPost: true},
+		UpdateMask:     []string{"only_admins_can_post"},
+	}, fix.handler.handleGroupUpdatePermissions)
+	conversation, err := fix.conversationSvc.GetConversationByID(fix.conversationID)
+	if err != nil {
+		t.Fatalf("GetConversationByID() error = %v", err)
+	}
+	if conversation.Permissions.OnlyAdminsCanPost {
+		t.Fatal("admin should not be able to update permissions")
+	}
+
+	dispatchNATSHandler(t, &apiv1.GroupUpdatePermissionsRequest{
+		ActorId:        lot6OwnerID.String(),
+		ConversationId: int32(fix.conversationID),
+		Permissions:    &apiv1.GroupPermissions{OnlyAdminsCanPost: true},
+		UpdateMask:     []string{"only_admins_can_post"},
+	}, fix.handler.handleGroupUpdatePermissions)
+
+	dispatchNATSHandler(t, &apiv1.SendMessageRequest{
+		ConversationId: int32(fix.conversationID),
+		SenderId:       lot6MemberID.String(),
+		Content:        "bloqué",
+	}, fix.handler.handleSendMessage)
+	if count := countConversationMessages(t, fix); count != 0 {
+		t.Fatalf("member should not post in announcement mode, got %d messages", count)
+	}
+
+	dispatchNATSHandler(t, &apiv1.SendMessageRequest{
+		ConversationId: int32(fix.conversationID),
+		SenderId:       lot6AdminID.String(),
+		Content:        "annonce",
+	}, fix.handler.handleSendMessage)
+	if count := countConversationMessages(t, fix); count != 1 {
+		t.Fatalf("admin should post in announcement mode, got %d messages", count)
+	}
+}
+
+func TestHandlerSendMessageSlowMode(t *testing.T) {
+	fix := newLot6Fixture(t)
+
+	dispatchNATSHandler(t, &apiv1.GroupUpdatePermissionsRequest{
+		ActorId:        lot6OwnerID.String(),
+		ConversationId: int32(fix.conversationID),
+		Permissions:    &apiv1.GroupPermissions{SlowModeSeconds: 60},
+		UpdateMask:     []string{"slow_mode_seconds"},
+	}, fix.handler.handleGroupUpdatePermissions)
+
+	for _, content := range []string{"premier", "trop tôt"} {
+		dispatchNATSHandler(t, &apiv1.SendMessageRequest{
+			ConversationId: int32(fix.conversationID),
+			SenderId:       lot6MemberID.String(),
+			Content:        content,
+		}, fix.handler.handleSendMessage)
+	}
+	if count := countConversationMessages(t, fix); count != 1 {
+		t.Fatalf("second message within slow mode should be rejected, got %d messages", count)
+	}
+
+	// Un autre membre n'est pas concerné par le délai du premier.
+	dispatchNATSHandler(t, &apiv1.SendMessageRequest{
+		ConversationId: int32(fix.conversationID),
+		SenderId:       lot6Member2ID.String(),
+		Content:        "bonjour",
+	}, fix.handler.handleSendMessage)
+	if count := countConversationMessages(t, fix); count != 2 {
+		t.Fatalf("slow mode is per member, got %d messages", count)
+	}
+}
+
+func TestMapConversationErrorSlowMode(t *testing.T) {
+	if code := mapConversationError(service.ErrSlowMode); code != errorCodeRateLimited {
+		t.Fatalf("ErrSlowMode should map to %s, got %s", errorCodeRateLimited, code)
+	}
+	if code := mapConversationError(service.ErrPostingRestricted); code != errorCodeForbidden {
+		t.Fatalf("ErrPostingRestricted should map to %s, got %s", errorCodeForbidden, code)
+	}
+}
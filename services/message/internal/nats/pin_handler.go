@@ -0,0 +1,196 @@
+package nats
+
+import (
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/broadcast"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func (h *Handler) handlePinMessage(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondPinMessageError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.PinMessageRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondPinMessageError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondPinMessageError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	conversationID := int(req.GetConversationId())
+	if conversationID <= 0 {
+		h.respondPinMessageError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+	if req.GetMessageId() <= 0 {
+		h.respondPinMessageError(msg, errorCodeBadRequest, "message_id required")
+		return
+	}
+	messageID := int(req.GetMessageId())
+
+	// On n'épingle qu'un message appartenant à la conversation ciblée.
+	message, err := h.svc.GetMessageById(messageID)
+	if err != nil || message.ConversationID != conversationID {
+		h.respondPinMessageError(msg, errorCodeNotFound, "message not found")
+		return
+	}
+
+	pin, err := h.conversationSvc.PinMessage(actorID, conversationID, messageID)
+	if err != nil {
+		code := mapConversationError(err)
+		h.respondPinMessageError(msg, code, err.Error())
+		return
+	}
+
+	broadcast.Publish(h.publisher, broadcast.ConversationRoom(conversationID), map[string]interface{}{
+		"action":          "message_pinned",
+		"conversation_id": conversationID,
+		"message_id":      messageID,
+		"pinned_by":       pin.PinnedBy.String(),
+		"pinned_at":       pin.PinnedAt.Unix(),
+	})
+
+	data := pinToProto(pin)
+	data.Message = chatMessageToProto(message)
+	h.respondProto(msg, &apiv1.PinMessageResponse{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+func (h *Handler) handleUnpinMessage(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondUnpinMessageError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.UnpinMessageRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondUnpinMessageError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondUnpinMessageError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	conversationID := int(req.GetConversationId())
+	if conversationID <= 0 {
+		h.respondUnpinMessageError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+	if req.GetMessageId() <= 0 {
+		h.respondUnpinMessageError(msg, errorCodeBadRequest, "message_id required")
+		return
+	}
+	messageID := int(req.GetMessageId())
+
+	if err := h.conversationSvc.UnpinMessage(actorID, conversationID, messageID); err != nil {
+		code := mapConversationError(err)
+		h.respondUnpinMessageError(msg, code, err.Error())
+		return
+	}
+
+	broadcast.Publish(h.publisher, broadcast.ConversationRoom(conversationID), map[string]interface{}{
+		"action":          "message_unpinned",
+		"conversation_id": conversationID,
+		"message_id":      messageID,
+		"unpinned_by":     actorID.String(),
+	})
+
+	h.respondProto(msg, &apiv1.UnpinMessageResponse{Ok: true})
+}
+
+func (h *Handler) handleListPins(msg *nats.Msg) {
+	if h.conversationSvc == nil {
+		h.respondListPinsError(msg, errorCodeInternal, "conversation service unavailable")
+		return
+	}
+
+	var req apiv1.ListPinsRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondListPinsError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondListPinsError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	conversationID := int(req.GetConversationId())
+	if conversationID <= 0 {
+		h.respondListPinsError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+
+	pins, err := h.conversationSvc.ListPins(actorID, conversationID)
+	if err != nil {
+		code := mapConversationError(err)
+		h.respondListPinsError(msg, code, err.Error())
+		return
+	}
+
+	data := make([]*apiv1.MessagePin, 0, len(pins))
+	for _, pin := range pins {
+		item := pinToProto(pin)
+		// Un message supprimé entre-temps reste listé sans contenu plutôt que de faire échouer la liste.
+		if message, err := h.svc.GetMessageById(pin.MessageID); err == nil {
+			item.Message = chatMessageToProto(message)
+		}
+		data = append(data, item)
+	}
+
+	h.respondProto(msg, &apiv1.ListPinsResponse{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+func pinToProto(pin *models.ConversationPin) *apiv1.MessagePin {
+	if pin == nil {
+		return nil
+	}
+	return &apiv1.MessagePin{
+		ConversationId: int32(pin.ConversationID),
+		MessageId:      int32(pin.MessageID),
+		PinnedBy:       pin.PinnedBy.String(),
+		PinnedAt:       pin.PinnedAt.Unix(),
+	}
+}
+
+func (h *Handler) respondPinMessageError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.PinMessageResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondUnpinMessageError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.UnpinMessageResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondListPinsError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.ListPinsResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
@@ -0,0 +1,309 @@
+package nats
+
+import (
+	"log"
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/broadcast"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func (h *Handler) handlePollCreate(msg *nats.Msg) {
+	var req apiv1.PollCreateRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondPollCreateError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondPollCreateError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 {
+		h.respondPollCreateError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+	conversationID := int(req.GetConversationId())
+	if err := h.authorizePost(actorID, conversationID, h.svc); err != nil {
+		code := mapConversationError(err)
+		h.respondPollCreateError(msg, code, err.Error())
+		return
+	}
+
+	now := time.Now().UTC()
+	poll := &models.Poll{
+		ConversationID: conversationID,
+		CreatedBy:      actorID,
+		Question:       req.GetQuestion(),
+		MultiChoice:    req.GetMultiChoice(),
+		Anonymous:      req.GetAnonymous(),
+	}
+	for _, label := range req.GetOptions() {
+		poll.Options = append(poll.Options, models.PollOption{Label: label})
+	}
+	if req.GetClosesAt() > 0 {
+		closesAt := time.Unix(req.GetClosesAt(), 0).UTC()
+		poll.ClosesAt = &closesAt
+	}
+	if err := h.svc.PreparePoll(poll, now); err != nil {
+		code := mapMessageError(err)
+		h.respondPollCreateError(msg, code, err.Error())
+		return
+	}
+
+	// Le sondage s'affiche dans la timeline via un message dont le contenu est la question.
+	chatMsg, err := h.batchWriter.Submit(&models.ChatMessage{
+		SenderID:       actorID,
+		ConversationID: conversationID,
+		Content:        poll.Question,
+		Status:         "sent",
+	})
+	if err != nil {
+		code := mapMessageError(err)
+		h.respondPollCreateError(msg, code, err.Error())
+		return
+	}
+	poll.MessageID = chatMsg.ID
+
+	created, err := h.svc.CreatePoll(poll)
+	if err != nil {
+		if delErr := h.svc.DeleteMessageById(chatMsg.ID); delErr != nil {
+			log.Printf("poll create: rollback message %d: %v", chatMsg.ID, delErr)
+		}
+		code := mapMessageError(err)
+		h.respondPollCreateError(msg, code, err.Error())
+		return
+	}
+	chatMsg.PollID = &created.ID
+
+	payload := broadcast.MessagePayload(chatMsg)
+	payload["poll"] = created
+	broadcast.Publish(h.publisher, broadcast.ConversationRoom(conversationID), payload)
+
+	h.respondProto(msg, &apiv1.PollCreateResponse{
+		Ok:   true,
+		Data: pollToProto(created, now),
+	})
+}
+
+func (h *Handler) handlePollVote(msg *nats.Msg) {
+	var req apiv1.PollVoteRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondPollVoteError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondPollVoteError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetPollId() <= 0 {
+		h.respondPollVoteError(msg, errorCodeBadRequest, "poll_id required")
+		return
+	}
+	pollID := int(req.GetPollId())
+
+	if _, code, err := h.authorizePollMember(actorID, pollID); err != nil {
+		h.respondPollVoteError(msg, code, err.Error())
+		return
+	}
+
+	optionIDs := make([]int, 0, len(req.GetOptionIds()))
+	for _, optionID := range req.GetOptionIds() {
+		optionIDs = append(optionIDs, int(optionID))
+	}
+	now := time.Now().UTC()
+	poll, err := h.svc.VotePoll(pollID, actorID, optionIDs, now)
+	if err != nil {
+		code := mapMessageError(err)
+		h.respondPollVoteError(msg, code, err.Error())
+		return
+	}
+
+	h.broadcastPollUpdate(poll, now)
+	h.respondProto(msg, &apiv1.PollVoteResponse{
+		Ok:   true,
+		Data: pollToProto(poll, now),
+	})
+}
+
+func (h *Handler) handlePollClose(msg *nats.Msg) {
+	var req apiv1.PollCloseRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondPollCloseError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondPollCloseError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetPollId() <= 0 {
+		h.respondPollCloseError(msg, errorCodeBadRequest, "poll_id required")
+		return
+	}
+	pollID := int(req.GetPollId())
+
+	existing, code, err := h.authorizePollMember(actorID, pollID)
+	if err != nil {
+		h.respondPollCloseError(msg, code, err.Error())
+		return
+	}
+	isManager, err := h.conversationSvc.IsManager(actorID, existing.ConversationID)
+	if err != nil {
+		code := mapConversationError(err)
+		h.respondPollCloseError(msg, code, err.Error())
+		return
+	}
+
+	now := time.Now().UTC()
+	poll, err := h.svc.ClosePoll(pollID, actorID, isManager, now)
+	if err != nil {
+		code := mapMessageError(err)
+		h.respondPollCloseError(msg, code, err.Error())
+		return
+	}
+
+	h.broadcastPollUpdate(poll, now)
+	h.respondProto(msg, &apiv1.PollCloseResponse{
+		Ok:   true,
+		Data: pollToProto(poll, now),
+	})
+}
+
+func (h *Handler) handlePollGet(msg *nats.Msg) {
+	var req apiv1.PollGetRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondPollGetError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondPollGetError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetPollId() <= 0 {
+		h.respondPollGetError(msg, errorCodeBadRequest, "poll_id required")
+		return
+	}
+
+	poll, code, err := h.authorizePollMember(actorID, int(req.GetPollId()))
+	if err != nil {
+		h.respondPollGetError(msg, code, err.Error())
+		return
+	}
+
+	h.respondProto(msg, &apiv1.PollGetResponse{
+		Ok:   true,
+		Data: pollToProto(poll, time.Now().UTC()),
+	})
+}
+
+// authorizePollMember charge le sondage (vu par actorID) et vérifie l'appartenance à sa conversation.
+func (h *Handler) authorizePollMember(actorID uuid.UUID, pollID int) (*models.Poll, string, error) {
+	poll, err := h.svc.GetPoll(pollID, actorID)
+	if err != nil {
+		return nil, mapMessageError(err), err
+	}
+	if err := h.authorizeConversationMember(actorID, poll.ConversationID); err != nil {
+		return nil, mapConversationError(err), err
+	}
+	return poll, "", nil
+}
+
+// broadcastPollUpdate diffuse le décompte à la room (sans les votes propres à l'acteur).
+func (h *Handler) broadcastPollUpdate(poll *models.Poll, now time.Time) {
+	public := *poll
+	public.MyOptionIDs = nil
+	broadcast.Publish(h.publisher, broadcast.ConversationRoom(poll.ConversationID), map[string]interface{}{
+		"action":          "poll_update",
+		"poll_id":         poll.ID,
+		"message_id":      poll.MessageID,
+		"conversation_id": poll.ConversationID,
+		"closed":          poll.IsClosed(now),
+		"poll":            &public,
+	})
+}
+
+func pollToProto(poll *models.Poll, now time.Time) *apiv1.Poll {
+	if poll == nil {
+		return nil
+	}
+	out := &apiv1.Poll{
+		Id:             int32(poll.ID),
+		MessageId:      int32(poll.MessageID),
+		ConversationId: int32(poll.ConversationID),
+		CreatedBy:      poll.CreatedBy.String(),
+		Question:       poll.Question,
+		MultiChoice:    poll.MultiChoice,
+		Anonymous:      poll.Anonymous,
+		Closed:         poll.IsClosed(now),
+		CreatedAt:      poll.CreatedAt.Unix(),
+		TotalVoters:    int32(poll.TotalVoters),
+	}
+	if poll.ClosesAt != nil {
+		out.ClosesAt = poll.ClosesAt.Unix()
+	}
+	if poll.ClosedAt != nil {
+		out.ClosedAt = poll.ClosedAt.Unix()
+	}
+	for _, option := range poll.Options {
+		item := &apiv1.PollOption{
+			Id:        int32(option.ID),
+			Label:     option.Label,
+			VoteCount: int32(option.VoteCount),
+		}
+		for _, voterID := range option.VoterIDs {
+			item.VoterIds = append(item.VoterIds, voterID.String())
+		}
+		out.Options = append(out.Options, item)
+	}
+	for _, optionID := range poll.MyOptionIDs {
+		out.MyOptionIds = append(out.MyOptionIds, int32(optionID))
+	}
+	return out
+}
+
+func (h *Handler) respondPollCreateError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.PollCreateResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondPollVoteError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.PollVoteResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondPollCloseError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.PollCloseResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondPollGetError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.PollGetResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
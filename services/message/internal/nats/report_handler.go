@@ -0,0 +1,243 @@
+package nats
+
+import (
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/service"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+// reportNotificationType : type des notifications de signalement envoyées aux admins/owners.
+const reportNotificationType = "message_report"
+
+func (h *Handler) handleMessageReport(msg *nats.Msg) {
+	var req apiv1.MessageReportRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondMessageReportError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondMessageReportError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetMessageId() <= 0 {
+		h.respondMessageReportError(msg, errorCodeBadRequest, "message_id required")
+		return
+	}
+
+	reported, err := h.svc.GetMessageById(int(req.GetMessageId()))
+	if err != nil {
+		h.respondMessageReportError(msg, mapMessageError(err), err.Error())
+		return
+	}
+	if reported == nil {
+		h.respondMessageReportError(msg, errorCodeNotFound, "message not found")
+		return
+	}
+	if err := h.authorizeConversationMember(actorID, reported.ConversationID); err != nil {
+		h.respondMessageReportError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	report, err := h.svc.ReportMessage(actorID, reported, req.GetReason())
+	if err != nil {
+		h.respondMessageReportError(msg, mapMessageError(err), err.Error())
+		return
+	}
+
+	h.notifyMessageReport(report)
+
+	h.respondProto(msg, &apiv1.MessageReportResponse{
+		Ok:   true,
+		Data: messageReportToProto(report),
+	})
+}
+
+func (h *Handler) handleMessageReportList(msg *nats.Msg) {
+	var req apiv1.MessageReportListRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondMessageReportListError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondMessageReportListError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 {
+		h.respondMessageReportListError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+	conversationID := int(req.GetConversationId())
+	if err := h.authorizeConversationManager(actorID, conversationID); err != nil {
+		h.respondMessageReportListError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	reports, err := h.svc.ListReports(conversationID, models.MessageReportStatus(req.GetStatus()))
+	if err != nil {
+		h.respondMessageReportListError(msg, mapMessageError(err), err.Error())
+		return
+	}
+
+	data := make([]*apiv1.MessageReport, 0, len(reports))
+	for _, report := range reports {
+		data = append(data, messageReportToProto(report))
+	}
+	h.respondProto(msg, &apiv1.MessageReportListResponse{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+func (h *Handler) handleMessageReportResolve(msg *nats.Msg) {
+	var req apiv1.MessageReportResolveRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondMessageReportResolveError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondMessageReportResolveError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if req.GetConversationId() <= 0 || req.GetReportId() <= 0 {
+		h.respondMessageReportResolveError(msg, errorCodeBadRequest, "conversation_id and report_id required")
+		return
+	}
+	conversationID := int(req.GetConversationId())
+	if err := h.authorizeConversationManager(actorID, conversationID); err != nil {
+		h.respondMessageReportResolveError(msg, mapConversationError(err), err.Error())
+		return
+	}
+
+	report, err := h.svc.ResolveReport(actorID, conversationID, int(req.GetReportId()), models.MessageReportStatus(req.GetStatus()), time.Now())
+	if err != nil {
+		h.respondMessageReportResolveError(msg, mapMessageError(err), err.Error())
+		return
+	}
+
+	h.respondProto(msg, &apiv1.MessageReportResolveResponse{
+		Ok:   true,
+		Data: messageReportToProto(report),
+	})
+}
+
+// authorizeConversationManager : la file de modération est réservée aux admins/owners.
+func (h *Handler) authorizeConversationManager(userID uuid.UUID, conversationID int) error {
+	if err := h.authorizeConversationMember(userID, conversationID); err != nil {
+		return err
+	}
+	isManager, err := h.conversationSvc.IsManager(userID, conversationID)
+	if err != nil {
+		return err
+	}
+	if !isManager {
+		return service.ErrForbidden
+	}
+	return nil
+}
+
+// notifyMessageReport prévient chaque admin/owner de la conversation (best effort).
+// L'auteur du signalement n'est pas communiqué aux autres membres.
+func (h *Handler) notifyMessageReport(report *models.MessageReport) {
+	if h.publisher == nil || h.conversationSvc == nil {
+		return
+	}
+	managers, err := h.conversationSvc.ListManagerIDs(report.ConversationID)
+	if err != nil {
+		log.Printf("[reports] list managers (conversation %d): %v", report.ConversationID, err)
+		return
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"conversationId": strconv.Itoa(report.ConversationID),
+		"messageId":      strconv.Itoa(report.MessageID),
+		"reportId":       strconv.Itoa(report.ID),
+		"reason":         report.Reason,
+	})
+	if err != nil {
+		log.Printf("[reports] marshal payload: %v", err)
+		return
+	}
+
+	for _, managerID := range managers {
+		if managerID == report.ReporterID {
+			continue
+		}
+		data, err := json.Marshal(map[string]string{
+			"userId":  managerID.String(),
+			"type":    reportNotificationType,
+			"payload": string(payload),
+		})
+		if err != nil {
+			continue
+		}
+		if err := h.publisher.Publish(subjectNotificationSend, data); err != nil {
+			log.Printf("[reports] notification.send %s: %v", managerID, err)
+		}
+	}
+}
+
+func messageReportToProto(report *models.MessageReport) *apiv1.MessageReport {
+	if report == nil {
+		return nil
+	}
+	out := &apiv1.MessageReport{
+		Id:             int32(report.ID),
+		MessageId:      int32(report.MessageID),
+		ConversationId: int32(report.ConversationID),
+		ReporterId:     report.ReporterID.String(),
+		Reason:         report.Reason,
+		Status:         string(report.Status),
+		CreatedAt:      report.CreatedAt.Unix(),
+	}
+	if report.ResolvedBy != uuid.Nil {
+		out.ResolvedBy = report.ResolvedBy.String()
+	}
+	if report.ResolvedAt != nil {
+		out.ResolvedAt = report.ResolvedAt.Unix()
+	}
+	return out
+}
+
+func (h *Handler) respondMessageReportError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.MessageReportResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondMessageReportListError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.MessageReportListResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondMessageReportResolveError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.MessageReportResolveResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
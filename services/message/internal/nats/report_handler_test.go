@@ -0,0 +1,76 @@
+package nats
+
+import (
+	"testing"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/models"
+)
+
+func TestHandlerMessageReportWorkflow(t *testing.T) {
+	fix := newLot6Fixture(t)
+	publisher := &recordingPublisher{}
+	fix.handler.publisher = publisher
+
+	reported, err := fix.messageSvc.SendMessage(&models.ChatMessage{
+		SenderID:       lot6Member2ID,
+		ConversationID: fix.conversationID,
+		Content:        "spam",
+	})
+	if err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	// Un non-membre ne signale pas.
+	dispatchNATSHandler(t, &apiv1.MessageReportRequest{
+		ActorId:   lot6ExternalID.String(),
+		MessageId: int32(reported.ID),
+		Reason:    "spam",
+	}, fix.handler.handleMessageReport)
+	if reports, _ := fix.messageSvc.ListReports(fix.conversationID, ""); len(reports) != 0 {
+		t.Fatalf("non-member report should be rejected, got %+v", reports)
+	}
+
+	dispatchNATSHandler(t, &apiv1.MessageReportRequest{
+		ActorId:   lot6MemberID.String(),
+		MessageId: int32(reported.ID),
+		Reason:    "spam",
+	}, fix.handler.handleMessageReport)
+	reports, err := fix.messageSvc.ListReports(fix.conversationID, "")
+	if err != nil || len(reports) != 1 {
+		t.Fatalf("expected one pending report, got %+v (err = %v)", reports, err)
+	}
+
+	notified := map[string]bool{}
+	for _, p := range publisher.published {
+		if p.subject != subjectNotificationSend || p.data["type"] != reportNotificationType {
+			t.Fatalf("unexpected publish %s %v", p.subject, p.data)
+		}
+		notified[p.data["userId"].(string)] = true
+	}
+	if len(notified) != 2 || !notified[lot6OwnerID.String()] || !notified[lot6AdminID.String()] {
+		t.Fatalf("expected owner and admin to be notified, got %v", notified)
+	}
+
+	// Un membre simple ne traite pas la file de modération.
+	dispatchNATSHandler(t, &apiv1.MessageReportResolveRequest{
+		ActorId:        lot6MemberID.String(),
+		ConversationId: int32(fix.conversationID),
+		ReportId:       int32(reports[0].ID),
+		Status:         string(models.MessageReportResolved),
+	}, fix.handler.handleMessageReportResolve)
+	if pending, _ := fix.messageSvc.ListReports(fix.conversationID, ""); len(pending) != 1 {
+		t.Fatalf("member should not resolve reports, got %+v", pending)
+	}
+
+	dispatchNATSHandler(t, &apiv1.MessageReportResolveRequest{
+		ActorId:        lot6AdminID.String(),
+		ConversationId: int32(fix.conversationID),
+		ReportId:       int32(reports[0].ID),
+		Status:         string(models.MessageReportResolved),
+	}, fix.handler.handleMessageReportResolve)
+	resolved, _ := fix.messageSvc.ListReports(fix.conversationID, models.MessageReportResolved)
+	if len(resolved) != 1 || resolved[0].ResolvedBy != lot6AdminID {
+		t.Fatalf("expected report resolved by admin, got %+v", resolved)
+	}
+}
@@ -0,0 +1,184 @@
+package nats
+
+import (
+	"strings"
+	"time"
+
+	apiv1 "github.com/Mathis-brgs/storm-project/services/message/api/v1"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/protobuf/proto"
+)
+
+func (h *Handler) handleScheduleMessage(msg *nats.Msg) {
+	var req apiv1.ScheduleMessageRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondScheduleMessageError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+	if req.GetConversationId() <= 0 {
+		h.respondScheduleMessageError(msg, errorCodeBadRequest, "conversation_id required")
+		return
+	}
+	conversationID := int(req.GetConversationId())
+	senderID, err := parseUUID("sender_id", req.GetSenderId())
+	if err != nil {
+		h.respondScheduleMessageError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+	if strings.TrimSpace(req.GetContent()) == "" {
+		h.respondScheduleMessageError(msg, errorCodeBadRequest, "content required")
+		return
+	}
+	if req.GetSendAt() <= 0 {
+		h.respondScheduleMessageError(msg, errorCodeBadRequest, "send_at required")
+		return
+	}
+	if err := h.authorizePost(senderID, conversationID, nil); err != nil {
+		code := mapConversationError(err)
+		h.respondScheduleMessageError(msg, code, err.Error())
+		return
+	}
+	// Vérifiée dès la programmation, puis de nouveau à l'échéance par le scheduler.
+	if _, err := h.attachments.Authorize(senderID, conversationID, req.GetAttachment()); err != nil {
+		h.respondScheduleMessageError(msg, mapMessageError(err), err.Error())
+		return
+	}
+
+	scheduled := &models.ScheduledMessage{
+		SenderID:       senderID,
+		ConversationID: conversationID,
+		Content:        req.GetContent(),
+		Attachment:     req.GetAttachment(),
+		SendAt:         time.Unix(req.GetSendAt(), 0).UTC(),
+	}
+	if req.GetReplyToId() > 0 {
+		replyID := int(req.GetReplyToId())
+		scheduled.ReplyToID = &replyID
+	}
+
+	result, err := h.svc.ScheduleMessage(scheduled)
+	if err != nil {
+		code := mapMessageError(err)
+		h.respondScheduleMessageError(msg, code, err.Error())
+		return
+	}
+
+	h.respondProto(msg, &apiv1.ScheduleMessageResponse{
+		Ok:   true,
+		Data: scheduledMessageToProto(result),
+	})
+}
+
+func (h *Handler) handleListScheduledMessages(msg *nats.Msg) {
+	var req apiv1.ListScheduledMessagesRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondListScheduledMessagesError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondListScheduledMessagesError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.svc.ListScheduledMessages(actorID, int(req.GetConversationId()))
+	if err != nil {
+		code := mapMessageError(err)
+		h.respondListScheduledMessagesError(msg, code, err.Error())
+		return
+	}
+
+	data := make([]*apiv1.ScheduledMessage, 0, len(result))
+	for _, scheduled := range result {
+		data = append(data, scheduledMessageToProto(scheduled))
+	}
+	h.respondProto(msg, &apiv1.ListScheduledMessagesResponse{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+func (h *Handler) handleCancelScheduledMessage(msg *nats.Msg) {
+	var req apiv1.CancelScheduledMessageRequest
+	if err := proto.Unmarshal(msg.Data, &req); err != nil {
+		h.respondCancelScheduledMessageError(msg, errorCodeBadRequest, "invalid request format")
+		return
+	}
+	if req.GetId() == 0 {
+		h.respondCancelScheduledMessageError(msg, errorCodeBadRequest, "id required")
+		return
+	}
+	actorID, err := parseUUID("actor_id", req.GetActorId())
+	if err != nil {
+		h.respondCancelScheduledMessageError(msg, errorCodeBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.svc.CancelScheduledMessage(actorID, int(req.GetId()))
+	if err != nil {
+		code := mapMessageError(err)
+		h.respondCancelScheduledMessageError(msg, code, err.Error())
+		return
+	}
+
+	h.respondProto(msg, &apiv1.CancelScheduledMessageResponse{
+		Ok:   true,
+		Data: scheduledMessageToProto(result),
+	})
+}
+
+func scheduledMessageToProto(m *models.ScheduledMessage) *apiv1.ScheduledMessage {
+	if m == nil {
+		return nil
+	}
+	out := &apiv1.ScheduledMessage{
+		Id:             int32(m.ID),
+		SenderId:       m.SenderID.String(),
+		ConversationId: int32(m.ConversationID),
+		Content:        m.Content,
+		Attachment:     m.Attachment,
+		SendAt:         m.SendAt.Unix(),
+		Status:         m.Status,
+		LastError:      m.LastError,
+		CreatedAt:      m.CreatedAt.Unix(),
+		UpdatedAt:      m.UpdatedAt.Unix(),
+	}
+	if m.ReplyToID != nil {
+		out.ReplyToId = int32(*m.ReplyToID)
+	}
+	if m.MessageID != nil {
+		out.MessageId = int32(*m.MessageID)
+	}
+	return out
+}
+
+func (h *Handler) respondScheduleMessageError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.ScheduleMessageResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondListScheduledMessagesError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.ListScheduledMessagesResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
+
+func (h *Handler) respondCancelScheduledMessageError(msg *nats.Msg, code, text string) {
+	h.respondProto(msg, &apiv1.CancelScheduledMessageResponse{
+		Ok: false,
+		Error: &apiv1.Error{
+			Code:    code,
+			Message: text,
+		},
+	})
+}
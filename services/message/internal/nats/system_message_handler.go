@@ -0,0 +1,12 @@
+package nats
+
+import (
+	"github.com/Mathis-brgs/storm-project/services/message/internal/broadcast"
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+)
+
+// broadcastSystemMessage diffuse un message système dans la room de la conversation,
+// sous la même forme qu'un message utilisateur (action "message") avec kind et system.
+func (h *Handler) broadcastSystemMessage(msg *models.ChatMessage) {
+	broadcast.Publish(h.publisher, broadcast.ConversationRoom(msg.ConversationID), broadcast.MessagePayload(msg))
+}
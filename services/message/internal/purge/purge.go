@@ -0,0 +1,131 @@
+package purge
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/service"
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	subjectMediaPurge = "media.purge.requested"
+
+	defaultInterval    = time.Hour
+	defaultBatchSize   = 50
+	mediaDeleteTimeout = 5 * time.Second
+)
+
+// Requester est le sous-ensemble de *nats.Conn utilisé pour demander la suppression des médias.
+type Requester interface {
+	Request(subject string, data []byte, timeout time.Duration) (*nats.Msg, error)
+}
+
+// Purger supprime définitivement les conversations supprimées depuis plus que le délai de grâce :
+// messages (et données associées), puis conversation, puis médias des pièces jointes.
+// Chaque étape est idempotente : une purge interrompue est reprise au passage suivant.
+type Purger struct {
+	conversationSvc *service.ConversationService
+	messageSvc      *service.MessageService
+	media           Requester
+
+	interval  time.Duration
+	batchSize int
+}
+
+// media peut être nil : les fichiers des pièces jointes ne sont alors pas supprimés.
+func New(conversationSvc *service.ConversationService, messageSvc *service.MessageService, media Requester) *Purger {
+	return &Purger{
+		conversationSvc: conversationSvc,
+		messageSvc:      messageSvc,
+		media:           media,
+		interval:        defaultInterval,
+		batchSize:       defaultBatchSize,
+	}
+}
+
+// Run purge toutes les heures jusqu'à l'annulation du contexte.
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := p.RunOnce(time.Now()); err != nil {
+				log.Printf("[purge] run: %v", err)
+			}
+		}
+	}
+}
+
+// RunOnce purge les conversations purgeables à now ; retourne le nombre de conversations purgées.
+func (p *Purger) RunOnce(now time.Time) (int, error) {
+	ids, err := p.conversationSvc.ListPurgeableConversations(now, p.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, id := range ids {
+		if err := p.purge(id, now); err != nil {
+			log.Printf("[purge] conversation %d failed: %v", id, err)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+func (p *Purger) purge(conversationID int, now time.Time) error {
+	attachments, err := p.messageSvc.PurgeConversationMessages(conversationID)
+	if err != nil {
+		return err
+	}
+	if err := p.conversationSvc.PurgeConversation(conversationID, now); err != nil {
+		return err
+	}
+
+	// Best effort : un média orphelin ne bloque pas la purge.
+	for _, attachment := range attachments {
+		p.deleteMedia(conversationID, attachment)
+	}
+	return nil
+}
+
+// deleteMedia demande la libération du média ; le media-service refuse un média rattaché à une autre
+// conversation que celle purgée.
+func (p *Purger) deleteMedia(conversationID int, attachment string) {
+	if p.media == nil {
+		return
+	}
+	key := mediaKey(attachment)
+	if key == "" {
+		return
+	}
+
+	payload, _ := json.Marshal(map[string]interface{}{"mediaId": key, "conversationId": conversationID})
+	reply, err := p.media.Request(subjectMediaPurge, payload, mediaDeleteTimeout)
+	if err != nil {
+		log.Printf("[purge] media delete %s: %v", key, err)
+		return
+	}
+
+	var resp struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(reply.Data, &resp); err == nil && resp.Error != "" {
+		log.Printf("[purge] media delete %s: %s", key, resp.Error)
+	}
+}
+
+// mediaKey extrait la clé objet (media/<nanos>_<nom>) d'une URL endpoint/bucket/key.
+// Une pièce jointe qui n'est pas un média du service (lien externe) est ignorée.
+func mediaKey(attachment string) string {
+	return models.AttachmentMediaID(attachment)
+}
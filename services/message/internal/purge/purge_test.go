@@ -0,0 +1,97 @@
+package purge
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/service"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+)
+
+var purgeOwnerID = uuid.MustParse("e1000001-0000-0000-0000-000000000001")
+
+type recordingRequester struct {
+	subjects        []string
+	mediaIDs        []string
+	conversationIDs []int
+}
+
+func (r *recordingRequester) Request(subject string, data []byte, _ time.Duration) (*nats.Msg, error) {
+	var payload struct {
+		MediaID        string `json:"mediaId"`
+		ConversationID int    `json:"conversationId"`
+	}
+	_ = json.Unmarshal(data, &payload)
+	r.subjects = append(r.subjects, subject)
+	r.mediaIDs = append(r.mediaIDs, payload.MediaID)
+	r.conversationIDs = append(r.conversationIDs, payload.ConversationID)
+	return &nats.Msg{Data: []byte(`{"success":true}`)}, nil
+}
+
+func TestMediaKey(t *testing.T) {
+	cases := map[string]string{
+		"http://minio:9000/storm/media/1_photo.png": "media/1_photo.png",
+		"media/2_doc.pdf":               "media/2_doc.pdf",
+		"https://example.com/image.png": "",
+		"":                              "",
+	}
+	for attachment, want := range cases {
+		if got := mediaKey(attachment); got != want {
+			t.Errorf("mediaKey(%q) = %q, want %q", attachment, got, want)
+		}
+	}
+}
+
+func TestRunOncePurgesExpiredConversations(t *testing.T) {
+	conversationSvc := service.NewConversationService(memory.NewConversationRepo())
+	messageSvc := service.NewMessageService(memory.NewMessageRepo())
+	requester := &recordingRequester{}
+	purger := New(conversationSvc, messageSvc, requester)
+
+	conversation, err := conversationSvc.CreateConversation(purgeOwnerID, "Équipe", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	for _, attachment := range []string{"http://minio:9000/storm/media/1_photo.png", "https://example.com/lien.png", ""} {
+		if _, err := messageSvc.SendMessage(&models.ChatMessage{
+			SenderID:       purgeOwnerID,
+			ConversationID: conversation.ID,
+			Content:        "pièce jointe",
+			Attachment:     attachment,
+		}); err != nil {
+			t.Fatalf("SendMessage() error = %v", err)
+		}
+	}
+	if err := conversationSvc.DeleteConversation(purgeOwnerID, conversation.ID); err != nil {
+		t.Fatalf("DeleteConversation() error = %v", err)
+	}
+
+	// Encore dans le délai de grâce : rien n'est purgé.
+	if purged, err := purger.RunOnce(time.Now()); err != nil || purged != 0 {
+		t.Fatalf("RunOnce() within grace period = %d (%v), want 0", purged, err)
+	}
+
+	purged, err := purger.RunOnce(time.Now().Add(service.ConversationRestoreGracePeriod + time.Hour))
+	if err != nil || purged != 1 {
+		t.Fatalf("RunOnce() = %d (%v), want 1", purged, err)
+	}
+	if len(requester.mediaIDs) != 1 || requester.mediaIDs[0] != "media/1_photo.png" || requester.subjects[0] != subjectMediaPurge {
+		t.Fatalf("unexpected media deletions: %v %v", requester.subjects, requester.mediaIDs)
+	}
+	if requester.conversationIDs[0] != conversation.ID {
+		t.Fatalf("media purge must name the purged conversation, got %v", requester.conversationIDs)
+	}
+
+	if messages, _ := messageSvc.GetMessagesByConversationID(conversation.ID); len(messages) != 0 {
+		t.Fatalf("messages should be purged, got %d", len(messages))
+	}
+	if _, err := conversationSvc.RestoreConversation(purgeOwnerID, conversation.ID, time.Now()); !errors.Is(err, repo.ErrConversationNotFound) {
+		t.Fatalf("purged conversation restore: expected ErrConversationNotFound, got %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package repo
 
 import (
+	"time"
+
 	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
 	"github.com/google/uuid"
 )
@@ -8,8 +10,32 @@ import (
 type ConversationRepo interface {
 	CreateConversation(conversation *models.Conversation) (*models.Conversation, error)
 	GetConversationByID(id int) (*models.Conversation, error)
-	ListConversationsByUser(userID uuid.UUID) ([]*models.Conversation, error)
+	// ListConversationsByUser : conversations de userID qu'il a archivées (archived) ou non.
+	ListConversationsByUser(userID uuid.UUID, archived bool) ([]*models.Conversation, error)
+	// UpdateConversation enregistre name, avatar_url et description et met à jour updated_at.
+	UpdateConversation(conversation *models.Conversation) (*models.Conversation, error)
+	// ReferencedAvatars : parmi mediaIDs (media/...), ceux encore utilisés comme avatar d'une conversation
+	// (supprimées comprises, tant qu'elles ne sont pas purgées).
+	ReferencedAvatars(mediaIDs []string) ([]string, error)
+	// UpdateConversationPermissions remplace les permissions et met à jour updated_at.
+	UpdateConversationPermissions(id int, permissions models.ConversationPermissions) (*models.Conversation, error)
 	SoftDeleteConversation(id int) error
+	// GetDeletedConversation : conversation supprimée (soft delete) et memberships retirés avec elle ;
+	// ErrConversationNotFound si elle n'existe pas ou n'est pas supprimée.
+	GetDeletedConversation(id int) (*models.Conversation, []*models.ConversationMembership, error)
+	// RestoreConversation annule la suppression et réactive les memberships retirés avec elle, atomiquement ;
+	// ErrConversationNotFound si deleted_at ne vaut plus deletedAt (déjà restaurée ou purgée),
+	// ErrDirectConversationExists si la paire d'une conversation directe en a recréé une.
+	RestoreConversation(id int, deletedAt time.Time) (*models.Conversation, error)
+	// ListConversationsDeletedBefore : identifiants des conversations supprimées avant before, plus anciennes d'abord.
+	ListConversationsDeletedBefore(before time.Time, limit int) ([]int, error)
+	// PurgeConversation supprime définitivement une conversation supprimée avant before, avec ses memberships,
+	// épingles, invitations et demandes d'adhésion ; ErrConversationNotFound si restaurée entre-temps.
+	PurgeConversation(id int, before time.Time) error
+	GetDirectConversation(directKey string) (*models.Conversation, error)
+	// CreateDirectConversation crée la conversation et ses memberships atomiquement ;
+	// ErrDirectConversationExists si la paire a déjà une conversation directe active.
+	CreateDirectConversation(conversation *models.Conversation, memberIDs []uuid.UUID) (*models.Conversation, error)
 
 	CreateMembership(membership *models.ConversationMembership) (*models.ConversationMembership, error)
 	GetMembership(conversationID int, userID uuid.UUID) (*models.ConversationMembership, error)
@@ -17,5 +43,70 @@ type ConversationRepo interface {
 	UpdateMembershipRole(conversationID int, userID uuid.UUID, role models.ConversationRole) (*models.ConversationMembership, error)
 	SoftDeleteMembership(conversationID int, userID uuid.UUID) error
 	SoftDeleteMembershipsByConversation(conversationID int) error
+	// SetMembershipArchived archive (archivedAt non nil) ou désarchive la conversation pour ce membre.
+	SetMembershipArchived(conversationID int, userID uuid.UUID, archivedAt *time.Time) (*models.ConversationMembership, error)
 	CountOwners(conversationID int) (int, error)
+	// TransferOwnership promeut toUserID owner et rétrograde fromUserID admin dans une transaction ;
+	// ErrNotOwner si fromUserID n'est plus owner au moment du verrou.
+	TransferOwnership(conversationID int, fromUserID, toUserID uuid.UUID) (*models.ConversationMembership, *models.ConversationMembership, error)
+	// AddMemberships crée les memberships dans une transaction, un résultat par utilisateur (même ordre) :
+	// ErrMembershipAlreadyExists pour un membre actif, les autres sont tout de même ajoutés.
+	AddMemberships(conversationID int, userIDs []uuid.UUID, role models.ConversationRole) ([]MembershipResult, error)
+	// RemoveMemberships retire les memberships acceptés par check dans une transaction, un résultat par utilisateur.
+	RemoveMemberships(conversationID int, userIDs []uuid.UUID, check MembershipCheck) ([]MembershipResult, error)
+	// ClaimPostSlot enregistre now comme dernier envoi de userID si le précédent date d'au moins interval,
+	// atomiquement (mode lent) ; sinon retourne ce précédent envoi et claimed = false.
+	// ErrMembershipNotFound sans membership actif.
+	ClaimPostSlot(conversationID int, userID uuid.UUID, now time.Time, interval time.Duration) (last *time.Time, claimed bool, err error)
+
+	CreatePin(pin *models.ConversationPin) (*models.ConversationPin, error)
+	DeletePin(conversationID, messageID int) error
+	ListPins(conversationID int) ([]*models.ConversationPin, error)
+
+	CreateInvite(invite *models.ConversationInvite) (*models.ConversationInvite, error)
+	GetInviteByID(id int) (*models.ConversationInvite, error)
+	ListInvites(conversationID int) ([]*models.ConversationInvite, error)
+	RevokeInvite(id int, revokedAt time.Time) (*models.ConversationInvite, error)
+	// RedeemInvite vérifie l'invitation sous verrou, crée le membership (rôle member),
+	// incrémente use_count et trace l'utilisation, de façon atomique.
+	RedeemInvite(token string, userID uuid.UUID, now time.Time) (*models.ConversationInvite, *models.ConversationMembership, error)
+	ListInviteUses(inviteID int) ([]*models.ConversationInviteUse, error)
+
+	// CreateJoinRequest : ErrJoinRequestExists si une demande pending existe déjà pour ce couple.
+	CreateJoinRequest(request *models.ConversationJoinRequest) (*models.ConversationJoinRequest, error)
+	GetJoinRequest(id int) (*models.ConversationJoinRequest, error)
+	// GetLatestJoinRequest : dernière demande de userID pour la conversation, quel que soit son statut
+	// (ErrJoinRequestNotFound si aucune).
+	GetLatestJoinRequest(conversationID int, userID uuid.UUID) (*models.ConversationJoinRequest, error)
+	// ListJoinRequests : demandes de la conversation au statut donné, plus anciennes d'abord.
+	ListJoinRequests(conversationID int, status models.JoinRequestStatus) ([]*models.ConversationJoinRequest, error)
+	// DecideJoinRequest passe une demande pending à approved/denied sous verrou ; une approbation crée
+	// le membership (rôle member) dans la même transaction. ErrJoinRequestDecided si déjà traitée.
+	DecideJoinRequest(id int, status models.JoinRequestStatus, decidedBy uuid.UUID, now time.Time) (*models.ConversationJoinRequest, *models.ConversationMembership, error)
+
+	// BlockUser est idempotent : un blocage existant est retourné tel quel (date d'origine).
+	BlockUser(blockerID, blockedID uuid.UUID, now time.Time) (*models.UserBlock, error)
+	// UnblockUser : ErrBlockNotFound si blockerID n'avait pas bloqué blockedID.
+	UnblockUser(blockerID, blockedID uuid.UUID) error
+	// ListBlockedUsers : utilisateurs bloqués par blockerID, plus récents d'abord.
+	ListBlockedUsers(blockerID uuid.UUID) ([]*models.UserBlock, error)
+	HasBlocked(blockerID, blockedID uuid.UUID) (bool, error)
+
+	// Modération : chaque opération écrit entry dans le journal d'audit, dans la même transaction.
+	// BanMember retire aussi le membership actif (removed = true) ; ErrUserBanned si déjà banni.
+	BanMember(ban *models.ConversationBan, entry *models.AuditLogEntry) (saved *models.ConversationBan, removed bool, err error)
+	// UnbanMember : ErrBanNotFound si l'utilisateur n'est pas banni.
+	UnbanMember(conversationID int, userID uuid.UUID, entry *models.AuditLogEntry) error
+	IsBanned(conversationID int, userID uuid.UUID) (bool, error)
+	ListBans(conversationID int) ([]*models.ConversationBan, error)
+	// MuteMember crée ou remplace la sourdine (nouvelle échéance).
+	MuteMember(mute *models.ConversationMute, entry *models.AuditLogEntry) (*models.ConversationMute, error)
+	// UnmuteMember : ErrMuteNotFound sans sourdine enregistrée.
+	UnmuteMember(conversationID int, userID uuid.UUID, entry *models.AuditLogEntry) error
+	// GetMute : ErrMuteNotFound sans sourdine ; une sourdine échue est retournée telle quelle.
+	GetMute(conversationID int, userID uuid.UUID) (*models.ConversationMute, error)
+	// KickMember retire le membership actif : ErrMembershipNotFound s'il n'existe pas.
+	KickMember(conversationID int, userID uuid.UUID, entry *models.AuditLogEntry) error
+	// ListAuditLog : entrées plus récentes d'abord, d'id < beforeID si beforeID > 0.
+	ListAuditLog(conversationID int, beforeID, limit int) ([]*models.AuditLogEntry, error)
 }
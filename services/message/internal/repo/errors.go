@@ -6,4 +6,36 @@ var (
 	ErrConversationNotFound    = errors.New("conversation not found")
 	ErrMembershipNotFound      = errors.New("membership not found")
 	ErrMembershipAlreadyExists = errors.New("membership already exists")
+	ErrNotOwner                = errors.New("membership is not owner")
+	ErrPinNotFound             = errors.New("pin not found")
+	ErrPinAlreadyExists        = errors.New("message already pinned")
+
+	ErrDirectConversationExists = errors.New("direct conversation already exists")
+
+	ErrInviteNotFound  = errors.New("invite not found")
+	ErrInviteExpired   = errors.New("invite expired")
+	ErrInviteRevoked   = errors.New("invite revoked")
+	ErrInviteExhausted = errors.New("invite has reached its maximum number of uses")
+
+	ErrJoinRequestNotFound = errors.New("join request not found")
+	ErrJoinRequestExists   = errors.New("a join request is already pending")
+	ErrJoinRequestDecided  = errors.New("join request already decided")
+
+	ErrScheduledMessageNotFound   = errors.New("scheduled message not found")
+	ErrScheduledMessageNotPending = errors.New("scheduled message is no longer pending")
+
+	ErrLinkPreviewNotFound = errors.New("link preview not found")
+
+	ErrPollNotFound = errors.New("poll not found")
+	ErrPollClosed   = errors.New("poll is closed")
+
+	ErrBlockNotFound = errors.New("block not found")
+
+	ErrReportNotFound = errors.New("report not found")
+	ErrReportExists   = errors.New("message already reported")
+	ErrReportResolved = errors.New("report already resolved")
+
+	ErrUserBanned   = errors.New("user is banned from this conversation")
+	ErrBanNotFound  = errors.New("ban not found")
+	ErrMuteNotFound = errors.New("mute not found")
 )
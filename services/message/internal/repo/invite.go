@@ -0,0 +1,20 @@
+package repo
+
+import (
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+)
+
+// CheckInviteUsable : règles communes aux implémentations de RedeemInvite (à appeler sous verrou).
+func CheckInviteUsable(invite *models.ConversationInvite, now time.Time) error {
+	switch {
+	case invite.RevokedAt != nil:
+		return ErrInviteRevoked
+	case invite.IsExpired(now):
+		return ErrInviteExpired
+	case invite.IsExhausted():
+		return ErrInviteExhausted
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package repo
+
+import (
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/google/uuid"
+)
+
+// MembershipResult : issue d'une opération groupée pour un utilisateur (Err nil = succès).
+type MembershipResult struct {
+	UserID     uuid.UUID
+	Membership *models.ConversationMembership
+	Err        error
+}
+
+// MembershipCheck est appelé sous verrou sur le membership ciblé par RemoveMemberships ;
+// une erreur non nil le laisse intact et devient le résultat de cet utilisateur.
+type MembershipCheck func(target *models.ConversationMembership) error
@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (r *conversationRepo) BlockUser(blockerID, blockedID uuid.UUID, now time.Time) (*models.UserBlock, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.blocks[blockerID]; !ok {
+		r.blocks[blockerID] = make(map[uuid.UUID]*models.UserBlock)
+	}
+	if existing, ok := r.blocks[blockerID][blockedID]; ok {
+		cpy := *existing
+		return &cpy, nil
+	}
+	block := &models.UserBlock{BlockerID: blockerID, BlockedID: blockedID, CreatedAt: now}
+	r.blocks[blockerID][blockedID] = block
+	cpy := *block
+	return &cpy, nil
+}
+
+func (r *conversationRepo) UnblockUser(blockerID, blockedID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.blocks[blockerID][blockedID]; !ok {
+		return repo.ErrBlockNotFound
+	}
+	delete(r.blocks[blockerID], blockedID)
+	return nil
+}
+
+func (r *conversationRepo) ListBlockedUsers(blockerID uuid.UUID) ([]*models.UserBlock, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*models.UserBlock, 0, len(r.blocks[blockerID]))
+	for _, block := range r.blocks[blockerID] {
+		cpy := *block
+		result = append(result, &cpy)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+		return result[i].BlockedID.String() < result[j].BlockedID.String()
+	})
+	return result, nil
+}
+
+func (r *conversationRepo) HasBlocked(blockerID, blockedID uuid.UUID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.blocks[blockerID][blockedID]
+	return ok, nil
+}
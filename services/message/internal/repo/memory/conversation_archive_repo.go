@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (r *conversationRepo) SetMembershipArchived(conversationID int, userID uuid.UUID, archivedAt *time.Time) (*models.ConversationMembership, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	membership := r.activeMembershipLocked(conversationID, userID)
+	if membership == nil {
+		return nil, repo.ErrMembershipNotFound
+	}
+	if archivedAt != nil {
+		at := *archivedAt
+		membership.ArchivedAt = &at
+	} else {
+		membership.ArchivedAt = nil
+	}
+	return cloneMembership(membership), nil
+}
+
+func (r *conversationRepo) GetDeletedConversation(id int) (*models.Conversation, []*models.ConversationMembership, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	conversation, ok := r.conversations[id]
+	if !ok || conversation.DeletedAt == nil {
+		return nil, nil, repo.ErrConversationNotFound
+	}
+
+	memberships := make([]*models.ConversationMembership, 0)
+	for _, membership := range r.memberships[id] {
+		if membership.DeletedAt != nil && !membership.DeletedAt.Before(*conversation.DeletedAt) {
+			memberships = append(memberships, cloneMembership(membership))
+		}
+	}
+	return cloneConversation(conversation), memberships, nil
+}
+
+func (r *conversationRepo) RestoreConversation(id int, deletedAt time.Time) (*models.Conversation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conversation, ok := r.conversations[id]
+	if !ok || conversation.DeletedAt == nil || !conversation.DeletedAt.Equal(deletedAt) {
+		return nil, repo.ErrConversationNotFound
+	}
+	if conversation.IsDirect() && r.findDirectLocked(conversation.DirectKey) != nil {
+		return nil, repo.ErrDirectConversationExists
+	}
+
+	for _, membership := range r.memberships[id] {
+		if membership.DeletedAt != nil && !membership.DeletedAt.Before(deletedAt) {
+			membership.DeletedAt = nil
+		}
+	}
+	conversation.DeletedAt = nil
+	conversation.UpdatedAt = time.Now()
+	return cloneConversation(conversation), nil
+}
+
+func (r *conversationRepo) ListConversationsDeletedBefore(before time.Time, limit int) ([]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deleted := make([]*models.Conversation, 0)
+	for _, conversation := range r.conversations {
+		if conversation.DeletedAt != nil && conversation.DeletedAt.Before(before) {
+			deleted = append(deleted, conversation)
+		}
+	}
+	sort.Slice(deleted, func(i, j int) bool {
+		return deleted[i].DeletedAt.Before(*deleted[j].DeletedAt)
+	})
+	if limit > 0 && len(deleted) > limit {
+		deleted = deleted[:limit]
+	}
+
+	ids := make([]int, 0, len(deleted))
+	for _, conversation := range deleted {
+		ids = append(ids, conversation.ID)
+	}
+	return ids, nil
+}
+
+func (r *conversationRepo) PurgeConversation(id int, before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conversation, ok := r.conversations[id]
+	if !ok || conversation.DeletedAt == nil || !conversation.DeletedAt.Before(before) {
+		return repo.ErrConversationNotFound
+	}
+
+	delete(r.conversations, id)
+	delete(r.memberships, id)
+	delete(r.pins, id)
+	for inviteID, invite := range r.invites {
+		if invite.ConversationID != id {
+			continue
+		}
+		delete(r.invites, inviteID)
+		uses := r.inviteUses[:0]
+		for _, use := range r.inviteUses {
+			if use.InviteID != inviteID {
+				uses = append(uses, use)
+			}
+		}
+		r.inviteUses = uses
+	}
+	for requestID, request := range r.joinRequests {
+		if request.ConversationID == id {
+			delete(r.joinRequests, requestID)
+		}
+	}
+	delete(r.bans, id)
+	delete(r.mutes, id)
+	entries := r.auditLog[:0]
+	for _, entry := range r.auditLog {
+		if entry.ConversationID != id {
+			entries = append(entries, entry)
+		}
+	}
+	r.auditLog = entries
+	return nil
+}
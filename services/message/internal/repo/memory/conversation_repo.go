@@ -15,6 +15,18 @@ type conversationRepo struct {
 	memberships   map[int]map[uuid.UUID]*models.ConversationMembership
 	nextConvID    int
 	nextMemberID  int
+	pins          map[int]map[int]*models.ConversationPin
+	invites       map[int]*models.ConversationInvite
+	inviteUses    []*models.ConversationInviteUse
+	nextInviteID  int
+	joinRequests  map[int]*models.ConversationJoinRequest
+	nextRequestID int
+	blocks        map[uuid.UUID]map[uuid.UUID]*models.UserBlock
+	bans          map[int]map[uuid.UUID]*models.ConversationBan
+	mutes         map[int]map[uuid.UUID]*models.ConversationMute
+	lastPosts     map[int]time.Time // par id de membership
+	auditLog      []*models.AuditLogEntry
+	nextAuditID   int
 }
 
 func NewConversationRepo() repo.ConversationRepo {
@@ -23,6 +35,16 @@ func NewConversationRepo() repo.ConversationRepo {
 		memberships:   make(map[int]map[uuid.UUID]*models.ConversationMembership),
 		nextConvID:    1,
 		nextMemberID:  1,
+		pins:          make(map[int]map[int]*models.ConversationPin),
+		invites:       make(map[int]*models.ConversationInvite),
+		nextInviteID:  1,
+		joinRequests:  make(map[int]*models.ConversationJoinRequest),
+		nextRequestID: 1,
+		blocks:        make(map[uuid.UUID]map[uuid.UUID]*models.UserBlock),
+		bans:          make(map[int]map[uuid.UUID]*models.ConversationBan),
+		mutes:         make(map[int]map[uuid.UUID]*models.ConversationMute),
+		lastPosts:     make(map[int]time.Time),
+		nextAuditID:   1,
 	}
 }
 
@@ -34,6 +56,9 @@ func (r *conversationRepo) CreateConversation(conversation *models.Conversation)
 	saved := *conversation
 	saved.ID = r.nextConvID
 	r.nextConvID++
+	if saved.Kind == "" {
+		saved.Kind = models.ConversationKindGroup
+	}
 	if saved.CreatedAt.IsZero() {
 		saved.CreatedAt = now
 	}
@@ -49,6 +74,58 @@ func (r *conversationRepo) CreateConversation(conversation *models.Conversation)
 	return cloneConversation(&saved), nil
 }
 
+func (r *conversationRepo) GetDirectConversation(directKey string) (*models.Conversation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if conversation := r.findDirectLocked(directKey); conversation != nil {
+		return cloneConversation(conversation), nil
+	}
+	return nil, repo.ErrConversationNotFound
+}
+
+func (r *conversationRepo) CreateDirectConversation(conversation *models.Conversation, memberIDs []uuid.UUID) (*models.Conversation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.findDirectLocked(conversation.DirectKey) != nil {
+		return nil, repo.ErrDirectConversationExists
+	}
+
+	now := time.Now()
+	saved := *conversation
+	saved.ID = r.nextConvID
+	r.nextConvID++
+	saved.Kind = models.ConversationKindDirect
+	saved.CreatedAt = now
+	saved.UpdatedAt = now
+	r.conversations[saved.ID] = &saved
+
+	members := make(map[uuid.UUID]*models.ConversationMembership, len(memberIDs))
+	for _, userID := range memberIDs {
+		members[userID] = &models.ConversationMembership{
+			ID:             r.nextMemberID,
+			UserID:         userID,
+			ConversationID: saved.ID,
+			Role:           models.ConversationRoleMember,
+			CreatedAt:      now,
+		}
+		r.nextMemberID++
+	}
+	r.memberships[saved.ID] = members
+
+	return cloneConversation(&saved), nil
+}
+
+func (r *conversationRepo) findDirectLocked(directKey string) *models.Conversation {
+	for _, conversation := range r.conversations {
+		if conversation.DeletedAt == nil && conversation.IsDirect() && conversation.DirectKey == directKey {
+			return conversation
+		}
+	}
+	return nil
+}
+
 func (r *conversationRepo) GetConversationByID(id int) (*models.Conversation, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -61,14 +138,14 @@ func (r *conversationRepo) GetConversationByID(id int) (*models.Conversation, er
 	return cloneConversation(conversation), nil
 }
 
-func (r *conversationRepo) ListConversationsByUser(userID uuid.UUID) ([]*models.Conversation, error) {
+func (r *conversationRepo) ListConversationsByUser(userID uuid.UUID, archived bool) ([]*models.Conversation, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	conversations := make([]*models.Conversation, 0)
 	for conversationID, members := range r.memberships {
 		membership, ok := members[userID]
-		if !ok || membership.DeletedAt != nil {
+		if !ok || membership.DeletedAt != nil || (membership.ArchivedAt != nil) != archived {
 			continue
 		}
 
@@ -82,6 +159,55 @@ func (r *conversationRepo) ListConversationsByUser(userID uuid.UUID) ([]*models.
 	return conversations, nil
 }
 
+func (r *conversationRepo) UpdateConversation(conversation *models.Conversation) (*models.Conversation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.conversations[conversation.ID]
+	if !ok || existing.DeletedAt != nil {
+		return nil, repo.ErrConversationNotFound
+	}
+
+	existing.Name = conversation.Name
+	existing.AvatarURL = conversation.AvatarURL
+	existing.Description = conversation.Description
+	existing.UpdatedAt = time.Now()
+	return cloneConversation(existing), nil
+}
+
+func (r *conversationRepo) ReferencedAvatars(mediaIDs []string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(mediaIDs))
+	for _, id := range mediaIDs {
+		wanted[id] = true
+	}
+	referenced := make([]string, 0)
+	for _, conversation := range r.conversations {
+		mediaID := models.AttachmentMediaID(conversation.AvatarURL)
+		if wanted[mediaID] {
+			wanted[mediaID] = false
+			referenced = append(referenced, mediaID)
+		}
+	}
+	return referenced, nil
+}
+
+func (r *conversationRepo) UpdateConversationPermissions(id int, permissions models.ConversationPermissions) (*models.Conversation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.conversations[id]
+	if !ok || existing.DeletedAt != nil {
+		return nil, repo.ErrConversationNotFound
+	}
+
+	existing.Permissions = permissions
+	existing.UpdatedAt = time.Now()
+	return cloneConversation(existing), nil
+}
+
 func (r *conversationRepo) SoftDeleteConversation(id int) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -251,5 +377,9 @@ func cloneMembership(membership *models.ConversationMembership) *models.Conversa
 		return nil
 	}
 	cpy := *membership
+	if membership.ArchivedAt != nil {
+		archivedAt := *membership.ArchivedAt
+		cpy.ArchivedAt = &archivedAt
+	}
 	return &cpy
 }
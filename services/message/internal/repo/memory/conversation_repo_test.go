@@ -72,7 +72,7 @@ func TestConversationRepoLifecycle(t *testing.T) {
 		t.Fatalf("CreateMembership(member) error = %v", err)
 	}
 
-	conversationsForOwner, err := r.ListConversationsByUser(repoOwnerID)
+	conversationsForOwner, err := r.ListConversationsByUser(repoOwnerID, false)
 	if err != nil {
 		t.Fatalf("ListConversationsByUser(owner) error = %v", err)
 	}
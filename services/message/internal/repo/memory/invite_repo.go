@@ -0,0 +1,156 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (r *conversationRepo) CreateInvite(invite *models.ConversationInvite) (*models.ConversationInvite, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conversation, ok := r.conversations[invite.ConversationID]
+	if !ok || conversation.DeletedAt != nil {
+		return nil, repo.ErrConversationNotFound
+	}
+
+	saved := *invite
+	saved.ID = r.nextInviteID
+	r.nextInviteID++
+	saved.UseCount = 0
+	saved.RevokedAt = nil
+	if saved.CreatedAt.IsZero() {
+		saved.CreatedAt = time.Now()
+	}
+	r.invites[saved.ID] = &saved
+	return cloneInvite(&saved), nil
+}
+
+func (r *conversationRepo) GetInviteByID(id int) (*models.ConversationInvite, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	invite, ok := r.invites[id]
+	if !ok {
+		return nil, repo.ErrInviteNotFound
+	}
+	return cloneInvite(invite), nil
+}
+
+func (r *conversationRepo) ListInvites(conversationID int) ([]*models.ConversationInvite, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*models.ConversationInvite, 0)
+	for _, invite := range r.invites {
+		if invite.ConversationID == conversationID {
+			result = append(result, cloneInvite(invite))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID > result[j].ID
+	})
+	return result, nil
+}
+
+func (r *conversationRepo) RevokeInvite(id int, revokedAt time.Time) (*models.ConversationInvite, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	invite, ok := r.invites[id]
+	if !ok {
+		return nil, repo.ErrInviteNotFound
+	}
+	if invite.RevokedAt == nil {
+		at := revokedAt
+		invite.RevokedAt = &at
+	}
+	return cloneInvite(invite), nil
+}
+
+func (r *conversationRepo) RedeemInvite(token string, userID uuid.UUID, now time.Time) (*models.ConversationInvite, *models.ConversationMembership, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var invite *models.ConversationInvite
+	for _, candidate := range r.invites {
+		if candidate.Token == token {
+			invite = candidate
+			break
+		}
+	}
+	if invite == nil {
+		return nil, nil, repo.ErrInviteNotFound
+	}
+	if err := repo.CheckInviteUsable(invite, now); err != nil {
+		return nil, nil, err
+	}
+
+	conversation, ok := r.conversations[invite.ConversationID]
+	if !ok || conversation.DeletedAt != nil {
+		return nil, nil, repo.ErrConversationNotFound
+	}
+	if _, banned := r.bans[invite.ConversationID][userID]; banned {
+		return nil, nil, repo.ErrUserBanned
+	}
+	if _, ok := r.memberships[invite.ConversationID]; !ok {
+		r.memberships[invite.ConversationID] = make(map[uuid.UUID]*models.ConversationMembership)
+	}
+	if existing, exists := r.memberships[invite.ConversationID][userID]; exists && existing.DeletedAt == nil {
+		return nil, nil, repo.ErrMembershipAlreadyExists
+	}
+
+	membership := &models.ConversationMembership{
+		ID:             r.nextMemberID,
+		UserID:         userID,
+		ConversationID: invite.ConversationID,
+		Role:           models.ConversationRoleMember,
+		CreatedAt:      now,
+	}
+	r.nextMemberID++
+	r.memberships[invite.ConversationID][userID] = membership
+
+	invite.UseCount++
+	r.inviteUses = append(r.inviteUses, &models.ConversationInviteUse{
+		ID:       len(r.inviteUses) + 1,
+		InviteID: invite.ID,
+		UserID:   userID,
+		UsedAt:   now,
+	})
+
+	return cloneInvite(invite), cloneMembership(membership), nil
+}
+
+func (r *conversationRepo) ListInviteUses(inviteID int) ([]*models.ConversationInviteUse, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*models.ConversationInviteUse, 0)
+	for i := len(r.inviteUses) - 1; i >= 0; i-- {
+		if use := r.inviteUses[i]; use.InviteID == inviteID {
+			cpy := *use
+			result = append(result, &cpy)
+		}
+	}
+	return result, nil
+}
+
+func cloneInvite(invite *models.ConversationInvite) *models.ConversationInvite {
+	if invite == nil {
+		return nil
+	}
+	cpy := *invite
+	if invite.ExpiresAt != nil {
+		expiresAt := *invite.ExpiresAt
+		cpy.ExpiresAt = &expiresAt
+	}
+	if invite.RevokedAt != nil {
+		revokedAt := *invite.RevokedAt
+		cpy.RevokedAt = &revokedAt
+	}
+	return &cpy
+}
@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (r *conversationRepo) CreateJoinRequest(request *models.ConversationJoinRequest) (*models.ConversationJoinRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conversation, ok := r.conversations[request.ConversationID]
+	if !ok || conversation.DeletedAt != nil {
+		return nil, repo.ErrConversationNotFound
+	}
+	for _, existing := range r.joinRequests {
+		if existing.ConversationID == request.ConversationID &&
+			existing.UserID == request.UserID &&
+			existing.Status == models.JoinRequestPending {
+			return nil, repo.ErrJoinRequestExists
+		}
+	}
+
+	saved := *request
+	saved.ID = r.nextRequestID
+	r.nextRequestID++
+	saved.Status = models.JoinRequestPending
+	saved.DecidedBy = uuid.Nil
+	saved.DecidedAt = nil
+	if saved.CreatedAt.IsZero() {
+		saved.CreatedAt = time.Now()
+	}
+	r.joinRequests[saved.ID] = &saved
+	return cloneJoinRequest(&saved), nil
+}
+
+func (r *conversationRepo) GetJoinRequest(id int) (*models.ConversationJoinRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	request, ok := r.joinRequests[id]
+	if !ok {
+		return nil, repo.ErrJoinRequestNotFound
+	}
+	return cloneJoinRequest(request), nil
+}
+
+func (r *conversationRepo) GetLatestJoinRequest(conversationID int, userID uuid.UUID) (*models.ConversationJoinRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var latest *models.ConversationJoinRequest
+	for _, request := range r.joinRequests {
+		if request.ConversationID != conversationID || request.UserID != userID {
+			continue
+		}
+		if latest == nil || request.CreatedAt.After(latest.CreatedAt) ||
+			(request.CreatedAt.Equal(latest.CreatedAt) && request.ID > latest.ID) {
+			latest = request
+		}
+	}
+	if latest == nil {
+		return nil, repo.ErrJoinRequestNotFound
+	}
+	return cloneJoinRequest(latest), nil
+}
+
+func (r *conversationRepo) ListJoinRequests(conversationID int, status models.JoinRequestStatus) ([]*models.ConversationJoinRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*models.ConversationJoinRequest, 0)
+	for _, request := range r.joinRequests {
+		if request.ConversationID == conversationID && request.Status == status {
+			result = append(result, cloneJoinRequest(request))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+func (r *conversationRepo) DecideJoinRequest(id int, status models.JoinRequestStatus, decidedBy uuid.UUID, now time.Time) (*models.ConversationJoinRequest, *models.ConversationMembership, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	request, ok := r.joinRequests[id]
+	if !ok {
+		return nil, nil, repo.ErrJoinRequestNotFound
+	}
+	if request.Status != models.JoinRequestPending {
+		return nil, nil, repo.ErrJoinRequestDecided
+	}
+
+	var membership *models.ConversationMembership
+	if status == models.JoinRequestApproved {
+		conversation, ok := r.conversations[request.ConversationID]
+		if !ok || conversation.DeletedAt != nil {
+			return nil, nil, repo.ErrConversationNotFound
+		}
+		if _, ok := r.memberships[request.ConversationID]; !ok {
+			r.memberships[request.ConversationID] = make(map[uuid.UUID]*models.ConversationMembership)
+		}
+		// Déjà membre entre-temps (invitation, ajout direct) : la demande est simplement clôturée.
+		if existing, exists := r.memberships[request.ConversationID][request.UserID]; exists && existing.DeletedAt == nil {
+			membership = existing
+		} else {
+			membership = &models.ConversationMembership{
+				ID:             r.nextMemberID,
+				UserID:         request.UserID,
+				ConversationID: request.ConversationID,
+				Role:           models.ConversationRoleMember,
+				CreatedAt:      now,
+			}
+			r.nextMemberID++
+			r.memberships[request.ConversationID][request.UserID] = membership
+		}
+	}
+
+	decidedAt := now
+	request.Status = status
+	request.DecidedBy = decidedBy
+	request.DecidedAt = &decidedAt
+	return cloneJoinRequest(request), cloneMembership(membership), nil
+}
+
+func cloneJoinRequest(request *models.ConversationJoinRequest) *models.ConversationJoinRequest {
+	if request == nil {
+		return nil
+	}
+	cpy := *request
+	if request.DecidedAt != nil {
+		decidedAt := *request.DecidedAt
+		cpy.DecidedAt = &decidedAt
+	}
+	return &cpy
+}
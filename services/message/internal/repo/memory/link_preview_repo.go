@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"errors"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+)
+
+func (r *messageRepo) GetLinkPreview(url string) (*models.LinkPreview, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	preview, ok := r.linkPreviews[url]
+	if !ok {
+		return nil, repo.ErrLinkPreviewNotFound
+	}
+	clone := *preview
+	return &clone, nil
+}
+
+func (r *messageRepo) SaveLinkPreview(preview *models.LinkPreview) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clone := *preview
+	r.linkPreviews[preview.URL] = &clone
+	return nil
+}
+
+func (r *messageRepo) SetMessageLinkPreview(messageID int, preview *models.LinkPreview) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	msg := r.findByIDLocked(messageID)
+	if msg == nil {
+		return errors.New("message not found")
+	}
+	clone := *preview
+	msg.LinkPreview = &clone
+	return nil
+}
@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (r *conversationRepo) TransferOwnership(conversationID int, fromUserID, toUserID uuid.UUID) (*models.ConversationMembership, *models.ConversationMembership, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	from := r.activeMembershipLocked(conversationID, fromUserID)
+	to := r.activeMembershipLocked(conversationID, toUserID)
+	if from == nil || to == nil {
+		return nil, nil, repo.ErrMembershipNotFound
+	}
+	if from.Role != models.ConversationRoleOwner {
+		return nil, nil, repo.ErrNotOwner
+	}
+
+	to.Role = models.ConversationRoleOwner
+	from.Role = models.ConversationRoleAdmin
+	return cloneMembership(from), cloneMembership(to), nil
+}
+
+func (r *conversationRepo) AddMemberships(conversationID int, userIDs []uuid.UUID, role models.ConversationRole) ([]repo.MembershipResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conversation, ok := r.conversations[conversationID]
+	if !ok || conversation.DeletedAt != nil {
+		return nil, repo.ErrConversationNotFound
+	}
+	if _, ok := r.memberships[conversationID]; !ok {
+		r.memberships[conversationID] = make(map[uuid.UUID]*models.ConversationMembership)
+	}
+
+	now := time.Now()
+	results := make([]repo.MembershipResult, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if r.activeMembershipLocked(conversationID, userID) != nil {
+			results = append(results, repo.MembershipResult{UserID: userID, Err: repo.ErrMembershipAlreadyExists})
+			continue
+		}
+		saved := &models.ConversationMembership{
+			ID:             r.nextMemberID,
+			UserID:         userID,
+			ConversationID: conversationID,
+			Role:           role,
+			CreatedAt:      now,
+		}
+		r.nextMemberID++
+		r.memberships[conversationID][userID] = saved
+		results = append(results, repo.MembershipResult{UserID: userID, Membership: cloneMembership(saved)})
+	}
+	return results, nil
+}
+
+func (r *conversationRepo) RemoveMemberships(conversationID int, userIDs []uuid.UUID, check repo.MembershipCheck) ([]repo.MembershipResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conversation, ok := r.conversations[conversationID]
+	if !ok || conversation.DeletedAt != nil {
+		return nil, repo.ErrConversationNotFound
+	}
+
+	now := time.Now()
+	results := make([]repo.MembershipResult, 0, len(userIDs))
+	for _, userID := range userIDs {
+		membership := r.activeMembershipLocked(conversationID, userID)
+		if membership == nil {
+			results = append(results, repo.MembershipResult{UserID: userID, Err: repo.ErrMembershipNotFound})
+			continue
+		}
+		if check != nil {
+			if err := check(cloneMembership(membership)); err != nil {
+				results = append(results, repo.MembershipResult{UserID: userID, Err: err})
+				continue
+			}
+		}
+		deletedAt := now
+		membership.DeletedAt = &deletedAt
+		results = append(results, repo.MembershipResult{UserID: userID, Membership: cloneMembership(membership)})
+	}
+	return results, nil
+}
+
+func (r *conversationRepo) ClaimPostSlot(conversationID int, userID uuid.UUID, now time.Time, interval time.Duration) (*time.Time, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	membership := r.activeMembershipLocked(conversationID, userID)
+	if membership == nil {
+		return nil, false, repo.ErrMembershipNotFound
+	}
+	if last, ok := r.lastPosts[membership.ID]; ok && last.After(now.Add(-interval)) {
+		return &last, false, nil
+	}
+	r.lastPosts[membership.ID] = now
+	return nil, true, nil
+}
+
+func (r *conversationRepo) activeMembershipLocked(conversationID int, userID uuid.UUID) *models.ConversationMembership {
+	membership, ok := r.memberships[conversationID][userID]
+	if !ok || membership.DeletedAt != nil {
+		return nil
+	}
+	return membership
+}
@@ -16,6 +16,19 @@ type messageRepo struct {
 	receipts map[int]map[uuid.UUID]*models.MessageReceipt
 	seenBy   map[int][]*models.MessageSeenBy
 	counter  int
+
+	scheduled       map[int]*models.ScheduledMessage
+	nextScheduledID int
+
+	linkPreviews map[string]*models.LinkPreview
+
+	polls        map[int]*models.Poll
+	pollVotes    map[int][]*models.PollVote
+	nextPollID   int
+	nextOptionID int
+
+	reports      map[int]*models.MessageReport
+	nextReportID int
 }
 
 func NewMessageRepo() repo.MessageRepo {
@@ -24,6 +37,16 @@ func NewMessageRepo() repo.MessageRepo {
 		receipts: make(map[int]map[uuid.UUID]*models.MessageReceipt),
 		seenBy:   make(map[int][]*models.MessageSeenBy),
 		counter:  0,
+
+		scheduled:       make(map[int]*models.ScheduledMessage),
+		nextScheduledID: 1,
+		linkPreviews:    make(map[string]*models.LinkPreview),
+		polls:           make(map[int]*models.Poll),
+		pollVotes:       make(map[int][]*models.PollVote),
+		nextPollID:      1,
+		nextOptionID:    1,
+		reports:         make(map[int]*models.MessageReport),
+		nextReportID:    1,
 	}
 }
 
@@ -31,6 +54,10 @@ func (r *messageRepo) SaveMessage(msg *models.ChatMessage) (*models.ChatMessage,
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	return r.saveMessageLocked(msg), nil
+}
+
+func (r *messageRepo) saveMessageLocked(msg *models.ChatMessage) *models.ChatMessage {
 	saved := *msg
 	r.counter++
 	saved.ID = r.counter
@@ -43,9 +70,12 @@ func (r *messageRepo) SaveMessage(msg *models.ChatMessage) (*models.ChatMessage,
 	if saved.Status == "" {
 		saved.Status = "sent"
 	}
+	if saved.Kind == "" {
+		saved.Kind = models.MessageKindUser
+	}
 
 	r.messages = append(r.messages, &saved)
-	return &saved, nil
+	return &saved
 }
 
 func (r *messageRepo) BulkSaveMessages(msgs []*models.ChatMessage) ([]*models.ChatMessage, error) {
@@ -110,6 +140,23 @@ func (r *messageRepo) GetMessagesByConversationID(conversationID int) ([]*models
 	return messages, nil
 }
 
+func (r *messageRepo) GetLastMessageAtBySender(conversationID int, senderID uuid.UUID) (*time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var last *time.Time
+	for _, msg := range r.messages {
+		if msg.ConversationID != conversationID || msg.SenderID != senderID || msg.IsSystem() {
+			continue
+		}
+		if last == nil || msg.CreatedAt.After(*last) {
+			createdAt := msg.CreatedAt
+			last = &createdAt
+		}
+	}
+	return last, nil
+}
+
 func (r *messageRepo) findByIDLocked(id int) *models.ChatMessage {
 	for _, m := range r.messages {
 		if m.ID == id {
@@ -249,6 +296,74 @@ func (r *messageRepo) DeleteMessageById(id int) error {
 	return errors.New("message not found")
 }
 
+func (r *messageRepo) PurgeConversationMessages(conversationID int) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attachments := make([]string, 0)
+	seen := make(map[string]struct{})
+	kept := r.messages[:0]
+	for _, msg := range r.messages {
+		if msg.ConversationID != conversationID {
+			kept = append(kept, msg)
+			continue
+		}
+		if _, ok := seen[msg.Attachment]; msg.Attachment != "" && !ok {
+			seen[msg.Attachment] = struct{}{}
+			attachments = append(attachments, msg.Attachment)
+		}
+		delete(r.receipts, msg.ID)
+		delete(r.seenBy, msg.ID)
+	}
+	r.messages = kept
+
+	for id, poll := range r.polls {
+		if poll.ConversationID == conversationID {
+			delete(r.polls, id)
+			delete(r.pollVotes, id)
+		}
+	}
+	for id, scheduled := range r.scheduled {
+		if scheduled.ConversationID == conversationID {
+			delete(r.scheduled, id)
+		}
+	}
+	for id, report := range r.reports {
+		if report.ConversationID == conversationID {
+			delete(r.reports, id)
+		}
+	}
+	return attachments, nil
+}
+
+func (r *messageRepo) ReferencedAttachments(mediaIDs []string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(mediaIDs))
+	for _, id := range mediaIDs {
+		wanted[id] = true
+	}
+	referenced := make([]string, 0)
+	cite := func(attachment string) {
+		mediaID := models.AttachmentMediaID(attachment)
+		if !wanted[mediaID] {
+			return
+		}
+		wanted[mediaID] = false
+		referenced = append(referenced, mediaID)
+	}
+	for _, msg := range r.messages {
+		cite(msg.Attachment)
+	}
+	for _, scheduled := range r.scheduled {
+		if scheduled.Status == models.ScheduledStatusPending || scheduled.Status == models.ScheduledStatusProcessing {
+			cite(scheduled.Attachment)
+		}
+	}
+	return referenced, nil
+}
+
 func cloneMessageReceipt(receipt *models.MessageReceipt) *models.MessageReceipt {
 	if receipt == nil {
 		return nil
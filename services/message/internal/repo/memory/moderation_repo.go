@@ -0,0 +1,180 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (r *conversationRepo) BanMember(ban *models.ConversationBan, entry *models.AuditLogEntry) (*models.ConversationBan, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conversation, ok := r.conversations[ban.ConversationID]
+	if !ok || conversation.DeletedAt != nil {
+		return nil, false, repo.ErrConversationNotFound
+	}
+	if _, exists := r.bans[ban.ConversationID][ban.UserID]; exists {
+		return nil, false, repo.ErrUserBanned
+	}
+
+	saved := *ban
+	if saved.CreatedAt.IsZero() {
+		saved.CreatedAt = time.Now()
+	}
+	if _, ok := r.bans[ban.ConversationID]; !ok {
+		r.bans[ban.ConversationID] = make(map[uuid.UUID]*models.ConversationBan)
+	}
+	r.bans[ban.ConversationID][ban.UserID] = &saved
+
+	removed := false
+	if membership, ok := r.memberships[ban.ConversationID][ban.UserID]; ok && membership.DeletedAt == nil {
+		deletedAt := saved.CreatedAt
+		membership.DeletedAt = &deletedAt
+		removed = true
+	}
+	r.appendAuditLocked(entry)
+
+	cpy := saved
+	return &cpy, removed, nil
+}
+
+func (r *conversationRepo) UnbanMember(conversationID int, userID uuid.UUID, entry *models.AuditLogEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.bans[conversationID][userID]; !ok {
+		return repo.ErrBanNotFound
+	}
+	delete(r.bans[conversationID], userID)
+	r.appendAuditLocked(entry)
+	return nil
+}
+
+func (r *conversationRepo) IsBanned(conversationID int, userID uuid.UUID) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.bans[conversationID][userID]
+	return ok, nil
+}
+
+func (r *conversationRepo) ListBans(conversationID int) ([]*models.ConversationBan, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*models.ConversationBan, 0, len(r.bans[conversationID]))
+	for _, ban := range r.bans[conversationID] {
+		cpy := *ban
+		result = append(result, &cpy)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+		return result[i].UserID.String() < result[j].UserID.String()
+	})
+	return result, nil
+}
+
+func (r *conversationRepo) MuteMember(mute *models.ConversationMute, entry *models.AuditLogEntry) (*models.ConversationMute, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conversation, ok := r.conversations[mute.ConversationID]
+	if !ok || conversation.DeletedAt != nil {
+		return nil, repo.ErrConversationNotFound
+	}
+
+	saved := *mute
+	if saved.CreatedAt.IsZero() {
+		saved.CreatedAt = time.Now()
+	}
+	if _, ok := r.mutes[mute.ConversationID]; !ok {
+		r.mutes[mute.ConversationID] = make(map[uuid.UUID]*models.ConversationMute)
+	}
+	r.mutes[mute.ConversationID][mute.UserID] = &saved
+	r.appendAuditLocked(entry)
+
+	cpy := saved
+	return &cpy, nil
+}
+
+func (r *conversationRepo) UnmuteMember(conversationID int, userID uuid.UUID, entry *models.AuditLogEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.mutes[conversationID][userID]; !ok {
+		return repo.ErrMuteNotFound
+	}
+	delete(r.mutes[conversationID], userID)
+	r.appendAuditLocked(entry)
+	return nil
+}
+
+func (r *conversationRepo) GetMute(conversationID int, userID uuid.UUID) (*models.ConversationMute, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	mute, ok := r.mutes[conversationID][userID]
+	if !ok {
+		return nil, repo.ErrMuteNotFound
+	}
+	cpy := *mute
+	return &cpy, nil
+}
+
+func (r *conversationRepo) KickMember(conversationID int, userID uuid.UUID, entry *models.AuditLogEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	membership, ok := r.memberships[conversationID][userID]
+	if !ok || membership.DeletedAt != nil {
+		return repo.ErrMembershipNotFound
+	}
+	now := time.Now()
+	membership.DeletedAt = &now
+	r.appendAuditLocked(entry)
+	return nil
+}
+
+func (r *conversationRepo) ListAuditLog(conversationID int, beforeID, limit int) ([]*models.AuditLogEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*models.AuditLogEntry, 0)
+	for i := len(r.auditLog) - 1; i >= 0 && len(result) < limit; i-- {
+		entry := r.auditLog[i]
+		if entry.ConversationID != conversationID || (beforeID > 0 && entry.ID >= beforeID) {
+			continue
+		}
+		result = append(result, cloneAuditLogEntry(entry))
+	}
+	return result, nil
+}
+
+// appendAuditLocked : appelant sous r.mu (écriture).
+func (r *conversationRepo) appendAuditLocked(entry *models.AuditLogEntry) {
+	saved := cloneAuditLogEntry(entry)
+	saved.ID = r.nextAuditID
+	r.nextAuditID++
+	if saved.CreatedAt.IsZero() {
+		saved.CreatedAt = time.Now()
+	}
+	r.auditLog = append(r.auditLog, saved)
+}
+
+func cloneAuditLogEntry(entry *models.AuditLogEntry) *models.AuditLogEntry {
+	if entry == nil {
+		return nil
+	}
+	cpy := *entry
+	if entry.ExpiresAt != nil {
+		expiresAt := *entry.ExpiresAt
+		cpy.ExpiresAt = &expiresAt
+	}
+	return &cpy
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+)
+
+func (r *conversationRepo) CreatePin(pin *models.ConversationPin) (*models.ConversationPin, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conversation, ok := r.conversations[pin.ConversationID]
+	if !ok || conversation.DeletedAt != nil {
+		return nil, repo.ErrConversationNotFound
+	}
+	if _, ok := r.pins[pin.ConversationID]; !ok {
+		r.pins[pin.ConversationID] = make(map[int]*models.ConversationPin)
+	}
+	if _, exists := r.pins[pin.ConversationID][pin.MessageID]; exists {
+		return nil, repo.ErrPinAlreadyExists
+	}
+
+	saved := *pin
+	if saved.PinnedAt.IsZero() {
+		saved.PinnedAt = time.Now()
+	}
+	r.pins[pin.ConversationID][pin.MessageID] = &saved
+
+	cpy := saved
+	return &cpy, nil
+}
+
+func (r *conversationRepo) DeletePin(conversationID, messageID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.pins[conversationID][messageID]; !ok {
+		return repo.ErrPinNotFound
+	}
+	delete(r.pins[conversationID], messageID)
+	return nil
+}
+
+func (r *conversationRepo) ListPins(conversationID int) ([]*models.ConversationPin, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*models.ConversationPin, 0, len(r.pins[conversationID]))
+	for _, pin := range r.pins[conversationID] {
+		cpy := *pin
+		result = append(result, &cpy)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PinnedAt.After(result[j].PinnedAt)
+	})
+	return result, nil
+}
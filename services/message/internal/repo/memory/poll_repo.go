@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"errors"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (r *messageRepo) CreatePoll(poll *models.Poll) (*models.Poll, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	msg := r.findByIDLocked(poll.MessageID)
+	if msg == nil {
+		return nil, errors.New("message not found")
+	}
+	for _, existing := range r.polls {
+		if existing.MessageID == poll.MessageID {
+			return nil, errors.New("invalid poll: message already carries a poll")
+		}
+	}
+
+	saved := clonePoll(poll)
+	saved.ID = r.nextPollID
+	r.nextPollID++
+	if saved.CreatedAt.IsZero() {
+		saved.CreatedAt = time.Now().UTC()
+	}
+	for i := range saved.Options {
+		saved.Options[i].ID = r.nextOptionID
+		saved.Options[i].Position = i
+		r.nextOptionID++
+	}
+	r.polls[saved.ID] = saved
+
+	pollID := saved.ID
+	msg.PollID = &pollID
+	return clonePoll(saved), nil
+}
+
+func (r *messageRepo) GetPollByID(id int) (*models.Poll, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	poll, ok := r.polls[id]
+	if !ok {
+		return nil, repo.ErrPollNotFound
+	}
+	return clonePoll(poll), nil
+}
+
+func (r *messageRepo) ListPollVotes(pollID int) ([]*models.PollVote, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.polls[pollID]; !ok {
+		return nil, repo.ErrPollNotFound
+	}
+	votes := make([]*models.PollVote, 0, len(r.pollVotes[pollID]))
+	for _, vote := range r.pollVotes[pollID] {
+		clone := *vote
+		votes = append(votes, &clone)
+	}
+	return votes, nil
+}
+
+func (r *messageRepo) ReplacePollVotes(pollID int, userID uuid.UUID, optionIDs []int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.polls[pollID]; !ok {
+		return repo.ErrPollNotFound
+	}
+
+	kept := make([]*models.PollVote, 0, len(r.pollVotes[pollID]))
+	for _, vote := range r.pollVotes[pollID] {
+		if vote.UserID != userID {
+			kept = append(kept, vote)
+		}
+	}
+	now := time.Now().UTC()
+	for _, optionID := range optionIDs {
+		kept = append(kept, &models.PollVote{
+			PollID:   pollID,
+			OptionID: optionID,
+			UserID:   userID,
+			VotedAt:  now,
+		})
+	}
+	r.pollVotes[pollID] = kept
+	return nil
+}
+
+func (r *messageRepo) ClosePoll(id int, closedAt time.Time) (*models.Poll, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	poll, ok := r.polls[id]
+	if !ok {
+		return nil, repo.ErrPollNotFound
+	}
+	if poll.ClosedAt != nil {
+		return nil, repo.ErrPollClosed
+	}
+	closed := closedAt
+	poll.ClosedAt = &closed
+	return clonePoll(poll), nil
+}
+
+func clonePoll(poll *models.Poll) *models.Poll {
+	clone := *poll
+	clone.Options = append([]models.PollOption(nil), poll.Options...)
+	if poll.ClosesAt != nil {
+		closesAt := *poll.ClosesAt
+		clone.ClosesAt = &closesAt
+	}
+	if poll.ClosedAt != nil {
+		closedAt := *poll.ClosedAt
+		clone.ClosedAt = &closedAt
+	}
+	return &clone
+}
@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (r *messageRepo) CreateMessageReport(report *models.MessageReport) (*models.MessageReport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	found := false
+	for _, msg := range r.messages {
+		if msg.ID == report.MessageID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, errors.New("message not found")
+	}
+	for _, existing := range r.reports {
+		if existing.MessageID == report.MessageID && existing.ReporterID == report.ReporterID {
+			return nil, repo.ErrReportExists
+		}
+	}
+
+	saved := *report
+	saved.ID = r.nextReportID
+	r.nextReportID++
+	saved.Status = models.MessageReportPending
+	saved.ResolvedBy = uuid.Nil
+	saved.ResolvedAt = nil
+	if saved.CreatedAt.IsZero() {
+		saved.CreatedAt = time.Now()
+	}
+	r.reports[saved.ID] = &saved
+	return cloneMessageReport(&saved), nil
+}
+
+func (r *messageRepo) GetMessageReport(id int) (*models.MessageReport, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	report, ok := r.reports[id]
+	if !ok {
+		return nil, repo.ErrReportNotFound
+	}
+	return cloneMessageReport(report), nil
+}
+
+func (r *messageRepo) ListMessageReports(conversationID int, status models.MessageReportStatus) ([]*models.MessageReport, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*models.MessageReport, 0)
+	for _, report := range r.reports {
+		if report.ConversationID == conversationID && report.Status == status {
+			result = append(result, cloneMessageReport(report))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+func (r *messageRepo) ResolveMessageReport(id int, status models.MessageReportStatus, resolvedBy uuid.UUID, now time.Time) (*models.MessageReport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report, ok := r.reports[id]
+	if !ok {
+		return nil, repo.ErrReportNotFound
+	}
+	if report.Status != models.MessageReportPending {
+		return nil, repo.ErrReportResolved
+	}
+	resolvedAt := now
+	report.Status = status
+	report.ResolvedBy = resolvedBy
+	report.ResolvedAt = &resolvedAt
+	return cloneMessageReport(report), nil
+}
+
+func cloneMessageReport(report *models.MessageReport) *models.MessageReport {
+	if report == nil {
+		return nil
+	}
+	cpy := *report
+	if report.ResolvedAt != nil {
+		resolvedAt := *report.ResolvedAt
+		cpy.ResolvedAt = &resolvedAt
+	}
+	return &cpy
+}
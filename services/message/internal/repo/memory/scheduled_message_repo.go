@@ -0,0 +1,184 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (r *messageRepo) CreateScheduledMessage(msg *models.ScheduledMessage) (*models.ScheduledMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	saved := *msg
+	saved.ID = r.nextScheduledID
+	r.nextScheduledID++
+	if saved.Status == "" {
+		saved.Status = models.ScheduledStatusPending
+	}
+	if saved.CreatedAt.IsZero() {
+		saved.CreatedAt = now
+	}
+	if saved.UpdatedAt.IsZero() {
+		saved.UpdatedAt = now
+	}
+
+	r.scheduled[saved.ID] = &saved
+	return cloneScheduledMessage(&saved), nil
+}
+
+func (r *messageRepo) GetScheduledMessageByID(id int) (*models.ScheduledMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	msg, ok := r.scheduled[id]
+	if !ok {
+		return nil, repo.ErrScheduledMessageNotFound
+	}
+	return cloneScheduledMessage(msg), nil
+}
+
+func (r *messageRepo) ListScheduledMessagesBySender(senderID uuid.UUID, conversationID int) ([]*models.ScheduledMessage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]*models.ScheduledMessage, 0)
+	for _, msg := range r.scheduled {
+		if msg.SenderID != senderID {
+			continue
+		}
+		if conversationID != 0 && msg.ConversationID != conversationID {
+			continue
+		}
+		out = append(out, cloneScheduledMessage(msg))
+	}
+	sortScheduledMessages(out)
+	return out, nil
+}
+
+func (r *messageRepo) CancelScheduledMessage(id int) (*models.ScheduledMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	msg, ok := r.scheduled[id]
+	if !ok {
+		return nil, repo.ErrScheduledMessageNotFound
+	}
+	if msg.Status != models.ScheduledStatusPending {
+		return nil, repo.ErrScheduledMessageNotPending
+	}
+	msg.Status = models.ScheduledStatusCancelled
+	msg.UpdatedAt = time.Now()
+	return cloneScheduledMessage(msg), nil
+}
+
+func (r *messageRepo) ClaimDueScheduledMessages(now time.Time, lease time.Duration, limit int) ([]*models.ScheduledMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	due := make([]*models.ScheduledMessage, 0)
+	for _, msg := range r.scheduled {
+		switch msg.Status {
+		case models.ScheduledStatusPending:
+			if msg.SendAt.After(now) {
+				continue
+			}
+		case models.ScheduledStatusProcessing:
+			if msg.ClaimedAt != nil && msg.ClaimedAt.Add(lease).After(now) {
+				continue
+			}
+		default:
+			continue
+		}
+		due = append(due, msg)
+	}
+	sortScheduledMessages(due)
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+
+	out := make([]*models.ScheduledMessage, 0, len(due))
+	for _, msg := range due {
+		claimedAt := now
+		msg.Status = models.ScheduledStatusProcessing
+		msg.ClaimedAt = &claimedAt
+		msg.UpdatedAt = now
+		out = append(out, cloneScheduledMessage(msg))
+	}
+	return out, nil
+}
+
+func (r *messageRepo) SendScheduledMessage(scheduled *models.ScheduledMessage, msg *models.ChatMessage) (*models.ChatMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.scheduled[scheduled.ID]
+	if !ok {
+		return nil, repo.ErrScheduledMessageNotFound
+	}
+	if !holdsLease(current, scheduled.ClaimedAt) {
+		return nil, repo.ErrScheduledMessageNotPending
+	}
+	saved := r.saveMessageLocked(msg)
+	mid := saved.ID
+	current.Status = models.ScheduledStatusSent
+	current.MessageID = &mid
+	current.LastError = ""
+	current.UpdatedAt = time.Now()
+	return saved, nil
+}
+
+func (r *messageRepo) MarkScheduledMessageFailed(id int, claimedAt time.Time, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	msg, ok := r.scheduled[id]
+	if !ok {
+		return repo.ErrScheduledMessageNotFound
+	}
+	if !holdsLease(msg, &claimedAt) {
+		return repo.ErrScheduledMessageNotPending
+	}
+	msg.Status = models.ScheduledStatusFailed
+	msg.LastError = reason
+	msg.UpdatedAt = time.Now()
+	return nil
+}
+
+// holdsLease : la ligne est toujours en cours d'envoi sous la réclamation claimedAt.
+func holdsLease(msg *models.ScheduledMessage, claimedAt *time.Time) bool {
+	return msg.Status == models.ScheduledStatusProcessing && msg.ClaimedAt != nil && claimedAt != nil && msg.ClaimedAt.Equal(*claimedAt)
+}
+
+func sortScheduledMessages(list []*models.ScheduledMessage) {
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].SendAt.Equal(list[j].SendAt) {
+			return list[i].ID < list[j].ID
+		}
+		return list[i].SendAt.Before(list[j].SendAt)
+	})
+}
+
+func cloneScheduledMessage(msg *models.ScheduledMessage) *models.ScheduledMessage {
+	if msg == nil {
+		return nil
+	}
+	cpy := *msg
+	if msg.ReplyToID != nil {
+		v := *msg.ReplyToID
+		cpy.ReplyToID = &v
+	}
+	if msg.MessageID != nil {
+		v := *msg.MessageID
+		cpy.MessageID = &v
+	}
+	if msg.ClaimedAt != nil {
+		v := *msg.ClaimedAt
+		cpy.ClaimedAt = &v
+	}
+	return &cpy
+}
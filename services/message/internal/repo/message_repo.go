@@ -12,12 +12,56 @@ type MessageRepo interface {
 	BulkSaveMessages(msgs []*models.ChatMessage) ([]*models.ChatMessage, error)
 	GetMessageById(id int) (*models.ChatMessage, error)
 	GetMessagesByConversationID(conversationID int) ([]*models.ChatMessage, error)
+	// GetLastMessageAtBySender : date du dernier message (hors système, supprimés compris) de senderID
+	// dans la conversation ; nil s'il n'en a envoyé aucun.
+	GetLastMessageAtBySender(conversationID int, senderID uuid.UUID) (*time.Time, error)
 	MarkMessageReceivedByID(id int, userID uuid.UUID, receivedAt time.Time) (*models.MessageReceipt, error)
 	GetMessageReceiptByID(id int, userID uuid.UUID) (*models.MessageReceipt, error)
 	UpdateMessageById(id int, content string) (*models.ChatMessage, error)
 	DeleteMessageById(id int) error
+	// PurgeConversationMessages supprime définitivement les messages de la conversation avec leurs
+	// accusés, vues et sondages ; retourne les pièces jointes (non vides) à supprimer du stockage média.
+	PurgeConversationMessages(conversationID int) ([]string, error)
+	// ReferencedAttachments : parmi mediaIDs (media/...), ceux encore cités par un message non supprimé
+	// ou par un message programmé en attente (pièce jointe = media ID ou URL contenant la clé).
+	ReferencedAttachments(mediaIDs []string) ([]string, error)
 
 	SetMessageStatus(id int, status string) error
 	MarkMessageSeenBy(id int, userID uuid.UUID, displayName string) (*models.MessageSeenBy, error)
 	GetSeenByForMessage(id int) ([]*models.MessageSeenBy, error)
+
+	CreateScheduledMessage(msg *models.ScheduledMessage) (*models.ScheduledMessage, error)
+	GetScheduledMessageByID(id int) (*models.ScheduledMessage, error)
+	ListScheduledMessagesBySender(senderID uuid.UUID, conversationID int) ([]*models.ScheduledMessage, error)
+	CancelScheduledMessage(id int) (*models.ScheduledMessage, error)
+	// ClaimDueScheduledMessages passe en 'processing' les messages dus (send_at <= now) ainsi que
+	// ceux dont le bail (claimed_at + lease) a expiré. Une ligne n'est réclamée que par un seul appelant.
+	ClaimDueScheduledMessages(now time.Time, lease time.Duration, limit int) ([]*models.ScheduledMessage, error)
+	// SendScheduledMessage enregistre le message et passe la ligne à 'sent' atomiquement, à condition
+	// que scheduled soit encore sous le bail de sa réclamation (ErrScheduledMessageNotPending sinon).
+	SendScheduledMessage(scheduled *models.ScheduledMessage, msg *models.ChatMessage) (*models.ChatMessage, error)
+	// MarkScheduledMessageFailed n'agit que si la ligne porte encore le bail claimedAt.
+	MarkScheduledMessageFailed(id int, claimedAt time.Time, reason string) error
+
+	// GetLinkPreview lit le cache par URL (ErrLinkPreviewNotFound si absent).
+	GetLinkPreview(url string) (*models.LinkPreview, error)
+	SaveLinkPreview(preview *models.LinkPreview) error
+	SetMessageLinkPreview(messageID int, preview *models.LinkPreview) error
+
+	// CreatePoll insère le sondage et ses options (poll.MessageID doit exister).
+	CreatePoll(poll *models.Poll) (*models.Poll, error)
+	GetPollByID(id int) (*models.Poll, error)
+	ListPollVotes(pollID int) ([]*models.PollVote, error)
+	// ReplacePollVotes remplace atomiquement les votes de userID (optionIDs vide = retrait du vote).
+	ReplacePollVotes(pollID int, userID uuid.UUID, optionIDs []int) error
+	// ClosePoll fixe closed_at ; ErrPollClosed si le sondage est déjà fermé.
+	ClosePoll(id int, closedAt time.Time) (*models.Poll, error)
+
+	// CreateMessageReport : ErrReportExists si reporter_id a déjà signalé ce message.
+	CreateMessageReport(report *models.MessageReport) (*models.MessageReport, error)
+	GetMessageReport(id int) (*models.MessageReport, error)
+	// ListMessageReports : signalements de la conversation au statut donné, plus anciens d'abord.
+	ListMessageReports(conversationID int, status models.MessageReportStatus) ([]*models.MessageReport, error)
+	// ResolveMessageReport clôt un signalement pending (resolved/dismissed) ; ErrReportResolved si déjà traité.
+	ResolveMessageReport(id int, status models.MessageReportStatus, resolvedBy uuid.UUID, now time.Time) (*models.MessageReport, error)
 }
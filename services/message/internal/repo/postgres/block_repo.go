@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+const userBlockColumns = `blocker_id, blocked_id, created_at`
+
+func (r *conversationRepo) BlockUser(blockerID, blockedID uuid.UUID, now time.Time) (*models.UserBlock, error) {
+	// DO UPDATE sans effet plutôt que DO NOTHING : RETURNING renvoie aussi la ligne existante.
+	query := `
+		INSERT INTO user_blocks (blocker_id, blocked_id, created_at)
+		VALUES ($1::uuid, $2::uuid, $3)
+		ON CONFLICT (blocker_id, blocked_id) DO UPDATE SET blocker_id = EXCLUDED.blocker_id
+		RETURNING ` + userBlockColumns
+
+	return scanUserBlock(r.db.QueryRow(query, blockerID.String(), blockedID.String(), now))
+}
+
+func (r *conversationRepo) UnblockUser(blockerID, blockedID uuid.UUID) error {
+	result, err := r.db.Exec(`
+		DELETE FROM user_blocks
+		WHERE blocker_id = $1::uuid
+		  AND blocked_id = $2::uuid
+	`, blockerID.String(), blockedID.String())
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo.ErrBlockNotFound
+	}
+	return nil
+}
+
+func (r *conversationRepo) ListBlockedUsers(blockerID uuid.UUID) ([]*models.UserBlock, error) {
+	rows, err := r.db.Query(`
+		SELECT `+userBlockColumns+`
+		FROM user_blocks
+		WHERE blocker_id = $1::uuid
+		ORDER BY created_at DESC, blocked_id ASC
+	`, blockerID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*models.UserBlock, 0)
+	for rows.Next() {
+		block, err := scanUserBlock(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, block)
+	}
+	return result, rows.Err()
+}
+
+func (r *conversationRepo) HasBlocked(blockerID, blockedID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM user_blocks
+			WHERE blocker_id = $1::uuid
+			  AND blocked_id = $2::uuid
+		)
+	`, blockerID.String(), blockedID.String()).Scan(&exists)
+	return exists, err
+}
+
+func scanUserBlock(row scanner) (*models.UserBlock, error) {
+	var (
+		block        models.UserBlock
+		blockerIDStr string
+		blockedIDStr string
+	)
+	if err := row.Scan(&blockerIDStr, &blockedIDStr, &block.CreatedAt); err != nil {
+		return nil, err
+	}
+	blockerID, err := uuid.Parse(blockerIDStr)
+	if err != nil {
+		return nil, err
+	}
+	blockedID, err := uuid.Parse(blockedIDStr)
+	if err != nil {
+		return nil, err
+	}
+	block.BlockerID = blockerID
+	block.BlockedID = blockedID
+	return &block, nil
+}
@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func (r *conversationRepo) SetMembershipArchived(conversationID int, userID uuid.UUID, archivedAt *time.Time) (*models.ConversationMembership, error) {
+	var archived interface{}
+	if archivedAt != nil {
+		archived = *archivedAt
+	}
+
+	membership, err := scanMembership(r.db.QueryRow(`
+		UPDATE conversations_users
+		SET archived_at = $3
+		WHERE conversation_id = $1
+		  AND user_id = $2::uuid
+		  AND deleted_at IS NULL
+		RETURNING `+membershipColumns, conversationID, userID.String(), archived))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrMembershipNotFound
+		}
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (r *conversationRepo) GetDeletedConversation(id int) (*models.Conversation, []*models.ConversationMembership, error) {
+	conversation, err := scanConversation(r.db.QueryRow(`
+		SELECT `+conversationColumns+`
+		FROM conversations
+		WHERE id = $1
+		  AND deleted_at IS NOT NULL
+	`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, repo.ErrConversationNotFound
+		}
+		return nil, nil, err
+	}
+
+	// DeleteConversation retire les memberships juste après la conversation : deleted_at >= celui de la conversation.
+	rows, err := r.db.Query(`
+		SELECT `+membershipColumns+`
+		FROM conversations_users
+		WHERE conversation_id = $1
+		  AND deleted_at >= $2
+		ORDER BY role DESC, id ASC
+	`, id, *conversation.DeletedAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	memberships := make([]*models.ConversationMembership, 0)
+	for rows.Next() {
+		membership, scanErr := scanMembership(rows)
+		if scanErr != nil {
+			return nil, nil, scanErr
+		}
+		memberships = append(memberships, membership)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return conversation, memberships, nil
+}
+
+func (r *conversationRepo) RestoreConversation(id int, deletedAt time.Time) (*models.Conversation, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	conversation, err := scanConversation(tx.QueryRow(`
+		UPDATE conversations
+		SET deleted_at = NULL, updated_at = NOW()
+		WHERE id = $1
+		  AND deleted_at = $2
+		RETURNING `+conversationColumns, id, deletedAt))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrConversationNotFound
+		}
+		// Une nouvelle conversation directe a été créée pour la même paire depuis la suppression.
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, repo.ErrDirectConversationExists
+		}
+		return nil, err
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE conversations_users
+		SET deleted_at = NULL
+		WHERE conversation_id = $1
+		  AND deleted_at >= $2
+	`, id, deletedAt); err != nil {
+		return nil, translateMembershipInsertError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return conversation, nil
+}
+
+func (r *conversationRepo) ListConversationsDeletedBefore(before time.Time, limit int) ([]int, error) {
+	rows, err := r.db.Query(`
+		SELECT id
+		FROM conversations
+		WHERE deleted_at IS NOT NULL
+		  AND deleted_at < $1
+		ORDER BY deleted_at ASC
+		LIMIT $2
+	`, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *conversationRepo) PurgeConversation(id int, before time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// FOR UPDATE : une restauration concurrente attend la purge (et échoue) ou la précède (et la purge est annulée).
+	var deletedAt sql.NullTime
+	if err := tx.QueryRow(`SELECT deleted_at FROM conversations WHERE id = $1 FOR UPDATE`, id).Scan(&deletedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repo.ErrConversationNotFound
+		}
+		return err
+	}
+	if !deletedAt.Valid || !deletedAt.Time.Before(before) {
+		return repo.ErrConversationNotFound
+	}
+
+	if _, err := tx.Exec(`DELETE FROM conversations_users WHERE conversation_id = $1`, id); err != nil {
+		return err
+	}
+	// Épingles, invitations, demandes d'adhésion, messages programmés et sondages suivent par ON DELETE CASCADE.
+	if _, err := tx.Exec(`DELETE FROM conversations WHERE id = $1`, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
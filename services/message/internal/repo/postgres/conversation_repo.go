@@ -15,6 +15,10 @@ type conversationRepo struct {
 	db *sql.DB
 }
 
+const membershipColumns = `id, created_at, deleted_at, user_id, conversation_id, role, archived_at`
+
+const conversationColumns = `id, kind, COALESCE(direct_key, ''), name, COALESCE(avatar_url, ''), description, COALESCE(created_by::text, ''), created_at, updated_at, deleted_at, only_admins_can_post, members_can_add, members_can_pin, slow_mode_seconds, join_requests_enabled`
+
 func NewConversationRepo(db *sql.DB) repo.ConversationRepo {
 	return &conversationRepo{db: db}
 }
@@ -23,8 +27,7 @@ func (r *conversationRepo) CreateConversation(conversation *models.Conversation)
 	query := `
 		INSERT INTO conversations (name, avatar_url, created_by, created_at, updated_at)
 		VALUES ($1, NULLIF($2, ''), $3::uuid, $4, $5)
-		RETURNING id, name, COALESCE(avatar_url, ''), COALESCE(created_by::text, ''), created_at, updated_at, deleted_at
-	`
+		RETURNING ` + conversationColumns
 
 	now := time.Now()
 	if conversation.CreatedAt.IsZero() {
@@ -53,7 +56,7 @@ func (r *conversationRepo) CreateConversation(conversation *models.Conversation)
 
 func (r *conversationRepo) GetConversationByID(id int) (*models.Conversation, error) {
 	query := `
-		SELECT id, name, COALESCE(avatar_url, ''), COALESCE(created_by::text, ''), created_at, updated_at, deleted_at
+		SELECT ` + conversationColumns + `
 		FROM conversations
 		WHERE id = $1
 		  AND deleted_at IS NULL
@@ -70,19 +73,21 @@ func (r *conversationRepo) GetConversationByID(id int) (*models.Conversation, er
 	return conversation, nil
 }
 
-func (r *conversationRepo) ListConversationsByUser(userID uuid.UUID) ([]*models.Conversation, error) {
+func (r *conversationRepo) ListConversationsByUser(userID uuid.UUID, archived bool) ([]*models.Conversation, error) {
 	query := `
-		SELECT c.id, c.name, COALESCE(c.avatar_url, ''), COALESCE(c.created_by::text, ''), c.created_at, c.updated_at, c.deleted_at
+		SELECT c.id, c.kind, COALESCE(c.direct_key, ''), c.name, COALESCE(c.avatar_url, ''), c.description, COALESCE(c.created_by::text, ''), c.created_at, c.updated_at, c.deleted_at,
+		       c.only_admins_can_post, c.members_can_add, c.members_can_pin, c.slow_mode_seconds, c.join_requests_enabled
 		FROM conversations c
 		INNER JOIN conversations_users cu
 		  ON cu.conversation_id = c.id
 		WHERE cu.user_id = $1::uuid
 		  AND cu.deleted_at IS NULL
+		  AND (cu.archived_at IS NOT NULL) = $2
 		  AND c.deleted_at IS NULL
 		ORDER BY c.updated_at DESC, c.id DESC
 	`
 
-	rows, err := r.db.Query(query, userID.String())
+	rows, err := r.db.Query(query, userID.String(), archived)
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +109,82 @@ func (r *conversationRepo) ListConversationsByUser(userID uuid.UUID) ([]*models.
 	return conversations, nil
 }
 
+func (r *conversationRepo) UpdateConversation(conversation *models.Conversation) (*models.Conversation, error) {
+	query := `
+		UPDATE conversations
+		SET name = $2, avatar_url = NULLIF($3, ''), description = $4, updated_at = NOW()
+		WHERE id = $1
+		  AND deleted_at IS NULL
+		RETURNING ` + conversationColumns
+
+	updated, err := scanConversation(r.db.QueryRow(
+		query,
+		conversation.ID,
+		conversation.Name,
+		conversation.AvatarURL,
+		conversation.Description,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrConversationNotFound
+		}
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (r *conversationRepo) ReferencedAvatars(mediaIDs []string) ([]string, error) {
+	if len(mediaIDs) == 0 {
+		return nil, nil
+	}
+	// Expression identique à l'index de la migration 025 (models.AttachmentMediaID).
+	rows, err := r.db.Query(`
+		SELECT DISTINCT substring(avatar_url FROM 'media/[0-9]+_.*$')
+		FROM conversations
+		WHERE substring(avatar_url FROM 'media/[0-9]+_.*$') = ANY($1)
+	`, pq.Array(mediaIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	referenced := make([]string, 0)
+	for rows.Next() {
+		var ref string
+		if err := rows.Scan(&ref); err != nil {
+			return nil, err
+		}
+		referenced = append(referenced, ref)
+	}
+	return referenced, rows.Err()
+}
+
+func (r *conversationRepo) UpdateConversationPermissions(id int, permissions models.ConversationPermissions) (*models.Conversation, error) {
+	query := `
+		UPDATE conversations
+		SET only_admins_can_post = $2, members_can_add = $3, members_can_pin = $4, slow_mode_seconds = $5, join_requests_enabled = $6, updated_at = NOW()
+		WHERE id = $1
+		  AND deleted_at IS NULL
+		RETURNING ` + conversationColumns
+
+	updated, err := scanConversation(r.db.QueryRow(
+		query,
+		id,
+		permissions.OnlyAdminsCanPost,
+		permissions.MembersCanAddMembers,
+		permissions.MembersCanPin,
+		permissions.SlowModeSeconds,
+		permissions.JoinRequestsEnabled,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrConversationNotFound
+		}
+		return nil, err
+	}
+	return updated, nil
+}
+
 func (r *conversationRepo) SoftDeleteConversation(id int) error {
 	query := `
 		UPDATE conversations
@@ -128,12 +209,71 @@ func (r *conversationRepo) SoftDeleteConversation(id int) error {
 	return nil
 }
 
+func (r *conversationRepo) GetDirectConversation(directKey string) (*models.Conversation, error) {
+	query := `
+		SELECT ` + conversationColumns + `
+		FROM conversations
+		WHERE direct_key = $1
+		  AND deleted_at IS NULL
+	`
+
+	conversation, err := scanConversation(r.db.QueryRow(query, directKey))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrConversationNotFound
+		}
+		return nil, err
+	}
+	return conversation, nil
+}
+
+func (r *conversationRepo) CreateDirectConversation(conversation *models.Conversation, memberIDs []uuid.UUID) (*models.Conversation, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// L'index unique partiel uq_conversations_direct_key_active arbitre les créations concurrentes.
+	query := `
+		INSERT INTO conversations (kind, direct_key, name, created_by, created_at, updated_at)
+		VALUES ($1, $2, '', $3::uuid, NOW(), NOW())
+		ON CONFLICT (direct_key) WHERE direct_key IS NOT NULL AND deleted_at IS NULL DO NOTHING
+		RETURNING ` + conversationColumns
+
+	saved, err := scanConversation(tx.QueryRow(
+		query,
+		string(models.ConversationKindDirect),
+		conversation.DirectKey,
+		nullUUID(conversation.CreatedBy),
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrDirectConversationExists
+		}
+		return nil, err
+	}
+
+	for _, userID := range memberIDs {
+		if _, err := tx.Exec(`
+			INSERT INTO conversations_users (created_at, user_id, conversation_id, role)
+			VALUES (NOW(), $1::uuid, $2, $3)
+		`, userID.String(), saved.ID, int(models.ConversationRoleMember)); err != nil {
+			return nil, translateMembershipInsertError(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
 func (r *conversationRepo) CreateMembership(membership *models.ConversationMembership) (*models.ConversationMembership, error) {
 	query := `
 		INSERT INTO conversations_users (created_at, user_id, conversation_id, role)
 		VALUES ($1, $2::uuid, $3, $4)
-		RETURNING id, created_at, deleted_at, user_id, conversation_id, role
-	`
+		RETURNING ` + membershipColumns
 
 	createdAt := membership.CreatedAt
 	if createdAt.IsZero() {
@@ -150,7 +290,7 @@ func (r *conversationRepo) CreateMembership(membership *models.ConversationMembe
 
 func (r *conversationRepo) GetMembership(conversationID int, userID uuid.UUID) (*models.ConversationMembership, error) {
 	query := `
-		SELECT id, created_at, deleted_at, user_id, conversation_id, role
+		SELECT ` + membershipColumns + `
 		FROM conversations_users
 		WHERE conversation_id = $1
 		  AND user_id = $2::uuid
@@ -175,7 +315,7 @@ func (r *conversationRepo) ListMemberships(conversationID int) ([]*models.Conver
 	}
 
 	query := `
-		SELECT id, created_at, deleted_at, user_id, conversation_id, role
+		SELECT ` + membershipColumns + `
 		FROM conversations_users
 		WHERE conversation_id = $1
 		  AND deleted_at IS NULL
@@ -211,8 +351,7 @@ func (r *conversationRepo) UpdateMembershipRole(conversationID int, userID uuid.
 		WHERE conversation_id = $2
 		  AND user_id = $3::uuid
 		  AND deleted_at IS NULL
-		RETURNING id, created_at, deleted_at, user_id, conversation_id, role
-	`
+		RETURNING ` + membershipColumns
 
 	updated, err := scanMembership(r.db.QueryRow(query, int(role), conversationID, userID.String()))
 	if err != nil {
@@ -291,12 +430,20 @@ func scanConversation(row scanner) (*models.Conversation, error) {
 
 	if err := row.Scan(
 		&conversation.ID,
+		&conversation.Kind,
+		&conversation.DirectKey,
 		&conversation.Name,
 		&conversation.AvatarURL,
+		&conversation.Description,
 		&createdByStr,
 		&conversation.CreatedAt,
 		&conversation.UpdatedAt,
 		&deletedAt,
+		&conversation.Permissions.OnlyAdminsCanPost,
+		&conversation.Permissions.MembersCanAddMembers,
+		&conversation.Permissions.MembersCanPin,
+		&conversation.Permissions.SlowModeSeconds,
+		&conversation.Permissions.JoinRequestsEnabled,
 	); err != nil {
 		return nil, err
 	}
@@ -321,6 +468,7 @@ func scanMembership(row scanner) (*models.ConversationMembership, error) {
 		role       int
 		userIDStr  string
 		deletedAt  sql.NullTime
+		archivedAt sql.NullTime
 	)
 
 	if err := row.Scan(
@@ -330,6 +478,7 @@ func scanMembership(row scanner) (*models.ConversationMembership, error) {
 		&userIDStr,
 		&membership.ConversationID,
 		&role,
+		&archivedAt,
 	); err != nil {
 		return nil, err
 	}
@@ -343,6 +492,9 @@ func scanMembership(row scanner) (*models.ConversationMembership, error) {
 	if deletedAt.Valid {
 		membership.DeletedAt = &deletedAt.Time
 	}
+	if archivedAt.Valid {
+		membership.ArchivedAt = &archivedAt.Time
+	}
 
 	return &membership, nil
 }
@@ -0,0 +1,267 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const inviteColumns = `id, token, conversation_id, created_by, expires_at, max_uses, use_count, revoked_at, created_at`
+
+func (r *conversationRepo) CreateInvite(invite *models.ConversationInvite) (*models.ConversationInvite, error) {
+	query := `
+		INSERT INTO conversation_invites (token, conversation_id, created_by, expires_at, max_uses, created_at)
+		SELECT $1, c.id, $3::uuid, $4, $5, NOW()
+		FROM conversations c
+		WHERE c.id = $2
+		  AND c.deleted_at IS NULL
+		RETURNING ` + inviteColumns
+
+	var expiresAt interface{}
+	if invite.ExpiresAt != nil {
+		expiresAt = *invite.ExpiresAt
+	}
+	saved, err := scanInvite(r.db.QueryRow(
+		query,
+		invite.Token,
+		invite.ConversationID,
+		invite.CreatedBy.String(),
+		expiresAt,
+		invite.MaxUses,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrConversationNotFound
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return nil, repo.ErrConversationNotFound
+		}
+		return nil, err
+	}
+	return saved, nil
+}
+
+func (r *conversationRepo) GetInviteByID(id int) (*models.ConversationInvite, error) {
+	query := `SELECT ` + inviteColumns + ` FROM conversation_invites WHERE id = $1`
+
+	invite, err := scanInvite(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrInviteNotFound
+		}
+		return nil, err
+	}
+	return invite, nil
+}
+
+func (r *conversationRepo) ListInvites(conversationID int) ([]*models.ConversationInvite, error) {
+	query := `
+		SELECT ` + inviteColumns + `
+		FROM conversation_invites
+		WHERE conversation_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+	rows, err := r.db.Query(query, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*models.ConversationInvite, 0)
+	for rows.Next() {
+		invite, err := scanInvite(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, invite)
+	}
+	return result, rows.Err()
+}
+
+func (r *conversationRepo) RevokeInvite(id int, revokedAt time.Time) (*models.ConversationInvite, error) {
+	// COALESCE : une révocation déjà faite garde sa date d'origine.
+	query := `
+		UPDATE conversation_invites
+		SET revoked_at = COALESCE(revoked_at, $2)
+		WHERE id = $1
+		RETURNING ` + inviteColumns
+
+	invite, err := scanInvite(r.db.QueryRow(query, id, revokedAt))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrInviteNotFound
+		}
+		return nil, err
+	}
+	return invite, nil
+}
+
+func (r *conversationRepo) RedeemInvite(token string, userID uuid.UUID, now time.Time) (*models.ConversationInvite, *models.ConversationMembership, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	// FOR UPDATE : sérialise les utilisations concurrentes d'un même jeton (max_uses).
+	invite, err := scanInvite(tx.QueryRow(`
+		SELECT `+inviteColumns+`
+		FROM conversation_invites
+		WHERE token = $1
+		FOR UPDATE
+	`, token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, repo.ErrInviteNotFound
+		}
+		return nil, nil, err
+	}
+	if err := repo.CheckInviteUsable(invite, now); err != nil {
+		return nil, nil, err
+	}
+
+	var deletedAt sql.NullTime
+	if err := tx.QueryRow(`SELECT deleted_at FROM conversations WHERE id = $1`, invite.ConversationID).Scan(&deletedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, repo.ErrConversationNotFound
+		}
+		return nil, nil, err
+	}
+	if deletedAt.Valid {
+		return nil, nil, repo.ErrConversationNotFound
+	}
+
+	var banned bool
+	if err := tx.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM conversation_bans
+			WHERE conversation_id = $1
+			  AND user_id = $2::uuid
+		)
+	`, invite.ConversationID, userID.String()).Scan(&banned); err != nil {
+		return nil, nil, err
+	}
+	if banned {
+		return nil, nil, repo.ErrUserBanned
+	}
+
+	var exists bool
+	if err := tx.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM conversations_users
+			WHERE conversation_id = $1
+			  AND user_id = $2::uuid
+			  AND deleted_at IS NULL
+		)
+	`, invite.ConversationID, userID.String()).Scan(&exists); err != nil {
+		return nil, nil, err
+	}
+	if exists {
+		return nil, nil, repo.ErrMembershipAlreadyExists
+	}
+
+	membership, err := scanMembership(tx.QueryRow(`
+		INSERT INTO conversations_users (created_at, user_id, conversation_id, role)
+		VALUES ($1, $2::uuid, $3, $4)
+		RETURNING `+membershipColumns, now, userID.String(), invite.ConversationID, int(models.ConversationRoleMember)))
+	if err != nil {
+		return nil, nil, translateMembershipInsertError(err)
+	}
+
+	if err := tx.QueryRow(`
+		UPDATE conversation_invites
+		SET use_count = use_count + 1
+		WHERE id = $1
+		RETURNING use_count
+	`, invite.ID).Scan(&invite.UseCount); err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := tx.Exec(`
+		INSERT INTO conversation_invite_uses (invite_id, user_id, used_at)
+		VALUES ($1, $2::uuid, $3)
+	`, invite.ID, userID.String(), now); err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+	return invite, membership, nil
+}
+
+func (r *conversationRepo) ListInviteUses(inviteID int) ([]*models.ConversationInviteUse, error) {
+	query := `
+		SELECT id, invite_id, user_id, used_at
+		FROM conversation_invite_uses
+		WHERE invite_id = $1
+		ORDER BY used_at DESC, id DESC
+	`
+	rows, err := r.db.Query(query, inviteID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*models.ConversationInviteUse, 0)
+	for rows.Next() {
+		var (
+			use       models.ConversationInviteUse
+			userIDStr string
+		)
+		if err := rows.Scan(&use.ID, &use.InviteID, &userIDStr, &use.UsedAt); err != nil {
+			return nil, err
+		}
+		parsed, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return nil, err
+		}
+		use.UserID = parsed
+		result = append(result, &use)
+	}
+	return result, rows.Err()
+}
+
+func scanInvite(row scanner) (*models.ConversationInvite, error) {
+	var (
+		invite       models.ConversationInvite
+		createdByStr string
+		expiresAt    sql.NullTime
+		revokedAt    sql.NullTime
+	)
+
+	if err := row.Scan(
+		&invite.ID,
+		&invite.Token,
+		&invite.ConversationID,
+		&createdByStr,
+		&expiresAt,
+		&invite.MaxUses,
+		&invite.UseCount,
+		&revokedAt,
+		&invite.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	parsed, err := uuid.Parse(createdByStr)
+	if err != nil {
+		return nil, err
+	}
+	invite.CreatedBy = parsed
+	if expiresAt.Valid {
+		t := expiresAt.Time
+		invite.ExpiresAt = &t
+	}
+	if revokedAt.Valid {
+		t := revokedAt.Time
+		invite.RevokedAt = &t
+	}
+	return &invite, nil
+}
@@ -0,0 +1,216 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const joinRequestColumns = `id, conversation_id, user_id, message, status, COALESCE(decided_by::text, ''), decided_at, created_at`
+
+func (r *conversationRepo) CreateJoinRequest(request *models.ConversationJoinRequest) (*models.ConversationJoinRequest, error) {
+	query := `
+		INSERT INTO conversation_join_requests (conversation_id, user_id, message, status, created_at)
+		SELECT c.id, $2::uuid, $3, 'pending', NOW()
+		FROM conversations c
+		WHERE c.id = $1
+		  AND c.deleted_at IS NULL
+		RETURNING ` + joinRequestColumns
+
+	saved, err := scanJoinRequest(r.db.QueryRow(query, request.ConversationID, request.UserID.String(), request.Message))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrConversationNotFound
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case "23505":
+				return nil, repo.ErrJoinRequestExists
+			case "23503":
+				return nil, repo.ErrConversationNotFound
+			}
+		}
+		return nil, err
+	}
+	return saved, nil
+}
+
+func (r *conversationRepo) GetJoinRequest(id int) (*models.ConversationJoinRequest, error) {
+	query := `SELECT ` + joinRequestColumns + ` FROM conversation_join_requests WHERE id = $1`
+
+	request, err := scanJoinRequest(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrJoinRequestNotFound
+		}
+		return nil, err
+	}
+	return request, nil
+}
+
+func (r *conversationRepo) GetLatestJoinRequest(conversationID int, userID uuid.UUID) (*models.ConversationJoinRequest, error) {
+	query := `
+		SELECT ` + joinRequestColumns + `
+		FROM conversation_join_requests
+		WHERE conversation_id = $1
+		  AND user_id = $2::uuid
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+	request, err := scanJoinRequest(r.db.QueryRow(query, conversationID, userID.String()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrJoinRequestNotFound
+		}
+		return nil, err
+	}
+	return request, nil
+}
+
+func (r *conversationRepo) ListJoinRequests(conversationID int, status models.JoinRequestStatus) ([]*models.ConversationJoinRequest, error) {
+	query := `
+		SELECT ` + joinRequestColumns + `
+		FROM conversation_join_requests
+		WHERE conversation_id = $1
+		  AND status = $2
+		ORDER BY created_at ASC, id ASC
+	`
+	rows, err := r.db.Query(query, conversationID, string(status))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*models.ConversationJoinRequest, 0)
+	for rows.Next() {
+		request, err := scanJoinRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, request)
+	}
+	return result, rows.Err()
+}
+
+func (r *conversationRepo) DecideJoinRequest(id int, status models.JoinRequestStatus, decidedBy uuid.UUID, now time.Time) (*models.ConversationJoinRequest, *models.ConversationMembership, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	// FOR UPDATE : deux admins qui décident en même temps ne traitent la demande qu'une fois.
+	request, err := scanJoinRequest(tx.QueryRow(`
+		SELECT `+joinRequestColumns+`
+		FROM conversation_join_requests
+		WHERE id = $1
+		FOR UPDATE
+	`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, repo.ErrJoinRequestNotFound
+		}
+		return nil, nil, err
+	}
+	if request.Status != models.JoinRequestPending {
+		return nil, nil, repo.ErrJoinRequestDecided
+	}
+
+	var membership *models.ConversationMembership
+	if status == models.JoinRequestApproved {
+		var deletedAt sql.NullTime
+		if err := tx.QueryRow(`SELECT deleted_at FROM conversations WHERE id = $1`, request.ConversationID).Scan(&deletedAt); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, nil, repo.ErrConversationNotFound
+			}
+			return nil, nil, err
+		}
+		if deletedAt.Valid {
+			return nil, nil, repo.ErrConversationNotFound
+		}
+
+		// Déjà membre entre-temps (invitation, ajout direct) : la demande est simplement clôturée.
+		membership, err = scanMembership(tx.QueryRow(`
+			SELECT `+membershipColumns+`
+			FROM conversations_users
+			WHERE conversation_id = $1
+			  AND user_id = $2::uuid
+			  AND deleted_at IS NULL
+			ORDER BY id DESC
+			LIMIT 1
+		`, request.ConversationID, request.UserID.String()))
+		if errors.Is(err, sql.ErrNoRows) {
+			membership, err = scanMembership(tx.QueryRow(`
+				INSERT INTO conversations_users (created_at, user_id, conversation_id, role)
+				VALUES ($1, $2::uuid, $3, $4)
+				RETURNING `+membershipColumns, now, request.UserID.String(), request.ConversationID, int(models.ConversationRoleMember)))
+			if err != nil {
+				return nil, nil, translateMembershipInsertError(err)
+			}
+		} else if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	request, err = scanJoinRequest(tx.QueryRow(`
+		UPDATE conversation_join_requests
+		SET status = $2, decided_by = $3::uuid, decided_at = $4
+		WHERE id = $1
+		RETURNING `+joinRequestColumns, id, string(status), decidedBy.String(), now))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+	return request, membership, nil
+}
+
+func scanJoinRequest(row scanner) (*models.ConversationJoinRequest, error) {
+	var (
+		request      models.ConversationJoinRequest
+		userIDStr    string
+		status       string
+		decidedByStr string
+		decidedAt    sql.NullTime
+	)
+
+	if err := row.Scan(
+		&request.ID,
+		&request.ConversationID,
+		&userIDStr,
+		&request.Message,
+		&status,
+		&decidedByStr,
+		&decidedAt,
+		&request.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	parsed, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, err
+	}
+	request.UserID = parsed
+	request.Status = models.JoinRequestStatus(status)
+	if decidedByStr != "" {
+		decidedBy, err := uuid.Parse(decidedByStr)
+		if err != nil {
+			return nil, err
+		}
+		request.DecidedBy = decidedBy
+	}
+	if decidedAt.Valid {
+		t := decidedAt.Time
+		request.DecidedAt = &t
+	}
+	return &request, nil
+}
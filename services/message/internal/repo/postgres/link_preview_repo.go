@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+)
+
+func (r *messageRepo) GetLinkPreview(url string) (*models.LinkPreview, error) {
+	query := `
+		SELECT url, title, description, image_url, site_name, fetched_at
+		FROM link_previews
+		WHERE url = $1
+	`
+
+	var preview models.LinkPreview
+	err := r.db.QueryRow(query, url).Scan(
+		&preview.URL, &preview.Title, &preview.Description, &preview.ImageURL, &preview.SiteName, &preview.FetchedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrLinkPreviewNotFound
+		}
+		return nil, err
+	}
+	return &preview, nil
+}
+
+func (r *messageRepo) SaveLinkPreview(preview *models.LinkPreview) error {
+	query := `
+		INSERT INTO link_previews (url, title, description, image_url, site_name, fetched_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (url) DO UPDATE
+		SET title = EXCLUDED.title,
+		    description = EXCLUDED.description,
+		    image_url = EXCLUDED.image_url,
+		    site_name = EXCLUDED.site_name,
+		    fetched_at = EXCLUDED.fetched_at
+	`
+	_, err := r.db.Exec(query,
+		preview.URL, preview.Title, preview.Description, preview.ImageURL, preview.SiteName, preview.FetchedAt,
+	)
+	return err
+}
+
+func (r *messageRepo) SetMessageLinkPreview(messageID int, preview *models.LinkPreview) error {
+	data, err := json.Marshal(preview)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE messages
+		SET link_preview = $1::jsonb
+		WHERE id = $2
+		  AND deleted_at IS NULL
+	`
+	result, err := r.db.Exec(query, string(data), messageID)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return errors.New("message not found")
+	}
+	return nil
+}
+
+// decodeLinkPreview lit la colonne messages.link_preview (NULL tant que l'unfurl n'a rien trouvé).
+func decodeLinkPreview(raw []byte) (*models.LinkPreview, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var preview models.LinkPreview
+	if err := json.Unmarshal(raw, &preview); err != nil {
+		return nil, err
+	}
+	return &preview, nil
+}
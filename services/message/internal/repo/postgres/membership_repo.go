@@ -0,0 +1,224 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (r *conversationRepo) TransferOwnership(conversationID int, fromUserID, toUserID uuid.UUID) (*models.ConversationMembership, *models.ConversationMembership, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	// FOR UPDATE sur les deux lignes : un changement de rôle concurrent attend la fin du transfert.
+	rows, err := tx.Query(`
+		SELECT `+membershipColumns+`
+		FROM conversations_users
+		WHERE conversation_id = $1
+		  AND user_id IN ($2::uuid, $3::uuid)
+		  AND deleted_at IS NULL
+		ORDER BY id
+		FOR UPDATE
+	`, conversationID, fromUserID.String(), toUserID.String())
+	if err != nil {
+		return nil, nil, err
+	}
+	var from, to *models.ConversationMembership
+	for rows.Next() {
+		membership, scanErr := scanMembership(rows)
+		if scanErr != nil {
+			rows.Close()
+			return nil, nil, scanErr
+		}
+		switch membership.UserID {
+		case fromUserID:
+			from = membership
+		case toUserID:
+			to = membership
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if from == nil || to == nil {
+		return nil, nil, repo.ErrMembershipNotFound
+	}
+	if from.Role != models.ConversationRoleOwner {
+		return nil, nil, repo.ErrNotOwner
+	}
+
+	updateRole := `
+		UPDATE conversations_users
+		SET role = $2
+		WHERE id = $1
+		RETURNING ` + membershipColumns
+	to, err = scanMembership(tx.QueryRow(updateRole, to.ID, int(models.ConversationRoleOwner)))
+	if err != nil {
+		return nil, nil, err
+	}
+	from, err = scanMembership(tx.QueryRow(updateRole, from.ID, int(models.ConversationRoleAdmin)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+	return from, to, nil
+}
+
+func (r *conversationRepo) AddMemberships(conversationID int, userIDs []uuid.UUID, role models.ConversationRole) ([]repo.MembershipResult, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockActiveConversation(tx, conversationID); err != nil {
+		return nil, err
+	}
+
+	// ON CONFLICT sur l'index partiel uq_conversations_users_active : un membre actif ne fait pas échouer le lot.
+	now := time.Now()
+	results := make([]repo.MembershipResult, 0, len(userIDs))
+	for _, userID := range userIDs {
+		membership, err := scanMembership(tx.QueryRow(`
+			INSERT INTO conversations_users (created_at, user_id, conversation_id, role)
+			VALUES ($1, $2::uuid, $3, $4)
+			ON CONFLICT (conversation_id, user_id) WHERE deleted_at IS NULL DO NOTHING
+			RETURNING `+membershipColumns, now, userID.String(), conversationID, int(role)))
+		if errors.Is(err, sql.ErrNoRows) {
+			results = append(results, repo.MembershipResult{UserID: userID, Err: repo.ErrMembershipAlreadyExists})
+			continue
+		}
+		if err != nil {
+			return nil, translateMembershipInsertError(err)
+		}
+		results = append(results, repo.MembershipResult{UserID: userID, Membership: membership})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *conversationRepo) RemoveMemberships(conversationID int, userIDs []uuid.UUID, check repo.MembershipCheck) ([]repo.MembershipResult, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockActiveConversation(tx, conversationID); err != nil {
+		return nil, err
+	}
+
+	results := make([]repo.MembershipResult, 0, len(userIDs))
+	for _, userID := range userIDs {
+		membership, err := scanMembership(tx.QueryRow(`
+			SELECT `+membershipColumns+`
+			FROM conversations_users
+			WHERE conversation_id = $1
+			  AND user_id = $2::uuid
+			  AND deleted_at IS NULL
+			FOR UPDATE
+		`, conversationID, userID.String()))
+		if errors.Is(err, sql.ErrNoRows) {
+			results = append(results, repo.MembershipResult{UserID: userID, Err: repo.ErrMembershipNotFound})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if check != nil {
+			if err := check(membership); err != nil {
+				results = append(results, repo.MembershipResult{UserID: userID, Err: err})
+				continue
+			}
+		}
+
+		membership, err = scanMembership(tx.QueryRow(`
+			UPDATE conversations_users
+			SET deleted_at = NOW()
+			WHERE id = $1
+			RETURNING `+membershipColumns, membership.ID))
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, repo.MembershipResult{UserID: userID, Membership: membership})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// lockActiveConversation verrouille la conversation pour sérialiser les opérations groupées sur ses membres.
+func (r *conversationRepo) ClaimPostSlot(conversationID int, userID uuid.UUID, now time.Time, interval time.Duration) (*time.Time, bool, error) {
+	var id int
+	var last sql.NullTime
+	err := r.db.QueryRow(`
+		SELECT id, last_posted_at
+		FROM conversations_users
+		WHERE conversation_id = $1
+		  AND user_id = $2::uuid
+		  AND deleted_at IS NULL
+		ORDER BY id DESC
+		LIMIT 1
+	`, conversationID, userID.String()).Scan(&id, &last)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, repo.ErrMembershipNotFound
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	// UPDATE conditionnel : de deux envois simultanés, un seul trouve l'ancien last_posted_at.
+	result, err := r.db.Exec(`
+		UPDATE conversations_users
+		SET last_posted_at = $2
+		WHERE id = $1
+		  AND (last_posted_at IS NULL OR last_posted_at <= $3)
+	`, id, now, now.Add(-interval))
+	if err != nil {
+		return nil, false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, err
+	}
+	if affected == 1 {
+		return nil, true, nil
+	}
+	if err := r.db.QueryRow(`SELECT last_posted_at FROM conversations_users WHERE id = $1`, id).Scan(&last); err != nil {
+		return nil, false, err
+	}
+	if !last.Valid {
+		return nil, false, nil
+	}
+	return &last.Time, false, nil
+}
+
+func lockActiveConversation(tx *sql.Tx, conversationID int) error {
+	var deletedAt sql.NullTime
+	if err := tx.QueryRow(`SELECT deleted_at FROM conversations WHERE id = $1 FOR UPDATE`, conversationID).Scan(&deletedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repo.ErrConversationNotFound
+		}
+		return err
+	}
+	if deletedAt.Valid {
+		return repo.ErrConversationNotFound
+	}
+	return nil
+}
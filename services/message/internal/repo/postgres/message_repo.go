@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -12,6 +13,7 @@ import (
 	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
 	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type messageRepo struct {
@@ -23,9 +25,18 @@ func NewMessageRepo(db *sql.DB) repo.MessageRepo {
 }
 
 func (r *messageRepo) SaveMessage(msg *models.ChatMessage) (*models.ChatMessage, error) {
+	return insertMessage(r.db, msg)
+}
+
+// rowQuerier : *sql.DB ou *sql.Tx.
+type rowQuerier interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+func insertMessage(q rowQuerier, msg *models.ChatMessage) (*models.ChatMessage, error) {
 	query := `
-		INSERT INTO messages (sender_id, content, conversation_id, attachment, reply_to_id, status, forward_from_id, created_at, updated_at)
-		VALUES ($1::uuid, $2, $3, $4, $5, COALESCE(NULLIF($6, ''), 'sent'), $7, $8, $9)
+		INSERT INTO messages (sender_id, content, conversation_id, attachment, reply_to_id, status, forward_from_id, created_at, updated_at, mentions, kind, system_event, attachment_info)
+		VALUES ($1::uuid, $2, $3, $4, $5, COALESCE(NULLIF($6, ''), 'sent'), $7, $8, $9, $10::uuid[], $11, $12::jsonb, $13::jsonb)
 		RETURNING id, created_at
 	`
 
@@ -48,14 +59,23 @@ func (r *messageRepo) SaveMessage(msg *models.ChatMessage) (*models.ChatMessage,
 	if msg.ForwardFromID != nil {
 		forwardFromID = *msg.ForwardFromID
 	}
+	kind := messageKind(msg.Kind)
+	systemEvent, err := encodeSystemEvent(msg.System)
+	if err != nil {
+		return nil, err
+	}
+	attachmentInfo, err := encodeAttachmentInfo(msg.AttachmentInfo)
+	if err != nil {
+		return nil, err
+	}
 
 	var id int
 	var createdAt time.Time
-	err := r.db.QueryRow(
+	err = q.QueryRow(
 		query,
 		msg.SenderID.String(), msg.Content, msg.ConversationID, nullString(msg.Attachment),
 		replyToID, status, forwardFromID,
-		msg.CreatedAt, msg.UpdatedAt,
+		msg.CreatedAt, msg.UpdatedAt, uuidArray(msg.Mentions), string(kind), systemEvent, attachmentInfo,
 	).Scan(&id, &createdAt)
 	if err != nil {
 		return nil, err
@@ -67,6 +87,7 @@ func (r *messageRepo) SaveMessage(msg *models.ChatMessage) (*models.ChatMessage,
 	saved.UpdatedAt = msg.UpdatedAt
 	saved.ReceivedAt = nil
 	saved.Status = status
+	saved.Kind = kind
 
 	return &saved, nil
 }
@@ -84,15 +105,15 @@ func (r *messageRepo) BulkSaveMessages(msgs []*models.ChatMessage) ([]*models.Ch
 	}
 
 	now := time.Now()
-	const fields = 9
+	const fields = 13
 	placeholders := make([]string, len(msgs))
 	args := make([]interface{}, 0, len(msgs)*fields)
 
 	for i, msg := range msgs {
 		b := i * fields
 		placeholders[i] = fmt.Sprintf(
-			"($%d::uuid,$%d,$%d,$%d,$%d,COALESCE(NULLIF($%d,''),'sent'),$%d,$%d,$%d)",
-			b+1, b+2, b+3, b+4, b+5, b+6, b+7, b+8, b+9,
+			"($%d::uuid,$%d,$%d,$%d,$%d,COALESCE(NULLIF($%d,''),'sent'),$%d,$%d,$%d,$%d::uuid[],$%d,$%d::jsonb,$%d::jsonb)",
+			b+1, b+2, b+3, b+4, b+5, b+6, b+7, b+8, b+9, b+10, b+11, b+12, b+13,
 		)
 		if msg.CreatedAt.IsZero() {
 			msg.CreatedAt = now
@@ -111,14 +132,23 @@ func (r *messageRepo) BulkSaveMessages(msgs []*models.ChatMessage) ([]*models.Ch
 		if msg.ForwardFromID != nil {
 			forwardFromID = *msg.ForwardFromID
 		}
+		msg.Kind = messageKind(msg.Kind)
+		systemEvent, err := encodeSystemEvent(msg.System)
+		if err != nil {
+			return nil, err
+		}
+		attachmentInfo, err := encodeAttachmentInfo(msg.AttachmentInfo)
+		if err != nil {
+			return nil, err
+		}
 		args = append(args,
 			msg.SenderID.String(), msg.Content, msg.ConversationID, nullString(msg.Attachment),
 			replyToID, status, forwardFromID,
-			msg.CreatedAt, msg.UpdatedAt,
+			msg.CreatedAt, msg.UpdatedAt, uuidArray(msg.Mentions), string(msg.Kind), systemEvent, attachmentInfo,
 		)
 	}
 
-	query := "INSERT INTO messages (sender_id,content,conversation_id,attachment,reply_to_id,status,forward_from_id,created_at,updated_at) VALUES " +
+	query := "INSERT INTO messages (sender_id,content,conversation_id,attachment,reply_to_id,status,forward_from_id,created_at,updated_at,mentions,kind,system_event,attachment_info) VALUES " +
 		strings.Join(placeholders, ",") + " RETURNING id,created_at"
 
 	rows, err := r.db.Query(query, args...)
@@ -151,7 +181,9 @@ func (r *messageRepo) GetMessageById(id int) (*models.ChatMessage, error) {
 	query := `
 		SELECT id, sender_id, content, conversation_id, COALESCE(attachment, ''),
 		       reply_to_id, COALESCE(NULLIF(TRIM(status), ''), 'sent'), forward_from_id,
-		       created_at, updated_at
+		       created_at, updated_at, mentions, link_preview,
+		       (SELECT p.id FROM polls p WHERE p.message_id = messages.id),
+		       kind, system_event, attachment_info
 		FROM messages
 		WHERE id = $1
 		  AND deleted_at IS NULL
@@ -161,10 +193,16 @@ func (r *messageRepo) GetMessageById(id int) (*models.ChatMessage, error) {
 	var senderIDStr string
 	var replyToID, forwardFromID sql.NullInt64
 	var status sql.NullString
+	var mentions []string
+	var linkPreview []byte
+	var pollID sql.NullInt64
+	var kind string
+	var systemEvent, attachmentInfo []byte
 	err := r.db.QueryRow(query, id).Scan(
 		&msg.ID, &senderIDStr, &msg.Content, &msg.ConversationID, &msg.Attachment,
 		&replyToID, &status, &forwardFromID,
-		&msg.CreatedAt, &msg.UpdatedAt,
+		&msg.CreatedAt, &msg.UpdatedAt, pq.Array(&mentions), &linkPreview, &pollID,
+		&kind, &systemEvent, &attachmentInfo,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -189,6 +227,23 @@ func (r *messageRepo) GetMessageById(id int) (*models.ChatMessage, error) {
 		fi := int(forwardFromID.Int64)
 		msg.ForwardFromID = &fi
 	}
+	if msg.Mentions, err = parseUUIDs(mentions); err != nil {
+		return nil, err
+	}
+	if msg.LinkPreview, err = decodeLinkPreview(linkPreview); err != nil {
+		return nil, err
+	}
+	if pollID.Valid {
+		pid := int(pollID.Int64)
+		msg.PollID = &pid
+	}
+	msg.Kind = models.MessageKind(kind)
+	if msg.System, err = decodeSystemEvent(systemEvent); err != nil {
+		return nil, err
+	}
+	if msg.AttachmentInfo, err = decodeAttachmentInfo(attachmentInfo); err != nil {
+		return nil, err
+	}
 
 	return &msg, nil
 }
@@ -197,10 +252,12 @@ func (r *messageRepo) GetMessagesByConversationID(conversationID int) ([]*models
 	query := `
 		SELECT m.id, m.sender_id, m.content, m.conversation_id, COALESCE(m.attachment, ''),
 		       m.reply_to_id, COALESCE(m.status, 'sent'), m.forward_from_id,
-		       m.created_at, m.updated_at,
+		       m.created_at, m.updated_at, m.mentions, m.link_preview, p.id AS poll_id,
+		       m.kind, m.system_event, m.attachment_info,
 		       r.id AS reply_id, r.sender_id AS reply_sender_id, r.content AS reply_content
 		FROM messages m
 		LEFT JOIN messages r ON r.id = m.reply_to_id AND r.deleted_at IS NULL
+		LEFT JOIN polls p ON p.message_id = m.id
 		WHERE m.conversation_id = $1
 		  AND m.deleted_at IS NULL
 		ORDER BY m.created_at DESC, m.id DESC
@@ -220,10 +277,16 @@ func (r *messageRepo) GetMessagesByConversationID(conversationID int) ([]*models
 		var status sql.NullString
 		var replyID sql.NullInt64
 		var replySenderID, replyContent sql.NullString
+		var mentions []string
+		var linkPreview []byte
+		var pollID sql.NullInt64
+		var kind string
+		var systemEvent, attachmentInfo []byte
 		if err := rows.Scan(
 			&msg.ID, &senderIDStr, &msg.Content, &msg.ConversationID, &msg.Attachment,
 			&replyToID, &status, &forwardFromID,
-			&msg.CreatedAt, &msg.UpdatedAt,
+			&msg.CreatedAt, &msg.UpdatedAt, pq.Array(&mentions), &linkPreview, &pollID,
+			&kind, &systemEvent, &attachmentInfo,
 			&replyID, &replySenderID, &replyContent,
 		); err != nil {
 			return nil, err
@@ -245,6 +308,23 @@ func (r *messageRepo) GetMessagesByConversationID(conversationID int) ([]*models
 			fi := int(forwardFromID.Int64)
 			msg.ForwardFromID = &fi
 		}
+		if msg.Mentions, err = parseUUIDs(mentions); err != nil {
+			return nil, err
+		}
+		if msg.LinkPreview, err = decodeLinkPreview(linkPreview); err != nil {
+			return nil, err
+		}
+		if pollID.Valid {
+			pid := int(pollID.Int64)
+			msg.PollID = &pid
+		}
+		msg.Kind = models.MessageKind(kind)
+		if msg.System, err = decodeSystemEvent(systemEvent); err != nil {
+			return nil, err
+		}
+		if msg.AttachmentInfo, err = decodeAttachmentInfo(attachmentInfo); err != nil {
+			return nil, err
+		}
 		if replyID.Valid && replySenderID.Valid {
 			msg.ReplyTo = &models.ReplyToRef{
 				ID:       int(replyID.Int64),
@@ -271,6 +351,24 @@ func (r *messageRepo) GetMessagesByConversationID(conversationID int) ([]*models
 	return messages, nil
 }
 
+func (r *messageRepo) GetLastMessageAtBySender(conversationID int, senderID uuid.UUID) (*time.Time, error) {
+	query := `
+		SELECT MAX(created_at)
+		FROM messages
+		WHERE conversation_id = $1
+		  AND sender_id = $2::uuid
+		  AND kind = 'user'
+	`
+	var last sql.NullTime
+	if err := r.db.QueryRow(query, conversationID, senderID.String()).Scan(&last); err != nil {
+		return nil, err
+	}
+	if !last.Valid {
+		return nil, nil
+	}
+	return &last.Time, nil
+}
+
 func (r *messageRepo) UpdateMessageById(id int, content string) (*models.ChatMessage, error) {
 	query := `
 		UPDATE messages
@@ -279,17 +377,25 @@ func (r *messageRepo) UpdateMessageById(id int, content string) (*models.ChatMes
 		  AND deleted_at IS NULL
 		RETURNING id, sender_id, conversation_id, content, COALESCE(attachment, ''),
 		          reply_to_id, COALESCE(NULLIF(TRIM(status), ''), 'sent'), forward_from_id,
-		          created_at, updated_at
+		          created_at, updated_at, mentions, link_preview,
+		          (SELECT p.id FROM polls p WHERE p.message_id = messages.id),
+		          kind, system_event, attachment_info
 	`
 
 	var msg models.ChatMessage
 	var senderIDStr string
 	var replyToID, forwardFromID sql.NullInt64
 	var status sql.NullString
+	var mentions []string
+	var linkPreview []byte
+	var pollID sql.NullInt64
+	var kind string
+	var systemEvent, attachmentInfo []byte
 	err := r.db.QueryRow(query, content, time.Now(), id).Scan(
 		&msg.ID, &senderIDStr, &msg.ConversationID, &msg.Content, &msg.Attachment,
 		&replyToID, &status, &forwardFromID,
-		&msg.CreatedAt, &msg.UpdatedAt,
+		&msg.CreatedAt, &msg.UpdatedAt, pq.Array(&mentions), &linkPreview, &pollID,
+		&kind, &systemEvent, &attachmentInfo,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -314,6 +420,23 @@ func (r *messageRepo) UpdateMessageById(id int, content string) (*models.ChatMes
 		fi := int(forwardFromID.Int64)
 		msg.ForwardFromID = &fi
 	}
+	if msg.Mentions, err = parseUUIDs(mentions); err != nil {
+		return nil, err
+	}
+	if msg.LinkPreview, err = decodeLinkPreview(linkPreview); err != nil {
+		return nil, err
+	}
+	if pollID.Valid {
+		pid := int(pollID.Int64)
+		msg.PollID = &pid
+	}
+	msg.Kind = models.MessageKind(kind)
+	if msg.System, err = decodeSystemEvent(systemEvent); err != nil {
+		return nil, err
+	}
+	if msg.AttachmentInfo, err = decodeAttachmentInfo(attachmentInfo); err != nil {
+		return nil, err
+	}
 
 	return &msg, nil
 }
@@ -525,9 +648,165 @@ func (r *messageRepo) DeleteMessageById(id int) error {
 	return nil
 }
 
+func (r *messageRepo) PurgeConversationMessages(conversationID int) ([]string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		SELECT DISTINCT attachment
+		FROM messages
+		WHERE conversation_id = $1
+		  AND attachment IS NOT NULL
+		  AND attachment <> ''
+	`, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	attachments := make([]string, 0)
+	for rows.Next() {
+		var attachment string
+		if err := rows.Scan(&attachment); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, query := range []string{
+		`DELETE FROM message_receipts WHERE message_id IN (SELECT id FROM messages WHERE conversation_id = $1)`,
+		`DELETE FROM message_seen_by WHERE message_id IN (SELECT id FROM messages WHERE conversation_id = $1)`,
+		// Sondages et épingles suivent par ON DELETE CASCADE ; les réponses/transferts ailleurs passent à NULL.
+		`DELETE FROM messages WHERE conversation_id = $1`,
+	} {
+		if _, err := tx.Exec(query, conversationID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *messageRepo) ReferencedAttachments(mediaIDs []string) ([]string, error) {
+	if len(mediaIDs) == 0 {
+		return nil, nil
+	}
+	// Expressions identiques aux index de la migration 024 (models.AttachmentMediaID).
+	rows, err := r.db.Query(`
+		SELECT ref FROM (
+			SELECT substring(attachment FROM 'media/[0-9]+_.*$') AS ref
+			FROM messages
+			WHERE deleted_at IS NULL
+			UNION
+			SELECT substring(attachment FROM 'media/[0-9]+_.*$')
+			FROM scheduled_messages
+			WHERE status IN ('pending', 'processing')
+		) refs
+		WHERE ref = ANY($1)
+	`, pq.Array(mediaIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	referenced := make([]string, 0)
+	for rows.Next() {
+		var ref string
+		if err := rows.Scan(&ref); err != nil {
+			return nil, err
+		}
+		referenced = append(referenced, ref)
+	}
+	return referenced, rows.Err()
+}
+
 func nullString(s string) interface{} {
 	if s == "" {
 		return nil
 	}
 	return s
 }
+
+// uuidArray convertit les mentions en tableau Postgres (jamais NULL : la colonne a un défaut '{}').
+func uuidArray(ids []uuid.UUID) interface{} {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+	return pq.Array(values)
+}
+
+func parseUUIDs(values []string) ([]uuid.UUID, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func messageKind(kind models.MessageKind) models.MessageKind {
+	if kind == "" {
+		return models.MessageKindUser
+	}
+	return kind
+}
+
+func encodeSystemEvent(event *models.SystemEvent) (interface{}, error) {
+	if event == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return string(raw), nil
+}
+
+func decodeSystemEvent(raw []byte) (*models.SystemEvent, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var event models.SystemEvent
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func encodeAttachmentInfo(info *models.AttachmentInfo) (interface{}, error) {
+	if info == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+	return string(raw), nil
+}
+
+func decodeAttachmentInfo(raw []byte) (*models.AttachmentInfo, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var info models.AttachmentInfo
+	if err := json.Unmarshal(raw, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
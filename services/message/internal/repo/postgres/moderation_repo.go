@@ -0,0 +1,318 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	banColumns      = `conversation_id, user_id, banned_by, reason, created_at`
+	muteColumns     = `conversation_id, user_id, muted_by, muted_until, created_at`
+	auditLogColumns = `id, conversation_id, actor_id, action, target_id, reason, expires_at, created_at`
+)
+
+func (r *conversationRepo) BanMember(ban *models.ConversationBan, entry *models.AuditLogEntry) (*models.ConversationBan, bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+
+	// Verrou sur la conversation : sérialisé avec AddMemberships, un ajout concurrent ne contourne pas le bannissement.
+	if err := lockActiveConversation(tx, ban.ConversationID); err != nil {
+		return nil, false, err
+	}
+
+	saved, err := scanBan(tx.QueryRow(`
+		INSERT INTO conversation_bans (conversation_id, user_id, banned_by, reason, created_at)
+		VALUES ($1, $2::uuid, $3::uuid, $4, $5)
+		RETURNING `+banColumns, ban.ConversationID, ban.UserID.String(), ban.BannedBy.String(), ban.Reason, ban.CreatedAt))
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, false, repo.ErrUserBanned
+		}
+		return nil, false, err
+	}
+
+	result, err := tx.Exec(`
+		UPDATE conversations_users
+		SET deleted_at = $3
+		WHERE conversation_id = $1
+		  AND user_id = $2::uuid
+		  AND deleted_at IS NULL
+	`, ban.ConversationID, ban.UserID.String(), saved.CreatedAt)
+	if err != nil {
+		return nil, false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := insertAuditEntry(tx, entry); err != nil {
+		return nil, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+	return saved, affected > 0, nil
+}
+
+func (r *conversationRepo) UnbanMember(conversationID int, userID uuid.UUID, entry *models.AuditLogEntry) error {
+	return r.deleteWithAudit(`
+		DELETE FROM conversation_bans
+		WHERE conversation_id = $1
+		  AND user_id = $2::uuid
+	`, conversationID, userID, entry, repo.ErrBanNotFound)
+}
+
+func (r *conversationRepo) IsBanned(conversationID int, userID uuid.UUID) (bool, error) {
+	var banned bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM conversation_bans
+			WHERE conversation_id = $1
+			  AND user_id = $2::uuid
+		)
+	`, conversationID, userID.String()).Scan(&banned)
+	return banned, err
+}
+
+func (r *conversationRepo) ListBans(conversationID int) ([]*models.ConversationBan, error) {
+	rows, err := r.db.Query(`
+		SELECT `+banColumns+`
+		FROM conversation_bans
+		WHERE conversation_id = $1
+		ORDER BY created_at DESC, user_id ASC
+	`, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*models.ConversationBan, 0)
+	for rows.Next() {
+		ban, err := scanBan(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ban)
+	}
+	return result, rows.Err()
+}
+
+func (r *conversationRepo) MuteMember(mute *models.ConversationMute, entry *models.AuditLogEntry) (*models.ConversationMute, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	saved, err := scanMute(tx.QueryRow(`
+		INSERT INTO conversation_mutes (conversation_id, user_id, muted_by, muted_until, created_at)
+		VALUES ($1, $2::uuid, $3::uuid, $4, $5)
+		ON CONFLICT (conversation_id, user_id) DO UPDATE
+		SET muted_by = EXCLUDED.muted_by,
+		    muted_until = EXCLUDED.muted_until,
+		    created_at = EXCLUDED.created_at
+		RETURNING `+muteColumns, mute.ConversationID, mute.UserID.String(), mute.MutedBy.String(), mute.MutedUntil, mute.CreatedAt))
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return nil, repo.ErrConversationNotFound
+		}
+		return nil, err
+	}
+	if err := insertAuditEntry(tx, entry); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+func (r *conversationRepo) UnmuteMember(conversationID int, userID uuid.UUID, entry *models.AuditLogEntry) error {
+	return r.deleteWithAudit(`
+		DELETE FROM conversation_mutes
+		WHERE conversation_id = $1
+		  AND user_id = $2::uuid
+	`, conversationID, userID, entry, repo.ErrMuteNotFound)
+}
+
+func (r *conversationRepo) GetMute(conversationID int, userID uuid.UUID) (*models.ConversationMute, error) {
+	mute, err := scanMute(r.db.QueryRow(`
+		SELECT `+muteColumns+`
+		FROM conversation_mutes
+		WHERE conversation_id = $1
+		  AND user_id = $2::uuid
+	`, conversationID, userID.String()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrMuteNotFound
+		}
+		return nil, err
+	}
+	return mute, nil
+}
+
+func (r *conversationRepo) KickMember(conversationID int, userID uuid.UUID, entry *models.AuditLogEntry) error {
+	return r.deleteWithAudit(`
+		UPDATE conversations_users
+		SET deleted_at = NOW()
+		WHERE conversation_id = $1
+		  AND user_id = $2::uuid
+		  AND deleted_at IS NULL
+	`, conversationID, userID, entry, repo.ErrMembershipNotFound)
+}
+
+func (r *conversationRepo) ListAuditLog(conversationID int, beforeID, limit int) ([]*models.AuditLogEntry, error) {
+	rows, err := r.db.Query(`
+		SELECT `+auditLogColumns+`
+		FROM conversation_audit_log
+		WHERE conversation_id = $1
+		  AND ($2 = 0 OR id < $2)
+		ORDER BY id DESC
+		LIMIT $3
+	`, conversationID, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*models.AuditLogEntry, 0)
+	for rows.Next() {
+		entry, err := scanAuditLogEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, entry)
+	}
+	return result, rows.Err()
+}
+
+// deleteWithAudit exécute query (paramètres conversation_id, user_id) et journalise entry dans la même
+// transaction ; notFound si aucune ligne n'est touchée.
+func (r *conversationRepo) deleteWithAudit(query string, conversationID int, userID uuid.UUID, entry *models.AuditLogEntry, notFound error) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(query, conversationID, userID.String())
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return notFound
+	}
+	if err := insertAuditEntry(tx, entry); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertAuditEntry(tx *sql.Tx, entry *models.AuditLogEntry) error {
+	var expiresAt sql.NullTime
+	if entry.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: *entry.ExpiresAt, Valid: true}
+	}
+	_, err := tx.Exec(`
+		INSERT INTO conversation_audit_log (conversation_id, actor_id, action, target_id, reason, expires_at, created_at)
+		VALUES ($1, $2::uuid, $3, $4::uuid, $5, $6, $7)
+	`, entry.ConversationID, entry.ActorID.String(), string(entry.Action), entry.TargetID.String(), entry.Reason, expiresAt, entry.CreatedAt)
+	return err
+}
+
+func scanBan(row scanner) (*models.ConversationBan, error) {
+	var (
+		ban         models.ConversationBan
+		userIDStr   string
+		bannedByStr string
+	)
+	if err := row.Scan(&ban.ConversationID, &userIDStr, &bannedByStr, &ban.Reason, &ban.CreatedAt); err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, err
+	}
+	bannedBy, err := uuid.Parse(bannedByStr)
+	if err != nil {
+		return nil, err
+	}
+	ban.UserID = userID
+	ban.BannedBy = bannedBy
+	return &ban, nil
+}
+
+func scanMute(row scanner) (*models.ConversationMute, error) {
+	var (
+		mute       models.ConversationMute
+		userIDStr  string
+		mutedByStr string
+	)
+	if err := row.Scan(&mute.ConversationID, &userIDStr, &mutedByStr, &mute.MutedUntil, &mute.CreatedAt); err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, err
+	}
+	mutedBy, err := uuid.Parse(mutedByStr)
+	if err != nil {
+		return nil, err
+	}
+	mute.UserID = userID
+	mute.MutedBy = mutedBy
+	return &mute, nil
+}
+
+func scanAuditLogEntry(row scanner) (*models.AuditLogEntry, error) {
+	var (
+		entry       models.AuditLogEntry
+		actorIDStr  string
+		action      string
+		targetIDStr string
+		expiresAt   sql.NullTime
+	)
+	if err := row.Scan(
+		&entry.ID,
+		&entry.ConversationID,
+		&actorIDStr,
+		&action,
+		&targetIDStr,
+		&entry.Reason,
+		&expiresAt,
+		&entry.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	actorID, err := uuid.Parse(actorIDStr)
+	if err != nil {
+		return nil, err
+	}
+	targetID, err := uuid.Parse(targetIDStr)
+	if err != nil {
+		return nil, err
+	}
+	entry.ActorID = actorID
+	entry.TargetID = targetID
+	entry.Action = models.AuditAction(action)
+	if expiresAt.Valid {
+		t := expiresAt.Time
+		entry.ExpiresAt = &t
+	}
+	return &entry, nil
+}
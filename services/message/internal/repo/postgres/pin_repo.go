@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"errors"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func (r *conversationRepo) CreatePin(pin *models.ConversationPin) (*models.ConversationPin, error) {
+	query := `
+		INSERT INTO conversation_pins (conversation_id, message_id, pinned_by, pinned_at)
+		VALUES ($1, $2, $3::uuid, NOW())
+		RETURNING conversation_id, message_id, pinned_by, pinned_at
+	`
+
+	saved, err := scanPin(r.db.QueryRow(query, pin.ConversationID, pin.MessageID, pin.PinnedBy.String()))
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case "23505":
+				return nil, repo.ErrPinAlreadyExists
+			case "23503":
+				return nil, errors.New("message not found")
+			}
+		}
+		return nil, err
+	}
+	return saved, nil
+}
+
+func (r *conversationRepo) DeletePin(conversationID, messageID int) error {
+	query := `
+		DELETE FROM conversation_pins
+		WHERE conversation_id = $1
+		  AND message_id = $2
+	`
+	result, err := r.db.Exec(query, conversationID, messageID)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return repo.ErrPinNotFound
+	}
+	return nil
+}
+
+func (r *conversationRepo) ListPins(conversationID int) ([]*models.ConversationPin, error) {
+	query := `
+		SELECT p.conversation_id, p.message_id, p.pinned_by, p.pinned_at
+		FROM conversation_pins p
+		JOIN messages m ON m.id = p.message_id AND m.deleted_at IS NULL
+		WHERE p.conversation_id = $1
+		ORDER BY p.pinned_at DESC
+	`
+	rows, err := r.db.Query(query, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*models.ConversationPin, 0)
+	for rows.Next() {
+		pin, err := scanPin(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, pin)
+	}
+	return result, rows.Err()
+}
+
+func scanPin(row scanner) (*models.ConversationPin, error) {
+	var (
+		pin         models.ConversationPin
+		pinnedByStr string
+	)
+	if err := row.Scan(&pin.ConversationID, &pin.MessageID, &pinnedByStr, &pin.PinnedAt); err != nil {
+		return nil, err
+	}
+	parsed, err := uuid.Parse(pinnedByStr)
+	if err != nil {
+		return nil, err
+	}
+	pin.PinnedBy = parsed
+	return &pin, nil
+}
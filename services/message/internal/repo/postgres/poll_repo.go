@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const pollColumns = `
+	id, message_id, conversation_id, created_by, question, multi_choice, anonymous,
+	closes_at, closed_at, created_at
+`
+
+func (r *messageRepo) CreatePoll(poll *models.Poll) (*models.Poll, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO polls (message_id, conversation_id, created_by, question, multi_choice, anonymous, closes_at, created_at)
+		VALUES ($1, $2, $3::uuid, $4, $5, $6, $7, NOW())
+		RETURNING ` + pollColumns
+
+	var closesAt interface{}
+	if poll.ClosesAt != nil {
+		closesAt = *poll.ClosesAt
+	}
+	saved, err := scanPoll(tx.QueryRow(
+		query,
+		poll.MessageID, poll.ConversationID, poll.CreatedBy.String(), poll.Question,
+		poll.MultiChoice, poll.Anonymous, closesAt,
+	))
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case "23505":
+				return nil, errors.New("invalid poll: message already carries a poll")
+			case "23503":
+				return nil, errors.New("message not found")
+			}
+		}
+		return nil, err
+	}
+
+	for i, option := range poll.Options {
+		var optionID int
+		if err := tx.QueryRow(
+			`INSERT INTO poll_options (poll_id, position, label) VALUES ($1, $2, $3) RETURNING id`,
+			saved.ID, i, option.Label,
+		).Scan(&optionID); err != nil {
+			return nil, err
+		}
+		saved.Options = append(saved.Options, models.PollOption{ID: optionID, Position: i, Label: option.Label})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+func (r *messageRepo) GetPollByID(id int) (*models.Poll, error) {
+	query := `SELECT ` + pollColumns + ` FROM polls WHERE id = $1`
+
+	poll, err := scanPoll(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrPollNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`SELECT id, position, label FROM poll_options WHERE poll_id = $1 ORDER BY position ASC`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var option models.PollOption
+		if err := rows.Scan(&option.ID, &option.Position, &option.Label); err != nil {
+			return nil, err
+		}
+		poll.Options = append(poll.Options, option)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return poll, nil
+}
+
+func (r *messageRepo) ListPollVotes(pollID int) ([]*models.PollVote, error) {
+	rows, err := r.db.Query(`
+		SELECT poll_id, option_id, user_id, voted_at
+		FROM poll_votes
+		WHERE poll_id = $1
+		ORDER BY voted_at ASC
+	`, pollID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	votes := make([]*models.PollVote, 0)
+	for rows.Next() {
+		var vote models.PollVote
+		var userIDStr string
+		if err := rows.Scan(&vote.PollID, &vote.OptionID, &userIDStr, &vote.VotedAt); err != nil {
+			return nil, err
+		}
+		if vote.UserID, err = uuid.Parse(userIDStr); err != nil {
+			return nil, err
+		}
+		votes = append(votes, &vote)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return votes, nil
+}
+
+func (r *messageRepo) ReplacePollVotes(pollID int, userID uuid.UUID, optionIDs []int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Verrou sur le sondage : deux votes concurrents du même utilisateur ne se mélangent pas.
+	var closedAt sql.NullTime
+	if err := tx.QueryRow(`SELECT closed_at FROM polls WHERE id = $1 FOR UPDATE`, pollID).Scan(&closedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repo.ErrPollNotFound
+		}
+		return err
+	}
+	if closedAt.Valid {
+		return repo.ErrPollClosed
+	}
+
+	if _, err := tx.Exec(`DELETE FROM poll_votes WHERE poll_id = $1 AND user_id = $2::uuid`, pollID, userID.String()); err != nil {
+		return err
+	}
+	for _, optionID := range optionIDs {
+		if _, err := tx.Exec(
+			`INSERT INTO poll_votes (poll_id, option_id, user_id, voted_at) VALUES ($1, $2, $3::uuid, NOW())`,
+			pollID, optionID, userID.String(),
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *messageRepo) ClosePoll(id int, closedAt time.Time) (*models.Poll, error) {
+	query := `
+		UPDATE polls
+		SET closed_at = $2
+		WHERE id = $1
+		  AND closed_at IS NULL
+		RETURNING ` + pollColumns
+
+	if _, err := scanPoll(r.db.QueryRow(query, id, closedAt)); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if _, getErr := r.GetPollByID(id); getErr != nil {
+			return nil, getErr
+		}
+		return nil, repo.ErrPollClosed
+	}
+	return r.GetPollByID(id)
+}
+
+func scanPoll(row scanner) (*models.Poll, error) {
+	var (
+		poll               models.Poll
+		createdByStr       string
+		closesAt, closedAt sql.NullTime
+	)
+	if err := row.Scan(
+		&poll.ID, &poll.MessageID, &poll.ConversationID, &createdByStr, &poll.Question,
+		&poll.MultiChoice, &poll.Anonymous, &closesAt, &closedAt, &poll.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	createdBy, err := uuid.Parse(createdByStr)
+	if err != nil {
+		return nil, err
+	}
+	poll.CreatedBy = createdBy
+	if closesAt.Valid {
+		t := closesAt.Time
+		poll.ClosesAt = &t
+	}
+	if closedAt.Valid {
+		t := closedAt.Time
+		poll.ClosedAt = &t
+	}
+	return &poll, nil
+}
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const messageReportColumns = `id, message_id, conversation_id, reporter_id, reason, status, COALESCE(resolved_by::text, ''), resolved_at, created_at`
+
+func (r *messageRepo) CreateMessageReport(report *models.MessageReport) (*models.MessageReport, error) {
+	query := `
+		INSERT INTO message_reports (message_id, conversation_id, reporter_id, reason, status, created_at)
+		VALUES ($1, $2, $3::uuid, $4, 'pending', NOW())
+		RETURNING ` + messageReportColumns
+
+	saved, err := scanMessageReport(r.db.QueryRow(query, report.MessageID, report.ConversationID, report.ReporterID.String(), report.Reason))
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case "23505":
+				return nil, repo.ErrReportExists
+			case "23503":
+				return nil, errors.New("message not found")
+			}
+		}
+		return nil, err
+	}
+	return saved, nil
+}
+
+func (r *messageRepo) GetMessageReport(id int) (*models.MessageReport, error) {
+	query := `SELECT ` + messageReportColumns + ` FROM message_reports WHERE id = $1`
+
+	report, err := scanMessageReport(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrReportNotFound
+		}
+		return nil, err
+	}
+	return report, nil
+}
+
+func (r *messageRepo) ListMessageReports(conversationID int, status models.MessageReportStatus) ([]*models.MessageReport, error) {
+	query := `
+		SELECT ` + messageReportColumns + `
+		FROM message_reports
+		WHERE conversation_id = $1
+		  AND status = $2
+		ORDER BY created_at ASC, id ASC
+	`
+	rows, err := r.db.Query(query, conversationID, string(status))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*models.MessageReport, 0)
+	for rows.Next() {
+		report, err := scanMessageReport(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, report)
+	}
+	return result, rows.Err()
+}
+
+func (r *messageRepo) ResolveMessageReport(id int, status models.MessageReportStatus, resolvedBy uuid.UUID, now time.Time) (*models.MessageReport, error) {
+	// status = 'pending' dans le WHERE : deux modérateurs simultanés ne clôturent le signalement qu'une fois.
+	report, err := scanMessageReport(r.db.QueryRow(`
+		UPDATE message_reports
+		SET status = $2, resolved_by = $3::uuid, resolved_at = $4
+		WHERE id = $1
+		  AND status = 'pending'
+		RETURNING `+messageReportColumns, id, string(status), resolvedBy.String(), now))
+	if err == nil {
+		return report, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if _, err := r.GetMessageReport(id); err != nil {
+		return nil, err
+	}
+	return nil, repo.ErrReportResolved
+}
+
+func scanMessageReport(row scanner) (*models.MessageReport, error) {
+	var (
+		report        models.MessageReport
+		reporterIDStr string
+		status        string
+		resolvedByStr string
+		resolvedAt    sql.NullTime
+	)
+
+	if err := row.Scan(
+		&report.ID,
+		&report.MessageID,
+		&report.ConversationID,
+		&reporterIDStr,
+		&report.Reason,
+		&status,
+		&resolvedByStr,
+		&resolvedAt,
+		&report.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	reporterID, err := uuid.Parse(reporterIDStr)
+	if err != nil {
+		return nil, err
+	}
+	report.ReporterID = reporterID
+	report.Status = models.MessageReportStatus(status)
+	if resolvedByStr != "" {
+		resolvedBy, err := uuid.Parse(resolvedByStr)
+		if err != nil {
+			return nil, err
+		}
+		report.ResolvedBy = resolvedBy
+	}
+	if resolvedAt.Valid {
+		t := resolvedAt.Time
+		report.ResolvedAt = &t
+	}
+	return &report, nil
+}
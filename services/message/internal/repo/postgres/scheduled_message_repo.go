@@ -0,0 +1,232 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+const scheduledMessageColumns = `
+	id, sender_id, conversation_id, content, COALESCE(attachment, ''), reply_to_id,
+	send_at, status, message_id, COALESCE(last_error, ''), claimed_at, created_at, updated_at
+`
+
+func (r *messageRepo) CreateScheduledMessage(msg *models.ScheduledMessage) (*models.ScheduledMessage, error) {
+	query := `
+		INSERT INTO scheduled_messages (sender_id, conversation_id, content, attachment, reply_to_id, send_at, status, created_at, updated_at)
+		VALUES ($1::uuid, $2, $3, $4, $5, $6, 'pending', NOW(), NOW())
+		RETURNING ` + scheduledMessageColumns
+
+	var replyToID interface{}
+	if msg.ReplyToID != nil {
+		replyToID = *msg.ReplyToID
+	}
+
+	row := r.db.QueryRow(
+		query,
+		msg.SenderID.String(), msg.ConversationID, msg.Content, nullString(msg.Attachment),
+		replyToID, msg.SendAt,
+	)
+	return scanScheduledMessage(row)
+}
+
+func (r *messageRepo) GetScheduledMessageByID(id int) (*models.ScheduledMessage, error) {
+	query := `SELECT ` + scheduledMessageColumns + ` FROM scheduled_messages WHERE id = $1`
+
+	saved, err := scanScheduledMessage(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo.ErrScheduledMessageNotFound
+		}
+		return nil, err
+	}
+	return saved, nil
+}
+
+func (r *messageRepo) ListScheduledMessagesBySender(senderID uuid.UUID, conversationID int) ([]*models.ScheduledMessage, error) {
+	query := `
+		SELECT ` + scheduledMessageColumns + `
+		FROM scheduled_messages
+		WHERE sender_id = $1::uuid
+		  AND ($2 = 0 OR conversation_id = $2)
+		ORDER BY send_at ASC, id ASC
+	`
+	rows, err := r.db.Query(query, senderID.String(), conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]*models.ScheduledMessage, 0)
+	for rows.Next() {
+		saved, err := scanScheduledMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, saved)
+	}
+	return out, rows.Err()
+}
+
+func (r *messageRepo) CancelScheduledMessage(id int) (*models.ScheduledMessage, error) {
+	query := `
+		UPDATE scheduled_messages
+		SET status = 'cancelled', updated_at = NOW()
+		WHERE id = $1
+		  AND status = 'pending'
+		RETURNING ` + scheduledMessageColumns
+
+	saved, err := scanScheduledMessage(r.db.QueryRow(query, id))
+	if err == nil {
+		return saved, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if _, getErr := r.GetScheduledMessageByID(id); getErr != nil {
+		return nil, getErr
+	}
+	return nil, repo.ErrScheduledMessageNotPending
+}
+
+// ClaimDueScheduledMessages réclame atomiquement un lot de messages dus.
+// FOR UPDATE SKIP LOCKED : deux replicas qui tournent en même temps ne voient jamais la même ligne,
+// et le passage à 'processing' est committé avant que le message ne soit envoyé.
+func (r *messageRepo) ClaimDueScheduledMessages(now time.Time, lease time.Duration, limit int) ([]*models.ScheduledMessage, error) {
+	query := `
+		UPDATE scheduled_messages
+		SET status = 'processing', claimed_at = $1, updated_at = $1
+		WHERE id IN (
+			SELECT id
+			FROM scheduled_messages
+			WHERE (status = 'pending' AND send_at <= $1)
+			   OR (status = 'processing' AND claimed_at <= $2)
+			ORDER BY send_at ASC, id ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + scheduledMessageColumns
+
+	rows, err := r.db.Query(query, now, now.Add(-lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]*models.ScheduledMessage, 0)
+	for rows.Next() {
+		saved, err := scanScheduledMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, saved)
+	}
+	return out, rows.Err()
+}
+
+// SendScheduledMessage insère le message et passe la ligne à 'sent' dans la même transaction.
+// La ligne est verrouillée et doit toujours porter le bail du réclamant (status 'processing',
+// claimed_at inchangé) : un replica dont le bail a expiré et été repris n'envoie rien.
+func (r *messageRepo) SendScheduledMessage(scheduled *models.ScheduledMessage, msg *models.ChatMessage) (*models.ChatMessage, error) {
+	if scheduled.ClaimedAt == nil {
+		return nil, repo.ErrScheduledMessageNotPending
+	}
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id int
+	err = tx.QueryRow(`
+		SELECT id
+		FROM scheduled_messages
+		WHERE id = $1
+		  AND status = 'processing'
+		  AND claimed_at = $2
+		FOR UPDATE
+	`, scheduled.ID, *scheduled.ClaimedAt).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repo.ErrScheduledMessageNotPending
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := insertMessage(tx, msg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(`
+		UPDATE scheduled_messages
+		SET status = 'sent', message_id = $2, last_error = NULL, updated_at = NOW()
+		WHERE id = $1
+	`, scheduled.ID, saved.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// MarkScheduledMessageFailed n'écrase que la ligne encore sous le bail claimedAt.
+func (r *messageRepo) MarkScheduledMessageFailed(id int, claimedAt time.Time, reason string) error {
+	query := `
+		UPDATE scheduled_messages
+		SET status = 'failed', last_error = $3, updated_at = NOW()
+		WHERE id = $1
+		  AND status = 'processing'
+		  AND claimed_at = $2
+	`
+	result, err := r.db.Exec(query, id, claimedAt, reason)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		if _, getErr := r.GetScheduledMessageByID(id); getErr != nil {
+			return getErr
+		}
+		return repo.ErrScheduledMessageNotPending
+	}
+	return nil
+}
+
+func scanScheduledMessage(row scanner) (*models.ScheduledMessage, error) {
+	var (
+		msg                  models.ScheduledMessage
+		senderIDStr          string
+		replyToID, messageID sql.NullInt64
+		claimedAt            sql.NullTime
+	)
+	if err := row.Scan(
+		&msg.ID, &senderIDStr, &msg.ConversationID, &msg.Content, &msg.Attachment, &replyToID,
+		&msg.SendAt, &msg.Status, &messageID, &msg.LastError, &claimedAt, &msg.CreatedAt, &msg.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	parsed, err := uuid.Parse(senderIDStr)
+	if err != nil {
+		return nil, err
+	}
+	msg.SenderID = parsed
+	if replyToID.Valid {
+		v := int(replyToID.Int64)
+		msg.ReplyToID = &v
+	}
+	if messageID.Valid {
+		v := int(messageID.Int64)
+		msg.MessageID = &v
+	}
+	if claimedAt.Valid {
+		msg.ClaimedAt = &claimedAt.Time
+	}
+	return &msg, nil
+}
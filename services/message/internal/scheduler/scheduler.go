@@ -0,0 +1,142 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Mathis-brgs/storm-project/services/message/internal/attachments"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/broadcast"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/mentions"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/service"
+)
+
+const (
+	defaultInterval  = time.Second
+	defaultLease     = time.Minute
+	defaultBatchSize = 100
+)
+
+// Scheduler envoie les messages programmés arrivés à échéance.
+// Les lignes sont réclamées en base (status 'processing' + claimed_at) : plusieurs replicas
+// peuvent tourner en parallèle, chacun n'envoie que ce qu'il a réclamé. Un message réclamé
+// par un replica qui meurt avant l'envoi est repris après expiration du bail ; l'insertion du
+// message et le passage à 'sent' sont atomiques et réservés au détenteur du bail en cours.
+type Scheduler struct {
+	svc             *service.MessageService
+	conversationSvc *service.ConversationService
+	publisher       broadcast.Publisher
+	mentions        *mentions.Resolver
+	attachments     *attachments.Resolver
+
+	interval  time.Duration
+	lease     time.Duration
+	batchSize int
+}
+
+// mentionResolver peut être nil : les @username ne sont alors ni résolus ni notifiés.
+// attachmentResolver peut être nil : la pièce jointe part sans métadonnées.
+func New(svc *service.MessageService, conversationSvc *service.ConversationService, publisher broadcast.Publisher, mentionResolver *mentions.Resolver, attachmentResolver *attachments.Resolver) *Scheduler {
+	return &Scheduler{
+		svc:             svc,
+		conversationSvc: conversationSvc,
+		publisher:       publisher,
+		mentions:        mentionResolver,
+		attachments:     attachmentResolver,
+		interval:        defaultInterval,
+		lease:           defaultLease,
+		batchSize:       defaultBatchSize,
+	}
+}
+
+// Run traite les échéances toutes les secondes jusqu'à l'annulation du contexte.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.RunOnce(time.Now()); err != nil {
+				log.Printf("[scheduler] run: %v", err)
+			}
+		}
+	}
+}
+
+// RunOnce réclame et envoie les messages dus à now ; retourne le nombre de messages envoyés.
+func (s *Scheduler) RunOnce(now time.Time) (int, error) {
+	due, err := s.svc.ClaimDueScheduledMessages(now, s.lease, s.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, scheduled := range due {
+		if err := s.dispatch(scheduled, now); err != nil {
+			log.Printf("[scheduler] scheduled message %d failed: %v", scheduled.ID, err)
+			if errors.Is(err, repo.ErrScheduledMessageNotPending) {
+				// Bail perdu : un autre replica a repris la ligne, c'est lui qui l'envoie.
+				continue
+			}
+			if errors.Is(err, service.ErrSlowMode) {
+				// Mode lent : l'envoi est différé, la ligne reste « processing » jusqu'à l'expiration du bail.
+				continue
+			}
+			if errors.Is(err, attachments.ErrMediaUnavailable) {
+				// Pièce jointe non vérifiable : la ligne reste « processing », reprise à l'expiration du bail.
+				continue
+			}
+			if markErr := s.svc.MarkScheduledMessageFailed(scheduled, err.Error()); markErr != nil {
+				log.Printf("[scheduler] mark failed %d: %v", scheduled.ID, markErr)
+			}
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+func (s *Scheduler) dispatch(scheduled *models.ScheduledMessage, now time.Time) error {
+	// L'auteur a pu quitter la conversation, ou le groupe passer en lecture seule, entre la
+	// programmation et l'échéance. Le mode lent s'applique à l'envoi, comme pour un message direct :
+	// plusieurs messages programmés à la même échéance partent à son rythme.
+	if s.conversationSvc != nil {
+		if err := s.conversationSvc.AuthorizePost(scheduled.SenderID, scheduled.ConversationID, now, s.svc); err != nil {
+			return err
+		}
+	}
+
+	chatMsg := &models.ChatMessage{
+		SenderID:       scheduled.SenderID,
+		ConversationID: scheduled.ConversationID,
+		Content:        scheduled.Content,
+		Attachment:     scheduled.Attachment,
+		ReplyToID:      scheduled.ReplyToID,
+		Status:         "sent",
+	}
+	info, err := s.attachments.Authorize(scheduled.SenderID, scheduled.ConversationID, scheduled.Attachment)
+	if err != nil {
+		return err
+	}
+	chatMsg.AttachmentInfo = info
+	// Résolution à l'échéance : l'appartenance des mentionnés est celle du moment de l'envoi.
+	chatMsg.Mentions = s.mentions.Resolve(scheduled.ConversationID, scheduled.SenderID, scheduled.Content)
+	// Pas de batch.Writer ici : BulkSaveMessages insère sans condition, alors que l'insertion doit être
+	// atomique avec le passage à 'sent' et conditionnée au bail. Passer par le writer puis marquer la ligne
+	// enverrait deux fois un message dont le bail a expiré entre-temps. Le writer n'apporte que le
+	// regroupement des insertions ; le scheduler insère au plus batchSize messages par tick.
+	saved, err := s.svc.SendScheduledMessage(scheduled, chatMsg)
+	if err != nil {
+		return err
+	}
+
+	broadcast.Publish(s.publisher, broadcast.ConversationRoom(saved.ConversationID), broadcast.MessagePayload(saved))
+	s.mentions.Notify(saved)
+	return nil
+}
@@ -0,0 +1,245 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/service"
+	"github.com/google/uuid"
+)
+
+var (
+	schedOwnerID  = uuid.MustParse("d1000001-0000-0000-0000-000000000001")
+	schedMemberID = uuid.MustParse("d1000002-0000-0000-0000-000000000002")
+)
+
+type recordingPublisher struct {
+	mu       sync.Mutex
+	subjects []string
+	payloads []map[string]interface{}
+}
+
+func (p *recordingPublisher) Publish(subject string, data []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var payload map[string]interface{}
+	_ = json.Unmarshal(data, &payload)
+	p.subjects = append(p.subjects, subject)
+	p.payloads = append(p.payloads, payload)
+	return nil
+}
+
+type fixture struct {
+	scheduler       *Scheduler
+	messageSvc      *service.MessageService
+	conversationSvc *service.ConversationService
+	publisher       *recordingPublisher
+	conversationID  int
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	messageRepo := memory.NewMessageRepo()
+	messageSvc := service.NewMessageService(messageRepo)
+	conversationSvc := service.NewConversationService(memory.NewConversationRepo())
+
+	conversation, err := conversationSvc.CreateConversation(schedOwnerID, "Scheduler", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	if _, err := conversationSvc.AddMember(schedOwnerID, conversation.ID, schedMemberID, models.ConversationRoleMember); err != nil {
+		t.Fatalf("AddMember() error = %v", err)
+	}
+
+	publisher := &recordingPublisher{}
+	return &fixture{
+		scheduler:       New(messageSvc, conversationSvc, publisher, nil, nil),
+		messageSvc:      messageSvc,
+		conversationSvc: conversationSvc,
+		publisher:       publisher,
+		conversationID:  conversation.ID,
+	}
+}
+
+func (f *fixture) schedule(t *testing.T, senderID uuid.UUID, content string, in time.Duration) *models.ScheduledMessage {
+	t.Helper()
+	scheduled, err := f.messageSvc.ScheduleMessage(&models.ScheduledMessage{
+		SenderID:       senderID,
+		ConversationID: f.conversationID,
+		Content:        content,
+		SendAt:         time.Now().Add(in),
+	})
+	if err != nil {
+		t.Fatalf("ScheduleMessage() error = %v", err)
+	}
+	return scheduled
+}
+
+func TestSchedulerRunOnceSendsOnlyDueMessages(t *testing.T) {
+	fix := newFixture(t)
+
+	due := fix.schedule(t, schedMemberID, "due soon", time.Minute)
+	later := fix.schedule(t, schedMemberID, "much later", time.Hour)
+
+	sent, err := fix.scheduler.RunOnce(time.Now().Add(2 * time.Minute))
+	if err != nil {
+		t.Fatalf("RunOnce() error = %v", err)
+	}
+	if sent != 1 {
+		t.Fatalf("expected 1 message sent, got %d", sent)
+	}
+
+	messages, err := fix.messageSvc.GetMessagesByConversationID(fix.conversationID)
+	if err != nil {
+		t.Fatalf("GetMessagesByConversationID() error = %v", err)
+	}
+	if len(messages) != 1 || messages[0].Content != "due soon" {
+		t.Fatalf("expected the due message to be persisted, got %+v", messages)
+	}
+
+	saved, _ := fix.messageSvc.ListScheduledMessages(schedMemberID, fix.conversationID)
+	statuses := map[int]string{}
+	for _, s := range saved {
+		statuses[s.ID] = s.Status
+	}
+	if statuses[due.ID] != models.ScheduledStatusSent {
+		t.Fatalf("expected due message status sent, got %q", statuses[due.ID])
+	}
+	if statuses[later.ID] != models.ScheduledStatusPending {
+		t.Fatalf("expected later message to stay pending, got %q", statuses[later.ID])
+	}
+
+	if len(fix.publisher.subjects) != 1 {
+		t.Fatalf("expected 1 broadcast, got %d", len(fix.publisher.subjects))
+	}
+	wantSubject := "message.broadcast.conversation:" + strconv.Itoa(fix.conversationID)
+	if fix.publisher.subjects[0] != wantSubject {
+		t.Fatalf("expected broadcast on %s, got %s", wantSubject, fix.publisher.subjects[0])
+	}
+	if got := fix.publisher.payloads[0]["action"]; got != "message" {
+		t.Fatalf("expected action=message, got %v", got)
+	}
+}
+
+func TestSchedulerRunOnceDoesNotResendClaimedMessages(t *testing.T) {
+	fix := newFixture(t)
+	fix.schedule(t, schedMemberID, "only once", time.Minute)
+
+	now := time.Now().Add(2 * time.Minute)
+	if sent, err := fix.scheduler.RunOnce(now); err != nil || sent != 1 {
+		t.Fatalf("first RunOnce() = %d, %v", sent, err)
+	}
+	if sent, err := fix.scheduler.RunOnce(now.Add(time.Hour)); err != nil || sent != 0 {
+		t.Fatalf("second RunOnce() should not resend, got %d, %v", sent, err)
+	}
+
+	messages, _ := fix.messageSvc.GetMessagesByConversationID(fix.conversationID)
+	if len(messages) != 1 {
+		t.Fatalf("expected exactly one persisted message, got %d", len(messages))
+	}
+}
+
+func TestSchedulerMarksFailedWhenSenderLeft(t *testing.T) {
+	fix := newFixture(t)
+	scheduled := fix.schedule(t, schedMemberID, "after leaving", time.Minute)
+
+	if err := fix.conversationSvc.LeaveConversation(schedMemberID, fix.conversationID); err != nil {
+		t.Fatalf("LeaveConversation() error = %v", err)
+	}
+
+	if sent, err := fix.scheduler.RunOnce(time.Now().Add(2 * time.Minute)); err != nil || sent != 0 {
+		t.Fatalf("RunOnce() = %d, %v", sent, err)
+	}
+
+	list, _ := fix.messageSvc.ListScheduledMessages(schedMemberID, 0)
+	if len(list) != 1 || list[0].ID != scheduled.ID || list[0].Status != models.ScheduledStatusFailed {
+		t.Fatalf("expected scheduled message to be failed, got %+v", list)
+	}
+	if len(fix.publisher.subjects) != 0 {
+		t.Fatalf("failed message should not be broadcast")
+	}
+}
+
+func TestSchedulerDefersMessagesUnderSlowMode(t *testing.T) {
+	fix := newFixture(t)
+	slowMode := 60
+	if _, _, err := fix.conversationSvc.UpdatePermissions(schedOwnerID, fix.conversationID, service.ConversationPermissionsUpdate{SlowModeSeconds: &slowMode}); err != nil {
+		t.Fatalf("UpdatePermissions() error = %v", err)
+	}
+	for _, content := range []string{"first", "second", "third"} {
+		fix.schedule(t, schedMemberID, content, time.Minute)
+	}
+
+	now := time.Now().Add(2 * time.Minute)
+	if sent, err := fix.scheduler.RunOnce(now); err != nil || sent != 1 {
+		t.Fatalf("first RunOnce() = %d, %v, want 1 message under slow mode", sent, err)
+	}
+	// Les messages différés sont repris à l'expiration du bail, un par délai du mode lent.
+	if sent, err := fix.scheduler.RunOnce(now.Add(2 * time.Minute)); err != nil || sent != 1 {
+		t.Fatalf("second RunOnce() = %d, %v, want 1 deferred message", sent, err)
+	}
+
+	messages, _ := fix.messageSvc.GetMessagesByConversationID(fix.conversationID)
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 persisted messages, got %d", len(messages))
+	}
+	list, _ := fix.messageSvc.ListScheduledMessages(schedMemberID, fix.conversationID)
+	for _, scheduled := range list {
+		if scheduled.Status == models.ScheduledStatusFailed {
+			t.Fatalf("deferred message must not fail, got %+v", scheduled)
+		}
+	}
+}
+
+func TestSchedulerReclaimsExpiredLease(t *testing.T) {
+	messageRepo := memory.NewMessageRepo()
+	svc := service.NewMessageService(messageRepo)
+	if _, err := svc.ScheduleMessage(&models.ScheduledMessage{
+		SenderID:       schedMemberID,
+		ConversationID: 1,
+		Content:        "crashed replica",
+		SendAt:         time.Now().Add(time.Minute),
+	}); err != nil {
+		t.Fatalf("ScheduleMessage() error = %v", err)
+	}
+
+	now := time.Now().Add(2 * time.Minute)
+	claimed, err := svc.ClaimDueScheduledMessages(now, time.Minute, 10)
+	if err != nil || len(claimed) != 1 {
+		t.Fatalf("first claim = %d, %v", len(claimed), err)
+	}
+	if again, _ := svc.ClaimDueScheduledMessages(now.Add(30*time.Second), time.Minute, 10); len(again) != 0 {
+		t.Fatalf("message under lease must not be claimed twice, got %d", len(again))
+	}
+	reclaimed, _ := svc.ClaimDueScheduledMessages(now.Add(2*time.Minute), time.Minute, 10)
+	if len(reclaimed) != 1 {
+		t.Fatalf("expired lease should be reclaimed, got %d", len(reclaimed))
+	}
+
+	// Le replica qui a perdu son bail n'envoie ni n'écrase plus rien.
+	msg := &models.ChatMessage{SenderID: schedMemberID, ConversationID: 1, Content: "crashed replica"}
+	if _, err := svc.SendScheduledMessage(claimed[0], msg); !errors.Is(err, repo.ErrScheduledMessageNotPending) {
+		t.Fatalf("send under a lost lease should conflict, got %v", err)
+	}
+	if err := svc.MarkScheduledMessageFailed(claimed[0], "boom"); !errors.Is(err, repo.ErrScheduledMessageNotPending) {
+		t.Fatalf("mark failed under a lost lease should conflict, got %v", err)
+	}
+	if _, err := svc.SendScheduledMessage(reclaimed[0], msg); err != nil {
+		t.Fatalf("send under the current lease: %v", err)
+	}
+	if messages, _ := svc.GetMessagesByConversationID(1); len(messages) != 1 {
+		t.Fatalf("expected exactly one persisted message, got %d", len(messages))
+	}
+
+	if _, err := svc.CancelScheduledMessage(schedMemberID, claimed[0].ID); !errors.Is(err, repo.ErrScheduledMessageNotPending) {
+		t.Fatalf("cancel of a processing message should conflict, got %v", err)
+	}
+}
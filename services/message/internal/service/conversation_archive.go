@@ -0,0 +1,87 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/google/uuid"
+)
+
+// ConversationRestoreGracePeriod : délai pendant lequel un owner peut restaurer une conversation supprimée ;
+// au-delà, la purge la supprime définitivement.
+const ConversationRestoreGracePeriod = 30 * 24 * time.Hour
+
+var ErrRestoreWindowExpired = errors.New("conversation can no longer be restored")
+
+// ArchiveConversation masque (archived) ou réaffiche la conversation dans la liste de userID uniquement.
+// Idempotent : archiver une conversation déjà archivée conserve la date d'origine.
+func (s *ConversationService) ArchiveConversation(userID uuid.UUID, conversationID int, archived bool, now time.Time) (*models.ConversationMembership, error) {
+	if err := validateConversationAndUser(conversationID, userID); err != nil {
+		return nil, err
+	}
+
+	membership, err := s.requireActorMembership(conversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if (membership.ArchivedAt != nil) == archived {
+		return membership, nil
+	}
+
+	var archivedAt *time.Time
+	if archived {
+		archivedAt = &now
+	}
+	return s.conversationRepo.SetMembershipArchived(conversationID, userID, archivedAt)
+}
+
+// ListArchivedConversations : conversations archivées par userID (absentes de ListConversationsByUser).
+func (s *ConversationService) ListArchivedConversations(userID uuid.UUID) ([]*models.Conversation, error) {
+	if userID == uuid.Nil {
+		return nil, ErrInvalidUserID
+	}
+	return s.conversationRepo.ListConversationsByUser(userID, true)
+}
+
+// RestoreConversation annule la suppression d'une conversation dans le délai de grâce ;
+// réservé à un owner au moment de la suppression. Les membres retirés par la suppression sont réintégrés.
+func (s *ConversationService) RestoreConversation(actorID uuid.UUID, conversationID int, now time.Time) (*models.Conversation, error) {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return nil, err
+	}
+
+	conversation, memberships, err := s.conversationRepo.GetDeletedConversation(conversationID)
+	if err != nil {
+		return nil, err
+	}
+	isOwner := false
+	for _, membership := range memberships {
+		if membership.UserID == actorID && membership.Role == models.ConversationRoleOwner {
+			isOwner = true
+			break
+		}
+	}
+	if !isOwner {
+		return nil, ErrForbidden
+	}
+	if now.Sub(*conversation.DeletedAt) > ConversationRestoreGracePeriod {
+		return nil, ErrRestoreWindowExpired
+	}
+
+	return s.conversationRepo.RestoreConversation(conversationID, *conversation.DeletedAt)
+}
+
+// ListPurgeableConversations : conversations supprimées depuis plus que le délai de grâce à now.
+func (s *ConversationService) ListPurgeableConversations(now time.Time, limit int) ([]int, error) {
+	return s.conversationRepo.ListConversationsDeletedBefore(now.Add(-ConversationRestoreGracePeriod), limit)
+}
+
+// PurgeConversation supprime définitivement la conversation si elle est toujours purgeable à now.
+// Les messages sont purgés à part (MessageService.PurgeConversationMessages), avant cet appel.
+func (s *ConversationService) PurgeConversation(conversationID int, now time.Time) error {
+	if conversationID == 0 {
+		return ErrInvalidConversationID
+	}
+	return s.conversationRepo.PurgeConversation(conversationID, now.Add(-ConversationRestoreGracePeriod))
+}
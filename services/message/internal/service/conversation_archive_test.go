@@ -0,0 +1,180 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
+)
+
+func TestConversationServiceArchiveIsPerUser(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+
+	conversation, err := svc.CreateConversation(testUserOwner, "Équipe", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	if _, err := svc.AddMember(testUserOwner, conversation.ID, testUserMember, models.ConversationRoleMember); err != nil {
+		t.Fatalf("AddMember() error = %v", err)
+	}
+
+	now := time.Now()
+	membership, err := svc.ArchiveConversation(testUserMember, conversation.ID, true, now)
+	if err != nil {
+		t.Fatalf("ArchiveConversation() error = %v", err)
+	}
+	if membership.ArchivedAt == nil {
+		t.Fatal("expected archived_at to be set")
+	}
+	// Idempotent : la date d'origine est conservée.
+	again, err := svc.ArchiveConversation(testUserMember, conversation.ID, true, now.Add(time.Hour))
+	if err != nil || again.ArchivedAt == nil || !again.ArchivedAt.Equal(*membership.ArchivedAt) {
+		t.Fatalf("re-archive should keep original date, got %+v (%v)", again, err)
+	}
+
+	active, err := svc.ListConversationsByUser(testUserMember)
+	if err != nil || len(active) != 0 {
+		t.Fatalf("archived conversation should be hidden, got %d (%v)", len(active), err)
+	}
+	archived, err := svc.ListArchivedConversations(testUserMember)
+	if err != nil || len(archived) != 1 || archived[0].ID != conversation.ID {
+		t.Fatalf("expected conversation in archived list, got %+v (%v)", archived, err)
+	}
+	ownerList, err := svc.ListConversationsByUser(testUserOwner)
+	if err != nil || len(ownerList) != 1 {
+		t.Fatalf("owner list should be unaffected, got %d (%v)", len(ownerList), err)
+	}
+
+	membership, err = svc.ArchiveConversation(testUserMember, conversation.ID, false, now)
+	if err != nil || membership.ArchivedAt != nil {
+		t.Fatalf("unarchive: got %+v (%v)", membership, err)
+	}
+	if active, _ := svc.ListConversationsByUser(testUserMember); len(active) != 1 {
+		t.Fatalf("unarchived conversation should be listed, got %d", len(active))
+	}
+
+	if _, err := svc.ArchiveConversation(testUserOther, conversation.ID, true, now); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("non-member archive: expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestConversationServiceRestoreConversation(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+
+	conversation, err := svc.CreateConversation(testUserOwner, "Équipe", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	if _, err := svc.AddMember(testUserOwner, conversation.ID, testUserMember, models.ConversationRoleMember); err != nil {
+		t.Fatalf("AddMember() error = %v", err)
+	}
+	if err := svc.DeleteConversation(testUserOwner, conversation.ID); err != nil {
+		t.Fatalf("DeleteConversation() error = %v", err)
+	}
+
+	now := time.Now()
+	if _, err := svc.RestoreConversation(testUserMember, conversation.ID, now); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("member restore should be forbidden, got %v", err)
+	}
+	if _, err := svc.RestoreConversation(testUserOwner, conversation.ID, now.Add(ConversationRestoreGracePeriod+time.Hour)); !errors.Is(err, ErrRestoreWindowExpired) {
+		t.Fatalf("late restore: expected ErrRestoreWindowExpired, got %v", err)
+	}
+
+	restored, err := svc.RestoreConversation(testUserOwner, conversation.ID, now)
+	if err != nil {
+		t.Fatalf("RestoreConversation() error = %v", err)
+	}
+	if restored.DeletedAt != nil {
+		t.Fatal("restored conversation should not be deleted")
+	}
+	isMember, err := svc.IsMember(testUserMember, conversation.ID)
+	if err != nil || !isMember {
+		t.Fatalf("member should be restored, got %v (%v)", isMember, err)
+	}
+
+	if _, err := svc.RestoreConversation(testUserOwner, conversation.ID, now); !errors.Is(err, repo.ErrConversationNotFound) {
+		t.Fatalf("second restore: expected ErrConversationNotFound, got %v", err)
+	}
+}
+
+func TestConversationServiceRestoreKeepsMembersWhoLeftBefore(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+
+	conversation, err := svc.CreateConversation(testUserOwner, "Équipe", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	if _, err := svc.AddMember(testUserOwner, conversation.ID, testUserMember, models.ConversationRoleMember); err != nil {
+		t.Fatalf("AddMember() error = %v", err)
+	}
+	if err := svc.LeaveConversation(testUserMember, conversation.ID); err != nil {
+		t.Fatalf("LeaveConversation() error = %v", err)
+	}
+	if err := svc.DeleteConversation(testUserOwner, conversation.ID); err != nil {
+		t.Fatalf("DeleteConversation() error = %v", err)
+	}
+
+	if _, err := svc.RestoreConversation(testUserOwner, conversation.ID, time.Now()); err != nil {
+		t.Fatalf("RestoreConversation() error = %v", err)
+	}
+	if isMember, _ := svc.IsMember(testUserMember, conversation.ID); isMember {
+		t.Fatal("member who left before deletion should not be restored")
+	}
+}
+
+func TestConversationServicePurgeConversation(t *testing.T) {
+	conversationSvc := NewConversationService(memory.NewConversationRepo())
+	messageSvc := NewMessageService(memory.NewMessageRepo())
+
+	conversation, err := conversationSvc.CreateConversation(testUserOwner, "Équipe", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	if _, err := messageSvc.SendMessage(&models.ChatMessage{
+		SenderID:       testUserOwner,
+		ConversationID: conversation.ID,
+		Content:        "photo",
+		Attachment:     "http://minio:9000/storm/media/1_photo.png",
+	}); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	if err := conversationSvc.DeleteConversation(testUserOwner, conversation.ID); err != nil {
+		t.Fatalf("DeleteConversation() error = %v", err)
+	}
+
+	now := time.Now()
+	if ids, err := conversationSvc.ListPurgeableConversations(now, 10); err != nil || len(ids) != 0 {
+		t.Fatalf("conversation within grace period should not be purgeable, got %v (%v)", ids, err)
+	}
+
+	later := now.Add(ConversationRestoreGracePeriod + time.Hour)
+	ids, err := conversationSvc.ListPurgeableConversations(later, 10)
+	if err != nil || len(ids) != 1 || ids[0] != conversation.ID {
+		t.Fatalf("expected conversation to be purgeable, got %v (%v)", ids, err)
+	}
+
+	attachments, err := messageSvc.PurgeConversationMessages(conversation.ID)
+	if err != nil {
+		t.Fatalf("PurgeConversationMessages() error = %v", err)
+	}
+	if len(attachments) != 1 || attachments[0] != "http://minio:9000/storm/media/1_photo.png" {
+		t.Fatalf("unexpected attachments: %v", attachments)
+	}
+	if err := conversationSvc.PurgeConversation(conversation.ID, later); err != nil {
+		t.Fatalf("PurgeConversation() error = %v", err)
+	}
+
+	messages, err := messageSvc.GetMessagesByConversationID(conversation.ID)
+	if err != nil || len(messages) != 0 {
+		t.Fatalf("messages should be purged, got %d (%v)", len(messages), err)
+	}
+	if _, err := conversationSvc.RestoreConversation(testUserOwner, conversation.ID, now); !errors.Is(err, repo.ErrConversationNotFound) {
+		t.Fatalf("purged conversation restore: expected ErrConversationNotFound, got %v", err)
+	}
+	if ids, _ := conversationSvc.ListPurgeableConversations(later, 10); len(ids) != 0 {
+		t.Fatalf("purged conversation should no longer be listed, got %v", ids)
+	}
+}
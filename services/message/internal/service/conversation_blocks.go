@@ -0,0 +1,71 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/google/uuid"
+)
+
+// ErrBlocked enveloppe ErrForbidden : l'utilisateur ciblé a bloqué l'acteur.
+var ErrBlocked = fmt.Errorf("%w: this user has blocked you", ErrForbidden)
+
+// BlockUser bloque userID pour actorID (idempotent). Le blocage est à sens unique :
+// seul le bloqueur est protégé (DM, ajout aux groupes, mentions, messages masqués).
+func (s *ConversationService) BlockUser(actorID, userID uuid.UUID) (*models.UserBlock, error) {
+	if actorID == uuid.Nil || userID == uuid.Nil {
+		return nil, ErrInvalidUserID
+	}
+	if actorID == userID {
+		return nil, fmt.Errorf("%w: cannot block yourself", ErrInvalidUserID)
+	}
+	return s.conversationRepo.BlockUser(actorID, userID, time.Now())
+}
+
+func (s *ConversationService) UnblockUser(actorID, userID uuid.UUID) error {
+	if actorID == uuid.Nil || userID == uuid.Nil {
+		return ErrInvalidUserID
+	}
+	return s.conversationRepo.UnblockUser(actorID, userID)
+}
+
+func (s *ConversationService) ListBlockedUsers(actorID uuid.UUID) ([]*models.UserBlock, error) {
+	if actorID == uuid.Nil {
+		return nil, ErrInvalidUserID
+	}
+	return s.conversationRepo.ListBlockedUsers(actorID)
+}
+
+// HasBlocked indique si blockerID a bloqué blockedID (utilisé aussi par la résolution des mentions).
+func (s *ConversationService) HasBlocked(blockerID, blockedID uuid.UUID) (bool, error) {
+	if blockerID == uuid.Nil || blockedID == uuid.Nil {
+		return false, nil
+	}
+	return s.conversationRepo.HasBlocked(blockerID, blockedID)
+}
+
+// BlockedUserSet retourne l'ensemble des utilisateurs bloqués par actorID (filtrage de LIST_MESSAGES).
+func (s *ConversationService) BlockedUserSet(actorID uuid.UUID) (map[uuid.UUID]struct{}, error) {
+	blocks, err := s.ListBlockedUsers(actorID)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[uuid.UUID]struct{}, len(blocks))
+	for _, block := range blocks {
+		set[block.BlockedID] = struct{}{}
+	}
+	return set, nil
+}
+
+// requireNotBlocked : ErrBlocked si userID a bloqué actorID.
+func (s *ConversationService) requireNotBlocked(userID, actorID uuid.UUID) error {
+	blocked, err := s.conversationRepo.HasBlocked(userID, actorID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrBlocked
+	}
+	return nil
+}
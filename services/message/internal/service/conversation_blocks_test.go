@@ -0,0 +1,79 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
+	"github.com/google/uuid"
+)
+
+func TestConversationServiceBlockUser(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+
+	if _, err := svc.BlockUser(testUserMember, testUserMember); !errors.Is(err, ErrInvalidUserID) {
+		t.Fatalf("self block: expected ErrInvalidUserID, got %v", err)
+	}
+	first, err := svc.BlockUser(testUserMember, testUserOther)
+	if err != nil {
+		t.Fatalf("BlockUser() error = %v", err)
+	}
+	again, err := svc.BlockUser(testUserMember, testUserOther)
+	if err != nil || !again.CreatedAt.Equal(first.CreatedAt) {
+		t.Fatalf("BlockUser() should be idempotent, got %+v, %v", again, err)
+	}
+
+	if blocked, _ := svc.HasBlocked(testUserMember, testUserOther); !blocked {
+		t.Fatalf("expected member to have blocked other")
+	}
+	if blocked, _ := svc.HasBlocked(testUserOther, testUserMember); blocked {
+		t.Fatalf("blocking must be one-way")
+	}
+	blocks, err := svc.ListBlockedUsers(testUserMember)
+	if err != nil || len(blocks) != 1 || blocks[0].BlockedID != testUserOther {
+		t.Fatalf("ListBlockedUsers() = %+v, %v", blocks, err)
+	}
+
+	if err := svc.UnblockUser(testUserMember, testUserOther); err != nil {
+		t.Fatalf("UnblockUser() error = %v", err)
+	}
+	if err := svc.UnblockUser(testUserMember, testUserOther); !errors.Is(err, repo.ErrBlockNotFound) {
+		t.Fatalf("second unblock: expected ErrBlockNotFound, got %v", err)
+	}
+}
+
+func TestConversationServiceBlockPreventsDirectAndAdd(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+	conversation, err := svc.CreateConversation(testUserOwner, "Projet", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	if _, err := svc.BlockUser(testUserMember, testUserOwner); err != nil {
+		t.Fatalf("BlockUser() error = %v", err)
+	}
+
+	if _, _, err := svc.GetOrCreateDirectConversation(testUserOwner, testUserMember); !errors.Is(err, ErrBlocked) {
+		t.Fatalf("direct with blocker: expected ErrBlocked, got %v", err)
+	}
+	// Le bloqueur peut toujours écrire au bloqué.
+	if _, _, err := svc.GetOrCreateDirectConversation(testUserMember, testUserOwner); err != nil {
+		t.Fatalf("blocker opening a direct should succeed, got %v", err)
+	}
+
+	if _, err := svc.AddMember(testUserOwner, conversation.ID, testUserMember, models.ConversationRoleMember); !errors.Is(err, ErrBlocked) || !errors.Is(err, ErrForbidden) {
+		t.Fatalf("add blocker: expected ErrBlocked (forbidden), got %v", err)
+	}
+
+	results, err := svc.AddMembers(testUserOwner, conversation.ID, []uuid.UUID{testUserMember, testUserOther}, models.ConversationRoleMember)
+	if err != nil {
+		t.Fatalf("AddMembers() error = %v", err)
+	}
+	if len(results) != 2 || !errors.Is(results[0].Err, ErrBlocked) || results[1].Err != nil {
+		t.Fatalf("expected blocker refused and other added in request order, got %+v", results)
+	}
+	if ok, _ := svc.IsMember(testUserMember, conversation.ID); ok {
+		t.Fatalf("blocker must not have been added")
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+// GetOrCreateDirectConversation retourne la conversation directe unique entre actorID et userID,
+// ou la crée (created = true). Si actorID l'avait quittée, il y est réintégré ; l'autre participant, s'il
+// est parti, n'est pas réintégré à sa place : il la rouvre lui-même.
+// Les deux participants sont simples membres : pas d'ajout, de changement de rôle ni de suppression.
+func (s *ConversationService) GetOrCreateDirectConversation(actorID, userID uuid.UUID) (*models.Conversation, bool, error) {
+	if actorID == uuid.Nil || userID == uuid.Nil {
+		return nil, false, ErrInvalidUserID
+	}
+	if actorID == userID {
+		return nil, false, fmt.Errorf("%w: cannot open a direct conversation with yourself", ErrInvalidConversation)
+	}
+	if err := s.requireNotBlocked(userID, actorID); err != nil {
+		return nil, false, err
+	}
+
+	directKey := models.DirectKey(actorID, userID)
+	conversation, err := s.conversationRepo.GetDirectConversation(directKey)
+	if err == nil {
+		if err := s.restoreDirectMember(conversation.ID, actorID); err != nil {
+			return nil, false, err
+		}
+		return conversation, false, nil
+	}
+	if !errors.Is(err, repo.ErrConversationNotFound) {
+		return nil, false, err
+	}
+
+	created, err := s.conversationRepo.CreateDirectConversation(&models.Conversation{
+		Kind:      models.ConversationKindDirect,
+		DirectKey: directKey,
+		CreatedBy: actorID,
+	}, []uuid.UUID{actorID, userID})
+	if errors.Is(err, repo.ErrDirectConversationExists) {
+		// Création concurrente par l'autre participant : on renvoie la sienne.
+		existing, getErr := s.conversationRepo.GetDirectConversation(directKey)
+		if getErr != nil {
+			return nil, false, getErr
+		}
+		return existing, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return created, true, nil
+}
+
+func (s *ConversationService) restoreDirectMember(conversationID int, userID uuid.UUID) error {
+	_, err := s.conversationRepo.GetMembership(conversationID, userID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, repo.ErrMembershipNotFound) {
+		return err
+	}
+	if _, err := s.conversationRepo.CreateMembership(&models.ConversationMembership{
+		UserID:         userID,
+		ConversationID: conversationID,
+		Role:           models.ConversationRoleMember,
+	}); err != nil && !errors.Is(err, repo.ErrMembershipAlreadyExists) {
+		return err
+	}
+	return nil
+}
+
+// requireGroupConversation refuse les opérations de gestion de groupe sur une conversation directe.
+func (s *ConversationService) requireGroupConversation(conversationID int) error {
+	conversation, err := s.conversationRepo.GetConversationByID(conversationID)
+	if err != nil {
+		return err
+	}
+	if conversation.IsDirect() {
+		return ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
+)
+
+func TestConversationServiceDirectGetOrCreate(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+
+	first, created, err := svc.GetOrCreateDirectConversation(testUserOwner, testUserMember)
+	if err != nil {
+		t.Fatalf("GetOrCreateDirectConversation() error = %v", err)
+	}
+	if !created || !first.IsDirect() {
+		t.Fatalf("expected a new direct conversation, got created=%v kind=%q", created, first.Kind)
+	}
+	if first.DirectPeer(testUserOwner) != testUserMember || first.DirectPeer(testUserMember) != testUserOwner {
+		t.Fatalf("unexpected direct peers for key %q", first.DirectKey)
+	}
+
+	// Ordre inversé : même conversation.
+	second, created, err := svc.GetOrCreateDirectConversation(testUserMember, testUserOwner)
+	if err != nil {
+		t.Fatalf("GetOrCreateDirectConversation(reversed) error = %v", err)
+	}
+	if created || second.ID != first.ID {
+		t.Fatalf("expected existing conversation %d, got %d (created=%v)", first.ID, second.ID, created)
+	}
+
+	conversations, err := svc.ListConversationsByUser(testUserMember)
+	if err != nil {
+		t.Fatalf("ListConversationsByUser() error = %v", err)
+	}
+	if len(conversations) != 1 || !conversations[0].IsDirect() {
+		t.Fatalf("expected one direct conversation in list, got %+v", conversations)
+	}
+
+	if _, _, err := svc.GetOrCreateDirectConversation(testUserOwner, testUserOwner); !errors.Is(err, ErrInvalidConversation) {
+		t.Fatalf("self direct conversation: expected ErrInvalidConversation, got %v", err)
+	}
+}
+
+func TestConversationServiceDirectRejectsGroupManagement(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+
+	direct, _, err := svc.GetOrCreateDirectConversation(testUserOwner, testUserMember)
+	if err != nil {
+		t.Fatalf("GetOrCreateDirectConversation() error = %v", err)
+	}
+
+	if _, err := svc.AddMember(testUserOwner, direct.ID, testUserOther, models.ConversationRoleMember); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("AddMember on direct: expected ErrForbidden, got %v", err)
+	}
+	if err := svc.RemoveMember(testUserOwner, direct.ID, testUserMember); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("RemoveMember on direct: expected ErrForbidden, got %v", err)
+	}
+	if _, err := svc.UpdateMemberRole(testUserOwner, direct.ID, testUserMember, models.ConversationRoleAdmin); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("UpdateMemberRole on direct: expected ErrForbidden, got %v", err)
+	}
+
+	// Un participant parti n'est pas réintégré par l'autre ; il l'est en rouvrant lui-même le DM.
+	if err := svc.LeaveConversation(testUserMember, direct.ID); err != nil {
+		t.Fatalf("LeaveConversation() error = %v", err)
+	}
+	if _, _, err := svc.GetOrCreateDirectConversation(testUserOwner, testUserMember); err != nil {
+		t.Fatalf("GetOrCreateDirectConversation(after leave) error = %v", err)
+	}
+	if isMember, err := svc.IsMember(testUserMember, direct.ID); err != nil || isMember {
+		t.Fatalf("peer who left must not be restored by the other participant, isMember=%v err=%v", isMember, err)
+	}
+	reopened, _, err := svc.GetOrCreateDirectConversation(testUserMember, testUserOwner)
+	if err != nil || reopened.ID != direct.ID {
+		t.Fatalf("GetOrCreateDirectConversation(by the peer) = %+v, %v", reopened, err)
+	}
+	isMember, err := svc.IsMember(testUserMember, direct.ID)
+	if err != nil || !isMember {
+		t.Fatalf("member should be restored when reopening, isMember=%v err=%v", isMember, err)
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+const (
+	maxInviteUses     = 1000
+	maxInviteLifetime = 30 * 24 * time.Hour
+	inviteTokenBytes  = 16
+)
+
+// CreateInvite crée un lien d'invitation vers un groupe (admin/owner).
+// expiresAt nil : pas d'expiration ; maxUses 0 : illimité.
+func (s *ConversationService) CreateInvite(actorID uuid.UUID, conversationID int, expiresAt *time.Time, maxUses int, now time.Time) (*models.ConversationInvite, error) {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return nil, err
+	}
+	if maxUses < 0 || maxUses > maxInviteUses {
+		return nil, fmt.Errorf("%w: max_uses must be between 0 and %d", ErrInvalidConversation, maxInviteUses)
+	}
+	if expiresAt != nil {
+		if !expiresAt.After(now) {
+			return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidConversation)
+		}
+		if expiresAt.Sub(now) > maxInviteLifetime {
+			return nil, fmt.Errorf("%w: expires_at too far in the future", ErrInvalidConversation)
+		}
+	}
+	if err := s.requireGroupConversation(conversationID); err != nil {
+		return nil, err
+	}
+	if _, err := s.requireConversationManager(conversationID, actorID); err != nil {
+		return nil, err
+	}
+
+	token, err := newInviteToken()
+	if err != nil {
+		return nil, err
+	}
+	return s.conversationRepo.CreateInvite(&models.ConversationInvite{
+		Token:          token,
+		ConversationID: conversationID,
+		CreatedBy:      actorID,
+		ExpiresAt:      expiresAt,
+		MaxUses:        maxUses,
+		CreatedAt:      now,
+	})
+}
+
+// ListInvites : invitations du groupe, révoquées et expirées comprises (admin/owner).
+func (s *ConversationService) ListInvites(actorID uuid.UUID, conversationID int) ([]*models.ConversationInvite, error) {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return nil, err
+	}
+	if _, err := s.requireConversationManager(conversationID, actorID); err != nil {
+		return nil, err
+	}
+	return s.conversationRepo.ListInvites(conversationID)
+}
+
+// RevokeInvite désactive définitivement une invitation (admin/owner). Idempotent.
+func (s *ConversationService) RevokeInvite(actorID uuid.UUID, conversationID, inviteID int, now time.Time) (*models.ConversationInvite, error) {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return nil, err
+	}
+	if inviteID <= 0 {
+		return nil, repo.ErrInviteNotFound
+	}
+	if _, err := s.requireConversationManager(conversationID, actorID); err != nil {
+		return nil, err
+	}
+
+	invite, err := s.conversationRepo.GetInviteByID(inviteID)
+	if err != nil {
+		return nil, err
+	}
+	if invite.ConversationID != conversationID {
+		return nil, repo.ErrInviteNotFound
+	}
+	return s.conversationRepo.RevokeInvite(inviteID, now)
+}
+
+// JoinByInvite fait rejoindre userID au groupe de l'invitation, avec le rôle membre.
+// Les contrôles (révocation, expiration, quota) et l'audit sont atomiques côté repo.
+func (s *ConversationService) JoinByInvite(userID uuid.UUID, token string, now time.Time) (*models.Conversation, *models.ConversationMembership, error) {
+	if userID == uuid.Nil {
+		return nil, nil, ErrInvalidUserID
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, nil, repo.ErrInviteNotFound
+	}
+
+	invite, membership, err := s.conversationRepo.RedeemInvite(token, userID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	conversation, err := s.conversationRepo.GetConversationByID(invite.ConversationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.postSystemMessage(conversation.ID, memberEvent(models.SystemEventMemberJoined, userID, uuid.Nil))
+	return conversation, membership, nil
+}
+
+func newInviteToken() (string, error) {
+	buf := make([]byte, inviteTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate invite token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
+)
+
+func TestConversationServiceCreateInvite(t *testing.T) {
+	conversationRepo := memory.NewConversationRepo()
+	svc := NewConversationService(conversationRepo)
+	now := time.Now()
+
+	conversation, err := svc.CreateConversation(testUserOwner, "Équipe", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	if _, err := svc.AddMember(testUserOwner, conversation.ID, testUserMember, models.ConversationRoleMember); err != nil {
+		t.Fatalf("AddMember(member) error = %v", err)
+	}
+
+	if _, err := svc.CreateInvite(testUserMember, conversation.ID, nil, 0, now); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("member invite creation should be forbidden, got %v", err)
+	}
+	past := now.Add(-time.Minute)
+	if _, err := svc.CreateInvite(testUserOwner, conversation.ID, &past, 0, now); !errors.Is(err, ErrInvalidConversation) {
+		t.Fatalf("past expiry: expected ErrInvalidConversation, got %v", err)
+	}
+	if _, err := svc.CreateInvite(testUserOwner, conversation.ID, nil, maxInviteUses+1, now); !errors.Is(err, ErrInvalidConversation) {
+		t.Fatalf("too many uses: expected ErrInvalidConversation, got %v", err)
+	}
+
+	expiresAt := now.Add(time.Hour)
+	first, err := svc.CreateInvite(testUserOwner, conversation.ID, &expiresAt, 5, now)
+	if err != nil {
+		t.Fatalf("CreateInvite() error = %v", err)
+	}
+	second, err := svc.CreateInvite(testUserOwner, conversation.ID, nil, 0, now)
+	if err != nil {
+		t.Fatalf("CreateInvite() error = %v", err)
+	}
+	if first.Token == "" || first.Token == second.Token {
+		t.Fatalf("expected distinct non-empty tokens, got %q and %q", first.Token, second.Token)
+	}
+	if first.MaxUses != 5 || first.ExpiresAt == nil || first.CreatedBy != testUserOwner {
+		t.Fatalf("unexpected invite %+v", first)
+	}
+
+	if _, err := svc.ListInvites(testUserMember, conversation.ID); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("member listing should be forbidden, got %v", err)
+	}
+	invites, err := svc.ListInvites(testUserOwner, conversation.ID)
+	if err != nil {
+		t.Fatalf("ListInvites() error = %v", err)
+	}
+	if len(invites) != 2 {
+		t.Fatalf("expected 2 invites, got %d", len(invites))
+	}
+}
+
+func TestConversationServiceCreateInviteRejectsDirect(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+
+	direct, _, err := svc.GetOrCreateDirectConversation(testUserOwner, testUserMember)
+	if err != nil {
+		t.Fatalf("GetOrCreateDirectConversation() error = %v", err)
+	}
+	if _, err := svc.CreateInvite(testUserOwner, direct.ID, nil, 0, time.Now()); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("direct conversation invite should be forbidden, got %v", err)
+	}
+}
+
+func TestConversationServiceJoinByInvite(t *testing.T) {
+	conversationRepo := memory.NewConversationRepo()
+	svc := NewConversationService(conversationRepo)
+	now := time.Now()
+
+	conversation, err := svc.CreateConversation(testUserOwner, "Équipe", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	invite, err := svc.CreateInvite(testUserOwner, conversation.ID, nil, 1, now)
+	if err != nil {
+		t.Fatalf("CreateInvite() error = %v", err)
+	}
+
+	if _, _, err := svc.JoinByInvite(testUserMember, "unknown", now); !errors.Is(err, repo.ErrInviteNotFound) {
+		t.Fatalf("unknown token: expected ErrInviteNotFound, got %v", err)
+	}
+
+	joined, membership, err := svc.JoinByInvite(testUserMember, invite.Token, now)
+	if err != nil {
+		t.Fatalf("JoinByInvite() error = %v", err)
+	}
+	if joined.ID != conversation.ID || membership.Role != models.ConversationRoleMember || membership.UserID != testUserMember {
+		t.Fatalf("unexpected join result %+v / %+v", joined, membership)
+	}
+	if isMember, err := svc.IsMember(testUserMember, conversation.ID); err != nil || !isMember {
+		t.Fatalf("member should have joined the conversation (err = %v)", err)
+	}
+
+	// Quota atteint : un second utilisateur est refusé.
+	if _, _, err := svc.JoinByInvite(testUserOther, invite.Token, now); !errors.Is(err, repo.ErrInviteExhausted) {
+		t.Fatalf("exhausted invite: expected ErrInviteExhausted, got %v", err)
+	}
+
+	uses, err := conversationRepo.ListInviteUses(invite.ID)
+	if err != nil {
+		t.Fatalf("ListInviteUses() error = %v", err)
+	}
+	if len(uses) != 1 || uses[0].UserID != testUserMember {
+		t.Fatalf("expected one audited use by member, got %+v", uses)
+	}
+}
+
+func TestConversationServiceJoinByInviteRejectsUnusable(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+	now := time.Now()
+
+	conversation, err := svc.CreateConversation(testUserOwner, "Équipe", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+
+	expiresAt := now.Add(time.Hour)
+	expiring, err := svc.CreateInvite(testUserOwner, conversation.ID, &expiresAt, 0, now)
+	if err != nil {
+		t.Fatalf("CreateInvite() error = %v", err)
+	}
+	if _, _, err := svc.JoinByInvite(testUserMember, expiring.Token, expiresAt); !errors.Is(err, repo.ErrInviteExpired) {
+		t.Fatalf("expired invite: expected ErrInviteExpired, got %v", err)
+	}
+
+	revoked, err := svc.CreateInvite(testUserOwner, conversation.ID, nil, 0, now)
+	if err != nil {
+		t.Fatalf("CreateInvite() error = %v", err)
+	}
+	if _, err := svc.RevokeInvite(testUserOwner, conversation.ID+1, revoked.ID, now); err == nil {
+		t.Fatalf("revoking through another conversation should fail")
+	}
+	if _, err := svc.RevokeInvite(testUserOwner, conversation.ID, revoked.ID, now); err != nil {
+		t.Fatalf("RevokeInvite() error = %v", err)
+	}
+	if _, _, err := svc.JoinByInvite(testUserMember, revoked.Token, now); !errors.Is(err, repo.ErrInviteRevoked) {
+		t.Fatalf("revoked invite: expected ErrInviteRevoked, got %v", err)
+	}
+
+	// Déjà membre : conflit, sans consommer l'invitation.
+	open, err := svc.CreateInvite(testUserOwner, conversation.ID, nil, 0, now)
+	if err != nil {
+		t.Fatalf("CreateInvite() error = %v", err)
+	}
+	if _, _, err := svc.JoinByInvite(testUserOwner, open.Token, now); !errors.Is(err, repo.ErrMembershipAlreadyExists) {
+		t.Fatalf("existing member: expected ErrMembershipAlreadyExists, got %v", err)
+	}
+}
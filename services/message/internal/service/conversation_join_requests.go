@@ -0,0 +1,148 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+const (
+	maxJoinRequestMessageChars = 500
+	// JoinRequestCooldown : délai minimal entre deux demandes d'un même utilisateur pour un groupe
+	// (chaque demande notifie tous les admins).
+	JoinRequestCooldown = 24 * time.Hour
+)
+
+var (
+	// ErrJoinRequestsDisabled enveloppe ErrForbidden : le groupe n'accepte pas de demandes d'adhésion.
+	ErrJoinRequestsDisabled = fmt.Errorf("%w: this group does not accept join requests", ErrForbidden)
+	ErrJoinRequestTooSoon   = errors.New("join request sent too recently")
+)
+
+// RequestToJoin enregistre une demande d'adhésion de userID au groupe, à valider par un admin/owner.
+// Le groupe doit accepter les demandes (permission join_requests_enabled) et l'utilisateur ne peut en
+// déposer qu'une par JoinRequestCooldown.
+func (s *ConversationService) RequestToJoin(userID uuid.UUID, conversationID int, message string, now time.Time) (*models.ConversationJoinRequest, error) {
+	if err := validateConversationAndUser(conversationID, userID); err != nil {
+		return nil, err
+	}
+	message = strings.TrimSpace(message)
+	if len([]rune(message)) > maxJoinRequestMessageChars {
+		return nil, fmt.Errorf("%w: join request message too long", ErrInvalidConversation)
+	}
+	conversation, err := s.conversationRepo.GetConversationByID(conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if conversation.IsDirect() {
+		return nil, ErrForbidden
+	}
+	if !conversation.Permissions.JoinRequestsEnabled {
+		return nil, ErrJoinRequestsDisabled
+	}
+
+	isMember, err := s.IsMember(userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if isMember {
+		return nil, repo.ErrMembershipAlreadyExists
+	}
+	if err := s.requireNotBanned(conversationID, userID); err != nil {
+		return nil, err
+	}
+	// Une demande en attente est signalée par CreateJoinRequest (ErrJoinRequestExists).
+	latest, err := s.conversationRepo.GetLatestJoinRequest(conversationID, userID)
+	if err != nil && !errors.Is(err, repo.ErrJoinRequestNotFound) {
+		return nil, err
+	}
+	if latest != nil && latest.Status != models.JoinRequestPending {
+		if wait := latest.CreatedAt.Add(JoinRequestCooldown).Sub(now); wait > 0 {
+			return nil, fmt.Errorf("%w (retry in %ds)", ErrJoinRequestTooSoon, int((wait+time.Second-1)/time.Second))
+		}
+	}
+
+	return s.conversationRepo.CreateJoinRequest(&models.ConversationJoinRequest{
+		ConversationID: conversationID,
+		UserID:         userID,
+		Message:        message,
+		CreatedAt:      now,
+	})
+}
+
+// ListJoinRequests : demandes en attente du groupe, plus anciennes d'abord (admin/owner).
+func (s *ConversationService) ListJoinRequests(actorID uuid.UUID, conversationID int) ([]*models.ConversationJoinRequest, error) {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return nil, err
+	}
+	if _, err := s.requireConversationManager(conversationID, actorID); err != nil {
+		return nil, err
+	}
+	return s.conversationRepo.ListJoinRequests(conversationID, models.JoinRequestPending)
+}
+
+// DecideJoinRequest approuve ou refuse une demande en attente (admin/owner).
+// En cas d'approbation, le membership (rôle member) est retourné.
+func (s *ConversationService) DecideJoinRequest(actorID uuid.UUID, conversationID, requestID int, approve bool, now time.Time) (*models.ConversationJoinRequest, *models.ConversationMembership, error) {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return nil, nil, err
+	}
+	if requestID <= 0 {
+		return nil, nil, repo.ErrJoinRequestNotFound
+	}
+	if _, err := s.requireConversationManager(conversationID, actorID); err != nil {
+		return nil, nil, err
+	}
+
+	request, err := s.conversationRepo.GetJoinRequest(requestID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if request.ConversationID != conversationID {
+		return nil, nil, repo.ErrJoinRequestNotFound
+	}
+
+	status := models.JoinRequestDenied
+	if approve {
+		status = models.JoinRequestApproved
+	}
+	// Banni après sa demande : la demande ne peut plus qu'être refusée.
+	if approve {
+		if err := s.requireNotBanned(conversationID, request.UserID); err != nil {
+			return nil, nil, err
+		}
+	}
+	// Demandeur devenu membre entre-temps (invitation, ajout direct) : rien de nouveau dans la timeline.
+	alreadyMember, err := s.IsMember(request.UserID, conversationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	decided, membership, err := s.conversationRepo.DecideJoinRequest(requestID, status, actorID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	if membership != nil && !alreadyMember {
+		s.postSystemMessage(conversationID, roleEvent(models.SystemEventMemberAdded, actorID, decided.UserID, membership.Role))
+	}
+	return decided, membership, nil
+}
+
+// ListManagerIDs : admins et owners du groupe (destinataires des notifications de demandes).
+func (s *ConversationService) ListManagerIDs(conversationID int) ([]uuid.UUID, error) {
+	memberships, err := s.conversationRepo.ListMemberships(conversationID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(memberships))
+	for _, membership := range memberships {
+		if membership.Role == models.ConversationRoleAdmin || membership.Role == models.ConversationRoleOwner {
+			ids = append(ids, membership.UserID)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
+)
+
+func TestConversationServiceJoinRequestApproval(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+	now := time.Now()
+
+	conversation, err := svc.CreateConversation(testUserOwner, "Équipe", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	if _, err := svc.AddMember(testUserOwner, conversation.ID, testUserAdmin, models.ConversationRoleAdmin); err != nil {
+		t.Fatalf("AddMember(admin) error = %v", err)
+	}
+	if _, err := svc.RequestToJoin(testUserMember, conversation.ID, "", now); !errors.Is(err, ErrJoinRequestsDisabled) || !errors.Is(err, ErrForbidden) {
+		t.Fatalf("closed group: expected ErrJoinRequestsDisabled, got %v", err)
+	}
+	enableJoinRequests(t, svc, conversation.ID)
+
+	if _, err := svc.RequestToJoin(testUserAdmin, conversation.ID, "", now); !errors.Is(err, repo.ErrMembershipAlreadyExists) {
+		t.Fatalf("member request: expected ErrMembershipAlreadyExists, got %v", err)
+	}
+
+	request, err := svc.RequestToJoin(testUserMember, conversation.ID, "  Bonjour  ", now)
+	if err != nil {
+		t.Fatalf("RequestToJoin() error = %v", err)
+	}
+	if request.Status != models.JoinRequestPending || request.Message != "Bonjour" {
+		t.Fatalf("unexpected request %+v", request)
+	}
+	if _, err := svc.RequestToJoin(testUserMember, conversation.ID, "", now); !errors.Is(err, repo.ErrJoinRequestExists) {
+		t.Fatalf("duplicate request: expected ErrJoinRequestExists, got %v", err)
+	}
+
+	if _, err := svc.ListJoinRequests(testUserMember, conversation.ID); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("non-member listing should be forbidden, got %v", err)
+	}
+	pending, err := svc.ListJoinRequests(testUserAdmin, conversation.ID)
+	if err != nil {
+		t.Fatalf("ListJoinRequests() error = %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != request.ID {
+		t.Fatalf("expected the pending request, got %+v", pending)
+	}
+
+	managers, err := svc.ListManagerIDs(conversation.ID)
+	if err != nil {
+		t.Fatalf("ListManagerIDs() error = %v", err)
+	}
+	if len(managers) != 2 {
+		t.Fatalf("expected owner and admin, got %v", managers)
+	}
+
+	decided, membership, err := svc.DecideJoinRequest(testUserAdmin, conversation.ID, request.ID, true, now)
+	if err != nil {
+		t.Fatalf("DecideJoinRequest() error = %v", err)
+	}
+	if decided.Status != models.JoinRequestApproved || decided.DecidedBy != testUserAdmin || decided.DecidedAt == nil {
+		t.Fatalf("unexpected decided request %+v", decided)
+	}
+	if membership == nil || membership.UserID != testUserMember || membership.Role != models.ConversationRoleMember {
+		t.Fatalf("unexpected membership %+v", membership)
+	}
+	if _, _, err := svc.DecideJoinRequest(testUserOwner, conversation.ID, request.ID, false, now); !errors.Is(err, repo.ErrJoinRequestDecided) {
+		t.Fatalf("second decision: expected ErrJoinRequestDecided, got %v", err)
+	}
+	pending, err = svc.ListJoinRequests(testUserAdmin, conversation.ID)
+	if err != nil || len(pending) != 0 {
+		t.Fatalf("expected no pending request, got %+v (err = %v)", pending, err)
+	}
+}
+
+func TestConversationServiceJoinRequestDenial(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+	now := time.Now()
+
+	conversation, err := svc.CreateConversation(testUserOwner, "Équipe", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	other, err := svc.CreateConversation(testUserOwner, "Autre", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	enableJoinRequests(t, svc, conversation.ID)
+	request, err := svc.RequestToJoin(testUserOther, conversation.ID, "", now)
+	if err != nil {
+		t.Fatalf("RequestToJoin() error = %v", err)
+	}
+
+	if _, _, err := svc.DecideJoinRequest(testUserOwner, other.ID, request.ID, true, now); !errors.Is(err, repo.ErrJoinRequestNotFound) {
+		t.Fatalf("cross-conversation decision: expected ErrJoinRequestNotFound, got %v", err)
+	}
+
+	decided, membership, err := svc.DecideJoinRequest(testUserOwner, conversation.ID, request.ID, false, now)
+	if err != nil {
+		t.Fatalf("DecideJoinRequest() error = %v", err)
+	}
+	if decided.Status != models.JoinRequestDenied || membership != nil {
+		t.Fatalf("unexpected denial result %+v / %+v", decided, membership)
+	}
+	if isMember, _ := svc.IsMember(testUserOther, conversation.ID); isMember {
+		t.Fatalf("denied user should not be a member")
+	}
+
+	// Après un refus, une nouvelle demande n'est possible qu'une fois le délai écoulé.
+	if _, err := svc.RequestToJoin(testUserOther, conversation.ID, "", now.Add(time.Hour)); !errors.Is(err, ErrJoinRequestTooSoon) {
+		t.Fatalf("repeated request: expected ErrJoinRequestTooSoon, got %v", err)
+	}
+	if _, err := svc.RequestToJoin(testUserOther, conversation.ID, "", now.Add(JoinRequestCooldown+time.Minute)); err != nil {
+		t.Fatalf("RequestToJoin() after cooldown error = %v", err)
+	}
+}
+
+func enableJoinRequests(t *testing.T, svc *ConversationService, conversationID int) {
+	t.Helper()
+	if _, _, err := svc.UpdatePermissions(testUserOwner, conversationID, ConversationPermissionsUpdate{JoinRequestsEnabled: boolPtr(true)}); err != nil {
+		t.Fatalf("UpdatePermissions(join_requests_enabled) error = %v", err)
+	}
+}
+
+func TestConversationServiceJoinRequestRejectsDirect(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+
+	direct, _, err := svc.GetOrCreateDirectConversation(testUserOwner, testUserMember)
+	if err != nil {
+		t.Fatalf("GetOrCreateDirectConversation() error = %v", err)
+	}
+	if _, err := svc.RequestToJoin(testUserOther, direct.ID, "", time.Now()); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("direct conversation join request should be forbidden, got %v", err)
+	}
+}
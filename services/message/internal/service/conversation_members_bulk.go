@@ -0,0 +1,193 @@
+package service
+
+import (
+	"fmt"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+// maxBulkMembers : nombre maximal d'utilisateurs par opération groupée.
+const maxBulkMembers = 100
+
+// TransferOwnership cède la propriété du groupe à un membre : actorID (owner) devient admin,
+// userID devient owner, atomiquement. Retourne l'ancien puis le nouveau membership.
+func (s *ConversationService) TransferOwnership(actorID uuid.UUID, conversationID int, userID uuid.UUID) (*models.ConversationMembership, *models.ConversationMembership, error) {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return nil, nil, err
+	}
+	if userID == uuid.Nil {
+		return nil, nil, ErrInvalidUserID
+	}
+	if userID == actorID {
+		return nil, nil, fmt.Errorf("%w: cannot transfer ownership to yourself", ErrInvalidMembershipRole)
+	}
+	if err := s.requireGroupConversation(conversationID); err != nil {
+		return nil, nil, err
+	}
+
+	actorMembership, err := s.requireActorMembership(conversationID, actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if actorMembership.Role != models.ConversationRoleOwner {
+		return nil, nil, ErrForbidden
+	}
+	target, err := s.conversationRepo.GetMembership(conversationID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if target.Role == models.ConversationRoleOwner {
+		return nil, nil, fmt.Errorf("%w: user is already owner", ErrInvalidMembershipRole)
+	}
+
+	previousOwner, newOwner, err := s.conversationRepo.TransferOwnership(conversationID, actorID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.postSystemMessage(conversationID, memberEvent(models.SystemEventOwnershipTransferred, actorID, userID))
+	return previousOwner, newOwner, nil
+}
+
+// AddMembers ajoute plusieurs utilisateurs en une transaction, mêmes règles que AddMember.
+// Les erreurs propres à un utilisateur (déjà membre, identifiant vide, blocage, bannissement) sont dans son résultat ;
+// l'erreur retournée concerne la requête entière. Les doublons sont ignorés.
+func (s *ConversationService) AddMembers(actorID uuid.UUID, conversationID int, userIDs []uuid.UUID, role models.ConversationRole) ([]repo.MembershipResult, error) {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return nil, err
+	}
+	if !models.IsValidConversationRole(role) {
+		return nil, ErrInvalidMembershipRole
+	}
+	if err := validateBulkUserIDs(userIDs); err != nil {
+		return nil, err
+	}
+	if err := s.requireGroupConversation(conversationID); err != nil {
+		return nil, err
+	}
+
+	actorMembership, err := s.requireMemberPermission(conversationID, actorID, canMembersAdd)
+	if err != nil {
+		return nil, err
+	}
+	if actorMembership.Role != models.ConversationRoleOwner && role != models.ConversationRoleMember {
+		return nil, ErrForbidden
+	}
+
+	ordered, valid := splitBulkUserIDs(userIDs)
+	// Les utilisateurs qui ont bloqué l'acteur ou qui sont bannis ne sont pas ajoutés
+	// (ErrBlocked / repo.ErrUserBanned dans leur résultat).
+	allowed := make([]uuid.UUID, 0, len(valid))
+	blocked := make([]repo.MembershipResult, 0)
+	for _, userID := range valid {
+		err := s.requireNotBlocked(userID, actorID)
+		if err == nil {
+			err = s.requireNotBanned(conversationID, userID)
+		}
+		if err != nil {
+			blocked = append(blocked, repo.MembershipResult{UserID: userID, Err: err})
+			continue
+		}
+		allowed = append(allowed, userID)
+	}
+	saved, err := s.conversationRepo.AddMemberships(conversationID, allowed, role)
+	if err != nil {
+		return nil, err
+	}
+	results := mergeBulkResults(ordered, append(saved, blocked...))
+	for _, result := range results {
+		if result.Err == nil {
+			s.postSystemMessage(conversationID, roleEvent(models.SystemEventMemberAdded, actorID, result.UserID, role))
+		}
+	}
+	return results, nil
+}
+
+// RemoveMembers retire plusieurs membres en une transaction, mêmes règles que RemoveMember
+// (un admin ne retire que des membres). L'acteur ne peut pas se retirer ici (GROUP_LEAVE) :
+// un owner qui retire d'autres owners reste donc owner, la garde du dernier owner est respectée.
+func (s *ConversationService) RemoveMembers(actorID uuid.UUID, conversationID int, userIDs []uuid.UUID) ([]repo.MembershipResult, error) {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return nil, err
+	}
+	if err := validateBulkUserIDs(userIDs); err != nil {
+		return nil, err
+	}
+	if err := s.requireGroupConversation(conversationID); err != nil {
+		return nil, err
+	}
+
+	actorMembership, err := s.requireConversationManager(conversationID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	check := func(target *models.ConversationMembership) error {
+		if target.UserID == actorID {
+			return fmt.Errorf("%w: use leave to remove yourself", ErrForbidden)
+		}
+		if actorMembership.Role == models.ConversationRoleAdmin && target.Role != models.ConversationRoleMember {
+			return ErrForbidden
+		}
+		return nil
+	}
+
+	ordered, valid := splitBulkUserIDs(userIDs)
+	removed, err := s.conversationRepo.RemoveMemberships(conversationID, valid, check)
+	if err != nil {
+		return nil, err
+	}
+	results := mergeBulkResults(ordered, removed)
+	for _, result := range results {
+		if result.Err == nil {
+			s.postSystemMessage(conversationID, memberEvent(models.SystemEventMemberRemoved, actorID, result.UserID))
+		}
+	}
+	return results, nil
+}
+
+func validateBulkUserIDs(userIDs []uuid.UUID) error {
+	if len(userIDs) == 0 {
+		return fmt.Errorf("%w: user_ids required", ErrInvalidUserID)
+	}
+	if len(userIDs) > maxBulkMembers {
+		return fmt.Errorf("%w: at most %d users per request", ErrInvalidConversation, maxBulkMembers)
+	}
+	return nil
+}
+
+// splitBulkUserIDs dédoublonne userIDs (ordre conservé) et isole les identifiants valides envoyés au repo.
+func splitBulkUserIDs(userIDs []uuid.UUID) ([]uuid.UUID, []uuid.UUID) {
+	seen := make(map[uuid.UUID]struct{}, len(userIDs))
+	ordered := make([]uuid.UUID, 0, len(userIDs))
+	valid := make([]uuid.UUID, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if _, ok := seen[userID]; ok {
+			continue
+		}
+		seen[userID] = struct{}{}
+		ordered = append(ordered, userID)
+		if userID != uuid.Nil {
+			valid = append(valid, userID)
+		}
+	}
+	return ordered, valid
+}
+
+// mergeBulkResults replace les résultats du repo dans l'ordre de la requête (uuid.Nil : ErrInvalidUserID).
+func mergeBulkResults(ordered []uuid.UUID, fromRepo []repo.MembershipResult) []repo.MembershipResult {
+	byUser := make(map[uuid.UUID]repo.MembershipResult, len(fromRepo))
+	for _, result := range fromRepo {
+		byUser[result.UserID] = result
+	}
+	results := make([]repo.MembershipResult, 0, len(ordered))
+	for _, userID := range ordered {
+		result, ok := byUser[userID]
+		if !ok {
+			result = repo.MembershipResult{UserID: userID, Err: ErrInvalidUserID}
+		}
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
+	"github.com/google/uuid"
+)
+
+func TestConversationServiceTransferOwnership(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+
+	conversation, err := svc.CreateConversation(testUserOwner, "Équipe", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	if _, err := svc.AddMember(testUserOwner, conversation.ID, testUserMember, models.ConversationRoleMember); err != nil {
+		t.Fatalf("AddMember() error = %v", err)
+	}
+
+	if _, _, err := svc.TransferOwnership(testUserMember, conversation.ID, testUserOwner); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("member transfer should be forbidden, got %v", err)
+	}
+	if _, _, err := svc.TransferOwnership(testUserOwner, conversation.ID, testUserOwner); !errors.Is(err, ErrInvalidMembershipRole) {
+		t.Fatalf("self transfer: expected ErrInvalidMembershipRole, got %v", err)
+	}
+	if _, _, err := svc.TransferOwnership(testUserOwner, conversation.ID, testUserOther); !errors.Is(err, repo.ErrMembershipNotFound) {
+		t.Fatalf("non-member transfer: expected ErrMembershipNotFound, got %v", err)
+	}
+
+	previous, next, err := svc.TransferOwnership(testUserOwner, conversation.ID, testUserMember)
+	if err != nil {
+		t.Fatalf("TransferOwnership() error = %v", err)
+	}
+	if previous.UserID != testUserOwner || previous.Role != models.ConversationRoleAdmin {
+		t.Fatalf("previous owner should be admin, got %+v", previous)
+	}
+	if next.UserID != testUserMember || next.Role != models.ConversationRoleOwner {
+		t.Fatalf("new owner should be owner, got %+v", next)
+	}
+	if owners, err := svc.conversationRepo.CountOwners(conversation.ID); err != nil || owners != 1 {
+		t.Fatalf("expected exactly one owner, got %d (%v)", owners, err)
+	}
+
+	// L'ancien owner, désormais admin, ne peut plus transférer.
+	if _, _, err := svc.TransferOwnership(testUserOwner, conversation.ID, testUserMember); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("former owner transfer should be forbidden, got %v", err)
+	}
+}
+
+func TestConversationServiceTransferOwnershipRejectsDirect(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+
+	direct, _, err := svc.GetOrCreateDirectConversation(testUserOwner, testUserMember)
+	if err != nil {
+		t.Fatalf("GetOrCreateDirectConversation() error = %v", err)
+	}
+	if _, _, err := svc.TransferOwnership(testUserOwner, direct.ID, testUserMember); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("direct conversation transfer should be forbidden, got %v", err)
+	}
+}
+
+func TestConversationServiceAddMembers(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+
+	conversation, err := svc.CreateConversation(testUserOwner, "Équipe", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	if _, err := svc.AddMember(testUserOwner, conversation.ID, testUserAdmin, models.ConversationRoleAdmin); err != nil {
+		t.Fatalf("AddMember(admin) error = %v", err)
+	}
+
+	if _, err := svc.AddMembers(testUserOwner, conversation.ID, nil, models.ConversationRoleMember); !errors.Is(err, ErrInvalidUserID) {
+		t.Fatalf("empty list: expected ErrInvalidUserID, got %v", err)
+	}
+	tooMany := make([]uuid.UUID, maxBulkMembers+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.New()
+	}
+	if _, err := svc.AddMembers(testUserOwner, conversation.ID, tooMany, models.ConversationRoleMember); !errors.Is(err, ErrInvalidConversation) {
+		t.Fatalf("too many users: expected ErrInvalidConversation, got %v", err)
+	}
+	if _, err := svc.AddMembers(testUserAdmin, conversation.ID, []uuid.UUID{testUserMember}, models.ConversationRoleAdmin); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("admin adding admins should be forbidden, got %v", err)
+	}
+
+	results, err := svc.AddMembers(testUserAdmin, conversation.ID, []uuid.UUID{testUserMember, testUserOwner, uuid.Nil, testUserOther, testUserMember}, models.ConversationRoleMember)
+	if err != nil {
+		t.Fatalf("AddMembers() error = %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results (duplicates ignored), got %d", len(results))
+	}
+	if results[0].UserID != testUserMember || results[0].Err != nil || results[0].Membership == nil {
+		t.Fatalf("member should be added, got %+v", results[0])
+	}
+	if !errors.Is(results[1].Err, repo.ErrMembershipAlreadyExists) {
+		t.Fatalf("owner: expected ErrMembershipAlreadyExists, got %v", results[1].Err)
+	}
+	if !errors.Is(results[2].Err, ErrInvalidUserID) {
+		t.Fatalf("nil user: expected ErrInvalidUserID, got %v", results[2].Err)
+	}
+	if results[3].UserID != testUserOther || results[3].Err != nil {
+		t.Fatalf("other should be added, got %+v", results[3])
+	}
+
+	members, err := svc.ListMembers(testUserOwner, conversation.ID)
+	if err != nil {
+		t.Fatalf("ListMembers() error = %v", err)
+	}
+	if len(members) != 4 {
+		t.Fatalf("expected 4 members, got %d", len(members))
+	}
+}
+
+func TestConversationServiceRemoveMembers(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+
+	conversation, err := svc.CreateConversation(testUserOwner, "Équipe", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	if _, err := svc.AddMembers(testUserOwner, conversation.ID, []uuid.UUID{testUserAdmin}, models.ConversationRoleAdmin); err != nil {
+		t.Fatalf("AddMembers(admin) error = %v", err)
+	}
+	if _, err := svc.AddMembers(testUserOwner, conversation.ID, []uuid.UUID{testUserMember, testUserOther}, models.ConversationRoleMember); err != nil {
+		t.Fatalf("AddMembers(members) error = %v", err)
+	}
+
+	if _, err := svc.RemoveMembers(testUserMember, conversation.ID, []uuid.UUID{testUserOther}); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("member bulk remove should be forbidden, got %v", err)
+	}
+
+	results, err := svc.RemoveMembers(testUserAdmin, conversation.ID, []uuid.UUID{testUserOwner, testUserAdmin, testUserMember, uuid.New()})
+	if err != nil {
+		t.Fatalf("RemoveMembers() error = %v", err)
+	}
+	if !errors.Is(results[0].Err, ErrForbidden) {
+		t.Fatalf("admin removing owner: expected ErrForbidden, got %v", results[0].Err)
+	}
+	if !errors.Is(results[1].Err, ErrForbidden) {
+		t.Fatalf("self removal: expected ErrForbidden, got %v", results[1].Err)
+	}
+	if results[2].Err != nil || results[2].UserID != testUserMember {
+		t.Fatalf("member should be removed, got %+v", results[2])
+	}
+	if !errors.Is(results[3].Err, repo.ErrMembershipNotFound) {
+		t.Fatalf("unknown user: expected ErrMembershipNotFound, got %v", results[3].Err)
+	}
+
+	// L'owner peut retirer un admin ; il reste owner lui-même.
+	results, err = svc.RemoveMembers(testUserOwner, conversation.ID, []uuid.UUID{testUserAdmin, testUserOther})
+	if err != nil {
+		t.Fatalf("RemoveMembers(owner) error = %v", err)
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			t.Fatalf("owner bulk remove: unexpected error for %s: %v", result.UserID, result.Err)
+		}
+	}
+	members, err := svc.ListMembers(testUserOwner, conversation.ID)
+	if err != nil {
+		t.Fatalf("ListMembers() error = %v", err)
+	}
+	if len(members) != 1 || members[0].UserID != testUserOwner {
+		t.Fatalf("only the owner should remain, got %+v", members)
+	}
+}
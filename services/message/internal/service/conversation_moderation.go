@@ -0,0 +1,236 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+const (
+	maxModerationReasonChars = 500
+	// MaxMuteDuration : durée maximale d'une sourdine.
+	MaxMuteDuration = 30 * 24 * time.Hour
+
+	DefaultAuditLogLimit = 50
+	MaxAuditLogLimit     = 200
+)
+
+var (
+	ErrAlreadyBanned       = errors.New("user is already banned")
+	ErrInvalidMuteDuration = errors.New("invalid mute duration")
+	// ErrMuted enveloppe ErrForbidden : l'auteur est en sourdine (échéance ajoutée au message).
+	ErrMuted = fmt.Errorf("%w: you are muted in this conversation", ErrForbidden)
+)
+
+// BanMember bannit userID du groupe (admin/owner, cible de rôle strictement inférieur) : son membership
+// éventuel est retiré et il ne peut plus être ajouté ni rejoindre. Un non-membre peut être banni.
+// removed indique qu'un membership actif a été retiré.
+func (s *ConversationService) BanMember(actorID uuid.UUID, conversationID int, userID uuid.UUID, reason string, now time.Time) (ban *models.ConversationBan, removed bool, err error) {
+	reason, err = s.prepareModeration(actorID, conversationID, userID, reason, true)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ban, removed, err = s.conversationRepo.BanMember(&models.ConversationBan{
+		ConversationID: conversationID,
+		UserID:         userID,
+		BannedBy:       actorID,
+		Reason:         reason,
+		CreatedAt:      now,
+	}, auditEntry(conversationID, actorID, models.AuditActionBan, userID, reason, nil, now))
+	if errors.Is(err, repo.ErrUserBanned) {
+		return nil, false, ErrAlreadyBanned
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if removed {
+		s.postSystemMessage(conversationID, memberEvent(models.SystemEventMemberBanned, actorID, userID))
+	}
+	return ban, removed, nil
+}
+
+// UnbanMember lève le bannissement (admin/owner) ; l'utilisateur n'est pas réintégré.
+func (s *ConversationService) UnbanMember(actorID uuid.UUID, conversationID int, userID uuid.UUID, now time.Time) error {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return err
+	}
+	if userID == uuid.Nil {
+		return ErrInvalidUserID
+	}
+	if err := s.requireGroupConversation(conversationID); err != nil {
+		return err
+	}
+	if _, err := s.requireConversationManager(conversationID, actorID); err != nil {
+		return err
+	}
+	return s.conversationRepo.UnbanMember(conversationID, userID,
+		auditEntry(conversationID, actorID, models.AuditActionUnban, userID, "", nil, now))
+}
+
+// ListBans : bannissements du groupe, plus récents d'abord (admin/owner).
+func (s *ConversationService) ListBans(actorID uuid.UUID, conversationID int) ([]*models.ConversationBan, error) {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return nil, err
+	}
+	if _, err := s.requireConversationManager(conversationID, actorID); err != nil {
+		return nil, err
+	}
+	return s.conversationRepo.ListBans(conversationID)
+}
+
+// MuteMember met un membre en sourdine pendant duration (admin/owner, cible de rôle strictement
+// inférieur). Une nouvelle sourdine remplace la précédente.
+func (s *ConversationService) MuteMember(actorID uuid.UUID, conversationID int, userID uuid.UUID, duration time.Duration, reason string, now time.Time) (*models.ConversationMute, error) {
+	if duration <= 0 || duration > MaxMuteDuration {
+		return nil, fmt.Errorf("%w: must be between 1s and %s", ErrInvalidMuteDuration, MaxMuteDuration)
+	}
+	reason, err := s.prepareModeration(actorID, conversationID, userID, reason, false)
+	if err != nil {
+		return nil, err
+	}
+
+	until := now.Add(duration)
+	return s.conversationRepo.MuteMember(&models.ConversationMute{
+		ConversationID: conversationID,
+		UserID:         userID,
+		MutedBy:        actorID,
+		MutedUntil:     until,
+		CreatedAt:      now,
+	}, auditEntry(conversationID, actorID, models.AuditActionMute, userID, reason, &until, now))
+}
+
+// UnmuteMember lève la sourdine avant son échéance (admin/owner).
+func (s *ConversationService) UnmuteMember(actorID uuid.UUID, conversationID int, userID uuid.UUID, now time.Time) error {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return err
+	}
+	if userID == uuid.Nil {
+		return ErrInvalidUserID
+	}
+	if err := s.requireGroupConversation(conversationID); err != nil {
+		return err
+	}
+	if _, err := s.requireConversationManager(conversationID, actorID); err != nil {
+		return err
+	}
+	return s.conversationRepo.UnmuteMember(conversationID, userID,
+		auditEntry(conversationID, actorID, models.AuditActionUnmute, userID, "", nil, now))
+}
+
+// KickMember retire un membre avec une raison journalisée (admin/owner, cible de rôle strictement
+// inférieur). Contrairement au bannissement, il peut être ajouté à nouveau.
+func (s *ConversationService) KickMember(actorID uuid.UUID, conversationID int, userID uuid.UUID, reason string, now time.Time) error {
+	reason, err := s.prepareModeration(actorID, conversationID, userID, reason, false)
+	if err != nil {
+		return err
+	}
+	if err := s.conversationRepo.KickMember(conversationID, userID,
+		auditEntry(conversationID, actorID, models.AuditActionKick, userID, reason, nil, now)); err != nil {
+		return err
+	}
+	s.postSystemMessage(conversationID, memberEvent(models.SystemEventMemberRemoved, actorID, userID))
+	return nil
+}
+
+// ListAuditLog : journal de modération du groupe, plus récent d'abord, paginé par beforeID (admin/owner).
+func (s *ConversationService) ListAuditLog(actorID uuid.UUID, conversationID, beforeID, limit int) ([]*models.AuditLogEntry, error) {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return nil, err
+	}
+	if beforeID < 0 {
+		beforeID = 0
+	}
+	if limit <= 0 {
+		limit = DefaultAuditLogLimit
+	}
+	if limit > MaxAuditLogLimit {
+		limit = MaxAuditLogLimit
+	}
+	if _, err := s.requireConversationManager(conversationID, actorID); err != nil {
+		return nil, err
+	}
+	return s.conversationRepo.ListAuditLog(conversationID, beforeID, limit)
+}
+
+// requireNotBanned : repo.ErrUserBanned si userID est banni du groupe.
+func (s *ConversationService) requireNotBanned(conversationID int, userID uuid.UUID) error {
+	banned, err := s.conversationRepo.IsBanned(conversationID, userID)
+	if err != nil {
+		return err
+	}
+	if banned {
+		return repo.ErrUserBanned
+	}
+	return nil
+}
+
+// requireNotMuted : ErrMuted si userID est en sourdine active à now.
+func (s *ConversationService) requireNotMuted(conversationID int, userID uuid.UUID, now time.Time) error {
+	mute, err := s.conversationRepo.GetMute(conversationID, userID)
+	if errors.Is(err, repo.ErrMuteNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if mute.IsActive(now) {
+		return fmt.Errorf("%w until %s", ErrMuted, mute.MutedUntil.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// prepareModeration valide une action de modération et retourne la raison normalisée : groupe,
+// acteur admin/owner, cible distincte de rôle strictement inférieur. allowNonMember : la cible
+// peut ne pas être membre (bannissement préventif).
+func (s *ConversationService) prepareModeration(actorID uuid.UUID, conversationID int, userID uuid.UUID, reason string, allowNonMember bool) (string, error) {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return "", err
+	}
+	if userID == uuid.Nil {
+		return "", ErrInvalidUserID
+	}
+	if userID == actorID {
+		return "", fmt.Errorf("%w: cannot moderate yourself", ErrForbidden)
+	}
+	reason = strings.TrimSpace(reason)
+	if len([]rune(reason)) > maxModerationReasonChars {
+		return "", fmt.Errorf("%w: reason too long", ErrInvalidConversation)
+	}
+	if err := s.requireGroupConversation(conversationID); err != nil {
+		return "", err
+	}
+
+	actorMembership, err := s.requireConversationManager(conversationID, actorID)
+	if err != nil {
+		return "", err
+	}
+	target, err := s.conversationRepo.GetMembership(conversationID, userID)
+	if errors.Is(err, repo.ErrMembershipNotFound) && allowNonMember {
+		return reason, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if target.Role >= actorMembership.Role {
+		return "", ErrForbidden
+	}
+	return reason, nil
+}
+
+func auditEntry(conversationID int, actorID uuid.UUID, action models.AuditAction, targetID uuid.UUID, reason string, expiresAt *time.Time, now time.Time) *models.AuditLogEntry {
+	return &models.AuditLogEntry{
+		ConversationID: conversationID,
+		ActorID:        actorID,
+		Action:         action,
+		TargetID:       targetID,
+		Reason:         reason,
+		ExpiresAt:      expiresAt,
+		CreatedAt:      now,
+	}
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+func TestConversationServiceBanMember(t *testing.T) {
+	svc, conversationID := newPermissionsFixture(t)
+	now := time.Now()
+
+	if _, _, err := svc.BanMember(testUserMember, conversationID, testUserOther, "", now); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("member ban should be forbidden, got %v", err)
+	}
+	if _, _, err := svc.BanMember(testUserAdmin, conversationID, testUserOwner, "", now); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("admin banning owner should be forbidden, got %v", err)
+	}
+	if _, _, err := svc.BanMember(testUserOwner, conversationID, testUserOwner, "", now); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("self ban should be forbidden, got %v", err)
+	}
+
+	ban, removed, err := svc.BanMember(testUserAdmin, conversationID, testUserMember, "  spam  ", now)
+	if err != nil {
+		t.Fatalf("BanMember() error = %v", err)
+	}
+	if !removed || ban.Reason != "spam" || ban.BannedBy != testUserAdmin {
+		t.Fatalf("unexpected ban %+v (removed = %v)", ban, removed)
+	}
+	if ok, _ := svc.IsMember(testUserMember, conversationID); ok {
+		t.Fatalf("banned member must have been removed")
+	}
+	if _, _, err := svc.BanMember(testUserOwner, conversationID, testUserMember, "", now); !errors.Is(err, ErrAlreadyBanned) {
+		t.Fatalf("second ban: expected ErrAlreadyBanned, got %v", err)
+	}
+
+	// Bannissement préventif d'un non-membre.
+	if _, removed, err := svc.BanMember(testUserOwner, conversationID, testUserOther, "", now); err != nil || removed {
+		t.Fatalf("ban non-member: removed = %v, err = %v", removed, err)
+	}
+	bans, err := svc.ListBans(testUserAdmin, conversationID)
+	if err != nil || len(bans) != 2 {
+		t.Fatalf("ListBans() = %+v, %v", bans, err)
+	}
+}
+
+func TestConversationServiceBanPreventsRejoin(t *testing.T) {
+	svc, conversationID := newPermissionsFixture(t)
+	enableJoinRequests(t, svc, conversationID)
+	now := time.Now()
+
+	invite, err := svc.CreateInvite(testUserOwner, conversationID, nil, 0, now)
+	if err != nil {
+		t.Fatalf("CreateInvite() error = %v", err)
+	}
+	if _, _, err := svc.BanMember(testUserOwner, conversationID, testUserMember, "", now); err != nil {
+		t.Fatalf("BanMember() error = %v", err)
+	}
+
+	if _, err := svc.AddMember(testUserOwner, conversationID, testUserMember, models.ConversationRoleMember); !errors.Is(err, repo.ErrUserBanned) {
+		t.Fatalf("re-add: expected ErrUserBanned, got %v", err)
+	}
+	results, err := svc.AddMembers(testUserOwner, conversationID, []uuid.UUID{testUserMember, testUserOther}, models.ConversationRoleMember)
+	if err != nil {
+		t.Fatalf("AddMembers() error = %v", err)
+	}
+	if len(results) != 2 || !errors.Is(results[0].Err, repo.ErrUserBanned) || results[1].Err != nil {
+		t.Fatalf("expected banned user refused and other added, got %+v", results)
+	}
+	if _, _, err := svc.JoinByInvite(testUserMember, invite.Token, now); !errors.Is(err, repo.ErrUserBanned) {
+		t.Fatalf("invite redemption: expected ErrUserBanned, got %v", err)
+	}
+	if _, err := svc.RequestToJoin(testUserMember, conversationID, "", now); !errors.Is(err, repo.ErrUserBanned) {
+		t.Fatalf("join request: expected ErrUserBanned, got %v", err)
+	}
+
+	if err := svc.UnbanMember(testUserAdmin, conversationID, testUserMember, now); err != nil {
+		t.Fatalf("UnbanMember() error = %v", err)
+	}
+	if err := svc.UnbanMember(testUserAdmin, conversationID, testUserMember, now); !errors.Is(err, repo.ErrBanNotFound) {
+		t.Fatalf("second unban: expected ErrBanNotFound, got %v", err)
+	}
+	if _, _, err := svc.JoinByInvite(testUserMember, invite.Token, now); err != nil {
+		t.Fatalf("unbanned user should join by invite, got %v", err)
+	}
+}
+
+func TestConversationServiceBanRefusesPendingJoinRequest(t *testing.T) {
+	svc, conversationID := newPermissionsFixture(t)
+	now := time.Now()
+
+	enableJoinRequests(t, svc, conversationID)
+	request, err := svc.RequestToJoin(testUserOther, conversationID, "", now)
+	if err != nil {
+		t.Fatalf("RequestToJoin() error = %v", err)
+	}
+	if _, _, err := svc.BanMember(testUserOwner, conversationID, testUserOther, "", now); err != nil {
+		t.Fatalf("BanMember() error = %v", err)
+	}
+	if _, _, err := svc.DecideJoinRequest(testUserOwner, conversationID, request.ID, true, now); !errors.Is(err, repo.ErrUserBanned) {
+		t.Fatalf("approve banned requester: expected ErrUserBanned, got %v", err)
+	}
+	if _, membership, err := svc.DecideJoinRequest(testUserOwner, conversationID, request.ID, false, now); err != nil || membership != nil {
+		t.Fatalf("deny banned requester: membership = %+v, err = %v", membership, err)
+	}
+}
+
+func TestConversationServiceMuteBlocksPosting(t *testing.T) {
+	svc, conversationID := newPermissionsFixture(t)
+	now := time.Now()
+
+	if _, err := svc.MuteMember(testUserOwner, conversationID, testUserMember, 0, "", now); !errors.Is(err, ErrInvalidMuteDuration) {
+		t.Fatalf("zero duration: expected ErrInvalidMuteDuration, got %v", err)
+	}
+	if _, err := svc.MuteMember(testUserOwner, conversationID, testUserMember, MaxMuteDuration+time.Second, "", now); !errors.Is(err, ErrInvalidMuteDuration) {
+		t.Fatalf("too long: expected ErrInvalidMuteDuration, got %v", err)
+	}
+	if _, err := svc.MuteMember(testUserAdmin, conversationID, testUserOther, time.Hour, "", now); !errors.Is(err, repo.ErrMembershipNotFound) {
+		t.Fatalf("mute non-member: expected ErrMembershipNotFound, got %v", err)
+	}
+
+	mute, err := svc.MuteMember(testUserAdmin, conversationID, testUserMember, time.Hour, "flood", now)
+	if err != nil {
+		t.Fatalf("MuteMember() error = %v", err)
+	}
+	if !mute.MutedUntil.Equal(now.Add(time.Hour)) {
+		t.Fatalf("unexpected muted_until %v", mute.MutedUntil)
+	}
+
+	err = svc.AuthorizePost(testUserMember, conversationID, now.Add(time.Minute), nil)
+	if !errors.Is(err, ErrMuted) || !errors.Is(err, ErrForbidden) {
+		t.Fatalf("muted member: expected ErrMuted (forbidden), got %v", err)
+	}
+	if err := svc.AuthorizePost(testUserMember, conversationID, now.Add(time.Hour+time.Second), nil); err != nil {
+		t.Fatalf("expired mute should allow posting, got %v", err)
+	}
+
+	if err := svc.UnmuteMember(testUserAdmin, conversationID, testUserMember, now); err != nil {
+		t.Fatalf("UnmuteMember() error = %v", err)
+	}
+	if err := svc.AuthorizePost(testUserMember, conversationID, now.Add(time.Minute), nil); err != nil {
+		t.Fatalf("unmuted member should post, got %v", err)
+	}
+	if err := svc.UnmuteMember(testUserAdmin, conversationID, testUserMember, now); !errors.Is(err, repo.ErrMuteNotFound) {
+		t.Fatalf("second unmute: expected ErrMuteNotFound, got %v", err)
+	}
+}
+
+func TestConversationServiceKickAndAuditLog(t *testing.T) {
+	svc, conversationID := newPermissionsFixture(t)
+	now := time.Now()
+
+	if err := svc.KickMember(testUserAdmin, conversationID, testUserMember, "hors sujet", now); err != nil {
+		t.Fatalf("KickMember() error = %v", err)
+	}
+	if ok, _ := svc.IsMember(testUserMember, conversationID); ok {
+		t.Fatalf("kicked member must have been removed")
+	}
+	if err := svc.KickMember(testUserAdmin, conversationID, testUserMember, "", now); !errors.Is(err, repo.ErrMembershipNotFound) {
+		t.Fatalf("kick non-member: expected ErrMembershipNotFound, got %v", err)
+	}
+	// Une exclusion n'empêche pas de revenir.
+	if _, err := svc.AddMember(testUserOwner, conversationID, testUserMember, models.ConversationRoleMember); err != nil {
+		t.Fatalf("kicked member should be re-added, got %v", err)
+	}
+	if _, err := svc.MuteMember(testUserOwner, conversationID, testUserMember, time.Hour, "", now); err != nil {
+		t.Fatalf("MuteMember() error = %v", err)
+	}
+	if _, _, err := svc.BanMember(testUserOwner, conversationID, testUserAdmin, "", now); err != nil {
+		t.Fatalf("BanMember() error = %v", err)
+	}
+
+	if _, err := svc.ListAuditLog(testUserMember, conversationID, 0, 0); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("member audit log should be forbidden, got %v", err)
+	}
+	entries, err := svc.ListAuditLog(testUserOwner, conversationID, 0, 0)
+	if err != nil {
+		t.Fatalf("ListAuditLog() error = %v", err)
+	}
+	want := []models.AuditAction{models.AuditActionBan, models.AuditActionMute, models.AuditActionKick}
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d entries, got %+v", len(want), entries)
+	}
+	for i, action := range want {
+		if entries[i].Action != action {
+			t.Fatalf("entry %d: expected %s, got %s", i, action, entries[i].Action)
+		}
+	}
+	if entries[1].ExpiresAt == nil || entries[2].Reason != "hors sujet" || entries[2].ActorID != testUserAdmin {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+
+	page, err := svc.ListAuditLog(testUserOwner, conversationID, entries[0].ID, 1)
+	if err != nil || len(page) != 1 || page[0].ID != entries[1].ID {
+		t.Fatalf("paginated audit log = %+v, %v", page, err)
+	}
+}
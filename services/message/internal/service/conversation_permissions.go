@@ -0,0 +1,192 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+// MaxSlowModeSeconds : 6 h, borne aussi imposée en base (migration 018).
+const MaxSlowModeSeconds = 6 * 60 * 60
+
+// Champs modifiables par GROUP_UPDATE_PERMISSIONS (valeurs de update_mask).
+const (
+	PermissionOnlyAdminsCanPost    = "only_admins_can_post"
+	PermissionMembersCanAddMembers = "members_can_add_members"
+	PermissionMembersCanPin        = "members_can_pin"
+	PermissionSlowModeSeconds      = "slow_mode_seconds"
+	PermissionJoinRequestsEnabled  = "join_requests_enabled"
+)
+
+var (
+	// ErrPostingRestricted enveloppe ErrForbidden : conversation en lecture seule pour les membres.
+	ErrPostingRestricted = fmt.Errorf("%w: only admins can post in this conversation", ErrForbidden)
+	ErrSlowMode          = errors.New("slow mode: wait before sending another message")
+)
+
+// ConversationPermissionsUpdate : permissions à modifier, nil = inchangé.
+type ConversationPermissionsUpdate struct {
+	OnlyAdminsCanPost    *bool
+	MembersCanAddMembers *bool
+	MembersCanPin        *bool
+	SlowModeSeconds      *int
+	JoinRequestsEnabled  *bool
+}
+
+// LastMessageLookup fournit la date du dernier message d'un membre (MessageService), pour le mode lent.
+type LastMessageLookup interface {
+	GetLastMessageAtBySender(conversationID int, senderID uuid.UUID) (*time.Time, error)
+}
+
+// UpdatePermissions modifie les permissions d'un groupe (owner uniquement).
+// Retourne la conversation et les permissions effectivement modifiées (vide : rien n'a changé).
+func (s *ConversationService) UpdatePermissions(actorID uuid.UUID, conversationID int, update ConversationPermissionsUpdate) (*models.Conversation, []string, error) {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return nil, nil, err
+	}
+	if update.OnlyAdminsCanPost == nil && update.MembersCanAddMembers == nil && update.MembersCanPin == nil && update.SlowModeSeconds == nil && update.JoinRequestsEnabled == nil {
+		return nil, nil, fmt.Errorf("%w: nothing to update", ErrInvalidConversation)
+	}
+	if update.SlowModeSeconds != nil && (*update.SlowModeSeconds < 0 || *update.SlowModeSeconds > MaxSlowModeSeconds) {
+		return nil, nil, fmt.Errorf("%w: slow_mode_seconds must be between 0 and %d", ErrInvalidConversation, MaxSlowModeSeconds)
+	}
+	if err := s.requireGroupConversation(conversationID); err != nil {
+		return nil, nil, err
+	}
+	membership, err := s.requireActorMembership(conversationID, actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if membership.Role != models.ConversationRoleOwner {
+		return nil, nil, ErrForbidden
+	}
+
+	conversation, err := s.conversationRepo.GetConversationByID(conversationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	permissions := conversation.Permissions
+	changed := make([]string, 0, 5)
+	if update.OnlyAdminsCanPost != nil && *update.OnlyAdminsCanPost != permissions.OnlyAdminsCanPost {
+		permissions.OnlyAdminsCanPost = *update.OnlyAdminsCanPost
+		changed = append(changed, PermissionOnlyAdminsCanPost)
+	}
+	if update.MembersCanAddMembers != nil && *update.MembersCanAddMembers != permissions.MembersCanAddMembers {
+		permissions.MembersCanAddMembers = *update.MembersCanAddMembers
+		changed = append(changed, PermissionMembersCanAddMembers)
+	}
+	if update.MembersCanPin != nil && *update.MembersCanPin != permissions.MembersCanPin {
+		permissions.MembersCanPin = *update.MembersCanPin
+		changed = append(changed, PermissionMembersCanPin)
+	}
+	if update.SlowModeSeconds != nil && *update.SlowModeSeconds != permissions.SlowModeSeconds {
+		permissions.SlowModeSeconds = *update.SlowModeSeconds
+		changed = append(changed, PermissionSlowModeSeconds)
+	}
+	if update.JoinRequestsEnabled != nil && *update.JoinRequestsEnabled != permissions.JoinRequestsEnabled {
+		permissions.JoinRequestsEnabled = *update.JoinRequestsEnabled
+		changed = append(changed, PermissionJoinRequestsEnabled)
+	}
+
+	if len(changed) == 0 {
+		return conversation, changed, nil
+	}
+	updated, err := s.conversationRepo.UpdateConversationPermissions(conversationID, permissions)
+	if err != nil {
+		return nil, nil, err
+	}
+	return updated, changed, nil
+}
+
+// AuthorizePost vérifie qu'userID peut écrire dans la conversation à now : membre, pas en sourdine,
+// canal non réservé aux admins, délai du mode lent écoulé. Si le mode lent s'applique, un succès réserve l'envoi
+// à now : l'appelant doit ensuite envoyer le message. lastMessages est nil à la programmation d'un message,
+// le mode lent étant alors vérifié à son envoi. Admins et owners ne sont soumis qu'à la sourdine.
+func (s *ConversationService) AuthorizePost(userID uuid.UUID, conversationID int, now time.Time, lastMessages LastMessageLookup) error {
+	if err := validateConversationAndUser(conversationID, userID); err != nil {
+		return err
+	}
+	conversation, err := s.conversationRepo.GetConversationByID(conversationID)
+	if err != nil {
+		return err
+	}
+	membership, err := s.requireActorMembership(conversationID, userID)
+	if err != nil {
+		return err
+	}
+	// La sourdine s'applique aussi à un membre promu depuis, et aux messages programmés.
+	if err := s.requireNotMuted(conversationID, userID, now); err != nil {
+		return err
+	}
+	if membership.Role != models.ConversationRoleMember {
+		return nil
+	}
+
+	permissions := conversation.Permissions
+	if permissions.OnlyAdminsCanPost {
+		return ErrPostingRestricted
+	}
+	if permissions.SlowModeSeconds > 0 && lastMessages != nil {
+		interval := time.Duration(permissions.SlowModeSeconds) * time.Second
+		last, err := lastMessages.GetLastMessageAtBySender(conversationID, userID)
+		if err != nil {
+			return err
+		}
+		if last != nil && last.Add(interval).After(now) {
+			return slowModeError(*last, interval, now)
+		}
+		// Réservation atomique : deux envois simultanés ne passent pas tous les deux le contrôle.
+		previous, claimed, err := s.conversationRepo.ClaimPostSlot(conversationID, userID, now, interval)
+		if err != nil {
+			if errors.Is(err, repo.ErrMembershipNotFound) {
+				return ErrForbidden
+			}
+			return err
+		}
+		if !claimed {
+			if previous == nil {
+				previous = &now
+			}
+			return slowModeError(*previous, interval, now)
+		}
+	}
+	return nil
+}
+
+// slowModeError : ErrSlowMode avec le délai restant depuis le dernier envoi last, arrondi à la seconde supérieure.
+func slowModeError(last time.Time, interval time.Duration, now time.Time) error {
+	wait := last.Add(interval).Sub(now)
+	return fmt.Errorf("%w (retry in %ds)", ErrSlowMode, int((wait+time.Second-1)/time.Second))
+}
+
+// requireMemberPermission exige un admin/owner, ou un membre si allowed(permissions) l'autorise.
+func (s *ConversationService) requireMemberPermission(conversationID int, actorID uuid.UUID, allowed func(models.ConversationPermissions) bool) (*models.ConversationMembership, error) {
+	membership, err := s.requireActorMembership(conversationID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if membership.Role != models.ConversationRoleMember {
+		return membership, nil
+	}
+	conversation, err := s.conversationRepo.GetConversationByID(conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed(conversation.Permissions) {
+		return nil, ErrForbidden
+	}
+	return membership, nil
+}
+
+func canMembersAdd(permissions models.ConversationPermissions) bool {
+	return permissions.MembersCanAddMembers
+}
+
+func canMembersPin(permissions models.ConversationPermissions) bool {
+	return permissions.MembersCanPin
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
+	"github.com/google/uuid"
+)
+
+type fixedLastMessage struct {
+	at *time.Time
+}
+
+func (f fixedLastMessage) GetLastMessageAtBySender(int, uuid.UUID) (*time.Time, error) {
+	return f.at, nil
+}
+
+func boolPtr(v bool) *bool { return &v }
+
+func intPtr(v int) *int { return &v }
+
+func newPermissionsFixture(t *testing.T) (*ConversationService, int) {
+	t.Helper()
+	svc := NewConversationService(memory.NewConversationRepo())
+
+	conversation, err := svc.CreateConversation(testUserOwner, "Annonces", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	if _, err := svc.AddMember(testUserOwner, conversation.ID, testUserAdmin, models.ConversationRoleAdmin); err != nil {
+		t.Fatalf("AddMember(admin) error = %v", err)
+	}
+	if _, err := svc.AddMember(testUserOwner, conversation.ID, testUserMember, models.ConversationRoleMember); err != nil {
+		t.Fatalf("AddMember(member) error = %v", err)
+	}
+	return svc, conversation.ID
+}
+
+func TestConversationServiceUpdatePermissions(t *testing.T) {
+	svc, conversationID := newPermissionsFixture(t)
+
+	if _, _, err := svc.UpdatePermissions(testUserAdmin, conversationID, ConversationPermissionsUpdate{OnlyAdminsCanPost: boolPtr(true)}); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("admin update should be forbidden, got %v", err)
+	}
+	if _, _, err := svc.UpdatePermissions(testUserOwner, conversationID, ConversationPermissionsUpdate{}); !errors.Is(err, ErrInvalidConversation) {
+		t.Fatalf("empty update: expected ErrInvalidConversation, got %v", err)
+	}
+	if _, _, err := svc.UpdatePermissions(testUserOwner, conversationID, ConversationPermissionsUpdate{SlowModeSeconds: intPtr(MaxSlowModeSeconds + 1)}); !errors.Is(err, ErrInvalidConversation) {
+		t.Fatalf("slow mode too long: expected ErrInvalidConversation, got %v", err)
+	}
+
+	conversation, changed, err := svc.UpdatePermissions(testUserOwner, conversationID, ConversationPermissionsUpdate{
+		OnlyAdminsCanPost: boolPtr(true),
+		MembersCanPin:     boolPtr(false),
+		SlowModeSeconds:   intPtr(30),
+	})
+	if err != nil {
+		t.Fatalf("UpdatePermissions() error = %v", err)
+	}
+	if !conversation.Permissions.OnlyAdminsCanPost || conversation.Permissions.SlowModeSeconds != 30 {
+		t.Fatalf("unexpected permissions %+v", conversation.Permissions)
+	}
+	if len(changed) != 2 || changed[0] != PermissionOnlyAdminsCanPost || changed[1] != PermissionSlowModeSeconds {
+		t.Fatalf("unexpected changed fields %v", changed)
+	}
+}
+
+func TestConversationServiceUpdatePermissionsRejectsDirect(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+	direct, _, err := svc.GetOrCreateDirectConversation(testUserOwner, testUserMember)
+	if err != nil {
+		t.Fatalf("GetOrCreateDirectConversation() error = %v", err)
+	}
+	if _, _, err := svc.UpdatePermissions(testUserOwner, direct.ID, ConversationPermissionsUpdate{OnlyAdminsCanPost: boolPtr(true)}); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("direct conversation: expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestConversationServiceAuthorizePostOnlyAdmins(t *testing.T) {
+	svc, conversationID := newPermissionsFixture(t)
+	now := time.Now()
+
+	if err := svc.AuthorizePost(testUserMember, conversationID, now, nil); err != nil {
+		t.Fatalf("member should post by default, got %v", err)
+	}
+	if err := svc.AuthorizePost(testUserOther, conversationID, now, nil); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("non-member: expected ErrForbidden, got %v", err)
+	}
+
+	if _, _, err := svc.UpdatePermissions(testUserOwner, conversationID, ConversationPermissionsUpdate{OnlyAdminsCanPost: boolPtr(true)}); err != nil {
+		t.Fatalf("UpdatePermissions() error = %v", err)
+	}
+	if err := svc.AuthorizePost(testUserMember, conversationID, now, nil); !errors.Is(err, ErrPostingRestricted) || !errors.Is(err, ErrForbidden) {
+		t.Fatalf("member in announcement channel: expected ErrPostingRestricted, got %v", err)
+	}
+	if err := svc.AuthorizePost(testUserAdmin, conversationID, now, nil); err != nil {
+		t.Fatalf("admin should post, got %v", err)
+	}
+}
+
+func TestConversationServiceAuthorizePostSlowMode(t *testing.T) {
+	svc, conversationID := newPermissionsFixture(t)
+	if _, _, err := svc.UpdatePermissions(testUserOwner, conversationID, ConversationPermissionsUpdate{SlowModeSeconds: intPtr(30)}); err != nil {
+		t.Fatalf("UpdatePermissions() error = %v", err)
+	}
+
+	now := time.Now()
+	recent := fixedLastMessage{at: func() *time.Time { at := now.Add(-10 * time.Second); return &at }()}
+	old := fixedLastMessage{at: func() *time.Time { at := now.Add(-31 * time.Second); return &at }()}
+
+	if err := svc.AuthorizePost(testUserMember, conversationID, now, recent); !errors.Is(err, ErrSlowMode) {
+		t.Fatalf("message within slow mode: expected ErrSlowMode, got %v", err)
+	}
+	if err := svc.AuthorizePost(testUserMember, conversationID, now, fixedLastMessage{}); err != nil {
+		t.Fatalf("first message should pass, got %v", err)
+	}
+	// Envoi simultané, avant l'insertion du premier message : la place réservée le bloque.
+	if err := svc.AuthorizePost(testUserMember, conversationID, now, fixedLastMessage{}); !errors.Is(err, ErrSlowMode) {
+		t.Fatalf("concurrent message: expected ErrSlowMode, got %v", err)
+	}
+	if err := svc.AuthorizePost(testUserMember, conversationID, now.Add(31*time.Second), old); err != nil {
+		t.Fatalf("message after slow mode delay should pass, got %v", err)
+	}
+	if err := svc.AuthorizePost(testUserAdmin, conversationID, now, recent); err != nil {
+		t.Fatalf("admin is exempt from slow mode, got %v", err)
+	}
+	// Programmation d'un message : le mode lent s'applique à son envoi, pas ici.
+	if err := svc.AuthorizePost(testUserMember, conversationID, now.Add(32*time.Second), nil); err != nil {
+		t.Fatalf("scheduling should skip slow mode, got %v", err)
+	}
+}
+
+func TestConversationServiceMemberPermissions(t *testing.T) {
+	svc, conversationID := newPermissionsFixture(t)
+
+	if _, err := svc.AddMember(testUserMember, conversationID, testUserOther, models.ConversationRoleMember); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("member add should be forbidden by default, got %v", err)
+	}
+	if _, err := svc.PinMessage(testUserMember, conversationID, 1); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("member pin should be forbidden by default, got %v", err)
+	}
+
+	if _, _, err := svc.UpdatePermissions(testUserOwner, conversationID, ConversationPermissionsUpdate{
+		MembersCanAddMembers: boolPtr(true),
+		MembersCanPin:        boolPtr(true),
+	}); err != nil {
+		t.Fatalf("UpdatePermissions() error = %v", err)
+	}
+
+	if _, err := svc.AddMember(testUserMember, conversationID, testUserOther, models.ConversationRoleAdmin); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("member cannot add an admin, got %v", err)
+	}
+	if _, err := svc.AddMember(testUserMember, conversationID, testUserOther, models.ConversationRoleMember); err != nil {
+		t.Fatalf("member add should be allowed, got %v", err)
+	}
+	if _, err := svc.PinMessage(testUserMember, conversationID, 1); err != nil {
+		t.Fatalf("member pin should be allowed, got %v", err)
+	}
+	if err := svc.UnpinMessage(testUserMember, conversationID, 1); err != nil {
+		t.Fatalf("member unpin should be allowed, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"errors"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidMessageID = errors.New("message ID is empty")
+
+// PinMessage épingle messageID dans la conversation (admin/owner, ou membre si members_can_pin).
+// L'appartenance du message à la conversation est vérifiée par l'appelant (handler NATS).
+func (s *ConversationService) PinMessage(actorID uuid.UUID, conversationID, messageID int) (*models.ConversationPin, error) {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return nil, err
+	}
+	if messageID == 0 {
+		return nil, ErrInvalidMessageID
+	}
+
+	if _, err := s.requireMemberPermission(conversationID, actorID, canMembersPin); err != nil {
+		return nil, err
+	}
+
+	return s.conversationRepo.CreatePin(&models.ConversationPin{
+		ConversationID: conversationID,
+		MessageID:      messageID,
+		PinnedBy:       actorID,
+	})
+}
+
+func (s *ConversationService) UnpinMessage(actorID uuid.UUID, conversationID, messageID int) error {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return err
+	}
+	if messageID == 0 {
+		return ErrInvalidMessageID
+	}
+
+	if _, err := s.requireMemberPermission(conversationID, actorID, canMembersPin); err != nil {
+		return err
+	}
+
+	return s.conversationRepo.DeletePin(conversationID, messageID)
+}
+
+// ListPins : tout membre de la conversation peut consulter les messages épinglés.
+func (s *ConversationService) ListPins(actorID uuid.UUID, conversationID int) ([]*models.ConversationPin, error) {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.requireActorMembership(conversationID, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.conversationRepo.ListPins(conversationID)
+}
+
+// requireConversationManager exige un membership admin ou owner.
+func (s *ConversationService) requireConversationManager(conversationID int, actorID uuid.UUID) (*models.ConversationMembership, error) {
+	membership, err := s.requireActorMembership(conversationID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if membership.Role != models.ConversationRoleAdmin && membership.Role != models.ConversationRoleOwner {
+		return nil, ErrForbidden
+	}
+	return membership, nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
+)
+
+func TestConversationServicePinRoleRules(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+
+	conversation, err := svc.CreateConversation(testUserOwner, "Pins", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	if _, err := svc.AddMember(testUserOwner, conversation.ID, testUserAdmin, models.ConversationRoleAdmin); err != nil {
+		t.Fatalf("AddMember(admin) error = %v", err)
+	}
+	if _, err := svc.AddMember(testUserOwner, conversation.ID, testUserMember, models.ConversationRoleMember); err != nil {
+		t.Fatalf("AddMember(member) error = %v", err)
+	}
+
+	if _, err := svc.PinMessage(testUserMember, conversation.ID, 1); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("member pin should be forbidden, got %v", err)
+	}
+	if _, err := svc.PinMessage(testUserOther, conversation.ID, 1); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("non-member pin should be forbidden, got %v", err)
+	}
+	if _, err := svc.PinMessage(testUserOwner, conversation.ID, 1); err != nil {
+		t.Fatalf("owner should pin, got %v", err)
+	}
+	if _, err := svc.PinMessage(testUserAdmin, conversation.ID, 2); err != nil {
+		t.Fatalf("admin should pin, got %v", err)
+	}
+	if _, err := svc.PinMessage(testUserAdmin, conversation.ID, 1); !errors.Is(err, repo.ErrPinAlreadyExists) {
+		t.Fatalf("expected ErrPinAlreadyExists, got %v", err)
+	}
+
+	pins, err := svc.ListPins(testUserMember, conversation.ID)
+	if err != nil {
+		t.Fatalf("member should list pins, got %v", err)
+	}
+	if len(pins) != 2 {
+		t.Fatalf("expected 2 pins, got %d", len(pins))
+	}
+
+	if err := svc.UnpinMessage(testUserMember, conversation.ID, 1); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("member unpin should be forbidden, got %v", err)
+	}
+	if err := svc.UnpinMessage(testUserAdmin, conversation.ID, 1); err != nil {
+		t.Fatalf("admin should unpin, got %v", err)
+	}
+	if err := svc.UnpinMessage(testUserAdmin, conversation.ID, 1); !errors.Is(err, repo.ErrPinNotFound) {
+		t.Fatalf("expected ErrPinNotFound, got %v", err)
+	}
+	if _, err := svc.ListPins(testUserOther, conversation.ID); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("non-member list should be forbidden, got %v", err)
+	}
+}
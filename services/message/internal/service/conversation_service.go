@@ -25,6 +25,9 @@ var (
 
 type ConversationService struct {
 	conversationRepo repo.ConversationRepo
+	// systemMessages / onSystemMessage : messages système de la timeline, voir EnableSystemMessages.
+	systemMessages  SystemMessageWriter
+	onSystemMessage func(*models.ChatMessage)
 }
 
 func NewConversationService(conversationRepo repo.ConversationRepo) *ConversationService {
@@ -80,7 +83,7 @@ func (s *ConversationService) ListConversationsByUser(userID uuid.UUID) ([]*mode
 	if userID == uuid.Nil {
 		return nil, ErrInvalidUserID
 	}
-	return s.conversationRepo.ListConversationsByUser(userID)
+	return s.conversationRepo.ListConversationsByUser(userID, false)
 }
 
 func (s *ConversationService) AddMember(actorID uuid.UUID, conversationID int, userID uuid.UUID, role models.ConversationRole) (*models.ConversationMembership, error) {
@@ -93,18 +96,24 @@ func (s *ConversationService) AddMember(actorID uuid.UUID, conversationID int, u
 	if !models.IsValidConversationRole(role) {
 		return nil, ErrInvalidMembershipRole
 	}
-
-	actorMembership, err := s.requireActorMembership(conversationID, actorID)
-	if err != nil {
+	if err := s.requireGroupConversation(conversationID); err != nil {
 		return nil, err
 	}
 
-	if actorMembership.Role == models.ConversationRoleMember {
-		return nil, ErrForbidden
+	// Un membre n'ajoute que si le groupe l'autorise (members_can_add_members), et seulement au rôle 0.
+	actorMembership, err := s.requireMemberPermission(conversationID, actorID, canMembersAdd)
+	if err != nil {
+		return nil, err
 	}
 	if actorMembership.Role != models.ConversationRoleOwner && role != models.ConversationRoleMember {
 		return nil, ErrForbidden
 	}
+	if err := s.requireNotBlocked(userID, actorID); err != nil {
+		return nil, err
+	}
+	if err := s.requireNotBanned(conversationID, userID); err != nil {
+		return nil, err
+	}
 
 	if _, err := s.conversationRepo.GetMembership(conversationID, userID); err == nil {
 		return nil, repo.ErrMembershipAlreadyExists
@@ -117,7 +126,12 @@ func (s *ConversationService) AddMember(actorID uuid.UUID, conversationID int, u
 		ConversationID: conversationID,
 		Role:           role,
 	}
-	return s.conversationRepo.CreateMembership(membership)
+	saved, err := s.conversationRepo.CreateMembership(membership)
+	if err != nil {
+		return nil, err
+	}
+	s.postSystemMessage(conversationID, roleEvent(models.SystemEventMemberAdded, actorID, userID, role))
+	return saved, nil
 }
 
 func (s *ConversationService) RemoveMember(actorID uuid.UUID, conversationID int, userID uuid.UUID) error {
@@ -130,6 +144,9 @@ func (s *ConversationService) RemoveMember(actorID uuid.UUID, conversationID int
 	if actorID == userID {
 		return s.LeaveConversation(actorID, conversationID)
 	}
+	if err := s.requireGroupConversation(conversationID); err != nil {
+		return err
+	}
 
 	actorMembership, err := s.requireActorMembership(conversationID, actorID)
 	if err != nil {
@@ -159,7 +176,11 @@ func (s *ConversationService) RemoveMember(actorID uuid.UUID, conversationID int
 		}
 	}
 
-	return s.conversationRepo.SoftDeleteMembership(conversationID, userID)
+	if err := s.conversationRepo.SoftDeleteMembership(conversationID, userID); err != nil {
+		return err
+	}
+	s.postSystemMessage(conversationID, memberEvent(models.SystemEventMemberRemoved, actorID, userID))
+	return nil
 }
 
 func (s *ConversationService) UpdateMemberRole(actorID uuid.UUID, conversationID int, userID uuid.UUID, newRole models.ConversationRole) (*models.ConversationMembership, error) {
@@ -172,6 +193,9 @@ func (s *ConversationService) UpdateMemberRole(actorID uuid.UUID, conversationID
 	if !models.IsValidConversationRole(newRole) {
 		return nil, ErrInvalidMembershipRole
 	}
+	if err := s.requireGroupConversation(conversationID); err != nil {
+		return nil, err
+	}
 
 	actorMembership, err := s.requireActorMembership(conversationID, actorID)
 	if err != nil {
@@ -199,7 +223,12 @@ func (s *ConversationService) UpdateMemberRole(actorID uuid.UUID, conversationID
 		}
 	}
 
-	return s.conversationRepo.UpdateMembershipRole(conversationID, userID, newRole)
+	updated, err := s.conversationRepo.UpdateMembershipRole(conversationID, userID, newRole)
+	if err != nil {
+		return nil, err
+	}
+	s.postSystemMessage(conversationID, roleEvent(models.SystemEventRoleChanged, actorID, userID, newRole))
+	return updated, nil
 }
 
 func (s *ConversationService) LeaveConversation(userID uuid.UUID, conversationID int) error {
@@ -222,7 +251,11 @@ func (s *ConversationService) LeaveConversation(userID uuid.UUID, conversationID
 		}
 	}
 
-	return s.conversationRepo.SoftDeleteMembership(conversationID, userID)
+	if err := s.conversationRepo.SoftDeleteMembership(conversationID, userID); err != nil {
+		return err
+	}
+	s.postSystemMessage(conversationID, memberEvent(models.SystemEventMemberLeft, userID, uuid.Nil))
+	return nil
 }
 
 func (s *ConversationService) DeleteConversation(actorID uuid.UUID, conversationID int) error {
@@ -275,6 +308,20 @@ func (s *ConversationService) IsMember(userID uuid.UUID, conversationID int) (bo
 	return true, nil
 }
 
+// IsManager indique si userID est admin ou owner de la conversation.
+func (s *ConversationService) IsManager(userID uuid.UUID, conversationID int) (bool, error) {
+	if err := validateConversationAndUser(conversationID, userID); err != nil {
+		return false, err
+	}
+	if _, err := s.requireConversationManager(conversationID, userID); err != nil {
+		if errors.Is(err, ErrForbidden) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 func (s *ConversationService) requireActorMembership(conversationID int, actorID uuid.UUID) (*models.ConversationMembership, error) {
 	if _, err := s.conversationRepo.GetConversationByID(conversationID); err != nil {
 		return nil, err
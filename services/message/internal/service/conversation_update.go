@@ -0,0 +1,104 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	maxConversationDescriptionChars = 500
+	maxConversationAvatarURLChars   = 2048
+)
+
+// Champs modifiables par GROUP_UPDATE (valeurs de update_mask).
+const (
+	ConversationFieldName        = "name"
+	ConversationFieldAvatarURL   = "avatar_url"
+	ConversationFieldDescription = "description"
+)
+
+// ConversationUpdate : champs à modifier, nil = inchangé.
+type ConversationUpdate struct {
+	Name        *string
+	AvatarURL   *string
+	Description *string
+}
+
+// UpdateConversation modifie les métadonnées d'un groupe (admin/owner).
+// Retourne la conversation et les champs effectivement modifiés (vide : rien n'a changé, updated_at intact).
+func (s *ConversationService) UpdateConversation(actorID uuid.UUID, conversationID int, update ConversationUpdate) (*models.Conversation, []string, error) {
+	if err := validateConversationAndUser(conversationID, actorID); err != nil {
+		return nil, nil, err
+	}
+	if update.Name == nil && update.AvatarURL == nil && update.Description == nil {
+		return nil, nil, fmt.Errorf("%w: nothing to update", ErrInvalidConversation)
+	}
+	if err := s.requireGroupConversation(conversationID); err != nil {
+		return nil, nil, err
+	}
+	if _, err := s.requireConversationManager(conversationID, actorID); err != nil {
+		return nil, nil, err
+	}
+
+	conversation, err := s.conversationRepo.GetConversationByID(conversationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changed := make([]string, 0, 3)
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if len(name) > maxConversationNameChars {
+			return nil, nil, fmt.Errorf("%w: name too long", ErrInvalidConversation)
+		}
+		if name != conversation.Name {
+			conversation.Name = name
+			changed = append(changed, ConversationFieldName)
+		}
+	}
+	if update.AvatarURL != nil {
+		avatarURL := strings.TrimSpace(*update.AvatarURL)
+		if len(avatarURL) > maxConversationAvatarURLChars {
+			return nil, nil, fmt.Errorf("%w: avatar_url too long", ErrInvalidConversation)
+		}
+		if avatarURL != conversation.AvatarURL {
+			conversation.AvatarURL = avatarURL
+			changed = append(changed, ConversationFieldAvatarURL)
+		}
+	}
+	if update.Description != nil {
+		description := strings.TrimSpace(*update.Description)
+		if len([]rune(description)) > maxConversationDescriptionChars {
+			return nil, nil, fmt.Errorf("%w: description too long", ErrInvalidConversation)
+		}
+		if description != conversation.Description {
+			conversation.Description = description
+			changed = append(changed, ConversationFieldDescription)
+		}
+	}
+
+	if len(changed) == 0 {
+		return conversation, changed, nil
+	}
+	updated, err := s.conversationRepo.UpdateConversation(conversation)
+	if err != nil {
+		return nil, nil, err
+	}
+	if changed[0] == ConversationFieldName {
+		s.postSystemMessage(conversationID, models.SystemEvent{
+			Type:    models.SystemEventConversationRenamed,
+			ActorID: actorID,
+			Name:    updated.Name,
+		})
+	}
+	return updated, changed, nil
+}
+
+// ReferencedAvatars retourne les media IDs encore utilisés comme avatar de groupe, pour le
+// ramasse-miettes du media-service.
+func (s *ConversationService) ReferencedAvatars(mediaIDs []string) ([]string, error) {
+	return s.conversationRepo.ReferencedAvatars(mediaIDs)
+}
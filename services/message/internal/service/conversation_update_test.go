@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
+)
+
+func TestConversationServiceUpdateConversation(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+
+	conversation, err := svc.CreateConversation(testUserOwner, "Équipe", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	if _, err := svc.AddMember(testUserOwner, conversation.ID, testUserAdmin, models.ConversationRoleAdmin); err != nil {
+		t.Fatalf("AddMember(admin) error = %v", err)
+	}
+	if _, err := svc.AddMember(testUserOwner, conversation.ID, testUserMember, models.ConversationRoleMember); err != nil {
+		t.Fatalf("AddMember(member) error = %v", err)
+	}
+
+	name := "Équipe produit"
+	if _, _, err := svc.UpdateConversation(testUserMember, conversation.ID, ConversationUpdate{Name: &name}); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("member update should be forbidden, got %v", err)
+	}
+	if _, _, err := svc.UpdateConversation(testUserOther, conversation.ID, ConversationUpdate{Name: &name}); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("non-member update should be forbidden, got %v", err)
+	}
+	if _, _, err := svc.UpdateConversation(testUserAdmin, conversation.ID, ConversationUpdate{}); !errors.Is(err, ErrInvalidConversation) {
+		t.Fatalf("empty update: expected ErrInvalidConversation, got %v", err)
+	}
+	tooLong := strings.Repeat("d", maxConversationDescriptionChars+1)
+	if _, _, err := svc.UpdateConversation(testUserAdmin, conversation.ID, ConversationUpdate{Description: &tooLong}); !errors.Is(err, ErrInvalidConversation) {
+		t.Fatalf("long description: expected ErrInvalidConversation, got %v", err)
+	}
+
+	description := "  Sujet : lancement v2  "
+	updated, changed, err := svc.UpdateConversation(testUserAdmin, conversation.ID, ConversationUpdate{Name: &name, Description: &description})
+	if err != nil {
+		t.Fatalf("admin update error = %v", err)
+	}
+	if updated.Name != name || updated.Description != "Sujet : lancement v2" {
+		t.Fatalf("unexpected conversation %+v", updated)
+	}
+	if len(changed) != 2 || changed[0] != ConversationFieldName || changed[1] != ConversationFieldDescription {
+		t.Fatalf("unexpected changed fields %v", changed)
+	}
+	if updated.UpdatedAt.Before(conversation.UpdatedAt) {
+		t.Fatalf("updated_at should move forward")
+	}
+
+	// Valeurs identiques : aucun champ modifié.
+	_, changed, err = svc.UpdateConversation(testUserOwner, conversation.ID, ConversationUpdate{Name: &name})
+	if err != nil {
+		t.Fatalf("no-op update error = %v", err)
+	}
+	if len(changed) != 0 {
+		t.Fatalf("expected no changed fields, got %v", changed)
+	}
+}
+
+func TestConversationServiceUpdateConversationRejectsDirect(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+
+	direct, _, err := svc.GetOrCreateDirectConversation(testUserOwner, testUserMember)
+	if err != nil {
+		t.Fatalf("GetOrCreateDirectConversation() error = %v", err)
+	}
+	name := "DM"
+	if _, _, err := svc.UpdateConversation(testUserOwner, direct.ID, ConversationUpdate{Name: &name}); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("direct conversation update should be forbidden, got %v", err)
+	}
+}
+
+func TestConversationServiceReferencedAvatars(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+
+	conversation, err := svc.CreateConversation(testUserOwner, "Équipe", "media/1_logo.png")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	avatarURL := "http://minio:9000/media/media/2_nouveau.png"
+	if _, _, err := svc.UpdateConversation(testUserOwner, conversation.ID, ConversationUpdate{AvatarURL: &avatarURL}); err != nil {
+		t.Fatalf("UpdateConversation() error = %v", err)
+	}
+	if _, err := svc.CreateConversation(testUserOwner, "Autre", "media/3_autre.png"); err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+
+	referenced, err := svc.ReferencedAvatars([]string{"media/1_logo.png", "media/2_nouveau.png", "media/3_autre.png", "media/4_inconnu.png"})
+	if err != nil {
+		t.Fatalf("ReferencedAvatars() error = %v", err)
+	}
+	sort.Strings(referenced)
+	// L'ancien avatar n'est plus référencé.
+	if want := []string{"media/2_nouveau.png", "media/3_autre.png"}; !reflect.DeepEqual(referenced, want) {
+		t.Fatalf("ReferencedAvatars() = %v, want %v", referenced, want)
+	}
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+)
+
+// GetLinkPreview lit le cache d'aperçus par URL (repo.ErrLinkPreviewNotFound si absent).
+func (s *MessageService) GetLinkPreview(url string) (*models.LinkPreview, error) {
+	return s.messageRepo.GetLinkPreview(url)
+}
+
+func (s *MessageService) SaveLinkPreview(preview *models.LinkPreview) error {
+	return s.messageRepo.SaveLinkPreview(preview)
+}
+
+// AttachLinkPreview enregistre l'aperçu sur le message (appelé par l'unfurl asynchrone).
+func (s *MessageService) AttachLinkPreview(messageID int, preview *models.LinkPreview) error {
+	return s.messageRepo.SetMessageLinkPreview(messageID, preview)
+}
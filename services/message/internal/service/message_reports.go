@@ -0,0 +1,85 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+const maxReportReasonLength = 1000
+
+var (
+	ErrReportReasonEmpty   = errors.New("invalid report: reason is empty")
+	ErrReportReasonTooLong = errors.New("invalid report: reason too long")
+	ErrReportInvalidStatus = errors.New("invalid report: status must be resolved or dismissed")
+	ErrReportOwnMessage    = errors.New("invalid report: cannot report your own message")
+	ErrReportSystemMessage = errors.New("invalid report: system messages cannot be reported")
+)
+
+// ReportMessage place msg dans la file de modération de sa conversation.
+// L'appartenance de reporterID à la conversation est vérifiée par l'appelant (handler NATS).
+func (s *MessageService) ReportMessage(reporterID uuid.UUID, msg *models.ChatMessage, reason string) (*models.MessageReport, error) {
+	if reporterID == uuid.Nil {
+		return nil, ErrInvalidUserID
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrReportReasonEmpty
+	}
+	if len([]rune(reason)) > maxReportReasonLength {
+		return nil, ErrReportReasonTooLong
+	}
+	if msg.IsSystem() {
+		return nil, ErrReportSystemMessage
+	}
+	if msg.SenderID == reporterID {
+		return nil, ErrReportOwnMessage
+	}
+
+	return s.messageRepo.CreateMessageReport(&models.MessageReport{
+		MessageID:      msg.ID,
+		ConversationID: msg.ConversationID,
+		ReporterID:     reporterID,
+		Reason:         reason,
+	})
+}
+
+// ListReports retourne la file de modération d'une conversation (statut vide : pending).
+// Réservé aux admins/owners, vérifié par l'appelant.
+func (s *MessageService) ListReports(conversationID int, status models.MessageReportStatus) ([]*models.MessageReport, error) {
+	if conversationID == 0 {
+		return nil, ErrInvalidConversationID
+	}
+	if status == "" {
+		status = models.MessageReportPending
+	}
+	switch status {
+	case models.MessageReportPending, models.MessageReportResolved, models.MessageReportDismissed:
+	default:
+		return nil, errors.New("invalid report: unknown status")
+	}
+	return s.messageRepo.ListMessageReports(conversationID, status)
+}
+
+// ResolveReport clôt un signalement de la conversation (resolved ou dismissed).
+// Un signalement d'une autre conversation est traité comme introuvable.
+func (s *MessageService) ResolveReport(actorID uuid.UUID, conversationID, reportID int, status models.MessageReportStatus, now time.Time) (*models.MessageReport, error) {
+	if actorID == uuid.Nil {
+		return nil, ErrInvalidUserID
+	}
+	if status != models.MessageReportResolved && status != models.MessageReportDismissed {
+		return nil, ErrReportInvalidStatus
+	}
+	report, err := s.messageRepo.GetMessageReport(reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report.ConversationID != conversationID {
+		return nil, repo.ErrReportNotFound
+	}
+	return s.messageRepo.ResolveMessageReport(reportID, status, actorID, now)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
+)
+
+func TestMessageServiceReportLifecycle(t *testing.T) {
+	svc := NewMessageService(memory.NewMessageRepo())
+	msg, err := svc.SendMessage(&models.ChatMessage{SenderID: testUserOther, ConversationID: 7, Content: "spam"})
+	if err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	if _, err := svc.ReportMessage(testUserMember, msg, "  "); !errors.Is(err, ErrReportReasonEmpty) {
+		t.Fatalf("empty reason: expected ErrReportReasonEmpty, got %v", err)
+	}
+	if _, err := svc.ReportMessage(testUserMember, msg, strings.Repeat("x", maxReportReasonLength+1)); !errors.Is(err, ErrReportReasonTooLong) {
+		t.Fatalf("long reason: expected ErrReportReasonTooLong, got %v", err)
+	}
+	if _, err := svc.ReportMessage(testUserOther, msg, "moi-même"); !errors.Is(err, ErrReportOwnMessage) {
+		t.Fatalf("own message: expected ErrReportOwnMessage, got %v", err)
+	}
+
+	report, err := svc.ReportMessage(testUserMember, msg, " publicité ")
+	if err != nil {
+		t.Fatalf("ReportMessage() error = %v", err)
+	}
+	if report.Status != models.MessageReportPending || report.Reason != "publicité" || report.ConversationID != 7 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if _, err := svc.ReportMessage(testUserMember, msg, "encore"); !errors.Is(err, repo.ErrReportExists) {
+		t.Fatalf("duplicate report: expected ErrReportExists, got %v", err)
+	}
+
+	pending, err := svc.ListReports(7, "")
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("ListReports(pending) = %+v, %v", pending, err)
+	}
+
+	if _, err := svc.ResolveReport(testUserAdmin, 7, report.ID, models.MessageReportPending, time.Now()); !errors.Is(err, ErrReportInvalidStatus) {
+		t.Fatalf("pending status: expected ErrReportInvalidStatus, got %v", err)
+	}
+	if _, err := svc.ResolveReport(testUserAdmin, 8, report.ID, models.MessageReportResolved, time.Now()); !errors.Is(err, repo.ErrReportNotFound) {
+		t.Fatalf("other conversation: expected ErrReportNotFound, got %v", err)
+	}
+	resolved, err := svc.ResolveReport(testUserAdmin, 7, report.ID, models.MessageReportDismissed, time.Now())
+	if err != nil {
+		t.Fatalf("ResolveReport() error = %v", err)
+	}
+	if resolved.Status != models.MessageReportDismissed || resolved.ResolvedBy != testUserAdmin || resolved.ResolvedAt == nil {
+		t.Fatalf("unexpected resolved report %+v", resolved)
+	}
+	if _, err := svc.ResolveReport(testUserOwner, 7, report.ID, models.MessageReportResolved, time.Now()); !errors.Is(err, repo.ErrReportResolved) {
+		t.Fatalf("second resolve: expected ErrReportResolved, got %v", err)
+	}
+
+	if pending, _ := svc.ListReports(7, models.MessageReportPending); len(pending) != 0 {
+		t.Fatalf("expected empty pending queue, got %+v", pending)
+	}
+	if dismissed, _ := svc.ListReports(7, models.MessageReportDismissed); len(dismissed) != 1 {
+		t.Fatalf("expected 1 dismissed report, got %+v", dismissed)
+	}
+}
@@ -135,3 +135,23 @@ func (s *MessageService) DeleteMessageById(id int) error {
 	log.Printf("Message deleted: %d", id)
 	return nil
 }
+
+// GetLastMessageAtBySender : date du dernier message de senderID dans la conversation (mode lent).
+func (s *MessageService) GetLastMessageAtBySender(conversationID int, senderID uuid.UUID) (*time.Time, error) {
+	return s.messageRepo.GetLastMessageAtBySender(conversationID, senderID)
+}
+
+// PurgeConversationMessages supprime définitivement les messages d'une conversation purgée
+// et retourne leurs pièces jointes, à supprimer du stockage média par l'appelant.
+func (s *MessageService) PurgeConversationMessages(conversationID int) ([]string, error) {
+	if conversationID == 0 {
+		return nil, errors.New("conversation id is empty")
+	}
+	return s.messageRepo.PurgeConversationMessages(conversationID)
+}
+
+// ReferencedAttachments retourne les media IDs encore attachés à un message, pour le ramasse-miettes
+// du media-service.
+func (s *MessageService) ReferencedAttachments(mediaIDs []string) ([]string, error) {
+	return s.messageRepo.ReferencedAttachments(mediaIDs)
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"reflect"
+	"sort"
 	"testing"
 	"time"
 
@@ -65,3 +67,87 @@ func TestMessageServiceMarkMessageReceivedByID_InvalidID(t *testing.T) {
 		t.Fatalf("expected error for empty user id")
 	}
 }
+
+func TestMessageServiceScheduleMessageValidation(t *testing.T) {
+	svc := NewMessageService(memory.NewMessageRepo())
+
+	if _, err := svc.ScheduleMessage(&models.ScheduledMessage{
+		SenderID:       testMessageSender,
+		ConversationID: 1,
+		Content:        "too late",
+		SendAt:         time.Now().Add(-time.Minute),
+	}); err != ErrScheduleInPast {
+		t.Fatalf("expected ErrScheduleInPast, got %v", err)
+	}
+
+	scheduled, err := svc.ScheduleMessage(&models.ScheduledMessage{
+		SenderID:       testMessageSender,
+		ConversationID: 1,
+		Content:        "  tomorrow  ",
+		SendAt:         time.Now().Add(24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("ScheduleMessage() error = %v", err)
+	}
+	if scheduled.Status != models.ScheduledStatusPending || scheduled.Content != "tomorrow" {
+		t.Fatalf("unexpected scheduled message %+v", scheduled)
+	}
+
+	if _, err := svc.CancelScheduledMessage(testMessageReceiver, scheduled.ID); err != ErrForbidden {
+		t.Fatalf("only the sender can cancel, got %v", err)
+	}
+	cancelled, err := svc.CancelScheduledMessage(testMessageSender, scheduled.ID)
+	if err != nil {
+		t.Fatalf("CancelScheduledMessage() error = %v", err)
+	}
+	if cancelled.Status != models.ScheduledStatusCancelled {
+		t.Fatalf("expected cancelled status, got %q", cancelled.Status)
+	}
+}
+
+func TestMessageServiceReferencedAttachments(t *testing.T) {
+	svc := NewMessageService(memory.NewMessageRepo())
+	send := func(attachment string) *models.ChatMessage {
+		t.Helper()
+		saved, err := svc.SendMessage(&models.ChatMessage{SenderID: testMessageSender, ConversationID: 1, Content: "pièce jointe", Attachment: attachment})
+		if err != nil {
+			t.Fatalf("SendMessage(%s) error = %v", attachment, err)
+		}
+		return saved
+	}
+	schedule := func(attachment string) *models.ScheduledMessage {
+		t.Helper()
+		scheduled, err := svc.ScheduleMessage(&models.ScheduledMessage{SenderID: testMessageSender, ConversationID: 1, Content: "plus tard", Attachment: attachment, SendAt: time.Now().Add(time.Hour)})
+		if err != nil {
+			t.Fatalf("ScheduleMessage(%s) error = %v", attachment, err)
+		}
+		return scheduled
+	}
+
+	send("media/1_photo.png")
+	send("http://minio:9000/media/media/2_ancienne.png")
+	// Nom antérieur à la normalisation : le media ID va jusqu'au bout de la pièce jointe.
+	send("media/7_dossier/photo.png")
+	deleted := send("media/3_supprimee.png")
+	if err := svc.DeleteMessageById(deleted.ID); err != nil {
+		t.Fatalf("DeleteMessageById() error = %v", err)
+	}
+	schedule("media/4_programmee.png")
+	cancelled := schedule("media/5_annulee.png")
+	if _, err := svc.CancelScheduledMessage(testMessageSender, cancelled.ID); err != nil {
+		t.Fatalf("CancelScheduledMessage() error = %v", err)
+	}
+
+	referenced, err := svc.ReferencedAttachments([]string{
+		"media/1_photo.png", "media/2_ancienne.png", "media/3_supprimee.png",
+		"media/4_programmee.png", "media/5_annulee.png", "media/6_inconnue.png", "media/7_dossier/photo.png",
+	})
+	if err != nil {
+		t.Fatalf("ReferencedAttachments() error = %v", err)
+	}
+	sort.Strings(referenced)
+	want := []string{"media/1_photo.png", "media/2_ancienne.png", "media/4_programmee.png", "media/7_dossier/photo.png"}
+	if !reflect.DeepEqual(referenced, want) {
+		t.Fatalf("ReferencedAttachments() = %v, want %v", referenced, want)
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+const (
+	minPollOptions        = 2
+	maxPollOptions        = 10
+	maxPollQuestionLength = 300
+	maxPollOptionLength   = 100
+)
+
+var (
+	ErrPollQuestionEmpty   = errors.New("invalid poll: question is empty")
+	ErrPollQuestionTooLong = errors.New("invalid poll: question too long")
+	ErrPollOptionCount     = errors.New("invalid poll: 2 to 10 options required")
+	ErrPollOptionInvalid   = errors.New("invalid poll: options must be unique, non-empty and at most 100 characters")
+	ErrPollClosesInPast    = errors.New("invalid poll: closes_at must be in the future")
+	ErrPollSingleChoice    = errors.New("invalid vote: this poll accepts a single option")
+	ErrPollUnknownOption   = errors.New("invalid vote: unknown option")
+)
+
+// PreparePoll valide et normalise un sondage avant la création de son message.
+func (s *MessageService) PreparePoll(poll *models.Poll, now time.Time) error {
+	if poll.CreatedBy == uuid.Nil {
+		return errors.New("sender ID is empty")
+	}
+	if poll.ConversationID == 0 {
+		return errors.New("conversation ID is empty")
+	}
+
+	poll.Question = strings.TrimSpace(poll.Question)
+	if poll.Question == "" {
+		return ErrPollQuestionEmpty
+	}
+	if len([]rune(poll.Question)) > maxPollQuestionLength {
+		return ErrPollQuestionTooLong
+	}
+
+	if len(poll.Options) < minPollOptions || len(poll.Options) > maxPollOptions {
+		return ErrPollOptionCount
+	}
+	seen := make(map[string]bool, len(poll.Options))
+	for i := range poll.Options {
+		label := strings.TrimSpace(poll.Options[i].Label)
+		key := strings.ToLower(label)
+		if label == "" || len([]rune(label)) > maxPollOptionLength || seen[key] {
+			return ErrPollOptionInvalid
+		}
+		seen[key] = true
+		poll.Options[i].Label = label
+		poll.Options[i].Position = i
+	}
+
+	if poll.ClosesAt != nil && !poll.ClosesAt.After(now) {
+		return ErrPollClosesInPast
+	}
+	return nil
+}
+
+// CreatePoll rattache le sondage (déjà validé par PreparePoll) à son message.
+func (s *MessageService) CreatePoll(poll *models.Poll) (*models.Poll, error) {
+	saved, err := s.messageRepo.CreatePoll(poll)
+	if err != nil {
+		return nil, err
+	}
+	tallyPoll(saved, nil, uuid.Nil)
+	return saved, nil
+}
+
+// GetPoll retourne le sondage avec son décompte ; viewerID renseigne MyOptionIDs.
+func (s *MessageService) GetPoll(pollID int, viewerID uuid.UUID) (*models.Poll, error) {
+	poll, err := s.messageRepo.GetPollByID(pollID)
+	if err != nil {
+		return nil, err
+	}
+	votes, err := s.messageRepo.ListPollVotes(pollID)
+	if err != nil {
+		return nil, err
+	}
+	tallyPoll(poll, votes, viewerID)
+	return poll, nil
+}
+
+// VotePoll remplace le vote de userID ; optionIDs vide retire le vote.
+// L'appartenance à la conversation est vérifiée par l'appelant (handler NATS).
+func (s *MessageService) VotePoll(pollID int, userID uuid.UUID, optionIDs []int, now time.Time) (*models.Poll, error) {
+	if userID == uuid.Nil {
+		return nil, ErrInvalidUserID
+	}
+	poll, err := s.messageRepo.GetPollByID(pollID)
+	if err != nil {
+		return nil, err
+	}
+	if poll.IsClosed(now) {
+		return nil, repo.ErrPollClosed
+	}
+
+	unique := make([]int, 0, len(optionIDs))
+	seen := make(map[int]bool, len(optionIDs))
+	for _, optionID := range optionIDs {
+		if seen[optionID] {
+			continue
+		}
+		if !poll.HasOption(optionID) {
+			return nil, ErrPollUnknownOption
+		}
+		seen[optionID] = true
+		unique = append(unique, optionID)
+	}
+	if !poll.MultiChoice && len(unique) > 1 {
+		return nil, ErrPollSingleChoice
+	}
+
+	if err := s.messageRepo.ReplacePollVotes(pollID, userID, unique); err != nil {
+		return nil, err
+	}
+	return s.GetPoll(pollID, userID)
+}
+
+// ClosePoll : réservé à l'auteur du sondage ou à un admin/owner de la conversation (isManager).
+func (s *MessageService) ClosePoll(pollID int, actorID uuid.UUID, isManager bool, now time.Time) (*models.Poll, error) {
+	poll, err := s.messageRepo.GetPollByID(pollID)
+	if err != nil {
+		return nil, err
+	}
+	if poll.CreatedBy != actorID && !isManager {
+		return nil, ErrForbidden
+	}
+	if poll.IsClosed(now) && poll.ClosedAt == nil {
+		// Échéance dépassée : on fige closed_at à closes_at pour garder la date réelle de fin.
+		now = *poll.ClosesAt
+	}
+	if _, err := s.messageRepo.ClosePoll(pollID, now); err != nil {
+		return nil, err
+	}
+	return s.GetPoll(pollID, actorID)
+}
+
+// tallyPoll calcule les compteurs par option ; les votants ne sont exposés que si le sondage n'est pas anonyme.
+func tallyPoll(poll *models.Poll, votes []*models.PollVote, viewerID uuid.UUID) {
+	indexByOption := make(map[int]int, len(poll.Options))
+	for i := range poll.Options {
+		poll.Options[i].VoteCount = 0
+		poll.Options[i].VoterIDs = nil
+		indexByOption[poll.Options[i].ID] = i
+	}
+
+	voters := make(map[uuid.UUID]bool)
+	poll.MyOptionIDs = nil
+	for _, vote := range votes {
+		i, ok := indexByOption[vote.OptionID]
+		if !ok {
+			continue
+		}
+		poll.Options[i].VoteCount++
+		if !poll.Anonymous {
+			poll.Options[i].VoterIDs = append(poll.Options[i].VoterIDs, vote.UserID)
+		}
+		voters[vote.UserID] = true
+		if viewerID != uuid.Nil && vote.UserID == viewerID {
+			poll.MyOptionIDs = append(poll.MyOptionIDs, vote.OptionID)
+		}
+	}
+	poll.TotalVoters = len(voters)
+}
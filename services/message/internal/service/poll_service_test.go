@@ -0,0 +1,176 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
+)
+
+func TestMessageServicePreparePollValidation(t *testing.T) {
+	svc := NewMessageService(memory.NewMessageRepo())
+	now := time.Now().UTC()
+	past := now.Add(-time.Minute)
+
+	cases := []struct {
+		name    string
+		poll    models.Poll
+		wantErr error
+	}{
+		{"empty question", models.Poll{Question: "  ", Options: pollOptions("a", "b")}, ErrPollQuestionEmpty},
+		{"question too long", models.Poll{Question: strings.Repeat("q", maxPollQuestionLength+1), Options: pollOptions("a", "b")}, ErrPollQuestionTooLong},
+		{"single option", models.Poll{Question: "Q ?", Options: pollOptions("a")}, ErrPollOptionCount},
+		{"too many options", models.Poll{Question: "Q ?", Options: pollOptions("1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11")}, ErrPollOptionCount},
+		{"duplicate option", models.Poll{Question: "Q ?", Options: pollOptions("Oui", " oui ")}, ErrPollOptionInvalid},
+		{"blank option", models.Poll{Question: "Q ?", Options: pollOptions("a", " ")}, ErrPollOptionInvalid},
+		{"closes in past", models.Poll{Question: "Q ?", Options: pollOptions("a", "b"), ClosesAt: &past}, ErrPollClosesInPast},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			poll := tc.poll
+			poll.CreatedBy = testUserOwner
+			poll.ConversationID = 1
+			if err := svc.PreparePoll(&poll, now); !errors.Is(err, tc.wantErr) {
+				t.Fatalf("PreparePoll() error = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+
+	poll := models.Poll{CreatedBy: testUserOwner, ConversationID: 1, Question: "  Midi ?  ", Options: pollOptions(" Pizza ", "Sushi")}
+	if err := svc.PreparePoll(&poll, now); err != nil {
+		t.Fatalf("PreparePoll(valid) error = %v", err)
+	}
+	if poll.Question != "Midi ?" || poll.Options[0].Label != "Pizza" || poll.Options[1].Position != 1 {
+		t.Fatalf("poll not normalized: %+v", poll)
+	}
+}
+
+func TestMessageServicePollVotes(t *testing.T) {
+	svc, poll := newTestPoll(t, false, false)
+	now := time.Now().UTC()
+	first, second := poll.Options[0].ID, poll.Options[1].ID
+
+	if _, err := svc.VotePoll(poll.ID, testUserMember, []int{first, second}, now); !errors.Is(err, ErrPollSingleChoice) {
+		t.Fatalf("expected ErrPollSingleChoice, got %v", err)
+	}
+	if _, err := svc.VotePoll(poll.ID, testUserMember, []int{9999}, now); !errors.Is(err, ErrPollUnknownOption) {
+		t.Fatalf("expected ErrPollUnknownOption, got %v", err)
+	}
+
+	if _, err := svc.VotePoll(poll.ID, testUserMember, []int{first}, now); err != nil {
+		t.Fatalf("VotePoll(first) error = %v", err)
+	}
+	// Un second vote remplace le premier.
+	updated, err := svc.VotePoll(poll.ID, testUserMember, []int{second}, now)
+	if err != nil {
+		t.Fatalf("VotePoll(second) error = %v", err)
+	}
+	if updated.Options[0].VoteCount != 0 || updated.Options[1].VoteCount != 1 || updated.TotalVoters != 1 {
+		t.Fatalf("vote should be replaced, got %+v", updated.Options)
+	}
+	if len(updated.MyOptionIDs) != 1 || updated.MyOptionIDs[0] != second {
+		t.Fatalf("MyOptionIDs = %v, want [%d]", updated.MyOptionIDs, second)
+	}
+	if len(updated.Options[1].VoterIDs) != 1 || updated.Options[1].VoterIDs[0] != testUserMember {
+		t.Fatalf("voters should be visible on a public poll, got %v", updated.Options[1].VoterIDs)
+	}
+
+	// Liste vide : retrait du vote.
+	cleared, err := svc.VotePoll(poll.ID, testUserMember, nil, now)
+	if err != nil {
+		t.Fatalf("VotePoll(clear) error = %v", err)
+	}
+	if cleared.TotalVoters != 0 || cleared.Options[1].VoteCount != 0 {
+		t.Fatalf("vote should be cleared, got %+v", cleared)
+	}
+}
+
+func TestMessageServicePollMultiChoiceAnonymous(t *testing.T) {
+	svc, poll := newTestPoll(t, true, true)
+	now := time.Now().UTC()
+	first, second := poll.Options[0].ID, poll.Options[1].ID
+
+	if _, err := svc.VotePoll(poll.ID, testUserMember, []int{first, second, first}, now); err != nil {
+		t.Fatalf("VotePoll(multi) error = %v", err)
+	}
+	tally, err := svc.VotePoll(poll.ID, testUserAdmin, []int{first}, now)
+	if err != nil {
+		t.Fatalf("VotePoll(admin) error = %v", err)
+	}
+	if tally.Options[0].VoteCount != 2 || tally.Options[1].VoteCount != 1 || tally.TotalVoters != 2 {
+		t.Fatalf("unexpected tally: %+v (total %d)", tally.Options, tally.TotalVoters)
+	}
+	for _, option := range tally.Options {
+		if len(option.VoterIDs) != 0 {
+			t.Fatalf("anonymous poll must not expose voters, got %v", option.VoterIDs)
+		}
+	}
+}
+
+func TestMessageServiceClosePoll(t *testing.T) {
+	svc, poll := newTestPoll(t, false, false)
+	now := time.Now().UTC()
+
+	if _, err := svc.ClosePoll(poll.ID, testUserMember, false, now); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("non-author close should be forbidden, got %v", err)
+	}
+	closed, err := svc.ClosePoll(poll.ID, testUserAdmin, true, now)
+	if err != nil {
+		t.Fatalf("manager close error = %v", err)
+	}
+	if closed.ClosedAt == nil {
+		t.Fatalf("closed poll should carry closed_at")
+	}
+	if _, err := svc.VotePoll(poll.ID, testUserMember, []int{poll.Options[0].ID}, now); !errors.Is(err, repo.ErrPollClosed) {
+		t.Fatalf("vote on closed poll: expected ErrPollClosed, got %v", err)
+	}
+
+	// Échéance atteinte sans fermeture explicite.
+	svc2, poll2 := newTestPoll(t, false, false)
+	if _, err := svc2.VotePoll(poll2.ID, testUserMember, []int{poll2.Options[0].ID}, poll2.ClosesAt.Add(time.Second)); !errors.Is(err, repo.ErrPollClosed) {
+		t.Fatalf("vote after closes_at: expected ErrPollClosed, got %v", err)
+	}
+}
+
+func newTestPoll(t *testing.T, multiChoice, anonymous bool) (*MessageService, *models.Poll) {
+	t.Helper()
+	svc := NewMessageService(memory.NewMessageRepo())
+	now := time.Now().UTC()
+	closesAt := now.Add(time.Hour)
+
+	poll := &models.Poll{
+		CreatedBy:      testUserOwner,
+		ConversationID: 1,
+		Question:       "Réunion demain ?",
+		MultiChoice:    multiChoice,
+		Anonymous:      anonymous,
+		ClosesAt:       &closesAt,
+		Options:        pollOptions("Oui", "Non", "Peut-être"),
+	}
+	if err := svc.PreparePoll(poll, now); err != nil {
+		t.Fatalf("PreparePoll() error = %v", err)
+	}
+	msg, err := svc.SendMessage(&models.ChatMessage{SenderID: testUserOwner, ConversationID: 1, Content: poll.Question})
+	if err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	poll.MessageID = msg.ID
+
+	created, err := svc.CreatePoll(poll)
+	if err != nil {
+		t.Fatalf("CreatePoll() error = %v", err)
+	}
+	return svc, created
+}
+
+func pollOptions(labels ...string) []models.PollOption {
+	options := make([]models.PollOption, 0, len(labels))
+	for _, label := range labels {
+		options = append(options, models.PollOption{Label: label})
+	}
+	return options
+}
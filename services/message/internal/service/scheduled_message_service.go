@@ -0,0 +1,90 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo"
+	"github.com/google/uuid"
+)
+
+// maxScheduleHorizon borne la date d'envoi d'un message programmé.
+const maxScheduleHorizon = 365 * 24 * time.Hour
+
+var (
+	ErrScheduleInPast      = errors.New("invalid send_at: must be in the future")
+	ErrScheduleTooFarAhead = errors.New("invalid send_at: too far in the future")
+)
+
+func (s *MessageService) ScheduleMessage(msg *models.ScheduledMessage) (*models.ScheduledMessage, error) {
+	if msg.SenderID == uuid.Nil {
+		return nil, errors.New("sender ID is empty")
+	}
+	if msg.ConversationID == 0 {
+		return nil, errors.New("conversation ID is empty")
+	}
+
+	content := strings.TrimSpace(msg.Content)
+	if content == "" {
+		return nil, errors.New("message content is empty")
+	}
+	if len(content) > maxMessageContentLength {
+		return nil, errors.New("message content too long")
+	}
+	msg.Content = content
+
+	now := time.Now()
+	if !msg.SendAt.After(now) {
+		return nil, ErrScheduleInPast
+	}
+	if msg.SendAt.Sub(now) > maxScheduleHorizon {
+		return nil, ErrScheduleTooFarAhead
+	}
+	msg.Status = models.ScheduledStatusPending
+
+	return s.messageRepo.CreateScheduledMessage(msg)
+}
+
+// ListScheduledMessages retourne les messages programmés de senderID (conversationID = 0 : toutes).
+func (s *MessageService) ListScheduledMessages(senderID uuid.UUID, conversationID int) ([]*models.ScheduledMessage, error) {
+	if senderID == uuid.Nil {
+		return nil, errors.New("sender ID is empty")
+	}
+	return s.messageRepo.ListScheduledMessagesBySender(senderID, conversationID)
+}
+
+// CancelScheduledMessage annule un message encore en attente ; seul l'auteur peut annuler.
+func (s *MessageService) CancelScheduledMessage(actorID uuid.UUID, id int) (*models.ScheduledMessage, error) {
+	if id == 0 {
+		return nil, errors.New("id is empty")
+	}
+	if actorID == uuid.Nil {
+		return nil, errors.New("actor ID is empty")
+	}
+
+	existing, err := s.messageRepo.GetScheduledMessageByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if existing.SenderID != actorID {
+		return nil, ErrForbidden
+	}
+	return s.messageRepo.CancelScheduledMessage(id)
+}
+
+func (s *MessageService) ClaimDueScheduledMessages(now time.Time, lease time.Duration, limit int) ([]*models.ScheduledMessage, error) {
+	return s.messageRepo.ClaimDueScheduledMessages(now, lease, limit)
+}
+
+func (s *MessageService) SendScheduledMessage(scheduled *models.ScheduledMessage, msg *models.ChatMessage) (*models.ChatMessage, error) {
+	return s.messageRepo.SendScheduledMessage(scheduled, msg)
+}
+
+func (s *MessageService) MarkScheduledMessageFailed(scheduled *models.ScheduledMessage, reason string) error {
+	if scheduled.ClaimedAt == nil {
+		return repo.ErrScheduledMessageNotPending
+	}
+	return s.messageRepo.MarkScheduledMessageFailed(scheduled.ID, *scheduled.ClaimedAt, reason)
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"log"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/google/uuid"
+)
+
+// SystemMessageWriter enregistre les messages système (implémenté par MessageService).
+type SystemMessageWriter interface {
+	SendMessage(msg *models.ChatMessage) (*models.ChatMessage, error)
+}
+
+// EnableSystemMessages active l'écriture des messages système dans la timeline des groupes
+// (membre ajouté, retiré, parti, changement de rôle, renommage). onSaved est appelé après chaque
+// écriture réussie (diffusion temps réel) et peut être nil. Sans appel, aucune écriture (tests).
+func (s *ConversationService) EnableSystemMessages(writer SystemMessageWriter, onSaved func(*models.ChatMessage)) {
+	s.systemMessages = writer
+	s.onSystemMessage = onSaved
+}
+
+// postSystemMessage écrit un message système ; best effort : un échec est journalisé sans annuler
+// la mutation déjà appliquée. Les conversations directes n'en reçoivent pas.
+func (s *ConversationService) postSystemMessage(conversationID int, event models.SystemEvent) {
+	if s.systemMessages == nil {
+		return
+	}
+	conversation, err := s.conversationRepo.GetConversationByID(conversationID)
+	if err != nil || conversation.IsDirect() {
+		return
+	}
+
+	saved, err := s.systemMessages.SendMessage(&models.ChatMessage{
+		SenderID:       event.ActorID,
+		ConversationID: conversationID,
+		Content:        event.Type,
+		Kind:           models.MessageKindSystem,
+		System:         &event,
+	})
+	if err != nil {
+		log.Printf("[system-messages] %s (conversation %d): %v", event.Type, conversationID, err)
+		return
+	}
+	if s.onSystemMessage != nil {
+		s.onSystemMessage(saved)
+	}
+}
+
+func memberEvent(eventType string, actorID, targetID uuid.UUID) models.SystemEvent {
+	event := models.SystemEvent{Type: eventType, ActorID: actorID}
+	if targetID != uuid.Nil {
+		event.TargetID = &targetID
+	}
+	return event
+}
+
+func roleEvent(eventType string, actorID, targetID uuid.UUID, role models.ConversationRole) models.SystemEvent {
+	event := memberEvent(eventType, actorID, targetID)
+	event.Role = &role
+	return event
+}
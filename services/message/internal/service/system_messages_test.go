@@ -0,0 +1,112 @@
+package service
+
+import (
+	"testing"
+
+	models "github.com/Mathis-brgs/storm-project/services/message/internal/models"
+	"github.com/Mathis-brgs/storm-project/services/message/internal/repo/memory"
+)
+
+func TestConversationServiceWritesSystemMessages(t *testing.T) {
+	messageRepo := memory.NewMessageRepo()
+	svc := NewConversationService(memory.NewConversationRepo())
+	var published []*models.ChatMessage
+	svc.EnableSystemMessages(NewMessageService(messageRepo), func(msg *models.ChatMessage) {
+		published = append(published, msg)
+	})
+
+	conversation, err := svc.CreateConversation(testUserOwner, "Équipe", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	if _, err := svc.AddMember(testUserOwner, conversation.ID, testUserMember, models.ConversationRoleMember); err != nil {
+		t.Fatalf("AddMember() error = %v", err)
+	}
+	if _, err := svc.UpdateMemberRole(testUserOwner, conversation.ID, testUserMember, models.ConversationRoleAdmin); err != nil {
+		t.Fatalf("UpdateMemberRole() error = %v", err)
+	}
+	name := "Équipe produit"
+	if _, _, err := svc.UpdateConversation(testUserOwner, conversation.ID, ConversationUpdate{Name: &name}); err != nil {
+		t.Fatalf("UpdateConversation() error = %v", err)
+	}
+	if _, err := svc.AddMember(testUserOwner, conversation.ID, testUserOther, models.ConversationRoleMember); err != nil {
+		t.Fatalf("AddMember(other) error = %v", err)
+	}
+	if err := svc.RemoveMember(testUserOwner, conversation.ID, testUserOther); err != nil {
+		t.Fatalf("RemoveMember() error = %v", err)
+	}
+	if err := svc.LeaveConversation(testUserMember, conversation.ID); err != nil {
+		t.Fatalf("LeaveConversation() error = %v", err)
+	}
+
+	expected := []string{
+		models.SystemEventMemberAdded,
+		models.SystemEventRoleChanged,
+		models.SystemEventConversationRenamed,
+		models.SystemEventMemberAdded,
+		models.SystemEventMemberRemoved,
+		models.SystemEventMemberLeft,
+	}
+	if len(published) != len(expected) {
+		t.Fatalf("expected %d system messages, got %d", len(expected), len(published))
+	}
+	for i, msg := range published {
+		if msg.Kind != models.MessageKindSystem || msg.System == nil || msg.System.Type != expected[i] {
+			t.Fatalf("message %d: expected %s system message, got %+v", i, expected[i], msg)
+		}
+		if msg.ConversationID != conversation.ID || msg.ID == 0 {
+			t.Fatalf("message %d should be persisted in the conversation, got %+v", i, msg)
+		}
+	}
+
+	added := published[0].System
+	if added.ActorID != testUserOwner || added.TargetID == nil || *added.TargetID != testUserMember {
+		t.Fatalf("unexpected member_added event %+v", added)
+	}
+	if role := published[1].System.Role; role == nil || *role != models.ConversationRoleAdmin {
+		t.Fatalf("role_changed should carry the new role, got %v", role)
+	}
+	if published[2].System.Name != name {
+		t.Fatalf("conversation_renamed should carry the new name, got %q", published[2].System.Name)
+	}
+	if left := published[5].System; left.ActorID != testUserMember || left.TargetID != nil {
+		t.Fatalf("unexpected member_left event %+v", left)
+	}
+
+	stored, err := messageRepo.GetMessagesByConversationID(conversation.ID)
+	if err != nil {
+		t.Fatalf("GetMessagesByConversationID() error = %v", err)
+	}
+	if len(stored) != len(expected) {
+		t.Fatalf("expected %d stored messages, got %d", len(expected), len(stored))
+	}
+}
+
+func TestConversationServiceSystemMessagesSkipFailuresAndDirect(t *testing.T) {
+	svc := NewConversationService(memory.NewConversationRepo())
+	var published int
+	svc.EnableSystemMessages(NewMessageService(memory.NewMessageRepo()), func(*models.ChatMessage) {
+		published++
+	})
+
+	conversation, err := svc.CreateConversation(testUserOwner, "Équipe", "")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	// Mutation refusée : aucun message système.
+	if _, err := svc.AddMember(testUserOther, conversation.ID, testUserMember, models.ConversationRoleMember); err == nil {
+		t.Fatalf("non-member add should fail")
+	}
+
+	direct, _, err := svc.GetOrCreateDirectConversation(testUserOwner, testUserMember)
+	if err != nil {
+		t.Fatalf("GetOrCreateDirectConversation() error = %v", err)
+	}
+	if err := svc.LeaveConversation(testUserMember, direct.ID); err != nil {
+		t.Fatalf("LeaveConversation(direct) error = %v", err)
+	}
+
+	if published != 0 {
+		t.Fatalf("expected no system message, got %d", published)
+	}
+}
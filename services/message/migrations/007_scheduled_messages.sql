@@ -0,0 +1,39 @@
+-- Migration 007: messages programmés (SCHEDULE_MESSAGE)
+-- À exécuter après 001/005/006. Idempotent.
+-- Le scheduler réclame les lignes dues avec FOR UPDATE SKIP LOCKED :
+-- un seul replica envoie chaque message, les lignes 'processing' dont le bail
+-- a expiré (crash/redémarrage) sont reprises au tick suivant.
+
+CREATE TABLE IF NOT EXISTS scheduled_messages (
+    id              SERIAL PRIMARY KEY,
+    sender_id       UUID NOT NULL,
+    conversation_id INTEGER NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    content         TEXT NOT NULL,
+    attachment      TEXT,
+    reply_to_id     INTEGER REFERENCES messages(id) ON DELETE SET NULL,
+    send_at         TIMESTAMPTZ NOT NULL,
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending',
+    message_id      INTEGER REFERENCES messages(id) ON DELETE SET NULL,
+    last_error      TEXT,
+    claimed_at      TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint
+        WHERE conname = 'chk_scheduled_messages_status'
+    ) THEN
+        ALTER TABLE scheduled_messages
+            ADD CONSTRAINT chk_scheduled_messages_status
+            CHECK (status IN ('pending', 'processing', 'sent', 'cancelled', 'failed'));
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_messages_due
+    ON scheduled_messages (send_at, id) WHERE status IN ('pending', 'processing');
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_messages_sender
+    ON scheduled_messages (sender_id, send_at);
@@ -0,0 +1,13 @@
+-- Migration 008: messages épinglés par conversation (PIN_MESSAGE / UNPIN_MESSAGE / LIST_PINS)
+-- À exécuter après 001/005/006. Idempotent.
+
+CREATE TABLE IF NOT EXISTS conversation_pins (
+    conversation_id INTEGER NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    message_id      INTEGER NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    pinned_by       UUID NOT NULL,
+    pinned_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (conversation_id, message_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversation_pins_conversation_pinned_at
+    ON conversation_pins (conversation_id, pinned_at DESC);
@@ -0,0 +1,8 @@
+-- Migration 009: mentions @username (NEW_MESSAGE)
+-- À exécuter après 001/005/006. Idempotent.
+-- Les usernames sont résolus par le message-service (user.search) au moment de l'envoi :
+-- seuls les UUID des membres mentionnés sont stockés, le contenu reste du texte brut.
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS mentions UUID[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_messages_mentions ON messages USING GIN (mentions);
@@ -0,0 +1,15 @@
+-- Migration 010: aperçus de liens (unfurl OpenGraph asynchrone)
+-- À exécuter après 001/005/006. Idempotent.
+-- link_previews sert de cache par URL (partagé entre replicas) ; l'aperçu retenu
+-- est recopié dans messages.link_preview pour être servi sans jointure.
+
+CREATE TABLE IF NOT EXISTS link_previews (
+    url         TEXT PRIMARY KEY,
+    title       TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    image_url   TEXT NOT NULL DEFAULT '',
+    site_name   TEXT NOT NULL DEFAULT '',
+    fetched_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS link_preview JSONB;
@@ -0,0 +1,35 @@
+-- Migration 011: sondages dans les conversations (POLL_CREATE / POLL_VOTE / POLL_CLOSE / POLL_GET)
+-- À exécuter après 001/005/006. Idempotent.
+-- Un sondage est rattaché au message qui l'affiche dans la timeline (messages.id, 1 sondage max par message).
+-- Les votes sont stockés par utilisateur : changer de vote remplace les lignes de l'utilisateur.
+
+CREATE TABLE IF NOT EXISTS polls (
+    id              SERIAL PRIMARY KEY,
+    message_id      INTEGER NOT NULL UNIQUE REFERENCES messages(id) ON DELETE CASCADE,
+    conversation_id INTEGER NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    created_by      UUID NOT NULL,
+    question        TEXT NOT NULL,
+    multi_choice    BOOLEAN NOT NULL DEFAULT FALSE,
+    anonymous       BOOLEAN NOT NULL DEFAULT FALSE,
+    closes_at       TIMESTAMPTZ,
+    closed_at       TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS poll_options (
+    id       SERIAL PRIMARY KEY,
+    poll_id  INTEGER NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    label    TEXT NOT NULL,
+    UNIQUE (poll_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS poll_votes (
+    poll_id   INTEGER NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    option_id INTEGER NOT NULL REFERENCES poll_options(id) ON DELETE CASCADE,
+    user_id   UUID NOT NULL,
+    voted_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (poll_id, option_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_poll_votes_poll_user ON poll_votes (poll_id, user_id);
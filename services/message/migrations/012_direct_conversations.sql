@@ -0,0 +1,60 @@
+-- Migration 012: conversations directes 1:1 (DIRECT_GET_OR_CREATE)
+-- À exécuter après 001/005/006. Idempotent.
+--   - conversations.kind : 'group' | 'direct'
+--   - conversations.direct_key : "<uuid min>:<uuid max>", unique parmi les conversations actives
+
+ALTER TABLE conversations
+    ADD COLUMN IF NOT EXISTS kind VARCHAR(16) NOT NULL DEFAULT 'group';
+
+ALTER TABLE conversations
+    ADD COLUMN IF NOT EXISTS direct_key TEXT;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint WHERE conname = 'chk_conversations_kind'
+    ) THEN
+        ALTER TABLE conversations
+            ADD CONSTRAINT chk_conversations_kind CHECK (kind IN ('group', 'direct'));
+    END IF;
+END $$;
+
+-- Reprise de l'existant : une conversation sans nom à exactement 2 membres actifs était un DM de fait.
+-- Seule la plus ancienne par paire devient 'direct' ; les doublons restent des groupes (messages conservés).
+WITH pairs AS (
+    SELECT cu.conversation_id,
+           MIN(cu.user_id::text) || ':' || MAX(cu.user_id::text) AS direct_key
+    FROM conversations_users cu
+    INNER JOIN conversations c
+      ON c.id = cu.conversation_id
+    WHERE cu.deleted_at IS NULL
+      AND c.deleted_at IS NULL
+      AND c.kind = 'group'
+      AND c.direct_key IS NULL
+      AND c.name IN ('', 'Untitled conversation')
+    GROUP BY cu.conversation_id
+    HAVING COUNT(DISTINCT cu.user_id) = 2
+),
+ranked AS (
+    SELECT p.conversation_id,
+           p.direct_key,
+           ROW_NUMBER() OVER (PARTITION BY p.direct_key ORDER BY p.conversation_id) AS rn
+    FROM pairs p
+    WHERE NOT EXISTS (
+        SELECT 1
+        FROM conversations d
+        WHERE d.direct_key = p.direct_key
+          AND d.deleted_at IS NULL
+    )
+)
+UPDATE conversations c
+SET kind = 'direct',
+    name = '',
+    direct_key = r.direct_key
+FROM ranked r
+WHERE c.id = r.conversation_id
+  AND r.rn = 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_conversations_direct_key_active
+    ON conversations (direct_key)
+    WHERE direct_key IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,5 @@
+-- Migration 013: description des conversations (GROUP_UPDATE)
+-- À exécuter après 001/005/006. Idempotent.
+
+ALTER TABLE conversations
+    ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
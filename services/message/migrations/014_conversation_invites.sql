@@ -0,0 +1,27 @@
+-- Migration 014: liens d'invitation vers les groupes (GROUP_INVITE_*) + audit des utilisations
+-- À exécuter après 001/005/006. Idempotent.
+
+CREATE TABLE IF NOT EXISTS conversation_invites (
+    id              SERIAL PRIMARY KEY,
+    token           TEXT NOT NULL UNIQUE,
+    conversation_id INTEGER NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    created_by      UUID NOT NULL,
+    expires_at      TIMESTAMPTZ,
+    max_uses        INTEGER NOT NULL DEFAULT 0 CHECK (max_uses >= 0), -- 0 = illimité
+    use_count       INTEGER NOT NULL DEFAULT 0,
+    revoked_at      TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversation_invites_conversation_created_at
+    ON conversation_invites (conversation_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS conversation_invite_uses (
+    id        SERIAL PRIMARY KEY,
+    invite_id INTEGER NOT NULL REFERENCES conversation_invites(id) ON DELETE CASCADE,
+    user_id   UUID NOT NULL,
+    used_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversation_invite_uses_invite
+    ON conversation_invite_uses (invite_id, used_at DESC);
@@ -0,0 +1,21 @@
+-- Migration 015: demandes d'adhésion aux groupes (GROUP_JOIN_REQUEST / GROUP_JOIN_DECIDE / GROUP_JOIN_LIST)
+-- À exécuter après 001/005/006. Idempotent.
+
+CREATE TABLE IF NOT EXISTS conversation_join_requests (
+    id              SERIAL PRIMARY KEY,
+    conversation_id INTEGER NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    user_id         UUID NOT NULL,
+    message         TEXT NOT NULL DEFAULT '',
+    status          TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'denied')),
+    decided_by      UUID,
+    decided_at      TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Une seule demande en attente par utilisateur et par groupe.
+CREATE UNIQUE INDEX IF NOT EXISTS uq_conversation_join_requests_pending
+    ON conversation_join_requests (conversation_id, user_id)
+    WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_conversation_join_requests_conversation_status
+    ON conversation_join_requests (conversation_id, status, created_at);
@@ -0,0 +1,20 @@
+-- Migration 016: messages système (ajout/retrait/départ de membre, changement de rôle, renommage)
+-- À exécuter après 001/005/006. Idempotent.
+--   - messages.kind : 'user' | 'system'
+--   - messages.system_event : données structurées de l'événement (type, actor_id, target_id, role, name)
+
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS kind VARCHAR(16) NOT NULL DEFAULT 'user';
+
+ALTER TABLE messages
+    ADD COLUMN IF NOT EXISTS system_event JSONB;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint WHERE conname = 'chk_messages_kind'
+    ) THEN
+        ALTER TABLE messages
+            ADD CONSTRAINT chk_messages_kind CHECK (kind IN ('user', 'system'));
+    END IF;
+END $$;
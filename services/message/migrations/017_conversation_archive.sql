@@ -0,0 +1,11 @@
+-- Migration 017: archivage par membre, restauration et purge des conversations supprimées
+-- À exécuter après 001/005/006. Idempotent.
+-- archived_at masque la conversation de la liste d'un seul membre ; la purge retrouve
+-- les conversations supprimées (deleted_at) au-delà du délai de grâce.
+
+ALTER TABLE conversations_users
+    ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_conversations_deleted_at
+    ON conversations (deleted_at)
+    WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,24 @@
+-- Migration 018: permissions par conversation (GROUP_UPDATE_PERMISSIONS)
+-- À exécuter après 001/005/006. Idempotent.
+-- Les valeurs par défaut reproduisent le comportement antérieur (rôles fixes, pas de mode lent).
+
+ALTER TABLE conversations
+    ADD COLUMN IF NOT EXISTS only_admins_can_post BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS members_can_add BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS members_can_pin BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS slow_mode_seconds INTEGER NOT NULL DEFAULT 0;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint WHERE conname = 'chk_conversations_slow_mode_seconds'
+    ) THEN
+        ALTER TABLE conversations
+            ADD CONSTRAINT chk_conversations_slow_mode_seconds
+            CHECK (slow_mode_seconds >= 0 AND slow_mode_seconds <= 21600);
+    END IF;
+END $$;
+
+-- Mode lent : dernier message d'un membre dans la conversation.
+CREATE INDEX IF NOT EXISTS idx_messages_conversation_sender_created
+    ON messages (conversation_id, sender_id, created_at DESC);
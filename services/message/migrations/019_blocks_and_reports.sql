@@ -0,0 +1,33 @@
+-- Migration 019: blocage d'utilisateurs (USER_BLOCK) et signalement de messages (MESSAGE_REPORT)
+-- À exécuter après 001/005/006. Idempotent.
+
+CREATE TABLE IF NOT EXISTS user_blocks (
+    blocker_id UUID NOT NULL,
+    blocked_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+-- "Qui m'a bloqué ?" : contrôles à l'ajout, au DM et aux mentions.
+CREATE INDEX IF NOT EXISTS idx_user_blocks_blocked
+    ON user_blocks (blocked_id);
+
+CREATE TABLE IF NOT EXISTS message_reports (
+    id              SERIAL PRIMARY KEY,
+    message_id      INTEGER NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    conversation_id INTEGER NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    reporter_id     UUID NOT NULL,
+    reason          TEXT NOT NULL,
+    status          TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'resolved', 'dismissed')),
+    resolved_by     UUID,
+    resolved_at     TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Un utilisateur ne signale un message qu'une fois.
+CREATE UNIQUE INDEX IF NOT EXISTS uq_message_reports_reporter
+    ON message_reports (message_id, reporter_id);
+
+CREATE INDEX IF NOT EXISTS idx_message_reports_conversation_status
+    ON message_reports (conversation_id, status, created_at);
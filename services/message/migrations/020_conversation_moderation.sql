@@ -0,0 +1,61 @@
+-- Migration 020: modération des groupes (GROUP_BAN, GROUP_MUTE, GROUP_KICK, GROUP_AUDIT_LOG)
+-- À exécuter après 001/005/006. Idempotent.
+--   - conversation_bans : un banni ne peut être ré-ajouté ni rejoindre par invitation ou demande d'adhésion
+--   - conversation_mutes : le membre lit mais ne publie plus jusqu'à muted_until (survit à un départ/retour)
+--   - conversation_audit_log : journal des actions de modération, en ajout seul
+
+CREATE TABLE IF NOT EXISTS conversation_bans (
+    conversation_id INTEGER NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    user_id         UUID NOT NULL,
+    banned_by       UUID NOT NULL,
+    reason          TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (conversation_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS conversation_mutes (
+    conversation_id INTEGER NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    user_id         UUID NOT NULL,
+    muted_by        UUID NOT NULL,
+    muted_until     TIMESTAMPTZ NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (conversation_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS conversation_audit_log (
+    id              SERIAL PRIMARY KEY,
+    conversation_id INTEGER NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    actor_id        UUID NOT NULL,
+    action          VARCHAR(16) NOT NULL,
+    target_id       UUID NOT NULL,
+    reason          TEXT NOT NULL DEFAULT '',
+    expires_at      TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_constraint WHERE conname = 'chk_conversation_audit_log_action'
+    ) THEN
+        ALTER TABLE conversation_audit_log
+            ADD CONSTRAINT chk_conversation_audit_log_action
+            CHECK (action IN ('ban', 'unban', 'mute', 'unmute', 'kick'));
+    END IF;
+END $$;
+
+-- GROUP_AUDIT_LOG : plus récentes d'abord, pagination par id.
+CREATE INDEX IF NOT EXISTS idx_conversation_audit_log_conversation
+    ON conversation_audit_log (conversation_id, id DESC);
+
+-- Ajout seul : une entrée du journal n'est jamais modifiée (la purge de la conversation les supprime en cascade).
+CREATE OR REPLACE FUNCTION conversation_audit_log_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'conversation_audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_conversation_audit_log_immutable ON conversation_audit_log;
+CREATE TRIGGER trg_conversation_audit_log_immutable
+    BEFORE UPDATE ON conversation_audit_log
+    FOR EACH ROW EXECUTE FUNCTION conversation_audit_log_immutable();
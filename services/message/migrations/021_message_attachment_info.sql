@@ -0,0 +1,6 @@
+-- Migration 021: métadonnées des pièces jointes (documents, audio, notes vocales)
+-- À exécuter après 001/005/006. Idempotent.
+-- messages.attachment_info : type, nom, taille, durée et forme d'onde du média (media_id = attachment),
+-- résolus auprès du media-service à l'envoi ; NULL pour les messages antérieurs ou non résolus.
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS attachment_info JSONB;
@@ -0,0 +1,12 @@
+-- Migration 022: index des médias cités par les messages (ramasse-miettes du media-service)
+-- À exécuter après 007/021. Idempotent.
+-- La clé média est le dernier segment media/<nanos>_<nom> de attachment (media ID ou ancienne URL
+-- endpoint/bucket/key) ; seuls les messages non supprimés et les envois programmés en attente comptent.
+
+CREATE INDEX IF NOT EXISTS idx_messages_media_ref
+    ON messages ((substring(attachment FROM 'media/[^/]+$')))
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_messages_media_ref
+    ON scheduled_messages ((substring(attachment FROM 'media/[^/]+$')))
+    WHERE status IN ('pending', 'processing');
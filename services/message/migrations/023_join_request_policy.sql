@@ -0,0 +1,10 @@
+-- Migration 023: politique des demandes d'adhésion (permission join_requests_enabled)
+-- À exécuter après 015/018. Idempotent.
+-- Désactivées par défaut : l'owner ouvre explicitement son groupe aux demandes.
+
+ALTER TABLE conversations
+    ADD COLUMN IF NOT EXISTS join_requests_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Délai entre deux demandes : dernière demande d'un utilisateur pour un groupe.
+CREATE INDEX IF NOT EXISTS idx_conversation_join_requests_conversation_user_created
+    ON conversation_join_requests (conversation_id, user_id, created_at DESC);
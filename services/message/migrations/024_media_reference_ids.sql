@@ -0,0 +1,16 @@
+-- Migration 024: références média reconnues jusqu'au bout du nom de fichier
+-- À exécuter après 022. Idempotent.
+-- Le media ID est media/<nanos>_<nom> jusqu'à la fin de attachment : un nom contenant « / » (médias
+-- envoyés avant la normalisation des noms côté media-service) n'était pas reconnu par 'media/[^/]+$',
+-- et le ramasse-miettes supprimait le média. Expression identique à models.AttachmentMediaID.
+
+DROP INDEX IF EXISTS idx_messages_media_ref;
+DROP INDEX IF EXISTS idx_scheduled_messages_media_ref;
+
+CREATE INDEX IF NOT EXISTS idx_messages_media_id_ref
+    ON messages ((substring(attachment FROM 'media/[0-9]+_.*$')))
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_messages_media_id_ref
+    ON scheduled_messages ((substring(attachment FROM 'media/[0-9]+_.*$')))
+    WHERE status IN ('pending', 'processing');
@@ -0,0 +1,7 @@
+-- Migration 025: index des médias utilisés comme avatar de groupe (ramasse-miettes du media-service)
+-- À exécuter après 024. Idempotent.
+-- Un avatar envoyé par /media/upload n'est cité par aucun message : sans cette référence, il était
+-- supprimé passé le délai de grâce. Expression identique à models.AttachmentMediaID.
+
+CREATE INDEX IF NOT EXISTS idx_conversations_avatar_media_ref
+    ON conversations ((substring(avatar_url FROM 'media/[0-9]+_.*$')));
@@ -0,0 +1,6 @@
+-- Migration 026: dernier envoi par membre, pour le mode lent
+-- À exécuter après 025. Idempotent.
+-- Le contrôle du mode lent réserve l'envoi dans la même requête (UPDATE conditionnel) : deux envois
+-- simultanés, ou des messages programmés à la même échéance, ne passent plus ensemble.
+
+ALTER TABLE conversations_users ADD COLUMN IF NOT EXISTS last_posted_at TIMESTAMPTZ;
@@ -15,11 +15,19 @@ const (
 	ttl            = 7 * 24 * time.Hour
 )
 
+// Priorités : une notification "high" (ex. mention) doit être délivrée même si
+// la conversation est en sourdine ; "normal" est la valeur par défaut.
+const (
+	PriorityNormal = "normal"
+	PriorityHigh   = "high"
+)
+
 type Notification struct {
 	ID        string `json:"id"`
 	UserID    string `json:"userId"`
 	Type      string `json:"type"`
 	Payload   string `json:"payload"`
+	Priority  string `json:"priority"`
 	CreatedAt int64  `json:"createdAt"`
 	Read      bool   `json:"read"`
 }
@@ -39,6 +47,13 @@ func (s *NotificationService) Send(ctx context.Context, notif Notification) erro
 	if notif.Type == "" {
 		return fmt.Errorf("type requis")
 	}
+	switch notif.Priority {
+	case "":
+		notif.Priority = PriorityNormal
+	case PriorityNormal, PriorityHigh:
+	default:
+		return fmt.Errorf("priority invalide: %s", notif.Priority)
+	}
 
 	notif.ID = fmt.Sprintf("%d", time.Now().UnixNano())
 	notif.CreatedAt = time.Now().Unix()
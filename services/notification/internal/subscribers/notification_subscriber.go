@@ -12,9 +12,10 @@ import (
 const respondErrorLogFormat = "nats respond error: %v"
 
 type SendRequest struct {
-	UserID  string `json:"userId"`
-	Type    string `json:"type"`
-	Payload string `json:"payload"`
+	UserID   string `json:"userId"`
+	Type     string `json:"type"`
+	Payload  string `json:"payload"`
+	Priority string `json:"priority,omitempty"` // normal (défaut) | high
 }
 
 type GetRequest struct {
@@ -26,7 +27,7 @@ type ErrorResponse struct {
 }
 
 func StartNotificationSubscribers(nc *nats.Conn, svc *service.NotificationService) error {
-	// notification.send — envoyer une notification à un user
+	// notification.send — envoyer une notification à un user (request/reply ou publish simple)
 	if _, err := nc.QueueSubscribe("notification.send", "notification", func(msg *nats.Msg) {
 		handleSend(msg, svc)
 	}); err != nil {
@@ -65,9 +66,10 @@ func handleSend(msg *nats.Msg, svc *service.NotificationService) {
 	}
 
 	notif := service.Notification{
-		UserID:  req.UserID,
-		Type:    req.Type,
-		Payload: req.Payload,
+		UserID:   req.UserID,
+		Type:     req.Type,
+		Payload:  req.Payload,
+		Priority: req.Priority,
 	}
 
 	if err := svc.Send(context.Background(), notif); err != nil {
@@ -75,6 +77,10 @@ func handleSend(msg *nats.Msg, svc *service.NotificationService) {
 		return
 	}
 
+	// Publication sans reply (ex. mentions émises par le message-service) : rien à répondre.
+	if msg.Reply == "" {
+		return
+	}
 	payload, _ := json.Marshal(map[string]string{"status": "sent"})
 	if err := msg.Respond(payload); err != nil {
 		log.Printf(respondErrorLogFormat, err)
@@ -151,6 +157,10 @@ func handleMessageSent(msg *nats.Msg, svc *service.NotificationService) {
 }
 
 func respondError(msg *nats.Msg, errMsg string) {
+	if msg.Reply == "" {
+		log.Printf("notification error: %s", errMsg)
+		return
+	}
 	payload, _ := json.Marshal(ErrorResponse{Error: errMsg})
 	if err := msg.Respond(payload); err != nil {
 		log.Printf(respondErrorLogFormat, err)
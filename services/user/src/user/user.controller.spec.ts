@@ -9,6 +9,8 @@ describe('UserController', () => {
     findById: jest.fn(),
     update: jest.fn(),
     search: jest.fn(),
+    findByUsernames: jest.fn(),
+    referencedMedia: jest.fn(),
     setStatus: jest.fn(),
     getStatus: jest.fn(),
   };
@@ -59,6 +61,42 @@ describe('UserController', () => {
     });
   });
 
+  // ── findByUsernames ───────────────────────────────────────────────────────
+
+  describe('findByUsernames', () => {
+    it('délègue à userService.findByUsernames et retourne le résultat', async () => {
+      const users = [{ id: 'user-uuid-1', username: 'testuser' }];
+      mockUserService.findByUsernames.mockResolvedValue(users);
+
+      const result = await controller.findByUsernames({
+        usernames: ['testuser'],
+      });
+
+      expect(mockUserService.findByUsernames).toHaveBeenCalledWith([
+        'testuser',
+      ]);
+      expect(result).toBe(users);
+    });
+  });
+
+  // ── referencedMedia ───────────────────────────────────────────────────────
+
+  describe('referencedMedia', () => {
+    it('délègue à userService.referencedMedia et retourne le résultat', async () => {
+      const referenced = { referenced: ['media/1_avatar.png'] };
+      mockUserService.referencedMedia.mockResolvedValue(referenced);
+
+      const result = await controller.referencedMedia({
+        media_ids: ['media/1_avatar.png'],
+      });
+
+      expect(mockUserService.referencedMedia).toHaveBeenCalledWith([
+        'media/1_avatar.png',
+      ]);
+      expect(result).toBe(referenced);
+    });
+  });
+
   // ── setStatus ─────────────────────────────────────────────────────────────
 
   describe('setStatus', () => {
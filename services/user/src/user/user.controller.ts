@@ -24,6 +24,16 @@ export class UserController {
     return this.userService.search(data.query);
   }
 
+  @MessagePattern('user.by_usernames')
+  findByUsernames(data: { usernames: string[] }) {
+    return this.userService.findByUsernames(data.usernames);
+  }
+
+  @MessagePattern('user.media_references')
+  referencedMedia(data: { media_ids: string[] }) {
+    return this.userService.referencedMedia(data.media_ids);
+  }
+
   @MessagePattern('user.status')
   setStatus(data: { userId: string; status: 'online' | 'offline' }) {
     return this.userService.setStatus(data.userId, data.status);
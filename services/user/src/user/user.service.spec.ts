@@ -1,6 +1,7 @@
 import { Test, TestingModule } from '@nestjs/testing';
 import { getRepositoryToken } from '@nestjs/typeorm';
 import { NotFoundException, ForbiddenException } from '@nestjs/common';
+import { In } from 'typeorm';
 import { UserService } from './user.service.js';
 import { User } from '../user.entity.js';
 import { REDIS_CLIENT } from './redis.constants.js';
@@ -10,7 +11,9 @@ describe('UserService', () => {
 
   const mockUserRepo = {
     findOne: jest.fn(),
+    find: jest.fn(),
     save: jest.fn(),
+    query: jest.fn(),
   };
 
   const mockRedis = {
@@ -133,6 +136,67 @@ describe('UserService', () => {
     });
   });
 
+  // ── findByUsernames ───────────────────────────────────────────────────────
+
+  describe('findByUsernames', () => {
+    it('should look up exact usernames in a single query', async () => {
+      mockUserRepo.find.mockResolvedValue([mockUser]);
+
+      const result = await service.findByUsernames([
+        'TestUser',
+        'testuser',
+        'other_user',
+      ]);
+
+      expect(mockUserRepo.find).toHaveBeenCalledTimes(1);
+      expect(mockUserRepo.find).toHaveBeenCalledWith({
+        where: { username: In(['testuser', 'other_user']) },
+      });
+      expect(result).toEqual([
+        {
+          id: 'user-uuid-1',
+          username: 'testuser',
+          display_name: 'Test User',
+          avatar_url: null,
+        },
+      ]);
+    });
+
+    it('should not query when no username is given', async () => {
+      const result = await service.findByUsernames([]);
+
+      expect(result).toEqual([]);
+      expect(mockUserRepo.find).not.toHaveBeenCalled();
+    });
+  });
+
+  // ── referencedMedia ───────────────────────────────────────────────────────
+
+  describe('referencedMedia', () => {
+    it('should return the media IDs used as avatars in a single query', async () => {
+      mockUserRepo.query.mockResolvedValue([{ ref: 'media/1_avatar.png' }]);
+
+      const result = await service.referencedMedia([
+        'media/1_avatar.png',
+        'media/1_avatar.png',
+        'media/2_photo.png',
+      ]);
+
+      expect(mockUserRepo.query).toHaveBeenCalledTimes(1);
+      expect(mockUserRepo.query).toHaveBeenCalledWith(expect.any(String), [
+        ['media/1_avatar.png', 'media/2_photo.png'],
+      ]);
+      expect(result).toEqual({ referenced: ['media/1_avatar.png'] });
+    });
+
+    it('should not query when no media ID is given', async () => {
+      const result = await service.referencedMedia([]);
+
+      expect(result).toEqual({ referenced: [] });
+      expect(mockUserRepo.query).not.toHaveBeenCalled();
+    });
+  });
+
   // ── setStatus ─────────────────────────────────────────────────────────────
 
   describe('setStatus', () => {
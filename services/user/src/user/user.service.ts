@@ -5,13 +5,18 @@ import {
   Inject,
 } from '@nestjs/common';
 import { InjectRepository } from '@nestjs/typeorm';
-import { Repository } from 'typeorm';
+import { In, Repository } from 'typeorm';
 import type { Redis } from 'ioredis';
 import { User } from '../user.entity.js';
 import { UpdateUserDto } from './dto/update-user.dto.js';
 import { REDIS_CLIENT } from './redis.constants.js';
 
 const STATUS_TTL = 5 * 60;
+// Même borne que les mentions par message côté message-service.
+const MAX_USERNAMES_LOOKUP = 20;
+// Media ID du media-service (media/<nanos>_<nom>), seul ou en fin d'URL : même expression que
+// models.AttachmentMediaID côté message-service.
+const MEDIA_ID_PATTERN = 'media/[0-9]+_.*$';
 
 @Injectable()
 export class UserService {
@@ -87,6 +92,53 @@ export class UserService {
     }));
   }
 
+  // Correspondance exacte (les usernames sont en minuscules), sans joker ni limite de pertinence.
+  async findByUsernames(usernames: string[]) {
+    const names = [
+      ...new Set(
+        (Array.isArray(usernames) ? usernames : [])
+          .filter((name) => typeof name === 'string')
+          .map((name) => name.toLowerCase()),
+      ),
+    ].slice(0, MAX_USERNAMES_LOOKUP);
+    if (names.length === 0) {
+      return [];
+    }
+
+    const users = await this.userRepo.find({
+      where: { username: In(names) },
+    });
+
+    return users.map((u) => ({
+      id: u.id,
+      username: u.username,
+      display_name: u.display_name,
+      avatar_url: u.avatar_url,
+    }));
+  }
+
+  // Médias encore utilisés comme avatar, pour le ramasse-miettes du media-service.
+  async referencedMedia(mediaIds: string[]) {
+    const ids = [
+      ...new Set(
+        (Array.isArray(mediaIds) ? mediaIds : []).filter(
+          (id) => typeof id === 'string',
+        ),
+      ),
+    ];
+    if (ids.length === 0) {
+      return { referenced: [] };
+    }
+
+    const rows: { ref: string }[] = await this.userRepo.query(
+      `SELECT DISTINCT substring(avatar_url FROM '${MEDIA_ID_PATTERN}') AS ref
+       FROM users
+       WHERE substring(avatar_url FROM '${MEDIA_ID_PATTERN}') = ANY($1)`,
+      [ids],
+    );
+    return { referenced: rows.map((row) => row.ref) };
+  }
+
   async setStatus(userId: string, status: 'online' | 'offline') {
     const key = `user:status:${userId}`;
     if (status === 'offline') {